@@ -0,0 +1,99 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+)
+
+// MigrateTo copies the contents of the configured
+// database into the given target database.
+var MigrateTo action.GTSAction = func(ctx context.Context) error {
+	targetType := strings.ToLower(config.GetAdminDbMigrateTargetType())
+	targetAddress := config.GetAdminDbMigrateTargetAddress()
+
+	switch {
+	case targetType != "postgres" && targetType != "sqlite":
+		return fmt.Errorf("target database type %s not supported", targetType)
+	case targetAddress == "":
+		return errors.New("no target database address set")
+	case targetType == strings.ToLower(config.GetDbType()) &&
+		(targetAddress == config.GetDbAddress() || targetAddress == config.GetDbPostgresConnectionString()):
+		return errors.New("target database is the same as the current database")
+	}
+
+	var sourceState state.State
+
+	source, err := bundb.NewBunDBService(ctx, &sourceState)
+	if err != nil {
+		return fmt.Errorf("error creating source dbservice: %s", err)
+	}
+	defer source.Close()
+
+	// Open the target database using the
+	// global config, swapping in target
+	// connection details just for this.
+	var (
+		dbType     = config.GetDbType()
+		dbAddress  = config.GetDbAddress()
+		dbPGString = config.GetDbPostgresConnectionString()
+	)
+
+	config.Config(func(cfg *config.Configuration) {
+		cfg.DbType = targetType
+		if targetType == "sqlite" {
+			cfg.DbAddress = targetAddress
+		} else {
+			cfg.DbPostgresConnectionString = targetAddress
+		}
+	})
+
+	// This runs migrations on the target,
+	// creating the schema if necessary.
+	var targetState state.State
+	target, err := bundb.NewBunDBService(ctx, &targetState)
+
+	config.Config(func(cfg *config.Configuration) {
+		cfg.DbType = dbType
+		cfg.DbAddress = dbAddress
+		cfg.DbPostgresConnectionString = dbPGString
+	})
+
+	if err != nil {
+		return fmt.Errorf("error creating target dbservice: %s", err)
+	}
+	defer target.Close()
+
+	migrator := trans.NewMigrator(source, target, config.GetAdminDbMigrateBatchSize())
+	if err := migrator.Migrate(ctx); err != nil {
+		return err
+	}
+
+	log.Infof(ctx, "migration to %s database complete", targetType)
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -41,14 +42,35 @@ var Export action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	exporter := trans.NewExporter(dbConn)
-
 	path := config.GetAdminTransPath()
 	if path == "" {
 		return errors.New("no path set")
 	}
 
-	if err := exporter.ExportMinimal(ctx, path); err != nil {
+	if !config.GetAdminTransFull() {
+		if config.GetAdminTransSince() != "" || config.GetAdminTransSkipMedia() {
+			return errors.New("since and skip-media can only be used with full")
+		}
+
+		exporter := trans.NewExporter(dbConn, nil)
+		if err := exporter.ExportMinimal(ctx, path); err != nil {
+			return err
+		}
+
+		return dbConn.Close()
+	}
+
+	var storage *gtsstorage.Driver
+	if !config.GetAdminTransSkipMedia() {
+		//nolint:contextcheck
+		storage, err = gtsstorage.AutoConfig()
+		if err != nil {
+			return fmt.Errorf("error creating storage backend: %w", err)
+		}
+	}
+
+	exporter := trans.NewExporter(dbConn, storage)
+	if err := exporter.ExportFull(ctx, path, config.GetAdminTransSince()); err != nil {
 		return err
 	}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/trans"
 )
 
@@ -41,7 +42,16 @@ var Import action.GTSAction = func(ctx context.Context) error {
 	// Set the state DB connection
 	state.DB = dbConn
 
-	importer := trans.NewImporter(dbConn)
+	// Full exports may contain media and
+	// emoji files which go into storage.
+	//
+	//nolint:contextcheck
+	storage, err := gtsstorage.AutoConfig()
+	if err != nil {
+		return fmt.Errorf("error creating storage backend: %w", err)
+	}
+
+	importer := trans.NewImporter(dbConn, storage)
 
 	path := config.GetAdminTransPath()
 	if path == "" {
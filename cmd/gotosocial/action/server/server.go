@@ -64,8 +64,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	gtsstorage "github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Start creates and starts a gotosocial server
@@ -239,6 +241,9 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
+	// Create a Web Push notification sender.
+	webPushSender := webpush.NewSender(client, state)
+
 	// Initialize both home / list timelines.
 	state.Timelines.Home = timeline.NewManager(
 		tlprocessor.HomeTimelineGrab(state),
@@ -297,12 +302,20 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		emailSender,
 		visFilter,
 		intFilter,
+		webPushSender,
 	)
 
 	// Initialize the specialized workers pools.
 	state.Workers.Client.Init(messages.ClientMsgIndices())
 	state.Workers.Federator.Init(messages.FederatorMsgIndices())
 	state.Workers.Delivery.Init(client)
+	if config.GetAdvancedDeliveryJournal() {
+		// Set up optional delivery journal, loading stored domain states.
+		state.Workers.Delivery.Journal, err = delivery.NewJournal(ctx, state.DB)
+		if err != nil {
+			return fmt.Errorf("error initializing delivery journal: %w", err)
+		}
+	}
 	state.Workers.Client.Process = process.Workers().ProcessFromClientAPI
 	state.Workers.Federator.Process = process.Workers().ProcessFromFediAPI
 
@@ -314,6 +327,37 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		return fmt.Errorf("error scheduling poll expiries: %w", err)
 	}
 
+	// Requeue any media transcodes interrupted by shutdown.
+	if err := mediaManager.RequeueTranscodes(ctx); err != nil {
+		return fmt.Errorf("error requeueing media transcodes: %w", err)
+	}
+
+	// Schedule publishing of all pending scheduled statuses.
+	if err := process.Status().ScheduledStatusesScheduleAll(ctx); err != nil {
+		return fmt.Errorf("error scheduling statuses: %w", err)
+	}
+
+	// Schedule fetching + processing of domain permission subscriptions.
+	if err := process.Admin().ScheduleDomainPermissionSubscriptions(); err != nil {
+		return fmt.Errorf("error scheduling domain permission subscriptions: %w", err)
+	}
+
+	// Schedule requeueing of parked deliveries (if journal enabled).
+	if err := process.Admin().ScheduleDeliveryUnparking(); err != nil {
+		return fmt.Errorf("error scheduling delivery unparking: %w", err)
+	}
+
+	// Schedule periodic computation of trends.
+	if err := process.Trends().ScheduleTrends(); err != nil {
+		return fmt.Errorf("error scheduling trends: %w", err)
+	}
+
+	// Schedule periodic computation of instance
+	// reputations (if reputation federation enabled).
+	if err := process.Admin().ScheduleReputations(); err != nil {
+		return fmt.Errorf("error scheduling reputations: %w", err)
+	}
+
 	// Initialize metrics.
 	if err := metrics.Initialize(state.DB); err != nil {
 		return fmt.Errorf("error initializing metrics: %w", err)
@@ -425,10 +469,17 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	// rate limiting
 	rlLimit := config.GetAdvancedRateLimitRequests()
 	rlExceptions := config.GetAdvancedRateLimitExceptions()
-	clLimit := middleware.RateLimit(rlLimit, rlExceptions)        // client api
-	s2sLimit := middleware.RateLimit(rlLimit, rlExceptions)       // server-to-server (AP)
-	fsMainLimit := middleware.RateLimit(rlLimit, rlExceptions)    // fileserver / web templates
-	fsEmojiLimit := middleware.RateLimit(rlLimit*2, rlExceptions) // fileserver (emojis only, use high limit)
+	rlPolicy := func(name string, limit int) gin.HandlerFunc {
+		return middleware.RateLimitPolicies(
+			&state.RateLimits,
+			rlExceptions,
+			middleware.RateLimitPolicy{Name: name, Limit: limit},
+		)
+	}
+	clLimit := rlPolicy(middleware.RateLimitPolicyClient, rlLimit)         // client api
+	s2sLimit := rlPolicy(middleware.RateLimitPolicyFederation, rlLimit)    // server-to-server (AP)
+	fsMainLimit := rlPolicy(middleware.RateLimitPolicyFileserver, rlLimit) // fileserver / web templates
+	fsEmojiLimit := rlPolicy(middleware.RateLimitPolicyEmojis, rlLimit*2)  // fileserver (emojis only, use high limit)
 
 	// throttling
 	cpuMultiplier := config.GetAdvancedThrottlingMultiplier()
@@ -20,6 +20,7 @@ package main
 import (
 	"github.com/spf13/cobra"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/account"
+	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/db"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/media/prune"
 	"github.com/superseriousbusiness/gotosocial/cmd/gotosocial/action/admin/trans"
@@ -146,6 +147,19 @@ func adminCommands() *cobra.Command {
 	config.AddAdminAccountPassword(adminAccountPasswordCmd)
 	adminAccountCmd.AddCommand(adminAccountPasswordCmd)
 
+	adminAccountDisable2FACmd := &cobra.Command{
+		Use:   "disable-2fa",
+		Short: "disable two-factor authentication for the given local account, eg., if they have lost their authenticator and recovery codes",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), account.Disable2FA)
+		},
+	}
+	config.AddAdminAccount(adminAccountDisable2FACmd)
+	adminAccountCmd.AddCommand(adminAccountDisable2FACmd)
+
 	adminCmd.AddCommand(adminAccountCmd)
 
 	/*
@@ -163,6 +177,7 @@ func adminCommands() *cobra.Command {
 		},
 	}
 	config.AddAdminTrans(adminExportCmd)
+	config.AddAdminTransExport(adminExportCmd)
 	adminCmd.AddCommand(adminExportCmd)
 
 	adminImportCmd := &cobra.Command{
@@ -178,6 +193,29 @@ func adminCommands() *cobra.Command {
 	config.AddAdminTrans(adminImportCmd)
 	adminCmd.AddCommand(adminImportCmd)
 
+	/*
+		ADMIN DB COMMANDS
+	*/
+
+	adminDbCmd := &cobra.Command{
+		Use:   "db",
+		Short: "admin commands related to the database",
+	}
+
+	adminDbMigrateToCmd := &cobra.Command{
+		Use:   "migrate-to",
+		Short: "copy the contents of the database into another database, eg., to move from sqlite to postgres",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return preRun(preRunArgs{cmd: cmd})
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return run(cmd.Context(), db.MigrateTo)
+		},
+	}
+	config.AddAdminDbMigrate(adminDbMigrateToCmd)
+	adminDbCmd.AddCommand(adminDbMigrateToCmd)
+	adminCmd.AddCommand(adminDbCmd)
+
 	/*
 		ADMIN MEDIA COMMANDS
 	*/
@@ -24,6 +24,7 @@ import (
 	"encoding/pem"
 	"errors"
 	"fmt"
+	"mime"
 	"net/url"
 	"strings"
 	"time"
@@ -1454,3 +1455,77 @@ func isFollowers(uris []*url.URL, followersURI string) bool {
 
 	return false
 }
+
+// ExtractQuoteURI extracts the URI of the status quoted
+// by the given statusable, if any. A FEP-e232 Link tag
+// takes precedence over the quote properties used by
+// other software. Will return nil if no valid URI found.
+func ExtractQuoteURI(i Statusable) *url.URL {
+	if tagsProp := i.GetActivityStreamsTag(); tagsProp != nil {
+		for iter := tagsProp.Begin(); iter != tagsProp.End(); iter = iter.Next() {
+			if !iter.IsActivityStreamsLink() {
+				continue
+			}
+
+			link := iter.GetActivityStreamsLink()
+			if link == nil || !isQuoteLinkMediaType(link.GetActivityStreamsMediaType()) {
+				continue
+			}
+
+			if hrefProp := link.GetActivityStreamsHref(); hrefProp != nil &&
+				hrefProp.IsIRI() && hrefProp.GetIRI() != nil {
+				// Found one we can use.
+				return hrefProp.GetIRI()
+			}
+		}
+	}
+
+	withUnknown, ok := i.(WithUnknownProperties)
+	if !ok {
+		return nil
+	}
+
+	unknown := withUnknown.GetUnknownProperties()
+	for _, prop := range []string{
+		quoteURIProp,
+		misskeyQuoteURIProp,
+		quoteURLProp,
+	} {
+		raw, _ := unknown[prop].(string)
+		if raw == "" {
+			continue
+		}
+
+		uri, err := url.Parse(raw)
+		if err != nil || uri.Scheme == "" || uri.Host == "" {
+			continue
+		}
+
+		// Found one we can use.
+		return uri
+	}
+
+	return nil
+}
+
+// isQuoteLinkMediaType returns whether the given media
+// type property is that of a link to an AS object.
+func isQuoteLinkMediaType(prop vocab.ActivityStreamsMediaTypeProperty) bool {
+	if prop == nil {
+		return false
+	}
+
+	mediaType, params, err := mime.ParseMediaType(prop.Get())
+	if err != nil {
+		return false
+	}
+
+	switch mediaType {
+	case "application/activity+json":
+		return true
+	case "application/ld+json":
+		return params["profile"] == "https://www.w3.org/ns/activitystreams"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+)
+
+type ExtractQuoteTestSuite struct {
+	APTestSuite
+}
+
+func (suite *ExtractQuoteTestSuite) resolve(rawNote string) ap.Statusable {
+	statusable, err := ap.ResolveStatusable(
+		context.Background(),
+		io.NopCloser(
+			bytes.NewBufferString(rawNote),
+		),
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return statusable
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteLinkTag() {
+	statusable := suite.resolve(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/2",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "look at this",
+  "quoteUri": "https://example.org/notes/ignored",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "application/ld+json; profile=\"https://www.w3.org/ns/activitystreams\"",
+      "href": "https://example.org/notes/1",
+      "name": "RE: https://example.org/notes/1"
+    }
+  ]
+}`)
+
+	// Link tag takes precedence.
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.Equal("https://example.org/notes/1", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteMisskey() {
+	statusable := suite.resolve(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://misskey.example.org/notes/2",
+  "type": "Note",
+  "attributedTo": "https://misskey.example.org/users/someone",
+  "content": "look at this",
+  "_misskey_quote": "https://misskey.example.org/notes/1",
+  "tag": [
+    {
+      "type": "Link",
+      "mediaType": "text/html",
+      "href": "https://misskey.example.org/notes/not-a-quote"
+    }
+  ]
+}`)
+
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	suite.Equal("https://misskey.example.org/notes/1", quoteURI.String())
+}
+
+func (suite *ExtractQuoteTestSuite) TestExtractQuoteNone() {
+	statusable := suite.resolve(`{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "id": "https://example.org/notes/2",
+  "type": "Note",
+  "attributedTo": "https://example.org/users/someone",
+  "content": "not a quote",
+  "quoteUrl": "not a url"
+}`)
+
+	suite.Nil(ap.ExtractQuoteURI(statusable))
+}
+
+func (suite *ExtractQuoteTestSuite) TestSetQuoteURI() {
+	quoteURI, _ := url.Parse("https://example.org/notes/1")
+
+	note := streams.NewActivityStreamsNote()
+	ap.SetQuoteURI(note, quoteURI)
+
+	// Should round-trip.
+	suite.Equal(quoteURI.String(), ap.ExtractQuoteURI(note).String())
+
+	data, err := ap.Serialize(note)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(quoteURI.String(), data["quoteUri"])
+	suite.Equal(quoteURI.String(), data["_misskey_quote"])
+	suite.Equal(map[string]interface{}{
+		"type":      "Link",
+		"mediaType": `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`,
+		"href":      quoteURI.String(),
+		"name":      "RE: " + quoteURI.String(),
+	}, data["tag"])
+}
+
+func TestExtractQuoteTestSuite(t *testing.T) {
+	suite.Run(t, &ExtractQuoteTestSuite{})
+}
@@ -173,6 +173,7 @@ type Accountable interface {
 	WithDiscoverable
 	WithURL
 	WithPublicKey
+	WithUnknownProperties
 	WithInbox
 	WithOutbox
 	WithFollowing
@@ -196,6 +197,7 @@ type Statusable interface {
 	WithName
 	WithInReplyTo
 	WithPublished
+	WithUpdated
 	WithURL
 	WithAttributedTo
 	WithTo
@@ -434,6 +436,7 @@ type WithValue interface {
 // WithImage represents an activity with ActivityStreamsImageProperty
 type WithImage interface {
 	GetActivityStreamsImage() vocab.ActivityStreamsImageProperty
+	SetActivityStreamsImage(vocab.ActivityStreamsImageProperty)
 }
 
 // WithSummary represents an activity with ActivityStreamsSummaryProperty
@@ -460,6 +463,12 @@ type WithPublicKey interface {
 	SetW3IDSecurityV1PublicKey(vocab.W3IDSecurityV1PublicKeyProperty)
 }
 
+// WithUnknownProperties represents an activity with properties not
+// (yet) supported by the activity library, eg., assertionMethod.
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithInbox represents an activity with ActivityStreamsInboxProperty
 type WithInbox interface {
 	GetActivityStreamsInbox() vocab.ActivityStreamsInboxProperty
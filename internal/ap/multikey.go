@@ -0,0 +1,258 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap
+
+import (
+	"crypto/ed25519"
+	"errors"
+	"math/big"
+	"net/url"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// Ed25519 keys are exposed on actors as Multikey entries in the
+// assertionMethod property, as described in FEP-521a, since the
+// publicKey property only supports a single (RSA) PEM-encoded key.
+//
+// See: https://codeberg.org/fediverse/fep/src/branch/main/fep/521a/fep-521a.md
+
+const (
+	assertionMethodProp = "assertionMethod"
+	multikeyType        = "Multikey"
+
+	// dataIntegrityContext is the JSON-LD context
+	// defining assertionMethod and Multikey terms.
+	dataIntegrityContext = "https://w3id.org/security/data-integrity/v1"
+)
+
+// ed25519MulticodecPrefix is the varint-encoded
+// multicodec prefix for an ed25519 public key.
+var ed25519MulticodecPrefix = []byte{0xed, 0x01}
+
+// ExtractEd25519Key extracts the first valid ed25519 Multikey from
+// the assertionMethod property of an actor with the given ID, along
+// with the ID of the key. Keys controlled by anyone other than the
+// actor are ignored. Returns an error if no valid key was found.
+//
+// As assertionMethod is not supported by the activity library, this
+// reads the property from the actor's raw (unknown) properties.
+func ExtractEd25519Key(i WithUnknownProperties, actorID *url.URL) (
+	ed25519.PublicKey, // pubkey
+	*url.URL, // pubkey ID
+	error,
+) {
+	var entries []interface{}
+	switch v := i.GetUnknownProperties()[assertionMethodProp].(type) {
+	case []interface{}:
+		entries = v
+	case map[string]interface{}:
+		entries = []interface{}{v}
+	default:
+		return nil, nil, gtserror.New("no assertionMethod entries")
+	}
+
+	for _, entry := range entries {
+		// Only embedded keys are
+		// supported, not references.
+		raw, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		if !isMultikey(raw["type"]) {
+			continue
+		}
+
+		if controller, _ := raw["controller"].(string); controller != actorID.String() {
+			continue
+		}
+
+		idStr, _ := raw["id"].(string)
+		id, err := url.Parse(idStr)
+		if err != nil || idStr == "" {
+			continue
+		}
+
+		multibase, _ := raw["publicKeyMultibase"].(string)
+		pubKey, err := decodeEd25519Multikey(multibase)
+		if err != nil {
+			continue
+		}
+
+		return pubKey, id, nil
+	}
+
+	return nil, nil, gtserror.New("couldn't find valid ed25519 multikey")
+}
+
+// SetEd25519Key sets the given ed25519 public key on the assertionMethod
+// property of an actor as a Multikey with keyID, controlled by actorID.
+func SetEd25519Key(i WithUnknownProperties, keyID *url.URL, actorID *url.URL, pubKey ed25519.PublicKey) {
+	i.GetUnknownProperties()[assertionMethodProp] = []interface{}{
+		map[string]interface{}{
+			"id":                 keyID.String(),
+			"type":               multikeyType,
+			"controller":         actorID.String(),
+			"publicKeyMultibase": encodeEd25519Multikey(pubKey),
+		},
+	}
+}
+
+// isMultikey returns whether the given
+// raw JSON type value includes Multikey.
+func isMultikey(t interface{}) bool {
+	switch t := t.(type) {
+	case string:
+		return t == multikeyType
+	case []interface{}:
+		return slices.Contains(t, interface{}(multikeyType))
+	default:
+		return false
+	}
+}
+
+// normalizeOutgoingMultikeyContext adds the context required for
+// assertionMethod + Multikey to the given serialized data, if it,
+// or an object embedded in it, has an assertionMethod property.
+// This is needed because the activity library doesn't know about
+// assertionMethod, and so won't add the context itself.
+func normalizeOutgoingMultikeyContext(data map[string]interface{}) {
+	hasAssertionMethod := func(m interface{}) bool {
+		raw, ok := m.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		_, ok = raw[assertionMethodProp]
+		return ok
+	}
+
+	found := hasAssertionMethod(data)
+	switch object := data["object"].(type) {
+	case map[string]interface{}:
+		found = found || hasAssertionMethod(object)
+	case []interface{}:
+		found = found || slices.ContainsFunc(object, hasAssertionMethod)
+	}
+
+	if !found {
+		return
+	}
+
+	switch ctx := data["@context"].(type) {
+	case string:
+		if ctx != dataIntegrityContext {
+			data["@context"] = []interface{}{ctx, dataIntegrityContext}
+		}
+	case []interface{}:
+		if !slices.Contains(ctx, interface{}(dataIntegrityContext)) {
+			data["@context"] = append(ctx, dataIntegrityContext)
+		}
+	case nil:
+		// No context to add to.
+	default:
+		data["@context"] = []interface{}{ctx, dataIntegrityContext}
+	}
+}
+
+// encodeEd25519Multikey encodes the given ed25519 public
+// key as a base58btc multibase-encoded multikey value.
+func encodeEd25519Multikey(pubKey ed25519.PublicKey) string {
+	b := make([]byte, 0, len(ed25519MulticodecPrefix)+len(pubKey))
+	b = append(b, ed25519MulticodecPrefix...)
+	b = append(b, pubKey...)
+	return "z" + base58Encode(b)
+}
+
+// decodeEd25519Multikey decodes the given base58btc
+// multibase-encoded multikey value as an ed25519 key.
+func decodeEd25519Multikey(s string) (ed25519.PublicKey, error) {
+	if len(s) == 0 || s[0] != 'z' {
+		return nil, errors.New("multikey not base58btc encoded")
+	}
+
+	b, err := base58Decode(s[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) != len(ed25519MulticodecPrefix)+ed25519.PublicKeySize ||
+		b[0] != ed25519MulticodecPrefix[0] ||
+		b[1] != ed25519MulticodecPrefix[1] {
+		return nil, errors.New("multikey not an ed25519 public key")
+	}
+
+	return ed25519.PublicKey(b[len(ed25519MulticodecPrefix):]), nil
+}
+
+// base58Alphabet is the bitcoin base58
+// alphabet, as used by base58btc multibase.
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+var bigRadix = big.NewInt(58)
+
+// base58Encode encodes the given bytes as base58btc.
+func base58Encode(b []byte) string {
+	x := new(big.Int).SetBytes(b)
+	mod := new(big.Int)
+
+	var out []byte
+	for x.Sign() > 0 {
+		x.DivMod(x, bigRadix, mod)
+		out = append(out, base58Alphabet[mod.Int64()])
+	}
+
+	// Leading zero bytes are
+	// encoded as leading ones.
+	for _, c := range b {
+		if c != 0 {
+			break
+		}
+		out = append(out, base58Alphabet[0])
+	}
+
+	slices.Reverse(out)
+	return string(out)
+}
+
+// base58Decode decodes the given base58btc string.
+func base58Decode(s string) ([]byte, error) {
+	x := new(big.Int)
+	for i := 0; i < len(s); i++ {
+		idx := -1
+		for j := 0; j < len(base58Alphabet); j++ {
+			if base58Alphabet[j] == s[i] {
+				idx = j
+				break
+			}
+		}
+		if idx < 0 {
+			return nil, errors.New("invalid base58 character")
+		}
+		x.Mul(x, bigRadix)
+		x.Add(x, big.NewInt(int64(idx)))
+	}
+
+	var zeros int
+	for zeros < len(s) && s[zeros] == base58Alphabet[0] {
+		zeros++
+	}
+
+	return append(make([]byte, zeros), x.Bytes()...), nil
+}
@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap_test
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+const multikeyActor = `{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1"
+  ],
+  "id": "https://server.example/users/alice",
+  "preferredUsername": "alice",
+  "assertionMethod": [
+    {
+      "id": "https://server.example/users/bob#ed25519-key",
+      "type": "Multikey",
+      "controller": "https://server.example/users/bob",
+      "publicKeyMultibase": "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+    },
+    {
+      "id": "https://server.example/users/alice#ed25519-key",
+      "type": "Multikey",
+      "controller": "https://server.example/users/alice",
+      "publicKeyMultibase": "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2"
+    }
+  ],
+  "type": "Person"
+}`
+
+type MultikeyTestSuite struct {
+	APTestSuite
+}
+
+func (suite *MultikeyTestSuite) TestExtractSetEd25519Key() {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(multikeyActor), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accountable, ok := t.(ap.Accountable)
+	if !ok {
+		suite.FailNow("", "could not parse %T as Accountable", t)
+	}
+
+	// Key controlled by bob should be skipped.
+	actorID := testrig.URLMustParse("https://server.example/users/alice")
+	pubKey, pubKeyID, err := ap.ExtractEd25519Key(accountable, actorID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(pubKey, 32)
+	suite.Equal("https://server.example/users/alice#ed25519-key", pubKeyID.String())
+
+	// Should serialize the same
+	// multikey back out again,
+	// with the correct context.
+	person := streams.NewActivityStreamsPerson()
+	ap.SetJSONLDId(person, actorID)
+	ap.SetEd25519Key(person, pubKeyID, actorID, pubKey)
+
+	data, err := ap.Serialize(person)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := json.MarshalIndent(data, "", "  ")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(`{
+  "@context": [
+    "https://www.w3.org/ns/activitystreams",
+    "https://w3id.org/security/data-integrity/v1"
+  ],
+  "assertionMethod": [
+    {
+      "controller": "https://server.example/users/alice",
+      "id": "https://server.example/users/alice#ed25519-key",
+      "publicKeyMultibase": "z6MkrJVnaZkeFzdQyMZu1cgjg7k1pZZ6pvBQ7XJPt4swbTQ2",
+      "type": "Multikey"
+    }
+  ],
+  "id": "https://server.example/users/alice",
+  "type": "Person"
+}`, string(b))
+}
+
+func (suite *MultikeyTestSuite) TestExtractEd25519KeyNone() {
+	m := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(stubActor), &m); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	t, err := streams.ToType(context.Background(), m)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accountable, ok := t.(ap.Accountable)
+	if !ok {
+		suite.FailNow("", "could not parse %T as Accountable", t)
+	}
+
+	actorID := testrig.URLMustParse("https://gts.superseriousbusiness.org/users/dumpsterqueer")
+	_, _, err = ap.ExtractEd25519Key(accountable, actorID)
+	suite.EqualError(err, "ExtractEd25519Key: no assertionMethod entries")
+}
+
+func TestMultikeyTestSuite(t *testing.T) {
+	suite.Run(t, &MultikeyTestSuite{})
+}
@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ap
+
+import (
+	"net/url"
+
+	"github.com/superseriousbusiness/activity/streams"
+)
+
+// Quotes of other statuses are indicated on a status both by
+// a Link tag, as described in FEP-e232, and by the properties
+// used by Misskey and Fedibird / Akkoma, which are still more
+// widely supported than the FEP.
+//
+// See: https://codeberg.org/fediverse/fep/src/branch/main/fep/e232/fep-e232.md
+
+const (
+	quoteURIProp        = "quoteUri"       // Fedibird
+	quoteURLProp        = "quoteUrl"       // Akkoma / Pleroma
+	misskeyQuoteURIProp = "_misskey_quote" // Misskey and forks
+
+	// quoteLinkMediaType is the media type of a
+	// FEP-e232 Link tag pointing to an AS object.
+	quoteLinkMediaType = `application/ld+json; profile="https://www.w3.org/ns/activitystreams"`
+)
+
+// SetQuoteURI marks the given statusable as quoting the
+// status with the given URI, by appending a FEP-e232 Link
+// tag, and setting the quote properties of other software.
+func SetQuoteURI(i Statusable, quoteURI *url.URL) {
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(quoteLinkMediaType)
+
+	hrefProp := streams.NewActivityStreamsHrefProperty()
+	hrefProp.SetIRI(quoteURI)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString("RE: " + quoteURI.String())
+
+	link := streams.NewActivityStreamsLink()
+	link.SetActivityStreamsMediaType(mediaTypeProp)
+	link.SetActivityStreamsHref(hrefProp)
+	link.SetActivityStreamsName(nameProp)
+
+	tagsProp := i.GetActivityStreamsTag()
+	if tagsProp == nil {
+		tagsProp = streams.NewActivityStreamsTagProperty()
+		i.SetActivityStreamsTag(tagsProp)
+	}
+	tagsProp.AppendActivityStreamsLink(link)
+
+	if withUnknown, ok := i.(WithUnknownProperties); ok {
+		unknown := withUnknown.GetUnknownProperties()
+		if unknown == nil {
+			// Nowhere to
+			// set these.
+			return
+		}
+
+		unknown[quoteURIProp] = quoteURI.String()
+		unknown[misskeyQuoteURIProp] = quoteURI.String()
+	}
+}
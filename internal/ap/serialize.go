@@ -36,7 +36,7 @@ import (
 //
 //   - OrderedCollection:       'orderedItems' property will always be made into an array.
 //   - OrderedCollectionPage:   'orderedItems' property will always be made into an array.
-//   - Any Accountable type:    'attachment' property will always be made into an array.
+//   - Any Accountable type:    'attachment' property will always be made into an array; context added for 'assertionMethod'.
 //   - Any Statusable type:     'attachment' property will always be made into an array; 'content', 'contentMap', and 'interactionPolicy' will be normalized.
 //   - Any Activityable type:   any 'object's set on an activity will be custom serialized as above.
 func Serialize(t vocab.Type) (m map[string]interface{}, e error) {
@@ -127,6 +127,10 @@ func serializeAccountable(t vocab.Type, includeContext bool) (map[string]interfa
 	NormalizeOutgoingAttachmentProp(accountable, data)
 	NormalizeOutgoingAlsoKnownAsProp(accountable, data)
 
+	if includeContext {
+		normalizeOutgoingMultikeyContext(data)
+	}
+
 	return data, nil
 }
 
@@ -183,5 +187,9 @@ func serializeActivityable(t vocab.Type, includeContext bool) (map[string]interf
 		return nil, err
 	}
 
+	if includeContext {
+		normalizeOutgoingMultikeyContext(data)
+	}
+
 	return data, nil
 }
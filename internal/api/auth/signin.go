@@ -100,6 +100,29 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	user, err := m.db.GetUserByID(c.Request.Context(), userid)
+	if err != nil {
+		err := fmt.Errorf("error getting user %s: %w", userid, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if user.TwoFactorEnabled() {
+		// Password was correct, but user must still provide
+		// a second factor before we consider them signed in,
+		// so park their ID somewhere authorize won't find it.
+		s.Delete(sessionUserID)
+		s.Set(sessionTwoFactorUser, userid)
+		if err := s.Save(); err != nil {
+			err := fmt.Errorf("error saving user id onto session: %s", err)
+			apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+			return
+		}
+
+		c.Redirect(http.StatusFound, "/auth"+AuthTwoFactorPath)
+		return
+	}
+
 	s.Set(sessionUserID, userid)
 	if err := s.Save(); err != nil {
 		err := fmt.Errorf("error saving user id onto session: %s", err)
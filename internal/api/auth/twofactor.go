@@ -0,0 +1,130 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// twoFactor wraps a form-submitted TOTP or recovery code.
+type twoFactor struct {
+	Code string `form:"code"`
+}
+
+// TwoFactorGETHandler should be served at https://example.org/auth/2fa.
+// Users who have two-factor auth enabled land here after entering a correct
+// password, and are presented a page on which to enter a code from their
+// authenticator app (or a recovery code). The form will then POST to the
+// same path, which will be handled by TwoFactorPOSTHandler.
+func (m *Module) TwoFactorGETHandler(c *gin.Context) {
+	if _, err := apiutil.NegotiateAccept(c, apiutil.HTMLAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorUser).(string)
+	if !ok || userID == "" {
+		// User hasn't entered their password yet
+		// (or has since been signed out), so start
+		// them at the beginning of the sign in flow.
+		c.Redirect(http.StatusSeeOther, "/auth"+AuthSignInPath)
+		return
+	}
+
+	instance, errWithCode := m.processor.InstanceGetV1(c.Request.Context())
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	page := apiutil.WebPage{
+		Template: "sign-in-2fa.tmpl",
+		Instance: instance,
+	}
+
+	apiutil.TemplateWebPage(c, page)
+}
+
+// TwoFactorPOSTHandler should be served at https://example.org/auth/2fa.
+// It checks the submitted code for the user who entered their password
+// in the previous step and, if correct, redirects to the auth handler
+// served at /oauth/authorize, the same as SignInPOSTHandler would.
+func (m *Module) TwoFactorPOSTHandler(c *gin.Context) {
+	s := sessions.Default(c)
+
+	userID, ok := s.Get(sessionTwoFactorUser).(string)
+	if !ok || userID == "" {
+		m.clearSession(s)
+		err := fmt.Errorf("key %s was not found in session", sessionTwoFactorUser)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &twoFactor{}
+	if err := c.ShouldBind(form); err != nil {
+		m.clearSession(s)
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Code == "" {
+		// don't clear session here, so
+		// the user can just go back and try again
+		err := errors.New("two-factor authentication code was not provided")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	user, err := m.db.GetUserByID(c.Request.Context(), userID)
+	if err != nil {
+		m.clearSession(s)
+		err := fmt.Errorf("error getting user %s: %w", userID, err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorCheck(c.Request.Context(), user, form.Code); errWithCode != nil {
+		// don't clear session here, so the user can
+		// just press back and try again if they mistyped
+		// (incorrect attempts are limited per user)
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	// Second factor checks out,
+	// user is now fully signed in.
+	s.Delete(sessionTwoFactorUser)
+	s.Set(sessionUserID, userID)
+	if err := s.Save(); err != nil {
+		err := fmt.Errorf("error saving user id onto session: %s", err)
+		apiutil.ErrorHandler(c, gtserror.NewErrorInternalError(err, oauth.HelpfulAdvice), m.processor.InstanceGetV1)
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/oauth"+OauthAuthorizePath)
+}
@@ -0,0 +1,200 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+const sessionTwoFactorUser = "two_factor_userid"
+
+type AuthTwoFactorTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// enableTwoFactor turns on two-factor
+// auth for the given user with a new secret.
+func (suite *AuthTwoFactorTestSuite) enableTwoFactor(user *gtsmodel.User) string {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	user.TwoFactorSecret = secret
+	user.TwoFactorEnabledAt = time.Now()
+	if err := suite.db.UpdateUser(
+		context.Background(), user,
+		"two_factor_secret", "two_factor_enabled_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return secret
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInWithoutTwoFactor() {
+	user := suite.testUsers["local_account_1"]
+
+	form := url.Values{"username": {user.Email}, "password": {"password"}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthSignInPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	suite.authModule.SignInPOSTHandler(ctx)
+
+	// Should go straight on to authorize.
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, recorder.Header().Get("Location"))
+	suite.Equal(user.ID, sessions.Default(ctx).Get(sessionUserID))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestSignInWithTwoFactor() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	form := url.Values{"username": {user.Email}, "password": {"password"}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthSignInPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	suite.authModule.SignInPOSTHandler(ctx)
+
+	// Should be sent to enter a code,
+	// without being signed in yet.
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal("/auth"+auth.AuthTwoFactorPath, recorder.Header().Get("Location"))
+	suite.Nil(sessions.Default(ctx).Get(sessionUserID))
+	suite.Equal(user.ID, sessions.Default(ctx).Get(sessionTwoFactorUser))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorCorrectCode() {
+	user := suite.testUsers["local_account_1"]
+	secret := suite.enableTwoFactor(user)
+
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form := url.Values{"code": {code}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionTwoFactorUser, user.ID)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	// Should now be signed in.
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal("/oauth"+auth.OauthAuthorizePath, recorder.Header().Get("Location"))
+	suite.Equal(user.ID, s.Get(sessionUserID))
+	suite.Nil(s.Get(sessionTwoFactorUser))
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorIncorrectCode() {
+	user := suite.testUsers["local_account_1"]
+	suite.enableTwoFactor(user)
+
+	form := url.Values{"code": {"nonsense"}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionTwoFactorUser, user.ID)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	// Should not be signed in, but
+	// should be allowed to try again.
+	suite.Equal(http.StatusUnauthorized, recorder.Code)
+	suite.Nil(s.Get(sessionUserID))
+	suite.Equal(user.ID, s.Get(sessionTwoFactorUser))
+
+	// Attempt should be counted against the user.
+	dbUser, err := suite.db.GetUserByID(context.Background(), user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, dbUser.TwoFactorFailures)
+}
+
+func (suite *AuthTwoFactorTestSuite) TestTwoFactorTooManyAttempts() {
+	user := suite.testUsers["local_account_1"]
+	secret := suite.enableTwoFactor(user)
+
+	// Use up all but one attempt.
+	user.TwoFactorFailures = 4
+	if err := suite.db.UpdateUser(context.Background(), user, "two_factor_failures"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form := url.Values{"code": {"nonsense"}}
+	ctx, recorder := suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+
+	s := sessions.Default(ctx)
+	s.Set(sessionTwoFactorUser, user.ID)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	// Should now be locked out.
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Nil(s.Get(sessionUserID))
+
+	// Signing in with the password again
+	// shouldn't lift the lock, even when
+	// then entering the correct code.
+	form = url.Values{"username": {user.Email}, "password": {"password"}}
+	ctx, _ = suite.newContext(http.MethodPost, "auth"+auth.AuthSignInPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+	suite.authModule.SignInPOSTHandler(ctx)
+	suite.Equal(user.ID, sessions.Default(ctx).Get(sessionTwoFactorUser))
+
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form = url.Values{"code": {code}}
+	ctx, recorder = suite.newContext(http.MethodPost, "auth"+auth.AuthTwoFactorPath, []byte(form.Encode()), "application/x-www-form-urlencoded")
+
+	s = sessions.Default(ctx)
+	s.Set(sessionTwoFactorUser, user.ID)
+	if err := s.Save(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.authModule.TwoFactorPOSTHandler(ctx)
+
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Nil(s.Get(sessionUserID))
+}
+
+func TestAuthTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(AuthTwoFactorTestSuite))
+}
@@ -18,6 +18,7 @@
 package api
 
 import (
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -35,6 +36,7 @@ import (
 	filtersV2 "github.com/superseriousbusiness/gotosocial/internal/api/client/filters/v2"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followedtags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/followrequests"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/groups"
 	importdata "github.com/superseriousbusiness/gotosocial/internal/api/client/import"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/instance"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/interactionpolicies"
@@ -46,15 +48,21 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notifications"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/polls"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/preferences"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/push"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/reports"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/scheduledstatuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timelines"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tokens"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -63,6 +71,7 @@ import (
 type Client struct {
 	processor *processing.Processor
 	db        db.DB
+	state     *state.State
 
 	accounts            *accounts.Module            // api/v1/accounts, api/v1/profile
 	admin               *admin.Module               // api/v1/admin
@@ -78,6 +87,7 @@ type Client struct {
 	filtersV2           *filtersV2.Module           // api/v2/filters
 	followRequests      *followrequests.Module      // api/v1/follow_requests
 	followedTags        *followedtags.Module        // api/v1/followed_tags
+	groups              *groups.Module              // api/v1/groups
 	importData          *importdata.Module          // api/v1/import
 	instance            *instance.Module            // api/v1/instance
 	interactionPolicies *interactionpolicies.Module // api/v1/interaction_policies
@@ -89,12 +99,16 @@ type Client struct {
 	notifications       *notifications.Module       // api/v1/notifications
 	polls               *polls.Module               // api/v1/polls
 	preferences         *preferences.Module         // api/v1/preferences
+	push                *push.Module                // api/v1/push
 	reports             *reports.Module             // api/v1/reports
+	scheduledStatuses   *scheduledstatuses.Module   // api/v1/scheduled_statuses
 	search              *search.Module              // api/v1/search, api/v2/search
 	statuses            *statuses.Module            // api/v1/statuses
 	streaming           *streaming.Module           // api/v1/streaming
 	tags                *tags.Module                // api/v1/tags
 	timelines           *timelines.Module           // api/v1/timelines
+	tokens              *tokens.Module              // api/v1/tokens
+	trends              *trends.Module              // api/v1/trends
 	user                *user.Module                // api/v1/user
 }
 
@@ -106,6 +120,13 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	apiGroup.Use(m...)
 	apiGroup.Use(
 		middleware.TokenCheck(c.db, c.processor.OAuthValidateBearerToken),
+		// Rate limit policies keyed on account where
+		// possible, so must come after token check.
+		middleware.RateLimitPolicies(
+			&c.state.RateLimits,
+			config.GetAdvancedRateLimitExceptions(),
+			rateLimitPolicies()...,
+		),
 		middleware.CacheControl(middleware.CacheControlConfig{
 			// Never cache client api responses.
 			Directives: []string{"no-store"},
@@ -129,6 +150,7 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.filtersV2.Route(h)
 	c.followRequests.Route(h)
 	c.followedTags.Route(h)
+	c.groups.Route(h)
 	c.importData.Route(h)
 	c.instance.Route(h)
 	c.interactionPolicies.Route(h)
@@ -140,12 +162,16 @@ func (c *Client) Route(r *router.Router, m ...gin.HandlerFunc) {
 	c.notifications.Route(h)
 	c.polls.Route(h)
 	c.preferences.Route(h)
+	c.push.Route(h)
 	c.reports.Route(h)
+	c.scheduledStatuses.Route(h)
 	c.search.Route(h)
 	c.statuses.Route(h)
 	c.streaming.Route(h)
 	c.tags.Route(h)
 	c.timelines.Route(h)
+	c.tokens.Route(h)
+	c.trends.Route(h)
 	c.user.Route(h)
 }
 
@@ -153,6 +179,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 	return &Client{
 		processor: p,
 		db:        state.DB,
+		state:     state,
 
 		accounts:            accounts.New(p),
 		admin:               admin.New(state, p),
@@ -168,6 +195,7 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		filtersV2:           filtersV2.New(p),
 		followRequests:      followrequests.New(p),
 		followedTags:        followedtags.New(p),
+		groups:              groups.New(p),
 		importData:          importdata.New(p),
 		instance:            instance.New(p),
 		interactionPolicies: interactionpolicies.New(p),
@@ -179,12 +207,71 @@ func NewClient(state *state.State, p *processing.Processor) *Client {
 		notifications:       notifications.New(p),
 		polls:               polls.New(p),
 		preferences:         preferences.New(p),
+		push:                push.New(p),
 		reports:             reports.New(p),
+		scheduledStatuses:   scheduledstatuses.New(p),
 		search:              search.New(p),
 		statuses:            statuses.New(p),
 		streaming:           streaming.New(p, time.Second*30, 4096),
 		tags:                tags.New(p),
 		timelines:           timelines.New(p),
+		tokens:              tokens.New(p),
+		trends:              trends.New(p),
 		user:                user.New(p),
 	}
 }
+
+// rateLimitPolicies returns the rate limit policies
+// to apply to client API requests, on top of the
+// per-IP limit applied to all of the client API.
+func rateLimitPolicies() []middleware.RateLimitPolicy {
+	return []middleware.RateLimitPolicy{
+		{
+			// All requests by an authorized
+			// account or app, whatever the IP.
+			Name:  middleware.RateLimitPolicyAccount,
+			Limit: config.GetAdvancedRateLimitAccountRequests(),
+			Match: func(c *gin.Context) bool {
+				_, ok := c.Get(oauth.SessionAuthorizedToken)
+				return ok
+			},
+		},
+		{
+			// Anything that's not a read.
+			Name:  middleware.RateLimitPolicyWrite,
+			Limit: config.GetAdvancedRateLimitWriteRequests(),
+			Match: func(c *gin.Context) bool {
+				switch c.Request.Method {
+				case http.MethodGet, http.MethodHead, http.MethodOptions:
+					return false
+				default:
+					return true
+				}
+			},
+		},
+		{
+			Name:  middleware.RateLimitPolicySearch,
+			Limit: config.GetAdvancedRateLimitSearchRequests(),
+			Match: matchRoute(http.MethodGet, search.BasePath),
+		},
+		{
+			Name:  middleware.RateLimitPolicyMedia,
+			Limit: config.GetAdvancedRateLimitMediaRequests(),
+			Match: matchRoute(http.MethodPost, media.BasePath),
+		},
+		{
+			Name:  middleware.RateLimitPolicyStatuses,
+			Limit: config.GetAdvancedRateLimitStatusRequests(),
+			Match: matchRoute(http.MethodPost, statuses.BasePath),
+		},
+	}
+}
+
+// matchRoute returns a function matching requests
+// with given method to the given client API route.
+func matchRoute(method string, path string) func(*gin.Context) bool {
+	path = "/api" + path
+	return func(c *gin.Context) bool {
+		return c.Request.Method == method && c.FullPath() == path
+	}
+}
@@ -51,8 +51,7 @@ import (
 //		type: integer
 //		description: >-
 //			Page number of results to return (starts at 0).
-//			This parameter is currently not used, offsets
-//			over 0 will always return 0 results.
+//			Searches for an exact account only ever have one page.
 //		default: 0
 //		maximum: 10
 //		minimum: 0
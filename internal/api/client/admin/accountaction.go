@@ -53,7 +53,7 @@ import (
 //	-
 //		name: type
 //		in: formData
-//		description: Type of action to be taken, currently only supports `suspend`.
+//		description: Type of action to be taken. One of `suspend`, `silence`, `unsilence`, `sensitive`, `unsensitive`.
 //		type: string
 //		required: true
 //	-
@@ -80,6 +80,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -89,6 +90,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "<p>i post about things that concern me</p>",
       "url": "http://localhost:8080/@1happyturtle",
@@ -138,6 +140,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -147,6 +150,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -192,6 +196,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01AY6P665V14JJR0AFVRT7311Y",
@@ -201,6 +206,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@localhost:8080",
@@ -237,6 +243,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH1H7YV1Z7D2C8K2730QBF",
@@ -246,6 +253,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "<p>hey yo this is my profile!</p>",
       "url": "http://localhost:8080/@the_mighty_zork",
@@ -286,6 +294,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH0BBE4FHXPH513MBVFHB0",
@@ -295,6 +304,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
       "url": "http://localhost:8080/@weed_lord420",
@@ -332,6 +342,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
@@ -341,6 +352,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "i'm a real son of a gun",
       "url": "http://example.org/@Some_User",
@@ -377,6 +389,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -386,6 +399,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -422,6 +436,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "062G5WYKY35KKD12EMSM3F8PJ8",
@@ -431,6 +446,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": true,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "if i die blame charles don't let that fuck become king",
       "url": "http://thequeenisstillalive.technology/@her_fuckin_maj",
@@ -468,6 +484,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "07GZRBAEMBNKGZ8Z9VSKSXKR98",
@@ -477,6 +494,7 @@ func (suite *AccountsGetTestSuite) TestAccountsGetFromTop() {
       "locked": false,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2020-08-10T12:13:28.000Z",
       "note": "",
       "url": "https://xn--xample-ova.org/users/@%C3%BCser",
@@ -552,6 +570,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01AY6P665V14JJR0AFVRT7311Y",
@@ -561,6 +580,7 @@ func (suite *AccountsGetTestSuite) TestAccountsMinID() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2020-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@localhost:8080",
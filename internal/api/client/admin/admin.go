@@ -28,42 +28,74 @@ import (
 )
 
 const (
-	BasePath                = "/v1/admin"
-	EmojiPath               = BasePath + "/custom_emojis"
-	EmojiPathWithID         = EmojiPath + "/:" + apiutil.IDKey
-	EmojiCategoriesPath     = EmojiPath + "/categories"
-	DomainBlocksPath        = BasePath + "/domain_blocks"
-	DomainBlocksPathWithID  = DomainBlocksPath + "/:" + apiutil.IDKey
-	DomainAllowsPath        = BasePath + "/domain_allows"
-	DomainAllowsPathWithID  = DomainAllowsPath + "/:" + apiutil.IDKey
-	DomainKeysExpirePath    = BasePath + "/domain_keys_expire"
-	HeaderAllowsPath        = BasePath + "/header_allows"
-	HeaderAllowsPathWithID  = HeaderAllowsPath + "/:" + apiutil.IDKey
-	HeaderBlocksPath        = BasePath + "/header_blocks"
-	HeaderBlocksPathWithID  = HeaderBlocksPath + "/:" + apiutil.IDKey
-	AccountsV1Path          = BasePath + "/accounts"
-	AccountsV2Path          = "/v2/admin/accounts"
-	AccountsPathWithID      = AccountsV1Path + "/:" + apiutil.IDKey
-	AccountsActionPath      = AccountsPathWithID + "/action"
-	AccountsApprovePath     = AccountsPathWithID + "/approve"
-	AccountsRejectPath      = AccountsPathWithID + "/reject"
-	MediaCleanupPath        = BasePath + "/media_cleanup"
-	MediaRefetchPath        = BasePath + "/media_refetch"
-	ReportsPath             = BasePath + "/reports"
-	ReportsPathWithID       = ReportsPath + "/:" + apiutil.IDKey
-	ReportsResolvePath      = ReportsPathWithID + "/resolve"
-	EmailPath               = BasePath + "/email"
-	EmailTestPath           = EmailPath + "/test"
-	InstanceRulesPath       = BasePath + "/instance/rules"
-	InstanceRulesPathWithID = InstanceRulesPath + "/:" + apiutil.IDKey
-	DebugPath               = BasePath + "/debug"
-	DebugAPUrlPath          = DebugPath + "/apurl"
-	DebugClearCachesPath    = DebugPath + "/caches/clear"
+	BasePath                        = "/v1/admin"
+	EmojiPath                       = BasePath + "/custom_emojis"
+	EmojiPathWithID                 = EmojiPath + "/:" + apiutil.IDKey
+	EmojiCategoriesPath             = EmojiPath + "/categories"
+	DomainBlocksPath                = BasePath + "/domain_blocks"
+	DomainBlocksPathWithID          = DomainBlocksPath + "/:" + apiutil.IDKey
+	DomainAllowsPath                = BasePath + "/domain_allows"
+	DomainAllowsPathWithID          = DomainAllowsPath + "/:" + apiutil.IDKey
+	DomainKeysExpirePath            = BasePath + "/domain_keys_expire"
+	DomainPermSubsPath              = BasePath + "/domain_permission_subscriptions"
+	DomainPermSubPathWithID         = DomainPermSubsPath + "/:" + apiutil.IDKey
+	DomainPermSubRemovePath         = DomainPermSubPathWithID + "/remove"
+	DomainPermDraftsPath            = BasePath + "/domain_permission_drafts"
+	DomainPermDraftWithID           = DomainPermDraftsPath + "/:" + apiutil.IDKey
+	DomainPermDraftAccept           = DomainPermDraftWithID + "/accept"
+	DomainPermDraftRemove           = DomainPermDraftWithID + "/remove"
+	HeaderAllowsPath                = BasePath + "/header_allows"
+	HeaderAllowsPathWithID          = HeaderAllowsPath + "/:" + apiutil.IDKey
+	HeaderBlocksPath                = BasePath + "/header_blocks"
+	HeaderBlocksPathWithID          = HeaderBlocksPath + "/:" + apiutil.IDKey
+	AccountsV1Path                  = BasePath + "/accounts"
+	AccountsV2Path                  = "/v2/admin/accounts"
+	AccountsPathWithID              = AccountsV1Path + "/:" + apiutil.IDKey
+	AccountsActionPath              = AccountsPathWithID + "/action"
+	AccountsApprovePath             = AccountsPathWithID + "/approve"
+	AccountsRejectPath              = AccountsPathWithID + "/reject"
+	AccountsUnsilencePath           = AccountsPathWithID + "/unsilence"
+	AccountsUnsensitivePath         = AccountsPathWithID + "/unsensitive"
+	AuditLogPath                    = BasePath + "/audit_log"
+	AuditLogPathWithID              = AuditLogPath + "/:" + apiutil.IDKey
+	InstanceReputationsPath         = BasePath + "/instance_reputations"
+	InstanceReputationsPathWithID   = InstanceReputationsPath + "/:" + apiutil.IDKey
+	InstanceReputationsOverridePath = InstanceReputationsPathWithID + "/override"
+	MediaCleanupPath                = BasePath + "/media_cleanup"
+	MediaHashBlocksPath             = BasePath + "/media_hash_blocks"
+	MediaHashBlocksPathWithID       = MediaHashBlocksPath + "/:" + apiutil.IDKey
+	MediaRefetchPath                = BasePath + "/media_refetch"
+	RateLimitsPath                  = BasePath + "/rate_limits"
+	RelaysPath                      = BasePath + "/relays"
+	RelaysPathWithID                = RelaysPath + "/:" + apiutil.IDKey
+	ReportsPath                     = BasePath + "/reports"
+	ReportsPathWithID               = ReportsPath + "/:" + apiutil.IDKey
+	ReportsResolvePath              = ReportsPathWithID + "/resolve"
+	ReportsReopenPath               = ReportsPathWithID + "/reopen"
+	ReportsForwardPath              = ReportsPathWithID + "/forward"
+	ReportsAssignPath               = ReportsPathWithID + "/assign"
+	ReportsUnassignPath             = ReportsPathWithID + "/unassign"
+	ReportsNotesPath                = ReportsPathWithID + "/notes"
+	ReportsNotesPathWithID          = ReportsNotesPath + "/:" + ReportNoteIDKey
+	TrendsPath                      = BasePath + "/trends/:" + TrendTypeKey
+	TrendsPathWithID                = TrendsPath + "/:" + apiutil.IDKey
+	TrendsApprovePath               = TrendsPathWithID + "/approve"
+	TrendsRejectPath                = TrendsPathWithID + "/reject"
+	EmailPath                       = BasePath + "/email"
+	EmailTestPath                   = EmailPath + "/test"
+	InstanceRulesPath               = BasePath + "/instance/rules"
+	InstanceRulesPathWithID         = InstanceRulesPath + "/:" + apiutil.IDKey
+	DebugPath                       = BasePath + "/debug"
+	DebugAPUrlPath                  = DebugPath + "/apurl"
+	DebugClearCachesPath            = DebugPath + "/caches/clear"
 
 	FilterQueryKey        = "filter"
 	MaxShortcodeDomainKey = "max_shortcode_domain"
 	MinShortcodeDomainKey = "min_shortcode_domain"
 	DomainQueryKey        = "domain"
+	TrendTypeKey          = "trend_type"
+	TrendStateKey         = "state"
+	ReportNoteIDKey       = "note_id"
 )
 
 type Module struct {
@@ -99,6 +131,19 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, DomainAllowsPathWithID, m.DomainAllowGETHandler)
 	attachHandler(http.MethodDelete, DomainAllowsPathWithID, m.DomainAllowDELETEHandler)
 
+	// domain permission subscription stuff
+	attachHandler(http.MethodGet, DomainPermSubsPath, m.DomainPermSubsGETHandler)
+	attachHandler(http.MethodPost, DomainPermSubsPath, m.DomainPermSubPOSTHandler)
+	attachHandler(http.MethodGet, DomainPermSubPathWithID, m.DomainPermSubGETHandler)
+	attachHandler(http.MethodPatch, DomainPermSubPathWithID, m.DomainPermSubPATCHHandler)
+	attachHandler(http.MethodPost, DomainPermSubRemovePath, m.DomainPermSubRemovePOSTHandler)
+
+	// domain permission draft stuff
+	attachHandler(http.MethodGet, DomainPermDraftsPath, m.DomainPermDraftsGETHandler)
+	attachHandler(http.MethodGet, DomainPermDraftWithID, m.DomainPermDraftGETHandler)
+	attachHandler(http.MethodPost, DomainPermDraftAccept, m.DomainPermDraftAcceptPOSTHandler)
+	attachHandler(http.MethodPost, DomainPermDraftRemove, m.DomainPermDraftRemovePOSTHandler)
+
 	// header filtering administration routes
 	attachHandler(http.MethodGet, HeaderAllowsPathWithID, m.HeaderFilterAllowGET)
 	attachHandler(http.MethodGet, HeaderBlocksPathWithID, m.HeaderFilterBlockGET)
@@ -119,15 +164,52 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
 	attachHandler(http.MethodPost, AccountsApprovePath, m.AccountApprovePOSTHandler)
 	attachHandler(http.MethodPost, AccountsRejectPath, m.AccountRejectPOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsilencePath, m.AccountUnsilencePOSTHandler)
+	attachHandler(http.MethodPost, AccountsUnsensitivePath, m.AccountUnsensitivePOSTHandler)
+
+	// audit log stuff
+	attachHandler(http.MethodGet, AuditLogPath, m.AuditLogGETHandler)
+	attachHandler(http.MethodGet, AuditLogPathWithID, m.AuditLogEntryGETHandler)
 
 	// media stuff
 	attachHandler(http.MethodPost, MediaCleanupPath, m.MediaCleanupPOSTHandler)
 	attachHandler(http.MethodPost, MediaRefetchPath, m.MediaRefetchPOSTHandler)
+	attachHandler(http.MethodGet, MediaHashBlocksPath, m.MediaHashBlocksGETHandler)
+	attachHandler(http.MethodPost, MediaHashBlocksPath, m.MediaHashBlockPOSTHandler)
+	attachHandler(http.MethodGet, MediaHashBlocksPathWithID, m.MediaHashBlockGETHandler)
+	attachHandler(http.MethodDelete, MediaHashBlocksPathWithID, m.MediaHashBlockDELETEHandler)
+
+	// rate limits stuff
+	attachHandler(http.MethodGet, RateLimitsPath, m.RateLimitsGETHandler)
+
+	// relays stuff
+	attachHandler(http.MethodGet, RelaysPath, m.RelaysGETHandler)
+	attachHandler(http.MethodPost, RelaysPath, m.RelayPOSTHandler)
+	attachHandler(http.MethodGet, RelaysPathWithID, m.RelayGETHandler)
+	attachHandler(http.MethodDelete, RelaysPathWithID, m.RelayDELETEHandler)
 
 	// reports stuff
 	attachHandler(http.MethodGet, ReportsPath, m.ReportsGETHandler)
 	attachHandler(http.MethodGet, ReportsPathWithID, m.ReportGETHandler)
 	attachHandler(http.MethodPost, ReportsResolvePath, m.ReportResolvePOSTHandler)
+	attachHandler(http.MethodPost, ReportsReopenPath, m.ReportReopenPOSTHandler)
+	attachHandler(http.MethodPost, ReportsForwardPath, m.ReportForwardPOSTHandler)
+	attachHandler(http.MethodPost, ReportsAssignPath, m.ReportAssignPOSTHandler)
+	attachHandler(http.MethodPost, ReportsUnassignPath, m.ReportUnassignPOSTHandler)
+	attachHandler(http.MethodGet, ReportsNotesPath, m.ReportNotesGETHandler)
+	attachHandler(http.MethodPost, ReportsNotesPath, m.ReportNotePOSTHandler)
+	attachHandler(http.MethodDelete, ReportsNotesPathWithID, m.ReportNoteDELETEHandler)
+
+	// trends stuff
+	attachHandler(http.MethodGet, TrendsPath, m.TrendsGETHandler)
+	attachHandler(http.MethodPost, TrendsApprovePath, m.TrendApprovePOSTHandler)
+	attachHandler(http.MethodPost, TrendsRejectPath, m.TrendRejectPOSTHandler)
+
+	// instance reputations stuff
+	attachHandler(http.MethodGet, InstanceReputationsPath, m.InstanceReputationsGETHandler)
+	attachHandler(http.MethodGet, InstanceReputationsPathWithID, m.InstanceReputationGETHandler)
+	attachHandler(http.MethodPost, InstanceReputationsOverridePath, m.InstanceReputationOverridePOSTHandler)
+	attachHandler(http.MethodDelete, InstanceReputationsOverridePath, m.InstanceReputationOverrideDELETEHandler)
 
 	// email stuff
 	attachHandler(http.MethodPost, EmailTestPath, m.EmailTestPOSTHandler)
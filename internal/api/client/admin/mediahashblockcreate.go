@@ -0,0 +1,143 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// MediaHashBlockPOSTHandler swagger:operation POST /api/v1/admin/media_hash_blocks mediaHashBlockCreate
+//
+// Block media by perceptual hash.
+//
+// Media with a perceptual hash within `threshold` bits of the blocked hash
+// is refused: local uploads are rejected, and remote media is not cached.
+// Each refusal opens an automatic report against the media's owner. Media
+// already stored before the block was created is not affected.
+//
+// Provide either `hash` directly, or `media_id` to block the hash of an
+// existing media attachment.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//	- multipart/form-data
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: hash
+//		in: formData
+//		description: Hex-encoded 64-bit perceptual hash (dHash) to block, eg., `3c3e0e1a3a1e1c1c`.
+//		type: string
+//	-
+//		name: media_id
+//		in: formData
+//		description: ID of a media attachment whose perceptual hash should be blocked.
+//		type: string
+//	-
+//		name: threshold
+//		in: formData
+//		description: >-
+//			Maximum hamming distance (number of differing bits) from the hash
+//			at which media is considered a match, to catch resized or recompressed copies.
+//		type: integer
+//		minimum: 0
+//		maximum: 16
+//		default: 0
+//	-
+//		name: comment
+//		in: formData
+//		description: Comment on why this hash is blocked.
+//		type: string
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: The newly-created media hash block.
+//			schema:
+//				"$ref": "#/definitions/adminMediaHashBlock"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: media not found
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: conflict (hash already blocked)
+//		'422':
+//			description: unprocessable (media has no perceptual hash)
+//		'500':
+//			description: internal server error
+func (m *Module) MediaHashBlockPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if authed.Account.IsMoving() {
+		apiutil.ForbiddenAfterMove(c)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.AdminMediaHashBlockCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	block, errWithCode := m.processor.Admin().MediaHashBlockCreate(c.Request.Context(), authed.Account, form)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, block)
+}
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/ratelimit"
+)
+
+type RateLimitsGetTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RateLimitsGetTestSuite) TestRateLimitsGet() {
+	suite.state.RateLimits = ratelimit.Tracker{}
+	suite.state.RateLimits.Register(middleware.RateLimitPolicyClient, 300)
+	suite.state.RateLimits.Register(middleware.RateLimitPolicySearch, 60)
+	suite.state.RateLimits.Throttle(middleware.RateLimitPolicySearch, "ip:192.0.2.0")
+	suite.state.RateLimits.Throttle(middleware.RateLimitPolicySearch, "account:01F8MH1H7YV1Z7D2C8K2730QBF")
+	suite.state.RateLimits.Throttle(middleware.RateLimitPolicySearch, "account:01F8MH1H7YV1Z7D2C8K2730QBF")
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.RateLimitsPath, "application/json")
+
+	suite.adminModule.RateLimitsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var policies []apimodel.AdminRateLimitPolicy
+	if err := json.Unmarshal(b, &policies); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if !suite.Len(policies, 2) {
+		suite.FailNow("")
+	}
+
+	// Policies sorted by name.
+	suite.Equal("client", policies[0].Name)
+	suite.Equal(300, policies[0].Limit)
+	suite.Zero(policies[0].Throttled)
+	suite.Empty(policies[0].Clients)
+
+	suite.Equal("search", policies[1].Name)
+	suite.Equal(60, policies[1].Limit)
+	suite.EqualValues(3, policies[1].Throttled)
+	if !suite.Len(policies[1].Clients, 2) {
+		suite.FailNow("")
+	}
+
+	// Most recently throttled first.
+	suite.Equal("account", policies[1].Clients[0].Type)
+	suite.Equal("01F8MH1H7YV1Z7D2C8K2730QBF", policies[1].Clients[0].ID)
+	suite.EqualValues(2, policies[1].Clients[0].Throttled)
+	suite.NotEmpty(policies[1].Clients[0].LastThrottledAt)
+
+	suite.Equal("ip", policies[1].Clients[1].Type)
+	suite.Equal("192.0.2.0", policies[1].Clients[1].ID)
+	suite.EqualValues(1, policies[1].Clients[1].Throttled)
+}
+
+func TestRateLimitsGetTestSuite(t *testing.T) {
+	suite.Run(t, &RateLimitsGetTestSuite{})
+}
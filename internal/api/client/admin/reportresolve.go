@@ -30,7 +30,7 @@ import (
 
 // ReportResolvePOSTHandler swagger:operation POST /api/v1/admin/reports/{id}/resolve adminReportResolve
 //
-// Mark a report as resolved.
+// Mark a report as resolved, optionally taking action against the reported account.
 //
 //	---
 //	tags:
@@ -62,6 +62,23 @@ import (
 //
 //			Sample: The reported account was suspended.
 //		type: string
+//	-
+//		name: account_action
+//		in: formData
+//		description: >-
+//			Optional action to take against the reported account
+//			when resolving the report. One of silence, suspend, sensitive.
+//			The action taken comment (if any) is used as the action text.
+//		type: string
+//	-
+//		name: delete_statuses
+//		in: formData
+//		description: >-
+//			Delete the statuses attached to the report when resolving it.
+//			Ignored if account_action is suspend, as suspending an account
+//			deletes all of its statuses anyway.
+//		type: boolean
+//		default: false
 //
 //	security:
 //	- OAuth2 Bearer:
@@ -81,6 +98,11 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'409':
+//			description: >-
+//				Conflict: There is already an admin action running that conflicts with this action.
+//				Check the error message in the response body for more information. This is a temporary
+//				error; it should be possible to process this action if you try again in a bit.
 //		'500':
 //			description: internal server error
 func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
@@ -118,7 +140,7 @@ func (m *Module) ReportResolvePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form.ActionTakenComment)
+	report, errWithCode := m.processor.Admin().ReportResolve(c.Request.Context(), authed.Account, reportID, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -140,10 +140,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
   {
     "id": "01GP3DFY9XQ1TJMZT5BGAZPXX7",
     "action_taken": true,
+    "state": "resolved",
     "action_taken_at": "2022-05-15T15:01:56.000Z",
     "category": "other",
     "comment": "this is a turtle, not a person, therefore should not be a poster",
     "forwarded": true,
+    "forwarded_domains": [],
+    "forwarded_at": null,
     "created_at": "2022-05-15T14:20:12.000Z",
     "updated_at": "2022-05-15T14:20:12.000Z",
     "account": {
@@ -167,6 +170,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -176,6 +180,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -212,6 +217,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -221,6 +227,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -270,6 +277,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -279,6 +287,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
         "url": "http://localhost:8080/@admin",
@@ -324,6 +333,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -333,6 +343,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2022-05-17T13:10:59.000Z",
         "note": "",
         "url": "http://localhost:8080/@admin",
@@ -364,10 +375,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
   {
     "id": "01GP3AWY4CRDVRNZKW0TEAMB5R",
     "action_taken": false,
+    "state": "open",
     "action_taken_at": null,
     "category": "other",
     "comment": "dark souls sucks, please yeet this nerd",
     "forwarded": true,
+    "forwarded_domains": [],
+    "forwarded_at": null,
     "created_at": "2022-05-14T10:20:03.000Z",
     "updated_at": "2022-05-14T10:20:03.000Z",
     "account": {
@@ -391,6 +405,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -400,6 +415,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -449,6 +465,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -458,6 +475,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -497,6 +515,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -505,6 +524,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetAll() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -614,10 +634,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
   {
     "id": "01GP3AWY4CRDVRNZKW0TEAMB5R",
     "action_taken": false,
+    "state": "open",
     "action_taken_at": null,
     "category": "other",
     "comment": "dark souls sucks, please yeet this nerd",
     "forwarded": true,
+    "forwarded_domains": [],
+    "forwarded_at": null,
     "created_at": "2022-05-14T10:20:03.000Z",
     "updated_at": "2022-05-14T10:20:03.000Z",
     "account": {
@@ -641,6 +664,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -650,6 +674,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -699,6 +724,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -708,6 +734,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -747,6 +774,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -755,6 +783,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetCreatedByAccount() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -864,10 +893,13 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
   {
     "id": "01GP3AWY4CRDVRNZKW0TEAMB5R",
     "action_taken": false,
+    "state": "open",
     "action_taken_at": null,
     "category": "other",
     "comment": "dark souls sucks, please yeet this nerd",
     "forwarded": true,
+    "forwarded_domains": [],
+    "forwarded_at": null,
     "created_at": "2022-05-14T10:20:03.000Z",
     "updated_at": "2022-05-14T10:20:03.000Z",
     "account": {
@@ -891,6 +923,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "approved": true,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -900,6 +933,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "locked": true,
         "discoverable": false,
         "bot": false,
+        "group": false,
         "created_at": "2022-06-04T13:12:00.000Z",
         "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
         "url": "http://localhost:8080/@1happyturtle",
@@ -949,6 +983,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
       "approved": false,
       "disabled": false,
       "silenced": false,
+      "sensitized": false,
       "suspended": false,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -958,6 +993,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -997,6 +1033,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
         "pinned": false,
         "content": "dark souls status bot: \"thoughts of dog\"",
         "reblog": null,
+        "quote": null,
         "account": {
           "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
           "username": "foss_satan",
@@ -1005,6 +1042,7 @@ func (suite *ReportsGetTestSuite) TestReportsGetTargetAccount() {
           "locked": false,
           "discoverable": true,
           "bot": false,
+          "group": false,
           "created_at": "2021-09-26T10:52:36.000Z",
           "note": "i post about like, i dunno, stuff, or whatever!!!!",
           "url": "http://fossbros-anonymous.io/@foss_satan",
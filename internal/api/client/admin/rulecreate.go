@@ -98,7 +98,7 @@ func (m *Module) RulePOSTHandler(c *gin.Context) {
 		return
 	}
 
-	apiRule, errWithCode := m.processor.Admin().RuleCreate(c.Request.Context(), form)
+	apiRule, errWithCode := m.processor.Admin().RuleCreate(c.Request.Context(), authed.Account, form)
 	if errWithCode != nil {
 		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
 		return
@@ -0,0 +1,184 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrendTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *TrendTestSuite) putLinkTrend() *gtsmodel.Trend {
+	trend := &gtsmodel.Trend{
+		ID:         id.NewULID(),
+		Type:       gtsmodel.TrendTypeLink,
+		Target:     "https://example.org/articles/sloths",
+		Score:      3,
+		Uses:       []int{4, 0, 0, 0, 0, 0, 0},
+		Accounts:   []int{3, 0, 0, 0, 0, 0, 0},
+		ComputedAt: time.Now(),
+		State:      gtsmodel.TrendStatePending,
+	}
+
+	if err := suite.db.PutTrend(context.Background(), trend); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return trend
+}
+
+func (suite *TrendTestSuite) trendRequest(
+	method string,
+	trendType string,
+	path string,
+	trendID string,
+	handler func(*gin.Context),
+	expectedHTTPStatus int,
+) []byte {
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["admin_account"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["admin_account"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["admin_account"])
+
+	requestURI := config.GetProtocol() + "://" + config.GetHost() + "/api" + path
+	ctx.Request = httptest.NewRequest(method, requestURI, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.AddParam(admin.TrendTypeKey, trendType)
+	if trendID != "" {
+		ctx.AddParam(apiutil.IDKey, trendID)
+	}
+
+	handler(ctx)
+
+	suite.Equal(expectedHTTPStatus, recorder.Code)
+
+	b, err := io.ReadAll(recorder.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return b
+}
+
+func (suite *TrendTestSuite) TestTrendApprove() {
+	trend := suite.putLinkTrend()
+
+	// Pending link should be up for review.
+	b := suite.trendRequest(
+		http.MethodGet,
+		"links",
+		"/v1/admin/trends/links?state=pending",
+		"",
+		suite.adminModule.TrendsGETHandler,
+		http.StatusOK,
+	)
+
+	var apiTrends []*apimodel.AdminTrend
+	if err := json.Unmarshal(b, &apiTrends); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(apiTrends, 1)
+	suite.Equal(trend.ID, apiTrends[0].ID)
+	suite.Equal("link", apiTrends[0].Type)
+	suite.Equal("pending", apiTrends[0].State)
+	suite.Equal("https://example.org/articles/sloths", apiTrends[0].Link.URL)
+	suite.Equal("4", apiTrends[0].Link.History[0].Uses)
+
+	// Approving it under the wrong type should 404.
+	suite.trendRequest(
+		http.MethodPost,
+		"tags",
+		"/v1/admin/trends/tags/"+trend.ID+"/approve",
+		trend.ID,
+		suite.adminModule.TrendApprovePOSTHandler,
+		http.StatusNotFound,
+	)
+
+	// Approve it properly.
+	b = suite.trendRequest(
+		http.MethodPost,
+		"links",
+		"/v1/admin/trends/links/"+trend.ID+"/approve",
+		trend.ID,
+		suite.adminModule.TrendApprovePOSTHandler,
+		http.StatusOK,
+	)
+
+	apiTrend := &apimodel.AdminTrend{}
+	if err := json.Unmarshal(b, apiTrend); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("approved", apiTrend.State)
+	suite.NotEmpty(apiTrend.ReviewedAt)
+
+	dbTrend, err := suite.db.GetTrendByID(context.Background(), trend.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbTrend.IsApproved())
+	suite.Equal(suite.testAccounts["admin_account"].ID, dbTrend.ReviewedByAccountID)
+
+	// Nothing should be pending anymore.
+	b = suite.trendRequest(
+		http.MethodGet,
+		"links",
+		"/v1/admin/trends/links?state=pending",
+		"",
+		suite.adminModule.TrendsGETHandler,
+		http.StatusOK,
+	)
+	suite.Equal("[]", string(b))
+}
+
+func (suite *TrendTestSuite) TestTrendRejectUnknownType() {
+	trend := suite.putLinkTrend()
+
+	suite.trendRequest(
+		http.MethodPost,
+		"accounts",
+		"/v1/admin/trends/accounts/"+trend.ID+"/reject",
+		trend.ID,
+		suite.adminModule.TrendRejectPOSTHandler,
+		http.StatusNotFound,
+	)
+}
+
+func TestTrendTestSuite(t *testing.T) {
+	suite.Run(t, &TrendTestSuite{})
+}
@@ -0,0 +1,170 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TrendsGETHandler swagger:operation GET /api/v1/admin/trends/{trend_type} trendsAdminGet
+//
+// View currently trending tags, statuses, or links, for review.
+//
+// Trends are returned in descending order of trend score, in any review state
+// unless filtered by state. Only approved trends are shown to users.
+//
+//	---
+//	tags:
+//	- admin
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: trend_type
+//		type: string
+//		description: Kind of trends to view.
+//		enum:
+//			- tags
+//			- statuses
+//			- links
+//		in: path
+//		required: true
+//	-
+//		name: state
+//		type: string
+//		description: Show only trends in this review state.
+//		enum:
+//			- pending
+//			- approved
+//			- rejected
+//		in: query
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of results to return.
+//		default: 20
+//		maximum: 100
+//		minimum: 1
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		in: query
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- admin
+//
+//	responses:
+//		'200':
+//			description: Currently trending items of the requested type.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/adminTrend"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'403':
+//			description: forbidden
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if !*authed.User.Admin {
+		err := fmt.Errorf("user %s not an admin", authed.User.ID)
+		apiutil.ErrorHandler(c, gtserror.NewErrorForbidden(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	trendType, errWithCode := parseTrendType(c)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), 20, 100, 1)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	offset, errWithCode := apiutil.ParseTrendsOffset(c.Query(apiutil.TrendsOffsetKey), 0, 1000, 0)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Admin().TrendsGet(
+		c.Request.Context(),
+		authed.Account,
+		trendType,
+		gtsmodel.TrendState(c.Query(TrendStateKey)),
+		limit,
+		offset,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
+
+// parseTrendType parses the trend type path
+// parameter (plural, as in the public trends
+// API) into the corresponding trend type.
+func parseTrendType(c *gin.Context) (gtsmodel.TrendType, gtserror.WithCode) {
+	switch value := c.Param(TrendTypeKey); value {
+	case "tags":
+		return gtsmodel.TrendTypeTag, nil
+	case "statuses":
+		return gtsmodel.TrendTypeStatus, nil
+	case "links":
+		return gtsmodel.TrendTypeLink, nil
+	default:
+		err := fmt.Errorf("trend type %s not recognized", value)
+		return "", gtserror.NewErrorNotFound(err, err.Error())
+	}
+}
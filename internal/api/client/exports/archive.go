@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package exports
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ArchivesGETHandler swagger:operation GET /api/v1/exports/archive archivesGet
+//
+// View your personal archives, newest first.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			name: archives
+//			description: Your archives.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/archive"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ArchivesGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archives, errWithCode := m.processor.Account().ArchivesGet(
+		c.Request.Context(),
+		authed.Account,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, archives)
+}
+
+// ArchivePOSTHandler swagger:operation POST /api/v1/exports/archive archiveRequest
+//
+// Request a personal archive of your account.
+//
+// The archive is a zip file in the same layout as Mastodon archives, containing your
+// profile, all of your posts as an ActivityPub outbox, the posts you've liked and
+// bookmarked, and the media attached to your posts and profile.
+//
+// The archive is generated in the background; poll `GET /api/v1/exports/archive`
+// until it's processed, then download it. Once the new archive is ready, it replaces
+// any previous archives. Archives can be requested at most once every 24 hours.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:accounts
+//
+//	responses:
+//		'202':
+//			description: The requested archive, not yet processed.
+//			schema:
+//				"$ref": "#/definitions/archive"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: An archive is still being generated, or was requested in the last 24 hours.
+//		'500':
+//			description: internal server error
+func (m *Module) ArchivePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	archive, errWithCode := m.processor.Account().ArchiveCreate(
+		c.Request.Context(),
+		authed.Account,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusAccepted, archive)
+}
+
+// ArchiveFileGETHandler swagger:operation GET /api/v1/exports/archive/{id}/archive.zip archiveFileGet
+//
+// Download one of your processed personal archives as a zip file.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/zip
+//
+//	parameters:
+//	-
+//		name: id
+//		type: string
+//		description: ID of the archive.
+//		in: path
+//		required: true
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			name: archive
+//			description: Zip file of the archive.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'404':
+//			description: not found
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: The archive is still being generated.
+//		'500':
+//			description: internal server error
+func (m *Module) ArchiveFileGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.AppZip); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	id, errWithCode := apiutil.ParseID(c.Param(apiutil.IDKey))
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	content, errWithCode := m.processor.Account().ArchiveFileGet(
+		ctx,
+		authed.Account,
+		id,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	defer func() {
+		// Close content when we're done, catch errors.
+		if err := content.Content.Close(); err != nil {
+			log.Errorf(ctx, "error closing archive file: %v", err)
+		}
+	}()
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, map[string]string{
+		"Content-Disposition": `attachment; filename="archive-` + id + `.zip"`,
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 )
 
@@ -32,6 +33,9 @@ const (
 	ListsPath     = BasePath + "/lists.csv"
 	BlocksPath    = BasePath + "/blocks.csv"
 	MutesPath     = BasePath + "/mutes.csv"
+
+	ArchivePath     = BasePath + "/archive"
+	ArchiveFilePath = ArchivePath + "/:" + apiutil.IDKey + "/archive.zip"
 )
 
 type Module struct {
@@ -51,4 +55,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, ListsPath, m.ExportListsGETHandler)
 	attachHandler(http.MethodGet, BlocksPath, m.ExportBlocksGETHandler)
 	attachHandler(http.MethodGet, MutesPath, m.ExportMutesGETHandler)
+	attachHandler(http.MethodGet, ArchivePath, m.ArchivesGETHandler)
+	attachHandler(http.MethodPost, ArchivePath, m.ArchivePOSTHandler)
+	attachHandler(http.MethodGet, ArchiveFilePath, m.ArchiveFileGETHandler)
 }
@@ -90,6 +90,7 @@ func (suite *GetTestSuite) TestGet() {
     "locked": true,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
     "url": "http://example.org/@Some_User",
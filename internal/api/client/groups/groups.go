@@ -0,0 +1,70 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	StatusIDKey = "status_id"
+
+	BasePath                   = "/v1/groups"
+	BasePathWithID             = BasePath + "/:" + apiutil.IDKey
+	FollowRequestsPath         = BasePathWithID + "/follow_requests"
+	FollowRequestAuthorizePath = FollowRequestsPath + "/:" + apiutil.AccountIDKey + "/authorize"
+	FollowRequestRejectPath    = FollowRequestsPath + "/:" + apiutil.AccountIDKey + "/reject"
+	ModeratorsPath             = BasePathWithID + "/moderators"
+	ModeratorsPathWithID       = ModeratorsPath + "/:" + apiutil.AccountIDKey
+	StatusesPathWithID         = BasePathWithID + "/statuses/:" + StatusIDKey
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	// create / get / delete groups
+	attachHandler(http.MethodPost, BasePath, m.GroupCreatePOSTHandler)
+	attachHandler(http.MethodGet, BasePath, m.GroupsGETHandler)
+	attachHandler(http.MethodGet, BasePathWithID, m.GroupGETHandler)
+	attachHandler(http.MethodDelete, BasePathWithID, m.GroupDELETEHandler)
+
+	// get / authorize / reject membership requests
+	attachHandler(http.MethodGet, FollowRequestsPath, m.GroupFollowRequestsGETHandler)
+	attachHandler(http.MethodPost, FollowRequestAuthorizePath, m.GroupFollowRequestAuthorizePOSTHandler)
+	attachHandler(http.MethodPost, FollowRequestRejectPath, m.GroupFollowRequestRejectPOSTHandler)
+
+	// get / add / remove moderators
+	attachHandler(http.MethodGet, ModeratorsPath, m.GroupModeratorsGETHandler)
+	attachHandler(http.MethodPost, ModeratorsPath, m.GroupModeratorsPOSTHandler)
+	attachHandler(http.MethodDelete, ModeratorsPathWithID, m.GroupModeratorDELETEHandler)
+
+	// remove posts from group
+	attachHandler(http.MethodDelete, StatusesPathWithID, m.GroupStatusDELETEHandler)
+}
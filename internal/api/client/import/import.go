@@ -39,6 +39,7 @@ const (
 var types = []string{
 	"following",
 	"blocks",
+	"outbox",
 }
 
 var modes = []string{
@@ -57,15 +58,75 @@ func New(processor *processing.Processor) *Module {
 }
 
 func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, BasePath, m.ImportsGETHandler)
 	attachHandler(http.MethodPost, BasePath, m.ImportPOSTHandler)
 }
 
+// ImportsGETHandler swagger:operation GET /api/v1/import importsGet
+//
+// View the progress of your data imports, newest first.
+//
+//	---
+//	tags:
+//	- import-export
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- read:accounts
+//
+//	responses:
+//		'200':
+//			name: imports
+//			description: Your imports.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/import"
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) ImportsGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	imports, errWithCode := m.processor.Account().ImportsGet(
+		c.Request.Context(),
+		authed.Account,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, imports)
+}
+
 // ImportPOSTHandler swagger:operation POST /api/v1/import importData
 //
-// Upload some CSV-formatted data to your account.
+// Upload some CSV-formatted data, or an archive, to your account.
 //
 // This can be used to migrate data from a Mastodon-compatible CSV file to a GoToSocial account.
 //
+// It can also be used to recreate posts from a Mastodon or GoToSocial archive zip, when moving
+// to this instance. The archive must belong to an account that you've added as an alias of your
+// account. Only public and unlisted posts, and your replies to them, are recreated, with their
+// original timestamps; they're not sent out to your followers as new posts.
+// The progress of this import can be viewed at `/api/v1/import`.
+//
 // Uploaded data will be processed asynchronously, and not all entries may be processed depending
 // on domain blocks, user-level blocks, network availability of referenced accounts and statuses, etc.
 //
@@ -83,7 +144,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //	-
 //		name: data
 //		in: formData
-//		description: The CSV data file to upload.
+//		description: The CSV data file, or archive zip, to upload.
 //		type: file
 //		required: true
 //	-
@@ -94,6 +155,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //
 //			- `following` - accounts to follow.
 //			- `blocks` - accounts to block.
+//			- `outbox` - posts to recreate from an archive zip; posts already imported are skipped.
 //		type: string
 //		required: true
 //	-
@@ -103,7 +165,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			Mode to use when creating entries from the data file:
 //
 //			- `merge` to merge entries in file with existing entries.
-//			- `overwrite` to replace existing entries with entries in file.
+//			- `overwrite` to replace existing entries with entries in file (not supported for `outbox`).
 //		type: string
 //		default: merge
 //
@@ -118,8 +180,12 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 //			description: bad request
 //		'401':
 //			description: unauthorized
+//		'403':
+//			description: forbidden
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable
 //		'500':
 //			description: internal server error
 func (m *Module) ImportPOSTHandler(c *gin.Context) {
@@ -167,6 +167,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch1() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -307,6 +308,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch2() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -447,6 +449,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch3() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -638,6 +641,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch6() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -804,6 +808,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch8() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -981,6 +986,7 @@ func (suite *InstancePatchTestSuite) TestInstancePatch9() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -84,6 +84,12 @@ import (
 //			description: The newly-created media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'202':
+//			description: >-
+//				The newly-created media attachment, which is still being processed (v2 only).
+//				Poll GET /api/v1/media/{id} until it returns 200 to get the full-size media url.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -142,6 +148,13 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		// so even though we have the URL already, remove it now to comply
 		// with the api
 		apiAttachment.URL = nil
+
+		if apiAttachment.Processing {
+			// Media is still being processed,
+			// so indicate this in the response.
+			apiutil.JSON(c, http.StatusAccepted, apiAttachment)
+			return
+		}
 	}
 
 	apiutil.JSON(c, http.StatusOK, apiAttachment)
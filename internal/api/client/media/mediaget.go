@@ -55,6 +55,12 @@ import (
 //			description: The requested media attachment.
 //			schema:
 //				"$ref": "#/definitions/attachment"
+//		'206':
+//			description: >-
+//				The requested media attachment, which is still being processed.
+//				The full-size media url will be null until processing is finished.
+//			schema:
+//				"$ref": "#/definitions/attachment"
 //		'400':
 //			description: bad request
 //		'401':
@@ -98,5 +104,12 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if attachment.Processing {
+		// Full-size media not
+		// available just yet.
+		apiutil.JSON(c, http.StatusPartialContent, attachment)
+		return
+	}
+
 	apiutil.JSON(c, http.StatusOK, attachment)
 }
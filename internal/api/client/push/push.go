@@ -0,0 +1,128 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// BasePath is the base path for serving the push API, minus the 'api' prefix.
+	BasePath = "/v1/push"
+	// SubscriptionPath is the path for serving the Web Push subscription of the current access token.
+	SubscriptionPath = BasePath + "/subscription"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	attachHandler(http.MethodGet, SubscriptionPath, m.PushSubscriptionGETHandler)
+	attachHandler(http.MethodPost, SubscriptionPath, m.PushSubscriptionPOSTHandler)
+	attachHandler(http.MethodPut, SubscriptionPath, m.PushSubscriptionPUTHandler)
+	attachHandler(http.MethodDelete, SubscriptionPath, m.PushSubscriptionDELETEHandler)
+}
+
+// normalizeUpdateRequest copies the form
+// variant of request data, if any, into
+// the nested JSON variant.
+func normalizeUpdateRequest(form *apimodel.PushSubscriptionUpdateRequest) {
+	if form.Data == nil {
+		form.Data = &apimodel.PushSubscriptionRequestData{}
+	}
+
+	if form.DataPolicy != nil {
+		form.Data.Policy = form.DataPolicy
+	}
+
+	formAlerts := []*bool{
+		form.DataAlertsFollow,
+		form.DataAlertsFollowRequest,
+		form.DataAlertsFavourite,
+		form.DataAlertsMention,
+		form.DataAlertsReblog,
+		form.DataAlertsPoll,
+		form.DataAlertsStatus,
+		form.DataAlertsAdminSignup,
+		form.DataAlertsPendingFavourite,
+		form.DataAlertsPendingReply,
+		form.DataAlertsPendingReblog,
+	}
+
+	anySet := false
+	for _, alert := range formAlerts {
+		if alert != nil {
+			anySet = true
+			break
+		}
+	}
+
+	if !anySet {
+		// No form alerts,
+		// nothing to do.
+		return
+	}
+
+	// Alerts given as form fields replace
+	// all alerts, just like JSON alerts do;
+	// any omitted alerts default to false.
+	form.Data.Alerts = &apimodel.PushSubscriptionAlerts{
+		Follow:           util.PtrOrZero(form.DataAlertsFollow),
+		FollowRequest:    util.PtrOrZero(form.DataAlertsFollowRequest),
+		Favourite:        util.PtrOrZero(form.DataAlertsFavourite),
+		Mention:          util.PtrOrZero(form.DataAlertsMention),
+		Reblog:           util.PtrOrZero(form.DataAlertsReblog),
+		Poll:             util.PtrOrZero(form.DataAlertsPoll),
+		Status:           util.PtrOrZero(form.DataAlertsStatus),
+		AdminSignup:      util.PtrOrZero(form.DataAlertsAdminSignup),
+		PendingFavourite: util.PtrOrZero(form.DataAlertsPendingFavourite),
+		PendingReply:     util.PtrOrZero(form.DataAlertsPendingReply),
+		PendingReblog:    util.PtrOrZero(form.DataAlertsPendingReblog),
+	}
+}
+
+// normalizeCreateRequest copies the form
+// variant of request subscription and data,
+// if any, into the nested JSON variants.
+func normalizeCreateRequest(form *apimodel.PushSubscriptionCreateRequest) {
+	if form.SubscriptionEndpoint != nil ||
+		form.SubscriptionKeysAuth != nil ||
+		form.SubscriptionKeysP256dh != nil {
+		form.Subscription = &apimodel.PushSubscriptionRequestSubscription{
+			Endpoint: util.PtrOrZero(form.SubscriptionEndpoint),
+			Keys: apimodel.PushSubscriptionRequestKeys{
+				Auth:   util.PtrOrZero(form.SubscriptionKeysAuth),
+				P256dh: util.PtrOrZero(form.SubscriptionKeysP256dh),
+			},
+		}
+	}
+
+	normalizeUpdateRequest(&form.PushSubscriptionUpdateRequest)
+}
@@ -0,0 +1,161 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// PushSubscriptionPUTHandler swagger:operation PUT /api/v1/push/subscription pushSubscriptionPut
+//
+// Update the alerts and policy of the Web Push subscription for the current access token.
+//
+//	---
+//	tags:
+//	- push
+//
+//	consumes:
+//	- application/json
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: data[alerts][follow]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when someone has followed you?
+//	-
+//		name: data[alerts][follow_request]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when someone has requested to follow you?
+//	-
+//		name: data[alerts][favourite]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a status you created has been favourited by someone else?
+//	-
+//		name: data[alerts][mention]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when someone else has mentioned you in a status?
+//	-
+//		name: data[alerts][reblog]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a status you created has been boosted by someone else?
+//	-
+//		name: data[alerts][poll]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a poll you voted in or created has ended?
+//	-
+//		name: data[alerts][status]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a subscribed account posts a status?
+//	-
+//		name: data[alerts][admin.sign_up]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a new user has signed up?
+//	-
+//		name: data[alerts][pending.favourite]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a fave is pending?
+//	-
+//		name: data[alerts][pending.reply]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a reply is pending?
+//	-
+//		name: data[alerts][pending.reblog]
+//		in: formData
+//		type: boolean
+//		description: Receive a push notification when a boost is pending?
+//	-
+//		name: data[policy]
+//		in: formData
+//		type: string
+//		enum:
+//			- all
+//			- followed
+//			- follower
+//			- none
+//		description: Which accounts to receive push notifications from.
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- push
+//
+//	responses:
+//		'200':
+//			description: Web Push subscription for current access token.
+//			schema:
+//				"$ref": "#/definitions/webPushSubscription"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized
+//		'406':
+//			description: not acceptable
+//		'404':
+//			description: This access token doesn't have an associated subscription.
+//		'500':
+//			description: internal server error
+func (m *Module) PushSubscriptionPUTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.PushSubscriptionUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+	normalizeUpdateRequest(form)
+
+	apiSubscription, errWithCode := m.processor.Push().Update(
+		c.Request.Context(),
+		authed.Token.GetAccess(),
+		form,
+	)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, apiSubscription)
+}
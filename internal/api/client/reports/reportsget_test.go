@@ -146,6 +146,7 @@ func (suite *ReportsGetTestSuite) TestGetReports() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -236,6 +237,7 @@ func (suite *ReportsGetTestSuite) TestGetReports4() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -310,6 +312,7 @@ func (suite *ReportsGetTestSuite) TestGetReports6() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -368,6 +371,7 @@ func (suite *ReportsGetTestSuite) TestGetReports7() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
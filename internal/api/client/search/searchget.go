@@ -82,8 +82,8 @@ import (
 //		type: integer
 //		description: >-
 //			Page number of results to return (starts at 0).
-//			This parameter is currently not used, page by selecting
-//			a specific query type and using maxID and minID instead.
+//			Text search results are ordered by relevance, so should be paged using offset.
+//			Searches for an exact account, status URL, or hashtag only ever have one page.
 //		default: 0
 //		maximum: 10
 //		minimum: 0
@@ -98,10 +98,13 @@ import (
 //			- @[username]@[domain]` -- search for a remote account with exact username and domain. Will only ever return 1 result at most.
 //			- `https://example.org/some/arbitrary/url` -- search for an account OR a status with the given URL. Will only ever return 1 result at most.
 //			- `#[hashtag_name]` -- search for a hashtag with the given hashtag name, or starting with the given hashtag name. Case insensitive. Can return multiple results.
-//			- any arbitrary string -- search for accounts or statuses containing the given string. Can return multiple results.
+//			- any arbitrary string -- search for accounts or statuses containing the given words. Can return multiple results, most relevant first.
 //
-//			Arbitrary string queries may include the following operators:
+//			Arbitrary string queries may use `"double quotes"` to search for a phrase, and `word*` to search for words starting with `word`.
+//			They may also include the following operators:
 //			- `from:localuser`, `from:remoteuser@instance.tld`: restrict results to statuses created by the specified account.
+//			- `has:media`, `has:poll`: restrict results to statuses with media attachments, or with a poll.
+//			- `before:YYYY-MM-DD`, `after:YYYY-MM-DD`: restrict results to statuses created before or after the given day.
 //		in: query
 //		required: true
 //	-
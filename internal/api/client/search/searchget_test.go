@@ -915,8 +915,8 @@ func (suite *SearchGetTestSuite) TestSearchAAny() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 5)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Accounts, 1)
+	suite.Len(searchResult.Statuses, 5)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -959,7 +959,7 @@ func (suite *SearchGetTestSuite) TestSearchAAnyFollowingOnly() {
 	}
 
 	suite.Len(searchResult.Accounts, 2)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Statuses, 5)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -1002,7 +1002,7 @@ func (suite *SearchGetTestSuite) TestSearchAStatuses() {
 	}
 
 	suite.Len(searchResult.Accounts, 0)
-	suite.Len(searchResult.Statuses, 7)
+	suite.Len(searchResult.Statuses, 5)
 	suite.Len(searchResult.Hashtags, 0)
 }
 
@@ -1130,7 +1130,7 @@ func (suite *SearchGetTestSuite) TestSearchAAccounts() {
 		suite.FailNow(err.Error())
 	}
 
-	suite.Len(searchResult.Accounts, 5)
+	suite.Len(searchResult.Accounts, 1)
 	suite.Len(searchResult.Statuses, 0)
 	suite.Len(searchResult.Hashtags, 0)
 }
@@ -1492,6 +1492,212 @@ func (suite *SearchGetTestSuite) TestSearchBadQueryType() {
 	}
 }
 
+func (suite *SearchGetTestSuite) TestSearchStatusesHasMediaOperator() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = `has:media from:the_mighty_zork`
+		queryType          *string = func() *string { i := "statuses"; return &i }()
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ``
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Len(searchResult.Accounts, 0)
+	suite.Len(searchResult.Statuses, 2)
+	suite.Len(searchResult.Hashtags, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesPhraseAndDateOperators() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = `"little gif" after:2021-10-19 before:2021-10-21`
+		queryType          *string = func() *string { i := "statuses"; return &i }()
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ``
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if suite.Len(searchResult.Statuses, 1) {
+		suite.Equal(testrig.NewTestStatuses()["local_account_1_status_4"].ID, searchResult.Statuses[0].ID)
+	}
+}
+
+func (suite *SearchGetTestSuite) TestSearchStatusesOffsetPastResults() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = func() *int { i := 1; return &i }()
+		resolve            *bool   = nil
+		query                      = `hi`
+		queryType          *string = func() *string { i := "statuses"; return &i }()
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusOK
+		expectedBody               = ``
+	)
+
+	searchResult, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Only one page of results.
+	suite.Len(searchResult.Statuses, 0)
+}
+
+func (suite *SearchGetTestSuite) TestSearchBadHasOperator() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = `sloths has:everything`
+		queryType          *string = nil
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusBadRequest
+		expectedBody               = `{"error":"Bad Request: the 'has:' search operator argument \"everything\" was not recognized, valid options are ['media', 'poll']"}`
+	)
+
+	_, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *SearchGetTestSuite) TestSearchBadDateOperator() {
+	var (
+		requestingAccount          = suite.testAccounts["local_account_1"]
+		token                      = suite.testTokens["local_account_1"]
+		user                       = suite.testUsers["local_account_1"]
+		maxID              *string = nil
+		minID              *string = nil
+		limit              *int    = nil
+		offset             *int    = nil
+		resolve            *bool   = nil
+		query                      = `sloths before:yesterday`
+		queryType          *string = nil
+		following          *bool   = nil
+		fromAccountID      *string = nil
+		expectedHTTPStatus         = http.StatusBadRequest
+		expectedBody               = `{"error":"Bad Request: the 'before:' search operator requires a date in the format YYYY-MM-DD, but got \"yesterday\""}`
+	)
+
+	_, err := suite.getSearch(
+		requestingAccount,
+		token,
+		apiutil.APIv2,
+		user,
+		maxID,
+		minID,
+		limit,
+		offset,
+		query,
+		queryType,
+		resolve,
+		following,
+		fromAccountID,
+		expectedHTTPStatus,
+		expectedBody)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
 func (suite *SearchGetTestSuite) TestSearchEmptyQuery() {
 	var (
 		requestingAccount          = suite.testAccounts["local_account_1"]
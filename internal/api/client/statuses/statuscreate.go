@@ -112,6 +112,16 @@ import (
 //		type: string
 //		in: formData
 //	-
+//		name: quoted_status_id
+//		x-go-name: QuotedStatusID
+//		description: |-
+//			ID of the status being quoted, if status is a quote.
+//			The quoted status must be public or unlisted, and
+//			its author's interaction policy must permit boosts
+//			by the requesting account.
+//		type: string
+//		in: formData
+//	-
 //		name: sensitive
 //		x-go-name: Sensitive
 //		description: Status and attached media should be marked as sensitive.
@@ -162,8 +172,6 @@ import (
 //			ISO 8601 Datetime at which to schedule a status.
 //			Providing this parameter will cause ScheduledStatus to be returned instead of Status.
 //			Must be at least 5 minutes in the future.
-//
-//			This feature isn't implemented yet.
 //		type: string
 //		in: formData
 //	-
@@ -191,7 +199,9 @@ import (
 //
 //	responses:
 //		'200':
-//			description: "The newly created status."
+//			description: >-
+//				The newly created status. If scheduled_at was
+//				provided, a scheduledStatus is returned instead.
 //			schema:
 //				"$ref": "#/definitions/status"
 //		'400':
@@ -204,6 +214,8 @@ import (
 //			description: not found
 //		'406':
 //			description: not acceptable
+//		'422':
+//			description: unprocessable content
 //		'500':
 //			description: internal server error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -243,6 +255,24 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if form.ScheduledAt != "" {
+		// Status should be published
+		// later, so schedule it instead.
+		scheduledStatus, errWithCode := m.processor.Status().ScheduledStatusCreate(
+			c.Request.Context(),
+			authed.Account,
+			authed.Application,
+			form,
+		)
+		if errWithCode != nil {
+			apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+			return
+		}
+
+		c.JSON(http.StatusOK, scheduledStatus)
+		return
+	}
+
 	apiStatus, errWithCode := m.processor.Status().Create(
 		c.Request.Context(),
 		authed.Account,
@@ -286,7 +316,7 @@ func validateNormalizeCreateStatus(form *apimodel.StatusCreateRequest) error {
 	}
 
 	if form.Poll != nil {
-		if err := validateNormalizeCreatePoll(form); err != nil {
+		if err := validateNormalizePoll(form.Poll); err != nil {
 			return err
 		}
 	}
@@ -308,17 +338,22 @@ func validateNormalizeCreateStatus(form *apimodel.StatusCreateRequest) error {
 	return nil
 }
 
-func validateNormalizeCreatePoll(form *apimodel.StatusCreateRequest) error {
+// validateNormalizePoll checks the given poll
+// for missing or too many options, and overlength
+// option inputs.
+//
+// Side effect: normalizes the poll's expires_in.
+func validateNormalizePoll(poll *apimodel.PollRequest) error {
 	maxPollOptions := config.GetStatusesPollMaxOptions()
 	maxPollChars := config.GetStatusesPollOptionMaxChars()
 
 	// Normalize poll expiry if necessary.
 	// If we parsed this as JSON, expires_in
 	// may be either a float64 or a string.
-	if ei := form.Poll.ExpiresInI; ei != nil {
+	if ei := poll.ExpiresInI; ei != nil {
 		switch e := ei.(type) {
 		case float64:
-			form.Poll.ExpiresIn = int(e)
+			poll.ExpiresIn = int(e)
 
 		case string:
 			expiresIn, err := strconv.Atoi(e)
@@ -326,22 +361,22 @@ func validateNormalizeCreatePoll(form *apimodel.StatusCreateRequest) error {
 				return fmt.Errorf("could not parse expires_in value %s as integer: %w", e, err)
 			}
 
-			form.Poll.ExpiresIn = expiresIn
+			poll.ExpiresIn = expiresIn
 
 		default:
 			return fmt.Errorf("could not parse expires_in type %T as integer", ei)
 		}
 	}
 
-	if len(form.Poll.Options) == 0 {
+	if len(poll.Options) == 0 {
 		return errors.New("poll with no options")
 	}
 
-	if len(form.Poll.Options) > maxPollOptions {
-		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(form.Poll.Options), maxPollOptions)
+	if len(poll.Options) > maxPollOptions {
+		return fmt.Errorf("too many poll options provided, %d provided but limit is %d", len(poll.Options), maxPollOptions)
 	}
 
-	for _, p := range form.Poll.Options {
+	for _, p := range poll.Options {
 		if length := len([]rune(p)); length > maxPollChars {
 			return fmt.Errorf("poll option too long, %d characters provided but limit is %d", length, maxPollChars)
 		}
@@ -28,6 +28,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
@@ -433,6 +434,50 @@ func (suite *StatusCreateTestSuite) TestPostNewStatusWithNoncanonicalLanguageTag
 }
 
 // Post a new status with an attached poll.
+func (suite *StatusCreateTestSuite) TestPostNewScheduledStatus() {
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := testrig.CreateGinTestContext(recorder, nil)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", statuses.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status":       {"this is a brand new status, but later!"},
+		"visibility":   {string(apimodel.VisibilityPublic)},
+		"scheduled_at": {time.Now().Add(time.Hour).Format(time.RFC3339)},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := io.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// We should get a scheduled status back, not a status.
+	scheduledStatus := &apimodel.ScheduledStatus{}
+	err = json.Unmarshal(b, scheduledStatus)
+	suite.NoError(err)
+
+	suite.NotEmpty(scheduledStatus.ID)
+	suite.NotEmpty(scheduledStatus.ScheduledAt)
+	suite.Equal("this is a brand new status, but later!", scheduledStatus.Params.Text)
+	suite.Equal("public", scheduledStatus.Params.Visibility)
+
+	// The status shouldn't exist yet.
+	_, err = suite.db.GetScheduledStatusByID(context.Background(), scheduledStatus.ID)
+	suite.NoError(err)
+	_, err = suite.db.GetStatusByID(context.Background(), scheduledStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
 func (suite *StatusCreateTestSuite) testPostNewStatusWithPoll(configure func(request *http.Request)) {
 	t := suite.testTokens["local_account_1"]
 	oauthToken := oauth.DBTokenToToken(t)
@@ -0,0 +1,139 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package statuses_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/statuses"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) editStatus(
+	accountFixtureName string,
+	targetStatusID string,
+	form url.Values,
+) (*httptest.ResponseRecorder, *apimodel.Status) {
+	var (
+		testApplication = suite.testApplications["application_1"]
+		testAccount     = suite.testAccounts[accountFixtureName]
+		testUser        = suite.testUsers[accountFixtureName]
+		testToken       = oauth.DBTokenToToken(suite.testTokens[accountFixtureName])
+		target          = fmt.Sprintf("http://localhost:8080%s", strings.ReplaceAll(statuses.BasePathWithID, ":id", targetStatusID))
+	)
+
+	// Setup request.
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest(http.MethodPut, target, nil)
+	request.Header.Set("accept", "application/json")
+	request.Form = form
+	ctx, _ := testrig.CreateGinTestContext(recorder, request)
+
+	// Set auth + path params.
+	ctx.Set(oauth.SessionAuthorizedApplication, testApplication)
+	ctx.Set(oauth.SessionAuthorizedToken, testToken)
+	ctx.Set(oauth.SessionAuthorizedUser, testUser)
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   statuses.IDKey,
+			Value: targetStatusID,
+		},
+	}
+
+	// Call the handler.
+	suite.statusModule.StatusEditPUTHandler(ctx)
+
+	if recorder.Code != http.StatusOK {
+		return recorder, nil
+	}
+
+	// Read body.
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := io.ReadAll(result.Body)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiStatus := new(apimodel.Status)
+	if err := json.Unmarshal(b, apiStatus); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return recorder, apiStatus
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	recorder, apiStatus := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status":       {"hello everyone! #edited"},
+		"spoiler_text": {"introduction post (edited)"},
+		"sensitive":    {"true"},
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	suite.Equal("<p>hello everyone! <a href=\"http://localhost:8080/tags/edited\" class=\"mention hashtag\" rel=\"tag nofollow noreferrer noopener\" target=\"_blank\">#<span>edited</span></a></p>", apiStatus.Content)
+	suite.Equal("introduction post (edited)", apiStatus.SpoilerText)
+	suite.True(apiStatus.Sensitive)
+	suite.NotNil(apiStatus.EditedAt)
+	suite.Len(apiStatus.Tags, 1)
+
+	// Visibility is carried over from the original.
+	suite.Equal(apimodel.VisibilityPublic, apiStatus.Visibility)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNoContent() {
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	recorder, _ := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"spoiler_text": {"just a content warning"},
+	})
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"Bad Request: no status, media, or poll provided"}`, recorder.Body.String())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	targetStatus := suite.testStatuses["local_account_2_status_1"]
+
+	recorder, _ := suite.editStatus("local_account_1", targetStatus.ID, url.Values{
+		"status": {"this isn't my status"},
+	})
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
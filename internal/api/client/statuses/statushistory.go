@@ -30,7 +30,7 @@ import (
 //
 // View edit history of status with the given ID.
 //
-// UNIMPLEMENTED: Currently this endpoint will always return an array of length 1, containing only the latest/current version of the status.
+// Edits are returned oldest first, with the latest/current version of the status as the final entry.
 //
 //	---
 //	tags:
@@ -109,6 +109,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
   "pinned": false,
   "content": "hello everyone!",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "really cool gts application",
     "website": "https://reallycool.app"
@@ -121,6 +122,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
     "url": "http://localhost:8080/@the_mighty_zork",
@@ -194,6 +196,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
   "pinned": false,
   "content": "hello everyone!",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "really cool gts application",
     "website": "https://reallycool.app"
@@ -206,6 +209,7 @@ func (suite *StatusMuteTestSuite) TestMuteUnmuteStatus() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
     "url": "http://localhost:8080/@the_mighty_zork",
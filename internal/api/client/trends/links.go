@@ -0,0 +1,98 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TrendsLinksGETHandler swagger:operation GET /api/v1/trends/links trendsLinksGet
+//
+// Get links that are currently being shared on this instance, most trending first.
+//
+// Trends are computed periodically from local and federated activity
+// over the past week, and are only shown once approved by an admin.
+//
+//	---
+//	tags:
+//	- trends
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of results to return.
+//		default: 10
+//		maximum: 20
+//		minimum: 1
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		in: query
+//
+//	responses:
+//		'200':
+//			description: Trending links, each with its daily usage history over the past week.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/trendsLink"
+//		'400':
+//			description: bad request
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsLinksGETHandler(c *gin.Context) {
+	// Trends are public, so
+	// auth is not required.
+	if _, err := oauth.Authed(c, false, false, false, false); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, offset, errWithCode := parsePaging(c, 10, 20)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Trends().LinksGet(c.Request.Context(), limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
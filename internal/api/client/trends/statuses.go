@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// TrendsStatusesGETHandler swagger:operation GET /api/v1/trends/statuses trendsStatusesGet
+//
+// Get statuses that are currently trending on this instance, most trending first.
+//
+// Trends are computed periodically from local and federated activity
+// over the past week, and are only shown once approved by an admin.
+//
+//	---
+//	tags:
+//	- trends
+//
+//	produces:
+//	- application/json
+//
+//	parameters:
+//	-
+//		name: limit
+//		type: integer
+//		description: Maximum number of results to return.
+//		default: 20
+//		maximum: 40
+//		minimum: 1
+//		in: query
+//	-
+//		name: offset
+//		type: integer
+//		description: Skip the first n results.
+//		default: 0
+//		in: query
+//
+//	responses:
+//		'200':
+//			description: Trending statuses.
+//			schema:
+//				type: array
+//				items:
+//					"$ref": "#/definitions/status"
+//		'400':
+//			description: bad request
+//		'406':
+//			description: not acceptable
+//		'500':
+//			description: internal server error
+func (m *Module) TrendsStatusesGETHandler(c *gin.Context) {
+	// Trends are public, but check if we can
+	// extract authentication anyway, to use it
+	// for visibility checks and filtering.
+	authed, err := oauth.Authed(c, false, false, false, false)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	limit, offset, errWithCode := parsePaging(c, 20, 40)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	resp, errWithCode := m.processor.Trends().StatusesGet(c.Request.Context(), authed.Account, limit, offset)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, resp)
+}
@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+)
+
+const (
+	BasePath     = "/v1/trends"
+	TagsPath     = BasePath + "/tags"
+	StatusesPath = BasePath + "/statuses"
+	LinksPath    = BasePath + "/links"
+)
+
+type Module struct {
+	processor *processing.Processor
+}
+
+func New(processor *processing.Processor) *Module {
+	return &Module{
+		processor: processor,
+	}
+}
+
+func (m *Module) Route(attachHandler func(method string, path string, f ...gin.HandlerFunc) gin.IRoutes) {
+	// Bare trends path is an
+	// alias for trending tags.
+	attachHandler(http.MethodGet, BasePath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, TagsPath, m.TrendsTagsGETHandler)
+	attachHandler(http.MethodGet, StatusesPath, m.TrendsStatusesGETHandler)
+	attachHandler(http.MethodGet, LinksPath, m.TrendsLinksGETHandler)
+}
+
+// parsePaging parses the limit and offset
+// query parameters common to all trends.
+func parsePaging(c *gin.Context, defaultLimit int, maxLimit int) (int, int, gtserror.WithCode) {
+	limit, errWithCode := apiutil.ParseLimit(c.Query(apiutil.LimitKey), defaultLimit, maxLimit, 1)
+	if errWithCode != nil {
+		return 0, 0, errWithCode
+	}
+
+	offset, errWithCode := apiutil.ParseTrendsOffset(c.Query(apiutil.TrendsOffsetKey), 0, 1000, 0)
+	if errWithCode != nil {
+		return 0, 0, errWithCode
+	}
+
+	return limit, offset, nil
+}
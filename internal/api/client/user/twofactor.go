@@ -0,0 +1,277 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const OIDCTwoFactorHelp = "two-factor authentication cannot be managed by GoToSocial as this instance is running with OIDC enabled; you must configure two-factor authentication with your OIDC provider"
+
+// TwoFactorSetupPOSTHandler swagger:operation POST /api/v1/user/2fa/setup userTwoFactorSetup
+//
+// Begin two-factor authentication enrolment for authenticated user.
+//
+// Returns a TOTP secret and otpauth:// provisioning URI to add to an authenticator app.
+// The same secret is returned on subsequent calls until enrolment is completed or cancelled.
+// Two-factor authentication is not enabled until confirmed with a valid code via /api/v1/user/2fa/enable.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:user
+//
+//	responses:
+//		'200':
+//			description: TOTP secret and provisioning URI.
+//			schema:
+//				"$ref": "#/definitions/twoFactorSetup"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password was incorrect
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: two-factor authentication is already enabled
+//		'422':
+//			description: unprocessable request because instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorSetupPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("instance running with OIDC")
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, OIDCTwoFactorHelp), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorSetupRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("two-factor setup request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	setup, errWithCode := m.processor.User().TwoFactorSetup(c.Request.Context(), authed.User, form.Password)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, setup)
+}
+
+// TwoFactorEnablePOSTHandler swagger:operation POST /api/v1/user/2fa/enable userTwoFactorEnable
+//
+// Enable two-factor authentication for authenticated user.
+//
+// Requires the user's current password, and a valid code for the secret returned by /api/v1/user/2fa/setup.
+// Returns single-use recovery codes, which will not be shown again.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:user
+//
+//	responses:
+//		'200':
+//			description: Two-factor authentication enabled.
+//			schema:
+//				"$ref": "#/definitions/twoFactorRecoveryCodes"
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password or code was incorrect
+//		'406':
+//			description: not acceptable
+//		'409':
+//			description: two-factor authentication is already enabled
+//		'422':
+//			description: unprocessable request because setup was not started, or instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorEnablePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("instance running with OIDC")
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, OIDCTwoFactorHelp), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorEnableRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("two-factor enable request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Code == "" {
+		err := errors.New("two-factor enable request missing field code")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	recoveryCodes, errWithCode := m.processor.User().TwoFactorEnable(c.Request.Context(), authed.User, form.Password, form.Code)
+	if errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.JSON(c, http.StatusOK, recoveryCodes)
+}
+
+// TwoFactorDisablePOSTHandler swagger:operation POST /api/v1/user/2fa/disable userTwoFactorDisable
+//
+// Disable two-factor authentication for authenticated user, or cancel enrolment in progress.
+//
+// The parameters can also be given in the body of the request, as JSON, if the content-type is set to 'application/json'.
+// The parameters can also be given in the body of the request, as XML, if the content-type is set to 'application/xml'.
+//
+//	---
+//	tags:
+//	- user
+//
+//	consumes:
+//	- application/json
+//	- application/xml
+//	- application/x-www-form-urlencoded
+//
+//	produces:
+//	- application/json
+//
+//	security:
+//	- OAuth2 Bearer:
+//		- write:user
+//
+//	responses:
+//		'200':
+//			description: Two-factor authentication disabled.
+//		'400':
+//			description: bad request
+//		'401':
+//			description: unauthorized, or password was incorrect
+//		'406':
+//			description: not acceptable
+//		'422':
+//			description: unprocessable request because two-factor authentication is not enabled, or instance is running with OIDC backend
+//		'500':
+//			description: internal error
+func (m *Module) TwoFactorDisablePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnauthorized(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if _, err := apiutil.NegotiateAccept(c, apiutil.JSONAcceptHeaders...); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorNotAcceptable(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if config.GetOIDCEnabled() {
+		err := errors.New("instance running with OIDC")
+		apiutil.ErrorHandler(c, gtserror.NewErrorUnprocessableEntity(err, OIDCTwoFactorHelp), m.processor.InstanceGetV1)
+		return
+	}
+
+	form := &apimodel.TwoFactorDisableRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if form.Password == "" {
+		err := errors.New("two-factor disable request missing field password")
+		apiutil.ErrorHandler(c, gtserror.NewErrorBadRequest(err, err.Error()), m.processor.InstanceGetV1)
+		return
+	}
+
+	if errWithCode := m.processor.User().TwoFactorDisable(c.Request.Context(), authed.User, form.Password); errWithCode != nil {
+		apiutil.ErrorHandler(c, errWithCode, m.processor.InstanceGetV1)
+		return
+	}
+
+	apiutil.Data(c, http.StatusOK, apiutil.AppJSON, apiutil.StatusOKJSON)
+}
@@ -31,6 +31,12 @@ const (
 	PasswordChangePath = BasePath + "/password_change"
 	// EmailChangePath is the path for POSTing an email address change request.
 	EmailChangePath = BasePath + "/email_change"
+	// TwoFactorSetupPath is the path for POSTing a request for a TOTP secret for two-factor auth enrolment.
+	TwoFactorSetupPath = BasePath + "/2fa/setup"
+	// TwoFactorEnablePath is the path for POSTing a TOTP code to enable two-factor auth.
+	TwoFactorEnablePath = BasePath + "/2fa/enable"
+	// TwoFactorDisablePath is the path for POSTing a request to disable two-factor auth.
+	TwoFactorDisablePath = BasePath + "/2fa/disable"
 )
 
 type Module struct {
@@ -47,4 +53,7 @@ func (m *Module) Route(attachHandler func(method string, path string, f ...gin.H
 	attachHandler(http.MethodGet, BasePath, m.UserGETHandler)
 	attachHandler(http.MethodPost, PasswordChangePath, m.PasswordChangePOSTHandler)
 	attachHandler(http.MethodPost, EmailChangePath, m.EmailChangePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorSetupPath, m.TwoFactorSetupPOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorEnablePath, m.TwoFactorEnablePOSTHandler)
+	attachHandler(http.MethodPost, TwoFactorDisablePath, m.TwoFactorDisablePOSTHandler)
 }
@@ -50,6 +50,8 @@ type Account struct {
 	Discoverable bool `json:"discoverable"`
 	// Account identifies as a bot.
 	Bot bool `json:"bot"`
+	// Account is a group actor.
+	Group bool `json:"group"`
 	// When the account was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
@@ -64,6 +64,8 @@ type AdminAccountInfo struct {
 	Disabled bool `json:"disabled"`
 	// Whether the account is currently silenced
 	Silenced bool `json:"silenced"`
+	// Whether the account's media is currently force-marked as sensitive.
+	Sensitized bool `json:"sensitized"`
 	// Whether the account is currently suspended.
 	Suspended bool `json:"suspended"`
 	// User-level information about the account.
@@ -84,6 +86,10 @@ type AdminReport struct {
 	// Whether an action has been taken by an admin in response to this report.
 	// example: false
 	ActionTaken bool `json:"action_taken"`
+	// Where this report is in the moderation workflow.
+	// One of open, assigned, resolved, reopened.
+	// example: assigned
+	State string `json:"state"`
 	// If an action was taken, at what time was this done? (ISO 8601 Datetime)
 	// Will be null if not set / no action yet taken.
 	// example: 2021-07-30T09:20:25+00:00
@@ -98,6 +104,14 @@ type AdminReport struct {
 	// Bool to indicate that report should be federated to remote instance.
 	// example: true
 	Forwarded bool `json:"forwarded"`
+	// Domains of remote instances that the report was successfully delivered to.
+	// Will be empty if the report was not (yet) delivered anywhere.
+	// example: ["example.org"]
+	ForwardedDomains []string `json:"forwarded_domains"`
+	// Time at which the report was last successfully delivered to a remote instance (ISO 8601 Datetime).
+	// Will be null if the report was not (yet) delivered anywhere.
+	// example: 2021-07-30T09:20:25+00:00
+	ForwardedAt *string `json:"forwarded_at"`
 	// The date when this report was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
@@ -132,6 +146,66 @@ type AdminReport struct {
 type AdminReportResolveRequest struct {
 	// Comment to show to the creator of the report when an admin marks it as resolved.
 	ActionTakenComment *string `form:"action_taken_comment" json:"action_taken_comment" xml:"action_taken_comment"`
+	// Type of admin action to take against the reported account
+	// when resolving the report. One of silence, suspend, sensitive.
+	AccountAction string `form:"account_action" json:"account_action" xml:"account_action"`
+	// Delete the statuses attached to the report when resolving it.
+	DeleteStatuses bool `form:"delete_statuses" json:"delete_statuses" xml:"delete_statuses"`
+}
+
+// AdminReportForwardRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/forward
+//
+// swagger:ignore
+type AdminReportForwardRequest struct {
+	// Additional domains to forward the report to, alongside the
+	// instance of the reported account. Each must be the domain of
+	// the author of a status that a reported status replies to.
+	ForwardToDomains []string `form:"forward_to_domains[]" json:"forward_to_domains" xml:"forward_to_domains"`
+}
+
+// AdminReportAssignRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/assign
+//
+// swagger:ignore
+type AdminReportAssignRequest struct {
+	// ID of the moderator account to assign the report to.
+	// If not set, the report is assigned to the requesting account.
+	AccountID string `form:"account_id" json:"account_id" xml:"account_id"`
+}
+
+// AdminReportNote models an internal note left
+// on a report, visible only to other moderators.
+//
+// swagger:model adminReportNote
+type AdminReportNote struct {
+	// ID of the note.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// The date when this note was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// ID of the report this note was left on.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ReportID string `json:"report_id"`
+	// The moderator account that wrote the note.
+	// Null if the account no longer exists.
+	Account *AdminAccountInfo `json:"account"`
+	// ID of the note on the same report that this note replies to.
+	// Null if this note is not a reply.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	InReplyToID *string `json:"in_reply_to_id"`
+	// Plaintext content of the note.
+	// example: I've reached out to their admin about this.
+	Content string `json:"content"`
+}
+
+// AdminReportNoteCreateRequest can be submitted along with a POST to /api/v1/admin/reports/{id}/notes
+//
+// swagger:ignore
+type AdminReportNoteCreateRequest struct {
+	// Plaintext content of the note.
+	Content string `form:"content" json:"content" xml:"content"`
+	// ID of the note on the same report to reply to, if any.
+	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
 }
 
 // AdminEmoji models the admin view of a custom emoji.
@@ -90,6 +90,12 @@ type Attachment struct {
 	// A hash computed by the BlurHash algorithm, for generating colorful preview thumbnails when media has not been downloaded yet.
 	// See https://github.com/woltapp/blurhash
 	Blurhash *string `json:"blurhash"`
+
+	// Attachment is still being processed
+	// (eg., transcoded), so the full-size
+	// file is not yet available. Not
+	// serialized, used to set status code.
+	Processing bool `json:"-"`
 }
 
 // WebAttachment is like Attachment, but with
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminAuditLogEntry models one change made
+// by an admin or moderator via the admin API.
+//
+// swagger:model adminAuditLogEntry
+type AdminAuditLogEntry struct {
+	// The ID of the audit log entry.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Time when the action was performed (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The admin or moderator account that performed the action.
+	// Omitted if the account no longer exists.
+	Account *Account `json:"account,omitempty"`
+	// The action that was performed.
+	// example: update
+	Action string `json:"action"`
+	// The type of entity targeted by the action.
+	// example: emoji
+	TargetType string `json:"target_type"`
+	// Identifier of the targeted entity. Usually an ID,
+	// but may be a domain name for actions on domains.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	TargetID string `json:"target_id,omitempty"`
+	// Snapshot of the target before the action was performed,
+	// in the same format as the target's admin API representation.
+	// Omitted if the target didn't exist before the action.
+	Before map[string]any `json:"before,omitempty"`
+	// Snapshot of the target after the action was performed,
+	// in the same format as the target's admin API representation.
+	// For actions performed asynchronously, this contains the
+	// parameters of the action instead. Omitted if the target
+	// doesn't exist after the action.
+	After map[string]any `json:"after,omitempty"`
+	// Top-level keys of the target that differ
+	// between the before and after snapshots.
+	Changes []string `json:"changes"`
+}
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// DomainPermissionSubscription represents an auto-refreshing subscription to a list of domain permissions (allows, blocks).
+//
+// swagger:model domainPermissionSubscription
+type DomainPermissionSubscription struct {
+	// The ID of the domain permission subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	ID string `json:"id"`
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	// example: 100
+	Priority uint8 `json:"priority"`
+	// Title of this subscription, as set by admin who created or updated it.
+	// example: really cool list of neato pals
+	Title string `json:"title"`
+	// The type of domain permission subscription (allow, block).
+	// example: block
+	PermissionType string `json:"permission_type"`
+	// If true, domain permissions arising from this subscription will be created as drafts that must be approved by a moderator to take effect.
+	// If false, domain permissions from this subscription will come into force immediately.
+	// example: true
+	AsDraft bool `json:"as_draft"`
+	// ID of the account that created this subscription.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	// readonly: true
+	CreatedBy string `json:"created_by"`
+	// Time at which the subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	CreatedAt string `json:"created_at"`
+	// URI to call in order to fetch the permissions list.
+	// example: https://www.example.org/blocklists/list1.csv
+	URI string `json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	// example: text/csv
+	ContentType string `json:"content_type"`
+	// Time of the most recent fetch attempt (successful or otherwise) (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	FetchedAt string `json:"fetched_at,omitempty"`
+	// Time of the most recent successful fetch (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	// readonly: true
+	SuccessfullyFetchedAt string `json:"successfully_fetched_at,omitempty"`
+	// If most recent fetch attempt failed, this field will contain an error message related to the fetch attempt.
+	// example: Oopsie doopsie, we made a fucky wucky.
+	// readonly: true
+	Error string `json:"error,omitempty"`
+}
+
+// DomainPermissionSubscriptionRequest is the form submitted as a POST to create, or PATCH to update, a domain permission subscription.
+//
+// swagger:ignore
+type DomainPermissionSubscriptionRequest struct {
+	// Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Priority *int `form:"priority" json:"priority"`
+	// Optional title for this subscription.
+	Title *string `form:"title" json:"title"`
+	// URI to call in order to fetch the permissions list.
+	URI *string `form:"uri" json:"uri"`
+	// MIME content type to use when parsing the permissions list.
+	ContentType *string `form:"content_type" json:"content_type"`
+	// If true, domain permissions arising from this subscription will be created as drafts.
+	AsDraft *bool `form:"as_draft" json:"as_draft"`
+	// Type of permissions to create by parsing the targeted list (allow, block).
+	PermissionType *string `form:"permission_type" json:"permission_type"`
+}
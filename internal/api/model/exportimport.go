@@ -61,11 +61,82 @@ type AccountExportStats struct {
 	MutesCount int `json:"mutes_count"`
 }
 
+// Archive models a personal archive of an account's data,
+// as requested at the /api/v1/exports/archive endpoint.
+//
+// swagger:model archive
+type Archive struct {
+	// The ID of the archive.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+
+	// Time when the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// Archive has finished generating, and can be downloaded.
+	Processed bool `json:"processed"`
+
+	// Time when the archive finished generating (ISO 8601 Datetime), if processed.
+	// example: 2021-07-30T09:30:25+00:00
+	ProcessedAt *string `json:"processed_at"`
+
+	// Size of the archive zip file in bytes, if processed.
+	// example: 104857600
+	Size int `json:"size"`
+}
+
+// Import models the progress of a data import,
+// as requested at the /api/v1/import endpoint.
+//
+// swagger:model import
+type Import struct {
+	// The ID of the import.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+
+	// Time when the import was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+
+	// Type of data being imported.
+	// example: outbox
+	Type string `json:"type"`
+
+	// Total number of items found in the uploaded file.
+	// example: 1200
+	Total int `json:"total"`
+
+	// Number of items imported so far.
+	// example: 1100
+	Imported int `json:"imported"`
+
+	// Number of items deliberately not imported,
+	// eg., followers-only posts, or boosts.
+	// example: 90
+	Skipped int `json:"skipped"`
+
+	// Number of items that could not be imported because of an error.
+	// example: 10
+	Failed int `json:"failed"`
+
+	// Descriptions of items that could not be imported,
+	// and why. Only the first 100 failures are included.
+	Failures []string `json:"failures"`
+
+	// Import has finished, and all items have been processed.
+	Finished bool `json:"finished"`
+
+	// Time when the import finished (ISO 8601 Datetime), if finished.
+	// example: 2021-07-30T09:30:25+00:00
+	FinishedAt *string `json:"finished_at"`
+}
+
 // AttachmentRequest models media attachment creation parameters.
 //
 // swagger: ignore
 type ImportRequest struct {
-	// The CSV data to upload.
+	// The CSV data, or archive zip, to upload.
 	Data *multipart.FileHeader `form:"data" binding:"required"`
 	// Type of entries contained in the data file.
 	//
@@ -74,6 +145,7 @@ type ImportRequest struct {
 	//	- `blocks` - accounts to block.
 	//	- `mutes` - accounts to mute.
 	//	- `bookmarks` - statuses to bookmark.
+	//	- `outbox` - statuses to recreate from an archive.
 	Type string `form:"type" binding:"required"`
 	// Mode to use when creating entries from the data file:
 	//	- `merge` to merge entries in file with existing entries.
@@ -172,6 +172,17 @@ type InstanceV2Configuration struct {
 	Emojis InstanceConfigurationEmojis `json:"emojis"`
 	// True if instance is running with OIDC as auth/identity backend, else omitted.
 	OIDCEnabled bool `json:"oidc_enabled,omitempty"`
+	// Instance configuration pertaining to Web Push.
+	VAPID InstanceV2ConfigurationVAPID `json:"vapid"`
+}
+
+// Instance configuration pertaining to Web Push.
+//
+// swagger:model instanceV2ConfigurationVAPID
+type InstanceV2ConfigurationVAPID struct {
+	// The instance's VAPID public key, used by clients to create Web Push subscriptions.
+	// example: BFj4RztYkjHuGHUo53qInqpxqyxGi8Q3neV2_XmVgfB1RfULuWk3CWk9V1nsTwmV9149yOy7XM27YiaO_n9xxnk
+	PublicKey string `json:"public_key"`
 }
 
 // Information about registering for this instance.
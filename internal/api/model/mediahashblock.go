@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminMediaHashBlock represents a block on media
+// with a perceptual hash near to the given hash.
+//
+// swagger:model adminMediaHashBlock
+type AdminMediaHashBlock struct {
+	// The ID of the media hash block.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Time this media hash block was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time this media hash block was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// Hex-encoded 64-bit perceptual hash (dHash) of the blocked media.
+	// example: 3c3e0e1a3a1e1c1c
+	Hash string `json:"hash"`
+	// Maximum hamming distance (number of differing bits)
+	// from hash at which media is considered a match.
+	// example: 4
+	Threshold int `json:"threshold"`
+	// Comment on why this hash is blocked.
+	// example: known spam image
+	Comment string `json:"comment"`
+	// ID of the account that created this block.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	CreatedBy string `json:"created_by"`
+}
+
+// AdminMediaHashBlockCreateRequest is the form submitted to create a media hash block.
+//
+// swagger:ignore
+type AdminMediaHashBlockCreateRequest struct {
+	// Hex-encoded 64-bit perceptual hash to block.
+	Hash string `form:"hash" json:"hash"`
+	// ID of a media attachment whose hash should be blocked, instead of hash.
+	MediaID string `form:"media_id" json:"media_id"`
+	// Maximum hamming distance from hash at which media is considered a match.
+	Threshold *int `form:"threshold" json:"threshold"`
+	// Comment on why this hash is blocked.
+	Comment string `form:"comment" json:"comment"`
+}
@@ -17,22 +17,31 @@
 
 package model
 
-// PushSubscription represents a subscription to the push streaming server.
+// PushSubscription represents a subscription to a Web Push server.
+//
+// swagger:model webPushSubscription
 type PushSubscription struct {
 	// The id of the push subscription in the database.
 	ID string `json:"id"`
 	// Where push alerts will be sent to.
 	Endpoint string `json:"endpoint"`
-	// The streaming server's VAPID key.
+	// The streaming server's VAPID public key.
 	ServerKey string `json:"server_key"`
 	// Which alerts should be delivered to the endpoint.
-	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	Alerts PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications.
+	// example: all
+	Policy string `json:"policy"`
 }
 
 // PushSubscriptionAlerts represents the specific alerts that this push subscription will give.
+//
+// swagger:model webPushSubscriptionAlerts
 type PushSubscriptionAlerts struct {
 	// Receive a push notification when someone has followed you?
 	Follow bool `json:"follow"`
+	// Receive a push notification when someone has requested to follow you?
+	FollowRequest bool `json:"follow_request"`
 	// Receive a push notification when a status you created has been favourited by someone else?
 	Favourite bool `json:"favourite"`
 	// Receive a push notification when someone else has mentioned you in a status?
@@ -41,4 +50,108 @@ type PushSubscriptionAlerts struct {
 	Reblog bool `json:"reblog"`
 	// Receive a push notification when a poll you voted in or created has ended?
 	Poll bool `json:"poll"`
+	// Receive a push notification when a subscribed account posts a status?
+	Status bool `json:"status"`
+	// Receive a push notification when a new user has signed up?
+	AdminSignup bool `json:"admin.sign_up"`
+	// Receive a push notification when a fave is pending?
+	PendingFavourite bool `json:"pending.favourite"`
+	// Receive a push notification when a reply is pending?
+	PendingReply bool `json:"pending.reply"`
+	// Receive a push notification when a boost is pending?
+	PendingReblog bool `json:"pending.reblog"`
+}
+
+// PushSubscriptionCreateRequest captures params for creating or replacing a push subscription.
+//
+// JSON requests use the nested Subscription and Data fields, while form requests
+// use the flattened fields, which are normalized into the nested ones by the handler.
+//
+// swagger:ignore
+type PushSubscriptionCreateRequest struct {
+	Subscription *PushSubscriptionRequestSubscription `form:"-" json:"subscription"`
+
+	SubscriptionEndpoint   *string `form:"subscription[endpoint]" json:"-"`
+	SubscriptionKeysAuth   *string `form:"subscription[keys][auth]" json:"-"`
+	SubscriptionKeysP256dh *string `form:"subscription[keys][p256dh]" json:"-"`
+
+	PushSubscriptionUpdateRequest
+}
+
+// PushSubscriptionRequestSubscription contains the
+// endpoint and keys of a push subscription request.
+//
+// swagger:ignore
+type PushSubscriptionRequestSubscription struct {
+	// Where push alerts will be sent to.
+	Endpoint string `json:"endpoint"`
+	// Keys for encrypting push alerts.
+	Keys PushSubscriptionRequestKeys `json:"keys"`
+}
+
+// PushSubscriptionRequestKeys contains the
+// user agent's encryption keys.
+//
+// swagger:ignore
+type PushSubscriptionRequestKeys struct {
+	// Base64url-encoded auth secret.
+	Auth string `json:"auth"`
+	// Base64url-encoded P-256 ECDH public key.
+	P256dh string `json:"p256dh"`
+}
+
+// PushSubscriptionUpdateRequest captures params for updating a push subscription's alerts and policy.
+//
+// JSON requests use the nested Data field, while form requests use the
+// flattened fields, which are normalized into the nested one by the handler.
+//
+// swagger:ignore
+type PushSubscriptionUpdateRequest struct {
+	Data *PushSubscriptionRequestData `form:"-" json:"data"`
+
+	DataAlertsFollow           *bool   `form:"data[alerts][follow]" json:"-"`
+	DataAlertsFollowRequest    *bool   `form:"data[alerts][follow_request]" json:"-"`
+	DataAlertsFavourite        *bool   `form:"data[alerts][favourite]" json:"-"`
+	DataAlertsMention          *bool   `form:"data[alerts][mention]" json:"-"`
+	DataAlertsReblog           *bool   `form:"data[alerts][reblog]" json:"-"`
+	DataAlertsPoll             *bool   `form:"data[alerts][poll]" json:"-"`
+	DataAlertsStatus           *bool   `form:"data[alerts][status]" json:"-"`
+	DataAlertsAdminSignup      *bool   `form:"data[alerts][admin.sign_up]" json:"-"`
+	DataAlertsPendingFavourite *bool   `form:"data[alerts][pending.favourite]" json:"-"`
+	DataAlertsPendingReply     *bool   `form:"data[alerts][pending.reply]" json:"-"`
+	DataAlertsPendingReblog    *bool   `form:"data[alerts][pending.reblog]" json:"-"`
+	DataPolicy                 *string `form:"data[policy]" json:"-"`
+}
+
+// PushSubscriptionRequestData contains the
+// alerts and policy of a push subscription request.
+//
+// swagger:ignore
+type PushSubscriptionRequestData struct {
+	// Which alerts should be delivered to the endpoint.
+	Alerts *PushSubscriptionAlerts `json:"alerts"`
+	// Which accounts should generate notifications.
+	Policy *string `json:"policy"`
+}
+
+// PushNotification represents the decrypted payload
+// of a Web Push message, in the format used by Mastodon.
+//
+// swagger:ignore
+type PushNotification struct {
+	// Access token of the push subscription,
+	// used by clients to fetch further details.
+	AccessToken string `json:"access_token"`
+	// Preferred locale of the receiving user.
+	PreferredLocale string `json:"preferred_locale"`
+	// ID of the notification.
+	NotificationID string `json:"notification_id"`
+	// Type of the notification.
+	NotificationType string `json:"notification_type"`
+	// URL of the origin account's avatar.
+	Icon string `json:"icon"`
+	// Title of the notification.
+	Title string `json:"title"`
+	// Plain-text body of the notification.
+	Body string `json:"body"`
 }
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRateLimitPolicy models a rate limit
+// policy and the clients throttled by it.
+//
+// swagger:model adminRateLimitPolicy
+type AdminRateLimitPolicy struct {
+	// Name of the policy.
+	// example: search
+	Name string `json:"name"`
+	// Requests permitted per client per rate limit window.
+	// example: 60
+	Limit int `json:"limit"`
+	// Total number of requests rejected
+	// under this policy since startup.
+	// example: 12
+	Throttled uint64 `json:"throttled"`
+	// Clients throttled under this policy,
+	// most recently throttled first.
+	Clients []AdminRateLimitClient `json:"clients"`
+}
+
+// AdminRateLimitClient models one
+// client throttled by a rate limit policy.
+//
+// swagger:model adminRateLimitClient
+type AdminRateLimitClient struct {
+	// What the client was identified by.
+	// enum:
+	//   - account
+	//   - application
+	//   - ip
+	Type string `json:"type"`
+	// ID of the account, client ID of the
+	// application, or (masked) IP address.
+	// example: 192.0.2.0
+	ID string `json:"id"`
+	// Number of requests rejected for this client.
+	// example: 4
+	Throttled uint64 `json:"throttled"`
+	// Time this client was last throttled (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	LastThrottledAt string `json:"last_throttled_at"`
+}
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminRelay represents a subscription to an ActivityPub relay.
+//
+// swagger:model adminRelay
+type AdminRelay struct {
+	// The ID of the relay subscription.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Time this relay subscription was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time this relay subscription was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+	// Inbox URL of the relay.
+	// example: https://relay.example.org/inbox
+	InboxURL string `json:"inbox_url"`
+	// URL of the relay actor, if known.
+	// Set when the relay accepts the subscription.
+	// example: https://relay.example.org/actor
+	ActorURL string `json:"actor_url,omitempty"`
+	// Kind of relay subscribed to.
+	// enum:
+	//   - mastodon
+	//   - litepub
+	Mode string `json:"mode"`
+	// State of the subscription.
+	// enum:
+	//   - pending
+	//   - accepted
+	//   - rejected
+	State string `json:"state"`
+}
+
+// AdminRelayCreateRequest is the form submitted to subscribe to a relay.
+//
+// swagger:ignore
+type AdminRelayCreateRequest struct {
+	// Inbox URL of the relay.
+	InboxURL string `form:"inbox_url" json:"inbox_url"`
+	// Kind of relay: mastodon or litepub.
+	Mode string `form:"mode" json:"mode"`
+}
@@ -82,6 +82,12 @@ type ReportCreateRequest struct {
 	// default: false
 	// in: formData
 	Forward bool `form:"forward" json:"forward" xml:"forward"`
+	// If forward is true, additional domains to forward the report to, alongside the
+	// instance of the reported account. Each must be the domain of the author of a
+	// status that one of the reported statuses replies to, else it is ignored.
+	// Sample: ["example.org"]
+	// in: formData
+	ForwardToDomains []string `form:"forward_to_domains[]" json:"forward_to_domains" xml:"forward_to_domains"`
 	// Specify if the report is due to spam, violation of enumerated instance rules, or some other reason.
 	// Currently only 'other' is supported.
 	// Sample: other
@@ -0,0 +1,67 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// AdminInstanceReputation models the reputation
+// of a remote instance, for admin review.
+//
+// swagger:model adminInstanceReputation
+type AdminInstanceReputation struct {
+	// The ID of the instance.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Domain of the instance.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Effective reputation score of the instance,
+	// taking account of any override set by an admin.
+	// example: 12
+	Reputation int64 `json:"reputation"`
+	// Reputation score of the instance as of the
+	// last reputation computation, ignoring overrides.
+	// example: 12
+	ComputedReputation int64 `json:"computed_reputation"`
+	// Reputation score set manually by an admin, if any.
+	// example: 50
+	Override *int64 `json:"override"`
+	// How inbound federation from this instance is currently
+	// treated, based on its effective reputation score.
+	// enum:
+	//   - normal
+	//   - slow
+	//   - quarantine
+	Federation string `json:"federation"`
+	// Number of messages from this instance caught by the spam filter.
+	// example: 2
+	SpamHits int `json:"spam_hits"`
+	// Time of first contact with this instance (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	FirstContactAt string `json:"first_contact_at"`
+	// Time reputation was last computed (ISO 8601 Datetime), if ever.
+	// example: 2021-07-30T09:20:25+00:00
+	ComputedAt string `json:"computed_at,omitempty"`
+}
+
+// AdminInstanceReputationOverrideRequest models a request
+// to override the reputation score of a remote instance.
+//
+// swagger:ignore
+type AdminInstanceReputationOverrideRequest struct {
+	// Reputation score to set for the instance.
+	Reputation *int64 `form:"reputation" json:"reputation"`
+}
@@ -18,21 +18,70 @@
 package model
 
 // ScheduledStatus represents a status that will be published at a future scheduled date.
+//
+// swagger:model scheduledStatus
 type ScheduledStatus struct {
-	ID               string        `json:"id"`
-	ScheduledAt      string        `json:"scheduled_at"`
-	Params           *StatusParams `json:"params"`
-	MediaAttachments []Attachment  `json:"media_attachments"`
+	// ID of the scheduled status.
+	ID string `json:"id"`
+	// ISO 8601 Datetime at which the status will be published.
+	// example: 2021-07-30T09:20:25.000Z
+	ScheduledAt string `json:"scheduled_at"`
+	// Parameters that will be used to publish the status.
+	Params *StatusParams `json:"params"`
+	// Media that will be attached when the status is published.
+	MediaAttachments []Attachment `json:"media_attachments"`
 }
 
 // StatusParams represents parameters for a scheduled status.
+//
+// swagger:model scheduledStatusParams
 type StatusParams struct {
-	Text          string   `json:"text"`
-	InReplyToID   string   `json:"in_reply_to_id,omitempty"`
-	MediaIDs      []string `json:"media_ids,omitempty"`
-	Sensitive     bool     `json:"sensitive,omitempty"`
-	SpoilerText   string   `json:"spoiler_text,omitempty"`
-	Visibility    string   `json:"visibility"`
-	ScheduledAt   string   `json:"scheduled_at,omitempty"`
-	ApplicationID string   `json:"application_id"`
+	// Text content of the status.
+	Text string `json:"text"`
+	// ID of the status being replied to, if status is a reply.
+	InReplyToID string `json:"in_reply_to_id,omitempty"`
+	// ID of the status being quoted, if status is a quote.
+	QuotedStatusID string `json:"quoted_status_id,omitempty"`
+	// IDs of media attachments that will be attached to the status.
+	MediaIDs []string `json:"media_ids,omitempty"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `json:"sensitive,omitempty"`
+	// Text to be shown as a warning or subject before the actual content.
+	SpoilerText string `json:"spoiler_text,omitempty"`
+	// Visibility of the status.
+	Visibility string `json:"visibility"`
+	// Status should not be federated.
+	LocalOnly bool `json:"local_only"`
+	// ISO 639 language code for the status.
+	Language string `json:"language,omitempty"`
+	// Poll that will be attached to the status.
+	Poll *StatusParamsPoll `json:"poll,omitempty"`
+	// ISO 8601 Datetime at which the status will be published.
+	ScheduledAt string `json:"scheduled_at,omitempty"`
+	// ID of the application used to schedule the status.
+	ApplicationID string `json:"application_id"`
+}
+
+// StatusParamsPoll represents the poll parameters of a scheduled status.
+//
+// swagger:model scheduledStatusParamsPoll
+type StatusParamsPoll struct {
+	// Array of possible answers.
+	Options []string `json:"options"`
+	// Duration the poll will be open, in seconds, from when the status is published.
+	ExpiresIn int `json:"expires_in"`
+	// Poll allows multiple choices.
+	Multiple bool `json:"multiple"`
+	// Poll hides vote counts until it ends.
+	HideTotals bool `json:"hide_totals"`
+}
+
+// ScheduledStatusUpdateRequest models a request
+// to move a scheduled status to a new time.
+//
+// swagger:ignore
+type ScheduledStatusUpdateRequest struct {
+	// ISO 8601 Datetime at which the status should be published.
+	// Must be at least 5 minutes in the future.
+	ScheduledAt string `form:"scheduled_at" json:"scheduled_at" xml:"scheduled_at"`
 }
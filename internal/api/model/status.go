@@ -29,6 +29,10 @@ type Status struct {
 	// The date when this status was created (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	CreatedAt string `json:"created_at"`
+	// The date when this status was last edited (ISO 8601 Datetime).
+	// Omitted if the status has never been edited.
+	// example: 2021-07-30T09:20:25+00:00
+	EditedAt *string `json:"edited_at,omitempty"`
 	// ID of the status being replied to.
 	// example: 01FBVD42CQ3ZEEVMW180SBX03B
 	// nullable: true
@@ -80,6 +84,9 @@ type Status struct {
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog"`
+	// The status that this status quotes, if visible to the requester.
+	// nullable: true
+	Quote *StatusQuoted `json:"quote"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application,omitempty"`
 	// The account that authored this status.
@@ -189,6 +196,13 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuoted represents a quoted status.
+//
+// swagger:model statusQuoted
+type StatusQuoted struct {
+	*Status
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:ignore
@@ -204,6 +218,8 @@ type StatusCreateRequest struct {
 	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
 	// ID of the status being replied to, if status is a reply.
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	QuotedStatusID string `form:"quoted_status_id" json:"quoted_status_id" xml:"quoted_status_id"`
 	// Status and attached media should be marked as sensitive.
 	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
@@ -225,6 +241,47 @@ type StatusCreateRequest struct {
 	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
 }
 
+// StatusEditRequest models status edit parameters.
+//
+// swagger:ignore
+type StatusEditRequest struct {
+	// Text content of the status.
+	// If media_ids is provided, this becomes optional.
+	// Attaching a poll is optional while status is provided.
+	Status string `form:"status" json:"status" xml:"status"`
+	// Array of Attachment ids to be attached as media.
+	// If provided, status becomes optional, and poll cannot be used.
+	MediaIDs []string `form:"media_ids[]" json:"media_ids" xml:"media_ids"`
+	// Updated descriptions and focus points of attached media.
+	// Only parsed from JSON request bodies.
+	MediaAttributes []AttachmentAttributesRequest `form:"-" json:"media_attributes" xml:"media_attributes"`
+	// Poll to include with this status.
+	Poll *PollRequest `form:"poll" json:"poll" xml:"poll"`
+	// Status and attached media should be marked as sensitive.
+	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	// Text to be shown as a warning or subject before the actual content.
+	// Statuses are generally collapsed behind this field.
+	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	// ISO 639 language code for this status.
+	Language string `form:"language" json:"language" xml:"language"`
+	// Content type to use when parsing this status.
+	ContentType StatusContentType `form:"content_type" json:"content_type" xml:"content_type"`
+}
+
+// AttachmentAttributesRequest models an edit
+// request for attachment(s) attached to a status.
+//
+// swagger:ignore
+type AttachmentAttributesRequest struct {
+	// ID of the attachment to update.
+	ID string `json:"id" xml:"id"`
+	// Description of the media file.
+	Description string `json:"description" xml:"description"`
+	// Focus of the media file, in the form
+	// of two comma-separated floats.
+	Focus string `json:"focus" xml:"focus"`
+}
+
 // Visibility models the visibility of a status.
 //
 // swagger:enum statusVisibility
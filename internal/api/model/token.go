@@ -33,3 +33,28 @@ type Token struct {
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
 }
+
+// TokenInfo represents an OAuth access token held by an
+// application on behalf of the requesting user. It never
+// contains the secret access token value itself.
+//
+// swagger:model tokenInfo
+type TokenInfo struct {
+	// The ID of the token.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// When the token was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Approximately when the token was last used to
+	// authenticate a request (ISO 8601 Datetime).
+	// Omitted if the token has never been used.
+	// example: 2021-07-30T09:20:25+00:00
+	LastUsed string `json:"last_used,omitempty"`
+	// OAuth scopes granted by this token, space-separated.
+	// example: read write admin
+	Scope string `json:"scope"`
+	// The application that holds this token.
+	// Omitted if the application no longer exists.
+	Application *Application `json:"application,omitempty"`
+}
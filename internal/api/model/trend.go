@@ -0,0 +1,66 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TrendsLink represents a link that is trending on this instance,
+// in the form of a preview card with additional usage history.
+//
+// swagger:model trendsLink
+type TrendsLink struct {
+	Card
+	// Daily history of this link's usage over the past week, newest first.
+	History []History `json:"history"`
+}
+
+// AdminTrend represents a trending tag, status, or
+// link, along with its review state, for admin review.
+//
+// swagger:model adminTrend
+type AdminTrend struct {
+	// The ID of the trend.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	ID string `json:"id"`
+	// Kind of thing that is trending.
+	// enum:
+	//   - tag
+	//   - status
+	//   - link
+	Type string `json:"type"`
+	// Review state of the trend. Only approved trends are shown to users.
+	// enum:
+	//   - pending
+	//   - approved
+	//   - rejected
+	State string `json:"state"`
+	// Trend score as of the last trends computation.
+	// Trends are returned in descending order of score.
+	// example: 12.5
+	Score float64 `json:"score"`
+	// Time this trend was first computed (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time this trend was last reviewed (ISO 8601 Datetime), if ever.
+	// example: 2021-07-30T09:20:25+00:00
+	ReviewedAt string `json:"reviewed_at,omitempty"`
+	// The trending tag, if type is tag.
+	Tag *Tag `json:"tag,omitempty"`
+	// The trending status, if type is status.
+	Status *Status `json:"status,omitempty"`
+	// The trending link, if type is link.
+	Link *TrendsLink `json:"link,omitempty"`
+}
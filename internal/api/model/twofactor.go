@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package model
+
+// TwoFactorSetup contains the details needed to
+// add a user's TOTP secret to an authenticator app.
+//
+// swagger:model twoFactorSetup
+type TwoFactorSetup struct {
+	// Base32-encoded TOTP secret, for manual entry into an authenticator app.
+	// example: JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	Secret string `json:"secret"`
+	// otpauth:// provisioning URI for the TOTP secret, suitable for rendering as a QR code.
+	// example: otpauth://totp/example.org:someone@example.org?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP
+	URI string `json:"uri"`
+}
+
+// TwoFactorRecoveryCodes contains single-use codes that
+// can be used to sign in if a user loses their authenticator.
+//
+// swagger:model twoFactorRecoveryCodes
+type TwoFactorRecoveryCodes struct {
+	// Recovery codes. These are only shown once, so the user should store them somewhere safe.
+	// example: ["c4tq2x7mvk", "w9f3hd2rjp"]
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorSetupRequest models two-factor auth setup parameters.
+//
+// swagger:parameters userTwoFactorSetup
+type TwoFactorSetupRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
+
+// TwoFactorEnableRequest models two-factor auth enable parameters.
+//
+// swagger:parameters userTwoFactorEnable
+type TwoFactorEnableRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+	// Current TOTP code from the user's authenticator app, to confirm enrolment.
+	//
+	// in: formData
+	// required: true
+	Code string `form:"code" json:"code" xml:"code" validation:"required"`
+}
+
+// TwoFactorDisableRequest models two-factor auth disable parameters.
+//
+// swagger:parameters userTwoFactorDisable
+type TwoFactorDisableRequest struct {
+	// User's current password, for verification.
+	//
+	// in: formData
+	// required: true
+	Password string `form:"password" json:"password" xml:"password" validation:"required"`
+}
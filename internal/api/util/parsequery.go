@@ -63,14 +63,25 @@ const (
 
 	TagNameKey = "tag_name"
 
+	/* Trends keys */
+
+	TrendsOffsetKey = "offset"
+
+	/* Instance reputation keys */
+
+	InstanceReputationsOffsetKey = "offset"
+
 	/* Web endpoint keys */
 
 	WebStatusIDKey = "status"
 
 	/* Domain permission keys */
 
-	DomainPermissionExportKey = "export"
-	DomainPermissionImportKey = "import"
+	DomainPermissionExportKey         = "export"
+	DomainPermissionImportKey         = "import"
+	DomainPermissionPermTypeKey       = "permission_type"
+	DomainPermissionSubscriptionIDKey = "subscription_id"
+	DomainPermissionRemoveChildrenKey = "remove_children"
 
 	/* Admin query keys */
 
@@ -92,6 +103,12 @@ const (
 	AdminRoleIDsKey     = "role_ids[]"
 	AdminInvitedByKey   = "invited_by"
 
+	/* Admin audit log keys */
+
+	AuditLogActionKey     = "action"
+	AuditLogTargetTypeKey = "target_type"
+	AuditLogTargetIDKey   = "target_id"
+
 	/* Interaction policy + request keys */
 
 	InteractionStatusIDKey   = "status_id"
@@ -161,6 +178,14 @@ func ParseSearchResolve(value string, defaultValue bool) (bool, gtserror.WithCod
 	return parseBool(value, defaultValue, SearchResolveKey)
 }
 
+func ParseTrendsOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, TrendsOffsetKey)
+}
+
+func ParseInstanceReputationsOffset(value string, defaultValue int, max, min int) (int, gtserror.WithCode) {
+	return parseInt(value, defaultValue, max, min, InstanceReputationsOffsetKey)
+}
+
 func ParseDomainPermissionExport(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, DomainPermissionExportKey)
 }
@@ -169,6 +194,10 @@ func ParseDomainPermissionImport(value string, defaultValue bool) (bool, gtserro
 	return parseBool(value, defaultValue, DomainPermissionImportKey)
 }
 
+func ParseDomainPermissionRemoveChildren(value string, defaultValue bool) (bool, gtserror.WithCode) {
+	return parseBool(value, defaultValue, DomainPermissionRemoveChildrenKey)
+}
+
 func ParseOnlyOtherAccounts(value string, defaultValue bool) (bool, gtserror.WithCode) {
 	return parseBool(value, defaultValue, OnlyOtherAccountsKey)
 }
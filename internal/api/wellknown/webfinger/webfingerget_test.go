@@ -98,6 +98,7 @@ func (suite *WebfingerGetTestSuite) funkifyAccountDomain(host string, accountDom
 		suite.emailSender,
 		visibility.NewFilter(&suite.state),
 		interaction.NewFilter(&suite.state),
+		testrig.NewWebPushSender(nil),
 	)
 
 	suite.webfingerModule = webfinger.New(suite.processor)
@@ -93,9 +93,11 @@ func (c *Caches) Init() {
 	c.initPollVote()
 	c.initPollVoteIDs()
 	c.initReport()
+	c.initScheduledStatus()
 	c.initStatus()
 	c.initStatusBookmark()
 	c.initStatusBookmarkIDs()
+	c.initStatusEdit()
 	c.initStatusFave()
 	c.initStatusFaveIDs()
 	c.initTag()
@@ -106,6 +108,8 @@ func (c *Caches) Init() {
 	c.initUser()
 	c.initUserMute()
 	c.initUserMuteIDs()
+	c.initWebPushSubscription()
+	c.initWebPushSubscriptionIDs()
 	c.initWebfinger()
 	c.initVisibility()
 }
@@ -170,9 +174,11 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.PollVote.Trim(threshold)
 	c.DB.PollVoteIDs.Trim(threshold)
 	c.DB.Report.Trim(threshold)
+	c.DB.ScheduledStatus.Trim(threshold)
 	c.DB.Status.Trim(threshold)
 	c.DB.StatusBookmark.Trim(threshold)
 	c.DB.StatusBookmarkIDs.Trim(threshold)
+	c.DB.StatusEdit.Trim(threshold)
 	c.DB.StatusFave.Trim(threshold)
 	c.DB.StatusFaveIDs.Trim(threshold)
 	c.DB.Tag.Trim(threshold)
@@ -183,6 +189,8 @@ func (c *Caches) Sweep(threshold float64) {
 	c.DB.User.Trim(threshold)
 	c.DB.UserMute.Trim(threshold)
 	c.DB.UserMuteIDs.Trim(threshold)
+	c.DB.WebPushSubscription.Trim(threshold)
+	c.DB.WebPushSubscriptionIDs.Trim(threshold)
 	c.Visibility.Trim(threshold)
 }
 
@@ -18,6 +18,8 @@
 package cache
 
 import (
+	"sync/atomic"
+
 	"codeberg.org/gruf/go-structr"
 	"github.com/superseriousbusiness/gotosocial/internal/cache/domain"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
@@ -124,6 +126,9 @@ type DBCaches struct {
 	// Media provides access to the gtsmodel Media database cache.
 	Media StructCache[*gtsmodel.MediaAttachment]
 
+	// MediaHashBlocks caches all of the server's media hash blocks.
+	MediaHashBlocks atomic.Pointer[[]*gtsmodel.MediaHashBlock]
+
 	// Mention provides access to the gtsmodel Mention database cache.
 	Mention StructCache[*gtsmodel.Mention]
 
@@ -142,9 +147,15 @@ type DBCaches struct {
 	// PollVoteIDs provides access to the poll vote IDs list database cache.
 	PollVoteIDs SliceCache[string]
 
+	// Relays caches all of the server's relay subscriptions.
+	Relays atomic.Pointer[[]*gtsmodel.Relay]
+
 	// Report provides access to the gtsmodel Report database cache.
 	Report StructCache[*gtsmodel.Report]
 
+	// ScheduledStatus provides access to the gtsmodel ScheduledStatus database cache.
+	ScheduledStatus StructCache[*gtsmodel.ScheduledStatus]
+
 	// Status provides access to the gtsmodel Status database cache.
 	Status StructCache[*gtsmodel.Status]
 
@@ -154,6 +165,9 @@ type DBCaches struct {
 	// StatusBookmarkIDs ...
 	StatusBookmarkIDs SliceCache[string]
 
+	// StatusEdit provides access to the gtsmodel StatusEdit database cache.
+	StatusEdit StructCache[*gtsmodel.StatusEdit]
+
 	// StatusFave provides access to the gtsmodel StatusFave database cache.
 	StatusFave StructCache[*gtsmodel.StatusFave]
 
@@ -183,6 +197,15 @@ type DBCaches struct {
 
 	// UserMuteIDs provides access to the user mute IDs database cache.
 	UserMuteIDs SliceCache[string]
+
+	// VAPIDKeyPair caches the server's VAPID key pair.
+	VAPIDKeyPair atomic.Pointer[gtsmodel.VAPIDKeyPair]
+
+	// WebPushSubscription provides access to the gtsmodel WebPushSubscription database cache.
+	WebPushSubscription StructCache[*gtsmodel.WebPushSubscription]
+
+	// WebPushSubscriptionIDs provides access to the Web Push subscription IDs database cache.
+	WebPushSubscriptionIDs SliceCache[string]
 }
 
 // NOTE:
@@ -228,6 +251,7 @@ func (c *Caches) initAccount() {
 			{Fields: "URL"},
 			{Fields: "Username,Domain", AllowZero: true},
 			{Fields: "PublicKeyURI"},
+			{Fields: "Ed25519PublicKeyURI"},
 			{Fields: "InboxURI"},
 			{Fields: "OutboxURI"},
 			{Fields: "FollowersURI"},
@@ -1156,6 +1180,7 @@ func (c *Caches) initReport() {
 		r2.Statuses = nil
 		r2.Rules = nil
 		r2.ActionTakenByAccount = nil
+		r2.AssignedAccount = nil
 
 		return r2
 	}
@@ -1170,6 +1195,38 @@ func (c *Caches) initReport() {
 	})
 }
 
+func (c *Caches) initScheduledStatus() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofScheduledStatus(), // model in-mem size.
+		config.GetCacheScheduledStatusMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.ScheduledStatus) *gtsmodel.ScheduledStatus {
+		s2 := new(gtsmodel.ScheduledStatus)
+		*s2 = *s1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		s2.Account = nil
+		s2.Application = nil
+		s2.MediaAttachments = nil
+
+		return s2
+	}
+
+	c.DB.ScheduledStatus.Init(structr.CacheConfig[*gtsmodel.ScheduledStatus]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initStatus() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1191,11 +1248,13 @@ func (c *Caches) initStatus() {
 		s2.InReplyToAccount = nil
 		s2.BoostOf = nil
 		s2.BoostOfAccount = nil
+		s2.Quote = nil
 		s2.Poll = nil
 		s2.Attachments = nil
 		s2.Tags = nil
 		s2.Mentions = nil
 		s2.Emojis = nil
+		s2.Edits = nil
 		s2.CreatedWithApplication = nil
 
 		return s2
@@ -1265,6 +1324,37 @@ func (c *Caches) initStatusBookmarkIDs() {
 	c.DB.StatusBookmarkIDs.Init(0, cap)
 }
 
+func (c *Caches) initStatusEdit() {
+	// Calculate maximum cache size.
+	cap := calculateResultCacheMax(
+		sizeofStatusEdit(), // model in-mem size.
+		config.GetCacheStatusEditMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.StatusEdit) *gtsmodel.StatusEdit {
+		s2 := new(gtsmodel.StatusEdit)
+		*s2 = *s1
+
+		// Don't include ptr fields that
+		// will be populated separately.
+		s2.Attachments = nil
+
+		return s2
+	}
+
+	c.DB.StatusEdit.Init(structr.CacheConfig[*gtsmodel.StatusEdit]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "StatusID", Multiple: true},
+		},
+		MaxSize:   cap,
+		IgnoreErr: ignoreErrors,
+		Copy:      copyF,
+	})
+}
+
 func (c *Caches) initStatusFave() {
 	// Calculate maximum cache size.
 	cap := calculateResultCacheMax(
@@ -1512,3 +1602,41 @@ func (c *Caches) initUserMuteIDs() {
 
 	c.DB.UserMuteIDs.Init(0, cap)
 }
+
+func (c *Caches) initWebPushSubscription() {
+	cap := calculateResultCacheMax(
+		sizeofWebPushSubscription(), // model in-mem size.
+		config.GetCacheWebPushSubscriptionMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	copyF := func(s1 *gtsmodel.WebPushSubscription) *gtsmodel.WebPushSubscription {
+		s2 := new(gtsmodel.WebPushSubscription)
+		*s2 = *s1
+		return s2
+	}
+
+	c.DB.WebPushSubscription.Init(structr.CacheConfig[*gtsmodel.WebPushSubscription]{
+		Indices: []structr.IndexConfig{
+			{Fields: "ID"},
+			{Fields: "TokenID"},
+			{Fields: "AccountID", Multiple: true},
+		},
+		MaxSize:    cap,
+		IgnoreErr:  ignoreErrors,
+		Copy:       copyF,
+		Invalidate: c.OnInvalidateWebPushSubscription,
+	})
+}
+
+func (c *Caches) initWebPushSubscriptionIDs() {
+	// Calculate maximum cache size.
+	cap := calculateSliceCacheMax(
+		config.GetCacheWebPushSubscriptionIDsMemRatio(),
+	)
+
+	log.Infof(nil, "cache size = %d", cap)
+
+	c.DB.WebPushSubscriptionIDs.Init(0, cap)
+}
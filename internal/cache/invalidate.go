@@ -219,6 +219,11 @@ func (c *Caches) OnInvalidateUser(user *gtsmodel.User) {
 	c.Visibility.Invalidate("RequesterID", user.AccountID)
 }
 
+func (c *Caches) OnInvalidateWebPushSubscription(subscription *gtsmodel.WebPushSubscription) {
+	// Invalidate Web Push subscription ID list for this account.
+	c.DB.WebPushSubscriptionIDs.Invalidate(subscription.AccountID)
+}
+
 func (c *Caches) OnInvalidateUserMute(mute *gtsmodel.UserMute) {
 	// Invalidate source account's user mute lists.
 	c.DB.UserMuteIDs.Invalidate(mute.AccountID)
@@ -18,6 +18,7 @@
 package cache
 
 import (
+	"crypto/ed25519"
 	"crypto/rsa"
 	"strings"
 	"time"
@@ -202,9 +203,11 @@ func totalOfRatios() float64 {
 		config.GetCachePollMemRatio() +
 		config.GetCachePollVoteMemRatio() +
 		config.GetCacheReportMemRatio() +
+		config.GetCacheScheduledStatusMemRatio() +
 		config.GetCacheStatusMemRatio() +
 		config.GetCacheStatusBookmarkMemRatio() +
 		config.GetCacheStatusBookmarkIDsMemRatio() +
+		config.GetCacheStatusEditMemRatio() +
 		config.GetCacheStatusFaveMemRatio() +
 		config.GetCacheStatusFaveIDsMemRatio() +
 		config.GetCacheTagMemRatio() +
@@ -212,6 +215,8 @@ func totalOfRatios() float64 {
 		config.GetCacheTokenMemRatio() +
 		config.GetCacheTombstoneMemRatio() +
 		config.GetCacheUserMemRatio() +
+		config.GetCacheWebPushSubscriptionMemRatio() +
+		config.GetCacheWebPushSubscriptionIDsMemRatio() +
 		config.GetCacheWebfingerMemRatio() +
 		config.GetCacheVisibilityMemRatio()
 }
@@ -243,6 +248,8 @@ func sizeofAccount() uintptr {
 		PrivateKey:              &rsa.PrivateKey{},
 		PublicKey:               &rsa.PublicKey{},
 		PublicKeyURI:            exampleURI,
+		Ed25519PublicKey:        make(ed25519.PublicKey, ed25519.PublicKeySize),
+		Ed25519PublicKeyURI:     exampleURI,
 		SensitizedAt:            exampleTime,
 		SilencedAt:              exampleTime,
 		SuspendedAt:             exampleTime,
@@ -587,9 +594,37 @@ func sizeofReport() uintptr {
 		Comment:                exampleText,
 		StatusIDs:              []string{exampleID, exampleID, exampleID},
 		Forwarded:              func() *bool { ok := true; return &ok }(),
+		ForwardedDomains:       []string{exampleURI},
+		ForwardedAt:            exampleTime,
 		ActionTaken:            exampleText,
 		ActionTakenAt:          exampleTime,
 		ActionTakenByAccountID: exampleID,
+		State:                  gtsmodel.ReportStateAssigned,
+		AssignedAccountID:      exampleID,
+	}))
+}
+
+func sizeofScheduledStatus() uintptr {
+	return uintptr(size.Of(&gtsmodel.ScheduledStatus{
+		ID:             exampleID,
+		CreatedAt:      exampleTime,
+		UpdatedAt:      exampleTime,
+		AccountID:      exampleID,
+		ScheduledAt:    exampleTime,
+		Text:           exampleText,
+		SpoilerText:    exampleText,
+		Sensitive:      func() *bool { ok := false; return &ok }(),
+		Visibility:     gtsmodel.VisibilityPublic,
+		LocalOnly:      func() *bool { ok := false; return &ok }(),
+		Language:       "en",
+		ContentType:    "text/plain",
+		InReplyToID:    exampleID,
+		MediaIDs:       []string{exampleID, exampleID, exampleID},
+		PollOptions:    []string{exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		PollExpiresIn:  3600,
+		PollMultiple:   func() *bool { ok := false; return &ok }(),
+		PollHideTotals: func() *bool { ok := false; return &ok }(),
+		ApplicationID:  exampleID,
 	}))
 }
 
@@ -615,6 +650,8 @@ func sizeofStatus() uintptr {
 		InReplyToAccountID:       exampleID,
 		BoostOfID:                exampleID,
 		BoostOfAccountID:         exampleID,
+		QuoteID:                  exampleID,
+		QuoteURI:                 exampleURI,
 		ContentWarning:           exampleUsername, // similar length
 		Visibility:               gtsmodel.VisibilityPublic,
 		Sensitive:                func() *bool { ok := false; return &ok }(),
@@ -639,6 +676,23 @@ func sizeofStatusBookmark() uintptr {
 	}))
 }
 
+func sizeofStatusEdit() uintptr {
+	return uintptr(size.Of(&gtsmodel.StatusEdit{
+		ID:                     exampleID,
+		Content:                exampleText,
+		ContentWarning:         exampleUsername, // similar length
+		Text:                   exampleText,
+		Language:               "en",
+		Sensitive:              func() *bool { ok := false; return &ok }(),
+		AttachmentIDs:          []string{exampleID, exampleID, exampleID},
+		AttachmentDescriptions: []string{exampleText, exampleText, exampleText},
+		PollOptions:            []string{exampleTextSmall, exampleTextSmall, exampleTextSmall, exampleTextSmall},
+		PollVotes:              []int{69, 420, 1337, 1969},
+		StatusID:               exampleID,
+		CreatedAt:              exampleTime,
+	}))
+}
+
 func sizeofStatusFave() uintptr {
 	return uintptr(size.Of(&gtsmodel.StatusFave{
 		ID:              exampleID,
@@ -691,6 +745,7 @@ func sizeofToken() uintptr {
 		Refresh:             "", // TODO: clients don't really support this very well yet
 		RefreshCreateAt:     exampleTime,
 		RefreshExpiresAt:    exampleTime,
+		LastUsed:            exampleTime,
 	}))
 }
 
@@ -704,6 +759,21 @@ func sizeofTombstone() uintptr {
 	}))
 }
 
+func sizeofWebPushSubscription() uintptr {
+	return uintptr(size.Of(&gtsmodel.WebPushSubscription{
+		ID:                exampleID,
+		CreatedAt:         exampleTime,
+		UpdatedAt:         exampleTime,
+		AccountID:         exampleID,
+		TokenID:           exampleID,
+		Endpoint:          exampleURI,
+		Auth:              exampleTextSmall,
+		P256dh:            exampleTextSmall,
+		NotificationFlags: 0,
+		Policy:            gtsmodel.WebPushNotificationPolicyAll,
+	}))
+}
+
 func sizeofVisibility() uintptr {
 	return uintptr(size.Of(&CachedVisibility{
 		ItemID:      exampleID,
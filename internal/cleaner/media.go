@@ -374,6 +374,25 @@ func (m *Media) pruneUnused(ctx context.Context, media *gtsmodel.MediaAttachment
 		}
 	}
 
+	// Check whether we have the required scheduled status for media.
+	scheduled, missing, err := m.getRelatedScheduledStatus(ctx, media)
+	if err != nil {
+		return false, err
+	} else if missing {
+		l.Debug("deleting due to missing scheduled status")
+		return true, m.delete(ctx, media)
+	}
+
+	if scheduled != nil {
+		// Check whether still attached to scheduled status.
+		for _, id := range scheduled.MediaIDs {
+			if id == media.ID {
+				l.Debug("skippping as attached to scheduled status")
+				return false, nil
+			}
+		}
+	}
+
 	// Media totally unused, delete it.
 	l.Debug("deleting unused media")
 	return true, m.delete(ctx, media)
@@ -542,6 +561,29 @@ func (m *Media) getRelatedStatus(ctx context.Context, media *gtsmodel.MediaAttac
 	return status, false, nil
 }
 
+func (m *Media) getRelatedScheduledStatus(ctx context.Context, media *gtsmodel.MediaAttachment) (*gtsmodel.ScheduledStatus, bool, error) {
+	if media.ScheduledStatusID == "" {
+		// no related scheduled status.
+		return nil, false, nil
+	}
+
+	// Load the scheduled status related to this media.
+	scheduled, err := m.state.DB.GetScheduledStatusByID(
+		gtscontext.SetBarebones(ctx),
+		media.ScheduledStatusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, false, gtserror.Newf("error fetching scheduled status by id %s: %w", media.ScheduledStatusID, err)
+	}
+
+	if scheduled == nil {
+		// scheduled status is missing.
+		return nil, true, nil
+	}
+
+	return scheduled, false, nil
+}
+
 func (m *Media) uncache(ctx context.Context, media *gtsmodel.MediaAttachment) error {
 	if gtscontext.DryRun(ctx) {
 		// Dry run, do nothing.
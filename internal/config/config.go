@@ -78,31 +78,42 @@ type Configuration struct {
 	WebTemplateBaseDir string `name:"web-template-base-dir" usage:"Basedir for html templating files for rendering pages and composing emails."`
 	WebAssetBaseDir    string `name:"web-asset-base-dir" usage:"Directory to serve static assets from, accessible at example.org/assets/"`
 
-	InstanceFederationMode         string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
-	InstanceFederationSpamFilter   bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
-	InstanceExposePeers            bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
-	InstanceExposeSuspended        bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
-	InstanceExposeSuspendedWeb     bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
-	InstanceExposePublicTimeline   bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
-	InstanceDeliverToSharedInboxes bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
-	InstanceInjectMastodonVersion  bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
-	InstanceLanguages              language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceFederationMode                          string             `name:"instance-federation-mode" usage:"Set instance federation mode."`
+	InstanceFederationSpamFilter                    bool               `name:"instance-federation-spam-filter" usage:"Enable basic spam filter heuristics for messages coming from other instances, and drop messages identified as spam"`
+	InstanceFederationReputation                    bool               `name:"instance-federation-reputation" usage:"Compute reputation scores for remote instances, and slow down or quarantine inbound federation from instances with a low reputation"`
+	InstanceFederationReputationSlowThreshold       int                `name:"instance-federation-reputation-slow-threshold" usage:"Inbound activities from instances with a reputation below this score are rate limited"`
+	InstanceFederationReputationSlowRate            int                `name:"instance-federation-reputation-slow-rate" usage:"Maximum number of inbound activities per minute to accept from each instance with a reputation below instance-federation-reputation-slow-threshold"`
+	InstanceFederationReputationQuarantineThreshold int                `name:"instance-federation-reputation-quarantine-threshold" usage:"Inbound activities from instances with a reputation below this score are quarantined (accepted but dropped)"`
+	InstanceExposePeers                             bool               `name:"instance-expose-peers" usage:"Allow unauthenticated users to query /api/v1/instance/peers?filter=open"`
+	InstanceExposeSuspended                         bool               `name:"instance-expose-suspended" usage:"Expose suspended instances via web UI, and allow unauthenticated users to query /api/v1/instance/peers?filter=suspended"`
+	InstanceExposeSuspendedWeb                      bool               `name:"instance-expose-suspended-web" usage:"Expose list of suspended instances as webpage on /about/suspended"`
+	InstanceExposePublicTimeline                    bool               `name:"instance-expose-public-timeline" usage:"Allow unauthenticated users to query /api/v1/timelines/public"`
+	InstanceDeliverToSharedInboxes                  bool               `name:"instance-deliver-to-shared-inboxes" usage:"Deliver federated messages to shared inboxes, if they're available."`
+	InstanceInjectMastodonVersion                   bool               `name:"instance-inject-mastodon-version" usage:"This injects a Mastodon compatible version in /api/v1/instance to help Mastodon clients that use that version for feature detection"`
+	InstanceLanguages                               language.Languages `name:"instance-languages" usage:"BCP47 language tags for the instance. Used to indicate the preferred languages of instance residents (in order from most-preferred to least-preferred)."`
+	InstanceSubscriptionsProcessFrom                string             `name:"instance-subscriptions-process-from" usage:"Time of day from which to start running instance subscriptions processing jobs. Should be in the format 'hh:mm', eg., '15:04'."`
+	InstanceSubscriptionsProcessEvery               time.Duration      `name:"instance-subscriptions-process-every" usage:"Period to elapse between instance subscriptions processing jobs, starting from instance-subscriptions-process-from."`
 
 	AccountsRegistrationOpen bool `name:"accounts-registration-open" usage:"Allow anyone to submit an account signup request. If false, server will be invite-only."`
 	AccountsReasonRequired   bool `name:"accounts-reason-required" usage:"Do new account signups require a reason to be submitted on registration?"`
 	AccountsAllowCustomCSS   bool `name:"accounts-allow-custom-css" usage:"Allow accounts to enable custom CSS for their profile pages and statuses."`
 	AccountsCustomCSSLength  int  `name:"accounts-custom-css-length" usage:"Maximum permitted length (characters) of custom CSS for accounts."`
 
-	MediaDescriptionMinChars int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
-	MediaDescriptionMaxChars int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
-	MediaRemoteCacheDays     int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
-	MediaEmojiLocalMaxSize   bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
-	MediaEmojiRemoteMaxSize  bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
-	MediaLocalMaxSize        bytesize.Size `name:"media-local-max-size" usage:"Max size in bytes of media uploaded to this instance via API"`
-	MediaRemoteMaxSize       bytesize.Size `name:"media-remote-max-size" usage:"Max size in bytes of media to download from other instances"`
-	MediaCleanupFrom         string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
-	MediaCleanupEvery        time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
-	MediaFfmpegPoolSize      int           `name:"media-ffmpeg-pool-size" usage:"Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS."`
+	MediaDescriptionMinChars   int           `name:"media-description-min-chars" usage:"Min required chars for an image description"`
+	MediaDescriptionMaxChars   int           `name:"media-description-max-chars" usage:"Max permitted chars for an image description"`
+	MediaRemoteCacheDays       int           `name:"media-remote-cache-days" usage:"Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely."`
+	MediaEmojiLocalMaxSize     bytesize.Size `name:"media-emoji-local-max-size" usage:"Max size in bytes of emojis uploaded to this instance via the admin API."`
+	MediaEmojiRemoteMaxSize    bytesize.Size `name:"media-emoji-remote-max-size" usage:"Max size in bytes of emojis to download from other instances."`
+	MediaLocalMaxSize          bytesize.Size `name:"media-local-max-size" usage:"Max size in bytes of media uploaded to this instance via API"`
+	MediaRemoteMaxSize         bytesize.Size `name:"media-remote-max-size" usage:"Max size in bytes of media to download from other instances"`
+	MediaCleanupFrom           string        `name:"media-cleanup-from" usage:"Time of day from which to start running media cleanup/prune jobs. Should be in the format 'hh:mm:ss', eg., '15:04:05'."`
+	MediaCleanupEvery          time.Duration `name:"media-cleanup-every" usage:"Period to elapse between cleanups, starting from media-cleanup-at."`
+	MediaFfmpegPoolSize        int           `name:"media-ffmpeg-pool-size" usage:"Number of instances of the embedded ffmpeg WASM binary to add to the media processing pool. 0 or less uses GOMAXPROCS."`
+	MediaTranscodeEnabled      bool          `name:"media-transcode-enabled" usage:"Re-encode uploaded video and audio to web-safe formats (H.264 with AAC or Opus), within the configured caps, if not already encoded as such."`
+	MediaTranscodeMaxDimension int           `name:"media-transcode-max-dimension" usage:"Max width or height in pixels of transcoded video. Larger video will be scaled down to fit."`
+	MediaTranscodeVideoBitrate int           `name:"media-transcode-video-bitrate" usage:"Max bitrate in kbps of transcoded video streams."`
+	MediaTranscodeAudioBitrate int           `name:"media-transcode-audio-bitrate" usage:"Bitrate in kbps of transcoded audio streams."`
+	MediaTranscodeAudioCodec   string        `name:"media-transcode-audio-codec" usage:"Codec to use for transcoded audio streams, one of: aac, opus."`
 
 	StorageBackend       string `name:"storage-backend" usage:"Storage backend to use for media attachments"`
 	StorageLocalBasePath string `name:"storage-local-base-path" usage:"Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir."`
@@ -159,14 +170,22 @@ type Configuration struct {
 	SyslogProtocol string `name:"syslog-protocol" usage:"Protocol to use when directing logs to syslog. Leave empty to connect to local syslog."`
 	SyslogAddress  string `name:"syslog-address" usage:"Address:port to send syslog logs to. Leave empty to connect to local syslog."`
 
-	AdvancedCookiesSamesite      string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
-	AdvancedRateLimitRequests    int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
-	AdvancedRateLimitExceptions  []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
-	AdvancedThrottlingMultiplier int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
-	AdvancedThrottlingRetryAfter time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
-	AdvancedSenderMultiplier     int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
-	AdvancedCSPExtraURIs         []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
-	AdvancedHeaderFilterMode     string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedCookiesSamesite          string        `name:"advanced-cookies-samesite" usage:"'strict' or 'lax', see https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/Set-Cookie/SameSite"`
+	AdvancedRateLimitRequests        int           `name:"advanced-rate-limit-requests" usage:"Amount of HTTP requests to permit within a 5 minute window. 0 or less turns rate limiting off."`
+	AdvancedRateLimitExceptions      []string      `name:"advanced-rate-limit-exceptions" usage:"Slice of CIDRs to exclude from rate limit restrictions."`
+	AdvancedRateLimitAccountRequests int           `name:"advanced-rate-limit-account-requests" usage:"Amount of client API requests to permit per authorized account or application within a 5 minute window, regardless of IP. 0 or less turns this limit off."`
+	AdvancedRateLimitWriteRequests   int           `name:"advanced-rate-limit-write-requests" usage:"Amount of client API write (non-GET) requests to permit per account, application or IP within a 5 minute window. 0 or less turns this limit off."`
+	AdvancedRateLimitSearchRequests  int           `name:"advanced-rate-limit-search-requests" usage:"Amount of search requests to permit per account, application or IP within a 5 minute window. 0 or less turns this limit off."`
+	AdvancedRateLimitMediaRequests   int           `name:"advanced-rate-limit-media-requests" usage:"Amount of media uploads to permit per account, application or IP within a 5 minute window. 0 or less turns this limit off."`
+	AdvancedRateLimitStatusRequests  int           `name:"advanced-rate-limit-status-requests" usage:"Amount of status creation requests to permit per account, application or IP within a 5 minute window. 0 or less turns this limit off."`
+	AdvancedThrottlingMultiplier     int           `name:"advanced-throttling-multiplier" usage:"Multiplier to use per cpu for http request throttling. 0 or less turns throttling off."`
+	AdvancedThrottlingRetryAfter     time.Duration `name:"advanced-throttling-retry-after" usage:"Retry-After duration response to send for throttled requests."`
+	AdvancedSenderMultiplier         int           `name:"advanced-sender-multiplier" usage:"Multiplier to use per cpu for batching outgoing fedi messages. 0 or less turns batching off (not recommended)."`
+	AdvancedCSPExtraURIs             []string      `name:"advanced-csp-extra-uris" usage:"Additional URIs to allow when building content-security-policy for media + images."`
+	AdvancedHeaderFilterMode         string        `name:"advanced-header-filter-mode" usage:"Set incoming request header filtering mode."`
+	AdvancedDeliveryJournal          bool          `name:"advanced-delivery-journal" usage:"Record pending outgoing deliveries in the database, so they can be replayed after a crash, and park deliveries to domains that keep failing."`
+	AdvancedDeliveryParkAfter        int           `name:"advanced-delivery-park-after" usage:"Number of consecutive failed deliveries to a domain after which further deliveries to it are parked. Only used with advanced-delivery-journal. 0 or less turns parking off."`
+	AdvancedDeliveryParkFor          time.Duration `name:"advanced-delivery-park-for" usage:"Duration to park deliveries to a failing domain for, before trying it again."`
 
 	// HTTPClient configuration vars.
 	HTTPClient HTTPClientConfiguration `name:"http-client"`
@@ -175,13 +194,19 @@ type Configuration struct {
 	Cache CacheConfiguration `name:"cache"`
 
 	// TODO: move these elsewhere, these are more ephemeral vs long-running flags like above
-	AdminAccountUsername     string `name:"username" usage:"the username to create/delete/etc"`
-	AdminAccountEmail        string `name:"email" usage:"the email address of this account"`
-	AdminAccountPassword     string `name:"password" usage:"the password to set for this account"`
-	AdminTransPath           string `name:"path" usage:"the path of the file to import from/export to"`
-	AdminMediaPruneDryRun    bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
-	AdminMediaListLocalOnly  bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
-	AdminMediaListRemoteOnly bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminAccountUsername        string `name:"username" usage:"the username to create/delete/etc"`
+	AdminAccountEmail           string `name:"email" usage:"the email address of this account"`
+	AdminAccountPassword        string `name:"password" usage:"the password to set for this account"`
+	AdminTransPath              string `name:"path" usage:"the path of the file to import from/export to"`
+	AdminTransFull              bool   `name:"full" usage:"export every database table plus stored media and emoji files, rather than just accounts, users, follows, blocks and instances"`
+	AdminTransSince             string `name:"since" usage:"with full, only export rows created or updated since this ULID (as logged at the end of a previous export), for incremental backups"`
+	AdminTransSkipMedia         bool   `name:"skip-media" usage:"with full, leave stored media and emoji files out of the export"`
+	AdminMediaPruneDryRun       bool   `name:"dry-run" usage:"perform a dry run and only log number of items eligible for pruning"`
+	AdminMediaListLocalOnly     bool   `name:"local-only" usage:"list only local attachments/emojis; if specified then remote-only cannot also be true"`
+	AdminMediaListRemoteOnly    bool   `name:"remote-only" usage:"list only remote attachments/emojis; if specified then local-only cannot also be true"`
+	AdminDbMigrateTargetType    string `name:"target-db-type" usage:"type of the database to migrate to: postgres or sqlite"`
+	AdminDbMigrateTargetAddress string `name:"target-db-address" usage:"filename of the sqlite database, or connection string of the postgres database, to migrate to"`
+	AdminDbMigrateBatchSize     int    `name:"batch-size" usage:"number of rows to copy from each table at a time"`
 
 	RequestIDHeader string `name:"request-id-header" usage:"Header to extract the Request ID from. Eg.,'X-Request-Id'."`
 }
@@ -230,9 +255,11 @@ type CacheConfiguration struct {
 	PollVoteMemRatio                  float64       `name:"poll-vote-mem-ratio"`
 	PollVoteIDsMemRatio               float64       `name:"poll-vote-ids-mem-ratio"`
 	ReportMemRatio                    float64       `name:"report-mem-ratio"`
+	ScheduledStatusMemRatio           float64       `name:"scheduled-status-mem-ratio"`
 	StatusMemRatio                    float64       `name:"status-mem-ratio"`
 	StatusBookmarkMemRatio            float64       `name:"status-bookmark-mem-ratio"`
 	StatusBookmarkIDsMemRatio         float64       `name:"status-bookmark-ids-mem-ratio"`
+	StatusEditMemRatio                float64       `name:"status-edit-mem-ratio"`
 	StatusFaveMemRatio                float64       `name:"status-fave-mem-ratio"`
 	StatusFaveIDsMemRatio             float64       `name:"status-fave-ids-mem-ratio"`
 	TagMemRatio                       float64       `name:"tag-mem-ratio"`
@@ -243,6 +270,8 @@ type CacheConfiguration struct {
 	UserMemRatio                      float64       `name:"user-mem-ratio"`
 	UserMuteMemRatio                  float64       `name:"user-mute-mem-ratio"`
 	UserMuteIDsMemRatio               float64       `name:"user-mute-ids-mem-ratio"`
+	WebPushSubscriptionMemRatio       float64       `name:"web-push-subscription-mem-ratio"`
+	WebPushSubscriptionIDsMemRatio    float64       `name:"web-push-subscription-ids-mem-ratio"`
 	WebfingerMemRatio                 float64       `name:"webfinger-mem-ratio"`
 	VisibilityMemRatio                float64       `name:"visibility-mem-ratio"`
 }
@@ -29,4 +29,9 @@ const (
 	RequestHeaderFilterModeAllow    = "allow"
 	RequestHeaderFilterModeBlock    = "block"
 	RequestHeaderFilterModeDisabled = ""
+
+	// Media transcode audio codec determines which
+	// codec is used for transcoded audio streams.
+	MediaTranscodeAudioCodecAAC  = "aac"
+	MediaTranscodeAudioCodecOpus = "opus"
 )
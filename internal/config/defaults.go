@@ -58,29 +58,40 @@ var Defaults = Configuration{
 	WebTemplateBaseDir: "./web/template/",
 	WebAssetBaseDir:    "./web/assets/",
 
-	InstanceFederationMode:         InstanceFederationModeDefault,
-	InstanceFederationSpamFilter:   false,
-	InstanceExposePeers:            false,
-	InstanceExposeSuspended:        false,
-	InstanceExposeSuspendedWeb:     false,
-	InstanceDeliverToSharedInboxes: true,
-	InstanceLanguages:              make(language.Languages, 0),
+	InstanceFederationMode:                          InstanceFederationModeDefault,
+	InstanceFederationSpamFilter:                    false,
+	InstanceFederationReputation:                    false,
+	InstanceFederationReputationSlowThreshold:       10,
+	InstanceFederationReputationSlowRate:            30,
+	InstanceFederationReputationQuarantineThreshold: -50,
+	InstanceExposePeers:                             false,
+	InstanceExposeSuspended:                         false,
+	InstanceExposeSuspendedWeb:                      false,
+	InstanceDeliverToSharedInboxes:                  true,
+	InstanceLanguages:                               make(language.Languages, 0),
+	InstanceSubscriptionsProcessFrom:                "23:00",        // 11pm.
+	InstanceSubscriptionsProcessEvery:               24 * time.Hour, // 1/day.
 
 	AccountsRegistrationOpen: false,
 	AccountsReasonRequired:   true,
 	AccountsAllowCustomCSS:   false,
 	AccountsCustomCSSLength:  10000,
 
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 1500,
-	MediaRemoteCacheDays:     7,
-	MediaLocalMaxSize:        40 * bytesize.MiB,
-	MediaRemoteMaxSize:       40 * bytesize.MiB,
-	MediaEmojiLocalMaxSize:   50 * bytesize.KiB,
-	MediaEmojiRemoteMaxSize:  100 * bytesize.KiB,
-	MediaCleanupFrom:         "00:00",        // Midnight.
-	MediaCleanupEvery:        24 * time.Hour, // 1/day.
-	MediaFfmpegPoolSize:      1,
+	MediaDescriptionMinChars:   0,
+	MediaDescriptionMaxChars:   1500,
+	MediaRemoteCacheDays:       7,
+	MediaLocalMaxSize:          40 * bytesize.MiB,
+	MediaRemoteMaxSize:         40 * bytesize.MiB,
+	MediaEmojiLocalMaxSize:     50 * bytesize.KiB,
+	MediaEmojiRemoteMaxSize:    100 * bytesize.KiB,
+	MediaCleanupFrom:           "00:00",        // Midnight.
+	MediaCleanupEvery:          24 * time.Hour, // 1/day.
+	MediaFfmpegPoolSize:        1,
+	MediaTranscodeEnabled:      false,
+	MediaTranscodeMaxDimension: 1920,
+	MediaTranscodeVideoBitrate: 2500,
+	MediaTranscodeAudioBitrate: 128,
+	MediaTranscodeAudioCodec:   MediaTranscodeAudioCodecAAC,
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
@@ -129,14 +140,22 @@ var Defaults = Configuration{
 	SyslogProtocol: "udp",
 	SyslogAddress:  "localhost:514",
 
-	AdvancedCookiesSamesite:      "lax",
-	AdvancedRateLimitRequests:    300, // 1 per second per 5 minutes
-	AdvancedRateLimitExceptions:  []string{},
-	AdvancedThrottlingMultiplier: 8, // 8 open requests per CPU
-	AdvancedThrottlingRetryAfter: time.Second * 30,
-	AdvancedSenderMultiplier:     2, // 2 senders per CPU
-	AdvancedCSPExtraURIs:         []string{},
-	AdvancedHeaderFilterMode:     RequestHeaderFilterModeDisabled,
+	AdvancedCookiesSamesite:          "lax",
+	AdvancedRateLimitRequests:        300, // 1 per second per 5 minutes
+	AdvancedRateLimitExceptions:      []string{},
+	AdvancedRateLimitAccountRequests: 300,
+	AdvancedRateLimitWriteRequests:   150,
+	AdvancedRateLimitSearchRequests:  60,
+	AdvancedRateLimitMediaRequests:   30,
+	AdvancedRateLimitStatusRequests:  30,
+	AdvancedThrottlingMultiplier:     8, // 8 open requests per CPU
+	AdvancedThrottlingRetryAfter:     time.Second * 30,
+	AdvancedSenderMultiplier:         2, // 2 senders per CPU
+	AdvancedCSPExtraURIs:             []string{},
+	AdvancedHeaderFilterMode:         RequestHeaderFilterModeDisabled,
+	AdvancedDeliveryJournal:          false,
+	AdvancedDeliveryParkAfter:        20,
+	AdvancedDeliveryParkFor:          time.Hour,
 
 	Cache: CacheConfiguration{
 		// Rough memory target that the total
@@ -193,9 +212,11 @@ var Defaults = Configuration{
 		PollVoteMemRatio:                  2,
 		PollVoteIDsMemRatio:               2,
 		ReportMemRatio:                    1,
+		ScheduledStatusMemRatio:           0.5,
 		StatusMemRatio:                    5,
 		StatusBookmarkMemRatio:            0.5,
 		StatusBookmarkIDsMemRatio:         2,
+		StatusEditMemRatio:                2,
 		StatusFaveMemRatio:                2,
 		StatusFaveIDsMemRatio:             3,
 		TagMemRatio:                       2,
@@ -206,6 +227,8 @@ var Defaults = Configuration{
 		UserMemRatio:                      0.25,
 		UserMuteMemRatio:                  2,
 		UserMuteIDsMemRatio:               3,
+		WebPushSubscriptionMemRatio:       1,
+		WebPushSubscriptionIDsMemRatio:    1,
 		WebfingerMemRatio:                 0.1,
 		VisibilityMemRatio:                2,
 	},
@@ -217,7 +240,8 @@ var Defaults = Configuration{
 		TLSInsecureSkipVerify: false,
 	},
 
-	AdminMediaPruneDryRun: true,
+	AdminMediaPruneDryRun:   true,
+	AdminDbMigrateBatchSize: 1000,
 
 	RequestIDHeader: "X-Request-Id",
 
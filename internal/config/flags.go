@@ -85,11 +85,17 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		// Instance
 		cmd.Flags().String(InstanceFederationModeFlag(), cfg.InstanceFederationMode, fieldtag("InstanceFederationMode", "usage"))
 		cmd.Flags().Bool(InstanceFederationSpamFilterFlag(), cfg.InstanceFederationSpamFilter, fieldtag("InstanceFederationSpamFilter", "usage"))
+		cmd.Flags().Bool(InstanceFederationReputationFlag(), cfg.InstanceFederationReputation, fieldtag("InstanceFederationReputation", "usage"))
+		cmd.Flags().Int(InstanceFederationReputationSlowThresholdFlag(), cfg.InstanceFederationReputationSlowThreshold, fieldtag("InstanceFederationReputationSlowThreshold", "usage"))
+		cmd.Flags().Int(InstanceFederationReputationSlowRateFlag(), cfg.InstanceFederationReputationSlowRate, fieldtag("InstanceFederationReputationSlowRate", "usage"))
+		cmd.Flags().Int(InstanceFederationReputationQuarantineThresholdFlag(), cfg.InstanceFederationReputationQuarantineThreshold, fieldtag("InstanceFederationReputationQuarantineThreshold", "usage"))
 		cmd.Flags().Bool(InstanceExposePeersFlag(), cfg.InstanceExposePeers, fieldtag("InstanceExposePeers", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedFlag(), cfg.InstanceExposeSuspended, fieldtag("InstanceExposeSuspended", "usage"))
 		cmd.Flags().Bool(InstanceExposeSuspendedWebFlag(), cfg.InstanceExposeSuspendedWeb, fieldtag("InstanceExposeSuspendedWeb", "usage"))
 		cmd.Flags().Bool(InstanceDeliverToSharedInboxesFlag(), cfg.InstanceDeliverToSharedInboxes, fieldtag("InstanceDeliverToSharedInboxes", "usage"))
 		cmd.Flags().StringSlice(InstanceLanguagesFlag(), cfg.InstanceLanguages.TagStrs(), fieldtag("InstanceLanguages", "usage"))
+		cmd.Flags().String(InstanceSubscriptionsProcessFromFlag(), cfg.InstanceSubscriptionsProcessFrom, fieldtag("InstanceSubscriptionsProcessFrom", "usage"))
+		cmd.Flags().Duration(InstanceSubscriptionsProcessEveryFlag(), cfg.InstanceSubscriptionsProcessEvery, fieldtag("InstanceSubscriptionsProcessEvery", "usage"))
 
 		// Accounts
 		cmd.Flags().Bool(AccountsRegistrationOpenFlag(), cfg.AccountsRegistrationOpen, fieldtag("AccountsRegistrationOpen", "usage"))
@@ -106,6 +112,11 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().Uint64(MediaEmojiRemoteMaxSizeFlag(), uint64(cfg.MediaEmojiRemoteMaxSize), fieldtag("MediaEmojiRemoteMaxSize", "usage"))
 		cmd.Flags().String(MediaCleanupFromFlag(), cfg.MediaCleanupFrom, fieldtag("MediaCleanupFrom", "usage"))
 		cmd.Flags().Duration(MediaCleanupEveryFlag(), cfg.MediaCleanupEvery, fieldtag("MediaCleanupEvery", "usage"))
+		cmd.Flags().Bool(MediaTranscodeEnabledFlag(), cfg.MediaTranscodeEnabled, fieldtag("MediaTranscodeEnabled", "usage"))
+		cmd.Flags().Int(MediaTranscodeMaxDimensionFlag(), cfg.MediaTranscodeMaxDimension, fieldtag("MediaTranscodeMaxDimension", "usage"))
+		cmd.Flags().Int(MediaTranscodeVideoBitrateFlag(), cfg.MediaTranscodeVideoBitrate, fieldtag("MediaTranscodeVideoBitrate", "usage"))
+		cmd.Flags().Int(MediaTranscodeAudioBitrateFlag(), cfg.MediaTranscodeAudioBitrate, fieldtag("MediaTranscodeAudioBitrate", "usage"))
+		cmd.Flags().String(MediaTranscodeAudioCodecFlag(), cfg.MediaTranscodeAudioCodec, fieldtag("MediaTranscodeAudioCodec", "usage"))
 
 		// Storage
 		cmd.Flags().String(StorageBackendFlag(), cfg.StorageBackend, fieldtag("StorageBackend", "usage"))
@@ -153,11 +164,19 @@ func (s *ConfigState) AddServerFlags(cmd *cobra.Command) {
 		cmd.Flags().String(AdvancedCookiesSamesiteFlag(), cfg.AdvancedCookiesSamesite, fieldtag("AdvancedCookiesSamesite", "usage"))
 		cmd.Flags().Int(AdvancedRateLimitRequestsFlag(), cfg.AdvancedRateLimitRequests, fieldtag("AdvancedRateLimitRequests", "usage"))
 		cmd.Flags().StringSlice(AdvancedRateLimitExceptionsFlag(), cfg.AdvancedRateLimitExceptions, fieldtag("AdvancedRateLimitExceptions", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitAccountRequestsFlag(), cfg.AdvancedRateLimitAccountRequests, fieldtag("AdvancedRateLimitAccountRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitWriteRequestsFlag(), cfg.AdvancedRateLimitWriteRequests, fieldtag("AdvancedRateLimitWriteRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitSearchRequestsFlag(), cfg.AdvancedRateLimitSearchRequests, fieldtag("AdvancedRateLimitSearchRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitMediaRequestsFlag(), cfg.AdvancedRateLimitMediaRequests, fieldtag("AdvancedRateLimitMediaRequests", "usage"))
+		cmd.Flags().Int(AdvancedRateLimitStatusRequestsFlag(), cfg.AdvancedRateLimitStatusRequests, fieldtag("AdvancedRateLimitStatusRequests", "usage"))
 		cmd.Flags().Int(AdvancedThrottlingMultiplierFlag(), cfg.AdvancedThrottlingMultiplier, fieldtag("AdvancedThrottlingMultiplier", "usage"))
 		cmd.Flags().Duration(AdvancedThrottlingRetryAfterFlag(), cfg.AdvancedThrottlingRetryAfter, fieldtag("AdvancedThrottlingRetryAfter", "usage"))
 		cmd.Flags().Int(AdvancedSenderMultiplierFlag(), cfg.AdvancedSenderMultiplier, fieldtag("AdvancedSenderMultiplier", "usage"))
 		cmd.Flags().StringSlice(AdvancedCSPExtraURIsFlag(), cfg.AdvancedCSPExtraURIs, fieldtag("AdvancedCSPExtraURIs", "usage"))
 		cmd.Flags().String(AdvancedHeaderFilterModeFlag(), cfg.AdvancedHeaderFilterMode, fieldtag("AdvancedHeaderFilterMode", "usage"))
+		cmd.Flags().Bool(AdvancedDeliveryJournalFlag(), cfg.AdvancedDeliveryJournal, fieldtag("AdvancedDeliveryJournal", "usage"))
+		cmd.Flags().Int(AdvancedDeliveryParkAfterFlag(), cfg.AdvancedDeliveryParkAfter, fieldtag("AdvancedDeliveryParkAfter", "usage"))
+		cmd.Flags().Duration(AdvancedDeliveryParkForFlag(), cfg.AdvancedDeliveryParkFor, fieldtag("AdvancedDeliveryParkFor", "usage"))
 
 		cmd.Flags().String(RequestIDHeaderFlag(), cfg.RequestIDHeader, fieldtag("RequestIDHeader", "usage"))
 	})
@@ -207,6 +226,21 @@ func AddAdminTrans(cmd *cobra.Command) {
 	}
 }
 
+// AddAdminTransExport attaches flags pertaining to the export command.
+func AddAdminTransExport(cmd *cobra.Command) {
+	full := AdminTransFullFlag()
+	fullUsage := fieldtag("AdminTransFull", "usage")
+	cmd.Flags().Bool(full, false, fullUsage)
+
+	since := AdminTransSinceFlag()
+	sinceUsage := fieldtag("AdminTransSince", "usage")
+	cmd.Flags().String(since, "", sinceUsage)
+
+	skipMedia := AdminTransSkipMediaFlag()
+	skipMediaUsage := fieldtag("AdminTransSkipMedia", "usage")
+	cmd.Flags().Bool(skipMedia, false, skipMediaUsage)
+}
+
 // AddAdminMediaList attaches flags pertaining to media list commands.
 func AddAdminMediaList(cmd *cobra.Command) {
 	localOnly := AdminMediaListLocalOnlyFlag()
@@ -224,3 +258,24 @@ func AddAdminMediaPrune(cmd *cobra.Command) {
 	usage := fieldtag("AdminMediaPruneDryRun", "usage")
 	cmd.Flags().Bool(name, true, usage)
 }
+
+// AddAdminDbMigrate attaches flags pertaining to the database migrate command.
+func AddAdminDbMigrate(cmd *cobra.Command) {
+	targetType := AdminDbMigrateTargetTypeFlag()
+	targetTypeUsage := fieldtag("AdminDbMigrateTargetType", "usage")
+	cmd.Flags().String(targetType, "", targetTypeUsage) // REQUIRED
+	if err := cmd.MarkFlagRequired(targetType); err != nil {
+		panic(err)
+	}
+
+	targetAddress := AdminDbMigrateTargetAddressFlag()
+	targetAddressUsage := fieldtag("AdminDbMigrateTargetAddress", "usage")
+	cmd.Flags().String(targetAddress, "", targetAddressUsage) // REQUIRED
+	if err := cmd.MarkFlagRequired(targetAddress); err != nil {
+		panic(err)
+	}
+
+	batchSize := AdminDbMigrateBatchSizeFlag()
+	batchSizeUsage := fieldtag("AdminDbMigrateBatchSize", "usage")
+	cmd.Flags().Int(batchSize, 1000, batchSizeUsage)
+}
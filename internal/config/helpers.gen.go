@@ -825,6 +825,124 @@ func GetInstanceFederationSpamFilter() bool { return global.GetInstanceFederatio
 // SetInstanceFederationSpamFilter safely sets the value for global configuration 'InstanceFederationSpamFilter' field
 func SetInstanceFederationSpamFilter(v bool) { global.SetInstanceFederationSpamFilter(v) }
 
+// GetInstanceFederationReputation safely fetches the Configuration value for state's 'InstanceFederationReputation' field
+func (st *ConfigState) GetInstanceFederationReputation() (v bool) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationReputation
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationReputation safely sets the Configuration value for state's 'InstanceFederationReputation' field
+func (st *ConfigState) SetInstanceFederationReputation(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationReputation = v
+	st.reloadToViper()
+}
+
+// InstanceFederationReputationFlag returns the flag name for the 'InstanceFederationReputation' field
+func InstanceFederationReputationFlag() string { return "instance-federation-reputation" }
+
+// GetInstanceFederationReputation safely fetches the value for global configuration 'InstanceFederationReputation' field
+func GetInstanceFederationReputation() bool { return global.GetInstanceFederationReputation() }
+
+// SetInstanceFederationReputation safely sets the value for global configuration 'InstanceFederationReputation' field
+func SetInstanceFederationReputation(v bool) { global.SetInstanceFederationReputation(v) }
+
+// GetInstanceFederationReputationSlowThreshold safely fetches the Configuration value for state's 'InstanceFederationReputationSlowThreshold' field
+func (st *ConfigState) GetInstanceFederationReputationSlowThreshold() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationReputationSlowThreshold
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationReputationSlowThreshold safely sets the Configuration value for state's 'InstanceFederationReputationSlowThreshold' field
+func (st *ConfigState) SetInstanceFederationReputationSlowThreshold(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationReputationSlowThreshold = v
+	st.reloadToViper()
+}
+
+// InstanceFederationReputationSlowThresholdFlag returns the flag name for the 'InstanceFederationReputationSlowThreshold' field
+func InstanceFederationReputationSlowThresholdFlag() string {
+	return "instance-federation-reputation-slow-threshold"
+}
+
+// GetInstanceFederationReputationSlowThreshold safely fetches the value for global configuration 'InstanceFederationReputationSlowThreshold' field
+func GetInstanceFederationReputationSlowThreshold() int {
+	return global.GetInstanceFederationReputationSlowThreshold()
+}
+
+// SetInstanceFederationReputationSlowThreshold safely sets the value for global configuration 'InstanceFederationReputationSlowThreshold' field
+func SetInstanceFederationReputationSlowThreshold(v int) {
+	global.SetInstanceFederationReputationSlowThreshold(v)
+}
+
+// GetInstanceFederationReputationSlowRate safely fetches the Configuration value for state's 'InstanceFederationReputationSlowRate' field
+func (st *ConfigState) GetInstanceFederationReputationSlowRate() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationReputationSlowRate
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationReputationSlowRate safely sets the Configuration value for state's 'InstanceFederationReputationSlowRate' field
+func (st *ConfigState) SetInstanceFederationReputationSlowRate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationReputationSlowRate = v
+	st.reloadToViper()
+}
+
+// InstanceFederationReputationSlowRateFlag returns the flag name for the 'InstanceFederationReputationSlowRate' field
+func InstanceFederationReputationSlowRateFlag() string {
+	return "instance-federation-reputation-slow-rate"
+}
+
+// GetInstanceFederationReputationSlowRate safely fetches the value for global configuration 'InstanceFederationReputationSlowRate' field
+func GetInstanceFederationReputationSlowRate() int {
+	return global.GetInstanceFederationReputationSlowRate()
+}
+
+// SetInstanceFederationReputationSlowRate safely sets the value for global configuration 'InstanceFederationReputationSlowRate' field
+func SetInstanceFederationReputationSlowRate(v int) {
+	global.SetInstanceFederationReputationSlowRate(v)
+}
+
+// GetInstanceFederationReputationQuarantineThreshold safely fetches the Configuration value for state's 'InstanceFederationReputationQuarantineThreshold' field
+func (st *ConfigState) GetInstanceFederationReputationQuarantineThreshold() (v int) {
+	st.mutex.RLock()
+	v = st.config.InstanceFederationReputationQuarantineThreshold
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceFederationReputationQuarantineThreshold safely sets the Configuration value for state's 'InstanceFederationReputationQuarantineThreshold' field
+func (st *ConfigState) SetInstanceFederationReputationQuarantineThreshold(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceFederationReputationQuarantineThreshold = v
+	st.reloadToViper()
+}
+
+// InstanceFederationReputationQuarantineThresholdFlag returns the flag name for the 'InstanceFederationReputationQuarantineThreshold' field
+func InstanceFederationReputationQuarantineThresholdFlag() string {
+	return "instance-federation-reputation-quarantine-threshold"
+}
+
+// GetInstanceFederationReputationQuarantineThreshold safely fetches the value for global configuration 'InstanceFederationReputationQuarantineThreshold' field
+func GetInstanceFederationReputationQuarantineThreshold() int {
+	return global.GetInstanceFederationReputationQuarantineThreshold()
+}
+
+// SetInstanceFederationReputationQuarantineThreshold safely sets the value for global configuration 'InstanceFederationReputationQuarantineThreshold' field
+func SetInstanceFederationReputationQuarantineThreshold(v int) {
+	global.SetInstanceFederationReputationQuarantineThreshold(v)
+}
+
 // GetInstanceExposePeers safely fetches the Configuration value for state's 'InstanceExposePeers' field
 func (st *ConfigState) GetInstanceExposePeers() (v bool) {
 	st.mutex.RLock()
@@ -1000,6 +1118,62 @@ func GetInstanceLanguages() language.Languages { return global.GetInstanceLangua
 // SetInstanceLanguages safely sets the value for global configuration 'InstanceLanguages' field
 func SetInstanceLanguages(v language.Languages) { global.SetInstanceLanguages(v) }
 
+// GetInstanceSubscriptionsProcessFrom safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessFrom() (v string) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessFrom
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the Configuration value for state's 'InstanceSubscriptionsProcessFrom' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessFrom(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessFrom = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessFromFlag returns the flag name for the 'InstanceSubscriptionsProcessFrom' field
+func InstanceSubscriptionsProcessFromFlag() string { return "instance-subscriptions-process-from" }
+
+// GetInstanceSubscriptionsProcessFrom safely fetches the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func GetInstanceSubscriptionsProcessFrom() string {
+	return global.GetInstanceSubscriptionsProcessFrom()
+}
+
+// SetInstanceSubscriptionsProcessFrom safely sets the value for global configuration 'InstanceSubscriptionsProcessFrom' field
+func SetInstanceSubscriptionsProcessFrom(v string) { global.SetInstanceSubscriptionsProcessFrom(v) }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) GetInstanceSubscriptionsProcessEvery() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.InstanceSubscriptionsProcessEvery
+	st.mutex.RUnlock()
+	return
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the Configuration value for state's 'InstanceSubscriptionsProcessEvery' field
+func (st *ConfigState) SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.InstanceSubscriptionsProcessEvery = v
+	st.reloadToViper()
+}
+
+// InstanceSubscriptionsProcessEveryFlag returns the flag name for the 'InstanceSubscriptionsProcessEvery' field
+func InstanceSubscriptionsProcessEveryFlag() string { return "instance-subscriptions-process-every" }
+
+// GetInstanceSubscriptionsProcessEvery safely fetches the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func GetInstanceSubscriptionsProcessEvery() time.Duration {
+	return global.GetInstanceSubscriptionsProcessEvery()
+}
+
+// SetInstanceSubscriptionsProcessEvery safely sets the value for global configuration 'InstanceSubscriptionsProcessEvery' field
+func SetInstanceSubscriptionsProcessEvery(v time.Duration) {
+	global.SetInstanceSubscriptionsProcessEvery(v)
+}
+
 // GetAccountsRegistrationOpen safely fetches the Configuration value for state's 'AccountsRegistrationOpen' field
 func (st *ConfigState) GetAccountsRegistrationOpen() (v bool) {
 	st.mutex.RLock()
@@ -1350,6 +1524,131 @@ func GetMediaFfmpegPoolSize() int { return global.GetMediaFfmpegPoolSize() }
 // SetMediaFfmpegPoolSize safely sets the value for global configuration 'MediaFfmpegPoolSize' field
 func SetMediaFfmpegPoolSize(v int) { global.SetMediaFfmpegPoolSize(v) }
 
+// GetMediaTranscodeEnabled safely fetches the Configuration value for state's 'MediaTranscodeEnabled' field
+func (st *ConfigState) GetMediaTranscodeEnabled() (v bool) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeEnabled
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeEnabled safely sets the Configuration value for state's 'MediaTranscodeEnabled' field
+func (st *ConfigState) SetMediaTranscodeEnabled(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeEnabled = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeEnabledFlag returns the flag name for the 'MediaTranscodeEnabled' field
+func MediaTranscodeEnabledFlag() string { return "media-transcode-enabled" }
+
+// GetMediaTranscodeEnabled safely fetches the value for global configuration 'MediaTranscodeEnabled' field
+func GetMediaTranscodeEnabled() bool { return global.GetMediaTranscodeEnabled() }
+
+// SetMediaTranscodeEnabled safely sets the value for global configuration 'MediaTranscodeEnabled' field
+func SetMediaTranscodeEnabled(v bool) { global.SetMediaTranscodeEnabled(v) }
+
+// GetMediaTranscodeMaxDimension safely fetches the Configuration value for state's 'MediaTranscodeMaxDimension' field
+func (st *ConfigState) GetMediaTranscodeMaxDimension() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeMaxDimension
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeMaxDimension safely sets the Configuration value for state's 'MediaTranscodeMaxDimension' field
+func (st *ConfigState) SetMediaTranscodeMaxDimension(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeMaxDimension = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeMaxDimensionFlag returns the flag name for the 'MediaTranscodeMaxDimension' field
+func MediaTranscodeMaxDimensionFlag() string { return "media-transcode-max-dimension" }
+
+// GetMediaTranscodeMaxDimension safely fetches the value for global configuration 'MediaTranscodeMaxDimension' field
+func GetMediaTranscodeMaxDimension() int { return global.GetMediaTranscodeMaxDimension() }
+
+// SetMediaTranscodeMaxDimension safely sets the value for global configuration 'MediaTranscodeMaxDimension' field
+func SetMediaTranscodeMaxDimension(v int) { global.SetMediaTranscodeMaxDimension(v) }
+
+// GetMediaTranscodeVideoBitrate safely fetches the Configuration value for state's 'MediaTranscodeVideoBitrate' field
+func (st *ConfigState) GetMediaTranscodeVideoBitrate() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeVideoBitrate
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeVideoBitrate safely sets the Configuration value for state's 'MediaTranscodeVideoBitrate' field
+func (st *ConfigState) SetMediaTranscodeVideoBitrate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeVideoBitrate = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeVideoBitrateFlag returns the flag name for the 'MediaTranscodeVideoBitrate' field
+func MediaTranscodeVideoBitrateFlag() string { return "media-transcode-video-bitrate" }
+
+// GetMediaTranscodeVideoBitrate safely fetches the value for global configuration 'MediaTranscodeVideoBitrate' field
+func GetMediaTranscodeVideoBitrate() int { return global.GetMediaTranscodeVideoBitrate() }
+
+// SetMediaTranscodeVideoBitrate safely sets the value for global configuration 'MediaTranscodeVideoBitrate' field
+func SetMediaTranscodeVideoBitrate(v int) { global.SetMediaTranscodeVideoBitrate(v) }
+
+// GetMediaTranscodeAudioBitrate safely fetches the Configuration value for state's 'MediaTranscodeAudioBitrate' field
+func (st *ConfigState) GetMediaTranscodeAudioBitrate() (v int) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeAudioBitrate
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeAudioBitrate safely sets the Configuration value for state's 'MediaTranscodeAudioBitrate' field
+func (st *ConfigState) SetMediaTranscodeAudioBitrate(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeAudioBitrate = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeAudioBitrateFlag returns the flag name for the 'MediaTranscodeAudioBitrate' field
+func MediaTranscodeAudioBitrateFlag() string { return "media-transcode-audio-bitrate" }
+
+// GetMediaTranscodeAudioBitrate safely fetches the value for global configuration 'MediaTranscodeAudioBitrate' field
+func GetMediaTranscodeAudioBitrate() int { return global.GetMediaTranscodeAudioBitrate() }
+
+// SetMediaTranscodeAudioBitrate safely sets the value for global configuration 'MediaTranscodeAudioBitrate' field
+func SetMediaTranscodeAudioBitrate(v int) { global.SetMediaTranscodeAudioBitrate(v) }
+
+// GetMediaTranscodeAudioCodec safely fetches the Configuration value for state's 'MediaTranscodeAudioCodec' field
+func (st *ConfigState) GetMediaTranscodeAudioCodec() (v string) {
+	st.mutex.RLock()
+	v = st.config.MediaTranscodeAudioCodec
+	st.mutex.RUnlock()
+	return
+}
+
+// SetMediaTranscodeAudioCodec safely sets the Configuration value for state's 'MediaTranscodeAudioCodec' field
+func (st *ConfigState) SetMediaTranscodeAudioCodec(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.MediaTranscodeAudioCodec = v
+	st.reloadToViper()
+}
+
+// MediaTranscodeAudioCodecFlag returns the flag name for the 'MediaTranscodeAudioCodec' field
+func MediaTranscodeAudioCodecFlag() string { return "media-transcode-audio-codec" }
+
+// GetMediaTranscodeAudioCodec safely fetches the value for global configuration 'MediaTranscodeAudioCodec' field
+func GetMediaTranscodeAudioCodec() string { return global.GetMediaTranscodeAudioCodec() }
+
+// SetMediaTranscodeAudioCodec safely sets the value for global configuration 'MediaTranscodeAudioCodec' field
+func SetMediaTranscodeAudioCodec(v string) { global.SetMediaTranscodeAudioCodec(v) }
+
 // GetStorageBackend safely fetches the Configuration value for state's 'StorageBackend' field
 func (st *ConfigState) GetStorageBackend() (v string) {
 	st.mutex.RLock()
@@ -2575,6 +2874,131 @@ func GetAdvancedRateLimitExceptions() []string { return global.GetAdvancedRateLi
 // SetAdvancedRateLimitExceptions safely sets the value for global configuration 'AdvancedRateLimitExceptions' field
 func SetAdvancedRateLimitExceptions(v []string) { global.SetAdvancedRateLimitExceptions(v) }
 
+// GetAdvancedRateLimitAccountRequests safely fetches the Configuration value for state's 'AdvancedRateLimitAccountRequests' field
+func (st *ConfigState) GetAdvancedRateLimitAccountRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitAccountRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitAccountRequests safely sets the Configuration value for state's 'AdvancedRateLimitAccountRequests' field
+func (st *ConfigState) SetAdvancedRateLimitAccountRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitAccountRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitAccountRequestsFlag returns the flag name for the 'AdvancedRateLimitAccountRequests' field
+func AdvancedRateLimitAccountRequestsFlag() string { return "advanced-rate-limit-account-requests" }
+
+// GetAdvancedRateLimitAccountRequests safely fetches the value for global configuration 'AdvancedRateLimitAccountRequests' field
+func GetAdvancedRateLimitAccountRequests() int { return global.GetAdvancedRateLimitAccountRequests() }
+
+// SetAdvancedRateLimitAccountRequests safely sets the value for global configuration 'AdvancedRateLimitAccountRequests' field
+func SetAdvancedRateLimitAccountRequests(v int) { global.SetAdvancedRateLimitAccountRequests(v) }
+
+// GetAdvancedRateLimitWriteRequests safely fetches the Configuration value for state's 'AdvancedRateLimitWriteRequests' field
+func (st *ConfigState) GetAdvancedRateLimitWriteRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitWriteRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitWriteRequests safely sets the Configuration value for state's 'AdvancedRateLimitWriteRequests' field
+func (st *ConfigState) SetAdvancedRateLimitWriteRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitWriteRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitWriteRequestsFlag returns the flag name for the 'AdvancedRateLimitWriteRequests' field
+func AdvancedRateLimitWriteRequestsFlag() string { return "advanced-rate-limit-write-requests" }
+
+// GetAdvancedRateLimitWriteRequests safely fetches the value for global configuration 'AdvancedRateLimitWriteRequests' field
+func GetAdvancedRateLimitWriteRequests() int { return global.GetAdvancedRateLimitWriteRequests() }
+
+// SetAdvancedRateLimitWriteRequests safely sets the value for global configuration 'AdvancedRateLimitWriteRequests' field
+func SetAdvancedRateLimitWriteRequests(v int) { global.SetAdvancedRateLimitWriteRequests(v) }
+
+// GetAdvancedRateLimitSearchRequests safely fetches the Configuration value for state's 'AdvancedRateLimitSearchRequests' field
+func (st *ConfigState) GetAdvancedRateLimitSearchRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitSearchRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitSearchRequests safely sets the Configuration value for state's 'AdvancedRateLimitSearchRequests' field
+func (st *ConfigState) SetAdvancedRateLimitSearchRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitSearchRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitSearchRequestsFlag returns the flag name for the 'AdvancedRateLimitSearchRequests' field
+func AdvancedRateLimitSearchRequestsFlag() string { return "advanced-rate-limit-search-requests" }
+
+// GetAdvancedRateLimitSearchRequests safely fetches the value for global configuration 'AdvancedRateLimitSearchRequests' field
+func GetAdvancedRateLimitSearchRequests() int { return global.GetAdvancedRateLimitSearchRequests() }
+
+// SetAdvancedRateLimitSearchRequests safely sets the value for global configuration 'AdvancedRateLimitSearchRequests' field
+func SetAdvancedRateLimitSearchRequests(v int) { global.SetAdvancedRateLimitSearchRequests(v) }
+
+// GetAdvancedRateLimitMediaRequests safely fetches the Configuration value for state's 'AdvancedRateLimitMediaRequests' field
+func (st *ConfigState) GetAdvancedRateLimitMediaRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitMediaRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitMediaRequests safely sets the Configuration value for state's 'AdvancedRateLimitMediaRequests' field
+func (st *ConfigState) SetAdvancedRateLimitMediaRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitMediaRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitMediaRequestsFlag returns the flag name for the 'AdvancedRateLimitMediaRequests' field
+func AdvancedRateLimitMediaRequestsFlag() string { return "advanced-rate-limit-media-requests" }
+
+// GetAdvancedRateLimitMediaRequests safely fetches the value for global configuration 'AdvancedRateLimitMediaRequests' field
+func GetAdvancedRateLimitMediaRequests() int { return global.GetAdvancedRateLimitMediaRequests() }
+
+// SetAdvancedRateLimitMediaRequests safely sets the value for global configuration 'AdvancedRateLimitMediaRequests' field
+func SetAdvancedRateLimitMediaRequests(v int) { global.SetAdvancedRateLimitMediaRequests(v) }
+
+// GetAdvancedRateLimitStatusRequests safely fetches the Configuration value for state's 'AdvancedRateLimitStatusRequests' field
+func (st *ConfigState) GetAdvancedRateLimitStatusRequests() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedRateLimitStatusRequests
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedRateLimitStatusRequests safely sets the Configuration value for state's 'AdvancedRateLimitStatusRequests' field
+func (st *ConfigState) SetAdvancedRateLimitStatusRequests(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedRateLimitStatusRequests = v
+	st.reloadToViper()
+}
+
+// AdvancedRateLimitStatusRequestsFlag returns the flag name for the 'AdvancedRateLimitStatusRequests' field
+func AdvancedRateLimitStatusRequestsFlag() string { return "advanced-rate-limit-status-requests" }
+
+// GetAdvancedRateLimitStatusRequests safely fetches the value for global configuration 'AdvancedRateLimitStatusRequests' field
+func GetAdvancedRateLimitStatusRequests() int { return global.GetAdvancedRateLimitStatusRequests() }
+
+// SetAdvancedRateLimitStatusRequests safely sets the value for global configuration 'AdvancedRateLimitStatusRequests' field
+func SetAdvancedRateLimitStatusRequests(v int) { global.SetAdvancedRateLimitStatusRequests(v) }
+
 // GetAdvancedThrottlingMultiplier safely fetches the Configuration value for state's 'AdvancedThrottlingMultiplier' field
 func (st *ConfigState) GetAdvancedThrottlingMultiplier() (v int) {
 	st.mutex.RLock()
@@ -2700,6 +3124,81 @@ func GetAdvancedHeaderFilterMode() string { return global.GetAdvancedHeaderFilte
 // SetAdvancedHeaderFilterMode safely sets the value for global configuration 'AdvancedHeaderFilterMode' field
 func SetAdvancedHeaderFilterMode(v string) { global.SetAdvancedHeaderFilterMode(v) }
 
+// GetAdvancedDeliveryJournal safely fetches the Configuration value for state's 'AdvancedDeliveryJournal' field
+func (st *ConfigState) GetAdvancedDeliveryJournal() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryJournal
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryJournal safely sets the Configuration value for state's 'AdvancedDeliveryJournal' field
+func (st *ConfigState) SetAdvancedDeliveryJournal(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryJournal = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryJournalFlag returns the flag name for the 'AdvancedDeliveryJournal' field
+func AdvancedDeliveryJournalFlag() string { return "advanced-delivery-journal" }
+
+// GetAdvancedDeliveryJournal safely fetches the value for global configuration 'AdvancedDeliveryJournal' field
+func GetAdvancedDeliveryJournal() bool { return global.GetAdvancedDeliveryJournal() }
+
+// SetAdvancedDeliveryJournal safely sets the value for global configuration 'AdvancedDeliveryJournal' field
+func SetAdvancedDeliveryJournal(v bool) { global.SetAdvancedDeliveryJournal(v) }
+
+// GetAdvancedDeliveryParkAfter safely fetches the Configuration value for state's 'AdvancedDeliveryParkAfter' field
+func (st *ConfigState) GetAdvancedDeliveryParkAfter() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryParkAfter
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryParkAfter safely sets the Configuration value for state's 'AdvancedDeliveryParkAfter' field
+func (st *ConfigState) SetAdvancedDeliveryParkAfter(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryParkAfter = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryParkAfterFlag returns the flag name for the 'AdvancedDeliveryParkAfter' field
+func AdvancedDeliveryParkAfterFlag() string { return "advanced-delivery-park-after" }
+
+// GetAdvancedDeliveryParkAfter safely fetches the value for global configuration 'AdvancedDeliveryParkAfter' field
+func GetAdvancedDeliveryParkAfter() int { return global.GetAdvancedDeliveryParkAfter() }
+
+// SetAdvancedDeliveryParkAfter safely sets the value for global configuration 'AdvancedDeliveryParkAfter' field
+func SetAdvancedDeliveryParkAfter(v int) { global.SetAdvancedDeliveryParkAfter(v) }
+
+// GetAdvancedDeliveryParkFor safely fetches the Configuration value for state's 'AdvancedDeliveryParkFor' field
+func (st *ConfigState) GetAdvancedDeliveryParkFor() (v time.Duration) {
+	st.mutex.RLock()
+	v = st.config.AdvancedDeliveryParkFor
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdvancedDeliveryParkFor safely sets the Configuration value for state's 'AdvancedDeliveryParkFor' field
+func (st *ConfigState) SetAdvancedDeliveryParkFor(v time.Duration) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdvancedDeliveryParkFor = v
+	st.reloadToViper()
+}
+
+// AdvancedDeliveryParkForFlag returns the flag name for the 'AdvancedDeliveryParkFor' field
+func AdvancedDeliveryParkForFlag() string { return "advanced-delivery-park-for" }
+
+// GetAdvancedDeliveryParkFor safely fetches the value for global configuration 'AdvancedDeliveryParkFor' field
+func GetAdvancedDeliveryParkFor() time.Duration { return global.GetAdvancedDeliveryParkFor() }
+
+// SetAdvancedDeliveryParkFor safely sets the value for global configuration 'AdvancedDeliveryParkFor' field
+func SetAdvancedDeliveryParkFor(v time.Duration) { global.SetAdvancedDeliveryParkFor(v) }
+
 // GetHTTPClientAllowIPs safely fetches the Configuration value for state's 'HTTPClient.AllowIPs' field
 func (st *ConfigState) GetHTTPClientAllowIPs() (v []string) {
 	st.mutex.RLock()
@@ -3712,6 +4211,31 @@ func GetCacheReportMemRatio() float64 { return global.GetCacheReportMemRatio() }
 // SetCacheReportMemRatio safely sets the value for global configuration 'Cache.ReportMemRatio' field
 func SetCacheReportMemRatio(v float64) { global.SetCacheReportMemRatio(v) }
 
+// GetCacheScheduledStatusMemRatio safely fetches the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) GetCacheScheduledStatusMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.ScheduledStatusMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheScheduledStatusMemRatio safely sets the Configuration value for state's 'Cache.ScheduledStatusMemRatio' field
+func (st *ConfigState) SetCacheScheduledStatusMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.ScheduledStatusMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheScheduledStatusMemRatioFlag returns the flag name for the 'Cache.ScheduledStatusMemRatio' field
+func CacheScheduledStatusMemRatioFlag() string { return "cache-scheduled-status-mem-ratio" }
+
+// GetCacheScheduledStatusMemRatio safely fetches the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func GetCacheScheduledStatusMemRatio() float64 { return global.GetCacheScheduledStatusMemRatio() }
+
+// SetCacheScheduledStatusMemRatio safely sets the value for global configuration 'Cache.ScheduledStatusMemRatio' field
+func SetCacheScheduledStatusMemRatio(v float64) { global.SetCacheScheduledStatusMemRatio(v) }
+
 // GetCacheStatusMemRatio safely fetches the Configuration value for state's 'Cache.StatusMemRatio' field
 func (st *ConfigState) GetCacheStatusMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -3787,6 +4311,31 @@ func GetCacheStatusBookmarkIDsMemRatio() float64 { return global.GetCacheStatusB
 // SetCacheStatusBookmarkIDsMemRatio safely sets the value for global configuration 'Cache.StatusBookmarkIDsMemRatio' field
 func SetCacheStatusBookmarkIDsMemRatio(v float64) { global.SetCacheStatusBookmarkIDsMemRatio(v) }
 
+// GetCacheStatusEditMemRatio safely fetches the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) GetCacheStatusEditMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.StatusEditMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheStatusEditMemRatio safely sets the Configuration value for state's 'Cache.StatusEditMemRatio' field
+func (st *ConfigState) SetCacheStatusEditMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.StatusEditMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheStatusEditMemRatioFlag returns the flag name for the 'Cache.StatusEditMemRatio' field
+func CacheStatusEditMemRatioFlag() string { return "cache-status-edit-mem-ratio" }
+
+// GetCacheStatusEditMemRatio safely fetches the value for global configuration 'Cache.StatusEditMemRatio' field
+func GetCacheStatusEditMemRatio() float64 { return global.GetCacheStatusEditMemRatio() }
+
+// SetCacheStatusEditMemRatio safely sets the value for global configuration 'Cache.StatusEditMemRatio' field
+func SetCacheStatusEditMemRatio(v float64) { global.SetCacheStatusEditMemRatio(v) }
+
 // GetCacheStatusFaveMemRatio safely fetches the Configuration value for state's 'Cache.StatusFaveMemRatio' field
 func (st *ConfigState) GetCacheStatusFaveMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4043,6 +4592,64 @@ func GetCacheUserMuteIDsMemRatio() float64 { return global.GetCacheUserMuteIDsMe
 // SetCacheUserMuteIDsMemRatio safely sets the value for global configuration 'Cache.UserMuteIDsMemRatio' field
 func SetCacheUserMuteIDsMemRatio(v float64) { global.SetCacheUserMuteIDsMemRatio(v) }
 
+// GetCacheWebPushSubscriptionMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionMemRatio' field
+func CacheWebPushSubscriptionMemRatioFlag() string { return "cache-web-push-subscription-mem-ratio" }
+
+// GetCacheWebPushSubscriptionMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func GetCacheWebPushSubscriptionMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionMemRatio()
+}
+
+// SetCacheWebPushSubscriptionMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionMemRatio' field
+func SetCacheWebPushSubscriptionMemRatio(v float64) { global.SetCacheWebPushSubscriptionMemRatio(v) }
+
+// GetCacheWebPushSubscriptionIDsMemRatio safely fetches the Configuration value for state's 'Cache.WebPushSubscriptionIDsMemRatio' field
+func (st *ConfigState) GetCacheWebPushSubscriptionIDsMemRatio() (v float64) {
+	st.mutex.RLock()
+	v = st.config.Cache.WebPushSubscriptionIDsMemRatio
+	st.mutex.RUnlock()
+	return
+}
+
+// SetCacheWebPushSubscriptionIDsMemRatio safely sets the Configuration value for state's 'Cache.WebPushSubscriptionIDsMemRatio' field
+func (st *ConfigState) SetCacheWebPushSubscriptionIDsMemRatio(v float64) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.Cache.WebPushSubscriptionIDsMemRatio = v
+	st.reloadToViper()
+}
+
+// CacheWebPushSubscriptionIDsMemRatioFlag returns the flag name for the 'Cache.WebPushSubscriptionIDsMemRatio' field
+func CacheWebPushSubscriptionIDsMemRatioFlag() string {
+	return "cache-web-push-subscription-ids-mem-ratio"
+}
+
+// GetCacheWebPushSubscriptionIDsMemRatio safely fetches the value for global configuration 'Cache.WebPushSubscriptionIDsMemRatio' field
+func GetCacheWebPushSubscriptionIDsMemRatio() float64 {
+	return global.GetCacheWebPushSubscriptionIDsMemRatio()
+}
+
+// SetCacheWebPushSubscriptionIDsMemRatio safely sets the value for global configuration 'Cache.WebPushSubscriptionIDsMemRatio' field
+func SetCacheWebPushSubscriptionIDsMemRatio(v float64) {
+	global.SetCacheWebPushSubscriptionIDsMemRatio(v)
+}
+
 // GetCacheWebfingerMemRatio safely fetches the Configuration value for state's 'Cache.WebfingerMemRatio' field
 func (st *ConfigState) GetCacheWebfingerMemRatio() (v float64) {
 	st.mutex.RLock()
@@ -4193,6 +4800,81 @@ func GetAdminTransPath() string { return global.GetAdminTransPath() }
 // SetAdminTransPath safely sets the value for global configuration 'AdminTransPath' field
 func SetAdminTransPath(v string) { global.SetAdminTransPath(v) }
 
+// GetAdminTransFull safely fetches the Configuration value for state's 'AdminTransFull' field
+func (st *ConfigState) GetAdminTransFull() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransFull
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransFull safely sets the Configuration value for state's 'AdminTransFull' field
+func (st *ConfigState) SetAdminTransFull(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransFull = v
+	st.reloadToViper()
+}
+
+// AdminTransFullFlag returns the flag name for the 'AdminTransFull' field
+func AdminTransFullFlag() string { return "full" }
+
+// GetAdminTransFull safely fetches the value for global configuration 'AdminTransFull' field
+func GetAdminTransFull() bool { return global.GetAdminTransFull() }
+
+// SetAdminTransFull safely sets the value for global configuration 'AdminTransFull' field
+func SetAdminTransFull(v bool) { global.SetAdminTransFull(v) }
+
+// GetAdminTransSince safely fetches the Configuration value for state's 'AdminTransSince' field
+func (st *ConfigState) GetAdminTransSince() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminTransSince
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransSince safely sets the Configuration value for state's 'AdminTransSince' field
+func (st *ConfigState) SetAdminTransSince(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransSince = v
+	st.reloadToViper()
+}
+
+// AdminTransSinceFlag returns the flag name for the 'AdminTransSince' field
+func AdminTransSinceFlag() string { return "since" }
+
+// GetAdminTransSince safely fetches the value for global configuration 'AdminTransSince' field
+func GetAdminTransSince() string { return global.GetAdminTransSince() }
+
+// SetAdminTransSince safely sets the value for global configuration 'AdminTransSince' field
+func SetAdminTransSince(v string) { global.SetAdminTransSince(v) }
+
+// GetAdminTransSkipMedia safely fetches the Configuration value for state's 'AdminTransSkipMedia' field
+func (st *ConfigState) GetAdminTransSkipMedia() (v bool) {
+	st.mutex.RLock()
+	v = st.config.AdminTransSkipMedia
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminTransSkipMedia safely sets the Configuration value for state's 'AdminTransSkipMedia' field
+func (st *ConfigState) SetAdminTransSkipMedia(v bool) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminTransSkipMedia = v
+	st.reloadToViper()
+}
+
+// AdminTransSkipMediaFlag returns the flag name for the 'AdminTransSkipMedia' field
+func AdminTransSkipMediaFlag() string { return "skip-media" }
+
+// GetAdminTransSkipMedia safely fetches the value for global configuration 'AdminTransSkipMedia' field
+func GetAdminTransSkipMedia() bool { return global.GetAdminTransSkipMedia() }
+
+// SetAdminTransSkipMedia safely sets the value for global configuration 'AdminTransSkipMedia' field
+func SetAdminTransSkipMedia(v bool) { global.SetAdminTransSkipMedia(v) }
+
 // GetAdminMediaPruneDryRun safely fetches the Configuration value for state's 'AdminMediaPruneDryRun' field
 func (st *ConfigState) GetAdminMediaPruneDryRun() (v bool) {
 	st.mutex.RLock()
@@ -4268,6 +4950,81 @@ func GetAdminMediaListRemoteOnly() bool { return global.GetAdminMediaListRemoteO
 // SetAdminMediaListRemoteOnly safely sets the value for global configuration 'AdminMediaListRemoteOnly' field
 func SetAdminMediaListRemoteOnly(v bool) { global.SetAdminMediaListRemoteOnly(v) }
 
+// GetAdminDbMigrateTargetType safely fetches the Configuration value for state's 'AdminDbMigrateTargetType' field
+func (st *ConfigState) GetAdminDbMigrateTargetType() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDbMigrateTargetType
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDbMigrateTargetType safely sets the Configuration value for state's 'AdminDbMigrateTargetType' field
+func (st *ConfigState) SetAdminDbMigrateTargetType(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDbMigrateTargetType = v
+	st.reloadToViper()
+}
+
+// AdminDbMigrateTargetTypeFlag returns the flag name for the 'AdminDbMigrateTargetType' field
+func AdminDbMigrateTargetTypeFlag() string { return "target-db-type" }
+
+// GetAdminDbMigrateTargetType safely fetches the value for global configuration 'AdminDbMigrateTargetType' field
+func GetAdminDbMigrateTargetType() string { return global.GetAdminDbMigrateTargetType() }
+
+// SetAdminDbMigrateTargetType safely sets the value for global configuration 'AdminDbMigrateTargetType' field
+func SetAdminDbMigrateTargetType(v string) { global.SetAdminDbMigrateTargetType(v) }
+
+// GetAdminDbMigrateTargetAddress safely fetches the Configuration value for state's 'AdminDbMigrateTargetAddress' field
+func (st *ConfigState) GetAdminDbMigrateTargetAddress() (v string) {
+	st.mutex.RLock()
+	v = st.config.AdminDbMigrateTargetAddress
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDbMigrateTargetAddress safely sets the Configuration value for state's 'AdminDbMigrateTargetAddress' field
+func (st *ConfigState) SetAdminDbMigrateTargetAddress(v string) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDbMigrateTargetAddress = v
+	st.reloadToViper()
+}
+
+// AdminDbMigrateTargetAddressFlag returns the flag name for the 'AdminDbMigrateTargetAddress' field
+func AdminDbMigrateTargetAddressFlag() string { return "target-db-address" }
+
+// GetAdminDbMigrateTargetAddress safely fetches the value for global configuration 'AdminDbMigrateTargetAddress' field
+func GetAdminDbMigrateTargetAddress() string { return global.GetAdminDbMigrateTargetAddress() }
+
+// SetAdminDbMigrateTargetAddress safely sets the value for global configuration 'AdminDbMigrateTargetAddress' field
+func SetAdminDbMigrateTargetAddress(v string) { global.SetAdminDbMigrateTargetAddress(v) }
+
+// GetAdminDbMigrateBatchSize safely fetches the Configuration value for state's 'AdminDbMigrateBatchSize' field
+func (st *ConfigState) GetAdminDbMigrateBatchSize() (v int) {
+	st.mutex.RLock()
+	v = st.config.AdminDbMigrateBatchSize
+	st.mutex.RUnlock()
+	return
+}
+
+// SetAdminDbMigrateBatchSize safely sets the Configuration value for state's 'AdminDbMigrateBatchSize' field
+func (st *ConfigState) SetAdminDbMigrateBatchSize(v int) {
+	st.mutex.Lock()
+	defer st.mutex.Unlock()
+	st.config.AdminDbMigrateBatchSize = v
+	st.reloadToViper()
+}
+
+// AdminDbMigrateBatchSizeFlag returns the flag name for the 'AdminDbMigrateBatchSize' field
+func AdminDbMigrateBatchSizeFlag() string { return "batch-size" }
+
+// GetAdminDbMigrateBatchSize safely fetches the value for global configuration 'AdminDbMigrateBatchSize' field
+func GetAdminDbMigrateBatchSize() int { return global.GetAdminDbMigrateBatchSize() }
+
+// SetAdminDbMigrateBatchSize safely sets the value for global configuration 'AdminDbMigrateBatchSize' field
+func SetAdminDbMigrateBatchSize(v int) { global.SetAdminDbMigrateBatchSize(v) }
+
 // GetRequestIDHeader safely fetches the Configuration value for state's 'RequestIDHeader' field
 func (st *ConfigState) GetRequestIDHeader() (v string) {
 	st.mutex.RLock()
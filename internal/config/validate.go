@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -100,6 +101,51 @@ func Validate() error {
 		)
 	}
 
+	// `media-transcode-audio-codec` should
+	// be "aac" or "opus", if transcoding.
+	if GetMediaTranscodeEnabled() {
+		switch codec := GetMediaTranscodeAudioCodec(); codec {
+		case MediaTranscodeAudioCodecAAC, MediaTranscodeAudioCodecOpus:
+			// No problem.
+
+		default:
+			errf(
+				"%s must be set to either aac or opus, provided value was %s",
+				MediaTranscodeAudioCodecFlag(), codec,
+			)
+		}
+
+		if GetMediaTranscodeMaxDimension() <= 0 {
+			errf("%s must be greater than 0", MediaTranscodeMaxDimensionFlag())
+		}
+
+		if GetMediaTranscodeVideoBitrate() <= 0 ||
+			GetMediaTranscodeAudioBitrate() <= 0 {
+			errf(
+				"%s and %s must be greater than 0",
+				MediaTranscodeVideoBitrateFlag(), MediaTranscodeAudioBitrateFlag(),
+			)
+		}
+	}
+
+	// `instance-subscriptions-process-from`
+	// should be a time of day as hh:mm.
+	if from := GetInstanceSubscriptionsProcessFrom(); from != "" {
+		if _, err := time.Parse("15:04", from); err != nil {
+			errf(
+				"%s must be a time of day in the format hh:mm, eg., 23:00, provided value was %s",
+				InstanceSubscriptionsProcessFromFlag(), from,
+			)
+		}
+	}
+
+	// `instance-subscriptions-process-every`
+	// must be positive, else it would never
+	// get to the next run.
+	if GetInstanceSubscriptionsProcessEvery() <= 0 {
+		errf("%s must be greater than 0", InstanceSubscriptionsProcessEveryFlag())
+	}
+
 	// Parse `instance-languages`, and
 	// set enriched version into config.
 	parsedLangs, err := language.InitLangs(GetInstanceLanguages().TagStrs())
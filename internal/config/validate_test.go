@@ -140,6 +140,24 @@ func (suite *ConfigValidateTestSuite) TestValidateConfigBadProtocolNoHost() {
 	suite.EqualError(err, "host must be set\nprotocol must be set to either http or https, provided value was foo")
 }
 
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadSubscriptionsProcessFrom() {
+	testrig.InitTestConfig()
+
+	config.SetInstanceSubscriptionsProcessFrom("23:00:00")
+
+	err := config.Validate()
+	suite.EqualError(err, "instance-subscriptions-process-from must be a time of day in the format hh:mm, eg., 23:00, provided value was 23:00:00")
+}
+
+func (suite *ConfigValidateTestSuite) TestValidateConfigBadSubscriptionsProcessEvery() {
+	testrig.InitTestConfig()
+
+	config.SetInstanceSubscriptionsProcessEvery(0)
+
+	err := config.Validate()
+	suite.EqualError(err, "instance-subscriptions-process-every must be greater than 0")
+}
+
 func TestConfigValidateTestSuite(t *testing.T) {
 	suite.Run(t, &ConfigValidateTestSuite{})
 }
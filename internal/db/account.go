@@ -45,6 +45,9 @@ type Account interface {
 	// GetAccountByPubkeyID returns one account with the given public key URI (ID), or an error if something goes wrong.
 	GetAccountByPubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error)
 
+	// GetAccountByEd25519PubkeyID returns one account with the given Ed25519 public key URI (ID), or an error if something goes wrong.
+	GetAccountByEd25519PubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error)
+
 	// GetAccountByInboxURI returns one account with the given inbox_uri, or an error if something goes wrong.
 	GetAccountByInboxURI(ctx context.Context, uri string) (*gtsmodel.Account, error)
 
@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 // Admin contains functions related to instance administration (new signups etc).
@@ -86,4 +87,25 @@ type Admin interface {
 
 	// DeleteAdminAction deletes admin action with the given ID.
 	DeleteAdminAction(ctx context.Context, id string) error
+
+	/*
+		AUDIT LOG FUNCS
+	*/
+
+	// GetAuditLogEntryByID returns the audit log entry with the given ID.
+	GetAuditLogEntryByID(ctx context.Context, id string) (*gtsmodel.AuditLogEntry, error)
+
+	// GetAuditLogEntries returns a page of audit log entries, newest first,
+	// optionally filtered by any of the given non-empty parameters.
+	GetAuditLogEntries(
+		ctx context.Context,
+		accountID string,
+		action string,
+		targetType gtsmodel.AuditLogTargetType,
+		targetID string,
+		page *paging.Page,
+	) ([]*gtsmodel.AuditLogEntry, error)
+
+	// PutAuditLogEntry puts one audit log entry in the database.
+	PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error
 }
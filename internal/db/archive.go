@@ -0,0 +1,42 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Archive interface {
+	// GetArchiveByID gets one archive with the given ID.
+	GetArchiveByID(ctx context.Context, id string) (*gtsmodel.Archive, error)
+
+	// GetArchivesByAccountID gets all archives
+	// belonging to the given account, newest first.
+	GetArchivesByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Archive, error)
+
+	// PutArchive puts the given archive in the database.
+	PutArchive(ctx context.Context, archive *gtsmodel.Archive) error
+
+	// UpdateArchive updates the given archive in the database.
+	UpdateArchive(ctx context.Context, archive *gtsmodel.Archive, columns ...string) error
+
+	// DeleteArchiveByID deletes one archive with the given ID.
+	DeleteArchiveByID(ctx context.Context, id string) error
+}
@@ -35,6 +35,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 	"github.com/uptrace/bun/dialect"
@@ -182,6 +183,20 @@ func (a *accountDB) GetAccountByPubkeyID(ctx context.Context, id string) (*gtsmo
 	)
 }
 
+func (a *accountDB) GetAccountByEd25519PubkeyID(ctx context.Context, id string) (*gtsmodel.Account, error) {
+	return a.getAccount(
+		ctx,
+		"Ed25519PublicKeyURI",
+		func(account *gtsmodel.Account) error {
+			return a.db.NewSelect().
+				Model(account).
+				Where("? = ?", bun.Ident("account.ed25519_public_key_uri"), id).
+				Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *accountDB) GetAccountByInboxURI(ctx context.Context, uri string) (*gtsmodel.Account, error) {
 	return a.getAccount(
 		ctx,
@@ -721,6 +736,10 @@ func (a *accountDB) PopulateAccount(ctx context.Context, account *gtsmodel.Accou
 }
 
 func (a *accountDB) PutAccount(ctx context.Context, account *gtsmodel.Account) error {
+	// Keep the plaintext version used
+	// for full-text search up to date.
+	account.NotePlain = text.SanitizeToSearchText(account.Note)
+
 	return a.state.Caches.DB.Account.Store(account, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -750,6 +769,15 @@ func (a *accountDB) UpdateAccount(ctx context.Context, account *gtsmodel.Account
 		columns = append(columns, "updated_at")
 	}
 
+	if len(columns) == 0 || slices.Contains(columns, "note") {
+		// Keep the plaintext version used
+		// for full-text search up to date.
+		account.NotePlain = text.SanitizeToSearchText(account.Note)
+		if len(columns) > 0 {
+			columns = append(columns, "note_plain")
+		}
+	}
+
 	return a.state.Caches.DB.Account.Store(account, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -19,11 +19,13 @@ package bundb
 
 import (
 	"context"
+	"crypto/ed25519"
 	"crypto/rand"
 	"crypto/rsa"
 	"errors"
 	"fmt"
 	"net/mail"
+	"slices"
 	"strings"
 	"time"
 
@@ -35,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -120,6 +123,12 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			return nil, err
 		}
 
+		edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			err := gtserror.Newf("error creating new ed25519 private key: %w", err)
+			return nil, err
+		}
+
 		account = &gtsmodel.Account{
 			ID:                    accountID,
 			Username:              newSignup.Username,
@@ -135,6 +144,9 @@ func (a *adminDB) NewSignup(ctx context.Context, newSignup gtsmodel.NewSignup) (
 			PrivateKey:            privKey,
 			PublicKey:             &privKey.PublicKey,
 			PublicKeyURI:          uris.PublicKeyURI,
+			Ed25519PrivateKey:     edPrivKey,
+			Ed25519PublicKey:      edPubKey,
+			Ed25519PublicKeyURI:   uris.Ed25519PublicKeyURI,
 		}
 
 		// Insert the new account!
@@ -258,6 +270,12 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		return err
 	}
 
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		log.Errorf(ctx, "error creating new ed25519 key: %s", err)
+		return err
+	}
+
 	aID, err := id.NewRandomULID()
 	if err != nil {
 		return err
@@ -272,6 +290,9 @@ func (a *adminDB) CreateInstanceAccount(ctx context.Context) error {
 		PrivateKey:            key,
 		PublicKey:             &key.PublicKey,
 		PublicKeyURI:          newAccountURIs.PublicKeyURI,
+		Ed25519PrivateKey:     edPrivKey,
+		Ed25519PublicKey:      edPubKey,
+		Ed25519PublicKeyURI:   newAccountURIs.Ed25519PublicKeyURI,
 		ActorType:             ap.ActorPerson,
 		URI:                   newAccountURIs.UserURI,
 		InboxURI:              newAccountURIs.InboxURI,
@@ -507,3 +528,108 @@ func (a *adminDB) DeleteAdminAction(ctx context.Context, id string) error {
 
 	return err
 }
+
+func (a *adminDB) GetAuditLogEntryByID(ctx context.Context, id string) (*gtsmodel.AuditLogEntry, error) {
+	entry := new(gtsmodel.AuditLogEntry)
+
+	if err := a.db.
+		NewSelect().
+		Model(entry).
+		Where("? = ?", bun.Ident("audit_log_entry.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (a *adminDB) GetAuditLogEntries(
+	ctx context.Context,
+	accountID string,
+	action string,
+	targetType gtsmodel.AuditLogTargetType,
+	targetID string,
+	page *paging.Page,
+) ([]*gtsmodel.AuditLogEntry, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		entries = make([]*gtsmodel.AuditLogEntry, 0, limit)
+	)
+
+	q := a.db.
+		NewSelect().
+		Model(&entries)
+
+	// Add any filters that were given.
+	if accountID != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.account_id"), accountID)
+	}
+
+	if action != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.action"), action)
+	}
+
+	if targetType != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.target_type"), targetType)
+	}
+
+	if targetID != "" {
+		q = q.Where("? = ?", bun.Ident("audit_log_entry.target_id"), targetID)
+	}
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("audit_log_entry.id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("audit_log_entry.id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("audit_log_entry.id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("audit_log_entry.id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(entries) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want entries
+	// to be sorted by ID desc, so reverse slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(entries)
+	}
+
+	return entries, nil
+}
+
+func (a *adminDB) PutAuditLogEntry(ctx context.Context, entry *gtsmodel.AuditLogEntry) error {
+	_, err := a.db.
+		NewInsert().
+		Model(entry).
+		Exec(ctx)
+
+	return err
+}
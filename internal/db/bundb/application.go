@@ -19,8 +19,12 @@ package bundb
 
 import (
 	"context"
+	"slices"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
@@ -143,6 +147,75 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 		return nil, err
 	}
 
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) GetAccessTokens(ctx context.Context, userID string, page *paging.Page) ([]*gtsmodel.Token, error) {
+	var (
+		// Get paging params.
+		minID = page.GetMin()
+		maxID = page.GetMax()
+		limit = page.GetLimit()
+		order = page.GetOrder()
+
+		// Make educated guess for slice size
+		tokenIDs = make([]string, 0, limit)
+	)
+
+	// Select IDs of this user's tokens that
+	// hold an access token, ie., ignore any
+	// outstanding authorization codes.
+	q := a.db.
+		NewSelect().
+		Table("tokens").
+		Column("id").
+		Where("? = ?", bun.Ident("user_id"), userID).
+		Where("? != ''", bun.Ident("access"))
+
+	// Add paging param max ID.
+	if maxID != "" {
+		q = q.Where("? < ?", bun.Ident("id"), maxID)
+	}
+
+	// Add paging param min ID.
+	if minID != "" {
+		q = q.Where("? > ?", bun.Ident("id"), minID)
+	}
+
+	// Add paging param order.
+	if order == paging.OrderAscending {
+		// Page up.
+		q = q.OrderExpr("? ASC", bun.Ident("id"))
+	} else {
+		// Page down.
+		q = q.OrderExpr("? DESC", bun.Ident("id"))
+	}
+
+	// Add paging param limit.
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	// Execute the query and scan into IDs.
+	if err := q.Scan(ctx, &tokenIDs); err != nil {
+		return nil, err
+	}
+
+	// Catch case of no items early
+	if len(tokenIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	// If we're paging up, we still want tokens
+	// to be sorted by ID desc, so reverse ids slice.
+	if order == paging.OrderAscending {
+		slices.Reverse(tokenIDs)
+	}
+
+	return a.getTokensByIDs(ctx, tokenIDs)
+}
+
+func (a *applicationDB) getTokensByIDs(ctx context.Context, tokenIDs []string) ([]*gtsmodel.Token, error) {
 	// Load all input token IDs via cache loader callback.
 	tokens, err := a.state.Caches.DB.Token.LoadIDs("ID",
 		tokenIDs,
@@ -174,6 +247,16 @@ func (a *applicationDB) GetAllTokens(ctx context.Context) ([]*gtsmodel.Token, er
 	return tokens, nil
 }
 
+func (a *applicationDB) GetTokenByID(ctx context.Context, id string) (*gtsmodel.Token, error) {
+	return a.getTokenBy(
+		"ID",
+		func(t *gtsmodel.Token) error {
+			return a.db.NewSelect().Model(t).Where("? = ?", bun.Ident("id"), id).Scan(ctx)
+		},
+		id,
+	)
+}
+
 func (a *applicationDB) GetTokenByCode(ctx context.Context, code string) (*gtsmodel.Token, error) {
 	return a.getTokenBy(
 		"Code",
@@ -223,6 +306,24 @@ func (a *applicationDB) PutToken(ctx context.Context, token *gtsmodel.Token) err
 	})
 }
 
+func (a *applicationDB) UpdateToken(ctx context.Context, token *gtsmodel.Token, columns ...string) error {
+	token.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return a.state.Caches.DB.Token.Store(token, func() error {
+		_, err := a.db.NewUpdate().
+			Model(token).
+			Where("? = ?", bun.Ident("id"), token.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
 func (a *applicationDB) DeleteTokenByID(ctx context.Context, id string) error {
 	_, err := a.db.NewDelete().
 		Table("tokens").
@@ -27,6 +27,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
 )
 
 type ApplicationTestSuite struct {
@@ -131,6 +132,40 @@ func (suite *ApplicationTestSuite) TestGetAllTokens() {
 	suite.NotEmpty(tokens)
 }
 
+func (suite *ApplicationTestSuite) TestGetAccessTokens() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	tokens, err := suite.db.GetAccessTokens(ctx, user.ID, &paging.Page{Limit: 20})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The user's authorization code shouldn't be included.
+	if suite.Len(tokens, 1) {
+		suite.Equal(suite.testTokens["local_account_1"].ID, tokens[0].ID)
+	}
+}
+
+func (suite *ApplicationTestSuite) TestUpdateTokenLastUsed() {
+	ctx := context.Background()
+	token := suite.testTokens["local_account_1"]
+
+	lastUsed := time.Now().Truncate(time.Second)
+	token.LastUsed = lastUsed
+	if err := suite.db.UpdateToken(ctx, token, "last_used"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Bypass the cache to check it was stored.
+	suite.state.Caches.DB.Token.Invalidate("ID", token.ID)
+	dbToken, err := suite.db.GetTokenByID(ctx, token.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(lastUsed.Equal(dbToken.LastUsed))
+}
+
 func TestApplicationTestSuite(t *testing.T) {
 	suite.Run(t, new(ApplicationTestSuite))
 }
@@ -56,34 +56,44 @@ type DBService struct {
 	db.Admin
 	db.AdvancedMigration
 	db.Application
+	db.Archive
 	db.Basic
 	db.Conversation
+	db.Delivery
 	db.Domain
 	db.Emoji
 	db.HeaderFilter
+	db.Import
 	db.Instance
 	db.Interaction
 	db.Filter
+	db.Group
 	db.List
 	db.Marker
 	db.Media
+	db.MediaHashBlock
 	db.Mention
 	db.Move
 	db.Notification
 	db.Poll
+	db.Relay
 	db.Relationship
 	db.Report
 	db.Rule
+	db.ScheduledStatus
 	db.Search
 	db.Session
 	db.Status
 	db.StatusBookmark
+	db.StatusEdit
 	db.StatusFave
 	db.Tag
 	db.Thread
 	db.Timeline
 	db.User
 	db.Tombstone
+	db.Trend
+	db.WebPush
 	db.WorkerTask
 	db *bun.DB
 }
@@ -193,6 +203,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Archive: &archiveDB{
+			db:    db,
+			state: state,
+		},
 		Basic: &basicDB{
 			db: db,
 		},
@@ -200,6 +214,9 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Delivery: &deliveryDB{
+			db: db,
+		},
 		Domain: &domainDB{
 			db:    db,
 			state: state,
@@ -212,6 +229,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Import: &importDB{
+			db:    db,
+			state: state,
+		},
 		Instance: &instanceDB{
 			db:    db,
 			state: state,
@@ -224,6 +245,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Group: &groupDB{
+			db:    db,
+			state: state,
+		},
 		List: &listDB{
 			db:    db,
 			state: state,
@@ -236,6 +261,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		MediaHashBlock: &mediaHashBlockDB{
+			db:    db,
+			state: state,
+		},
 		Mention: &mentionDB{
 			db:    db,
 			state: state,
@@ -252,6 +281,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Relay: &relayDB{
+			db:    db,
+			state: state,
+		},
 		Relationship: &relationshipDB{
 			db:    db,
 			state: state,
@@ -264,6 +297,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		ScheduledStatus: &scheduledStatusDB{
+			db:    db,
+			state: state,
+		},
 		Search: &searchDB{
 			db:    db,
 			state: state,
@@ -279,6 +316,10 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		StatusEdit: &statusEditDB{
+			db:    db,
+			state: state,
+		},
 		StatusFave: &statusFaveDB{
 			db:    db,
 			state: state,
@@ -303,6 +344,13 @@ func NewBunDBService(ctx context.Context, state *state.State) (db.DB, error) {
 			db:    db,
 			state: state,
 		},
+		Trend: &trendDB{
+			db: db,
+		},
+		WebPush: &webPushDB{
+			db:    db,
+			state: state,
+		},
 		WorkerTask: &workerTaskDB{
 			db: db,
 		},
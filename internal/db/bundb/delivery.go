@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+// deliveryDB provides access to the delivery journal. It
+// is deliberately uncached, as journaled deliveries are only
+// ever read on startup and on unparking a domain, and domain
+// delivery states are kept in memory by the delivery journal.
+type deliveryDB struct{ db *bun.DB }
+
+func (d *deliveryDB) GetPendingDeliveries(ctx context.Context) ([]*gtsmodel.PendingDelivery, error) {
+	var deliveries []*gtsmodel.PendingDelivery
+	if err := d.db.NewSelect().
+		Model(&deliveries).
+		Where("? = ?", bun.Ident("parked"), false).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (d *deliveryDB) GetParkedDeliveries(ctx context.Context, domain string) ([]*gtsmodel.PendingDelivery, error) {
+	var deliveries []*gtsmodel.PendingDelivery
+	if err := d.db.NewSelect().
+		Model(&deliveries).
+		Where("? = ?", bun.Ident("domain"), domain).
+		Where("? = ?", bun.Ident("parked"), true).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (d *deliveryDB) PutPendingDeliveries(ctx context.Context, deliveries []*gtsmodel.PendingDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	_, err := d.db.NewInsert().
+		Model(&deliveries).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdatePendingDelivery(ctx context.Context, delivery *gtsmodel.PendingDelivery, columns ...string) error {
+	_, err := d.db.NewUpdate().
+		Model(delivery).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), delivery.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeletePendingDeliveryByID(ctx context.Context, id string) error {
+	_, err := d.db.NewDelete().
+		Table("pending_deliveries").
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeletePendingDeliveriesByIRI(ctx context.Context, iri string) error {
+	_, err := d.db.NewDelete().
+		Table("pending_deliveries").
+		WhereOr("? = ?", bun.Ident("actor_id"), iri).
+		WhereOr("? = ?", bun.Ident("object_id"), iri).
+		WhereOr("? = ?", bun.Ident("target_id"), iri).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) GetDomainDeliveryStates(ctx context.Context) ([]*gtsmodel.DomainDeliveryState, error) {
+	var states []*gtsmodel.DomainDeliveryState
+	if err := d.db.NewSelect().
+		Model(&states).
+		OrderExpr("? ASC", bun.Ident("domain")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return states, nil
+}
+
+func (d *deliveryDB) PutDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState) error {
+	_, err := d.db.NewInsert().
+		Model(state).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) UpdateDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState, columns ...string) error {
+	state.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.NewUpdate().
+		Model(state).
+		Column(columns...).
+		Where("? = ?", bun.Ident("id"), state.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *deliveryDB) DeleteDomainDeliveryState(ctx context.Context, domain string) error {
+	_, err := d.db.NewDelete().
+		Table("domain_delivery_states").
+		Where("? = ?", bun.Ident("domain"), domain).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,158 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type DeliveryTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *DeliveryTestSuite) TestPendingDeliveryLifecycle() {
+	ctx := context.Background()
+
+	pending, err := suite.db.GetPendingDeliveries(ctx)
+	suite.NoError(err)
+	suite.Empty(pending)
+
+	// Journal a couple of deliveries.
+	statusURI := "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY"
+	deliveries := []*gtsmodel.PendingDelivery{
+		{
+			ID:       id.NewULID(),
+			Domain:   "example.org",
+			ActorID:  "http://localhost:8080/users/the_mighty_zork",
+			ObjectID: statusURI,
+			Data:     []byte(`{"url":"https://example.org/inbox"}`),
+			Parked:   util.Ptr(false),
+		},
+		{
+			ID:      id.NewULID(),
+			Domain:  "fossbros-anonymous.io",
+			ActorID: "http://localhost:8080/users/the_mighty_zork",
+			Data:    []byte(`{"url":"https://fossbros-anonymous.io/inbox"}`),
+			Parked:  util.Ptr(false),
+		},
+	}
+	if err := suite.db.PutPendingDeliveries(ctx, deliveries); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pending, err = suite.db.GetPendingDeliveries(ctx)
+	suite.NoError(err)
+	suite.Len(pending, 2)
+
+	// Park the second delivery, it should no
+	// longer be pending, but parked for its domain.
+	parkUntil := time.Now().Add(time.Hour).Truncate(time.Second)
+	deliveries[1].Parked = util.Ptr(true)
+	deliveries[1].Attempts = 3
+	deliveries[1].NextAttemptAt = parkUntil
+	if err := suite.db.UpdatePendingDelivery(ctx,
+		deliveries[1],
+		"parked",
+		"attempts",
+		"next_attempt_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pending, err = suite.db.GetPendingDeliveries(ctx)
+	suite.NoError(err)
+	suite.Len(pending, 1)
+	suite.Equal(deliveries[0].ID, pending[0].ID)
+
+	parked, err := suite.db.GetParkedDeliveries(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Len(parked, 1)
+	suite.Equal(3, parked[0].Attempts)
+	suite.True(parked[0].NextAttemptAt.Equal(parkUntil))
+
+	parked, err = suite.db.GetParkedDeliveries(ctx, "example.org")
+	suite.NoError(err)
+	suite.Empty(parked)
+
+	// Deleting by status IRI should drop the first delivery.
+	if err := suite.db.DeletePendingDeliveriesByIRI(ctx, statusURI); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pending, err = suite.db.GetPendingDeliveries(ctx)
+	suite.NoError(err)
+	suite.Empty(pending)
+
+	// Delete the parked delivery by ID.
+	if err := suite.db.DeletePendingDeliveryByID(ctx, deliveries[1].ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	parked, err = suite.db.GetParkedDeliveries(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Empty(parked)
+}
+
+func (suite *DeliveryTestSuite) TestDomainDeliveryStateLifecycle() {
+	ctx := context.Background()
+
+	state := &gtsmodel.DomainDeliveryState{
+		ID:            id.NewULID(),
+		Domain:        "example.org",
+		Failures:      1,
+		LastFailureAt: time.Now(),
+	}
+	if err := suite.db.PutDomainDeliveryState(ctx, state); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	state.Failures = 20
+	state.ParkedUntil = time.Now().Add(time.Hour)
+	if err := suite.db.UpdateDomainDeliveryState(ctx,
+		state,
+		"failures",
+		"parked_until",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	states, err := suite.db.GetDomainDeliveryStates(ctx)
+	suite.NoError(err)
+	suite.Len(states, 1)
+	suite.Equal(20, states[0].Failures)
+	suite.True(states[0].Parked())
+
+	if err := suite.db.DeleteDomainDeliveryState(ctx, "example.org"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	states, err = suite.db.GetDomainDeliveryStates(ctx)
+	suite.NoError(err)
+	suite.Empty(states)
+}
+
+func TestDeliveryTestSuite(t *testing.T) {
+	suite.Run(t, new(DeliveryTestSuite))
+}
@@ -0,0 +1,222 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+func (d *domainDB) GetDomainPermissionSubscriptionByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, error) {
+	var sub gtsmodel.DomainPermissionSubscription
+
+	if err := d.db.
+		NewSelect().
+		Model(&sub).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (d *domainDB) GetDomainPermissionSubscriptions(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*gtsmodel.DomainPermissionSubscription, error) {
+	subs := []*gtsmodel.DomainPermissionSubscription{}
+
+	q := d.db.
+		NewSelect().
+		Model(&subs).
+		// Highest priority first, then oldest
+		// first, so that ordering is stable.
+		OrderExpr("? DESC", bun.Ident("domain_permission_subscription.priority")).
+		OrderExpr("? ASC", bun.Ident("domain_permission_subscription.id"))
+
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_subscription.permission_type"), permType)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return subs, nil
+}
+
+func (d *domainDB) PutDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) error {
+	_, err := d.db.
+		NewInsert().
+		Model(sub).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) UpdateDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	columns ...string,
+) error {
+	sub.UpdatedAt = time.Now()
+	if len(columns) != 0 {
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := d.db.
+		NewUpdate().
+		Model(sub).
+		Column(columns...).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), sub.ID).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionSubscription(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionSubscription)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_subscription.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) GetDomainPermissionDraftByID(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	var draft gtsmodel.DomainPermissionDraft
+
+	if err := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDraftByDomain(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (*gtsmodel.DomainPermissionDraft, error) {
+	// Normalize the domain as punycode
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return nil, err
+	}
+
+	var draft gtsmodel.DomainPermissionDraft
+
+	if err := d.db.
+		NewSelect().
+		Model(&draft).
+		Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType).
+		Where("? = ?", bun.Ident("domain_permission_draft.domain"), domain).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return &draft, nil
+}
+
+func (d *domainDB) GetDomainPermissionDrafts(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+) ([]*gtsmodel.DomainPermissionDraft, error) {
+	drafts := []*gtsmodel.DomainPermissionDraft{}
+
+	q := d.db.
+		NewSelect().
+		Model(&drafts).
+		OrderExpr("? ASC", bun.Ident("domain_permission_draft.domain"))
+
+	if permType != gtsmodel.DomainPermissionUnknown {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.permission_type"), permType)
+	}
+
+	if subscriptionID != "" {
+		q = q.Where("? = ?", bun.Ident("domain_permission_draft.subscription_id"), subscriptionID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return drafts, nil
+}
+
+func (d *domainDB) PutDomainPermissionDraft(
+	ctx context.Context,
+	draft *gtsmodel.DomainPermissionDraft,
+) error {
+	// Normalize the domain as punycode
+	var err error
+	draft.Domain, err = util.Punify(draft.Domain)
+	if err != nil {
+		return err
+	}
+
+	_, err = d.db.
+		NewInsert().
+		Model(draft).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionDraft)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_draft.id"), id).
+		Exec(ctx)
+	return err
+}
+
+func (d *domainDB) DeleteDomainPermissionDraftsBySubscriptionID(
+	ctx context.Context,
+	subscriptionID string,
+) error {
+	_, err := d.db.
+		NewDelete().
+		Model((*gtsmodel.DomainPermissionDraft)(nil)).
+		Where("? = ?", bun.Ident("domain_permission_draft.subscription_id"), subscriptionID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,208 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"cmp"
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+// groupDB provides access to group moderators. It is
+// uncached, as moderators are only looked up when posts
+// are addressed to a local group, or on moderator actions.
+type groupDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (g *groupDB) NewGroup(ctx context.Context, newGroup gtsmodel.NewGroup) (*gtsmodel.Account, error) {
+	uris := uris.GenerateURIsForAccount(newGroup.Username)
+
+	accountID, err := id.NewRandomULID()
+	if err != nil {
+		err := gtserror.Newf("error creating new account id: %w", err)
+		return nil, err
+	}
+
+	privKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		err := gtserror.Newf("error creating new rsa private key: %w", err)
+		return nil, err
+	}
+
+	edPubKey, edPrivKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		err := gtserror.Newf("error creating new ed25519 private key: %w", err)
+		return nil, err
+	}
+
+	account := &gtsmodel.Account{
+		ID:                    accountID,
+		Username:              newGroup.Username,
+		DisplayName:           cmp.Or(newGroup.DisplayName, newGroup.Username),
+		Note:                  newGroup.Note,
+		NoteRaw:               newGroup.NoteRaw,
+		URI:                   uris.UserURI,
+		URL:                   uris.UserURL,
+		InboxURI:              uris.InboxURI,
+		OutboxURI:             uris.OutboxURI,
+		FollowingURI:          uris.FollowingURI,
+		FollowersURI:          uris.FollowersURI,
+		FeaturedCollectionURI: uris.FeaturedCollectionURI,
+		ActorType:             ap.ActorGroup,
+		Locked:                util.Ptr(newGroup.Locked),
+		Discoverable:          util.Ptr(true),
+		Bot:                   util.Ptr(false),
+		PrivateKey:            privKey,
+		PublicKey:             &privKey.PublicKey,
+		PublicKeyURI:          uris.PublicKeyURI,
+		Ed25519PrivateKey:     edPrivKey,
+		Ed25519PublicKey:      edPubKey,
+		Ed25519PublicKeyURI:   uris.Ed25519PublicKeyURI,
+	}
+
+	// Insert the new group account!
+	if err := g.state.DB.PutAccount(ctx, account); err != nil {
+		return nil, err
+	}
+
+	// Insert basic settings for new group;
+	// group re-announces are always public.
+	account.Settings = &gtsmodel.AccountSettings{
+		AccountID: accountID,
+		Privacy:   gtsmodel.VisibilityPublic,
+	}
+	if err := g.state.DB.PutAccountSettings(ctx, account.Settings); err != nil {
+		return nil, err
+	}
+
+	// Stub empty stats for new group.
+	if err := g.state.DB.StubAccountStats(ctx, account); err != nil {
+		return nil, err
+	}
+
+	// Add group creator as first moderator.
+	if err := g.PutGroupModerator(ctx, &gtsmodel.GroupModerator{
+		ID:        id.NewULID(),
+		GroupID:   accountID,
+		AccountID: newGroup.CreatorID,
+	}); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (g *groupDB) GetGroupModerators(ctx context.Context, groupID string) ([]*gtsmodel.GroupModerator, error) {
+	var moderators []*gtsmodel.GroupModerator
+	if err := g.db.NewSelect().
+		Model(&moderators).
+		Where("? = ?", bun.Ident("group_id"), groupID).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var errs gtserror.MultiError
+	for _, moderator := range moderators {
+		var err error
+		moderator.Account, err = g.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			moderator.AccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating moderator account: %w", err)
+		}
+	}
+
+	return moderators, errs.Combine()
+}
+
+func (g *groupDB) GetGroupModeratorsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.GroupModerator, error) {
+	var moderators []*gtsmodel.GroupModerator
+	if err := g.db.NewSelect().
+		Model(&moderators).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		OrderExpr("? ASC", bun.Ident("id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	var errs gtserror.MultiError
+	for _, moderator := range moderators {
+		var err error
+		moderator.Group, err = g.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			moderator.GroupID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating moderated group: %w", err)
+		}
+	}
+
+	return moderators, errs.Combine()
+}
+
+func (g *groupDB) IsGroupModerator(ctx context.Context, groupID string, accountID string) (bool, error) {
+	q := g.db.NewSelect().
+		Table("group_moderators").
+		Column("id").
+		Where("? = ?", bun.Ident("group_id"), groupID).
+		Where("? = ?", bun.Ident("account_id"), accountID)
+	return exists(ctx, q)
+}
+
+func (g *groupDB) PutGroupModerator(ctx context.Context, moderator *gtsmodel.GroupModerator) error {
+	_, err := g.db.NewInsert().
+		Model(moderator).
+		Exec(ctx)
+	return err
+}
+
+func (g *groupDB) DeleteGroupModerator(ctx context.Context, groupID string, accountID string) error {
+	_, err := g.db.NewDelete().
+		Table("group_moderators").
+		Where("? = ?", bun.Ident("group_id"), groupID).
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
+
+func (g *groupDB) DeleteGroupModeratorsByAccountID(ctx context.Context, accountID string) error {
+	_, err := g.db.NewDelete().
+		Table("group_moderators").
+		WhereOr("? = ?", bun.Ident("group_id"), accountID).
+		WhereOr("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx)
+	return err
+}
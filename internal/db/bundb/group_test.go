@@ -0,0 +1,140 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type GroupTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *GroupTestSuite) TestNewGroup() {
+	ctx := context.Background()
+	creator := suite.testAccounts["local_account_1"]
+
+	group, err := suite.db.NewGroup(ctx, gtsmodel.NewGroup{
+		Username:  "cool_group",
+		CreatorID: creator.ID,
+		Locked:    true,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(ap.ActorGroup, group.ActorType)
+	suite.True(group.IsGroup())
+	suite.True(group.IsLocal())
+	suite.False(group.IsInstance())
+	suite.True(*group.Locked)
+	suite.Equal("cool_group", group.DisplayName)
+	suite.NotNil(group.PrivateKey)
+	suite.NotNil(group.Settings)
+
+	// Fetch the group fresh from the db.
+	dbGroup, err := suite.db.GetAccountByUsernameDomain(ctx, "cool_group", "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(group.ID, dbGroup.ID)
+	suite.Equal(gtsmodel.VisibilityPublic, dbGroup.Settings.Privacy)
+
+	// Creator should be a moderator.
+	moderator, err := suite.db.IsGroupModerator(ctx, group.ID, creator.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(moderator)
+}
+
+func (suite *GroupTestSuite) TestGroupModerators() {
+	ctx := context.Background()
+	creator := suite.testAccounts["local_account_1"]
+	other := suite.testAccounts["local_account_2"]
+
+	group, err := suite.db.NewGroup(ctx, gtsmodel.NewGroup{
+		Username:  "cool_group",
+		CreatorID: creator.ID,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.PutGroupModerator(ctx, &gtsmodel.GroupModerator{
+		ID:        id.NewULID(),
+		GroupID:   group.ID,
+		AccountID: other.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	moderators, err := suite.db.GetGroupModerators(ctx, group.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(moderators, 2)
+	for _, moderator := range moderators {
+		suite.NotNil(moderator.Account)
+		suite.Equal(moderator.AccountID, moderator.Account.ID)
+	}
+	suite.ElementsMatch(
+		[]string{creator.ID, other.ID},
+		[]string{moderators[0].AccountID, moderators[1].AccountID},
+	)
+
+	moderated, err := suite.db.GetGroupModeratorsByAccountID(ctx, other.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(moderated, 1)
+	suite.Equal(group.ID, moderated[0].Group.ID)
+
+	// Remove the second moderator.
+	if err := suite.db.DeleteGroupModerator(ctx, group.ID, other.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	isModerator, err := suite.db.IsGroupModerator(ctx, group.ID, other.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(isModerator)
+
+	// Deleting the group's moderator
+	// entries should remove the creator.
+	if err := suite.db.DeleteGroupModeratorsByAccountID(ctx, group.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	moderators, err = suite.db.GetGroupModerators(ctx, group.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(moderators)
+}
+
+func TestGroupTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupTestSuite))
+}
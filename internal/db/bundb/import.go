@@ -0,0 +1,88 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type importDB struct {
+	db    *bun.DB
+	state *state.State
+}
+
+func (i *importDB) GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error) {
+	imp := new(gtsmodel.Import)
+	if err := i.db.
+		NewSelect().
+		Model(imp).
+		Where("? = ?", bun.Ident("import.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return imp, nil
+}
+
+func (i *importDB) GetImportsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Import, error) {
+	var imps []*gtsmodel.Import
+	if err := i.db.
+		NewSelect().
+		Model(&imps).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		OrderExpr("? DESC", bun.Ident("import.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return imps, nil
+}
+
+func (i *importDB) PutImport(ctx context.Context, imp *gtsmodel.Import) error {
+	_, err := i.db.NewInsert().Model(imp).Exec(ctx)
+	return err
+}
+
+func (i *importDB) UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error {
+	imp.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := i.db.
+		NewUpdate().
+		Model(imp).
+		Column(columns...).
+		Where("? = ?", bun.Ident("import.id"), imp.ID).
+		Exec(ctx)
+	return err
+}
+
+func (i *importDB) DeleteImportsByAccountID(ctx context.Context, accountID string) error {
+	_, err := i.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("imports"), bun.Ident("import")).
+		Where("? = ?", bun.Ident("import.account_id"), accountID).
+		Exec(ctx)
+	return err
+}
@@ -253,6 +253,25 @@ func (i *instanceDB) UpdateInstance(ctx context.Context, instance *gtsmodel.Inst
 	})
 }
 
+func (i *instanceDB) IncrementInstanceSpamHits(ctx context.Context, id string) error {
+	// Increment in the database rather than
+	// read-modify-write, so that concurrent
+	// inbox deliveries don't lose any hits.
+	if _, err := i.db.
+		NewUpdate().
+		Table("instances").
+		Set("? = ? + 1", bun.Ident("spam_hits"), bun.Ident("spam_hits")).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Cached instance is now stale.
+	i.state.Caches.DB.Instance.Invalidate("ID", id)
+	return nil
+}
+
 func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, error) {
 	instanceIDs := []string{}
 
@@ -293,6 +312,108 @@ func (i *instanceDB) GetInstancePeers(ctx context.Context, includeSuspended bool
 	return instances, nil
 }
 
+func (i *instanceDB) GetInstancesByReputation(ctx context.Context, limit int, offset int) ([]*gtsmodel.Instance, error) {
+	instanceIDs := []string{}
+
+	q := i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("instances"), bun.Ident("instance")).
+		// Select just the IDs of each instance.
+		Column("instance.id").
+		// Exclude our own instance.
+		Where("? != ?", bun.Ident("instance.domain"), config.GetHost()).
+		// Order by effective reputation, lowest first.
+		OrderExpr("COALESCE(?, ?) ASC",
+			bun.Ident("instance.reputation_override"),
+			bun.Ident("instance.reputation"),
+		).
+		OrderExpr("? ASC", bun.Ident("instance.domain"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.Scan(ctx, &instanceIDs); err != nil {
+		return nil, err
+	}
+
+	if len(instanceIDs) == 0 {
+		return nil, db.ErrNoEntries
+	}
+
+	instances := make([]*gtsmodel.Instance, 0, len(instanceIDs))
+
+	for _, id := range instanceIDs {
+		// Select each instance by its ID.
+		instance, err := i.GetInstanceByID(ctx, id)
+		if err != nil {
+			log.Errorf(ctx, "error getting instance %q: %v", id, err)
+			continue
+		}
+
+		// Append to return slice.
+		instances = append(instances, instance)
+	}
+
+	return instances, nil
+}
+
+func (i *instanceDB) CountInstanceLocalFollows(ctx context.Context, domain string) (int, error) {
+	return i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("follow.account_id"),
+		).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("target_account"),
+			bun.Ident("target_account.id"), bun.Ident("follow.target_account_id"),
+		).
+		WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				// Remote account follows local account.
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? = ?", bun.Ident("account.domain"), domain).
+						Where("? IS NULL", bun.Ident("target_account.domain"))
+				}).
+				// Local account follows remote account.
+				WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+					return q.
+						Where("? IS NULL", bun.Ident("account.domain")).
+						Where("? = ?", bun.Ident("target_account.domain"), domain)
+				})
+		}).
+		Count(ctx)
+}
+
+func (i *instanceDB) CountInstanceReports(ctx context.Context, domain string) (int, error) {
+	return i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
+		Join("JOIN ? AS ? ON ? = ?",
+			bun.Ident("accounts"), bun.Ident("account"),
+			bun.Ident("account.id"), bun.Ident("report.target_account_id"),
+		).
+		Where("? = ?", bun.Ident("account.domain"), domain).
+		Count(ctx)
+}
+
+func (i *instanceDB) CountInstanceBlocks(ctx context.Context, domain string) (int, error) {
+	return i.db.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("admin_actions"), bun.Ident("admin_action")).
+		Where("? = ?", bun.Ident("admin_action.target_category"), gtsmodel.AdminActionCategoryDomain).
+		Where("? = ?", bun.Ident("admin_action.target_id"), domain).
+		Where("? = ?", bun.Ident("admin_action.type"), gtsmodel.AdminActionSuspend).
+		Count(ctx)
+}
+
 func (i *instanceDB) GetInstanceAccounts(ctx context.Context, domain string, maxID string, limit int) ([]*gtsmodel.Account, error) {
 	// Ensure reasonable
 	if limit < 0 {
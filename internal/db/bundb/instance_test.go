@@ -19,6 +19,7 @@ package bundb_test
 
 import (
 	"context"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -128,6 +129,133 @@ func (suite *InstanceTestSuite) TestGetInstanceModeratorAddressesNoAdmin() {
 	suite.Empty(addresses)
 }
 
+func (suite *InstanceTestSuite) TestGetInstancesByReputation() {
+	ctx := context.Background()
+
+	// Unscored instances are ordered by domain.
+	instances, err := suite.db.GetInstancesByReputation(ctx, 10, 0)
+	suite.NoError(err)
+	suite.Len(instances, 2)
+	suite.Equal("example.org", instances[0].Domain)
+	suite.Equal("fossbros-anonymous.io", instances[1].Domain)
+
+	// Override should be taken into account.
+	instance, err := suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instance.ReputationOverride = util.Ptr(int64(-5))
+	if err := suite.db.UpdateInstance(ctx, instance, "reputation_override"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	instances, err = suite.db.GetInstancesByReputation(ctx, 10, 0)
+	suite.NoError(err)
+	suite.Len(instances, 2)
+	suite.Equal("fossbros-anonymous.io", instances[0].Domain)
+	suite.EqualValues(-5, instances[0].EffectiveReputation())
+
+	// Check paging.
+	instances, err = suite.db.GetInstancesByReputation(ctx, 10, 1)
+	suite.NoError(err)
+	suite.Len(instances, 1)
+	suite.Equal("example.org", instances[0].Domain)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceLocalFollows() {
+	ctx := context.Background()
+
+	count, err := suite.db.CountInstanceLocalFollows(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Zero(count)
+
+	// Add follows both ways between
+	// local and remote accounts.
+	for _, follow := range []*gtsmodel.Follow{
+		{
+			ID:              "01J9ZX4VNDYHB5JDAZSJY8NXNQ",
+			URI:             "http://localhost:8080/users/the_mighty_zork/follow/01J9ZX4VNDYHB5JDAZSJY8NXNQ",
+			AccountID:       suite.testAccounts["local_account_1"].ID,
+			TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+		},
+		{
+			ID:              "01J9ZX5BGWCQ7T1QK1MWQ5Z3NE",
+			URI:             "http://fossbros-anonymous.io/users/foss_satan/follow/01J9ZX5BGWCQ7T1QK1MWQ5Z3NE",
+			AccountID:       suite.testAccounts["remote_account_1"].ID,
+			TargetAccountID: suite.testAccounts["local_account_2"].ID,
+		},
+	} {
+		if err := suite.db.PutFollow(ctx, follow); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	count, err = suite.db.CountInstanceLocalFollows(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Equal(2, count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceReports() {
+	count, err := suite.db.CountInstanceReports(context.Background(), "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	count, err = suite.db.CountInstanceReports(context.Background(), "example.org")
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *InstanceTestSuite) TestCountInstanceBlocks() {
+	ctx := context.Background()
+
+	count, err := suite.db.CountInstanceBlocks(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Zero(count)
+
+	if err := suite.db.PutAdminAction(ctx, &gtsmodel.AdminAction{
+		ID:             "01J9ZX6M3Q4SR0N1Y2VFQ5N4ZD",
+		TargetCategory: gtsmodel.AdminActionCategoryDomain,
+		TargetID:       "fossbros-anonymous.io",
+		Type:           gtsmodel.AdminActionSuspend,
+		AccountID:      suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	count, err = suite.db.CountInstanceBlocks(ctx, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Equal(1, count)
+}
+
+func (suite *InstanceTestSuite) TestIncrementInstanceSpamHits() {
+	ctx := context.Background()
+
+	instance, err := suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(instance.SpamHits)
+
+	// Increment concurrently,
+	// no hits should be lost.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			suite.NoError(suite.db.IncrementInstanceSpamHits(ctx, instance.ID))
+		}()
+	}
+	wg.Wait()
+
+	instance, err = suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(10, instance.SpamHits)
+}
+
 func TestInstanceTestSuite(t *testing.T) {
 	suite.Run(t, new(InstanceTestSuite))
 }
@@ -295,3 +295,20 @@ func (m *mediaDB) GetCachedAttachmentsOlderThan(ctx context.Context, olderThan t
 
 	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
 }
+
+func (m *mediaDB) GetLocalProcessingAttachments(ctx context.Context) ([]*gtsmodel.MediaAttachment, error) {
+	var attachmentIDs []string
+
+	if err := m.db.
+		NewSelect().
+		Table("media_attachments").
+		Column("id").
+		Where("remote_url IS NULL").
+		Where("processing = ?", gtsmodel.ProcessingStatusProcessing).
+		Order("id ASC").
+		Scan(ctx, &attachmentIDs); err != nil {
+		return nil, err
+	}
+
+	return m.GetAttachmentsByIDs(ctx, attachmentIDs)
+}
@@ -0,0 +1,166 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type mediaHashBlockDB struct {
+	db    *bun.DB
+	state *state.State
+
+	// blocksLock prevents the
+	// cached blocks slice being
+	// repopulated concurrently
+	// with a write invalidating it.
+	blocksLock sync.Mutex
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlockByID(ctx context.Context, id string) (*gtsmodel.MediaHashBlock, error) {
+	return m.getMediaHashBlock(ctx, func(block *gtsmodel.MediaHashBlock) bool {
+		return block.ID == id
+	})
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlockByHash(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error) {
+	return m.getMediaHashBlock(ctx, func(block *gtsmodel.MediaHashBlock) bool {
+		return block.Hash == hash
+	})
+}
+
+func (m *mediaHashBlockDB) MatchMediaHashBlock(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error) {
+	if hash == "" {
+		// Media without a hash
+		// can never be matched.
+		return nil, db.ErrNoEntries
+	}
+
+	return m.getMediaHashBlock(ctx, func(block *gtsmodel.MediaHashBlock) bool {
+		return block.Matches(hash)
+	})
+}
+
+func (m *mediaHashBlockDB) getMediaHashBlock(ctx context.Context, match func(*gtsmodel.MediaHashBlock) bool) (*gtsmodel.MediaHashBlock, error) {
+	blocks, err := m.loadMediaHashBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(blocks, match)
+	if i == -1 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Return a copy, as the
+	// cached model is shared.
+	block := new(gtsmodel.MediaHashBlock)
+	*block = *blocks[i]
+
+	return block, nil
+}
+
+func (m *mediaHashBlockDB) GetMediaHashBlocks(ctx context.Context) ([]*gtsmodel.MediaHashBlock, error) {
+	blocks, err := m.loadMediaHashBlocks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return copies, as the
+	// cached models are shared.
+	out := make([]*gtsmodel.MediaHashBlock, len(blocks))
+	for i, block := range blocks {
+		out[i] = new(gtsmodel.MediaHashBlock)
+		*out[i] = *block
+	}
+
+	return out, nil
+}
+
+// loadMediaHashBlocks returns all media hash
+// blocks from the cache, loading them from the
+// database first if they're not currently cached.
+//
+// Every block must be compared against the hash
+// of each piece of processed media, as matching
+// is by distance, so they're cached all together.
+func (m *mediaHashBlockDB) loadMediaHashBlocks(ctx context.Context) ([]*gtsmodel.MediaHashBlock, error) {
+	if blocks := m.state.Caches.DB.MediaHashBlocks.Load(); blocks != nil {
+		return *blocks, nil
+	}
+
+	m.blocksLock.Lock()
+	defer m.blocksLock.Unlock()
+
+	// Check again in case another
+	// caller populated the cache
+	// while we awaited the lock.
+	if blocks := m.state.Caches.DB.MediaHashBlocks.Load(); blocks != nil {
+		return *blocks, nil
+	}
+
+	var blocks []*gtsmodel.MediaHashBlock
+	if err := m.db.
+		NewSelect().
+		Model(&blocks).
+		Order("media_hash_block.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	m.state.Caches.DB.MediaHashBlocks.Store(&blocks)
+	return blocks, nil
+}
+
+func (m *mediaHashBlockDB) PutMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error {
+	m.blocksLock.Lock()
+	defer m.blocksLock.Unlock()
+
+	if _, err := m.db.
+		NewInsert().
+		Model(block).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	m.state.Caches.DB.MediaHashBlocks.Store(nil)
+	return nil
+}
+
+func (m *mediaHashBlockDB) DeleteMediaHashBlockByID(ctx context.Context, id string) error {
+	m.blocksLock.Lock()
+	defer m.blocksLock.Unlock()
+
+	if _, err := m.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("media_hash_blocks"), bun.Ident("media_hash_block")).
+		Where("? = ?", bun.Ident("media_hash_block.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	m.state.Caches.DB.MediaHashBlocks.Store(nil)
+	return nil
+}
@@ -0,0 +1,85 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type MediaHashBlockTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *MediaHashBlockTestSuite) TestMediaHashBlockLifecycle() {
+	ctx := context.Background()
+
+	blocks, err := suite.db.GetMediaHashBlocks(ctx)
+	suite.NoError(err)
+	suite.Empty(blocks)
+
+	blockID := id.NewULID()
+	block := &gtsmodel.MediaHashBlock{
+		ID:                 blockID,
+		Hash:               "f0f0f0f0f0f0f0f0",
+		Threshold:          4,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+	if err := suite.db.PutMediaHashBlock(ctx, block); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	byHash, err := suite.db.GetMediaHashBlockByHash(ctx, block.Hash)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(blockID, byHash.ID)
+
+	// Within threshold (4 bits differ).
+	match, err := suite.db.MatchMediaHashBlock(ctx, "f0f0f0f0f0f0f0ff")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(blockID, match.ID)
+
+	// Outside threshold (8 bits differ), empty, and malformed.
+	for _, hash := range []string{"f0f0f0f0f0f0ffff", "", "not a hash"} {
+		_, err = suite.db.MatchMediaHashBlock(ctx, hash)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+
+	// Delete the block.
+	if err := suite.db.DeleteMediaHashBlockByID(ctx, blockID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetMediaHashBlockByID(ctx, blockID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = suite.db.MatchMediaHashBlock(ctx, block.Hash)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestMediaHashBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaHashBlockTestSuite))
+}
@@ -0,0 +1,112 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Array column type
+			// depends on dialect.
+			var arrayType string
+			switch tx.Dialect().Name() {
+			case dialect.PG:
+				arrayType = "VARCHAR[]"
+			case dialect.SQLite:
+				arrayType = "VARCHAR"
+			default:
+				log.Panic(ctx, "db dialect was neither pg nor sqlite")
+			}
+
+			// Add new columns to the users
+			// table for two-factor auth.
+			type spec struct {
+				column     string
+				columnType string
+			}
+			for _, spec := range []spec{
+				{
+					column:     "two_factor_secret",
+					columnType: "VARCHAR",
+				},
+				{
+					column:     "two_factor_backups",
+					columnType: arrayType,
+				},
+				{
+					column:     "two_factor_enabled_at",
+					columnType: "TIMESTAMPTZ",
+				},
+				{
+					column:     "two_factor_last_step",
+					columnType: "BIGINT",
+				},
+				{
+					column:     "two_factor_failures",
+					columnType: "INTEGER",
+				},
+				{
+					column:     "two_factor_locked_until",
+					columnType: "TIMESTAMPTZ",
+				},
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					"users", spec.column,
+				)
+				if err != nil {
+					// Real error.
+					return err
+				} else if exists {
+					// Already created.
+					continue
+				}
+
+				log.Infof(ctx, "adding column '%s' to 'users'...", spec.column)
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? ?",
+					bun.Ident("users"),
+					bun.Ident(spec.column),
+					bun.Safe(spec.columnType),
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,271 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+// Full-text search is done over plaintext copies of status
+// content and account notes, as indexing the html versions
+// would make eg., "span" or "class" match nearly everything.
+// These copies are kept up to date by the bundb package.
+
+// Postgres full-text search is done using GIN indexes on
+// tsvector expressions. NOTE: these expressions must exactly
+// match the ones used in internal/db/bundb/search.go,
+// otherwise Postgres won't use the indexes for searches.
+const (
+	pgStatusTSVector  = `to_tsvector('simple', COALESCE("content_plain", '') || ' ' || COALESCE("content_warning", ''))`
+	pgAccountTSVector = `setweight(to_tsvector('simple', "username" || ' ' || COALESCE("display_name", '')), 'A') || setweight(to_tsvector('simple', COALESCE("note_plain", '')), 'B')`
+)
+
+// SQLite full-text search is done using FTS5 virtual tables.
+//
+// FTS5 rows are keyed by integer rowid, but our tables are keyed
+// by ULID, and the implicit rowid of a table without an INTEGER
+// PRIMARY KEY may be changed by VACUUM. So each FTS5 table has
+// a companion "_ids" table mapping a stable rowid to our ID.
+//
+// Triggers keep the FTS5 tables in sync with their source
+// tables. NOTE: any future migration that recreates the
+// statuses or accounts tables must also recreate these.
+var sqliteFulltextStatements = []string{
+	// Statuses (ignoring boosts, which have no content).
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "statuses_fts" USING fts5("content", "content_warning", tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE TABLE IF NOT EXISTS "statuses_fts_ids" ("rowid" INTEGER PRIMARY KEY, "id" CHAR(26) NOT NULL UNIQUE)`,
+	`CREATE TRIGGER IF NOT EXISTS "statuses_fts_insert" AFTER INSERT ON "statuses" WHEN new."boost_of_id" IS NULL BEGIN
+		INSERT INTO "statuses_fts_ids" ("id") VALUES (new."id");
+		INSERT INTO "statuses_fts" ("rowid", "content", "content_warning")
+		VALUES ((SELECT "rowid" FROM "statuses_fts_ids" WHERE "id" = new."id"), new."content_plain", new."content_warning");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "statuses_fts_update" AFTER UPDATE OF "content_plain", "content_warning" ON "statuses" WHEN new."boost_of_id" IS NULL BEGIN
+		UPDATE "statuses_fts" SET "content" = new."content_plain", "content_warning" = new."content_warning"
+		WHERE "rowid" = (SELECT "rowid" FROM "statuses_fts_ids" WHERE "id" = new."id");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "statuses_fts_delete" AFTER DELETE ON "statuses" BEGIN
+		DELETE FROM "statuses_fts" WHERE "rowid" = (SELECT "rowid" FROM "statuses_fts_ids" WHERE "id" = old."id");
+		DELETE FROM "statuses_fts_ids" WHERE "id" = old."id";
+	END`,
+	`INSERT INTO "statuses_fts_ids" ("id")
+	SELECT "id" FROM "statuses" WHERE "boost_of_id" IS NULL`,
+	`INSERT INTO "statuses_fts" ("rowid", "content", "content_warning")
+	SELECT "statuses_fts_ids"."rowid", "statuses"."content_plain", "statuses"."content_warning"
+	FROM "statuses" JOIN "statuses_fts_ids" ON "statuses_fts_ids"."id" = "statuses"."id"`,
+
+	// Accounts.
+	`CREATE VIRTUAL TABLE IF NOT EXISTS "accounts_fts" USING fts5("username", "display_name", "note", tokenize = 'unicode61 remove_diacritics 2')`,
+	`CREATE TABLE IF NOT EXISTS "accounts_fts_ids" ("rowid" INTEGER PRIMARY KEY, "id" CHAR(26) NOT NULL UNIQUE)`,
+	`CREATE TRIGGER IF NOT EXISTS "accounts_fts_insert" AFTER INSERT ON "accounts" BEGIN
+		INSERT INTO "accounts_fts_ids" ("id") VALUES (new."id");
+		INSERT INTO "accounts_fts" ("rowid", "username", "display_name", "note")
+		VALUES ((SELECT "rowid" FROM "accounts_fts_ids" WHERE "id" = new."id"), new."username", new."display_name", new."note_plain");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "accounts_fts_update" AFTER UPDATE OF "username", "display_name", "note_plain" ON "accounts" BEGIN
+		UPDATE "accounts_fts" SET "username" = new."username", "display_name" = new."display_name", "note" = new."note_plain"
+		WHERE "rowid" = (SELECT "rowid" FROM "accounts_fts_ids" WHERE "id" = new."id");
+	END`,
+	`CREATE TRIGGER IF NOT EXISTS "accounts_fts_delete" AFTER DELETE ON "accounts" BEGIN
+		DELETE FROM "accounts_fts" WHERE "rowid" = (SELECT "rowid" FROM "accounts_fts_ids" WHERE "id" = old."id");
+		DELETE FROM "accounts_fts_ids" WHERE "id" = old."id";
+	END`,
+	`INSERT INTO "accounts_fts_ids" ("id")
+	SELECT "id" FROM "accounts"`,
+	`INSERT INTO "accounts_fts" ("rowid", "username", "display_name", "note")
+	SELECT "accounts_fts_ids"."rowid", "accounts"."username", "accounts"."display_name", "accounts"."note_plain"
+	FROM "accounts" JOIN "accounts_fts_ids" ON "accounts_fts_ids"."id" = "accounts"."id"`,
+}
+
+var pgFulltextStatements = []string{
+	`CREATE INDEX IF NOT EXISTS "statuses_fts_idx" ON "statuses" USING GIN ((` + pgStatusTSVector + `)) WHERE "boost_of_id" IS NULL`,
+	`CREATE INDEX IF NOT EXISTS "accounts_fts_idx" ON "accounts" USING GIN ((` + pgAccountTSVector + `))`,
+}
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		var statements []string
+		switch db.Dialect().Name() {
+		case dialect.PG:
+			statements = pgFulltextStatements
+		case dialect.SQLite:
+			statements = sqliteFulltextStatements
+		default:
+			panic("db conn was neither pg not sqlite")
+		}
+
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			log.Info(ctx, "building full-text search indexes for statuses and accounts; this may take a few minutes, please don't interrupt this migration!")
+
+			// Add and populate plaintext
+			// columns for the indexes to use.
+			for _, spec := range []struct {
+				table     string
+				column    string
+				plainCol  string
+				condition string
+			}{
+				{
+					table:     "statuses",
+					column:    "content",
+					plainCol:  "content_plain",
+					condition: `"boost_of_id" IS NULL`,
+				},
+				{
+					table:     "accounts",
+					column:    "note",
+					plainCol:  "note_plain",
+					condition: "TRUE",
+				},
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					spec.table, spec.plainCol,
+				)
+				if err != nil {
+					// Real error.
+					return err
+				} else if !exists {
+					log.Infof(ctx, "adding column '%s' to '%s'...", spec.plainCol, spec.table)
+					if _, err := tx.ExecContext(ctx,
+						"ALTER TABLE ? ADD COLUMN ? TEXT",
+						bun.Ident(spec.table),
+						bun.Ident(spec.plainCol),
+					); err != nil {
+						return err
+					}
+				}
+
+				if err := populatePlaintext(ctx, tx,
+					spec.table,
+					spec.column,
+					spec.plainCol,
+					spec.condition,
+				); err != nil {
+					return err
+				}
+			}
+
+			for _, statement := range statements {
+				if _, err := tx.ExecContext(ctx, statement); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
+
+// populatePlaintext sets plainCol to the html-stripped
+// contents of column, for each row of table matching
+// condition, paging through the table by ID.
+func populatePlaintext(
+	ctx context.Context,
+	tx bun.Tx,
+	table string,
+	column string,
+	plainCol string,
+	condition string,
+) error {
+	const batchSize = 1000
+
+	var (
+		maxID   = ""
+		updated = 0
+	)
+
+	for {
+		var rows []struct {
+			ID   string `bun:"id"`
+			HTML string `bun:"html"`
+		}
+
+		if err := tx.NewSelect().
+			Table(table).
+			ColumnExpr("? AS ?", bun.Ident("id"), bun.Ident("id")).
+			ColumnExpr("COALESCE(?, '') AS ?", bun.Ident(column), bun.Ident("html")).
+			Where("? > ?", bun.Ident("id"), maxID).
+			Where(condition).
+			OrderExpr("? ASC", bun.Ident("id")).
+			Limit(batchSize).
+			Scan(ctx, &rows); err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			break
+		}
+
+		for _, row := range rows {
+			if row.HTML == "" {
+				continue
+			}
+
+			if _, err := tx.NewUpdate().
+				Table(table).
+				Set("? = ?", bun.Ident(plainCol), searchText(row.HTML)).
+				Where("? = ?", bun.Ident("id"), row.ID).
+				Exec(ctx); err != nil {
+				return err
+			}
+		}
+
+		maxID = rows[len(rows)-1].ID
+		updated += len(rows)
+		log.Infof(ctx, "populated %s for %d %s so far...", plainCol, updated, table)
+	}
+
+	return nil
+}
+
+// searchStrictPolicy strips all html elements.
+var searchStrictPolicy = bluemonday.StrictPolicy()
+
+// searchText returns the plaintext of the given html,
+// separating the text of adjacent html elements with
+// a space. This is a copy of text.SanitizeToSearchText
+// as it was when this migration was written, so that
+// later changes to the text package don't change what
+// this migration populates the plaintext columns with.
+func searchText(in string) string {
+	// Unescape first to catch any tricky critters.
+	content := html.UnescapeString(strings.ReplaceAll(in, "<", " <"))
+
+	// Remove all detected HTML.
+	content = searchStrictPolicy.Sanitize(content)
+
+	// Unescape again to return plaintext.
+	content = html.UnescapeString(content)
+	return strings.TrimSpace(content)
+}
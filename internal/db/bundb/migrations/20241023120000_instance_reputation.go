@@ -0,0 +1,87 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Add new columns to the instances
+			// table for reputation-based federation.
+			type spec struct {
+				column     string
+				columnType string
+			}
+			for _, spec := range []spec{
+				{
+					column:     "reputation_override",
+					columnType: "BIGINT",
+				},
+				{
+					column:     "reputation_computed_at",
+					columnType: "TIMESTAMPTZ",
+				},
+				{
+					column:     "spam_hits",
+					columnType: "INTEGER NOT NULL DEFAULT 0",
+				},
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					"instances", spec.column,
+				)
+				if err != nil {
+					// Real error.
+					return err
+				} else if exists {
+					// Already created.
+					continue
+				}
+
+				log.Infof(ctx, "adding column '%s' to 'instances'...", spec.column)
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? ?",
+					bun.Ident("instances"),
+					bun.Ident(spec.column),
+					bun.Safe(spec.columnType),
+				); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Bytes column type
+			// depends on dialect.
+			var bytesType string
+			switch tx.Dialect().Name() {
+			case dialect.PG:
+				bytesType = "BYTEA"
+			case dialect.SQLite:
+				bytesType = "BLOB"
+			default:
+				log.Panic(ctx, "db dialect was neither pg nor sqlite")
+			}
+
+			// Add new columns to the accounts table
+			// for ed25519 keys, and to the instances
+			// table for signature scheme negotiation.
+			type spec struct {
+				table      string
+				column     string
+				columnType string
+			}
+			for _, spec := range []spec{
+				{
+					table:      "accounts",
+					column:     "ed25519_private_key",
+					columnType: bytesType,
+				},
+				{
+					table:      "accounts",
+					column:     "ed25519_public_key",
+					columnType: bytesType,
+				},
+				{
+					table:      "accounts",
+					column:     "ed25519_public_key_uri",
+					columnType: "VARCHAR",
+				},
+				{
+					table:      "instances",
+					column:     "signature_scheme",
+					columnType: "VARCHAR",
+				},
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					spec.table, spec.column,
+				)
+				if err != nil {
+					// Real error.
+					return err
+				} else if exists {
+					// Already created.
+					continue
+				}
+
+				log.Infof(ctx, "adding column '%s' to '%s'...", spec.column, spec.table)
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? ?",
+					bun.Ident(spec.table),
+					bun.Ident(spec.column),
+					bun.Safe(spec.columnType),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Index ed25519 public key URIs, as
+			// accounts are selected by them when
+			// authenticating signed requests.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("accounts").
+				Index("accounts_ed25519_public_key_uri_idx").
+				Column("ed25519_public_key_uri").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Generate ed25519 keys
+			// for all local accounts.
+			var accounts []struct {
+				ID  string `bun:"id"`
+				URI string `bun:"uri"`
+			}
+			if err := tx.
+				NewSelect().
+				Table("accounts").
+				Column("id", "uri").
+				Where("? IS NULL", bun.Ident("domain")).
+				Where("? IS NULL", bun.Ident("ed25519_private_key")).
+				Scan(ctx, &accounts); err != nil {
+				return err
+			}
+
+			log.Infof(ctx, "generating ed25519 keys for %d local accounts...", len(accounts))
+			for _, account := range accounts {
+				pubKey, privKey, err := ed25519.GenerateKey(rand.Reader)
+				if err != nil {
+					return err
+				}
+
+				if _, err := tx.
+					NewUpdate().
+					Table("accounts").
+					Set("? = ?", bun.Ident("ed25519_private_key"), []byte(privKey)).
+					Set("? = ?", bun.Ident("ed25519_public_key"), []byte(pubKey)).
+					Set("? = ?", bun.Ident("ed25519_public_key_uri"), account.URI+"/main-key#ed25519-key").
+					Where("? = ?", bun.Ident("id"), account.ID).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,76 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Add perceptual hash column
+			// to the media attachments table.
+			exists, err := doesColumnExist(ctx, tx,
+				"media_attachments", "perceptual_hash",
+			)
+			if err != nil {
+				// Real error.
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding column 'perceptual_hash' to 'media_attachments'...")
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? VARCHAR",
+					bun.Ident("media_attachments"),
+					bun.Ident("perceptual_hash"),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Create the media hash blocks table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.MediaHashBlock{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
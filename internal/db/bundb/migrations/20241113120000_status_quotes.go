@@ -0,0 +1,91 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Add quote columns to statuses, and
+			// to scheduled statuses so that quotes
+			// survive until publication time.
+			for _, spec := range []struct {
+				table      string
+				column     string
+				columnType string
+			}{
+				{"statuses", "quote_id", "CHAR(26)"},
+				{"statuses", "quote_uri", "VARCHAR"},
+				{"scheduled_statuses", "quote_id", "CHAR(26)"},
+			} {
+				exists, err := doesColumnExist(ctx, tx,
+					spec.table, spec.column,
+				)
+				if err != nil {
+					// Real error.
+					return err
+				}
+
+				if exists {
+					continue
+				}
+
+				log.Infof(ctx, "adding column '%s' to '%s'...", spec.column, spec.table)
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? "+spec.columnType,
+					bun.Ident(spec.table),
+					bun.Ident(spec.column),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Index quote IDs so that quotes
+			// of a status can be found quickly.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_quote_id_idx").
+				Column("quote_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,132 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Add state column to reports,
+			// defaulting existing reports to open.
+			exists, err := doesColumnExist(ctx, tx,
+				"reports", "state",
+			)
+			if err != nil {
+				// Real error.
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding column 'state' to 'reports'...")
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? SMALLINT NOT NULL DEFAULT ?",
+					bun.Ident("reports"),
+					bun.Ident("state"),
+					gtsmodel.ReportStateOpen,
+				); err != nil {
+					return err
+				}
+
+				// Reports that already had action
+				// taken on them are now resolved.
+				if _, err := tx.
+					NewUpdate().
+					Table("reports").
+					Set("? = ?", bun.Ident("state"), gtsmodel.ReportStateResolved).
+					Where("? IS NOT NULL", bun.Ident("action_taken_by_account_id")).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Add assigned account ID column to reports.
+			exists, err = doesColumnExist(ctx, tx,
+				"reports", "assigned_account_id",
+			)
+			if err != nil {
+				// Real error.
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding column 'assigned_account_id' to 'reports'...")
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? CHAR(26)",
+					bun.Ident("reports"),
+					bun.Ident("assigned_account_id"),
+				); err != nil {
+					return err
+				}
+
+				// Reports that already had action
+				// taken on them were handled by the
+				// account that took the action.
+				if _, err := tx.
+					NewUpdate().
+					Table("reports").
+					Set("? = ?", bun.Ident("assigned_account_id"), bun.Ident("action_taken_by_account_id")).
+					Where("? IS NOT NULL", bun.Ident("action_taken_by_account_id")).
+					Exec(ctx); err != nil {
+					return err
+				}
+			}
+
+			// Create the report notes table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.ReportNote{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index report notes by report,
+			// as that's how they're fetched.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("report_notes").
+				Index("report_notes_report_id_idx").
+				Column("report_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
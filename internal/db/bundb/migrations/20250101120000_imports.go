@@ -0,0 +1,122 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package migrations
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+
+			// Create the imports table.
+			if _, err := tx.
+				NewCreateTable().
+				Model(&gtsmodel.Import{}).
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Index imports by account,
+			// as that's how they're fetched.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("imports").
+				Index("imports_account_id_idx").
+				Column("account_id").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// Add column to mark
+			// statuses as imported.
+			exists, err := doesColumnExist(ctx, tx,
+				"statuses", "imported",
+			)
+			if err != nil {
+				// Real error.
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding column 'imported' to 'statuses'...")
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? BOOLEAN NOT NULL DEFAULT false",
+					bun.Ident("statuses"),
+					bun.Ident("imported"),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Add column to record what each imported
+			// status was imported from, so that archives
+			// imported more than once aren't duplicated.
+			exists, err = doesColumnExist(ctx, tx,
+				"statuses", "imported_from_uri",
+			)
+			if err != nil {
+				// Real error.
+				return err
+			}
+
+			if !exists {
+				log.Info(ctx, "adding column 'imported_from_uri' to 'statuses'...")
+				if _, err := tx.ExecContext(ctx,
+					"ALTER TABLE ? ADD COLUMN ? VARCHAR",
+					bun.Ident("statuses"),
+					bun.Ident("imported_from_uri"),
+				); err != nil {
+					return err
+				}
+			}
+
+			// Index imported statuses by account + source
+			// uri, as that's how they're checked for.
+			if _, err := tx.
+				NewCreateIndex().
+				Table("statuses").
+				Index("statuses_account_id_imported_from_uri_idx").
+				Column("account_id", "imported_from_uri").
+				IfNotExists().
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
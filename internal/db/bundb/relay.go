@@ -0,0 +1,196 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/uptrace/bun"
+)
+
+type relayDB struct {
+	db    *bun.DB
+	state *state.State
+
+	// relaysLock prevents the
+	// cached relays slice being
+	// repopulated concurrently
+	// with a write invalidating it.
+	relaysLock sync.Mutex
+}
+
+func (r *relayDB) GetRelayByID(ctx context.Context, id string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, func(relay *gtsmodel.Relay) bool {
+		return relay.ID == id
+	})
+}
+
+func (r *relayDB) GetRelayByInboxURI(ctx context.Context, inboxURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, func(relay *gtsmodel.Relay) bool {
+		return relay.InboxURI == inboxURI
+	})
+}
+
+func (r *relayDB) GetRelayByFollowURI(ctx context.Context, followURI string) (*gtsmodel.Relay, error) {
+	return r.getRelay(ctx, func(relay *gtsmodel.Relay) bool {
+		return relay.FollowURI == followURI
+	})
+}
+
+func (r *relayDB) GetRelayByActorURI(ctx context.Context, actorURI string) (*gtsmodel.Relay, error) {
+	if actorURI == "" {
+		// Not set until Accept,
+		// so never match on empty.
+		return nil, db.ErrNoEntries
+	}
+
+	return r.getRelay(ctx, func(relay *gtsmodel.Relay) bool {
+		return relay.ActorURI == actorURI
+	})
+}
+
+func (r *relayDB) getRelay(ctx context.Context, match func(*gtsmodel.Relay) bool) (*gtsmodel.Relay, error) {
+	relays, err := r.loadRelays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	i := slices.IndexFunc(relays, match)
+	if i == -1 {
+		return nil, db.ErrNoEntries
+	}
+
+	// Return a copy, as the
+	// cached model is shared.
+	relay := new(gtsmodel.Relay)
+	*relay = *relays[i]
+
+	return relay, nil
+}
+
+func (r *relayDB) GetRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	relays, err := r.loadRelays(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Return copies, as the
+	// cached models are shared.
+	out := make([]*gtsmodel.Relay, len(relays))
+	for i, relay := range relays {
+		out[i] = new(gtsmodel.Relay)
+		*out[i] = *relay
+	}
+
+	return out, nil
+}
+
+// loadRelays returns all relays from the
+// cache, loading them from the database
+// first if they're not currently cached.
+//
+// Relays are few and checked on every incoming
+// Announce, so they're cached all together.
+func (r *relayDB) loadRelays(ctx context.Context) ([]*gtsmodel.Relay, error) {
+	if relays := r.state.Caches.DB.Relays.Load(); relays != nil {
+		return *relays, nil
+	}
+
+	r.relaysLock.Lock()
+	defer r.relaysLock.Unlock()
+
+	// Check again in case another
+	// caller populated the cache
+	// while we awaited the lock.
+	if relays := r.state.Caches.DB.Relays.Load(); relays != nil {
+		return *relays, nil
+	}
+
+	var relays []*gtsmodel.Relay
+	if err := r.db.
+		NewSelect().
+		Model(&relays).
+		Order("relay.id ASC").
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	r.state.Caches.DB.Relays.Store(&relays)
+	return relays, nil
+}
+
+func (r *relayDB) PutRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	r.relaysLock.Lock()
+	defer r.relaysLock.Unlock()
+
+	if _, err := r.db.
+		NewInsert().
+		Model(relay).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	r.state.Caches.DB.Relays.Store(nil)
+	return nil
+}
+
+func (r *relayDB) UpdateRelay(ctx context.Context, relay *gtsmodel.Relay, columns ...string) error {
+	relay.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	r.relaysLock.Lock()
+	defer r.relaysLock.Unlock()
+
+	if _, err := r.db.
+		NewUpdate().
+		Model(relay).
+		Column(columns...).
+		Where("? = ?", bun.Ident("relay.id"), relay.ID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	r.state.Caches.DB.Relays.Store(nil)
+	return nil
+}
+
+func (r *relayDB) DeleteRelayByID(ctx context.Context, id string) error {
+	r.relaysLock.Lock()
+	defer r.relaysLock.Unlock()
+
+	if _, err := r.db.
+		NewDelete().
+		TableExpr("? AS ?", bun.Ident("relays"), bun.Ident("relay")).
+		Where("? = ?", bun.Ident("relay.id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	r.state.Caches.DB.Relays.Store(nil)
+	return nil
+}
@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type RelayTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *RelayTestSuite) TestRelayLifecycle() {
+	ctx := context.Background()
+
+	relays, err := suite.db.GetRelays(ctx)
+	suite.NoError(err)
+	suite.Empty(relays)
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:        relayID,
+		InboxURI:  "https://relay.example.org/inbox",
+		FollowURI: "http://localhost:8080/users/localhost:8080/follow/" + relayID,
+		Mode:      gtsmodel.RelayModeMastodon,
+		State:     gtsmodel.RelayStatePending,
+	}
+	if err := suite.db.PutRelay(ctx, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be gettable by inbox and follow URI,
+	// but not by actor URI until it's been set.
+	byInbox, err := suite.db.GetRelayByInboxURI(ctx, relay.InboxURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relayID, byInbox.ID)
+
+	byFollow, err := suite.db.GetRelayByFollowURI(ctx, relay.FollowURI)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relayID, byFollow.ID)
+
+	_, err = suite.db.GetRelayByActorURI(ctx, "")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Accept the relay, and check the update
+	// is reflected in subsequent gets.
+	byFollow.State = gtsmodel.RelayStateAccepted
+	byFollow.ActorURI = "https://relay.example.org/actor"
+	if err := suite.db.UpdateRelay(ctx, byFollow, "state", "actor_uri"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	byActor, err := suite.db.GetRelayByActorURI(ctx, "https://relay.example.org/actor")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(relayID, byActor.ID)
+	suite.True(byActor.IsAccepted())
+
+	relays, err = suite.db.GetRelays(ctx)
+	suite.NoError(err)
+	suite.Len(relays, 1)
+
+	// Delete the relay.
+	if err := suite.db.DeleteRelayByID(ctx, relayID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetRelayByID(ctx, relayID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, new(RelayTestSuite))
+}
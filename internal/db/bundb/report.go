@@ -238,6 +238,18 @@ func (r *reportDB) PopulateReport(ctx context.Context, report *gtsmodel.Report)
 		}
 	}
 
+	if report.AssignedAccountID != "" &&
+		report.AssignedAccount == nil {
+		// Report assigned account is not set, fetch from the database.
+		report.AssignedAccount, err = r.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			report.AssignedAccountID,
+		)
+		if err != nil {
+			errs.Appendf("error populating report assigned account: %w", err)
+		}
+	}
+
 	return errs.Combine()
 }
 
@@ -283,6 +295,14 @@ func (r *reportDB) DeleteReportByID(ctx context.Context, id string) error {
 		return err
 	}
 
+	// Delete any notes left on the report.
+	if _, err := r.db.NewDelete().
+		TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+		Where("? = ?", bun.Ident("report_note.report_id"), id).
+		Exec(ctx); err != nil {
+		return err
+	}
+
 	// Finally delete report from DB.
 	_, err = r.db.NewDelete().
 		TableExpr("? AS ?", bun.Ident("reports"), bun.Ident("report")).
@@ -290,3 +310,52 @@ func (r *reportDB) DeleteReportByID(ctx context.Context, id string) error {
 		Exec(ctx)
 	return err
 }
+
+func (r *reportDB) GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, error) {
+	note := new(gtsmodel.ReportNote)
+	if err := r.db.
+		NewSelect().
+		Model(note).
+		Where("? = ?", bun.Ident("report_note.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+func (r *reportDB) GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, error) {
+	var notes []*gtsmodel.ReportNote
+	if err := r.db.
+		NewSelect().
+		Model(&notes).
+		Where("? = ?", bun.Ident("report_note.report_id"), reportID).
+		OrderExpr("? ASC", bun.Ident("report_note.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return notes, nil
+}
+
+func (r *reportDB) PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) error {
+	_, err := r.db.NewInsert().Model(note).Exec(ctx)
+	return err
+}
+
+func (r *reportDB) DeleteReportNoteByID(ctx context.Context, id string) error {
+	return r.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Unthread any replies to this note.
+		if _, err := tx.NewUpdate().
+			TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+			Set("? = NULL", bun.Ident("in_reply_to_id")).
+			Where("? = ?", bun.Ident("report_note.in_reply_to_id"), id).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		_, err := tx.NewDelete().
+			TableExpr("? AS ?", bun.Ident("report_notes"), bun.Ident("report_note")).
+			Where("? = ?", bun.Ident("report_note.id"), id).
+			Exec(ctx)
+		return err
+	})
+}
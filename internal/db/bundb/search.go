@@ -20,7 +20,9 @@ package bundb
 import (
 	"context"
 	"strings"
+	"unicode"
 
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -29,33 +31,25 @@ import (
 	"github.com/uptrace/bun/dialect"
 )
 
-// todo: currently we pass an 'offset' parameter into functions owned by this struct,
-// which is ignored.
-//
-// The idea of 'offset' is to allow callers to page through results without supplying
-// maxID or minID params; they simply use the offset as more or less a 'page number'.
-// This works fine when you're dealing with something like Elasticsearch, but for
-// SQLite or Postgres 'LIKE' queries it doesn't really, because for each higher offset
-// you have to calculate the value of all the previous offsets as well *within the
-// execution time of the query*. It's MUCH more efficient to page using maxID and
-// minID for queries like this. For now, then, we just ignore the offset and hope that
-// the caller will page using maxID and minID instead.
-//
-// In future, however, it would be good to support offset in a way that doesn't totally
-// destroy database queries. One option would be to cache previous offsets when paging
-// down (which is the most common use case).
-//
-// For example, say a caller makes a call with offset 0: we run the query as normal,
-// and in a 10 minute cache or something, store the next maxID value as it would be for
-// offset 1, for the supplied query, limit, following, etc. Then when they call for
-// offset 1, instead of supplying 'offset' in the query and causing slowdown, we check
-// the cache to see if we have the next maxID value stored for that query, and use that
-// instead. If a caller out of the blue requests offset 4 or something, on an empty cache,
-// we could run the previous 4 queries and store the offsets for those before making the
-// 5th call for page 4.
+// Postgres tsvector expressions for full-text search.
+// NOTE: these must exactly match the expressions of the
+// GIN indexes created in the fulltext_search migration,
+// otherwise Postgres won't use the indexes for searches.
+const (
+	pgStatusTSVector  = `to_tsvector('simple', COALESCE("content_plain", '') || ' ' || COALESCE("content_warning", ''))`
+	pgAccountTSVector = `setweight(to_tsvector('simple', "username" || ' ' || COALESCE("display_name", '')), 'A') || setweight(to_tsvector('simple', COALESCE("note_plain", '')), 'B')`
+)
+
+// Text searches for accounts and statuses use a full-text index:
+// FTS5 virtual tables on SQLite (kept in sync by triggers), and
+// GIN indexes on tsvector expressions on Postgres. Results of text
+// searches are ordered by relevance rather than ID, so the 'offset'
+// parameter is used as a page number to page through them, and
+// maxID and minID just restrict the range of IDs searched.
 //
-// This isn't ideal, of course, but at least we could cover the most common use case of
-// a caller paging down through results.
+// Searches which aren't ranked (tags, usernames beginning with a
+// given prefix, and status searches with no text) are ordered by
+// ID, and are best paged using maxID and minID instead.
 type searchDB struct {
 	db    *bun.DB
 	state *state.State
@@ -64,11 +58,13 @@ type searchDB struct {
 // Query example (SQLite):
 //
 //	SELECT "account"."id" FROM "accounts" AS "account"
+//	JOIN "accounts_fts_ids" ON "accounts_fts_ids"."id" = "account"."id"
+//	JOIN "accounts_fts" ON "accounts_fts"."rowid" = "accounts_fts_ids"."rowid"
 //	WHERE (("account"."domain" IS NULL) OR ("account"."domain" != "account"."username"))
 //	AND ("account"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
 //	AND ("account"."id" IN (SELECT "target_account_id" FROM "follows" WHERE ("account_id" = '016T5Q3SQKBT337DAKVSKNXXW1')))
-//	AND ((SELECT "account"."username" || COALESCE("account"."display_name", '') || COALESCE("account"."note", '') AS "account_text") LIKE '%turtle%' ESCAPE '\')
-//	ORDER BY "account"."id" DESC LIMIT 10
+//	AND ("accounts_fts" MATCH '"turtle" *')
+//	ORDER BY "accounts_fts"."rank" ASC, "account"."id" DESC LIMIT 10
 func (s *searchDB) SearchForAccounts(
 	ctx context.Context,
 	accountID string,
@@ -88,6 +84,7 @@ func (s *searchDB) SearchForAccounts(
 	var (
 		accountIDs  = make([]string, 0, limit)
 		frontToBack = true
+		ranked      = false
 	)
 
 	q := s.db.
@@ -135,19 +132,40 @@ func (s *searchDB) SearchForAccounts(
 		query = query[1:]
 		q = whereStartsLike(q, bun.Ident("account.username"), query)
 	} else {
-		// Query looks like arbitrary string.
-		// Search using LIKE for matches of query
-		// string within accountText subquery.
-		subQ := s.accountText(following)
-		q = whereLike(q, subQ, query)
+		// Query looks like arbitrary text.
+		terms := parseSearchTerms(query)
+		if len(terms) == 0 {
+			// Nothing
+			// to search.
+			return nil, nil
+		}
+
+		// People tend to search for accounts by typing
+		// the start of a name, so match the last word
+		// of each term as a prefix, if not already.
+		for i := range terms {
+			terms[i].prefix = true
+		}
+
+		// Search using the full-text index. Only include
+		// note in searched text for accounts we follow.
+		q = s.accountsFullText(q, terms, following)
+		ranked = true
 	}
 
 	if limit > 0 {
 		// Limit amount of accounts returned.
 		q = q.Limit(limit)
+
+		if offset > 0 {
+			// Skip to requested page.
+			q = q.Offset(offset * limit)
+		}
 	}
 
-	if frontToBack {
+	if ranked {
+		// Most relevant first, already ordered.
+	} else if frontToBack {
 		// Page down.
 		q = q.Order("account.id DESC")
 	} else {
@@ -166,7 +184,7 @@ func (s *searchDB) SearchForAccounts(
 	// If we're paging up, we still want accounts
 	// to be sorted by ID desc, so reverse ids slice.
 	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
+	if !frontToBack && !ranked {
 		for l, r := 0, len(accountIDs)-1; l < r; l, r = l+1, r-1 {
 			accountIDs[l], accountIDs[r] = accountIDs[r], accountIDs[l]
 		}
@@ -198,77 +216,73 @@ func (s *searchDB) followedAccounts(accountID string) *bun.SelectQuery {
 		Where("? = ?", bun.Ident("follow.account_id"), accountID)
 }
 
-// accountText returns a subquery that selects a concatenation
-// of account username and display name as "account_text". If
-// `following` is true, then account note will also be included
-// in the concatenation.
-func (s *searchDB) accountText(following bool) *bun.SelectQuery {
-	var (
-		accountText = s.db.NewSelect()
-		query       string
-		args        []interface{}
-	)
+// accountsFullText restricts the given accounts query to accounts
+// matching the given terms, ordered by relevance. If `following`
+// is true, account note is searched as well as username and
+// display name.
+func (s *searchDB) accountsFullText(
+	q *bun.SelectQuery,
+	terms []searchTerm,
+	following bool,
+) *bun.SelectQuery {
+	switch d := s.db.Dialect().Name(); d {
 
-	if following {
-		// If querying for accounts we follow,
-		// include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account.note"), "",
-			bun.Ident("account_text"),
-		}
-	} else {
-		// If querying for accounts we're not following,
-		// don't include note in text search params.
-		args = []interface{}{
-			bun.Ident("account.username"),
-			bun.Ident("account.display_name"), "",
-			bun.Ident("account_text"),
+	case dialect.SQLite:
+		columns := "username display_name"
+		if following {
+			columns += " note"
 		}
-	}
-
-	// SQLite and Postgres use different syntaxes for
-	// concatenation, and we also need to use a
-	// different number of placeholders depending on
-	// following/not following. COALESCE calls ensure
-	// that we're not trying to concatenate null values.
-
-	switch d := s.db.Dialect().Name(); {
-
-	case d == dialect.SQLite && following:
-		query = "? || COALESCE(?, ?) || COALESCE(?, ?) AS ?"
 
-	case d == dialect.SQLite && !following:
-		query = "? || COALESCE(?, ?) AS ?"
+		return q.
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("accounts_fts_ids"),
+				bun.Ident("accounts_fts_ids.id"),
+				bun.Ident("account.id"),
+			).
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("accounts_fts"),
+				bun.Ident("accounts_fts.rowid"),
+				bun.Ident("accounts_fts_ids.rowid"),
+			).
+			Where("? MATCH ?", bun.Ident("accounts_fts"), ftsMatch(terms, columns)).
+			OrderExpr("? ASC", bun.Ident("accounts_fts.rank")).
+			OrderExpr("? DESC", bun.Ident("account.id"))
 
-	case d == dialect.PG && following:
-		query = "CONCAT(?, COALESCE(?, ?), COALESCE(?, ?)) AS ?"
+	case dialect.PG:
+		// Username and display name are
+		// weighted A, and note weighted B.
+		weights := "A"
+		if following {
+			weights += "B"
+		}
 
-	case d == dialect.PG && !following:
-		query = "CONCAT(?, COALESCE(?, ?)) AS ?"
+		tsquery := pgTSQuery(terms, weights)
+		return q.
+			Where("(?) @@ to_tsquery('simple', ?)", bun.Safe(pgAccountTSVector), tsquery).
+			OrderExpr("ts_rank((?), to_tsquery('simple', ?)) DESC", bun.Safe(pgAccountTSVector), tsquery).
+			OrderExpr("? DESC", bun.Ident("account.id"))
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
 	}
-
-	return accountText.ColumnExpr(query, args...)
 }
 
 // Query example (SQLite):
 //
 //	SELECT "status"."id"
 //	FROM "statuses" AS "status"
+//	JOIN "statuses_fts_ids" ON "statuses_fts_ids"."id" = "status"."id"
+//	JOIN "statuses_fts" ON "statuses_fts"."rowid" = "statuses_fts_ids"."rowid"
 //	WHERE ("status"."boost_of_id" IS NULL)
 //	AND (("status"."account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF') OR ("status"."in_reply_to_account_id" = '01F8MH1H7YV1Z7D2C8K2730QBF'))
 //	AND ("status"."id" < 'ZZZZZZZZZZZZZZZZZZZZZZZZZZ')
-//	AND ((SELECT "status"."content" || COALESCE("status"."content_warning", '') AS "status_text") LIKE '%hello%' ESCAPE '\')
-//	ORDER BY "status"."id" DESC LIMIT 10
+//	AND ("statuses_fts" MATCH '"hello"')
+//	ORDER BY "statuses_fts"."rank" ASC, "status"."id" DESC LIMIT 10
 func (s *searchDB) SearchForStatuses(
 	ctx context.Context,
 	requestingAccountID string,
-	query string,
-	fromAccountID string,
+	query *db.StatusSearchQuery,
 	maxID string,
 	minID string,
 	limit int,
@@ -283,6 +297,7 @@ func (s *searchDB) SearchForStatuses(
 	var (
 		statusIDs   = make([]string, 0, limit)
 		frontToBack = true
+		ranked      = false
 	)
 
 	q := s.db.
@@ -299,8 +314,45 @@ func (s *searchDB) SearchForStatuses(
 				Where("? = ?", bun.Ident("status.account_id"), requestingAccountID).
 				WhereOr("? = ?", bun.Ident("status.in_reply_to_account_id"), requestingAccountID)
 		})
-	if fromAccountID != "" {
-		q = q.Where("? = ?", bun.Ident("status.account_id"), fromAccountID)
+
+	if query.FromAccountID != "" {
+		q = q.Where("? = ?", bun.Ident("status.account_id"), query.FromAccountID)
+	}
+
+	if query.HasMedia {
+		// Attachments are stored as a json object; this
+		// implementation differs between SQLite and Postgres,
+		// so we have to be thorough to cover all eventualities
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			switch d := s.db.Dialect().Name(); d {
+			case dialect.PG:
+				return q.
+					Where("? IS NOT NULL", bun.Ident("status.attachments")).
+					Where("? != '{}'", bun.Ident("status.attachments"))
+			case dialect.SQLite:
+				return q.
+					Where("? IS NOT NULL", bun.Ident("status.attachments")).
+					Where("? != ''", bun.Ident("status.attachments")).
+					Where("? != 'null'", bun.Ident("status.attachments")).
+					Where("? != '{}'", bun.Ident("status.attachments")).
+					Where("? != '[]'", bun.Ident("status.attachments"))
+			default:
+				log.Panicf(ctx, "db conn %s was neither pg nor sqlite", d)
+				return q
+			}
+		})
+	}
+
+	if query.HasPoll {
+		q = q.Where("? IS NOT NULL", bun.Ident("status.poll_id"))
+	}
+
+	if !query.Before.IsZero() {
+		q = q.Where("? < ?", bun.Ident("status.created_at"), query.Before)
+	}
+
+	if !query.After.IsZero() {
+		q = q.Where("? >= ?", bun.Ident("status.created_at"), query.After)
 	}
 
 	// Return only items with a LOWER id than maxID.
@@ -317,19 +369,29 @@ func (s *searchDB) SearchForStatuses(
 		frontToBack = false
 	}
 
-	// Select status text as subquery.
-	statusTextSubq := s.statusText()
-
-	// Search using LIKE for matches of query
-	// string within statusText subquery.
-	q = whereLike(q, statusTextSubq, query)
+	if terms := parseSearchTerms(query.Text); len(terms) > 0 {
+		// Search using the full-text index.
+		q = s.statusesFullText(q, terms)
+		ranked = true
+	} else if query.Text != "" {
+		// Text contained nothing
+		// we could search for.
+		return nil, nil
+	}
 
 	if limit > 0 {
 		// Limit amount of statuses returned.
 		q = q.Limit(limit)
+
+		if offset > 0 {
+			// Skip to requested page.
+			q = q.Offset(offset * limit)
+		}
 	}
 
-	if frontToBack {
+	if ranked {
+		// Most relevant first, already ordered.
+	} else if frontToBack {
 		// Page down.
 		q = q.Order("status.id DESC")
 	} else {
@@ -348,7 +410,7 @@ func (s *searchDB) SearchForStatuses(
 	// If we're paging up, we still want statuses
 	// to be sorted by ID desc, so reverse ids slice.
 	// https://zchee.github.io/golang-wiki/SliceTricks/#reversing
-	if !frontToBack {
+	if !frontToBack && !ranked {
 		for l, r := 0, len(statusIDs)-1; l < r; l, r = l+1, r-1 {
 			statusIDs[l], statusIDs[r] = statusIDs[r], statusIDs[l]
 		}
@@ -370,32 +432,165 @@ func (s *searchDB) SearchForStatuses(
 	return statuses, nil
 }
 
-// statusText returns a subquery that selects a concatenation
-// of status content and content warning as "status_text".
-func (s *searchDB) statusText() *bun.SelectQuery {
-	statusText := s.db.NewSelect()
-
-	// SQLite and Postgres use different
-	// syntaxes for concatenation.
+// statusesFullText restricts the given statuses query to statuses
+// whose content or content warning match the given terms, ordered
+// by relevance.
+func (s *searchDB) statusesFullText(
+	q *bun.SelectQuery,
+	terms []searchTerm,
+) *bun.SelectQuery {
 	switch d := s.db.Dialect().Name(); d {
 
 	case dialect.SQLite:
-		statusText = statusText.ColumnExpr(
-			"? || COALESCE(?, ?) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		return q.
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("statuses_fts_ids"),
+				bun.Ident("statuses_fts_ids.id"),
+				bun.Ident("status.id"),
+			).
+			Join("JOIN ? ON ? = ?",
+				bun.Ident("statuses_fts"),
+				bun.Ident("statuses_fts.rowid"),
+				bun.Ident("statuses_fts_ids.rowid"),
+			).
+			Where("? MATCH ?", bun.Ident("statuses_fts"), ftsMatch(terms, "")).
+			OrderExpr("? ASC", bun.Ident("statuses_fts.rank")).
+			OrderExpr("? DESC", bun.Ident("status.id"))
 
 	case dialect.PG:
-		statusText = statusText.ColumnExpr(
-			"CONCAT(?, COALESCE(?, ?)) AS ?",
-			bun.Ident("status.content"), bun.Ident("status.content_warning"), "",
-			bun.Ident("status_text"))
+		tsquery := pgTSQuery(terms, "")
+		return q.
+			Where("(?) @@ to_tsquery('simple', ?)", bun.Safe(pgStatusTSVector), tsquery).
+			OrderExpr("ts_rank((?), to_tsquery('simple', ?)) DESC", bun.Safe(pgStatusTSVector), tsquery).
+			OrderExpr("? DESC", bun.Ident("status.id"))
 
 	default:
 		log.Panicf(nil, "db conn %s was neither pg nor sqlite", d)
+		return nil
+	}
+}
+
+// searchTerm is one term of a full-text
+// search query: a single word, or a phrase.
+type searchTerm struct {
+	// Lowercase words of the term,
+	// to be matched in sequence.
+	words []string
+
+	// Match last word
+	// as a prefix.
+	prefix bool
+}
+
+// parseSearchTerms splits full-text query text into terms.
+// Words inside "double quotes" form one phrase term, and
+// a term ending with * matches its last word as a prefix.
+//
+// Since both FTS5 and Postgres split indexed text into words
+// on anything that isn't a letter or number, so do we; an
+// unquoted term like "foo-bar" is treated as a phrase too.
+func parseSearchTerms(text string) []searchTerm {
+	var (
+		terms  []searchTerm
+		quoted bool
+	)
+
+	// Split text into chunks on double quotes,
+	// every second chunk being a quoted phrase.
+	for _, chunk := range strings.Split(text, `"`) {
+		var raws []string
+		if quoted {
+			raws = []string{chunk}
+		} else {
+			raws = strings.Fields(chunk)
+		}
+		quoted = !quoted
+
+		for _, raw := range raws {
+			words := strings.FieldsFunc(
+				strings.ToLower(raw),
+				func(r rune) bool {
+					return !unicode.IsLetter(r) &&
+						!unicode.IsNumber(r) &&
+						!unicode.IsMark(r)
+				},
+			)
+
+			if len(words) == 0 {
+				continue
+			}
+
+			terms = append(terms, searchTerm{
+				words:  words,
+				prefix: strings.HasSuffix(strings.TrimSpace(raw), "*"),
+			})
+		}
 	}
 
-	return statusText
+	return terms
+}
+
+// ftsMatch renders terms as an SQLite FTS5 MATCH expression,
+// where each term must match. If columns is set, terms will
+// only be matched against the given (space-separated) columns.
+//
+// Words only contain letters and numbers, so can be safely
+// placed within double quotes without further escaping.
+func ftsMatch(terms []searchTerm, columns string) string {
+	var b strings.Builder
+
+	for i, term := range terms {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+
+		if columns != "" {
+			b.WriteString("{" + columns + "} : ")
+		}
+
+		b.WriteString(`"` + strings.Join(term.words, " ") + `"`)
+
+		if term.prefix {
+			b.WriteString(" *")
+		}
+	}
+
+	return b.String()
+}
+
+// pgTSQuery renders terms as a Postgres tsquery, where each
+// term must match. If weights is set, words will only match
+// lexemes with one of the given weight labels (eg., "AB").
+//
+// Words only contain letters and numbers, so can be safely
+// placed within single quotes without further escaping.
+func pgTSQuery(terms []searchTerm, weights string) string {
+	var b strings.Builder
+
+	for i, term := range terms {
+		if i > 0 {
+			b.WriteString(" & ")
+		}
+
+		for j, word := range term.words {
+			if j > 0 {
+				b.WriteString(" <-> ")
+			}
+
+			b.WriteString("'" + word + "'")
+
+			prefix := term.prefix && j == len(term.words)-1
+			if prefix || weights != "" {
+				b.WriteByte(':')
+				if prefix {
+					b.WriteByte('*')
+				}
+				b.WriteString(weights)
+			}
+		}
+	}
+
+	return b.String()
 }
 
 // Query example (SQLite):
@@ -453,6 +648,11 @@ func (s *searchDB) SearchForTags(
 	if limit > 0 {
 		// Limit amount of tags returned.
 		q = q.Limit(limit)
+
+		if offset > 0 {
+			// Skip to requested page.
+			q = q.Offset(offset * limit)
+		}
 	}
 
 	if frontToBack {
@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type SearchTestSuite struct {
@@ -107,7 +109,7 @@ func (suite *SearchTestSuite) TestSearchAccountsFossAny() {
 func (suite *SearchTestSuite) TestSearchStatuses() {
 	testAccount := suite.testAccounts["local_account_1"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hello", "", "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "hello"}, "", "", 10, 0)
 	suite.NoError(err)
 	suite.Len(statuses, 1)
 }
@@ -116,13 +118,196 @@ func (suite *SearchTestSuite) TestSearchStatusesFromAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 	fromAccount := suite.testAccounts["local_account_2"]
 
-	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, "hi", fromAccount.ID, "", "", 10, 0)
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "hi", FromAccountID: fromAccount.ID}, "", "", 10, 0)
 	suite.NoError(err)
 	if suite.Len(statuses, 1) {
 		suite.Equal(fromAccount.ID, statuses[0].AccountID)
 	}
 }
 
+func (suite *SearchTestSuite) TestSearchAccountsPrefix() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Words are matched as prefixes for accounts.
+	accounts, err := suite.db.SearchForAccounts(context.Background(), testAccount.ID, "turt", "", "", 10, false, 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesPhrase() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: `"a little gif"`}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_1_status_4"].ID, statuses[0].ID)
+	}
+
+	// Same words out of order shouldn't match the phrase.
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: `"gif little a"`}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesPrefix() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// Whole words only by default.
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "sloth"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	statuses, err = suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "sloth*"}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_1_status_6"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesContentWarning() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	statuses, err := suite.db.SearchForStatuses(context.Background(), testAccount.ID, &db.StatusSearchQuery{Text: "REZNOR"}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_1_status_4"].ID, statuses[0].ID)
+	}
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesFilters() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+	)
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{HasMedia: true}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{HasPoll: true}, "", "", 10, 0)
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(suite.testStatuses["local_account_1_status_6"].ID, statuses[0].ID)
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{
+		After: testrig.TimeMustParse("2023-01-01T00:00:00Z"),
+	}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{
+		Text:   "hi",
+		Before: testrig.TimeMustParse("2022-01-01T00:00:00Z"),
+	}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 2)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesOffset() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		query       = &db.StatusSearchQuery{Text: "hi"}
+		seen        = make(map[string]struct{})
+	)
+
+	// Page through results one at a time.
+	for offset := 0; offset < 3; offset++ {
+		statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, query, "", "", 1, offset)
+		suite.NoError(err)
+		if suite.Len(statuses, 1) {
+			seen[statuses[0].ID] = struct{}{}
+		}
+	}
+	suite.Len(seen, 3)
+
+	// No more pages.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, query, "", "", 1, 3)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesUpdateDelete() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		status      = new(gtsmodel.Status)
+	)
+	*status = *suite.testStatuses["local_account_1_status_6"]
+
+	// Index should follow edits.
+	status.Content = "what do you think of pangolins?"
+	if err := suite.db.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: "sloths"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: "pangolins"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+
+	// And deletions.
+	if err := suite.db.DeleteStatusByID(ctx, status.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err = suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: "pangolins"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *SearchTestSuite) TestSearchStatusesIgnoresHTML() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		status      = new(gtsmodel.Status)
+	)
+	*status = *suite.testStatuses["local_account_1_status_6"]
+
+	status.Content = `<p>what do you think of <span class="h-card">pangolins</span>?</p>`
+	if err := suite.db.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Markup shouldn't match.
+	for _, text := range []string{"span", "class", "h-card"} {
+		statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: text}, "", "", 10, 0)
+		suite.NoError(err)
+		suite.Empty(statuses, text)
+	}
+
+	// But the text inside it should.
+	statuses, err := suite.db.SearchForStatuses(ctx, testAccount.ID, &db.StatusSearchQuery{Text: "pangolins"}, "", "", 10, 0)
+	suite.NoError(err)
+	suite.Len(statuses, 1)
+}
+
+func (suite *SearchTestSuite) TestSearchAccountsIgnoresHTML() {
+	var (
+		ctx         = context.Background()
+		testAccount = suite.testAccounts["local_account_1"]
+		account     = new(gtsmodel.Account)
+	)
+	*account = *suite.testAccounts["local_account_2"]
+
+	account.Note = `<p>i post about <span class="h-card">pangolins</span></p>`
+	if err := suite.db.UpdateAccount(ctx, account, "note"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	accounts, err := suite.db.SearchForAccounts(ctx, testAccount.ID, "span", "", "", 10, true, 0)
+	suite.NoError(err)
+	suite.Empty(accounts)
+
+	accounts, err = suite.db.SearchForAccounts(ctx, testAccount.ID, "pangolins", "", "", 10, true, 0)
+	suite.NoError(err)
+	suite.Len(accounts, 1)
+}
+
 func (suite *SearchTestSuite) TestSearchTags() {
 	// Search with full tag string.
 	tags, err := suite.db.SearchForTags(context.Background(), "welcome", "", "", 10, 0)
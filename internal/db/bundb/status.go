@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/uptrace/bun"
 )
@@ -129,6 +130,22 @@ func (s *statusDB) GetStatusByPollID(ctx context.Context, pollID string) (*gtsmo
 	)
 }
 
+func (s *statusDB) GetStatusByImportedFromURI(ctx context.Context, accountID string, uri string) (*gtsmodel.Status, error) {
+	var id string
+	if err := s.db.
+		NewSelect().
+		Table("statuses").
+		Column("id").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Where("? = ?", bun.Ident("imported_from_uri"), uri).
+		Limit(1).
+		Scan(ctx, &id); err != nil {
+		return nil, err
+	}
+
+	return s.GetStatusByID(ctx, id)
+}
+
 func (s *statusDB) GetStatusBoost(ctx context.Context, boostOfID string, byAccountID string) (*gtsmodel.Status, error) {
 	return s.getStatus(
 		ctx,
@@ -181,7 +198,7 @@ func (s *statusDB) getStatus(ctx context.Context, lookup string, dbQuery func(*g
 func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status) error {
 	var (
 		err  error
-		errs = gtserror.NewMultiError(9)
+		errs = gtserror.NewMultiError(10)
 	)
 
 	if status.Account == nil {
@@ -243,6 +260,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if status.QuoteID != "" && status.Quote == nil {
+		// Status quote is not set, fetch from database.
+		status.Quote, err = s.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			status.QuoteID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			errs.Appendf("error populating status quote: %w", err)
+		}
+	}
+
 	if status.PollID != "" && status.Poll == nil {
 		// Status poll is not set, fetch from database.
 		status.Poll, err = s.state.DB.GetPollByID(
@@ -298,6 +326,17 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 		}
 	}
 
+	if !status.EditsPopulated() {
+		// Status edits are out-of-date with IDs, repopulate.
+		status.Edits, err = s.state.DB.GetStatusEditsByIDs(
+			ctx, // leave fully populated for now
+			status.EditIDs,
+		)
+		if err != nil {
+			errs.Appendf("error populating status edits: %w", err)
+		}
+	}
+
 	if status.CreatedWithApplicationID != "" && status.CreatedWithApplication == nil {
 		// Populate the status' expected CreatedWithApplication (not always set).
 		status.CreatedWithApplication, err = s.state.DB.GetApplicationByID(
@@ -313,6 +352,10 @@ func (s *statusDB) PopulateStatus(ctx context.Context, status *gtsmodel.Status)
 }
 
 func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) error {
+	// Keep the plaintext version used
+	// for full-text search up to date.
+	status.ContentPlain = text.SanitizeToSearchText(status.Content)
+
 	return s.state.Caches.DB.Status.Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -397,6 +440,15 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 		columns = append(columns, "updated_at")
 	}
 
+	if len(columns) == 0 || slices.Contains(columns, "content") {
+		// Keep the plaintext version used
+		// for full-text search up to date.
+		status.ContentPlain = text.SanitizeToSearchText(status.Content)
+		if len(columns) > 0 {
+			columns = append(columns, "content_plain")
+		}
+	}
+
 	return s.state.Caches.DB.Status.Store(status, func() error {
 		// It is safe to run this database transaction within cache.Store
 		// as the cache does not attempt a mutex lock until AFTER hook.
@@ -418,6 +470,17 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			// remove links to any emojis this status no longer uses
+			q := tx.NewDelete().
+				Table("status_to_emojis").
+				Where("? = ?", bun.Ident("status_id"), status.ID)
+			if len(status.EmojiIDs) > 0 {
+				q = q.Where("? NOT IN (?)", bun.Ident("emoji_id"), bun.In(status.EmojiIDs))
+			}
+			if _, err := q.Exec(ctx); err != nil {
+				return err
+			}
+
 			// create links between this status and any tags it uses
 			for _, i := range status.TagIDs {
 				if _, err := tx.
@@ -434,6 +497,17 @@ func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status, co
 				}
 			}
 
+			// remove links to any tags this status no longer uses
+			q = tx.NewDelete().
+				Table("status_to_tags").
+				Where("? = ?", bun.Ident("status_id"), status.ID)
+			if len(status.TagIDs) > 0 {
+				q = q.Where("? NOT IN (?)", bun.Ident("tag_id"), bun.In(status.TagIDs))
+			}
+			if _, err := q.Exec(ctx); err != nil {
+				return err
+			}
+
 			// change the status ID of the media attachments to the new status
 			for _, a := range status.Attachments {
 				a.StatusID = status.ID
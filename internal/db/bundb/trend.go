@@ -0,0 +1,250 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/uptrace/bun"
+)
+
+// trendDB provides access to computed trends, and to
+// the raw usage data that trends are computed from. It's
+// uncached, as trends are only recomputed periodically.
+type trendDB struct{ db *bun.DB }
+
+func (t *trendDB) GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error) {
+	trend := new(gtsmodel.Trend)
+	if err := t.db.NewSelect().
+		Model(trend).
+		Where("? = ?", bun.Ident("trend.id"), id).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return trend, nil
+}
+
+func (t *trendDB) GetTrend(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.Trend, error) {
+	trend := new(gtsmodel.Trend)
+	if err := t.db.NewSelect().
+		Model(trend).
+		Where("? = ?", bun.Ident("trend.type"), trendType).
+		Where("? = ?", bun.Ident("trend.target"), target).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+	return trend, nil
+}
+
+func (t *trendDB) GetTrends(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	state gtsmodel.TrendState,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Trend, error) {
+	var trends []*gtsmodel.Trend
+
+	q := t.db.NewSelect().
+		Model(&trends).
+		Where("? = ?", bun.Ident("trend.type"), trendType).
+		Where("? > 0", bun.Ident("trend.score"))
+
+	if state != "" {
+		q = q.Where("? = ?", bun.Ident("trend.state"), state)
+	}
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if offset > 0 {
+		q = q.Offset(offset)
+	}
+
+	if err := q.
+		OrderExpr("? DESC", bun.Ident("trend.score")).
+		OrderExpr("? DESC", bun.Ident("trend.id")).
+		Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return trends, nil
+}
+
+func (t *trendDB) PutTrend(ctx context.Context, trend *gtsmodel.Trend) error {
+	_, err := t.db.NewInsert().
+		Model(trend).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error {
+	trend.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	_, err := t.db.NewUpdate().
+		Model(trend).
+		Column(columns...).
+		Where("? = ?", bun.Ident("trend.id"), trend.ID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) ClearStaleTrends(ctx context.Context, trendType gtsmodel.TrendType, before time.Time) error {
+	_, err := t.db.NewUpdate().
+		Table("trends").
+		Set("? = 0", bun.Ident("score")).
+		Where("? = ?", bun.Ident("type"), trendType).
+		Where("? > 0", bun.Ident("score")).
+		Where("? < ?", bun.Ident("computed_at"), before).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) GetTagUses(ctx context.Context, since time.Time) ([]*db.TrendUse, error) {
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating ulid: %w", err)
+	}
+
+	var uses []*db.TrendUse
+	if err := t.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("target")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("status_to_tag.status_id"),
+		).
+		// Status IDs are ULIDs, so this is
+		// equivalent to (but faster than)
+		// filtering on status creation time.
+		Where("? >= ?", bun.Ident("status_to_tag.status_id"), sinceID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Scan(ctx, &uses); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *trendDB) GetStatusInteractions(ctx context.Context, since time.Time) ([]*db.TrendUse, error) {
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating ulid: %w", err)
+	}
+
+	var faves []*db.TrendUse
+	if err := t.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_faves"), bun.Ident("status_fave")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.status_id"), bun.Ident("target")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status_fave.created_at"), bun.Ident("created_at")).
+		Where("? >= ?", bun.Ident("status_fave.id"), sinceID).
+		Scan(ctx, &faves); err != nil {
+		return nil, err
+	}
+
+	var boosts []*db.TrendUse
+	if err := t.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("statuses"), bun.Ident("status")).
+		ColumnExpr("? AS ?", bun.Ident("status.boost_of_id"), bun.Ident("target")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Where("? >= ?", bun.Ident("status.id"), sinceID).
+		Where("? IS NOT NULL", bun.Ident("status.boost_of_id")).
+		Scan(ctx, &boosts); err != nil {
+		return nil, err
+	}
+
+	return append(faves, boosts...), nil
+}
+
+func (t *trendDB) GetLinkUses(ctx context.Context, since time.Time) ([]*db.TrendUse, error) {
+	sinceID, err := id.NewULIDFromTime(since)
+	if err != nil {
+		return nil, gtserror.Newf("error creating ulid: %w", err)
+	}
+
+	var uses []*db.TrendUse
+	if err := t.db.NewSelect().
+		TableExpr("? AS ?", bun.Ident("trend_links"), bun.Ident("trend_link")).
+		ColumnExpr("? AS ?", bun.Ident("trend_link.url"), bun.Ident("target")).
+		ColumnExpr("? AS ?", bun.Ident("trend_link.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("trend_link.created_at"), bun.Ident("created_at")).
+		// Join on statuses so that links
+		// in since-deleted statuses, or
+		// non-public ones, don't count.
+		Join(
+			"JOIN ? AS ? ON ? = ?",
+			bun.Ident("statuses"), bun.Ident("status"),
+			bun.Ident("status.id"), bun.Ident("trend_link.status_id"),
+		).
+		Where("? >= ?", bun.Ident("trend_link.status_id"), sinceID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		Scan(ctx, &uses); err != nil {
+		return nil, err
+	}
+
+	return uses, nil
+}
+
+func (t *trendDB) PutTrendLinks(ctx context.Context, links []*gtsmodel.TrendLink) error {
+	if len(links) == 0 {
+		return nil
+	}
+
+	_, err := t.db.NewInsert().
+		Model(&links).
+		On("CONFLICT (?, ?) DO NOTHING", bun.Ident("status_id"), bun.Ident("url")).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) DeleteTrendLinks(ctx context.Context, statusID string) error {
+	_, err := t.db.NewDelete().
+		Table("trend_links").
+		Where("? = ?", bun.Ident("status_id"), statusID).
+		Exec(ctx)
+	return err
+}
+
+func (t *trendDB) DeleteTrendLinksBefore(ctx context.Context, before time.Time) error {
+	beforeID, err := id.NewULIDFromTime(before)
+	if err != nil {
+		return gtserror.Newf("error creating ulid: %w", err)
+	}
+
+	_, err = t.db.NewDelete().
+		Table("trend_links").
+		Where("? < ?", bun.Ident("status_id"), beforeID).
+		Exec(ctx)
+	return err
+}
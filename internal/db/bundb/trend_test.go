@@ -0,0 +1,242 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TrendTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TrendTestSuite) TestTrendLifecycle() {
+	ctx := context.Background()
+	computedAt := time.Now().Truncate(time.Second)
+
+	// Store a few trends of different types.
+	trends := []*gtsmodel.Trend{
+		{
+			ID:         id.NewULID(),
+			Type:       gtsmodel.TrendTypeTag,
+			Target:     suite.testTags["welcome"].ID,
+			Score:      2,
+			Uses:       []int{3, 0, 0, 0, 0, 0, 1},
+			Accounts:   []int{2, 0, 0, 0, 0, 0, 1},
+			ComputedAt: computedAt,
+			State:      gtsmodel.TrendStatePending,
+		},
+		{
+			ID:         id.NewULID(),
+			Type:       gtsmodel.TrendTypeTag,
+			Target:     suite.testTags["Hashtag"].ID,
+			Score:      5,
+			ComputedAt: computedAt,
+			State:      gtsmodel.TrendStatePending,
+		},
+		{
+			ID:         id.NewULID(),
+			Type:       gtsmodel.TrendTypeLink,
+			Target:     "https://example.org/some/article",
+			Score:      1,
+			ComputedAt: computedAt,
+			State:      gtsmodel.TrendStatePending,
+		},
+	}
+	for _, trend := range trends {
+		if err := suite.db.PutTrend(ctx, trend); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	// Same type + target can't be stored twice.
+	err := suite.db.PutTrend(ctx, &gtsmodel.Trend{
+		ID:     id.NewULID(),
+		Type:   gtsmodel.TrendTypeTag,
+		Target: suite.testTags["welcome"].ID,
+	})
+	suite.ErrorIs(err, db.ErrAlreadyExists)
+
+	trend, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeTag, suite.testTags["welcome"].ID)
+	suite.NoError(err)
+	suite.Equal(trends[0].ID, trend.ID)
+	suite.Equal([]int{3, 0, 0, 0, 0, 0, 1}, trend.Uses)
+	suite.Equal([]int{2, 0, 0, 0, 0, 0, 1}, trend.Accounts)
+
+	// Tag trends should be ordered by score.
+	tagTrends, err := suite.db.GetTrends(ctx, gtsmodel.TrendTypeTag, "", 0, 0)
+	suite.NoError(err)
+	suite.Len(tagTrends, 2)
+	suite.Equal(trends[1].ID, tagTrends[0].ID)
+	suite.Equal(trends[0].ID, tagTrends[1].ID)
+
+	// Offset should skip the first.
+	tagTrends, err = suite.db.GetTrends(ctx, gtsmodel.TrendTypeTag, "", 10, 1)
+	suite.NoError(err)
+	suite.Len(tagTrends, 1)
+	suite.Equal(trends[0].ID, tagTrends[0].ID)
+
+	// Nothing approved yet.
+	tagTrends, err = suite.db.GetTrends(ctx, gtsmodel.TrendTypeTag, gtsmodel.TrendStateApproved, 0, 0)
+	suite.NoError(err)
+	suite.Empty(tagTrends)
+
+	// Approve one.
+	trends[0].State = gtsmodel.TrendStateApproved
+	trends[0].ReviewedByAccountID = suite.testAccounts["admin_account"].ID
+	trends[0].ReviewedAt = time.Now()
+	if err := suite.db.UpdateTrend(ctx,
+		trends[0],
+		"state",
+		"reviewed_by_account_id",
+		"reviewed_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tagTrends, err = suite.db.GetTrends(ctx, gtsmodel.TrendTypeTag, gtsmodel.TrendStateApproved, 0, 0)
+	suite.NoError(err)
+	suite.Len(tagTrends, 1)
+	suite.True(tagTrends[0].IsApproved())
+	suite.Equal(suite.testAccounts["admin_account"].ID, tagTrends[0].ReviewedByAccountID)
+
+	// Recompute the approved tag trend only, the
+	// other tag trend should now be cleared, but
+	// link trends should be left untouched.
+	recomputedAt := computedAt.Add(15 * time.Minute)
+	trends[0].ComputedAt = recomputedAt
+	if err := suite.db.UpdateTrend(ctx, trends[0], "computed_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.db.ClearStaleTrends(ctx, gtsmodel.TrendTypeTag, recomputedAt); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tagTrends, err = suite.db.GetTrends(ctx, gtsmodel.TrendTypeTag, "", 0, 0)
+	suite.NoError(err)
+	suite.Len(tagTrends, 1)
+	suite.Equal(trends[0].ID, tagTrends[0].ID)
+
+	trend, err = suite.db.GetTrendByID(ctx, trends[1].ID)
+	suite.NoError(err)
+	suite.Zero(trend.Score)
+
+	linkTrends, err := suite.db.GetTrends(ctx, gtsmodel.TrendTypeLink, "", 0, 0)
+	suite.NoError(err)
+	suite.Len(linkTrends, 1)
+}
+
+func (suite *TrendTestSuite) TestGetTagUses() {
+	since := testrig.TimeMustParse("2020-01-01T00:00:00Z")
+
+	uses, err := suite.db.GetTagUses(context.Background(), since)
+	suite.NoError(err)
+	suite.NotEmpty(uses)
+
+	for _, use := range uses {
+		tag, err := suite.db.GetTag(context.Background(), use.Target)
+		suite.NoError(err)
+		suite.NotNil(tag)
+		suite.NotEmpty(use.AccountID)
+		suite.False(use.CreatedAt.IsZero())
+	}
+
+	// Nothing should be
+	// used in the future.
+	uses, err = suite.db.GetTagUses(context.Background(), time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Empty(uses)
+}
+
+func (suite *TrendTestSuite) TestGetStatusInteractions() {
+	since := testrig.TimeMustParse("2020-01-01T00:00:00Z")
+
+	interactions, err := suite.db.GetStatusInteractions(context.Background(), since)
+	suite.NoError(err)
+	suite.NotEmpty(interactions)
+
+	for _, interaction := range interactions {
+		_, err := suite.db.GetStatusByID(context.Background(), interaction.Target)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			suite.FailNow(err.Error())
+		}
+		suite.NotEmpty(interaction.AccountID)
+	}
+}
+
+func (suite *TrendTestSuite) TestTrendLinks() {
+	var (
+		ctx       = context.Background()
+		since     = testrig.TimeMustParse("2020-01-01T00:00:00Z")
+		public    = suite.testStatuses["admin_account_status_1"]
+		followers = suite.testStatuses["local_account_1_status_5"]
+	)
+
+	var links []*gtsmodel.TrendLink
+	for _, status := range []*gtsmodel.Status{public, followers} {
+		links = append(links, &gtsmodel.TrendLink{
+			StatusID:  status.ID,
+			URL:       "https://example.org/",
+			AccountID: status.AccountID,
+			CreatedAt: status.CreatedAt,
+		})
+	}
+
+	// Put links twice, the second
+	// time should just be ignored.
+	suite.NoError(suite.db.PutTrendLinks(ctx, links))
+	suite.NoError(suite.db.PutTrendLinks(ctx, links))
+
+	// Only the link in the public
+	// status should be counted.
+	uses, err := suite.db.GetLinkUses(ctx, since)
+	suite.NoError(err)
+	suite.Len(uses, 1)
+	suite.Equal("https://example.org/", uses[0].Target)
+	suite.Equal(public.AccountID, uses[0].AccountID)
+	suite.True(public.CreatedAt.Equal(uses[0].CreatedAt))
+
+	// Deleting links in the public
+	// status should remove its use.
+	suite.NoError(suite.db.DeleteTrendLinks(ctx, public.ID))
+	uses, err = suite.db.GetLinkUses(ctx, since)
+	suite.NoError(err)
+	suite.Empty(uses)
+
+	// As should deleting links in
+	// statuses before a given time.
+	suite.NoError(suite.db.PutTrendLinks(ctx, links))
+	suite.NoError(suite.db.DeleteTrendLinksBefore(ctx, followers.CreatedAt))
+	uses, err = suite.db.GetLinkUses(ctx, since)
+	suite.NoError(err)
+	suite.Empty(uses)
+}
+
+func TestTrendTestSuite(t *testing.T) {
+	suite.Run(t, new(TrendTestSuite))
+}
@@ -208,6 +208,57 @@ func (u *userDB) UpdateUser(ctx context.Context, user *gtsmodel.User, columns ..
 	})
 }
 
+func (u *userDB) IncrementUserTwoFactorFailures(ctx context.Context, id string) (int, error) {
+	// Increment in the database rather than
+	// read-modify-write, so that concurrent
+	// guesses are all counted.
+	var failures int
+	if err := u.db.
+		NewUpdate().
+		Table("users").
+		Set("? = COALESCE(?, 0) + 1", bun.Ident("two_factor_failures"), bun.Ident("two_factor_failures")).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		Returning("?", bun.Ident("two_factor_failures")).
+		Scan(ctx, &failures); err != nil {
+		return 0, err
+	}
+
+	// Cached user is now stale.
+	u.state.Caches.DB.User.Invalidate("ID", id)
+	return failures, nil
+}
+
+func (u *userDB) SetUserTwoFactorLastStep(ctx context.Context, id string, step int64) (bool, error) {
+	// Only update if the step is after the
+	// stored one, so that of two concurrent
+	// uses of one code only one succeeds.
+	res, err := u.db.
+		NewUpdate().
+		Table("users").
+		Set("? = ?", bun.Ident("two_factor_last_step"), step).
+		Set("? = ?", bun.Ident("updated_at"), time.Now()).
+		Where("? = ?", bun.Ident("id"), id).
+		WhereGroup(" AND ", func(q *bun.UpdateQuery) *bun.UpdateQuery {
+			return q.
+				Where("? IS NULL", bun.Ident("two_factor_last_step")).
+				WhereOr("? < ?", bun.Ident("two_factor_last_step"), step)
+		}).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	// Cached user is now stale.
+	u.state.Caches.DB.User.Invalidate("ID", id)
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return rows == 1, nil
+}
+
 func (u *userDB) DeleteUserByID(ctx context.Context, userID string) error {
 	defer u.state.Caches.DB.User.Invalidate("ID", userID)
 
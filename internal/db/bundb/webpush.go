@@ -0,0 +1,304 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb
+
+import (
+	"context"
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/uptrace/bun"
+)
+
+type webPushDB struct {
+	db    *bun.DB
+	state *state.State
+
+	// vapidKeyPairLock prevents
+	// concurrent generation of
+	// more than one key pair.
+	vapidKeyPairLock sync.Mutex
+}
+
+func (w *webPushDB) GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error) {
+	// Look for cached keys.
+	if vapidKeyPair := w.state.Caches.DB.VAPIDKeyPair.Load(); vapidKeyPair != nil {
+		return vapidKeyPair, nil
+	}
+
+	w.vapidKeyPairLock.Lock()
+	defer w.vapidKeyPairLock.Unlock()
+
+	// Check again in case another
+	// caller populated the cache
+	// while we awaited the lock.
+	if vapidKeyPair := w.state.Caches.DB.VAPIDKeyPair.Load(); vapidKeyPair != nil {
+		return vapidKeyPair, nil
+	}
+
+	// Look for previously stored keys.
+	vapidKeyPair := new(gtsmodel.VAPIDKeyPair)
+	err := w.db.
+		NewSelect().
+		Model(vapidKeyPair).
+		Limit(1).
+		Scan(ctx)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, err
+	}
+
+	if err != nil {
+		// No keys stored yet, generate new ones.
+		vapidKeyPair, err = newVAPIDKeyPair()
+		if err != nil {
+			return nil, gtserror.Newf("error generating VAPID key pair: %w", err)
+		}
+
+		if _, err := w.db.
+			NewInsert().
+			Model(vapidKeyPair).
+			Exec(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	// Store the keys in the cache.
+	w.state.Caches.DB.VAPIDKeyPair.Store(vapidKeyPair)
+
+	return vapidKeyPair, nil
+}
+
+// newVAPIDKeyPair generates a new P-256 key pair,
+// encoded in the formats expected by Web Push.
+func newVAPIDKeyPair() (*gtsmodel.VAPIDKeyPair, error) {
+	privateKey, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtsmodel.VAPIDKeyPair{
+		ID:      1,
+		Public:  base64.RawURLEncoding.EncodeToString(privateKey.PublicKey().Bytes()),
+		Private: base64.RawURLEncoding.EncodeToString(privateKey.Bytes()),
+	}, nil
+}
+
+func (w *webPushDB) DeleteVAPIDKeyPair(ctx context.Context) error {
+	w.vapidKeyPairLock.Lock()
+	defer w.vapidKeyPairLock.Unlock()
+
+	return w.db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		// Delete any existing keys.
+		if _, err := tx.
+			NewDelete().
+			Table("vapid_key_pairs").
+			Where("1 = 1").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Subscriptions were created against the
+		// deleted keys, so they're now useless.
+		if _, err := tx.
+			NewDelete().
+			Table("web_push_subscriptions").
+			Where("1 = 1").
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// Clear the caches.
+		w.state.Caches.DB.VAPIDKeyPair.Store(nil)
+		w.state.Caches.DB.WebPushSubscription.Clear()
+		w.state.Caches.DB.WebPushSubscriptionIDs.Clear()
+
+		return nil
+	})
+}
+
+func (w *webPushDB) GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error) {
+	return w.state.Caches.DB.WebPushSubscription.LoadOne("TokenID",
+		func() (*gtsmodel.WebPushSubscription, error) {
+			var subscription gtsmodel.WebPushSubscription
+
+			// Not cached! Perform database query.
+			if err := w.db.NewSelect().
+				Model(&subscription).
+				Where("? = ?", bun.Ident("token_id"), tokenID).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return &subscription, nil
+		}, tokenID,
+	)
+}
+
+func (w *webPushDB) GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error) {
+	// Fetch IDs of all subscriptions created by this account.
+	subscriptionIDs, err := w.state.Caches.DB.WebPushSubscriptionIDs.Load(accountID, func() ([]string, error) {
+		var subscriptionIDs []string
+
+		// Subscription IDs not cached,
+		// perform database query.
+		if err := w.db.
+			NewSelect().
+			Table("web_push_subscriptions").
+			Column("id").
+			Where("? = ?", bun.Ident("account_id"), accountID).
+			Order("id DESC").
+			Scan(ctx, &subscriptionIDs); err != nil {
+			return nil, err
+		}
+
+		return subscriptionIDs, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	// Load all subscriptions by their IDs.
+	subscriptions, err := w.state.Caches.DB.WebPushSubscription.LoadIDs("ID",
+		subscriptionIDs,
+		func(uncached []string) ([]*gtsmodel.WebPushSubscription, error) {
+			// Preallocate expected length of uncached subscriptions.
+			subscriptions := make([]*gtsmodel.WebPushSubscription, 0, len(uncached))
+
+			// Perform database query scanning
+			// the remaining (uncached) IDs.
+			if err := w.db.NewSelect().
+				Model(&subscriptions).
+				Where("? IN (?)", bun.Ident("id"), bun.In(uncached)).
+				Scan(ctx); err != nil {
+				return nil, err
+			}
+
+			return subscriptions, nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reorder the subscriptions by their
+	// IDs to ensure in correct order.
+	getID := func(s *gtsmodel.WebPushSubscription) string { return s.ID }
+	util.OrderBy(subscriptions, subscriptionIDs, getID)
+
+	return subscriptions, nil
+}
+
+func (w *webPushDB) PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error {
+	return w.state.Caches.DB.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.NewInsert().Model(subscription).Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error {
+	subscription.UpdatedAt = time.Now()
+	if len(columns) > 0 {
+		// If we're updating by column,
+		// ensure "updated_at" is included.
+		columns = append(columns, "updated_at")
+	}
+
+	return w.state.Caches.DB.WebPushSubscription.Store(subscription, func() error {
+		_, err := w.db.NewUpdate().
+			Model(subscription).
+			Where("? = ?", bun.Ident("id"), subscription.ID).
+			Column(columns...).
+			Exec(ctx)
+		return err
+	})
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByID(ctx context.Context, id string) error {
+	// Deleted subscription fields,
+	// needed for cache invalidation.
+	var deleted gtsmodel.WebPushSubscription
+
+	// Delete subscription, returning the account ID.
+	if _, err := w.db.NewDelete().
+		Model(&deleted).
+		Where("? = ?", bun.Ident("id"), id).
+		Returning("?", bun.Ident("account_id")).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate the subscription by ID, and manually
+	// invalidate its account's subscription IDs in
+	// case the subscription itself wasn't cached.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("ID", id)
+	w.state.Caches.OnInvalidateWebPushSubscription(&deleted)
+
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error {
+	// Deleted subscription fields,
+	// needed for cache invalidation.
+	var deleted gtsmodel.WebPushSubscription
+
+	// Delete subscription, returning the account ID.
+	if _, err := w.db.NewDelete().
+		Model(&deleted).
+		Where("? = ?", bun.Ident("token_id"), tokenID).
+		Returning("?", bun.Ident("account_id")).
+		Exec(ctx); err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return err
+	}
+
+	// Invalidate the subscription by token ID, and
+	// manually invalidate its account's subscription
+	// IDs in case the subscription itself wasn't cached.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("TokenID", tokenID)
+	w.state.Caches.OnInvalidateWebPushSubscription(&deleted)
+
+	return nil
+}
+
+func (w *webPushDB) DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error {
+	// Delete all subscriptions owned by given account.
+	if _, err := w.db.NewDelete().
+		Table("web_push_subscriptions").
+		Where("? = ?", bun.Ident("account_id"), accountID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	// Invalidate all cached subscriptions by account ID.
+	w.state.Caches.DB.WebPushSubscription.Invalidate("AccountID", accountID)
+	w.state.Caches.DB.WebPushSubscriptionIDs.Invalidate(accountID)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type WebPushTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *WebPushTestSuite) TestGetVAPIDKeyPair() {
+	ctx := context.Background()
+
+	// Should match our fixture.
+	vapidKeyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(testrig.NewTestVAPIDKeyPair().Public, vapidKeyPair.Public)
+
+	// Deleting the keys should also delete all subscriptions.
+	if err := suite.db.DeleteVAPIDKeyPair(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, suite.testAccounts["local_account_1"].ID)
+	suite.NoError(err)
+	suite.Empty(subscriptions)
+
+	// Getting the keys again should generate new ones.
+	newVAPIDKeyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEqual(vapidKeyPair.Public, newVAPIDKeyPair.Public)
+	suite.NotEqual(vapidKeyPair.Private, newVAPIDKeyPair.Private)
+
+	// Which should be stable.
+	sameVAPIDKeyPair, err := suite.db.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(newVAPIDKeyPair, sameVAPIDKeyPair)
+}
+
+func (suite *WebPushTestSuite) TestWebPushSubscriptions() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testToken := suite.testTokens["local_account_1_user_authorization_token"]
+
+	// Account already has one subscription from fixtures.
+	subscriptions, err := suite.db.GetWebPushSubscriptionsByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(subscriptions, 1)
+
+	// Add another for a different token.
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: testAccount.ID,
+		TokenID:   testToken.ID,
+		Endpoint:  "https://example.org/push/another",
+		Auth:      "nEOwKCGKs7HggG0qnuPiIA",
+		P256dh:    "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGvfLGDKxI3SSrziHW3UQuw0YIQC3vETqOUteUwxqD7ijU",
+		Policy:    gtsmodel.WebPushNotificationPolicyFollowed,
+	}
+	subscription.NotificationFlags.Set(gtsmodel.NotificationMention, true)
+	if err := suite.db.PutWebPushSubscription(ctx, subscription); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Both should now be returned for the account.
+	subscriptions, err = suite.db.GetWebPushSubscriptionsByAccountID(ctx, testAccount.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(subscriptions, 2)
+
+	// Update the new one.
+	subscription.NotificationFlags.Set(gtsmodel.NotificationFave, true)
+	if err := suite.db.UpdateWebPushSubscription(ctx, subscription, "notification_flags"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Fetch it by token ID and check our update.
+	dbSubscription, err := suite.db.GetWebPushSubscriptionByTokenID(ctx, testToken.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbSubscription.NotificationFlags.Get(gtsmodel.NotificationMention))
+	suite.True(dbSubscription.NotificationFlags.Get(gtsmodel.NotificationFave))
+	suite.False(dbSubscription.NotificationFlags.Get(gtsmodel.NotificationFollow))
+	suite.Equal(gtsmodel.WebPushNotificationPolicyFollowed, dbSubscription.Policy)
+
+	// Delete it by token ID.
+	if err := suite.db.DeleteWebPushSubscriptionByTokenID(ctx, testToken.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, testToken.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Deleting it again should be fine.
+	if err := suite.db.DeleteWebPushSubscriptionByTokenID(ctx, testToken.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Delete the rest by account ID.
+	if err := suite.db.DeleteWebPushSubscriptionsByAccountID(ctx, testAccount.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	subscriptions, err = suite.db.GetWebPushSubscriptionsByAccountID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(subscriptions)
+}
+
+func TestWebPushTestSuite(t *testing.T) {
+	suite.Run(t, new(WebPushTestSuite))
+}
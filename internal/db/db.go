@@ -28,33 +28,43 @@ type DB interface {
 	Admin
 	AdvancedMigration
 	Application
+	Archive
 	Basic
 	Conversation
+	Delivery
 	Domain
 	Emoji
 	HeaderFilter
+	Import
 	Instance
 	Interaction
 	Filter
+	Group
 	List
 	Marker
 	Media
+	MediaHashBlock
 	Mention
 	Move
 	Notification
 	Poll
+	Relay
 	Relationship
 	Report
 	Rule
+	ScheduledStatus
 	Search
 	Session
 	Status
 	StatusBookmark
+	StatusEdit
 	StatusFave
 	Tag
 	Thread
 	Timeline
 	User
 	Tombstone
+	Trend
+	WebPush
 	WorkerTask
 }
@@ -0,0 +1,61 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Delivery interface {
+	// GetPendingDeliveries fetches all journaled deliveries that
+	// are not currently parked, in the order they were journaled.
+	GetPendingDeliveries(ctx context.Context) ([]*gtsmodel.PendingDelivery, error)
+
+	// GetParkedDeliveries fetches all journaled deliveries to the given
+	// domain that are currently parked, in the order they were journaled.
+	GetParkedDeliveries(ctx context.Context, domain string) ([]*gtsmodel.PendingDelivery, error)
+
+	// PutPendingDeliveries inserts the given deliveries into the journal.
+	PutPendingDeliveries(ctx context.Context, deliveries []*gtsmodel.PendingDelivery) error
+
+	// UpdatePendingDelivery updates the given journaled delivery.
+	// If no columns are specified, all will be updated.
+	UpdatePendingDelivery(ctx context.Context, delivery *gtsmodel.PendingDelivery, columns ...string) error
+
+	// DeletePendingDeliveryByID deletes the journaled delivery with given ID.
+	DeletePendingDeliveryByID(ctx context.Context, id string) error
+
+	// DeletePendingDeliveriesByIRI deletes all journaled deliveries with
+	// the given ActivityPub ID IRI as their actor, object, or target ID.
+	DeletePendingDeliveriesByIRI(ctx context.Context, iri string) error
+
+	// GetDomainDeliveryStates fetches all stored domain delivery states.
+	GetDomainDeliveryStates(ctx context.Context) ([]*gtsmodel.DomainDeliveryState, error)
+
+	// PutDomainDeliveryState inserts the given domain delivery state.
+	PutDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState) error
+
+	// UpdateDomainDeliveryState updates the given domain delivery state.
+	// If no columns are specified, all will be updated.
+	UpdateDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState, columns ...string) error
+
+	// DeleteDomainDeliveryState deletes the delivery state for given domain.
+	DeleteDomainDeliveryState(ctx context.Context, domain string) error
+}
@@ -42,6 +42,9 @@ type Domain interface {
 	// GetDomainAllows returns all instance-level domain allows currently enforced by this instance.
 	GetDomainAllows(ctx context.Context) ([]*gtsmodel.DomainAllow, error)
 
+	// UpdateDomainAllow updates the given domain allow, setting the provided columns (empty for all).
+	UpdateDomainAllow(ctx context.Context, allow *gtsmodel.DomainAllow, columns ...string) error
+
 	// DeleteDomainAllow deletes an instance-level domain allow with the given domain, if it exists.
 	DeleteDomainAllow(ctx context.Context, domain string) error
 
@@ -57,9 +60,52 @@ type Domain interface {
 	// GetDomainBlocks returns all instance-level domain blocks currently enforced by this instance.
 	GetDomainBlocks(ctx context.Context) ([]*gtsmodel.DomainBlock, error)
 
+	// UpdateDomainBlock updates the given domain block, setting the provided columns (empty for all).
+	UpdateDomainBlock(ctx context.Context, block *gtsmodel.DomainBlock, columns ...string) error
+
 	// DeleteDomainBlock deletes an instance-level domain block with the given domain, if it exists.
 	DeleteDomainBlock(ctx context.Context, domain string) error
 
+	/*
+		Domain permission subscription + draft functions.
+	*/
+
+	// GetDomainPermissionSubscriptionByID gets one DomainPermissionSubscription with the given ID.
+	GetDomainPermissionSubscriptionByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionSubscription, error)
+
+	// GetDomainPermissionSubscriptions returns all DomainPermissionSubscriptions of the
+	// given permission type (or all types, if DomainPermissionUnknown), sorted by
+	// priority descending, so that the highest priority subscription comes first.
+	GetDomainPermissionSubscriptions(ctx context.Context, permType gtsmodel.DomainPermissionType) ([]*gtsmodel.DomainPermissionSubscription, error)
+
+	// PutDomainPermissionSubscription stores one DomainPermissionSubscription.
+	PutDomainPermissionSubscription(ctx context.Context, sub *gtsmodel.DomainPermissionSubscription) error
+
+	// UpdateDomainPermissionSubscription updates the provided columns of one DomainPermissionSubscription (empty for all).
+	UpdateDomainPermissionSubscription(ctx context.Context, sub *gtsmodel.DomainPermissionSubscription, columns ...string) error
+
+	// DeleteDomainPermissionSubscription deletes one DomainPermissionSubscription with the given id.
+	DeleteDomainPermissionSubscription(ctx context.Context, id string) error
+
+	// GetDomainPermissionDraftByID gets one DomainPermissionDraft with the given ID.
+	GetDomainPermissionDraftByID(ctx context.Context, id string) (*gtsmodel.DomainPermissionDraft, error)
+
+	// GetDomainPermissionDraftByDomain gets one DomainPermissionDraft with the given permission type and domain.
+	GetDomainPermissionDraftByDomain(ctx context.Context, permType gtsmodel.DomainPermissionType, domain string) (*gtsmodel.DomainPermissionDraft, error)
+
+	// GetDomainPermissionDrafts returns all DomainPermissionDrafts of the given permission
+	// type (or all types, if DomainPermissionUnknown), optionally filtered by subscription ID.
+	GetDomainPermissionDrafts(ctx context.Context, permType gtsmodel.DomainPermissionType, subscriptionID string) ([]*gtsmodel.DomainPermissionDraft, error)
+
+	// PutDomainPermissionDraft stores one DomainPermissionDraft.
+	PutDomainPermissionDraft(ctx context.Context, draft *gtsmodel.DomainPermissionDraft) error
+
+	// DeleteDomainPermissionDraft deletes one DomainPermissionDraft with the given id.
+	DeleteDomainPermissionDraft(ctx context.Context, id string) error
+
+	// DeleteDomainPermissionDraftsBySubscriptionID deletes all DomainPermissionDrafts created by the given subscription.
+	DeleteDomainPermissionDraftsBySubscriptionID(ctx context.Context, subscriptionID string) error
+
 	/*
 		Block/allow checking functions.
 	*/
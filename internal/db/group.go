@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Group contains functions related to local group actors.
+type Group interface {
+	// NewGroup creates a new local group account in the database with the given
+	// parameters, and adds the creating account as its first moderator. By the
+	// time this function is called, all parameters should have passed validation!
+	NewGroup(ctx context.Context, newGroup gtsmodel.NewGroup) (*gtsmodel.Account, error)
+
+	// GetGroupModerators gets the moderators of the group with
+	// the given ID, oldest first, with moderator accounts populated.
+	GetGroupModerators(ctx context.Context, groupID string) ([]*gtsmodel.GroupModerator, error)
+
+	// GetGroupModeratorsByAccountID gets the moderator entries of the
+	// account with the given ID, oldest first, with groups populated.
+	GetGroupModeratorsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.GroupModerator, error)
+
+	// IsGroupModerator returns whether the account
+	// with the given ID moderates the given group.
+	IsGroupModerator(ctx context.Context, groupID string, accountID string) (bool, error)
+
+	// PutGroupModerator puts the given group moderator in the database.
+	PutGroupModerator(ctx context.Context, moderator *gtsmodel.GroupModerator) error
+
+	// DeleteGroupModerator deletes the moderator entry
+	// of the given account for the given group, if any.
+	DeleteGroupModerator(ctx context.Context, groupID string, accountID string) error
+
+	// DeleteGroupModeratorsByAccountID deletes all moderator
+	// entries either of or for the account with the given ID.
+	DeleteGroupModeratorsByAccountID(ctx context.Context, accountID string) error
+}
@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Import interface {
+	// GetImportByID gets one import with the given ID.
+	GetImportByID(ctx context.Context, id string) (*gtsmodel.Import, error)
+
+	// GetImportsByAccountID gets all imports
+	// belonging to the given account, newest first.
+	GetImportsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.Import, error)
+
+	// PutImport puts the given import in the database.
+	PutImport(ctx context.Context, imp *gtsmodel.Import) error
+
+	// UpdateImport updates the given import in the database.
+	UpdateImport(ctx context.Context, imp *gtsmodel.Import, columns ...string) error
+
+	// DeleteImportsByAccountID deletes all
+	// imports belonging to the given account.
+	DeleteImportsByAccountID(ctx context.Context, accountID string) error
+}
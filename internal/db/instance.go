@@ -55,6 +55,26 @@ type Instance interface {
 	// GetInstancePeers returns a slice of instances that the host instance knows about.
 	GetInstancePeers(ctx context.Context, includeSuspended bool) ([]*gtsmodel.Instance, error)
 
+	// GetInstancesByReputation returns a page of known remote instances, ordered by
+	// effective reputation (taking account of any override), lowest first.
+	GetInstancesByReputation(ctx context.Context, limit int, offset int) ([]*gtsmodel.Instance, error)
+
+	// IncrementInstanceSpamHits atomically increments the
+	// spam hits counter of the instance with the given ID.
+	IncrementInstanceSpamHits(ctx context.Context, id string) error
+
+	// CountInstanceLocalFollows returns the number of follows between
+	// local accounts and accounts on the given domain, in either direction.
+	CountInstanceLocalFollows(ctx context.Context, domain string) (int, error)
+
+	// CountInstanceReports returns the number of reports
+	// filed against accounts on the given domain.
+	CountInstanceReports(ctx context.Context, domain string) (int, error)
+
+	// CountInstanceBlocks returns the number of times the given
+	// domain has been blocked, according to admin action history.
+	CountInstanceBlocks(ctx context.Context, domain string) (int, error)
+
 	// GetInstanceModeratorAddresses returns a slice of email addresses belonging to active
 	// (as in, not suspended) moderators + admins on this instance.
 	GetInstanceModeratorAddresses(ctx context.Context) ([]string, error)
@@ -51,4 +51,8 @@ type Media interface {
 	// GetCachedAttachmentsOlderThan gets limit n remote attachments (including avatars and headers) older than
 	// the given time. These will be returned in order of attachment.created_at descending (i.e. newest to oldest).
 	GetCachedAttachmentsOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, error)
+
+	// GetLocalProcessingAttachments gets all local attachments that have not yet
+	// finished processing, ie., those that are pending a background transcode.
+	GetLocalProcessingAttachments(ctx context.Context) ([]*gtsmodel.MediaAttachment, error)
 }
@@ -0,0 +1,46 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// MediaHashBlock contains functions related to media perceptual hash blocks.
+type MediaHashBlock interface {
+	// GetMediaHashBlockByID gets one media hash block with the given ID.
+	GetMediaHashBlockByID(ctx context.Context, id string) (*gtsmodel.MediaHashBlock, error)
+
+	// GetMediaHashBlockByHash gets one media hash block with exactly the given hash.
+	GetMediaHashBlockByHash(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error)
+
+	// GetMediaHashBlocks gets all media hash blocks.
+	GetMediaHashBlocks(ctx context.Context) ([]*gtsmodel.MediaHashBlock, error)
+
+	// MatchMediaHashBlock returns the first media hash block that the
+	// given perceptual hash is within threshold of, or ErrNoEntries.
+	MatchMediaHashBlock(ctx context.Context, hash string) (*gtsmodel.MediaHashBlock, error)
+
+	// PutMediaHashBlock puts the given media hash block in the database.
+	PutMediaHashBlock(ctx context.Context, block *gtsmodel.MediaHashBlock) error
+
+	// DeleteMediaHashBlockByID deletes the media hash block with the given ID.
+	DeleteMediaHashBlockByID(ctx context.Context, id string) error
+}
@@ -46,6 +46,21 @@ type Report interface {
 	// as a specific column.
 	UpdateReport(ctx context.Context, report *gtsmodel.Report, columns ...string) (*gtsmodel.Report, error)
 
-	// DeleteReportByID deletes report with the given id.
+	// DeleteReportByID deletes report with the given id,
+	// along with any moderator notes left on the report.
 	DeleteReportByID(ctx context.Context, id string) error
+
+	// GetReportNoteByID gets one report note by its db id.
+	GetReportNoteByID(ctx context.Context, id string) (*gtsmodel.ReportNote, error)
+
+	// GetReportNotes gets all notes left on
+	// the report with the given id, oldest first.
+	GetReportNotes(ctx context.Context, reportID string) ([]*gtsmodel.ReportNote, error)
+
+	// PutReportNote puts the given report note in the database.
+	PutReportNote(ctx context.Context, note *gtsmodel.ReportNote) error
+
+	// DeleteReportNoteByID deletes report note with the given id.
+	// Replies to the note are kept, but will no longer be threaded.
+	DeleteReportNoteByID(ctx context.Context, id string) error
 }
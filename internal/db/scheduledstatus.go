@@ -0,0 +1,58 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type ScheduledStatus interface {
+	// GetScheduledStatusByID gets one scheduled status with the given ID.
+	GetScheduledStatusByID(ctx context.Context, id string) (*gtsmodel.ScheduledStatus, error)
+
+	// GetScheduledStatusesForAcct returns scheduled statuses
+	// created by the given account ID, using given paging params.
+	GetScheduledStatusesForAcct(ctx context.Context, acctID string, page *paging.Page) ([]*gtsmodel.ScheduledStatus, error)
+
+	// GetAllScheduledStatuses returns all pending scheduled statuses, for all accounts.
+	GetAllScheduledStatuses(ctx context.Context) ([]*gtsmodel.ScheduledStatus, error)
+
+	// CountScheduledStatusesForAcct returns the number of pending statuses scheduled by
+	// the given account ID. If from and to are non-zero, only statuses scheduled
+	// to be published within that range (inclusive) are counted.
+	CountScheduledStatusesForAcct(ctx context.Context, acctID string, from time.Time, to time.Time) (int, error)
+
+	// PopulateScheduledStatus ensures that all sub-models of a scheduled status are populated.
+	PopulateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// PutScheduledStatus puts the given scheduled status in the database.
+	PutScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus) error
+
+	// UpdateScheduledStatus updates the given scheduled status in the database.
+	UpdateScheduledStatus(ctx context.Context, status *gtsmodel.ScheduledStatus, columns ...string) error
+
+	// DeleteScheduledStatusByID deletes one scheduled status with the given ID.
+	DeleteScheduledStatusByID(ctx context.Context, id string) error
+
+	// DeleteScheduledStatusesByAccountID deletes all scheduled statuses created by the given account ID.
+	DeleteScheduledStatusesByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,18 +19,45 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type Search interface {
-	// SearchForAccounts uses the given query text to search for accounts that accountID follows.
+	// SearchForAccounts uses the given full-text query to search for accounts,
+	// optionally restricted to accounts that accountID follows. Results are ranked
+	// by relevance, and offset can be used to page through them.
 	SearchForAccounts(ctx context.Context, accountID string, query string, maxID string, minID string, limit int, following bool, offset int) ([]*gtsmodel.Account, error)
 
-	// SearchForStatuses uses the given query text to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
-	// If fromAccountID is used, the results are restricted to statuses created by fromAccountID.
-	SearchForStatuses(ctx context.Context, requestingAccountID string, query string, fromAccountID string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
+	// SearchForStatuses uses the given query to search for statuses created by requestingAccountID, or in reply to requestingAccountID.
+	// Results are ranked by relevance, and offset can be used to page through them.
+	SearchForStatuses(ctx context.Context, requestingAccountID string, query *StatusSearchQuery, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Status, error)
 
 	// SearchForTags searches for tags that start with the given query text (case insensitive).
 	SearchForTags(ctx context.Context, query string, maxID string, minID string, limit int, offset int) ([]*gtsmodel.Tag, error)
 }
+
+// StatusSearchQuery contains the full-text
+// query and filters for a status search.
+type StatusSearchQuery struct {
+	// Text to search for. Words are matched exactly,
+	// "quoted words" are matched as a phrase, and
+	// words ending with * are matched as a prefix.
+	Text string
+
+	// If set, only statuses created by this account.
+	FromAccountID string
+
+	// If set, only statuses with media attachments.
+	HasMedia bool
+
+	// If set, only statuses with a poll.
+	HasPoll bool
+
+	// If set, only statuses created before this time.
+	Before time.Time
+
+	// If set, only statuses created at or after this time.
+	After time.Time
+}
@@ -37,6 +37,9 @@ type Status interface {
 	// GetStatusByPollID fetches the status from the database with matching poll_id column.
 	GetStatusByPollID(ctx context.Context, pollID string) (*gtsmodel.Status, error)
 
+	// GetStatusByImportedFromURI fetches the status of given account ID that was imported from given archived object uri.
+	GetStatusByImportedFromURI(ctx context.Context, accountID string, uri string) (*gtsmodel.Status, error)
+
 	// GetStatusBoost fetches the status whose boost_of_id column refers to boostOfID, authored by given account ID.
 	GetStatusBoost(ctx context.Context, boostOfID string, byAccountID string) (*gtsmodel.Status, error)
 
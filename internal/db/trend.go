@@ -0,0 +1,84 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type Trend interface {
+	// GetTrendByID fetches the trend with given ID.
+	GetTrendByID(ctx context.Context, id string) (*gtsmodel.Trend, error)
+
+	// GetTrend fetches the trend of given type with given target.
+	GetTrend(ctx context.Context, trendType gtsmodel.TrendType, target string) (*gtsmodel.Trend, error)
+
+	// GetTrends fetches currently trending (ie., score > 0) trends of given
+	// type, with given review state, or with any state if state is empty.
+	// Trends are returned in descending order of score, with offset
+	// being the number of trends to skip (not a page number).
+	GetTrends(ctx context.Context, trendType gtsmodel.TrendType, state gtsmodel.TrendState, limit int, offset int) ([]*gtsmodel.Trend, error)
+
+	// PutTrend inserts the given trend.
+	PutTrend(ctx context.Context, trend *gtsmodel.Trend) error
+
+	// UpdateTrend updates the given trend.
+	// If no columns are specified, all will be updated.
+	UpdateTrend(ctx context.Context, trend *gtsmodel.Trend, columns ...string) error
+
+	// ClearStaleTrends zeroes the score of all trends of given type
+	// whose score was last computed before given time, ie., those
+	// that were not found to be trending in the latest computation.
+	ClearStaleTrends(ctx context.Context, trendType gtsmodel.TrendType, before time.Time) error
+
+	// GetTagUses fetches a use of each tag in each
+	// public status created at or after given time.
+	GetTagUses(ctx context.Context, since time.Time) ([]*TrendUse, error)
+
+	// GetStatusInteractions fetches a use of each status for
+	// each fave or boost of it made at or after given time.
+	GetStatusInteractions(ctx context.Context, since time.Time) ([]*TrendUse, error)
+
+	// GetLinkUses fetches a use of each link in each
+	// public status created at or after given time.
+	GetLinkUses(ctx context.Context, since time.Time) ([]*TrendUse, error)
+
+	// PutTrendLinks inserts the given links shared in
+	// a status, ignoring any that are already stored.
+	PutTrendLinks(ctx context.Context, links []*gtsmodel.TrendLink) error
+
+	// DeleteTrendLinks deletes all links
+	// shared in the status with given ID.
+	DeleteTrendLinks(ctx context.Context, statusID string) error
+
+	// DeleteTrendLinksBefore deletes all links shared in statuses
+	// created before given time, as they can no longer trend.
+	DeleteTrendLinksBefore(ctx context.Context, before time.Time) error
+}
+
+// TrendUse records a single use of a
+// trend target by an account, eg., a tag
+// used in a public status, or a status fave.
+type TrendUse struct {
+	Target    string    // ID of the used tag or faved / boosted status, or URL of the used link.
+	AccountID string    // ID of the account that used the target.
+	CreatedAt time.Time // Time at which the target was used.
+}
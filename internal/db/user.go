@@ -52,6 +52,14 @@ type User interface {
 	// UpdateUser updates one user by its primary key, updating either only the specified columns, or all of them.
 	UpdateUser(ctx context.Context, user *gtsmodel.User, columns ...string) error
 
+	// IncrementUserTwoFactorFailures atomically increments the count of incorrect
+	// two-factor codes entered for the user with given ID, returning the new count.
+	IncrementUserTwoFactorFailures(ctx context.Context, id string) (int, error)
+
+	// SetUserTwoFactorLastStep atomically sets the last accepted TOTP time step for the
+	// user with given ID, returning false if it's not after the currently stored step.
+	SetUserTwoFactorLastStep(ctx context.Context, id string, step int64) (bool, error)
+
 	// DeleteUserByID deletes one user by its ID.
 	DeleteUserByID(ctx context.Context, userID string) error
 
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// WebPush contains functions related to Web Push notifications.
+type WebPush interface {
+	// GetVAPIDKeyPair retrieves the instance's VAPID key pair.
+	// If there isn't one yet, it generates and stores a new one.
+	GetVAPIDKeyPair(ctx context.Context) (*gtsmodel.VAPIDKeyPair, error)
+
+	// DeleteVAPIDKeyPair deletes the instance's VAPID key pair, if there is one.
+	// Since existing subscriptions are bound to the key pair they were created
+	// with, this also deletes all Web Push subscriptions.
+	DeleteVAPIDKeyPair(ctx context.Context) error
+
+	// GetWebPushSubscriptionByTokenID retrieves the Web Push subscription owned by the given access token ID.
+	GetWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) (*gtsmodel.WebPushSubscription, error)
+
+	// GetWebPushSubscriptionsByAccountID retrieves all Web Push subscriptions owned by the given account ID.
+	GetWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) ([]*gtsmodel.WebPushSubscription, error)
+
+	// PutWebPushSubscription creates the given Web Push subscription.
+	PutWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription) error
+
+	// UpdateWebPushSubscription updates the given Web Push subscription.
+	UpdateWebPushSubscription(ctx context.Context, subscription *gtsmodel.WebPushSubscription, columns ...string) error
+
+	// DeleteWebPushSubscriptionByID deletes the Web Push subscription with the given ID.
+	DeleteWebPushSubscriptionByID(ctx context.Context, id string) error
+
+	// DeleteWebPushSubscriptionByTokenID deletes the Web Push subscription owned by the given access token ID, if any.
+	DeleteWebPushSubscriptionByTokenID(ctx context.Context, tokenID string) error
+
+	// DeleteWebPushSubscriptionsByAccountID deletes all Web Push subscriptions owned by the given account ID.
+	DeleteWebPushSubscriptionsByAccountID(ctx context.Context, accountID string) error
+}
@@ -19,6 +19,8 @@ package federation
 
 import (
 	"context"
+	"crypto"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"encoding/json"
 	"errors"
@@ -31,6 +33,7 @@ import (
 	"codeberg.org/gruf/go-kv"
 	"github.com/superseriousbusiness/activity/streams"
 	typepublickey "github.com/superseriousbusiness/activity/streams/impl/w3idsecurityv1/type_publickey"
+	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -38,6 +41,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
 	"github.com/superseriousbusiness/httpsig"
 )
 
@@ -53,14 +57,20 @@ type PubKeyAuth struct {
 	// for the Actor whose request we're now authenticating.
 	// Will be set only in cases where we had the Owner
 	// of the key stored in the database already.
-	CachedPubKey *rsa.PublicKey
+	//
+	// This will be either an *rsa.PublicKey, or an
+	// ed25519.PublicKey for RFC 9421 signatures.
+	CachedPubKey crypto.PublicKey
 
 	// FetchedPubKey is an up-to-date public key fetched
 	// from the remote instance. Will be set in cases
 	// where EITHER we hadn't seen the Actor before whose
 	// request we're now authenticating, OR a CachedPubKey
 	// was found in our database, but was expired.
-	FetchedPubKey *rsa.PublicKey
+	//
+	// This will be either an *rsa.PublicKey, or an
+	// ed25519.PublicKey for RFC 9421 signatures.
+	FetchedPubKey crypto.PublicKey
 
 	// OwnerURI is the ActivityPub id of the owner of
 	// the public key used to sign the request we're
@@ -106,10 +116,12 @@ type PubKeyAuth struct {
 func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedUsername string) (*PubKeyAuth, gtserror.WithCode) {
 	// Thanks to the signature check middleware,
 	// we should already have an http signature
-	// verifier set on the context. If we don't,
-	// this is an unsigned request.
+	// verifier set on the context, (either for
+	// draft-cavage or RFC 9421 signatures). If
+	// we don't, this is an unsigned request.
 	verifier := gtscontext.HTTPSignatureVerifier(ctx)
-	if verifier == nil {
+	msgVerifier := gtscontext.HTTPMessageSignatureVerifier(ctx)
+	if verifier == nil && msgVerifier == nil {
 		err := gtserror.Newf("%w", errUnsigned)
 		errWithCode := gtserror.NewErrorUnauthorized(err, errUnsigned.Error(), "(verifier)")
 		return nil, errWithCode
@@ -169,13 +181,25 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// Attempt to verify auth with both fetched and cached keys.
-	if !verifyAuth(&l, verifier, pubKeyAuth.CachedPubKey) &&
-		!verifyAuth(&l, verifier, pubKeyAuth.FetchedPubKey) {
+	if msgVerifier != nil {
+		// Attempt to verify RFC 9421 message
+		// signature with both fetched and cached keys.
+		if !verifyMessageAuth(&l, msgVerifier, pubKeyAuth.CachedPubKey) &&
+			!verifyMessageAuth(&l, msgVerifier, pubKeyAuth.FetchedPubKey) {
 
-		const format = "authentication NOT PASSED for public key %s; tried algorithms %+v; signature value was '%s'"
-		text := fmt.Sprintf(format, pubKeyIDStr, signingAlgorithms, signature)
-		return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+			const format = "authentication NOT PASSED for public key %s; tried message signature with algorithm '%s'; signature value was '%s'"
+			text := fmt.Sprintf(format, pubKeyIDStr, msgVerifier.Algorithm(), signature)
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
+	} else {
+		// Attempt to verify auth with both fetched and cached keys.
+		if !verifyAuth(&l, verifier, pubKeyAuth.CachedPubKey) &&
+			!verifyAuth(&l, verifier, pubKeyAuth.FetchedPubKey) {
+
+			const format = "authentication NOT PASSED for public key %s; tried algorithms %+v; signature value was '%s'"
+			text := fmt.Sprintf(format, pubKeyIDStr, signingAlgorithms, signature)
+			return nil, gtserror.NewErrorUnauthorized(errors.New(text), text)
+		}
 	}
 
 	if pubKeyAuth.Owner == nil {
@@ -220,7 +244,7 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		// Catch a possible (but very rare) race condition where
 		// we've fetched a key, then fetched the Actor who owns the
 		// key, but the Key of the Actor has changed in the meantime.
-		if !pubKeyAuth.Owner.PublicKey.Equal(pubKeyAuth.FetchedPubKey) {
+		if !ownsPubKey(pubKeyAuth.Owner, pubKeyAuth.FetchedPubKey) {
 			err := gtserror.Newf(
 				"key mismatch: fetched key %s does not match pubkey of fetched Actor %s",
 				pubKeyID, pubKeyAuth.Owner.URI,
@@ -234,6 +258,24 @@ func (f *Federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		return nil, gtserror.NewErrorForbidden(errors.New(text))
 	}
 
+	if msgVerifier != nil && !isLocal {
+		// The remote signed this request using RFC 9421,
+		// so it must also accept them: remember this for
+		// our own requests to its host, taking precedence
+		// over anything learned through trial and error.
+		scheme := gtsmodel.SignatureSchemeRFC9421
+		if msgVerifier.Algorithm() == messagesig.ED25519 {
+			scheme = gtsmodel.SignatureSchemeRFC9421Ed25519
+		}
+
+		if err := f.transportController.SetSignatureScheme(ctx,
+			pubKeyAuth.OwnerURI.Host,
+			scheme,
+		); err != nil {
+			l.Errorf("error setting signature scheme: %v", err)
+		}
+	}
+
 	return pubKeyAuth, nil
 }
 
@@ -258,6 +300,16 @@ func (f *Federator) derefPubKeyDBOnly(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if owner == nil {
+		// Not an RSA key we know of, look
+		// for it as an ed25519 key instead.
+		owner, err = f.db.GetAccountByEd25519PubkeyID(ctx, pubKeyIDStr)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err = gtserror.Newf("db error getting account with ed25519 pubKeyID %s: %w", pubKeyIDStr, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
 	if owner == nil {
 		// We don't have this
 		// account stored (yet).
@@ -271,8 +323,18 @@ func (f *Federator) derefPubKeyDBOnly(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Select the matching key, taking care
+	// not to set a typed nil interface value.
+	var pubKey crypto.PublicKey
+	switch {
+	case owner.PublicKeyURI == pubKeyIDStr && owner.PublicKey != nil:
+		pubKey = owner.PublicKey
+	case owner.Ed25519PublicKeyURI == pubKeyIDStr && owner.Ed25519PublicKey != nil:
+		pubKey = owner.Ed25519PublicKey
+	}
+
 	return &PubKeyAuth{
-		CachedPubKey: owner.PublicKey,
+		CachedPubKey: pubKey,
 		OwnerURI:     ownerURI,
 		Owner:        owner,
 	}, nil
@@ -375,12 +437,19 @@ func (f *Federator) derefPubKey(
 	// we now successfully refreshed the pub key,
 	// we should update the account to reflect that.
 	owner := pubKeyAuth.Owner
-	owner.PublicKey = pubKeyAuth.FetchedPubKey
+	column := "public_key"
+	switch pubKey := pubKey.(type) {
+	case *rsa.PublicKey:
+		owner.PublicKey = pubKey
+	case ed25519.PublicKey:
+		owner.Ed25519PublicKey = pubKey
+		column = "ed25519_public_key"
+	}
 	owner.PublicKeyExpiresAt = time.Time{}
 	if err := f.db.UpdateAccount(
 		ctx,
 		owner,
-		"public_key",
+		column,
 		"public_key_expires_at",
 	); err != nil {
 		err := gtserror.Newf("db error updating account with refreshed public key (%s): %w", pubKeyIDStr, err)
@@ -491,15 +560,17 @@ func (f *Federator) fetchAccountInstance(
 	return nil
 }
 
-// parsePubKeyBytes extracts an rsa public key from the
-// given pubKeyBytes by trying to parse the pubKeyBytes
-// as an ActivityPub type. It will return the public key
-// itself, and the URI of the public key owner.
+// parsePubKeyBytes extracts a public key from the given
+// pubKeyBytes by trying to parse the pubKeyBytes as an
+// ActivityPub type. It will return the public key itself,
+// and the URI of the public key owner. The key will be an
+// ed25519.PublicKey if pubKeyID refers to an actor's ed25519
+// Multikey, else an *rsa.PublicKey.
 func parsePubKeyBytes(
 	ctx context.Context,
 	pubKeyBytes []byte,
 	pubKeyID *url.URL,
-) (*rsa.PublicKey, *url.URL, error) {
+) (crypto.PublicKey, *url.URL, error) {
 	m := make(map[string]interface{})
 	if err := json.Unmarshal(pubKeyBytes, &m); err != nil {
 		return nil, nil, err
@@ -511,6 +582,12 @@ func parsePubKeyBytes(
 	)
 
 	if t, err := streams.ToType(ctx, m); err == nil {
+		// See if Actor with an ed25519 key attached
+		// with this ID, as used for RFC 9421 signatures.
+		if edKey, ownerURI, ok := extractEd25519Key(t, pubKeyID); ok {
+			return edKey, ownerURI, nil
+		}
+
 		// See if Actor with a PublicKey attached.
 		wpk, ok := t.(ap.WithPublicKey)
 		if !ok {
@@ -546,6 +623,43 @@ func parsePubKeyBytes(
 	return pubKey, ownerURI, nil
 }
 
+// extractEd25519Key returns the ed25519 key with pubKeyID
+// from the given type, along with the URI of its owner, if
+// the type is an actor with such a key in assertionMethod.
+func extractEd25519Key(t vocab.Type, pubKeyID *url.URL) (ed25519.PublicKey, *url.URL, bool) {
+	accountable, ok := t.(ap.Accountable)
+	if !ok {
+		return nil, nil, false
+	}
+
+	ownerURI := ap.GetJSONLDId(accountable)
+	if ownerURI == nil {
+		return nil, nil, false
+	}
+
+	edKey, edKeyID, err := ap.ExtractEd25519Key(accountable, ownerURI)
+	if err != nil || edKeyID.String() != pubKeyID.String() {
+		return nil, nil, false
+	}
+
+	return edKey, ownerURI, true
+}
+
+// ownsPubKey returns whether the given
+// public key belongs to the given account.
+func ownsPubKey(account *gtsmodel.Account, pubKey crypto.PublicKey) bool {
+	switch pubKey := pubKey.(type) {
+	case *rsa.PublicKey:
+		return account.PublicKey != nil &&
+			account.PublicKey.Equal(pubKey)
+	case ed25519.PublicKey:
+		return account.Ed25519PublicKey != nil &&
+			account.Ed25519PublicKey.Equal(pubKey)
+	default:
+		return false
+	}
+}
+
 var signingAlgorithms = []httpsig.Algorithm{
 	httpsig.RSA_SHA256, // Prefer common RSA_SHA256.
 	httpsig.RSA_SHA512, // Fall back to less common RSA_SHA512.
@@ -585,7 +699,7 @@ var signingOptions = []signingOption{
 func verifyAuth(
 	l *log.Entry,
 	verifier httpsig.VerifierWithOptions,
-	pubKey *rsa.PublicKey,
+	pubKey crypto.PublicKey,
 ) bool {
 	if pubKey == nil {
 		return false
@@ -612,3 +726,25 @@ func verifyAuth(
 
 	return false
 }
+
+// verifyMessageAuth verifies auth using generated
+// RFC 9421 http message signature verifier, according
+// to pubkey. The algorithm is determined by the key
+// type, and the signature's alg parameter if given.
+func verifyMessageAuth(
+	l *log.Entry,
+	verifier *messagesig.Verifier,
+	pubKey crypto.PublicKey,
+) bool {
+	if pubKey == nil {
+		return false
+	}
+
+	if err := verifier.Verify(pubKey); err != nil {
+		l.Tracef("authentication NOT PASSED with message signature (%T): %v", pubKey, err)
+		return false
+	}
+
+	l.Tracef("authenticated PASSED with message signature (%T)", pubKey)
+	return true
+}
@@ -517,6 +517,7 @@ func (d *Dereferencer) enrichAccount(
 			tsport,
 			account.Username,
 			account.Domain,
+			account.URI,
 		)
 
 		switch {
@@ -704,6 +705,7 @@ func (d *Dereferencer) enrichAccount(
 			tsport,
 			latestAcc.Username,
 			accHost,
+			id.String(),
 		)
 		if err != nil {
 			// Webfingering account still failed, so we're not certain
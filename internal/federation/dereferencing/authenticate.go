@@ -23,8 +23,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
-// verifyAccountKeysOnUpdate verifies that account's public key hasn't changed on update from
+// verifyAccountKeysOnUpdate verifies that account's public keys haven't changed on update from
 // our existing stored representation, UNLESS the key has been explicitly expired (i.e. key rotation).
+// An ed25519 key may be newly added to an account that didn't have one, but never changed or removed.
 func verifyAccountKeysOnUpdate(existing, latest *gtsmodel.Account, now time.Time, federated bool) bool {
 	if federated {
 		// If this data was federated
@@ -43,7 +44,10 @@ func verifyAccountKeysOnUpdate(existing, latest *gtsmodel.Account, now time.Time
 
 	// Ensure that public keys have not changed.
 	if existing.PublicKey.Equal(latest.PublicKey) &&
-		existing.PublicKeyURI == latest.PublicKeyURI {
+		existing.PublicKeyURI == latest.PublicKeyURI &&
+		(existing.Ed25519PublicKey == nil ||
+			existing.Ed25519PublicKey.Equal(latest.Ed25519PublicKey) &&
+				existing.Ed25519PublicKeyURI == latest.Ed25519PublicKeyURI) {
 		return true
 	}
 
@@ -36,6 +36,11 @@ import (
 // The webfinger response will be parsed, and the subject
 // domain and AP URI will be extracted and returned.
 //
+// If knownURI is set, and the response contains more than
+// one suitable self link (eg., Lemmy returns both a Person
+// and a Group for a user and community of the same name),
+// then the link matching knownURI will be preferred.
+//
 // In case the response cannot be parsed, or the response
 // does not contain a valid subject string or AP URI, an
 // error will be returned instead.
@@ -44,6 +49,7 @@ func (d *Dereferencer) fingerRemoteAccount(
 	transport transport.Transport,
 	username string,
 	host string,
+	knownURI string,
 ) (
 	string, // discovered username
 	string, // discovered account domain
@@ -88,6 +94,10 @@ func (d *Dereferencer) fingerRemoteAccount(
 	//   - Must be self link.
 	//   - Must be AP type.
 	//   - Valid https/http URI.
+	//
+	// Or, if knownURI is set, for the
+	// suitable link that matches it.
+	var firstURI *url.URL
 	for _, link := range resp.Links {
 		if link.Rel != "self" {
 			// Not self link, ignore.
@@ -121,8 +131,20 @@ func (d *Dereferencer) fingerRemoteAccount(
 			continue
 		}
 
-		// All looks good, return happily!
-		return accUsername, accDomain, uri, nil
+		if knownURI == "" || link.Href == knownURI {
+			// All looks good, return happily!
+			return accUsername, accDomain, uri, nil
+		}
+
+		if firstURI == nil {
+			// Keep the first suitable link in
+			// case none match the known URI.
+			firstURI = uri
+		}
+	}
+
+	if firstURI != nil {
+		return accUsername, accDomain, firstURI, nil
 	}
 
 	return "", "", nil, gtserror.Newf("no suitable self, AP-type link found in webfinger response for %s", target)
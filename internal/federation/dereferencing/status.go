@@ -527,6 +527,14 @@ func (d *Dereferencer) enrichStatus(
 		latestStatus.ApprovedByURI = status.ApprovedByURI
 	}
 
+	// Snapshot of the existing status content, taken
+	// before fetching (and updating) media attachments.
+	// Only stored as a historical edit if changed.
+	var edit *gtsmodel.StatusEdit
+	if !isNew {
+		edit = status.Snapshot()
+	}
+
 	// Check if this is a permitted status we should accept.
 	// Function also sets "PendingApproval" bool as necessary.
 	permit, err := d.isPermittedStatus(ctx, requestUser, status, latestStatus)
@@ -571,6 +579,17 @@ func (d *Dereferencer) enrichStatus(
 		return nil, nil, gtserror.Newf("error populating emojis for status %s: %w", uri, err)
 	}
 
+	if !isNew {
+		// Carry-over historical edits.
+		latestStatus.EditIDs = status.EditIDs
+		latestStatus.Edits = status.Edits
+
+		// Store a historical edit if status content changed.
+		if err := d.handleStatusEdit(ctx, status, latestStatus, edit); err != nil {
+			return nil, nil, gtserror.Newf("error handling edit for status %s: %w", uri, err)
+		}
+	}
+
 	if isNew {
 		// This is new, put the status in the database.
 		err := d.state.DB.PutStatus(ctx, latestStatus)
@@ -584,9 +603,127 @@ func (d *Dereferencer) enrichStatus(
 		}
 	}
 
+	if latestStatus.QuoteURI != "" && latestStatus.QuoteID == "" {
+		// Status quotes a status we don't
+		// have stored yet, fetch it async.
+		d.fetchStatusQuoteAsync(ctx,
+			requestUser,
+			latestStatus,
+		)
+	}
+
 	return latestStatus, apubStatus, nil
 }
 
+// fetchStatusQuoteAsync queues dereferencing of the status
+// quoted by the given status, setting the quote ID on the
+// given status on success. This is done asynchronously
+// to avoid recursing through (possibly cyclical) chains
+// of quotes while holding the status dereferencing lock.
+func (d *Dereferencer) fetchStatusQuoteAsync(
+	ctx context.Context,
+	requestUser string,
+	status *gtsmodel.Status,
+) {
+	// Parse the quoted status URI.
+	quoteURI, err := url.Parse(status.QuoteURI)
+	if err != nil {
+		log.Errorf(ctx, "invalid quote uri %q: %v", status.QuoteURI, err)
+		return
+	}
+
+	statusID := status.ID
+	d.state.Workers.Dereference.Queue.Push(func(ctx context.Context) {
+		quote, _, err := d.GetStatusByURI(ctx, requestUser, quoteURI)
+		if err != nil {
+			log.Errorf(ctx, "error dereferencing quote %s: %v", quoteURI, err)
+			return
+		}
+
+		// Refetch the quoting status to get
+		// latest model, it may have changed.
+		status, err := d.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error getting status %s: %v", statusID, err)
+			return
+		}
+
+		if status.QuoteURI != quoteURI.String() {
+			// Quote was changed
+			// in the meantime.
+			return
+		}
+
+		// Set quote, normalizing the URI in case
+		// we were only given the quoted status URL.
+		status.QuoteID = quote.ID
+		status.QuoteURI = quote.URI
+		status.Quote = quote
+		if err := d.state.DB.UpdateStatus(ctx, status,
+			"quote_id",
+			"quote_uri",
+		); err != nil {
+			log.Errorf(ctx, "error updating status %s: %v", statusID, err)
+		}
+	})
+}
+
+// handleStatusEdit checks whether the content of the latest version
+// of a status differs from the existing version, and if so stores the
+// given snapshot of the existing version as a historical status edit.
+func (d *Dereferencer) handleStatusEdit(
+	ctx context.Context,
+	existing *gtsmodel.Status,
+	status *gtsmodel.Status,
+	edit *gtsmodel.StatusEdit,
+) error {
+	if !statusEdited(existing, status) {
+		// Nothing changed, just ensure
+		// last edit time is carried over.
+		if status.EditedAt.IsZero() {
+			status.EditedAt = existing.EditedAt
+		}
+		return nil
+	}
+
+	if existing.Poll != nil && status.Poll != nil &&
+		existing.Poll.ID == status.Poll.ID {
+		// Poll was kept along with its
+		// votes, no need to store counts.
+		edit.PollVotes = nil
+	}
+
+	var err error
+
+	// Generate new ID for edit from the time it was made.
+	edit.ID, err = id.NewULIDFromTime(edit.CreatedAt)
+	if err != nil {
+		log.Errorf(ctx, "invalid edit created at date (falling back to 'now'): %v", err)
+		edit.ID = id.NewULID() // just use "now"
+	}
+
+	// Insert the historical edit into the database.
+	if err := d.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		return gtserror.Newf("error putting edit in database: %w", err)
+	}
+
+	// Add the edit to status edits.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+
+	// If the remote didn't tell us when this edit
+	// happened (or claims an impossible time), we
+	// just mark the status as edited as of now.
+	if !status.EditedAt.After(edit.CreatedAt) {
+		status.EditedAt = time.Now()
+	}
+
+	return nil
+}
+
 func (d *Dereferencer) fetchStatusMentions(
 	ctx context.Context,
 	requestUser string,
@@ -791,10 +928,14 @@ func (d *Dereferencer) fetchStatusPoll(
 		insertStatusPoll = func(ctx context.Context, status *gtsmodel.Status) error {
 			var err error
 
-			// Generate new ID for poll from the status CreatedAt.
-			// TODO: update this to use "edited_at" when we add
-			//       support for edited status revision history.
-			status.Poll.ID, err = id.NewULIDFromTime(status.CreatedAt)
+			// Generate new ID for poll from the status
+			// EditedAt if set, else from the CreatedAt.
+			createdAt := status.EditedAt
+			if createdAt.IsZero() {
+				createdAt = status.CreatedAt
+			}
+
+			status.Poll.ID, err = id.NewULIDFromTime(createdAt)
 			if err != nil {
 				log.Errorf(ctx, "invalid created at date (falling back to 'now'): %v", err)
 				status.Poll.ID = id.NewULID() // just use "now"
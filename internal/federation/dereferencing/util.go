@@ -21,6 +21,7 @@ import (
 	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // getEmojiByShortcodeDomain searches input slice
@@ -70,3 +71,25 @@ func pollUpdated(existing, latest *gtsmodel.Poll) bool {
 func pollJustClosed(existing, latest *gtsmodel.Poll) bool {
 	return existing.ClosedAt.IsZero() && latest.Closed()
 }
+
+// statusEdited returns whether the content of a status has changed
+// in a way that indicates it was edited, i.e. changed content, content
+// warning, sensitivity, language, media attachments or poll options.
+func statusEdited(existing, latest *gtsmodel.Status) bool {
+	if existing.Content != latest.Content ||
+		existing.ContentWarning != latest.ContentWarning ||
+		util.PtrOrZero(existing.Sensitive) != util.PtrOrZero(latest.Sensitive) ||
+		existing.Language != latest.Language ||
+		!slices.Equal(existing.AttachmentIDs, latest.AttachmentIDs) {
+		return true
+	}
+
+	switch {
+	case existing.Poll == nil && latest.Poll == nil:
+		return false
+	case existing.Poll == nil || latest.Poll == nil:
+		return true
+	default:
+		return !slices.Equal(existing.Poll.Options, latest.Poll.Options)
+	}
+}
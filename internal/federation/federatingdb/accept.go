@@ -80,6 +80,27 @@ func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsA
 	// Iterate all provided objects in the activity,
 	// handling the ones we know how to handle.
 	for _, object := range ap.ExtractObjects(accept) {
+		// Check first whether this is a relay
+		// accepting our subscription, as relay
+		// Follows don't target an account.
+		relay, err := f.getRelayForObject(ctx, object)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			if err := f.setRelayState(
+				ctx,
+				relay,
+				gtsmodel.RelayStateAccepted,
+				receivingAcct,
+				requestingAcct,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if asType := object.GetType(); asType != nil {
 			// Check and handle any
 			// vocab.Type objects.
@@ -69,6 +69,19 @@ func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStre
 		)
 	}
 
+	// Relays Announce public posts from other
+	// instances; ingest these posts directly
+	// rather than as boosts by the relay actor.
+	isRelay, err := f.isRelay(ctx, requestingAcct)
+	if err != nil {
+		return err
+	}
+
+	if isRelay {
+		f.ingestRelayed(ctx, announce, receivingAcct, requestingAcct)
+		return nil
+	}
+
 	boost, isNew, err := f.converter.ASAnnounceToStatus(ctx, announce)
 	if err != nil {
 		return gtserror.Newf("error converting announce to boost: %w", err)
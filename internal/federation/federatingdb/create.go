@@ -159,6 +159,19 @@ func (f *federatingDB) activityCreate(
 		return gtserror.Newf("could not convert asType %T to ActivityStreamsCreate", asType)
 	}
 
+	// Mastodon-style relays forward public posts
+	// from other instances as Creates; these would
+	// otherwise be dropped as not relevant to us.
+	isRelay, err := f.isRelay(ctx, requestingAccount)
+	if err != nil {
+		return err
+	}
+
+	if isRelay {
+		f.ingestRelayed(ctx, create, receivingAccount, requestingAccount)
+		return nil
+	}
+
 	var errs gtserror.MultiError
 
 	// Extract objects from create activity.
@@ -360,6 +373,10 @@ func (f *federatingDB) createStatusable(
 			"status %s looked like spam (%v); dropping it",
 			ap.GetJSONLDId(statusable), err,
 		)
+
+		// Count this spam hit against the
+		// requester's instance reputation.
+		f.incrementSpamHits(ctx, requester)
 		return nil
 
 	default:
@@ -531,6 +548,7 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 	}
 
 	report.ID = id.NewULID()
+	report.State = gtsmodel.ReportStateOpen
 
 	if err := f.state.DB.PutReport(ctx, report); err != nil {
 		return fmt.Errorf("activityFlag: database error inserting report: %w", err)
@@ -546,3 +564,20 @@ func (f *federatingDB) activityFlag(ctx context.Context, asType vocab.Type, rece
 
 	return nil
 }
+
+// incrementSpamHits increments the spam hits
+// counter of the instance of the given account.
+func (f *federatingDB) incrementSpamHits(ctx context.Context, account *gtsmodel.Account) {
+	instance, err := f.state.DB.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		account.Domain,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error getting instance %s: %v", account.Domain, err)
+		return
+	}
+
+	if err := f.state.DB.IncrementInstanceSpamHits(ctx, instance.ID); err != nil {
+		log.Errorf(ctx, "error updating instance %s: %v", account.Domain, err)
+	}
+}
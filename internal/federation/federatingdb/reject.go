@@ -25,6 +25,7 @@ import (
 	"codeberg.org/gruf/go-logger/v2/level"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
@@ -50,6 +51,27 @@ func (f *federatingDB) Reject(ctx context.Context, reject vocab.ActivityStreamsR
 
 	for _, obj := range ap.ExtractObjects(reject) {
 
+		// Check first whether this is a relay
+		// rejecting our subscription, as relay
+		// Follows don't target an account.
+		relay, err := f.getRelayForObject(ctx, obj)
+		if err != nil {
+			return err
+		}
+
+		if relay != nil {
+			if err := f.setRelayState(
+				ctx,
+				relay,
+				gtsmodel.RelayStateRejected,
+				receivingAcct,
+				requestingAcct,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
 		if obj.IsIRI() {
 			// we have just the URI of whatever is being rejected, so we need to find out what it is
 			rejectedObjectIRI := obj.GetIRI()
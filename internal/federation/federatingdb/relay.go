@@ -0,0 +1,160 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// getRelayForObject returns the relay subscription whose
+// Follow is the given Accept / Reject object, if any.
+func (f *federatingDB) getRelayForObject(
+	ctx context.Context,
+	object ap.TypeOrIRI,
+) (*gtsmodel.Relay, error) {
+	objIRI := objectIRI(object)
+	if objIRI == nil {
+		return nil, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByFollowURI(ctx, objIRI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return relay, nil
+}
+
+// setRelayState updates the state of our relay subscription
+// in response to an Accept / Reject from the relay.
+func (f *federatingDB) setRelayState(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	state gtsmodel.RelayState,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) error {
+	// Relay Follows are always
+	// sent by the instance account.
+	if !receivingAcct.IsInstance() {
+		const text = "relay Follow was not sent by this inbox account"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	inboxURI, err := url.Parse(relay.InboxURI)
+	if err != nil {
+		err := gtserror.Newf("error parsing relay inbox uri: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Make sure the requester lives
+	// on the relay we subscribed to.
+	if requestingAcct.Domain != inboxURI.Host {
+		const text = "relay Follow target and requesting account were not the same"
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	// Lock on the Follow URI
+	// as we may be updating it.
+	unlock := f.state.FedLocks.Lock(relay.FollowURI)
+	defer unlock()
+
+	relay.State = state
+	relay.ActorURI = requestingAcct.URI
+	if err := f.state.DB.UpdateRelay(ctx,
+		relay,
+		"state",
+		"actor_uri",
+	); err != nil {
+		err := gtserror.Newf("db error updating relay: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// isRelay returns whether the given account
+// is the actor of a relay that has accepted
+// our subscription, and so whose Announces /
+// forwarded Creates we should ingest.
+func (f *federatingDB) isRelay(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (bool, error) {
+	if account.IsLocal() {
+		return false, nil
+	}
+
+	relay, err := f.state.DB.GetRelayByActorURI(ctx, account.URI)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return false, gtserror.Newf("db error getting relay: %w", err)
+	}
+
+	return relay != nil && relay.IsAccepted(), nil
+}
+
+// ingestRelayed queues each object of an Announce
+// or forwarded Create from a relay to be dereferenced
+// from its origin, so that relayed posts land in the
+// public timeline rather than as boosts by the relay.
+func (f *federatingDB) ingestRelayed(
+	ctx context.Context,
+	with ap.WithObject,
+	receivingAcct *gtsmodel.Account,
+	requestingAcct *gtsmodel.Account,
+) {
+	for _, object := range ap.ExtractObjects(with) {
+		objIRI := objectIRI(object)
+		if objIRI == nil {
+			continue
+		}
+
+		// Don't trust the relay's copy, deref by IRI
+		// to fetch the authentic status from its origin.
+		f.state.Workers.Federator.Queue.Push(&messages.FromFediAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			APIRI:          objIRI,
+			Receiving:      receivingAcct,
+			Requesting:     requestingAcct,
+		})
+	}
+}
+
+// objectIRI returns the IRI of the given
+// object, whether provided as IRI or type.
+func objectIRI(object ap.TypeOrIRI) *url.URL {
+	if object.IsIRI() {
+		return object.GetIRI()
+	}
+
+	if t := object.GetType(); t != nil {
+		return ap.GetJSONLDId(t)
+	}
+
+	return nil
+}
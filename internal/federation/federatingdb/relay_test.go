@@ -0,0 +1,173 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type RelayTestSuite struct {
+	FederatingDBTestSuite
+}
+
+// putRelay puts a pending relay subscription
+// to remote_account_1's instance in the db.
+func (suite *RelayTestSuite) putRelay() *gtsmodel.Relay {
+	instanceAccount := suite.testAccounts["instance_account"]
+
+	relay := &gtsmodel.Relay{
+		ID:        "01J7ZK4W5Q8S2Y7V0B9H1C3D4E",
+		InboxURI:  "http://fossbros-anonymous.io/inbox",
+		FollowURI: uris.GenerateURIForFollow(instanceAccount.Username, "01J7ZK4W5Q8S2Y7V0B9H1C3D4E"),
+		Mode:      gtsmodel.RelayModeMastodon,
+		State:     gtsmodel.RelayStatePending,
+	}
+
+	if err := suite.db.PutRelay(context.Background(), relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return relay
+}
+
+// newFollowResponse returns an Accept or Reject
+// from relayAccount of the given relay's Follow.
+func newFollowResponse[T interface {
+	vocab.Type
+	SetActivityStreamsActor(vocab.ActivityStreamsActorProperty)
+	SetActivityStreamsObject(vocab.ActivityStreamsObjectProperty)
+	SetJSONLDId(vocab.JSONLDIdProperty)
+}](activity T, relay *gtsmodel.Relay, relayAccount *gtsmodel.Account) T {
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(testrig.URLMustParse(relayAccount.URI + "/activities/01J7ZM0Z6V4X9Q2W3E4R5T6Y7U"))
+	activity.SetJSONLDId(idProp)
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(relayAccount.URI))
+	activity.SetActivityStreamsActor(actorProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(relay.FollowURI))
+	activity.SetActivityStreamsObject(objectProp)
+
+	return activity
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollow() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	relay := suite.putRelay()
+
+	accept := newFollowResponse(streams.NewActivityStreamsAccept(), relay, relayAccount)
+	if err := suite.federatingDB.Accept(ctx, accept); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Relay should now be accepted,
+	// with the relay actor stored.
+	relay, err := suite.db.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStateAccepted, relay.State)
+	suite.Equal(relayAccount.URI, relay.ActorURI)
+}
+
+func (suite *RelayTestSuite) TestRejectRelayFollow() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	relay := suite.putRelay()
+
+	reject := newFollowResponse(streams.NewActivityStreamsReject(), relay, relayAccount)
+	if err := suite.federatingDB.Reject(ctx, reject); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	relay, err := suite.db.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStateRejected, relay.State)
+}
+
+func (suite *RelayTestSuite) TestAcceptRelayFollowWrongInstance() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	otherAccount := suite.testAccounts["remote_account_2"]
+	ctx := createTestContext(instanceAccount, otherAccount)
+
+	relay := suite.putRelay()
+
+	// An Accept from an account that doesn't
+	// live on the relay should be refused.
+	accept := newFollowResponse(streams.NewActivityStreamsAccept(), relay, otherAccount)
+	suite.Error(suite.federatingDB.Accept(ctx, accept))
+
+	relay, err := suite.db.GetRelayByID(ctx, relay.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.RelayStatePending, relay.State)
+}
+
+func (suite *RelayTestSuite) TestRelayedAnnounce() {
+	instanceAccount := suite.testAccounts["instance_account"]
+	relayAccount := suite.testAccounts["remote_account_1"]
+	ctx := createTestContext(instanceAccount, relayAccount)
+
+	relay := suite.putRelay()
+	relay.State = gtsmodel.RelayStateAccepted
+	relay.ActorURI = relayAccount.URI
+	if err := suite.db.UpdateRelay(ctx, relay); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	announce := suite.testActivities["announce_forwarded_1_zork"]
+	if err := suite.federatingDB.Announce(ctx, announce.Activity.(vocab.ActivityStreamsAnnounce)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Rather than a boost by the relay, the
+	// announced status should be sent to be
+	// dereferenced + stored from its origin.
+	msg, ok := suite.getFederatorMsg(5 * time.Second)
+	if !ok {
+		suite.FailNow("timed out waiting for message")
+	}
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Nil(msg.GTSModel)
+	suite.Equal("http://example.org/users/Some_User/statuses/afaba698-5740-4e32-a702-af61aa543bc1", msg.APIRI.String())
+}
+
+func TestRelayTestSuite(t *testing.T) {
+	suite.Run(t, &RelayTestSuite{})
+}
@@ -241,6 +241,15 @@ func (f *Federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		return ctx, false, nil
 	}
 
+	// Check whether the requesting instance should be slowed
+	// down or quarantined on account of its low reputation.
+	ok, errWithCode := f.checkReputation(ctx, w, pubKeyAuth.Owner)
+	if errWithCode != nil {
+		return ctx, false, errWithCode
+	} else if !ok {
+		return ctx, false, nil
+	}
+
 	// We have everything we need now, set the requesting
 	// and receiving accounts on the context for later use.
 	ctx = gtscontext.SetRequestingAccount(ctx, pubKeyAuth.Owner)
@@ -26,15 +26,21 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	errorsv2 "codeberg.org/gruf/go-errors/v2"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 	"github.com/superseriousbusiness/httpsig"
+	"github.com/uptrace/bun"
 )
 
 type FederatingProtocolTestSuite struct {
@@ -266,6 +272,63 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxMessageSignature() {
+	var (
+		ctx              = context.Background()
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+		requestingAcct   = suite.testAccounts["remote_account_1"]
+	)
+
+	raw, err := ap.Serialize(activity.Activity)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Sign request using RFC 9421 http message
+	// signatures instead of draft-cavage.
+	request := httptest.NewRequest(http.MethodPost, receivingAccount.InboxURI, bytes.NewReader(b))
+	if err := messagesig.Sign(request, b,
+		requestingAcct.PrivateKey,
+		requestingAcct.PublicKeyURI,
+		messagesig.RSA_V1_5_SHA256,
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	verifier, err := messagesig.NewVerifier(request, config.GetProtocol())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	ctx = gtscontext.SetReceivingAccount(ctx, receivingAccount)
+	ctx = gtscontext.SetHTTPMessageSignatureVerifier(ctx, verifier)
+	ctx = gtscontext.SetHTTPSignature(ctx, request.Header.Get(messagesig.SignatureHeader))
+	ctx = gtscontext.SetHTTPSignaturePubKeyID(ctx, testrig.URLMustParse(verifier.KeyID()))
+
+	recorder := httptest.NewRecorder()
+	ctx, authed, err := suite.federator.AuthenticatePostInbox(ctx, recorder, request)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.NotNil(gtscontext.RequestingAccount(ctx))
+	suite.True(authed)
+
+	// The remote instance should now be
+	// known to accept RFC 9421 signatures.
+	instance, err := suite.state.DB.GetInstance(ctx, requestingAcct.Domain)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.SignatureSchemeRFC9421, instance.SignatureScheme)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyExpired() {
 	var (
 		ctx              = context.Background()
@@ -293,6 +356,122 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxKeyExpired()
 	suite.Equal(http.StatusOK, code)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxQuarantined() {
+	var (
+		ctx              = context.Background()
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetInstanceFederationReputation(true)
+
+	// Give requester's instance terrible reputation.
+	instance, err := suite.state.DB.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.ReputationOverride = util.Ptr(int64(-100))
+	if err := suite.state.DB.UpdateInstance(ctx, instance, "reputation_override"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, authed, _, code := suite.authenticatePostInbox(
+		ctx,
+		receivingAccount,
+		activity,
+	)
+
+	// Should be accepted, but not processed.
+	suite.False(authed)
+	suite.Equal(http.StatusAccepted, code)
+}
+
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxSlow() {
+	var (
+		ctx              = context.Background()
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetInstanceFederationReputation(true)
+
+	// Give requester's instance a reputation
+	// below the slow threshold, so it's slowed
+	// to 30 activities per minute.
+	instance, err := suite.state.DB.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	instance.Reputation = 5
+	instance.ReputationComputedAt = time.Now()
+	if err := suite.state.DB.UpdateInstance(ctx, instance,
+		"reputation",
+		"reputation_computed_at",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for i := 0; i < 30; i++ {
+		_, authed, _, _ := suite.authenticatePostInbox(
+			ctx,
+			receivingAccount,
+			activity,
+		)
+		suite.True(authed)
+	}
+
+	_, authed, _, _ := suite.authenticatePostInbox(
+		ctx,
+		receivingAccount,
+		activity,
+	)
+	suite.False(authed)
+}
+
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxNeverSeen() {
+	var (
+		ctx              = context.Background()
+		activity         = suite.testActivities["dm_for_zork"]
+		receivingAccount = suite.testAccounts["local_account_1"]
+	)
+
+	config.SetInstanceFederationReputation(true)
+
+	// Requester's instance has never been seen.
+	if _, err := suite.state.DB.(*bundb.DBService).DB().
+		NewDelete().
+		Table("instances").
+		Where("? = ?", bun.Ident("domain"), "fossbros-anonymous.io").
+		Exec(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.state.Caches.DB.Instance.Invalidate("Domain", "fossbros-anonymous.io")
+
+	// Even with a quarantine threshold above
+	// zero, it shouldn't be quarantined as it
+	// has no reputation to judge it by yet.
+	config.SetInstanceFederationReputationQuarantineThreshold(5)
+
+	// But it should be slowed to
+	// 30 activities per minute.
+	for i := 0; i < 30; i++ {
+		_, authed, _, code := suite.authenticatePostInbox(
+			ctx,
+			receivingAccount,
+			activity,
+		)
+		suite.True(authed)
+		suite.Equal(http.StatusOK, code)
+	}
+
+	_, authed, _, _ := suite.authenticatePostInbox(
+		ctx,
+		receivingAccount,
+		activity,
+	)
+	suite.False(authed)
+}
+
 func (suite *FederatingProtocolTestSuite) TestAuthenticatePostGoneWithTombstone() {
 	var (
 		activity         = suite.testActivities["delete_https://somewhere.mysterious/users/rest_in_piss#main-key"]
@@ -28,6 +28,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/ulule/limiter/v3"
 )
 
 var _ interface {
@@ -43,6 +44,7 @@ type Federator struct {
 	transportController transport.Controller
 	mediaManager        *media.Manager
 	actor               pub.FederatingActor
+	slowLimiter         *limiter.Limiter
 	dereferencing.Dereferencer
 }
 
@@ -64,6 +66,7 @@ func NewFederator(
 		converter:           converter,
 		transportController: transportController,
 		mediaManager:        mediaManager,
+		slowLimiter:         newSlowLimiter(),
 		Dereferencer: dereferencing.NewDereferencer(
 			state,
 			converter,
@@ -0,0 +1,142 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package federation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/ulule/limiter/v3"
+	"github.com/ulule/limiter/v3/drivers/store/memory"
+)
+
+// newSlowLimiter returns a new rate limiter for inbound
+// activities from low-reputation instances, based on
+// the configured slow rate, or nil if this is disabled.
+func newSlowLimiter() *limiter.Limiter {
+	rate := config.GetInstanceFederationReputationSlowRate()
+	if rate <= 0 {
+		return nil
+	}
+
+	return limiter.New(
+		memory.NewStore(),
+		limiter.Rate{
+			Period: time.Minute,
+			Limit:  int64(rate),
+		},
+	)
+}
+
+// checkReputation checks the reputation of the instance of the given
+// requesting account, if reputation-based federation is enabled.
+//
+// If the instance has a reputation below the quarantine threshold,
+// 202 Accepted is written to w, and false is returned, indicating
+// that the activity should be dropped without further processing.
+//
+// If the instance has a reputation below the slow threshold, or has
+// not been scored yet, and it has exceeded the slow rate, a 429 Too
+// Many Requests error is returned.
+func (f *Federator) checkReputation(
+	ctx context.Context,
+	w http.ResponseWriter,
+	requester *gtsmodel.Account,
+) (bool, gtserror.WithCode) {
+	if !config.GetInstanceFederationReputation() ||
+		requester.IsLocal() {
+		// Nothing
+		// to check.
+		return true, nil
+	}
+
+	instance, err := f.db.GetInstance(
+		gtscontext.SetBarebones(ctx),
+		requester.Domain,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		// Don't fail the request over this, just let it through.
+		log.Errorf(ctx, "db error getting instance %s: %v", requester.Domain, err)
+		return true, nil
+	}
+
+	// Instances that aren't known yet, or
+	// haven't been scored yet, have nothing
+	// to judge them by. So don't quarantine
+	// them, but do slow them until they've
+	// built up some reputation.
+	var reputation int64
+	unscored := instance == nil ||
+		(instance.ReputationOverride == nil &&
+			instance.ReputationComputedAt.IsZero())
+
+	if !unscored {
+		reputation = instance.EffectiveReputation()
+
+		if reputation < int64(config.GetInstanceFederationReputationQuarantineThreshold()) {
+			// Log this at info level so admins can
+			// see what's being dropped, and why.
+			log.Infof(ctx,
+				"quarantining inbound activity from %s (reputation %d); dropping it",
+				requester.Domain, reputation,
+			)
+			w.WriteHeader(http.StatusAccepted)
+			return false, nil
+		}
+
+		if reputation >= int64(config.GetInstanceFederationReputationSlowThreshold()) {
+			// Reputation fine.
+			return true, nil
+		}
+	}
+
+	if f.slowLimiter == nil {
+		// Slow rate disabled.
+		return true, nil
+	}
+
+	limit, err := f.slowLimiter.Get(ctx, requester.Domain)
+	if err != nil {
+		// Only an in-memory store, so this
+		// should never happen, but be nice.
+		log.Errorf(ctx, "error getting rate limit for %s: %v", requester.Domain, err)
+		return true, nil
+	}
+
+	if limit.Reached {
+		// Tell the remote instance when to
+		// retry, in seconds, then bail with 429.
+		retryAfter := max(limit.Reset-time.Now().Unix(), 1)
+		w.Header().Set("Retry-After", strconv.FormatInt(retryAfter, 10))
+
+		const text = "too many activities from low or unknown reputation instance"
+		err := gtserror.Newf("%s %s (reputation %d)", text, requester.Domain, reputation)
+		return false, gtserror.NewErrorTooManyRequests(err, text)
+	}
+
+	return true, nil
+}
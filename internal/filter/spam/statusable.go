@@ -60,7 +60,8 @@ type preppedMention struct {
 //
 // The decision is made based on the following heuristics, in order:
 //
-//  1. Receiver follow requester. Return nil.
+//  1. Receiver follow requester, or receiver is a
+//     group and requester is a member. Return nil.
 //  2. Statusable doesn't mention receiver. Return NotRelevant.
 //
 // If instance-federation-spam-filter = false, then return nil now.
@@ -89,6 +90,21 @@ func (f *Filter) StatusableOK(
 		return nil
 	}
 
+	if receiver.IsGroup() {
+		// Group members may address posts to the
+		// group without mentioning it (FEP-1b12),
+		// so check if requester follows the group.
+		member, err := f.state.DB.IsFollowing(ctx, requester.ID, receiver.ID)
+		if err != nil {
+			return gtserror.Newf("db error checking follow status: %w", err)
+		}
+
+		if member {
+			// Member posting to group.
+			return nil
+		}
+	}
+
 	// HEURISTIC 2: Check whether statusable mentions the
 	// receiver. If not, we don't want to process this message.
 	rawMentions, _ := ap.ExtractMentions(statusable)
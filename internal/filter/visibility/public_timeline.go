@@ -91,6 +91,18 @@ func (f *Filter) isStatusPublicTimelineable(ctx context.Context, requester *gtsm
 		return false, nil
 	}
 
+	// Statuses of silenced accounts are only
+	// visible to followers, never timelined.
+	silenced, err := f.isStatusAuthorSilenced(ctx, status)
+	if err != nil {
+		return false, err
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author silenced, not timelining")
+		return false, nil
+	}
+
 	for parent := status; parent.InReplyToURI != ""; {
 		// Fetch next parent to lookup.
 		parentID := parent.InReplyToID
@@ -22,6 +22,7 @@ import (
 	"slices"
 
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -104,6 +105,15 @@ func (f *Filter) isStatusVisible(
 		return false, nil
 	}
 
+	// Check whether statuses of any silenced
+	// status accounts are visible to the requester.
+	silencedVisible, err := f.areSilencedStatusAccountsVisible(ctx, requester, status)
+	if err != nil {
+		return false, gtserror.Newf("error checking status %s silenced account visibility: %w", status.ID, err)
+	} else if !silencedVisible {
+		return false, nil
+	}
+
 	if util.PtrOrValue(status.PendingApproval, false) {
 		// Use a different visibility heuristic
 		// for pending approval statuses.
@@ -280,3 +290,71 @@ func (f *Filter) areStatusAccountsVisible(ctx context.Context, requester *gtsmod
 
 	return true, nil
 }
+
+// areSilencedStatusAccountsVisible checks whether the status author and the status
+// boost-of author (if set), where silenced, allow the status to be visible to requester.
+// Statuses by silenced accounts are only visible to their author, to accounts
+// following their author, and to accounts they mention.
+func (f *Filter) areSilencedStatusAccountsVisible(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	for _, account := range []*gtsmodel.Account{
+		status.Account,
+		status.BoostOfAccount,
+	} {
+		if account == nil || !account.IsSilenced() {
+			// Not silenced (or
+			// not a boost), fine.
+			continue
+		}
+
+		if requester == nil {
+			// Silenced accounts' statuses
+			// are never visible unauthed.
+			log.Trace(ctx, "silenced status author not visible to unauthed requester")
+			return false, nil
+		}
+
+		if requester.ID == account.ID {
+			// Silenced authors can
+			// see their own statuses.
+			continue
+		}
+
+		if account == status.Account && status.MentionsAccount(requester.ID) {
+			// Silenced author mentions the requester,
+			// eg., in a reply, so allow them to see it.
+			continue
+		}
+
+		follows, err := f.state.DB.IsFollowing(ctx,
+			requester.ID,
+			account.ID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error checking follow %s->%s: %w", requester.ID, account.ID, err)
+		}
+
+		if !follows {
+			log.Trace(ctx, "silenced status author not followed by requester")
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// isStatusAuthorSilenced returns whether the author of the given
+// status has been silenced, populating the author if necessary.
+func (f *Filter) isStatusAuthorSilenced(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	if status.Account == nil {
+		account, err := f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			return false, gtserror.Newf("error getting status author %s: %w", status.AccountID, err)
+		}
+		status.Account = account
+	}
+
+	return status.Account.IsSilenced(), nil
+}
@@ -20,6 +20,7 @@ package visibility_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -237,6 +238,55 @@ func (suite *StatusVisibleTestSuite) TestVisibleLocalOnly() {
 	}
 }
 
+func (suite *StatusVisibleTestSuite) TestVisibleSilenced() {
+	ctx := context.Background()
+
+	// Silence the author of a public status.
+	testAccount := new(gtsmodel.Account)
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.SilencedAt = time.Now()
+	if err := suite.db.UpdateAccount(ctx, testAccount, "silenced_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	testStatus, err := suite.db.GetStatusByID(ctx, suite.testStatuses["local_account_1_status_1"].ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, testCase := range []struct {
+		acct    *gtsmodel.Account
+		visible bool
+	}{
+		{
+			acct:    suite.testAccounts["local_account_1"],
+			visible: true, // Own status, always visible.
+		},
+		{
+			acct:    nil,
+			visible: false, // No auth, should not be visible.
+		},
+		{
+			acct:    suite.testAccounts["local_account_2"],
+			visible: true, // Follower, should be visible.
+		},
+		{
+			acct:    suite.testAccounts["remote_account_2"],
+			visible: false, // Not a follower, should not be visible.
+		},
+	} {
+		visible, err := suite.filter.StatusVisible(ctx, testCase.acct, testStatus)
+		suite.NoError(err)
+		suite.Equal(testCase.visible, visible)
+	}
+
+	// Even visible to followers, status
+	// should never be public timelineable.
+	timelineable, err := suite.filter.StatusPublicTimelineable(ctx, suite.testAccounts["local_account_2"], testStatus)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
 func TestStatusVisibleTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusVisibleTestSuite))
 }
@@ -55,6 +55,18 @@ func (f *Filter) StatusTagTimelineable(
 		return false, nil
 	}
 
+	// Statuses of silenced accounts are only
+	// visible to followers, never timelined.
+	silenced, err := f.isStatusAuthorSilenced(ctx, status)
+	if err != nil {
+		return false, err
+	}
+
+	if silenced {
+		log.Trace(ctx, "status author silenced, not timelining")
+		return false, nil
+	}
+
 	// Looks good!
 	return true, nil
 }
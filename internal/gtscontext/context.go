@@ -23,6 +23,7 @@ import (
 	"net/url"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
 	"github.com/superseriousbusiness/httpsig"
 )
 
@@ -44,6 +45,8 @@ const (
 	httpSigPubKeyIDKey
 	dryRunKey
 	httpClientSignFnKey
+	httpMsgSigVerifierKey
+	deliveredFnKey
 )
 
 // DryRun returns whether the "dryrun" context key has been set. This can be
@@ -142,6 +145,22 @@ func SetHTTPClientSignFunc(ctx context.Context, fn func(*http.Request) error) co
 	return context.WithValue(ctx, httpClientSignFnKey, fn)
 }
 
+// DeliveredFunc returns a function to be called by the delivery worker once an
+// outgoing delivery queued with this context has been successfully delivered.
+func DeliveredFunc(ctx context.Context) func(context.Context) {
+	fn, _ := ctx.Value(deliveredFnKey).(func(context.Context))
+	return fn
+}
+
+// SetDeliveredFunc stores the given delivered function and returns the wrapped
+// context. See DeliveredFunc() for further information on the delivered function.
+//
+// Note this function is only held in memory, so it is not called for deliveries
+// that are restored from the delivery journal after a restart.
+func SetDeliveredFunc(ctx context.Context, fn func(context.Context)) context.Context {
+	return context.WithValue(ctx, deliveredFnKey, fn)
+}
+
 // HTTPSignatureVerifier returns an http signature verifier for the current ActivityPub
 // request chain. This verifier can be called to authenticate the current request.
 func HTTPSignatureVerifier(ctx context.Context) httpsig.VerifierWithOptions {
@@ -155,6 +174,19 @@ func SetHTTPSignatureVerifier(ctx context.Context, verifier httpsig.VerifierWith
 	return context.WithValue(ctx, httpSigVerifierKey, verifier)
 }
 
+// HTTPMessageSignatureVerifier returns an RFC 9421 http message signature verifier for
+// the current ActivityPub request chain, if the request was signed using RFC 9421. Else nil.
+func HTTPMessageSignatureVerifier(ctx context.Context) *messagesig.Verifier {
+	verifier, _ := ctx.Value(httpMsgSigVerifierKey).(*messagesig.Verifier)
+	return verifier
+}
+
+// SetHTTPMessageSignatureVerifier stores the given http message signature verifier and returns
+// the wrapped context. See HTTPMessageSignatureVerifier() for further information on the value.
+func SetHTTPMessageSignatureVerifier(ctx context.Context, verifier *messagesig.Verifier) context.Context {
+	return context.WithValue(ctx, httpMsgSigVerifierKey, verifier)
+}
+
 // HTTPSignature returns the http signature string
 // value for the current ActivityPub request chain.
 func HTTPSignature(ctx context.Context) string {
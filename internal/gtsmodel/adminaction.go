@@ -73,6 +73,8 @@ const (
 	AdminActionSuspend
 	AdminActionUnsuspend
 	AdminActionExpireKeys
+	AdminActionSensitize
+	AdminActionUnsensitize
 )
 
 func (t AdminActionType) String() string {
@@ -91,6 +93,10 @@ func (t AdminActionType) String() string {
 		return "unsuspend"
 	case AdminActionExpireKeys:
 		return "expire-keys"
+	case AdminActionSensitize:
+		return "sensitive"
+	case AdminActionUnsensitize:
+		return "unsensitive"
 	default:
 		return "unknown"
 	}
@@ -112,6 +118,10 @@ func NewAdminActionType(in string) AdminActionType {
 		return AdminActionUnsuspend
 	case "expire-keys":
 		return AdminActionExpireKeys
+	case "sensitive":
+		return AdminActionSensitize
+	case "unsensitive":
+		return AdminActionUnsensitize
 	default:
 		return AdminActionUnknown
 	}
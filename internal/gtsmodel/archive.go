@@ -0,0 +1,39 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Archive represents a personal archive of a local account's
+// data, generated in the background at the account owner's
+// request and kept in storage as a zip file for download.
+type Archive struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account this archive belongs to
+	ProcessedAt time.Time `bun:"type:timestamptz,nullzero"`                                   // when the archive finished generating, zero while still in progress
+	FilePath    string    `bun:",nullzero"`                                                   // storage key of the generated zip file
+	FileSize    int       `bun:",nullzero"`                                                   // size of the generated zip file in bytes
+}
+
+// Processed returns true if the archive
+// has finished generating, and can be
+// downloaded from storage at FilePath.
+func (a *Archive) Processed() bool {
+	return !a.ProcessedAt.IsZero()
+}
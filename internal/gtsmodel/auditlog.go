@@ -0,0 +1,79 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// AuditLogTargetType describes the type of
+// entity targeted by an audit logged action.
+type AuditLogTargetType string
+
+const (
+	AuditLogTargetAccount                      AuditLogTargetType = "account"
+	AuditLogTargetDomain                       AuditLogTargetType = "domain"
+	AuditLogTargetDomainBlock                  AuditLogTargetType = "domain_block"
+	AuditLogTargetDomainAllow                  AuditLogTargetType = "domain_allow"
+	AuditLogTargetDomainPermissionDraft        AuditLogTargetType = "domain_permission_draft"
+	AuditLogTargetDomainPermissionSubscription AuditLogTargetType = "domain_permission_subscription"
+	AuditLogTargetEmoji                        AuditLogTargetType = "emoji"
+	AuditLogTargetHeaderFilterAllow            AuditLogTargetType = "header_filter_allow"
+	AuditLogTargetHeaderFilterBlock            AuditLogTargetType = "header_filter_block"
+	AuditLogTargetInstanceReputation           AuditLogTargetType = "instance_reputation"
+	AuditLogTargetMedia                        AuditLogTargetType = "media"
+	AuditLogTargetMediaHashBlock               AuditLogTargetType = "media_hash_block"
+	AuditLogTargetRelay                        AuditLogTargetType = "relay"
+	AuditLogTargetReport                       AuditLogTargetType = "report"
+	AuditLogTargetReportNote                   AuditLogTargetType = "report_note"
+	AuditLogTargetRule                         AuditLogTargetType = "rule"
+	AuditLogTargetTrend                        AuditLogTargetType = "trend"
+)
+
+// Common audit logged actions. Actions taken on
+// accounts use the string value of AdminActionType.
+const (
+	AuditLogActionCreate   = "create"
+	AuditLogActionUpdate   = "update"
+	AuditLogActionDelete   = "delete"
+	AuditLogActionAccept   = "accept"
+	AuditLogActionApprove  = "approve"
+	AuditLogActionReject   = "reject"
+	AuditLogActionResolve  = "resolve"
+	AuditLogActionReopen   = "reopen"
+	AuditLogActionAssign   = "assign"
+	AuditLogActionUnassign = "unassign"
+	AuditLogActionForward  = "forward"
+	AuditLogActionRefetch  = "refetch"
+	AuditLogActionPrune    = "prune"
+)
+
+// AuditLogEntry records one change made by an
+// admin or moderator via the admin API, along
+// with serialized (JSON) snapshots of the target
+// from before and after the change, so that it's
+// possible to see exactly what was changed.
+type AuditLogEntry struct {
+	ID         string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt  time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Creation time of this item.
+	AccountID  string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that performed the action.
+	Account    *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	Action     string             `bun:",nullzero,notnull"`                                           // Action that was performed, eg., "create", "suspend".
+	TargetType AuditLogTargetType `bun:",nullzero,notnull"`                                           // Type of the entity targeted by the action.
+	TargetID   string             `bun:",nullzero"`                                                   // Identifier of the target. May be a ULID, or a domain name (in case of domains).
+	Before     string             `bun:",nullzero"`                                                   // JSON snapshot of the target before the action, if any.
+	After      string             `bun:",nullzero"`                                                   // JSON snapshot of the target after the action, if any.
+}
@@ -0,0 +1,57 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// PendingDelivery represents an outgoing ActivityPub delivery
+// recorded in the (optional) delivery journal. Entries are
+// written when a delivery is queued, updated on each failed
+// attempt, and removed once delivered or dropped, so that
+// pending deliveries can be replayed after a crash.
+type PendingDelivery struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // Id of this item in the database.
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	Domain        string    `bun:",nullzero,notnull"`                                           // Domain of the inbox this delivery is addressed to.
+	ActorID       string    `bun:",nullzero"`                                                   // ActivityPub actor ID IRI (if any) of the delivered activity.
+	ObjectID      string    `bun:",nullzero"`                                                   // ActivityPub object ID IRI (if any) of the delivered activity.
+	TargetID      string    `bun:",nullzero"`                                                   // ActivityPub target ID IRI (if any) of the delivered activity.
+	Data          []byte    `bun:",nullzero,notnull"`                                           // Serialized delivery data, see delivery.Delivery{}.Serialize().
+	Attempts      int       `bun:",notnull,default:0"`                                          // Number of delivery attempts made so far.
+	NextAttemptAt time.Time `bun:"type:timestamptz,nullzero"`                                   // Time at (or after) which delivery should next be attempted.
+	Parked        *bool     `bun:",nullzero,notnull,default:false"`                             // Delivery is parked until its domain's park expires.
+}
+
+// DomainDeliveryState tracks consecutive failed outgoing
+// deliveries to a remote domain, so that deliveries to a
+// host that appears to be dead can be parked for a while,
+// rather than repeatedly attempted.
+type DomainDeliveryState struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // Id of this item in the database.
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	Domain        string    `bun:",nullzero,notnull,unique"`                                    // Domain this delivery state applies to.
+	Failures      int       `bun:",notnull,default:0"`                                          // Number of consecutive failed deliveries to this domain.
+	LastFailureAt time.Time `bun:"type:timestamptz,nullzero"`                                   // Time of the most recent failed delivery.
+	ParkedUntil   time.Time `bun:"type:timestamptz,nullzero"`                                   // Deliveries to this domain are parked until this time, if set.
+}
+
+// Parked returns whether deliveries to this domain are currently parked.
+func (s *DomainDeliveryState) Parked() bool {
+	return !s.ParkedUntil.IsZero() && time.Now().Before(s.ParkedUntil)
+}
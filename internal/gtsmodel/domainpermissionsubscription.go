@@ -0,0 +1,53 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// DomainPermissionSubscription represents a remote list
+// of domain permissions (blocks or allows) that this instance
+// periodically fetches and applies as domain permission entries.
+type DomainPermissionSubscription struct {
+	ID                    string                   `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // Id of this item in the database.
+	CreatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was created.
+	UpdatedAt             time.Time                `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // Time when this item was last updated.
+	Priority              uint8                    `bun:""`                                                            // Priority of this subscription compared to others of the same permission type. 0-255 (higher = higher priority).
+	Title                 string                   `bun:",nullzero"`                                                   // Moderator-set title for this list.
+	PermissionType        DomainPermissionType     `bun:",notnull"`                                                    // Permission type of the subscription.
+	AsDraft               *bool                    `bun:",nullzero,notnull,default:true"`                              // Create domain permission entries resulting from this subscription as drafts.
+	CreatedByAccountID    string                   `bun:"type:CHAR(26),nullzero,notnull"`                              // Account ID of the creator of this subscription.
+	CreatedByAccount      *Account                 `bun:"-"`                                                           // Account corresponding to createdByAccountID.
+	URI                   string                   `bun:",nullzero,notnull,unique"`                                    // URI of the domain permission list.
+	ContentType           DomainPermSubContentType `bun:",nullzero,notnull"`                                           // Content type to expect from the URI.
+	FetchedAt             time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time when fetch of URI was last attempted.
+	SuccessfullyFetchedAt time.Time                `bun:"type:timestamptz,nullzero"`                                   // Time when the domain permission list was last successfully fetched, for efficient If-Modified-Since checks.
+	ETag                  string                   `bun:"etag,nullzero"`                                               // Etag last received from the server (if any) on successful fetch.
+	LastModified          time.Time                `bun:"type:timestamptz,nullzero"`                                   // Last-Modified time last received from the server (if any) on successful fetch.
+	Error                 string                   `bun:",nullzero"`                                                   // If latest fetch attempt errored, this field stores the error message. Cleared on latest successful fetch.
+}
+
+// DomainPermSubContentType is the content type
+// expected from a domain permission subscription.
+type DomainPermSubContentType string
+
+// Content types supported by domain permission subscriptions.
+const (
+	DomainPermSubContentTypeCSV       DomainPermSubContentType = "text/csv"         // Mastodon-style CSV export.
+	DomainPermSubContentTypeJSON      DomainPermSubContentType = "application/json" // JSON array of domain permissions.
+	DomainPermSubContentTypePlaintext DomainPermSubContentType = "text/plain"       // One domain per line.
+)
@@ -0,0 +1,48 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// GroupModerator represents an account that
+// moderates a local group actor, ie., that
+// may approve new members and remove posts
+// from the group. The account that created
+// a group is always one of its moderators.
+type GroupModerator struct {
+	ID        string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                                        // id of this item in the database
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`                     // when was item created
+	GroupID   string    `bun:"type:CHAR(26),unique:group_moderators_group_id_account_id_uniq,nullzero,notnull"` // id of the group account being moderated
+	Group     *Account  `bun:"-"`                                                                               // group account corresponding to GroupID
+	AccountID string    `bun:"type:CHAR(26),unique:group_moderators_group_id_account_id_uniq,nullzero,notnull"` // id of the moderating account
+	Account   *Account  `bun:"-"`                                                                               // account corresponding to AccountID
+}
+
+// NewGroup models parameters for the
+// creation of a new local group actor.
+//
+// This struct is not stored in the database,
+// it's just for passing around parameters.
+type NewGroup struct {
+	Username    string // Username of the new group account (required).
+	CreatorID   string // ID of the local account creating the group, who becomes its first moderator (required).
+	DisplayName string // Display name of the group (optional).
+	Note        string // Already-formatted note / description of the group (optional).
+	NoteRaw     string // Unformatted note as provided by the group creator (optional).
+	Locked      bool   // Require moderator approval of new members (optional).
+}
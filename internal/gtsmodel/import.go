@@ -0,0 +1,43 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Import represents a request by a local account to import
+// data from an uploaded file, which is processed in the
+// background with its progress tracked here for the account.
+type Import struct {
+	ID            string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt     time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID     string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account this import belongs to
+	Type          string    `bun:",nullzero,notnull"`                                           // type of data being imported, eg., "outbox"
+	TotalCount    int       `bun:",notnull,default:0"`                                          // total number of items found in the uploaded file
+	ImportedCount int       `bun:",notnull,default:0"`                                          // number of items imported so far
+	SkippedCount  int       `bun:",notnull,default:0"`                                          // number of items deliberately not imported, eg., private posts
+	FailedCount   int       `bun:",notnull,default:0"`                                          // number of items that could not be imported because of an error
+	Failures      []string  `bun:",array"`                                                      // descriptions of failed items, up to a limit
+	FinishedAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // when the import finished, zero while still in progress
+}
+
+// Finished returns true if all items
+// of the import have been processed.
+func (i *Import) Finished() bool {
+	return !i.FinishedAt.IsZero()
+}
@@ -21,26 +21,50 @@ import "time"
 
 // Instance represents a federated instance, either local or remote.
 type Instance struct {
-	ID                     string       `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time    `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	Domain                 string       `bun:",nullzero,notnull,unique"`                                    // Instance domain eg example.org
-	Title                  string       `bun:""`                                                            // Title of this instance as it would like to be displayed.
-	URI                    string       `bun:",nullzero,notnull,unique"`                                    // base URI of this instance eg https://example.org
-	SuspendedAt            time.Time    `bun:"type:timestamptz,nullzero"`                                   // When was this instance suspended, if at all?
-	DomainBlockID          string       `bun:"type:CHAR(26),nullzero"`                                      // ID of any existing domain block for this instance in the database
-	DomainBlock            *DomainBlock `bun:"rel:belongs-to"`                                              // Domain block corresponding to domainBlockID
-	ShortDescription       string       `bun:""`                                                            // Short description of this instance
-	ShortDescriptionText   string       `bun:""`                                                            // Raw text version of short description (before parsing).
-	Description            string       `bun:""`                                                            // Longer description of this instance.
-	DescriptionText        string       `bun:""`                                                            // Raw text version of long description (before parsing).
-	Terms                  string       `bun:""`                                                            // Terms and conditions of this instance.
-	TermsText              string       `bun:""`                                                            // Raw text version of terms (before parsing).
-	ContactEmail           string       `bun:""`                                                            // Contact email address for this instance
-	ContactAccountUsername string       `bun:",nullzero"`                                                   // Username of the contact account for this instance
-	ContactAccountID       string       `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
-	ContactAccount         *Account     `bun:"rel:belongs-to"`                                              // account corresponding to contactAccountID
-	Reputation             int64        `bun:",notnull,default:0"`                                          // Reputation score of this instance
-	Version                string       `bun:",nullzero"`                                                   // Version of the software used on this instance
-	Rules                  []Rule       `bun:"-"`                                                           // List of instance rules
+	ID                     string          `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time       `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Domain                 string          `bun:",nullzero,notnull,unique"`                                    // Instance domain eg example.org
+	Title                  string          `bun:""`                                                            // Title of this instance as it would like to be displayed.
+	URI                    string          `bun:",nullzero,notnull,unique"`                                    // base URI of this instance eg https://example.org
+	SuspendedAt            time.Time       `bun:"type:timestamptz,nullzero"`                                   // When was this instance suspended, if at all?
+	DomainBlockID          string          `bun:"type:CHAR(26),nullzero"`                                      // ID of any existing domain block for this instance in the database
+	DomainBlock            *DomainBlock    `bun:"rel:belongs-to"`                                              // Domain block corresponding to domainBlockID
+	ShortDescription       string          `bun:""`                                                            // Short description of this instance
+	ShortDescriptionText   string          `bun:""`                                                            // Raw text version of short description (before parsing).
+	Description            string          `bun:""`                                                            // Longer description of this instance.
+	DescriptionText        string          `bun:""`                                                            // Raw text version of long description (before parsing).
+	Terms                  string          `bun:""`                                                            // Terms and conditions of this instance.
+	TermsText              string          `bun:""`                                                            // Raw text version of terms (before parsing).
+	ContactEmail           string          `bun:""`                                                            // Contact email address for this instance
+	ContactAccountUsername string          `bun:",nullzero"`                                                   // Username of the contact account for this instance
+	ContactAccountID       string          `bun:"type:CHAR(26),nullzero"`                                      // Contact account ID in the database for this instance
+	ContactAccount         *Account        `bun:"rel:belongs-to"`                                              // account corresponding to contactAccountID
+	Reputation             int64           `bun:",notnull,default:0"`                                          // Reputation score of this instance
+	ReputationOverride     *int64          `bun:""`                                                            // Reputation score set manually by an admin, if any. Takes precedence over Reputation.
+	ReputationComputedAt   time.Time       `bun:"type:timestamptz,nullzero"`                                   // When was Reputation last computed, if at all?
+	SpamHits               int             `bun:",notnull,default:0"`                                          // Number of messages from this instance caught by the spam filter.
+	SignatureScheme        SignatureScheme `bun:",nullzero"`                                                   // Scheme that this instance accepts for signing http requests, if known.
+	Version                string          `bun:",nullzero"`                                                   // Version of the software used on this instance
+	Rules                  []Rule          `bun:"-"`                                                           // List of instance rules
 }
+
+// EffectiveReputation returns the reputation score of
+// this instance, taking account of any admin override.
+func (i *Instance) EffectiveReputation() int64 {
+	if i.ReputationOverride != nil {
+		return *i.ReputationOverride
+	}
+	return i.Reputation
+}
+
+// SignatureScheme denotes a scheme
+// for signing federated http requests.
+type SignatureScheme string
+
+const (
+	SignatureSchemeUnknown        SignatureScheme = ""                // Not known yet.
+	SignatureSchemeCavage         SignatureScheme = "cavage"          // Draft-cavage http signatures.
+	SignatureSchemeRFC9421        SignatureScheme = "rfc9421"         // RFC 9421 http message signatures, with RSA key.
+	SignatureSchemeRFC9421Ed25519 SignatureScheme = "rfc9421-ed25519" // RFC 9421 http message signatures, with Ed25519 key.
+)
@@ -36,6 +36,7 @@ type MediaAttachment struct {
 	Description       string           `bun:""`                                                            // Description of the attachment (for screenreaders)
 	ScheduledStatusID string           `bun:"type:CHAR(26),nullzero"`                                      // To which scheduled status does this attachment belong
 	Blurhash          string           `bun:",nullzero"`                                                   // What is the generated blurhash of this attachment
+	PerceptualHash    string           `bun:",nullzero"`                                                   // Hex-encoded 64-bit perceptual hash (dHash) of the attachment thumbnail, if generated
 	Processing        ProcessingStatus `bun:",notnull,default:2"`                                          // What is the processing status of this attachment
 	File              File             `bun:",embed:file_,notnull,nullzero"`                               // metadata for the whole file
 	Thumbnail         Thumbnail        `bun:",embed:thumbnail_,notnull,nullzero"`                          // small image thumbnail derived from a larger image, video, or audio file.
@@ -0,0 +1,64 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import (
+	"math/bits"
+	"strconv"
+	"time"
+)
+
+// MediaHashBlock represents a perceptual hash
+// of media blocked by admins of this instance.
+// Media with a perceptual hash within Threshold
+// bits of Hash is refused, whether local or remote.
+type MediaHashBlock struct {
+	ID                 string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Hash               string    `bun:",nullzero,notnull,unique"`                                    // hex-encoded 64-bit perceptual hash to block
+	Threshold          int       `bun:",notnull,default:0"`                                          // max hamming distance from Hash at which media is considered a match
+	Comment            string    `bun:",nullzero"`                                                   // admin comment on why this hash is blocked
+	CreatedByAccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account created this block
+	CreatedByAccount   *Account  `bun:"-"`                                                           // account corresponding to CreatedByAccountID
+}
+
+// Matches returns whether the given hex-encoded
+// perceptual hash is within threshold of this block.
+func (b *MediaHashBlock) Matches(hash string) bool {
+	distance, ok := PerceptualHashDistance(b.Hash, hash)
+	return ok && distance <= b.Threshold
+}
+
+// PerceptualHashDistance returns the hamming distance between
+// the two given hex-encoded 64-bit perceptual hashes, i.e. the
+// number of bits in which they differ. Returns false if either
+// hash could not be parsed.
+func PerceptualHashDistance(hash1, hash2 string) (int, bool) {
+	h1, err := strconv.ParseUint(hash1, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	h2, err := strconv.ParseUint(hash2, 16, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return bits.OnesCount64(h1 ^ h2), true
+}
@@ -0,0 +1,73 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Relay represents a subscription from this
+// instance to an ActivityPub relay, which
+// rebroadcasts public posts between instances.
+type Relay struct {
+	ID        string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	InboxURI  string     `bun:",nullzero,notnull,unique"`                                    // inbox of the relay, to which we deliver Follow + public posts
+	ActorURI  string     `bun:",nullzero"`                                                   // URI of the relay actor; set when the relay Accepts our Follow
+	FollowURI string     `bun:",nullzero,notnull,unique"`                                    // URI of the Follow we sent to the relay
+	Mode      RelayMode  `bun:",nullzero,notnull"`                                           // kind of relay we're subscribed to
+	State     RelayState `bun:",nullzero,notnull"`                                           // state of the subscription
+}
+
+// IsAccepted returns true if the
+// relay has accepted our Follow.
+func (r *Relay) IsAccepted() bool {
+	return r.State == RelayStateAccepted
+}
+
+// RelayMode denotes the kind of relay
+// subscribed to, which determines the
+// object of the Follow we send it.
+type RelayMode string
+
+const (
+	// RelayModeMastodon is a Mastodon-style relay,
+	// subscribed to by Following the Public collection.
+	RelayModeMastodon RelayMode = "mastodon"
+
+	// RelayModeLitePub is a LitePub-style relay,
+	// subscribed to by Following the relay actor.
+	RelayModeLitePub RelayMode = "litepub"
+)
+
+// RelayState denotes the state of
+// our subscription to a relay.
+type RelayState string
+
+const (
+	// RelayStatePending means our Follow has been
+	// sent, but not yet Accepted or Rejected.
+	RelayStatePending RelayState = "pending"
+
+	// RelayStateAccepted means the relay has Accepted our
+	// Follow, and will broadcast public posts between us.
+	RelayStateAccepted RelayState = "accepted"
+
+	// RelayStateRejected means the
+	// relay has Rejected our Follow.
+	RelayStateRejected RelayState = "rejected"
+)
@@ -26,22 +26,70 @@ import "time"
 // or another instance, OR a report that was created remotely (on another instance)
 // about a user on this instance, and received via the federated (s2s) API.
 type Report struct {
-	ID                     string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
-	CreatedAt              time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
-	UpdatedAt              time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
-	URI                    string    `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this report
-	AccountID              string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account created this report
-	Account                *Account  `bun:"-"`                                                           // account corresponding to AccountID
-	TargetAccountID        string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which account is targeted by this report
-	TargetAccount          *Account  `bun:"-"`                                                           // account corresponding to TargetAccountID
-	Comment                string    `bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
-	StatusIDs              []string  `bun:"statuses,array"`                                              // database IDs of any statuses referenced by this report
-	Statuses               []*Status `bun:"-"`                                                           // statuses corresponding to StatusIDs
-	RuleIDs                []string  `bun:"rules,array"`                                                 // database IDs of any rules referenced by this report
-	Rules                  []*Rule   `bun:"-"`                                                           // rules corresponding to RuleIDs
-	Forwarded              *bool     `bun:",nullzero,notnull,default:false"`                             // flag to indicate report should be forwarded to remote instance
-	ActionTaken            string    `bun:",nullzero"`                                                   // string description of what action was taken in response to this report
-	ActionTakenAt          time.Time `bun:"type:timestamptz,nullzero"`                                   // time at which action was taken, if any
-	ActionTakenByAccountID string    `bun:"type:CHAR(26),nullzero"`                                      // database ID of account which took action, if any
-	ActionTakenByAccount   *Account  `bun:"-"`                                                           // account corresponding to ActionTakenByID, if any
+	ID                     string      `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt              time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt              time.Time   `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URI                    string      `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this report
+	AccountID              string      `bun:"type:CHAR(26),nullzero,notnull"`                              // which account created this report
+	Account                *Account    `bun:"-"`                                                           // account corresponding to AccountID
+	TargetAccountID        string      `bun:"type:CHAR(26),nullzero,notnull"`                              // which account is targeted by this report
+	TargetAccount          *Account    `bun:"-"`                                                           // account corresponding to TargetAccountID
+	Comment                string      `bun:",nullzero"`                                                   // comment / explanation for this report, by the reporter
+	StatusIDs              []string    `bun:"statuses,array"`                                              // database IDs of any statuses referenced by this report
+	Statuses               []*Status   `bun:"-"`                                                           // statuses corresponding to StatusIDs
+	RuleIDs                []string    `bun:"rules,array"`                                                 // database IDs of any rules referenced by this report
+	Rules                  []*Rule     `bun:"-"`                                                           // rules corresponding to RuleIDs
+	Forwarded              *bool       `bun:",nullzero,notnull,default:false"`                             // flag to indicate report should be forwarded to remote instance
+	ForwardDomains         []string    `bun:"forward_domains,array"`                                       // additional domains (of reply-parents of reported statuses) the report should be forwarded to
+	ForwardedDomains       []string    `bun:"forwarded_domains,array"`                                     // domains that the report was successfully delivered to, if any
+	ForwardedAt            time.Time   `bun:"type:timestamptz,nullzero"`                                   // time at which the report was last successfully delivered, if ever
+	ActionTaken            string      `bun:",nullzero"`                                                   // string description of what action was taken in response to this report
+	ActionTakenAt          time.Time   `bun:"type:timestamptz,nullzero"`                                   // time at which action was taken, if any
+	ActionTakenByAccountID string      `bun:"type:CHAR(26),nullzero"`                                      // database ID of account which took action, if any
+	ActionTakenByAccount   *Account    `bun:"-"`                                                           // account corresponding to ActionTakenByID, if any
+	State                  ReportState `bun:",nullzero,notnull,default:1"`                                 // where this report is in the moderation workflow
+	AssignedAccountID      string      `bun:"type:CHAR(26),nullzero"`                                      // database ID of moderator account assigned to handle this report, if any
+	AssignedAccount        *Account    `bun:"-"`                                                           // account corresponding to AssignedAccountID, if any
+}
+
+// ReportState describes where a report
+// is in the moderation workflow.
+type ReportState uint8
+
+const (
+	ReportStateUnknown  ReportState = iota
+	ReportStateOpen                 // Not yet picked up by a moderator.
+	ReportStateAssigned             // Assigned to a moderator to handle.
+	ReportStateResolved             // Resolved, with or without action taken.
+	ReportStateReopened             // Reopened after being resolved.
+)
+
+func (s ReportState) String() string {
+	switch s {
+	case ReportStateOpen:
+		return "open"
+	case ReportStateAssigned:
+		return "assigned"
+	case ReportStateResolved:
+		return "resolved"
+	case ReportStateReopened:
+		return "reopened"
+	default:
+		return "unknown"
+	}
+}
+
+func NewReportState(in string) ReportState {
+	switch in {
+	case "open":
+		return ReportStateOpen
+	case "assigned":
+		return ReportStateAssigned
+	case "resolved":
+		return ReportStateResolved
+	case "reopened":
+		return ReportStateReopened
+	default:
+		return ReportStateUnknown
+	}
 }
@@ -0,0 +1,34 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ReportNote is an internal note left on a report
+// by a moderator, visible only to other moderators.
+// Notes may reply to other notes on the same report,
+// so that discussion of a report can be threaded.
+type ReportNote struct {
+	ID          string    `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt   time.Time `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	ReportID    string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which report this note is about
+	AccountID   string    `bun:"type:CHAR(26),nullzero,notnull"`                              // which moderator account wrote this note
+	Account     *Account  `bun:"-"`                                                           // account corresponding to AccountID
+	InReplyToID string    `bun:"type:CHAR(26),nullzero"`                                      // id of the note on the same report that this note replies to, if any
+	Content     string    `bun:",nullzero,notnull"`                                           // plaintext content of the note
+}
@@ -0,0 +1,68 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// ScheduledStatus represents a status that has been created by
+// a local account, but which is to be published at a later time.
+// It stores the (already validated) parameters of the status
+// create request, so that the status can be created from them
+// once the scheduled time comes around.
+type ScheduledStatus struct {
+	ID               string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // ID of this item in the database.
+	CreatedAt        time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was item created.
+	UpdatedAt        time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // When was item last updated.
+	AccountID        string             `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that scheduled the status.
+	Account          *Account           `bun:"-"`                                                           // Account corresponding to AccountID.
+	ScheduledAt      time.Time          `bun:"type:timestamptz,nullzero,notnull"`                           // Time at which the status should be published.
+	Text             string             `bun:""`                                                            // Text content of the status to publish.
+	SpoilerText      string             `bun:",nullzero"`                                                   // Content warning of the status to publish.
+	Sensitive        *bool              `bun:",nullzero,notnull,default:false"`                             // Should the status and attached media be marked as sensitive.
+	Visibility       Visibility         `bun:",nullzero,notnull"`                                           // Visibility of the status to publish.
+	LocalOnly        *bool              `bun:",nullzero,notnull,default:false"`                             // Should the status be local only (ie., not federated).
+	Language         string             `bun:",nullzero"`                                                   // Language tag of the status to publish.
+	ContentType      string             `bun:",nullzero"`                                                   // Content type to use when parsing the status text.
+	InReplyToID      string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the status being replied to, if any.
+	QuoteID          string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the status being quoted, if any.
+	MediaIDs         []string           `bun:"attachments,array"`                                           // IDs of media attachments to attach to the status.
+	MediaAttachments []*MediaAttachment `bun:"-"`                                                           // Media attachments corresponding to MediaIDs.
+	PollOptions      []string           `bun:",array"`                                                      // Poll options, only set if the status has a poll.
+	PollExpiresIn    int                `bun:",nullzero"`                                                   // Duration in seconds the poll should be open, from time of publishing.
+	PollMultiple     *bool              `bun:",nullzero,notnull,default:false"`                             // Is the poll multiple choice.
+	PollHideTotals   *bool              `bun:",nullzero,notnull,default:false"`                             // Should the poll hide vote counts until it ends.
+	ApplicationID    string             `bun:"type:CHAR(26),nullzero"`                                      // ID of the application used to schedule the status.
+	Application      *Application       `bun:"-"`                                                           // Application corresponding to ApplicationID.
+	PublishAttempts  int                `bun:",notnull,default:0"`                                          // Number of failed attempts to publish the status.
+	FailedAt         time.Time          `bun:"type:timestamptz,nullzero"`                                   // When did publishing the status fail for good, if it did.
+}
+
+// AttachmentsPopulated returns whether media attachments
+// are populated according to current MediaIDs.
+func (s *ScheduledStatus) AttachmentsPopulated() bool {
+	if len(s.MediaIDs) != len(s.MediaAttachments) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.MediaIDs {
+		if s.MediaAttachments[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
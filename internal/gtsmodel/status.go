@@ -20,6 +20,8 @@ package gtsmodel
 import (
 	"slices"
 	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Status represents a user-created 'post' or 'status' in the database, either remote or local
@@ -27,11 +29,13 @@ type Status struct {
 	ID                       string             `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
 	CreatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
 	UpdatedAt                time.Time          `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	EditedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // when this status was last edited (if set)
 	FetchedAt                time.Time          `bun:"type:timestamptz,nullzero"`                                   // when was item (remote) last fetched.
 	PinnedAt                 time.Time          `bun:"type:timestamptz,nullzero"`                                   // Status was pinned by owning account at this time.
 	URI                      string             `bun:",unique,nullzero,notnull"`                                    // activitypub URI of this status
 	URL                      string             `bun:",nullzero"`                                                   // web url for viewing this status
 	Content                  string             `bun:""`                                                            // content of this status; likely html-formatted but not guaranteed
+	ContentPlain             string             `bun:",nullzero"`                                                   // content of this status with html removed, for full-text search; set by the database on write
 	AttachmentIDs            []string           `bun:"attachments,array"`                                           // Database IDs of any media attachments associated with this status
 	Attachments              []*MediaAttachment `bun:"attached_media,rel:has-many"`                                 // Attachments corresponding to attachmentIDs
 	TagIDs                   []string           `bun:"tags,array"`                                                  // Database IDs of any tags used in this status
@@ -40,7 +44,11 @@ type Status struct {
 	Mentions                 []*Mention         `bun:"attached_mentions,rel:has-many"`                              // Mentions corresponding to mentionIDs
 	EmojiIDs                 []string           `bun:"emojis,array"`                                                // Database IDs of any emojis used in this status
 	Emojis                   []*Emoji           `bun:"attached_emojis,m2m:status_to_emojis"`                        // Emojis corresponding to emojiIDs. https://bun.uptrace.dev/guide/relations.html#many-to-many-relation
+	EditIDs                  []string           `bun:"edits,array"`                                                 // Database IDs of previous versions of this status, oldest first
+	Edits                    []*StatusEdit      `bun:"-"`                                                           // Edits corresponding to EditIDs
 	Local                    *bool              `bun:",nullzero,notnull,default:false"`                             // is this status from a local account?
+	Imported                 *bool              `bun:",nullzero,notnull,default:false"`                             // was this status imported from an archive, rather than posted on this instance?
+	ImportedFromURI          string             `bun:",nullzero"`                                                   // if imported, uri of the archived object this status was imported from
 	AccountID                string             `bun:"type:CHAR(26),nullzero,notnull"`                              // which account posted this status?
 	Account                  *Account           `bun:"rel:belongs-to"`                                              // account corresponding to accountID
 	AccountURI               string             `bun:",nullzero,notnull"`                                           // activitypub uri of the owner of this status
@@ -54,6 +62,9 @@ type Status struct {
 	BoostOfAccountID         string             `bun:"type:CHAR(26),nullzero"`                                      // id of the account that owns the boosted status
 	BoostOf                  *Status            `bun:"-"`                                                           // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `bun:"rel:belongs-to"`                                              // account that corresponds to boostOfAccountID
+	QuoteID                  string             `bun:"type:CHAR(26),nullzero"`                                      // id of the status this status quotes
+	QuoteURI                 string             `bun:",nullzero"`                                                   // activitypub uri of the status this status quotes
+	Quote                    *Status            `bun:"-"`                                                           // status corresponding to quoteID
 	ThreadID                 string             `bun:"type:CHAR(26),nullzero"`                                      // id of the thread to which this status belongs; only set for remote statuses if a local account is involved at some point in the thread, otherwise null
 	PollID                   string             `bun:"type:CHAR(26),nullzero"`                                      //
 	Poll                     *Poll              `bun:"-"`                                                           //
@@ -148,6 +159,20 @@ func (s *Status) EmojisPopulated() bool {
 	return true
 }
 
+// EditsPopulated returns whether edits are populated according to current EditIDs.
+func (s *Status) EditsPopulated() bool {
+	if len(s.EditIDs) != len(s.Edits) {
+		// this is the quickest indicator.
+		return false
+	}
+	for i, id := range s.EditIDs {
+		if s.Edits[i].ID != id {
+			return false
+		}
+	}
+	return true
+}
+
 // EmojissUpToDate returns whether status emoji attachments of receiving status are up-to-date
 // according to emoji attachments of the passed status, by comparing their emoji URIs. We don't
 // use IDs as this is used to determine whether there are new emojis to fetch.
@@ -212,12 +237,59 @@ func (s *Status) IsLocal() bool {
 	return s.Local != nil && *s.Local
 }
 
+// IsImported returns true if this status was
+// imported from an archive, rather than posted.
+func (s *Status) IsImported() bool {
+	return s.Imported != nil && *s.Imported
+}
+
 // IsLocalOnly returns true if this status
 // is "local-only" ie., unfederated.
 func (s *Status) IsLocalOnly() bool {
 	return s.Federated == nil || !*s.Federated
 }
 
+// Snapshot returns a historical status edit model (without ID)
+// containing the current content of the status, including poll
+// vote counts. It should be taken before the status is edited.
+func (s *Status) Snapshot() *StatusEdit {
+	// Previous version was either
+	// created at last edit, or at
+	// creation time of the status.
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	edit := &StatusEdit{
+		Content:        s.Content,
+		ContentWarning: s.ContentWarning,
+		Text:           s.Text,
+		Language:       s.Language,
+		Sensitive:      util.Ptr(util.PtrOrZero(s.Sensitive)),
+		AttachmentIDs:  slices.Clone(s.AttachmentIDs),
+		Attachments:    slices.Clone(s.Attachments),
+		StatusID:       s.ID,
+		CreatedAt:      createdAt,
+	}
+
+	// Store media descriptions as they
+	// are now, as they may be changed.
+	if len(s.Attachments) > 0 {
+		edit.AttachmentDescriptions = make([]string, len(s.Attachments))
+		for i, attachment := range s.Attachments {
+			edit.AttachmentDescriptions[i] = attachment.Description
+		}
+	}
+
+	if s.Poll != nil {
+		edit.PollOptions = slices.Clone(s.Poll.Options)
+		edit.PollVotes = slices.Clone(s.Poll.Votes)
+	}
+
+	return edit
+}
+
 // StatusToTag is an intermediate struct to facilitate the many2many relationship between a status and one or more tags.
 type StatusToTag struct {
 	StatusID string  `bun:"type:CHAR(26),unique:statustag,nullzero,notnull"`
@@ -39,4 +39,5 @@ type Token struct {
 	Refresh             string    `bun:",pk,nullzero,notnull,default:''"`                             // Refresh token, if present
 	RefreshCreateAt     time.Time `bun:"type:timestamptz,nullzero"`                                   // Refresh created at, if refresh present
 	RefreshExpiresAt    time.Time `bun:"type:timestamptz,nullzero"`                                   // Refresh expires at -- null means the refresh token never expires
+	LastUsed            time.Time `bun:"type:timestamptz,nullzero"`                                   // Approximate time this token was last used to authenticate a request, if ever
 }
@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// Trend represents a tag, status, or link that has been found
+// to be trending on this instance, along with its trend score
+// and its review state. Trends are only shown to users once
+// they have been approved by an admin.
+type Trend struct {
+	ID                  string     `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created (ie., when did target first trend)
+	UpdatedAt           time.Time  `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Type                TrendType  `bun:",nullzero,notnull,unique:trends_type_target_uniq"`            // kind of thing that is trending
+	Target              string     `bun:",nullzero,notnull,unique:trends_type_target_uniq"`            // ID of the trending tag or status, or URL of the trending link
+	Score               float64    `bun:",notnull,default:0"`                                          // trend score as of last computation, 0 if no longer trending
+	Uses                []int      `bun:",nullzero"`                                                   // daily uses over the past week, newest first (tags + links only)
+	Accounts            []int      `bun:",nullzero"`                                                   // daily distinct accounts over the past week, newest first (tags + links only)
+	ComputedAt          time.Time  `bun:"type:timestamptz,nullzero"`                                   // when were score + usage last computed
+	State               TrendState `bun:",nullzero,notnull,default:'pending'"`                         // review state of this trend
+	ReviewedByAccountID string     `bun:"type:CHAR(26),nullzero"`                                      // id of the admin account that last reviewed this trend, if any
+	ReviewedAt          time.Time  `bun:"type:timestamptz,nullzero"`                                   // when was this trend last reviewed, if ever
+}
+
+// IsApproved returns true if the
+// trend was approved by an admin.
+func (t *Trend) IsApproved() bool {
+	return t.State == TrendStateApproved
+}
+
+// TrendType denotes the kind
+// of thing that is trending.
+type TrendType string
+
+const (
+	// TrendTypeTag means a hashtag is
+	// trending, with Target being its ID.
+	TrendTypeTag TrendType = "tag"
+
+	// TrendTypeStatus means a status is
+	// trending, with Target being its ID.
+	TrendTypeStatus TrendType = "status"
+
+	// TrendTypeLink means a link shared in
+	// statuses is trending, with Target being its URL.
+	TrendTypeLink TrendType = "link"
+)
+
+// TrendState denotes the
+// review state of a trend.
+type TrendState string
+
+const (
+	// TrendStatePending means the trend has
+	// not yet been reviewed, so isn't shown.
+	TrendStatePending TrendState = "pending"
+
+	// TrendStateApproved means the trend was
+	// approved by an admin, so may be shown.
+	TrendStateApproved TrendState = "approved"
+
+	// TrendStateRejected means the trend was rejected
+	// by an admin, so will never be shown.
+	TrendStateRejected TrendState = "rejected"
+)
+
+// TrendLink is a trendable link shared in a status. Links are
+// extracted from status content when statuses are created or
+// edited, so that link trends can be computed without having
+// to reparse the content of every recent status each time.
+type TrendLink struct {
+	StatusID  string    `bun:"type:CHAR(26),nullzero,notnull,unique:trend_links_status_id_url_uniq"` // id of the status the link was shared in
+	URL       string    `bun:",nullzero,notnull,unique:trend_links_status_id_url_uniq"`              // normalized URL of the link
+	AccountID string    `bun:"type:CHAR(26),nullzero,notnull"`                                       // id of the account that shared the link
+	CreatedAt time.Time `bun:"type:timestamptz,nullzero,notnull"`                                    // when was the status created
+}
@@ -56,6 +56,18 @@ type User struct {
 	ResetPasswordToken     string       `bun:",nullzero"`                                                   // The generated token that the user can use to reset their password
 	ResetPasswordSentAt    time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did we email the user their reset-password email?
 	ExternalID             string       `bun:",nullzero,unique"`                                            // If the login for the user is managed externally (e.g OIDC), we need to keep a stable reference to the external object (e.g OIDC sub claim)
+	TwoFactorSecret        string       `bun:",nullzero"`                                                   // Base32-encoded TOTP secret for this user. Set during enrolment, before two-factor auth is enabled.
+	TwoFactorBackups       []string     `bun:",nullzero,array"`                                             // Bcrypt hashes of single-use recovery codes for when the user loses their authenticator.
+	TwoFactorEnabledAt     time.Time    `bun:"type:timestamptz,nullzero"`                                   // When did the user confirm enrolment and enable two-factor auth? Zero if not enabled.
+	TwoFactorLastStep      int64        `bun:",nullzero"`                                                   // TOTP time step of the last accepted code, so that codes can't be replayed.
+	TwoFactorFailures      int          `bun:",nullzero"`                                                   // Number of incorrect two-factor codes entered since the last correct one or lockout.
+	TwoFactorLockedUntil   time.Time    `bun:"type:timestamptz,nullzero"`                                   // Until when two-factor sign-in is locked after too many incorrect codes.
+}
+
+// TwoFactorEnabled returns whether this user
+// must provide a TOTP code when signing in.
+func (u *User) TwoFactorEnabled() bool {
+	return !u.TwoFactorEnabledAt.IsZero()
 }
 
 // DeniedUser represents one user sign-up that
@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package gtsmodel
+
+import "time"
+
+// WebPushSubscription represents an access token's
+// Web Push subscription. There can be at most one
+// subscription per access token.
+type WebPushSubscription struct {
+	ID                string                               `bun:"type:CHAR(26),pk,nullzero,notnull,unique"`                    // id of this item in the database
+	CreatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt         time.Time                            `bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID         string                               `bun:"type:CHAR(26),nullzero,notnull"`                              // ID of the account that owns this subscription.
+	TokenID           string                               `bun:"type:CHAR(26),nullzero,notnull,unique"`                       // ID of the access token that owns this subscription.
+	Endpoint          string                               `bun:",nullzero,notnull"`                                           // URL of the push endpoint (push service) that notifications should be POSTed to.
+	Auth              string                               `bun:",nullzero,notnull"`                                           // Base64url-encoded auth secret provided by the user agent.
+	P256dh            string                               `bun:",nullzero,notnull"`                                           // Base64url-encoded P-256 ECDH public key provided by the user agent.
+	NotificationFlags WebPushSubscriptionNotificationFlags `bun:",notnull"`                                                    // Which notification types this subscription receives.
+	Policy            WebPushNotificationPolicy            `bun:",nullzero,notnull,default:'all'"`                             // Which accounts this subscription receives notifications from.
+}
+
+// WebPushSubscriptionNotificationFlags is a bitfield
+// representation of a set of NotificationTypes.
+type WebPushSubscriptionNotificationFlags int64
+
+// webPushNotificationTypes orders notification types into bit
+// positions in WebPushSubscriptionNotificationFlags. Since the
+// flags are stored in the database, new types must ONLY ever
+// be appended to this slice, never inserted or reordered.
+var webPushNotificationTypes = []NotificationType{
+	NotificationFollow,
+	NotificationFollowRequest,
+	NotificationMention,
+	NotificationReblog,
+	NotificationFave,
+	NotificationPoll,
+	NotificationStatus,
+	NotificationSignup,
+	NotificationPendingFave,
+	NotificationPendingReply,
+	NotificationPendingReblog,
+}
+
+// Get returns whether the given
+// notification type is set in flags.
+func (n WebPushSubscriptionNotificationFlags) Get(notificationType NotificationType) bool {
+	for i, t := range webPushNotificationTypes {
+		if t == notificationType {
+			return n&(1<<i) != 0
+		}
+	}
+	return false
+}
+
+// Set sets or unsets the given
+// notification type in flags.
+func (n *WebPushSubscriptionNotificationFlags) Set(notificationType NotificationType, value bool) {
+	for i, t := range webPushNotificationTypes {
+		if t != notificationType {
+			continue
+		}
+		if value {
+			*n |= (1 << i)
+		} else {
+			*n &^= (1 << i)
+		}
+		return
+	}
+}
+
+// WebPushNotificationPolicy represents which
+// accounts a Web Push subscription should
+// receive notifications from.
+type WebPushNotificationPolicy string
+
+const (
+	// WebPushNotificationPolicyAll receives notifications from anyone.
+	WebPushNotificationPolicyAll WebPushNotificationPolicy = "all"
+	// WebPushNotificationPolicyFollowed receives notifications
+	// only from accounts the subscribing account follows.
+	WebPushNotificationPolicyFollowed WebPushNotificationPolicy = "followed"
+	// WebPushNotificationPolicyFollower receives notifications
+	// only from accounts that follow the subscribing account.
+	WebPushNotificationPolicyFollower WebPushNotificationPolicy = "follower"
+	// WebPushNotificationPolicyNone receives no notifications.
+	WebPushNotificationPolicyNone WebPushNotificationPolicy = "none"
+)
+
+// ParseWebPushNotificationPolicy parses the given string
+// as a WebPushNotificationPolicy, returning false if the
+// given string is not a recognized policy.
+func ParseWebPushNotificationPolicy(str string) (WebPushNotificationPolicy, bool) {
+	switch p := WebPushNotificationPolicy(str); p {
+	case WebPushNotificationPolicyAll,
+		WebPushNotificationPolicyFollowed,
+		WebPushNotificationPolicyFollower,
+		WebPushNotificationPolicyNone:
+		return p, true
+	default:
+		return "", false
+	}
+}
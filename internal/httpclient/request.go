@@ -73,3 +73,15 @@ func (r *Request) BackOff() time.Duration {
 	}
 	return r.backoff
 }
+
+// Attempts returns the number of
+// attempts made at this request.
+func (r *Request) Attempts() uint {
+	return r.attempts
+}
+
+// SetAttempts sets the number of attempts made at this
+// request, eg., when restoring a previously queued request.
+func (r *Request) SetAttempts(n uint) {
+	r.attempts = n
+}
@@ -42,7 +42,9 @@ func (t *signingtransport) RoundTrip(r *http.Request) (*http.Response, error) {
 		now := time.Now().UTC()
 		r.Header.Set("Date", now.Format("Mon, 02 Jan 2006 15:04:05")+" GMT")
 		r.Header.Del("Signature")
+		r.Header.Del("Signature-Input")
 		r.Header.Del("Digest")
+		r.Header.Del("Content-Digest")
 
 		// Sign the outgoing request.
 		if err := sign(r); err != nil {
@@ -157,6 +157,150 @@ func ffmpegGenerateStatic(ctx context.Context, filepath string) (string, error)
 	return outpath, nil
 }
 
+// ffmpegTranscodeVideo re-encodes input video as H.264 in an mp4 container, scaled down
+// to fit within maxDimension, and with any audio re-encoded using given audio codec. Bitrates
+// are in kbps. If noAudio is set, any audio streams in the input are dropped (e.g. for gifv).
+func ffmpegTranscodeVideo(
+	ctx context.Context,
+	outpath, inpath string,
+	maxDimension int,
+	videoBitrate int,
+	audioBitrate int,
+	audioCodec string,
+	noAudio bool,
+) error {
+	// Get directory from filepath.
+	dirpath := path.Dir(inpath)
+
+	// Max dimension as string.
+	maxDimen := strconv.Itoa(maxDimension)
+
+	args := []string{
+		// Only log errors.
+		"-loglevel", "error",
+
+		// Input file path.
+		"-i", inpath,
+
+		// Only first video stream,
+		// and first audio if any.
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+
+		// Drop all metadata.
+		"-map_metadata", "-1",
+
+		// Encode using libx264, with a
+		// fast preset and constant quality,
+		// capped at the max video bitrate.
+		// (libx264 codec: https://trac.ffmpeg.org/wiki/Encode/H.264)
+		"-codec:v", "libx264",
+		"-preset", "veryfast",
+		"-crf", "23",
+		"-maxrate", strconv.Itoa(videoBitrate) + "k",
+		"-bufsize", strconv.Itoa(2*videoBitrate) + "k",
+
+		// Scale down (never up) to fit within max dimensions,
+		// keeping aspect ratio and even dimensions for yuv420p.
+		// (scale filter: https://ffmpeg.org/ffmpeg-filters.html#scale)
+		"-filter:v", "scale='min(iw," + maxDimen + ")':'min(ih," + maxDimen + ")'" +
+			":force_original_aspect_ratio=decrease" +
+			":force_divisible_by=2",
+
+		// Use the most widely
+		// supported pixel format.
+		"-pix_fmt", "yuv420p",
+	}
+
+	if noAudio {
+		// Drop audio.
+		args = append(args, "-an")
+	} else {
+		// Encode audio with codec at bitrate.
+		args = append(args, audioCodecArgs(
+			audioCodec,
+			audioBitrate,
+		)...)
+	}
+
+	args = append(args,
+		// Move index to start of the
+		// file so playback can begin
+		// before download completes.
+		"-movflags", "+faststart",
+
+		// Overwrite.
+		"-y",
+
+		// Output.
+		outpath,
+	)
+
+	return ffmpeg(ctx, dirpath, args...)
+}
+
+// ffmpegTranscodeAudio re-encodes input audio using given audio codec at
+// given bitrate (in kbps), dropping any video (i.e. album art) streams.
+func ffmpegTranscodeAudio(
+	ctx context.Context,
+	outpath, inpath string,
+	audioBitrate int,
+	audioCodec string,
+) error {
+	// Get directory from filepath.
+	dirpath := path.Dir(inpath)
+
+	args := []string{
+		// Only log errors.
+		"-loglevel", "error",
+
+		// Input file path.
+		"-i", inpath,
+
+		// Only first audio stream.
+		"-map", "0:a:0",
+
+		// Drop video.
+		"-vn",
+	}
+
+	// Encode audio with codec at bitrate.
+	args = append(args, audioCodecArgs(
+		audioCodec,
+		audioBitrate,
+	)...)
+
+	args = append(args,
+		// Overwrite.
+		"-y",
+
+		// Output.
+		outpath,
+	)
+
+	return ffmpeg(ctx, dirpath, args...)
+}
+
+// audioCodecArgs returns the ffmpeg arguments to encode
+// audio with given codec name at given bitrate (in kbps).
+func audioCodecArgs(codec string, bitrate int) []string {
+	switch codec {
+	case "opus":
+		// Use libopus, as the native
+		// ffmpeg opus encoder is still
+		// marked as experimental.
+		codec = "libopus"
+	default:
+		// Use native ffmpeg aac encoder.
+		// (aac codec: https://trac.ffmpeg.org/wiki/Encode/AAC)
+		codec = "aac"
+	}
+	return []string{
+		"-codec:a", codec,
+		"-b:a", strconv.Itoa(bitrate) + "k",
+	}
+}
+
 // ffmpeg calls `ffmpeg [args...]` (WASM) with directory path mounted in runtime.
 func ffmpeg(ctx context.Context, dirpath string, args ...string) error {
 	var stderr byteutil.Buffer
@@ -30,6 +30,7 @@ import (
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-storage/disk"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
@@ -325,6 +326,71 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessTooLarge() {
 	suite.EqualError(err, "store: error draining data to tmp: reached read limit 263kiB")
 }
 
+func (suite *ManagerTestSuite) TestSimpleJpegProcessHashBlocked() {
+	ctx := context.Background()
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), nil
+	}
+
+	accountID := "01F8MH1H7YV1Z7D2C8K2730QBF" // local_account_1
+
+	// process the media once to get its hash
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.Len(attachment.PerceptualHash, 16)
+
+	// block the hash of the processed media
+	if err := suite.db.PutMediaHashBlock(ctx, &gtsmodel.MediaHashBlock{
+		ID:                 "01JBZQ8D8VJ1Y8P4W0KQ7B3Z6A",
+		Hash:               attachment.PerceptualHash,
+		Threshold:          4,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// process the same media again, which should now be refused
+	processing, err = suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+
+	attachment, err = processing.Load(ctx)
+	suite.ErrorIs(err, media.ErrBlockedMedia)
+	suite.Equal(gtsmodel.FileTypeUnknown, attachment.Type)
+	suite.False(*attachment.Cached)
+	suite.Empty(attachment.Thumbnail.Path)
+
+	// the owner of the media should have been reported
+	instanceAcct, err := suite.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reports, err := suite.db.GetReports(ctx, nil, instanceAcct.ID, accountID, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(reports, 1)
+	suite.Contains(reports[0].Comment, attachment.ID)
+	suite.False(*reports[0].Forwarded)
+}
+
 func (suite *ManagerTestSuite) TestPDFProcess() {
 	ctx := context.Background()
 
@@ -561,6 +627,144 @@ func (suite *ManagerTestSuite) TestBirdnestMp4Process() {
 	equalFiles(suite.T(), suite.state.Storage, dbAttachment.Thumbnail.Path, "./test/birdnest-thumbnail.webp")
 }
 
+func (suite *ManagerTestSuite) TestBirdnestMp4ProcessTranscodePending() {
+	ctx := context.Background()
+
+	// Enable transcoding with a max
+	// dimension smaller than the video.
+	config.SetMediaTranscodeEnabled(true)
+	config.SetMediaTranscodeMaxDimension(480)
+
+	data := func(_ context.Context) (io.ReadCloser, error) {
+		// load bytes from a test video
+		b, err := os.ReadFile("./test/birdnest-original.mp4")
+		if err != nil {
+			panic(err)
+		}
+		return io.NopCloser(bytes.NewBuffer(b)), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// process the media with no additional info provided
+	processing, err := suite.manager.CreateMedia(ctx,
+		accountID,
+		data,
+		media.AdditionalMediaInfo{},
+	)
+	suite.NoError(err)
+	suite.NotNil(processing)
+
+	// do a blocking call to fetch the attachment
+	attachment, err := processing.Load(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// attachment should be pending transcode,
+	// with the thumbnail stored, and the file
+	// kept in storage only until transcoded
+	suite.Equal(gtsmodel.ProcessingStatusProcessing, attachment.Processing)
+	suite.Equal(gtsmodel.FileTypeVideo, attachment.Type)
+	suite.False(*attachment.Cached)
+	suite.Empty(attachment.URL)
+	suite.Equal(accountID+"/attachment/pending/"+attachment.ID+".mp4", attachment.File.Path)
+	equalFiles(suite.T(), suite.state.Storage, attachment.File.Path, "./test/birdnest-original.mp4")
+	suite.NotEmpty(attachment.Thumbnail.Path)
+	suite.Equal("image/webp", attachment.Thumbnail.ContentType)
+
+	// file meta is that of the original for now
+	suite.Equal(404, attachment.FileMeta.Original.Width)
+	suite.Equal(720, attachment.FileMeta.Original.Height)
+
+	// the processing state should be stored in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessing, dbAttachment.Processing)
+	suite.Equal(attachment.File.Path, dbAttachment.File.Path)
+}
+
+// pendingTranscode puts the test opus file in storage as the pending
+// original of an existing attachment, and marks it as processing in
+// the database, as though a transcode was interrupted by a restart.
+func (suite *ManagerTestSuite) pendingTranscode(ctx context.Context) (*gtsmodel.MediaAttachment, string) {
+	attachment := new(gtsmodel.MediaAttachment)
+	*attachment = *suite.testAttachments["local_account_1_unattached_1"]
+
+	pendingPath := attachment.AccountID + "/attachment/pending/" + attachment.ID + ".opus"
+	if _, err := suite.state.Storage.PutFile(ctx, pendingPath, "./test/test-opus-original.opus"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	attachment.Type = gtsmodel.FileTypeAudio
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	attachment.Cached = util.Ptr(false)
+	attachment.URL = ""
+	attachment.File.Path = pendingPath
+	if err := suite.db.UpdateAttachment(ctx, attachment); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return attachment, pendingPath
+}
+
+func (suite *ManagerTestSuite) TestTranscodeRequeue() {
+	ctx := context.Background()
+	config.SetMediaTranscodeEnabled(true)
+	attachment, pendingPath := suite.pendingTranscode(ctx)
+
+	// Requeue pending transcodes, as
+	// is done on server startup, and
+	// run the requeued transcode.
+	err := suite.manager.RequeueTranscodes(ctx)
+	suite.NoError(err)
+	transcode, ok := suite.state.Workers.Processing.Queue.Pop()
+	if !ok {
+		suite.FailNow("expected transcode to be requeued")
+	}
+	transcode(ctx)
+
+	// attachment should now be fully processed
+	// (whether transcoded, or original stored)
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+	suite.Equal(gtsmodel.FileTypeAudio, dbAttachment.Type)
+	suite.True(*dbAttachment.Cached)
+	suite.NotEmpty(dbAttachment.URL)
+	suite.Contains(dbAttachment.File.Path, "/attachment/original/")
+
+	// file should be stored, and the
+	// pending original removed
+	has, err := suite.state.Storage.Has(ctx, dbAttachment.File.Path)
+	suite.NoError(err)
+	suite.True(has)
+	has, err = suite.state.Storage.Has(ctx, pendingPath)
+	suite.NoError(err)
+	suite.False(has)
+}
+
+func (suite *ManagerTestSuite) TestTranscodeRequeueMissingOriginal() {
+	ctx := context.Background()
+	config.SetMediaTranscodeEnabled(true)
+	attachment, pendingPath := suite.pendingTranscode(ctx)
+
+	// Lose the pending original.
+	err := suite.state.Storage.Delete(ctx, pendingPath)
+	suite.NoError(err)
+
+	err = suite.manager.RequeueTranscodes(ctx)
+	suite.NoError(err)
+
+	// nothing should be requeued, and
+	// the attachment no longer pending
+	suite.Zero(suite.state.Workers.Processing.Queue.Len())
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+	suite.Equal(gtsmodel.FileTypeUnknown, dbAttachment.Type)
+	suite.Empty(dbAttachment.File.Path)
+}
+
 func (suite *ManagerTestSuite) TestOpusProcess() {
 	ctx := context.Background()
 
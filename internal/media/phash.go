@@ -0,0 +1,205 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/disintegration/imaging"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"golang.org/x/image/webp"
+)
+
+// ErrBlockedMedia is returned when processed media
+// matches a perceptual hash blocked by instance admins.
+var ErrBlockedMedia = errors.New("media matches blocked perceptual hash")
+
+// generatePerceptualHash generates a hex-encoded 64-bit
+// difference hash (dHash) of the JPEG or Webp thumbnail
+// at filepath. For video, the thumbnail is a keyframe.
+//
+// The image is reduced to 9x8 grayscale, and each bit
+// records whether a pixel is brighter than its neighbour
+// to the right, so visually similar images give hashes
+// with a small hamming distance between them.
+func generatePerceptualHash(filepath string) (string, error) {
+	var decode func(io.Reader) (image.Image, error)
+
+	switch ext := getExtension(filepath); ext {
+	case "jpeg":
+		decode = jpeg.Decode
+	case "webp":
+		decode = webp.Decode
+	default:
+		return "", gtserror.Newf("unsupported thumbnail extension %s", ext)
+	}
+
+	// Open the file at given path.
+	file, err := os.Open(filepath)
+	if err != nil {
+		return "", gtserror.Newf("error opening input file %s: %w", filepath, err)
+	}
+
+	// Decode image from file.
+	img, err := decode(file)
+
+	// Done with file.
+	_ = file.Close()
+
+	if err != nil {
+		return "", gtserror.Newf("error decoding file %s: %w", filepath, err)
+	}
+
+	return dHash(img), nil
+}
+
+// dHash returns the hex-encoded 64-bit difference hash of img.
+func dHash(img image.Image) string {
+	tiny := imaging.Resize(imaging.Grayscale(img), 9, 8, imaging.Lanczos)
+
+	var hash uint64
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			// Grayscale, so any channel will do.
+			left := tiny.Pix[tiny.PixOffset(x, y)]
+			right := tiny.Pix[tiny.PixOffset(x+1, y)]
+
+			hash <<= 1
+			if left > right {
+				hash |= 1
+			}
+		}
+	}
+
+	return fmt.Sprintf("%016x", hash)
+}
+
+// checkHashBlocks checks the perceptual hash of the media
+// against all media hash blocks, returning ErrBlockedMedia
+// (after reporting the media's owner) if any matched.
+func (p *ProcessingMedia) checkHashBlocks(ctx context.Context) error {
+	block, err := p.mgr.state.DB.MatchMediaHashBlock(ctx, p.media.PerceptualHash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error matching media hash blocks: %w", err)
+	}
+
+	if block == nil {
+		// Not blocked.
+		return nil
+	}
+
+	// Reporting must happen even
+	// if processing is canceled.
+	ctx = context.WithoutCancel(ctx)
+
+	if err := p.mgr.reportBlockedMedia(ctx, p.media, block); err != nil {
+		log.Errorf(ctx, "error reporting blocked media %s: %v", p.media.ID, err)
+	}
+
+	return gtserror.Newf("%w: media %s matched block %s", ErrBlockedMedia, p.media.ID, block.ID)
+}
+
+// reportBlockedMedia opens a report, from the instance account,
+// against the owner of media matching the given hash block, so
+// admins are notified. If an unresolved report about the same
+// media already exists (eg., from a previous recache attempt
+// of remote media), no new report is opened.
+func (m *Manager) reportBlockedMedia(
+	ctx context.Context,
+	media *gtsmodel.MediaAttachment,
+	block *gtsmodel.MediaHashBlock,
+) error {
+	instanceAcct, err := m.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("db error getting instance account: %w", err)
+	}
+
+	targetAcct, err := m.state.DB.GetAccountByID(ctx, media.AccountID)
+	if err != nil {
+		return gtserror.Newf("db error getting media owner: %w", err)
+	}
+
+	reports, err := m.state.DB.GetReports(ctx,
+		util.Ptr(false),
+		instanceAcct.ID,
+		targetAcct.ID,
+		nil,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting existing reports: %w", err)
+	}
+
+	if slices.ContainsFunc(reports, func(r *gtsmodel.Report) bool {
+		return strings.Contains(r.Comment, media.ID)
+	}) {
+		// Already reported.
+		return nil
+	}
+
+	mediaURL := media.RemoteURL
+	if mediaURL == "" {
+		mediaURL = "local upload"
+	}
+
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             uris.GenerateURIForReport(reportID),
+		AccountID:       instanceAcct.ID,
+		Account:         instanceAcct,
+		TargetAccountID: targetAcct.ID,
+		TargetAccount:   targetAcct,
+		Comment: fmt.Sprintf(
+			"Automatic report: media %s (%s) with perceptual hash %s matched media hash block %s (hash %s, threshold %d), and was refused.",
+			media.ID, mediaURL, media.PerceptualHash, block.ID, block.Hash, block.Threshold,
+		),
+		Forwarded: util.Ptr(false),
+		State:     gtsmodel.ReportStateOpen,
+	}
+
+	if err := m.state.DB.PutReport(ctx, report); err != nil {
+		return gtserror.Newf("db error putting report: %w", err)
+	}
+
+	// Notify admins asynchronously.
+	m.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorPerson,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		Origin:         instanceAcct,
+		Target:         targetAcct,
+	})
+
+	return nil
+}
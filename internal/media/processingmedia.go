@@ -42,6 +42,7 @@ type ProcessingMedia struct {
 	proc   runners.Processor         // proc helps synchronize only a singular running processing instance
 	err    error                     // error stores permanent error value when done
 	mgr    *Manager                  // mgr instance (access to db / storage)
+	tmp    string                    // tmp media file path, if pending transcode
 }
 
 // ID returns the ID of the underlying media.
@@ -109,6 +110,13 @@ func (p *ProcessingMedia) load(ctx context.Context) (
 					log.Errorf(ctx, "error updating media in db: %v", e)
 				}
 
+				if p.tmp != "" {
+					// Media is pending transcode, queue
+					// this to happen in the background
+					// now that initial details are stored.
+					p.queueTranscode()
+				}
+
 				// Store values.
 				p.done = true
 				p.err = err
@@ -175,15 +183,7 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// Extract any video stream metadata from media.
 	// This will always be used regardless of type,
 	// as even audio files may contain embedded album art.
-	width, height, framerate := result.ImageMeta()
-	aspect := util.Div(float32(width), float32(height))
-	p.media.FileMeta.Original.Width = width
-	p.media.FileMeta.Original.Height = height
-	p.media.FileMeta.Original.Size = (width * height)
-	p.media.FileMeta.Original.Aspect = aspect
-	p.media.FileMeta.Original.Framerate = util.PtrIf(framerate)
-	p.media.FileMeta.Original.Duration = util.PtrIf(float32(result.duration))
-	p.media.FileMeta.Original.Bitrate = util.PtrIf(result.bitrate)
+	width, height, aspect := p.setOriginalMeta(result)
 
 	// Set media type from ffprobe format data.
 	p.media.Type, ext = result.GetFileType()
@@ -250,29 +250,20 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 			// Set newly determined blurhash.
 			p.media.Blurhash = newBlurhash
 		}
-	}
 
-	// Calculate final media attachment file path.
-	p.media.File.Path = uris.StoragePathForAttachment(
-		p.media.AccountID,
-		string(TypeAttachment),
-		string(SizeOriginal),
-		p.media.ID,
-		ext,
-	)
+		// Generate perceptual hash from the thumbnail
+		// (for video, a keyframe), which is checked
+		// against blocks before anything is stored.
+		p.media.PerceptualHash, err = generatePerceptualHash(thumbpath)
+		if err != nil {
+			return gtserror.Newf("error generating perceptual hash: %w", err)
+		}
 
-	// Copy temporary file into storage at path.
-	filesz, err := p.mgr.state.Storage.PutFile(ctx,
-		p.media.File.Path,
-		temppath,
-	)
-	if err != nil {
-		return gtserror.Newf("error writing media to storage: %w", err)
+		if err := p.checkHashBlocks(ctx); err != nil {
+			return err
+		}
 	}
 
-	// Set final determined file size.
-	p.media.File.FileSize = int(filesz)
-
 	if thumbpath != "" {
 		// Determine final thumbnail ext.
 		thumbExt := getExtension(thumbpath)
@@ -311,6 +302,73 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 		)
 	}
 
+	if needsTranscode(p.media, result, ext) {
+		// Keep a copy of the original in storage until
+		// transcoding is done, so that the transcode can
+		// be requeued if interrupted by eg., a restart.
+		p.media.File.Path = pendingTranscodePath(p.media, ext)
+		if _, err := p.mgr.state.Storage.PutFile(ctx,
+			p.media.File.Path,
+			temppath,
+		); err != nil {
+			return gtserror.Newf("error writing media to storage: %w", err)
+		}
+
+		// Hand tmp file over to be
+		// transcoded in background,
+		// which will then remove it.
+		p.tmp, temppath = temppath, ""
+
+		// Thumbnail is available but
+		// full-size media is not yet.
+		p.media.Processing = gtsmodel.ProcessingStatusProcessing
+		return nil
+	}
+
+	// Store media file as-is.
+	return p.storeFile(ctx, temppath, ext)
+}
+
+// setOriginalMeta sets original file metadata on the media from
+// given ffprobe result, returning the width, height and aspect.
+func (p *ProcessingMedia) setOriginalMeta(result *result) (width, height int, aspect float32) {
+	width, height, framerate := result.ImageMeta()
+	aspect = util.Div(float32(width), float32(height))
+	p.media.FileMeta.Original.Width = width
+	p.media.FileMeta.Original.Height = height
+	p.media.FileMeta.Original.Size = (width * height)
+	p.media.FileMeta.Original.Aspect = aspect
+	p.media.FileMeta.Original.Framerate = util.PtrIf(framerate)
+	p.media.FileMeta.Original.Duration = util.PtrIf(float32(result.duration))
+	p.media.FileMeta.Original.Bitrate = util.PtrIf(result.bitrate)
+	return
+}
+
+// storeFile copies the processed media file at path, with given
+// extension, into storage. It then updates the media file details
+// as necessary, and marks it as cached and finished processing.
+func (p *ProcessingMedia) storeFile(ctx context.Context, filepath string, ext string) error {
+	// Calculate final media attachment file path.
+	p.media.File.Path = uris.StoragePathForAttachment(
+		p.media.AccountID,
+		string(TypeAttachment),
+		string(SizeOriginal),
+		p.media.ID,
+		ext,
+	)
+
+	// Copy temporary file into storage at path.
+	filesz, err := p.mgr.state.Storage.PutFile(ctx,
+		p.media.File.Path,
+		filepath,
+	)
+	if err != nil {
+		return gtserror.Newf("error writing media to storage: %w", err)
+	}
+
+	// Set final determined file size.
+	p.media.File.FileSize = int(filesz)
+
 	// Generate a media attachment URL.
 	p.media.URL = uris.URIForAttachment(
 		p.media.AccountID,
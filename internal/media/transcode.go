@@ -0,0 +1,344 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package media
+
+import (
+	"context"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// needsTranscode returns whether given media, with ffprobe result and
+// determined file extension, should be transcoded to be web-safe and
+// within configured caps. Only local, non avatar / header, video and
+// audio is ever transcoded, and only when transcoding is enabled.
+func needsTranscode(media *gtsmodel.MediaAttachment, res *result, ext string) bool {
+	if !config.GetMediaTranscodeEnabled() ||
+		media.IsRemote() ||
+		util.PtrOrZero(media.Avatar) ||
+		util.PtrOrZero(media.Header) {
+		return false
+	}
+
+	var (
+		maxDimension = config.GetMediaTranscodeMaxDimension()
+		videoBitrate = uint64(config.GetMediaTranscodeVideoBitrate()) * 1000
+		audioBitrate = uint64(config.GetMediaTranscodeAudioBitrate()) * 1000
+	)
+
+	switch media.Type {
+	case gtsmodel.FileTypeVideo,
+		gtsmodel.FileTypeGifv:
+		if ext != "mp4" || len(res.video) == 0 {
+			// Only h264 in mp4
+			// is safe everywhere.
+			return true
+		}
+
+		video := res.video[0]
+		if video.codec != "h264" ||
+			video.pixfmt != "yuv420p" {
+			// Not web-safe, e.g. hevc
+			// or 10-bit color video.
+			return true
+		}
+
+		if video.width > maxDimension ||
+			video.height > maxDimension {
+			// Too big.
+			return true
+		}
+
+		for _, audio := range res.audio {
+			switch audio.codec {
+			case "aac", "mp3", "opus":
+				// Web-safe in mp4.
+			default:
+				return true
+			}
+		}
+
+		// Check overall bitrate within caps.
+		return exceedsBitrate(res.bitrate,
+			videoBitrate+audioBitrate,
+		)
+
+	case gtsmodel.FileTypeAudio:
+		if len(res.audio) == 0 {
+			return true
+		}
+
+		// Check for web-safe
+		// container + codec.
+		switch ext + "/" + res.audio[0].codec {
+		case "m4a/aac", "mp3/mp3", "ogg/opus":
+		default:
+			return true
+		}
+
+		// Check bitrate within caps.
+		return exceedsBitrate(res.bitrate,
+			audioBitrate,
+		)
+
+	default:
+		return false
+	}
+}
+
+// exceedsBitrate returns whether bitrate exceeds max bitrate,
+// allowing 10% slack to account for container overheads, and
+// the inaccuracy of encoders' target bitrates, so that media
+// already encoded to the caps isn't needlessly re-encoded.
+func exceedsBitrate(bitrate, max uint64) bool {
+	return bitrate > max+max/10
+}
+
+// transcodeExt returns the file extension
+// that media of given type is transcoded to.
+func transcodeExt(typ gtsmodel.FileType) string {
+	if typ != gtsmodel.FileTypeAudio {
+		// Video is always mp4.
+		return "mp4"
+	}
+
+	if config.GetMediaTranscodeAudioCodec() ==
+		config.MediaTranscodeAudioCodecOpus {
+		return "ogg"
+	}
+
+	return "m4a"
+}
+
+// pendingTranscodePath returns the storage path at which the original
+// file of media, with given extension, is kept while pending transcode.
+func pendingTranscodePath(media *gtsmodel.MediaAttachment, ext string) string {
+	return uris.StoragePathForAttachment(
+		media.AccountID,
+		string(TypeAttachment),
+		string(SizePending),
+		media.ID,
+		ext,
+	)
+}
+
+// RequeueTranscodes requeues transcoding of all local media still
+// pending transcode, eg., those interrupted by a restart, using the
+// original file kept in storage. Any media whose original can't be
+// fetched from storage is marked as failed, rather than left pending.
+func (m *Manager) RequeueTranscodes(ctx context.Context) error {
+	pending, err := m.state.DB.GetLocalProcessingAttachments(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting processing media: %w", err)
+	}
+
+	for _, media := range pending {
+		p := &ProcessingMedia{
+			media: media,
+			mgr:   m,
+		}
+
+		// Fetch pending original
+		// back out to a tmp file.
+		p.tmp, err = fetchToTmp(ctx,
+			m.state.Storage,
+			media.File.Path,
+		)
+		if err != nil {
+			log.Errorf(ctx, "error fetching original of media %s, marking as failed: %v", media.ID, err)
+
+			// Perform error cleanup,
+			// and update all columns.
+			p.cleanup(ctx)
+			if err := m.state.DB.UpdateAttachment(ctx, p.media); err != nil {
+				log.Errorf(ctx, "error updating media in db: %v", err)
+			}
+			continue
+		}
+
+		m.state.Workers.Processing.Queue.Push(p.transcode)
+	}
+
+	if len(pending) > 0 {
+		log.Infof(ctx, "requeued transcoding of %d media", len(pending))
+	}
+
+	return nil
+}
+
+// queueTranscode queues transcoding of the media's tmp file on the
+// processing worker queue, passing ownership of the tmp file to it.
+func (p *ProcessingMedia) queueTranscode() {
+	// Take a copy of the media so that the
+	// transcode has no shared state with p,
+	// which may still be in use by caller.
+	media := new(gtsmodel.MediaAttachment)
+	*media = *p.media
+
+	t := &ProcessingMedia{
+		media: media,
+		mgr:   p.mgr,
+		tmp:   p.tmp,
+	}
+
+	// Unset tmp as
+	// no longer ours.
+	p.tmp = ""
+
+	p.mgr.state.Workers.Processing.Queue.Push(t.transcode)
+}
+
+// transcode re-encodes the media's tmp file to be web-safe and within
+// configured caps, then stores the result and marks the media as done
+// processing. If transcoding fails, the original file is stored instead.
+// Either way, the pending original is then removed from storage.
+func (p *ProcessingMedia) transcode(ctx context.Context) {
+	var outpath string
+
+	// Original kept in storage
+	// while transcode pending.
+	pendingPath := p.media.File.Path
+
+	defer func() {
+		if err := remove(p.tmp, outpath); err != nil {
+			log.Errorf(ctx, "error(s) cleaning up files: %v", err)
+		}
+	}()
+
+	// Generate transcoded output path REPLACING extension.
+	ext := transcodeExt(p.media.Type)
+	if i := strings.IndexByte(p.tmp, '.'); i != -1 {
+		outpath = p.tmp[:i] + "_transcoded." + ext
+	}
+
+	// Transcode tmp file into output.
+	err := p.transcodeFile(ctx, outpath)
+
+	// Only update the columns set during
+	// transcode, so as not to overwrite any
+	// changes (e.g. description) made since.
+	columns := []string{
+		"processing",
+		"cached",
+		"url",
+		"file_path",
+		"file_content_type",
+		"file_file_size",
+		"original_width",
+		"original_height",
+		"original_size",
+		"original_aspect",
+		"original_duration",
+		"original_framerate",
+		"original_bitrate",
+	}
+
+	if err != nil {
+		log.Errorf(ctx, "error transcoding media %s, storing original: %v", p.media.ID, err)
+
+		// Fall back to storing the
+		// original file (metadata
+		// will already be cleaned).
+		err = p.storeFile(ctx, p.tmp, getExtension(p.tmp))
+	} else {
+		// Store newly transcoded file.
+		err = p.storeFile(ctx, outpath, ext)
+	}
+
+	if err != nil {
+		log.Errorf(ctx, "error storing media %s: %v", p.media.ID, err)
+
+		// Perform error cleanup,
+		// updating all columns.
+		p.cleanup(ctx)
+		columns = nil
+	}
+
+	// Update with latest details, whatever happened.
+	if err := p.mgr.state.DB.UpdateAttachment(ctx, p.media, columns...); err != nil {
+		log.Errorf(ctx, "error updating media in db: %v", err)
+
+		// Leave pending original
+		// in storage for requeue.
+		return
+	}
+
+	if pendingPath != "" && pendingPath != p.media.File.Path {
+		// Pending original no longer needed.
+		err := p.mgr.state.Storage.Delete(ctx, pendingPath)
+		if err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting %s: %v", pendingPath, err)
+		}
+	}
+}
+
+// transcodeFile transcodes the media's tmp file into outpath, updating
+// the media's original file metadata from the transcoded result.
+func (p *ProcessingMedia) transcodeFile(ctx context.Context, outpath string) error {
+	if outpath == "" {
+		return gtserror.New("input file missing extension")
+	}
+
+	var err error
+
+	switch p.media.Type {
+	case gtsmodel.FileTypeAudio:
+		err = ffmpegTranscodeAudio(ctx,
+			outpath,
+			p.tmp,
+			config.GetMediaTranscodeAudioBitrate(),
+			config.GetMediaTranscodeAudioCodec(),
+		)
+
+	default:
+		err = ffmpegTranscodeVideo(ctx,
+			outpath,
+			p.tmp,
+			config.GetMediaTranscodeMaxDimension(),
+			config.GetMediaTranscodeVideoBitrate(),
+			config.GetMediaTranscodeAudioBitrate(),
+			config.GetMediaTranscodeAudioCodec(),
+			p.media.Type == gtsmodel.FileTypeGifv,
+		)
+	}
+
+	if err != nil {
+		return gtserror.Newf("ffmpeg error: %w", err)
+	}
+
+	// Probe transcoded output for updated
+	// dimensions, duration, bitrate etc.
+	result, err := probe(ctx, outpath)
+	if err != nil {
+		return gtserror.Newf("ffprobe error: %w", err)
+	} else if result == nil {
+		return gtserror.New("unsupported transcoded data type")
+	}
+
+	// Update original metadata from result.
+	p.setOriginalMeta(result)
+
+	return nil
+}
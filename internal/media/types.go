@@ -29,6 +29,7 @@ const (
 	SizeSmall    Size = "small"    // SizeSmall is the key for small/thumbnail versions of media
 	SizeOriginal Size = "original" // SizeOriginal is the key for original/fullsize versions of media and emoji
 	SizeStatic   Size = "static"   // SizeStatic is the key for static (non-animated) versions of emoji
+	SizePending  Size = "pending"  // SizePending is the key for originals of media awaiting transcode
 )
 
 type Type string
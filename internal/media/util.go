@@ -19,6 +19,7 @@ package media
 
 import (
 	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -27,6 +28,7 @@ import (
 	"codeberg.org/gruf/go-bytesize"
 	"codeberg.org/gruf/go-iotools"
 	"codeberg.org/gruf/go-mimetypes"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // getExtension splits file extension from path.
@@ -136,6 +138,37 @@ func drainToTmp(rc io.ReadCloser) (string, error) {
 	return path, nil
 }
 
+// fetchToTmp fetches the file at given storage path into a new temp
+// file, with the same file extension, returning the temp file path.
+func fetchToTmp(ctx context.Context, st *storage.Driver, path string) (string, error) {
+	if path == "" {
+		return "", errors.New("no storage path")
+	}
+
+	// Open file in storage for reading.
+	rc, err := st.GetStream(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	// Drain file into tmp
+	// (this handles close).
+	tmppath, err := drainToTmp(rc)
+	if err != nil {
+		_ = remove(tmppath)
+		return "", err
+	}
+
+	// Add file extension to path.
+	newpath := tmppath + "." + getExtension(path)
+	if err := os.Rename(tmppath, newpath); err != nil {
+		_ = remove(tmppath)
+		return "", err
+	}
+
+	return newpath, nil
+}
+
 // remove only removes paths if not-empty.
 func remove(paths ...string) error {
 	var errs []error
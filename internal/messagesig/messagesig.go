@@ -0,0 +1,252 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+// Package messagesig implements signing and verification of
+// requests using HTTP Message Signatures, as per RFC 9421,
+// along with the Content-Digest field of RFC 9530.
+//
+// This is the successor to the draft-cavage HTTP signatures
+// scheme implemented by the httpsig library, and the two
+// share the Signature header name, so only one of them can
+// be used on any given request.
+package messagesig
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Algorithm is an HTTP message signature
+// algorithm, as registered in RFC 9421.
+type Algorithm string
+
+const (
+	RSA_V1_5_SHA256 Algorithm = "rsa-v1_5-sha256" // RSASSA-PKCS1-v1_5 using SHA-256
+	RSA_PSS_SHA512  Algorithm = "rsa-pss-sha512"  // RSASSA-PSS using SHA-512
+	ED25519         Algorithm = "ed25519"         // EdDSA using curve edwards25519
+)
+
+const (
+	SignatureHeader      = "Signature"       // Signature header, shared with draft-cavage signatures
+	SignatureInputHeader = "Signature-Input" // Signature-Input header, unique to RFC 9421 signatures
+	ContentDigestHeader  = "Content-Digest"  // Content-Digest header, as per RFC 9530
+)
+
+// ErrNotSigned is returned by NewVerifier
+// when a request has no RFC 9421 signature.
+var ErrNotSigned = errors.New("request has no " + SignatureInputHeader + " header")
+
+// signatureBase builds the signature base (RFC 9421 §2.5) for given
+// request with target URI, covering given components and parameters.
+// It returns the signature base, and the serialized signature params.
+func signatureBase(
+	r *http.Request,
+	target *url.URL,
+	components []item,
+	params []param,
+) (string, string, error) {
+	var (
+		b    strings.Builder
+		seen = make(map[string]struct{}, len(components))
+	)
+
+	for _, c := range components {
+		id := serializeItem(c)
+		if _, ok := seen[id]; ok {
+			return "", "", fmt.Errorf("component %s covered more than once", id)
+		}
+		seen[id] = struct{}{}
+
+		value, err := componentValue(r, target, c)
+		if err != nil {
+			return "", "", err
+		}
+
+		b.WriteString(id)
+		b.WriteString(": ")
+		b.WriteString(value)
+		b.WriteByte('\n')
+	}
+
+	sigParams := serializeInnerList(components, params)
+	b.WriteString(`"@signature-params": `)
+	b.WriteString(sigParams)
+
+	return b.String(), sigParams, nil
+}
+
+// componentValue returns the value of the given component for
+// request with target URI, either derived from the request, or
+// taken from the request header of the same name. Component
+// parameters (eg., ;sf, ;key, ;req) are not supported.
+func componentValue(r *http.Request, target *url.URL, c item) (string, error) {
+	name, ok := c.value.(string)
+	if !ok {
+		return "", errors.New("component identifier was not a string")
+	}
+
+	if len(c.params) > 0 {
+		return "", fmt.Errorf("parameters on component %q not supported", name)
+	}
+
+	switch name {
+	case "@method":
+		return r.Method, nil
+	case "@target-uri":
+		return target.String(), nil
+	case "@authority":
+		return strings.ToLower(target.Host), nil
+	case "@scheme":
+		return strings.ToLower(target.Scheme), nil
+	case "@request-target":
+		return target.RequestURI(), nil
+	case "@path":
+		path := target.EscapedPath()
+		if path == "" {
+			path = "/"
+		}
+		return path, nil
+	case "@query":
+		return "?" + target.RawQuery, nil
+	}
+
+	if strings.HasPrefix(name, "@") {
+		return "", fmt.Errorf("derived component %q not supported", name)
+	}
+
+	if name != strings.ToLower(name) {
+		return "", fmt.Errorf("component %q not lowercase", name)
+	}
+
+	values := r.Header.Values(name)
+	if len(values) == 0 {
+		// Some headers are moved from the
+		// header map by net/http, check those.
+		switch name {
+		case "host":
+			values = []string{target.Host}
+		case "content-length":
+			if r.ContentLength > 0 {
+				values = []string{strconv.FormatInt(r.ContentLength, 10)}
+			}
+		}
+	}
+
+	if len(values) == 0 {
+		return "", fmt.Errorf("covered header %q not present", name)
+	}
+
+	// Trim each value, taking care not
+	// to modify the header map itself.
+	trimmed := make([]string, len(values))
+	for i, v := range values {
+		trimmed[i] = strings.TrimSpace(v)
+	}
+
+	return strings.Join(trimmed, ", "), nil
+}
+
+// requestTarget returns the target URI of an outgoing
+// request, with any fragment and userinfo removed.
+func requestTarget(r *http.Request) *url.URL {
+	target := *r.URL
+	target.Fragment = ""
+	target.RawFragment = ""
+	target.User = nil
+	return &target
+}
+
+// incomingRequestTarget reconstructs the target URI of an
+// incoming request, as received on the given scheme.
+func incomingRequestTarget(r *http.Request, scheme string) (*url.URL, error) {
+	requestURI := r.RequestURI
+	if !strings.HasPrefix(requestURI, "/") {
+		// Not given in origin-form, (either
+		// unset, or in absolute-form), so
+		// use the path + query of parsed URL.
+		requestURI = r.URL.RequestURI()
+	}
+	return url.Parse(scheme + "://" + r.Host + requestURI)
+}
+
+// contentDigest returns a Content-Digest
+// field value for the given request body.
+func contentDigest(body []byte) string {
+	sum := sha256.Sum256(body)
+	return "sha-256=" + serializeBareItem(sum[:])
+}
+
+// checkContentDigest reads the body of the given request and checks
+// it against the request's Content-Digest header, replacing the body
+// afterwards so it can be read again. At least one digest must be of
+// a supported algorithm (sha-256 or sha-512), and all such must match.
+func checkContentDigest(r *http.Request) error {
+	members, err := parseDictionary(strings.Join(r.Header.Values(ContentDigestHeader), ", "))
+	if err != nil {
+		return fmt.Errorf("error parsing %s: %w", ContentDigestHeader, err)
+	}
+
+	var body []byte
+	if r.Body != nil {
+		body, err = io.ReadAll(r.Body)
+		_ = r.Body.Close()
+		if err != nil {
+			return fmt.Errorf("error reading request body: %w", err)
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	checked := false
+	for _, m := range members {
+		digest, ok := m.value.value.([]byte)
+		if m.inner || !ok {
+			continue
+		}
+
+		var sum []byte
+		switch m.key {
+		case "sha-256":
+			s := sha256.Sum256(body)
+			sum = s[:]
+		case "sha-512":
+			s := sha512.Sum512(body)
+			sum = s[:]
+		default:
+			// Unsupported.
+			continue
+		}
+
+		if !bytes.Equal(sum, digest) {
+			return fmt.Errorf("%s %s did not match request body", ContentDigestHeader, m.key)
+		}
+		checked = true
+	}
+
+	if !checked {
+		return fmt.Errorf("%s had no supported digests", ContentDigestHeader)
+	}
+
+	return nil
+}
@@ -0,0 +1,240 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messagesig_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
+)
+
+type MessageSigTestSuite struct {
+	suite.Suite
+}
+
+// incoming converts the given signed outgoing
+// request into a request as received by a server.
+func (suite *MessageSigTestSuite) incoming(out *http.Request, body []byte) *http.Request {
+	in, err := http.NewRequest(out.Method, out.URL.RequestURI(), bytes.NewReader(body))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	in.Host = out.URL.Host
+	in.RequestURI = out.URL.RequestURI()
+	in.Header = out.Header.Clone()
+	if body == nil {
+		in.ContentLength = 0
+	}
+	return in
+}
+
+func (suite *MessageSigTestSuite) TestVerifyRFC9421TestVector() {
+	// Example B.2.6 of RFC 9421,
+	// signing with test-key-ed25519.
+	der, err := base64.StdEncoding.DecodeString("MCowBQYDK2VwAyEAJrQLj5P/89iXES9+vFgrIy29clF9CC/oPPsw3c5D0bs=")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	pubKey, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	r, err := http.NewRequest(http.MethodPost, "/foo?param=Value&Pet=dog", strings.NewReader(`{"hello": "world"}`))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	r.Host = "example.com"
+	r.RequestURI = "/foo?param=Value&Pet=dog"
+	r.Header.Set("Date", "Tue, 20 Apr 2021 02:07:55 GMT")
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Content-Digest", "sha-512=:WZDPaVn/7XgHaAy8pmojAkGWoRx2UFChF41A2svX+TaPm+AbwAgBWnrIiYllu7BNNyealdVLvRwEmTHWXvJwew==:")
+	r.Header.Set("Content-Length", "18")
+	r.Header.Set("Signature-Input", `sig-b26=("date" "@method" "@path" "@authority" "content-type" "content-length");created=1618884473;keyid="test-key-ed25519"`)
+	r.Header.Set("Signature", `sig-b26=:wqcAqbmYJ2ji2glfAMaRy4gruYYnx2nEFN2HN6jrnDnQCK1u02Gb04v9EDgwUPiu4A0w6vuQv5lIp5WPpBKRCw==:`)
+
+	verifier, err := messagesig.NewVerifier(r, "https")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal("test-key-ed25519", verifier.KeyID())
+	suite.NoError(verifier.Verify(pubKey))
+
+	// Signature is years old, and doesn't
+	// cover the content digest or query.
+	suite.EqualError(verifier.Validate(time.Now()), "signature too old")
+	suite.EqualError(verifier.Validate(time.Unix(1618884473, 0)), "signature does not cover target uri")
+
+	// Should fail with a different key.
+	otherKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualError(verifier.Verify(otherKey), "ed25519 signature invalid")
+}
+
+func (suite *MessageSigTestSuite) TestSignVerify() {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	edPubKey, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	for _, test := range []struct {
+		alg     messagesig.Algorithm
+		key     crypto.PrivateKey
+		pubKey  crypto.PublicKey
+		method  string
+		body    []byte
+		covered []string
+	}{
+		{
+			alg:     messagesig.ED25519,
+			key:     edKey,
+			pubKey:  edPubKey,
+			method:  http.MethodGet,
+			covered: []string{"@method", "@target-uri"},
+		},
+		{
+			alg:     messagesig.ED25519,
+			key:     edKey,
+			pubKey:  edPubKey,
+			method:  http.MethodPost,
+			body:    []byte(`{"type":"Create"}`),
+			covered: []string{"@method", "@target-uri", "content-digest"},
+		},
+		{
+			alg:     messagesig.RSA_V1_5_SHA256,
+			key:     rsaKey,
+			pubKey:  &rsaKey.PublicKey,
+			method:  http.MethodPost,
+			body:    []byte(`{"type":"Create"}`),
+			covered: []string{"@method", "@target-uri", "content-digest"},
+		},
+		{
+			alg:     messagesig.RSA_PSS_SHA512,
+			key:     rsaKey,
+			pubKey:  &rsaKey.PublicKey,
+			method:  http.MethodGet,
+			covered: []string{"@method", "@target-uri"},
+		},
+	} {
+		var reqBody io.Reader
+		if test.body != nil {
+			reqBody = bytes.NewReader(test.body)
+		}
+
+		out, err := http.NewRequest(test.method, "https://example.org/users/someone/inbox?page=true", reqBody)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		keyID := "https://example.org/users/someone/main-key#key"
+		if err := messagesig.Sign(out, test.body, test.key, keyID, test.alg); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		in := suite.incoming(out, test.body)
+		verifier, err := messagesig.NewVerifier(in, "https")
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		suite.Equal(keyID, verifier.KeyID())
+		suite.Equal(test.alg, verifier.Algorithm())
+		for _, c := range test.covered {
+			suite.True(verifier.Covers(c), c)
+		}
+		suite.NoError(verifier.Validate(time.Now()))
+		suite.NoError(verifier.Verify(test.pubKey))
+
+		// Body should still be readable
+		// after content digest checked.
+		if test.body != nil {
+			b, err := io.ReadAll(in.Body)
+			suite.NoError(err)
+			suite.Equal(test.body, b)
+		}
+
+		// Signature should not verify
+		// if received on another scheme.
+		in = suite.incoming(out, test.body)
+		verifier, err = messagesig.NewVerifier(in, "http")
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Error(verifier.Verify(test.pubKey))
+	}
+}
+
+func (suite *MessageSigTestSuite) TestVerifyTamperedBody() {
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	body := []byte(`{"type":"Create"}`)
+	out, err := http.NewRequest(http.MethodPost, "https://example.org/users/someone/inbox", bytes.NewReader(body))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := messagesig.Sign(out, body, edKey, "https://example.org/users/someone/main-key#key", messagesig.ED25519); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	in := suite.incoming(out, []byte(`{"type":"Delete"}`))
+	_, err = messagesig.NewVerifier(in, "https")
+	suite.EqualError(err, "Content-Digest sha-256 did not match request body")
+}
+
+func (suite *MessageSigTestSuite) TestVerifyNotSigned() {
+	r, err := http.NewRequest(http.MethodGet, "/users/someone", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// A draft-cavage signature alone
+	// isn't an RFC 9421 signature.
+	r.Header.Set("Signature", `keyId="https://example.org/users/someone#main-key",algorithm="hs2019",headers="(request-target) host date",signature="bm90IGEgcmVhbCBzaWduYXR1cmU="`)
+
+	_, err = messagesig.NewVerifier(r, "https")
+	suite.ErrorIs(err, messagesig.ErrNotSigned)
+}
+
+func TestMessageSigTestSuite(t *testing.T) {
+	suite.Run(t, new(MessageSigTestSuite))
+}
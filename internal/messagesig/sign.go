@@ -0,0 +1,117 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messagesig
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// label is the label used for
+// signatures that we generate.
+const label = "sig1"
+
+// Sign signs the given outgoing request on behalf of keyID, using the
+// private key and algorithm, and sets the resulting Signature-Input and
+// Signature headers on the request. The signature covers the request
+// method and target URI. If body is not nil, a Content-Digest header
+// is also set for it, and covered by the signature.
+func Sign(
+	r *http.Request,
+	body []byte,
+	key crypto.PrivateKey,
+	keyID string,
+	alg Algorithm,
+) error {
+	components := []item{
+		{value: "@method"},
+		{value: "@target-uri"},
+	}
+
+	if body != nil {
+		r.Header.Set(ContentDigestHeader, contentDigest(body))
+		components = append(components, item{value: "content-digest"})
+	}
+
+	params := []param{
+		{key: "created", value: time.Now().Unix()},
+		{key: "keyid", value: keyID},
+		{key: "alg", value: string(alg)},
+	}
+
+	base, sigParams, err := signatureBase(r, requestTarget(r), components, params)
+	if err != nil {
+		return err
+	}
+
+	signature, err := sign(key, alg, []byte(base))
+	if err != nil {
+		return err
+	}
+
+	r.Header.Set(SignatureInputHeader, label+"="+sigParams)
+	r.Header.Set(SignatureHeader, label+"=:"+base64.StdEncoding.EncodeToString(signature)+":")
+	return nil
+}
+
+// sign signs the given signature base
+// with private key using algorithm.
+func sign(key crypto.PrivateKey, alg Algorithm, base []byte) ([]byte, error) {
+	switch alg {
+	case ED25519:
+		k, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires ed25519 key, got %T", alg, key)
+		}
+		return ed25519.Sign(k, base), nil
+
+	case RSA_V1_5_SHA256:
+		k, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires rsa key, got %T", alg, key)
+		}
+		sum := sha256.Sum256(base)
+		return rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:])
+
+	case RSA_PSS_SHA512:
+		k, ok := key.(*rsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("%s requires rsa key, got %T", alg, key)
+		}
+		sum := sha512.Sum512(base)
+		return rsa.SignPSS(rand.Reader, k, crypto.SHA512, sum[:], pssOptions)
+
+	default:
+		return nil, fmt.Errorf("unsupported algorithm %q", alg)
+	}
+}
+
+// pssOptions are the RSASSA-PSS options
+// for rsa-pss-sha512, as per RFC 9421.
+var pssOptions = &rsa.PSSOptions{
+	SaltLength: 64,
+	Hash:       crypto.SHA512,
+}
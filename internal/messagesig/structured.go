@@ -0,0 +1,433 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messagesig
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// This file contains just enough of a parser + serializer
+// for Structured Field Values (RFC 8941) to handle the
+// Signature, Signature-Input and Content-Digest fields.
+// Decimals are not supported, as none of these use them.
+
+// token is a structured field token,
+// kept distinct from a structured string.
+type token string
+
+// param is a single structured field parameter.
+type param struct {
+	key   string
+	value any
+}
+
+// item is a structured field item, ie.,
+// a bare item with optional parameters.
+type item struct {
+	value  any // string, token, int64, bool or []byte
+	params []param
+}
+
+// member is a structured field dictionary member,
+// whose value is either an item or an inner list.
+type member struct {
+	key    string
+	value  item   // set if not inner list.
+	list   []item // set if inner list.
+	inner  bool   // whether value is inner list.
+	params []param
+}
+
+// getParam returns the value of the parameter
+// with the given key from params, if present.
+func getParam(params []param, key string) (any, bool) {
+	for _, p := range params {
+		if p.key == key {
+			return p.value, true
+		}
+	}
+	return nil, false
+}
+
+// parser wraps a structured
+// field value being parsed.
+type parser struct {
+	s string
+	i int
+}
+
+func (p *parser) eof() bool { return p.i >= len(p.s) }
+
+func (p *parser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.i]
+}
+
+// skipSP skips any spaces.
+func (p *parser) skipSP() {
+	for !p.eof() && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+// skipOWS skips any optional whitespace.
+func (p *parser) skipOWS() {
+	for !p.eof() && (p.s[p.i] == ' ' || p.s[p.i] == '\t') {
+		p.i++
+	}
+}
+
+// parseDictionary parses the given field value as a
+// structured field dictionary, returning its members
+// in order. As per RFC 8941, where a key is repeated
+// the last value wins, but keeps the first position.
+func parseDictionary(s string) ([]member, error) {
+	p := &parser{s: s}
+	p.skipSP()
+
+	var members []member
+	for !p.eof() {
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		m := member{key: key}
+		if p.peek() == '=' {
+			p.i++
+			if p.peek() == '(' {
+				m.inner = true
+				m.list, m.params, err = p.parseInnerList()
+			} else {
+				m.value, err = p.parseItem()
+			}
+		} else {
+			// Bare key means boolean true.
+			m.value.value = true
+			m.value.params, err = p.parseParams()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		replaced := false
+		for i := range members {
+			if members[i].key == key {
+				members[i] = m
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			members = append(members, m)
+		}
+
+		p.skipOWS()
+		if p.eof() {
+			break
+		}
+
+		if p.peek() != ',' {
+			return nil, errors.New("expected comma between dictionary members")
+		}
+		p.i++
+
+		p.skipOWS()
+		if p.eof() {
+			return nil, errors.New("trailing comma in dictionary")
+		}
+	}
+
+	return members, nil
+}
+
+// parseInnerList parses an inner list and its parameters.
+func (p *parser) parseInnerList() ([]item, []param, error) {
+	if p.peek() != '(' {
+		return nil, nil, errors.New("expected inner list")
+	}
+	p.i++
+
+	var items []item
+	for !p.eof() {
+		p.skipSP()
+
+		if p.peek() == ')' {
+			p.i++
+			params, err := p.parseParams()
+			return items, params, err
+		}
+
+		it, err := p.parseItem()
+		if err != nil {
+			return nil, nil, err
+		}
+		items = append(items, it)
+
+		if c := p.peek(); c != ' ' && c != ')' {
+			return nil, nil, errors.New("malformed inner list")
+		}
+	}
+
+	return nil, nil, errors.New("unterminated inner list")
+}
+
+// parseItem parses a bare item and its parameters.
+func (p *parser) parseItem() (item, error) {
+	value, err := p.parseBareItem()
+	if err != nil {
+		return item{}, err
+	}
+
+	params, err := p.parseParams()
+	if err != nil {
+		return item{}, err
+	}
+
+	return item{value: value, params: params}, nil
+}
+
+// parseParams parses a (possibly empty) set of parameters.
+func (p *parser) parseParams() ([]param, error) {
+	var params []param
+	for p.peek() == ';' {
+		p.i++
+		p.skipSP()
+
+		key, err := p.parseKey()
+		if err != nil {
+			return nil, err
+		}
+
+		var value any = true
+		if p.peek() == '=' {
+			p.i++
+			if value, err = p.parseBareItem(); err != nil {
+				return nil, err
+			}
+		}
+
+		params = append(params, param{key: key, value: value})
+	}
+	return params, nil
+}
+
+// parseKey parses a dictionary or parameter key.
+func (p *parser) parseKey() (string, error) {
+	if c := p.peek(); !isLCAlpha(c) && c != '*' {
+		return "", errors.New("malformed key")
+	}
+
+	start := p.i
+	for !p.eof() {
+		c := p.s[p.i]
+		if !isLCAlpha(c) && !isDigit(c) &&
+			c != '_' && c != '-' && c != '.' && c != '*' {
+			break
+		}
+		p.i++
+	}
+	return p.s[start:p.i], nil
+}
+
+// parseBareItem parses a bare item, with no parameters.
+func (p *parser) parseBareItem() (any, error) {
+	switch c := p.peek(); {
+	case c == '-' || isDigit(c):
+		return p.parseInteger()
+	case c == '"':
+		return p.parseString()
+	case c == ':':
+		return p.parseByteSequence()
+	case c == '?':
+		return p.parseBoolean()
+	case isAlpha(c) || c == '*':
+		return p.parseToken()
+	default:
+		return nil, errors.New("unrecognized item")
+	}
+}
+
+func (p *parser) parseInteger() (int64, error) {
+	start := p.i
+	if p.peek() == '-' {
+		p.i++
+	}
+	for !p.eof() && isDigit(p.s[p.i]) {
+		p.i++
+	}
+
+	if p.peek() == '.' {
+		return 0, errors.New("decimals not supported")
+	}
+
+	// Max 15 digits, as per RFC 8941.
+	digits := p.s[start:p.i]
+	if len(strings.TrimPrefix(digits, "-")) > 15 {
+		return 0, errors.New("integer too long")
+	}
+
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+func (p *parser) parseString() (string, error) {
+	p.i++ // skip opening quote
+
+	var b strings.Builder
+	for !p.eof() {
+		c := p.s[p.i]
+		p.i++
+
+		switch {
+		case c == '\\':
+			if p.eof() {
+				return "", errors.New("unterminated string")
+			}
+			c = p.s[p.i]
+			p.i++
+			if c != '"' && c != '\\' {
+				return "", errors.New("invalid escape in string")
+			}
+			b.WriteByte(c)
+		case c == '"':
+			return b.String(), nil
+		case c < 0x20 || c > 0x7e:
+			return "", errors.New("invalid character in string")
+		default:
+			b.WriteByte(c)
+		}
+	}
+
+	return "", errors.New("unterminated string")
+}
+
+func (p *parser) parseByteSequence() ([]byte, error) {
+	p.i++ // skip opening colon
+
+	end := strings.IndexByte(p.s[p.i:], ':')
+	if end < 0 {
+		return nil, errors.New("unterminated byte sequence")
+	}
+
+	b64 := p.s[p.i : p.i+end]
+	p.i += end + 1
+
+	return base64.StdEncoding.DecodeString(b64)
+}
+
+func (p *parser) parseBoolean() (bool, error) {
+	p.i++ // skip question mark
+
+	switch p.peek() {
+	case '1':
+		p.i++
+		return true, nil
+	case '0':
+		p.i++
+		return false, nil
+	default:
+		return false, errors.New("malformed boolean")
+	}
+}
+
+func (p *parser) parseToken() (token, error) {
+	start := p.i
+	for !p.eof() {
+		c := p.s[p.i]
+		if !isTChar(c) && c != ':' && c != '/' {
+			break
+		}
+		p.i++
+	}
+	return token(p.s[start:p.i]), nil
+}
+
+// serializeInnerList serializes the given
+// items + params as an inner list.
+func serializeInnerList(items []item, params []param) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, it := range items {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(serializeItem(it))
+	}
+	b.WriteByte(')')
+	b.WriteString(serializeParams(params))
+	return b.String()
+}
+
+// serializeItem serializes the given item.
+func serializeItem(it item) string {
+	return serializeBareItem(it.value) + serializeParams(it.params)
+}
+
+// serializeParams serializes the given parameters.
+func serializeParams(params []param) string {
+	var b strings.Builder
+	for _, p := range params {
+		b.WriteByte(';')
+		b.WriteString(p.key)
+		if v, ok := p.value.(bool); ok && v {
+			// True is implied
+			// by a bare key.
+			continue
+		}
+		b.WriteByte('=')
+		b.WriteString(serializeBareItem(p.value))
+	}
+	return b.String()
+}
+
+// serializeBareItem serializes the given bare item value.
+func serializeBareItem(v any) string {
+	switch v := v.(type) {
+	case string:
+		r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+		return `"` + r.Replace(v) + `"`
+	case token:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		if v {
+			return "?1"
+		}
+		return "?0"
+	case []byte:
+		return ":" + base64.StdEncoding.EncodeToString(v) + ":"
+	default:
+		panic("unsupported structured field value")
+	}
+}
+
+func isLCAlpha(c byte) bool { return c >= 'a' && c <= 'z' }
+
+func isAlpha(c byte) bool { return isLCAlpha(c) || (c >= 'A' && c <= 'Z') }
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isTChar(c byte) bool {
+	return isAlpha(c) || isDigit(c) || strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0
+}
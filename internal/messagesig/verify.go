@@ -0,0 +1,268 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package messagesig
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// maxAge is the maximum age of a signature
+	// (from its created parameter) that's valid.
+	maxAge = time.Hour
+
+	// maxSkew is how far in the future a signature
+	// may have been created, to allow for clock skew.
+	maxSkew = 5 * time.Minute
+)
+
+// Verifier wraps one RFC 9421 signature of an incoming request,
+// for verification against the public key identified by KeyID().
+type Verifier struct {
+	keyID      string
+	alg        Algorithm
+	created    time.Time
+	expires    time.Time
+	components map[string]struct{}
+	hasBody    bool
+	base       []byte
+	signature  []byte
+}
+
+// NewVerifier prepares verification of the RFC 9421 signature
+// on the given incoming request, received on scheme (ie., http or
+// https), which is needed to reconstruct the request target URI.
+// If the request has multiple signatures, the first one is used.
+//
+// ErrNotSigned is returned if the request has no RFC 9421 signature,
+// and any other error indicates a malformed or unusable signature.
+//
+// If the signature covers the Content-Digest header, the request
+// body will be read and checked against it, and then replaced so
+// that it can be read again further down the line.
+func NewVerifier(r *http.Request, scheme string) (*Verifier, error) {
+	input := strings.Join(r.Header.Values(SignatureInputHeader), ", ")
+	if input == "" {
+		return nil, ErrNotSigned
+	}
+
+	inputs, err := parseDictionary(input)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", SignatureInputHeader, err)
+	}
+
+	signatures, err := parseDictionary(strings.Join(r.Header.Values(SignatureHeader), ", "))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing %s: %w", SignatureHeader, err)
+	}
+
+	// Find first signature input
+	// with a matching signature.
+	var (
+		sigInput  *member
+		signature []byte
+	)
+	for i := range inputs {
+		if !inputs[i].inner {
+			continue
+		}
+
+		for _, s := range signatures {
+			if s.key != inputs[i].key || s.inner {
+				continue
+			}
+
+			if b, ok := s.value.value.([]byte); ok {
+				sigInput, signature = &inputs[i], b
+				break
+			}
+		}
+
+		if sigInput != nil {
+			break
+		}
+	}
+
+	if sigInput == nil {
+		return nil, errors.New("no signature matching signature input")
+	}
+
+	v := &Verifier{
+		components: make(map[string]struct{}, len(sigInput.list)),
+		hasBody:    r.ContentLength != 0,
+		signature:  signature,
+	}
+
+	if keyID, ok := getParam(sigInput.params, "keyid"); ok {
+		v.keyID, _ = keyID.(string)
+	}
+	if v.keyID == "" {
+		return nil, errors.New("signature has no keyid")
+	}
+
+	if alg, ok := getParam(sigInput.params, "alg"); ok {
+		s, _ := alg.(string)
+		v.alg = Algorithm(s)
+	}
+
+	if created, ok := getParam(sigInput.params, "created"); ok {
+		i, ok := created.(int64)
+		if !ok {
+			return nil, errors.New("signature created was not an integer")
+		}
+		v.created = time.Unix(i, 0)
+	}
+
+	if expires, ok := getParam(sigInput.params, "expires"); ok {
+		i, ok := expires.(int64)
+		if !ok {
+			return nil, errors.New("signature expires was not an integer")
+		}
+		v.expires = time.Unix(i, 0)
+	}
+
+	for _, c := range sigInput.list {
+		if name, ok := c.value.(string); ok {
+			v.components[name] = struct{}{}
+		}
+	}
+
+	target, err := incomingRequestTarget(r, scheme)
+	if err != nil {
+		return nil, fmt.Errorf("error reconstructing target uri: %w", err)
+	}
+
+	base, _, err := signatureBase(r, target, sigInput.list, sigInput.params)
+	if err != nil {
+		return nil, err
+	}
+	v.base = []byte(base)
+
+	if v.Covers("content-digest") {
+		if err := checkContentDigest(r); err != nil {
+			return nil, err
+		}
+	}
+
+	return v, nil
+}
+
+// KeyID returns the keyid parameter of the signature.
+func (v *Verifier) KeyID() string {
+	return v.keyID
+}
+
+// Algorithm returns the alg parameter of
+// the signature, if set, else empty string.
+func (v *Verifier) Algorithm() Algorithm {
+	return v.alg
+}
+
+// Covers returns whether the signature
+// covers the given component identifier.
+func (v *Verifier) Covers(component string) bool {
+	_, ok := v.components[component]
+	return ok
+}
+
+// Validate checks that the signature is still valid as of
+// now, and that it covers enough of the request that it
+// can't be replayed against another target: ie., method,
+// target URI, and the Content-Digest if there's a body.
+func (v *Verifier) Validate(now time.Time) error {
+	switch {
+	case v.created.IsZero():
+		return errors.New("signature has no created time")
+	case now.Sub(v.created) > maxAge:
+		return errors.New("signature too old")
+	case v.created.Sub(now) > maxSkew:
+		return errors.New("signature created in the future")
+	case !v.expires.IsZero() && now.After(v.expires):
+		return errors.New("signature expired")
+	}
+
+	if !v.Covers("@method") {
+		return errors.New("signature does not cover @method")
+	}
+
+	if !v.Covers("@target-uri") &&
+		!(v.Covers("@authority") && v.Covers("@request-target")) &&
+		!(v.Covers("@authority") && v.Covers("@path") && v.Covers("@query")) {
+		return errors.New("signature does not cover target uri")
+	}
+
+	if v.hasBody && !v.Covers("content-digest") {
+		return errors.New("signature does not cover content-digest")
+	}
+
+	return nil
+}
+
+// Verify verifies the signature using the given public key,
+// which must be an ed25519.PublicKey or *rsa.PublicKey. If the
+// signature has no alg parameter, the algorithm to use is taken
+// from the key type, trying RSA algorithms in order of popularity.
+func (v *Verifier) Verify(pubKey crypto.PublicKey) error {
+	switch k := pubKey.(type) {
+	case ed25519.PublicKey:
+		if v.alg != "" && v.alg != ED25519 {
+			return fmt.Errorf("algorithm %q does not match ed25519 key", v.alg)
+		}
+		if !ed25519.Verify(k, v.base, v.signature) {
+			return errors.New("ed25519 signature invalid")
+		}
+		return nil
+
+	case *rsa.PublicKey:
+		switch v.alg {
+		case RSA_V1_5_SHA256:
+			return verifyRSAv15SHA256(k, v.base, v.signature)
+		case RSA_PSS_SHA512:
+			return verifyRSAPSSSHA512(k, v.base, v.signature)
+		case "":
+			if verifyRSAv15SHA256(k, v.base, v.signature) == nil {
+				return nil
+			}
+			return verifyRSAPSSSHA512(k, v.base, v.signature)
+		default:
+			return fmt.Errorf("algorithm %q does not match rsa key", v.alg)
+		}
+
+	default:
+		return fmt.Errorf("unsupported public key type %T", pubKey)
+	}
+}
+
+func verifyRSAv15SHA256(k *rsa.PublicKey, base []byte, signature []byte) error {
+	sum := sha256.Sum256(base)
+	return rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], signature)
+}
+
+func verifyRSAPSSSHA512(k *rsa.PublicKey, base []byte, signature []byte) error {
+	sum := sha512.Sum512(base)
+	return rsa.VerifyPSS(k, crypto.SHA512, sum[:], signature, pssOptions)
+}
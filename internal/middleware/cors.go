@@ -86,5 +86,19 @@ func CORS() gin.HandlerFunc {
 		MaxAge: 2 * time.Minute,
 	}
 
+	// Expose headers of the rate limit
+	// policies that apply to the client API.
+	for _, name := range []string{
+		RateLimitPolicyClient,
+		RateLimitPolicyAccount,
+		RateLimitPolicyWrite,
+		RateLimitPolicySearch,
+		RateLimitPolicyMedia,
+		RateLimitPolicyStatuses,
+	} {
+		limit, remaining, reset := RateLimitHeaders(name)
+		cfg.ExposeHeaders = append(cfg.ExposeHeaders, reset, limit, remaining)
+	}
+
 	return cors.New(cfg)
 }
@@ -22,10 +22,14 @@ import (
 	"net/http"
 	"net/netip"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/ratelimit"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/ulule/limiter/v3"
 	"github.com/ulule/limiter/v3/drivers/store/memory"
@@ -35,6 +39,39 @@ import (
 
 const rateLimitPeriod = 5 * time.Minute
 
+// Names of the rate limit policies applied
+// by GoToSocial, as used in response headers
+// and in the admin view of throttled clients.
+const (
+	RateLimitPolicyClient     = "client"     // client API + auth, per IP
+	RateLimitPolicyFederation = "federation" // server-to-server, per IP
+	RateLimitPolicyFileserver = "fileserver" // fileserver + web, per IP
+	RateLimitPolicyEmojis     = "emojis"     // emoji fileserver, per IP
+	RateLimitPolicyAccount    = "account"    // client API, per account
+	RateLimitPolicyWrite      = "write"      // client API writes
+	RateLimitPolicySearch     = "search"     // client API search
+	RateLimitPolicyMedia      = "media"      // client API media uploads
+	RateLimitPolicyStatuses   = "statuses"   // client API status creation
+)
+
+// RateLimitPolicy describes one bucket of
+// requests to rate limit independently.
+type RateLimitPolicy struct {
+	// Name of the policy. If set, the policy's limits
+	// are also returned in `X-RateLimit-<Name>-*` headers,
+	// and throttled clients are recorded under this name.
+	Name string
+
+	// Limit is the amount of requests to permit
+	// per client within a 5 minute window. 0
+	// or less turns this policy off.
+	Limit int
+
+	// Match returns whether the request falls
+	// under this policy. If nil, all requests do.
+	Match func(c *gin.Context) bool
+}
+
 // RateLimit returns a gin middleware that will automatically rate
 // limit caller (by IP address), and enrich the response header with
 // the following headers:
@@ -49,20 +86,66 @@ const rateLimitPeriod = 5 * time.Minute
 // If the config AdvancedRateLimitRequests value is <= 0, then a noop
 // handler will be returned, which performs no rate limiting.
 func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
-	if limit <= 0 {
+	return RateLimitPolicies(nil, exceptions, RateLimitPolicy{Limit: limit})
+}
+
+// RateLimitPolicies is like RateLimit, but checks each request
+// against every given policy that matches it, each policy having
+// its own separate bucket per client.
+//
+// Clients are keyed on their authorized account if the request
+// carries a valid user token, on their application if it carries
+// a valid app-only token, and on their (masked) IP otherwise. Note
+// this means the middleware must run after TokenCheck in order to
+// key on anything other than IP.
+//
+// Named policies set `X-RateLimit-<Name>-Limit`, -Remaining and
+// -Reset headers. The plain `X-RateLimit-*` headers are set to
+// the values of whichever policy has the fewest remaining requests,
+// so that clients unaware of policies still back off appropriately.
+//
+// Throttled requests under named policies are recorded in tracker,
+// if not nil. Any policies with a limit <= 0 are ignored, and if
+// none remain a noop handler is returned.
+func RateLimitPolicies(
+	tracker *ratelimit.Tracker,
+	exceptions []string,
+	policies ...RateLimitPolicy,
+) gin.HandlerFunc {
+	type limitedPolicy struct {
+		RateLimitPolicy
+		limiter *limiter.Limiter
+	}
+
+	limited := make([]limitedPolicy, 0, len(policies))
+	for _, policy := range policies {
+		if policy.Limit <= 0 {
+			// Policy is disabled.
+			continue
+		}
+
+		if tracker != nil && policy.Name != "" {
+			tracker.Register(policy.Name, policy.Limit)
+		}
+
+		limited = append(limited, limitedPolicy{
+			RateLimitPolicy: policy,
+			limiter: limiter.New(
+				memory.NewStore(),
+				limiter.Rate{
+					Period: rateLimitPeriod,
+					Limit:  int64(policy.Limit),
+				},
+			),
+		})
+	}
+
+	if len(limited) == 0 {
 		// Rate limiting is disabled.
 		// Return noop middleware.
 		return func(ctx *gin.Context) {}
 	}
 
-	limiter := limiter.New(
-		memory.NewStore(),
-		limiter.Rate{
-			Period: rateLimitPeriod,
-			Limit:  int64(limit),
-		},
-	)
-
 	// Convert exceptions IP ranges into prefixes.
 	exceptPrefs := make([]netip.Prefix, len(exceptions))
 	for i, str := range exceptions {
@@ -106,50 +189,53 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 			clientIP, _ = netip.AddrFromSlice(asIP)
 		}
 
-		// Fetch rate limit info for this (masked) clientIP.
-		context, err := limiter.Get(c, clientIP.String())
-		if err != nil {
-			// Since we use an in-memory cache now,
-			// it's actually impossible for this to
-			// error, but handle it nicely anyway in
-			// case we switch implementation in future.
-			errWithCode := gtserror.NewErrorInternalError(err)
-
-			// Set error on gin context so it'll
-			// be picked up by logging middleware.
-			c.Error(errWithCode) //nolint:errcheck
-
-			// Bail with 500.
-			c.AbortWithStatusJSON(
-				errWithCode.Code(),
-				gin.H{"error": errWithCode.Safe()},
-			)
-			return
-		}
+		// Determine the key to rate limit on.
+		key := rateLimitKey(c, clientIP)
 
-		// Provide reset in same format used by
-		// Mastodon. There's no real standard as
-		// to what format X-RateLimit-Reset SHOULD
-		// use, but since most clients interacting
-		// with us will expect the Mastodon version,
-		// it makes sense to take this.
-		resetT := time.Unix(context.Reset, 0)
-		reset := util.FormatISO8601(resetT)
-
-		c.Header("X-RateLimit-Limit", strconv.FormatInt(context.Limit, 10))
-		c.Header("X-RateLimit-Remaining", strconv.FormatInt(context.Remaining, 10))
-		c.Header("X-RateLimit-Reset", reset)
-
-		if context.Reached {
-			// Return JSON error message for
-			// consistency with other endpoints.
-			apiutil.Data(c,
-				http.StatusTooManyRequests,
-				apiutil.AppJSON,
-				apiutil.ErrorRateLimited,
-			)
-			c.Abort()
-			return
+		for _, policy := range limited {
+			if policy.Match != nil && !policy.Match(c) {
+				// Not applicable.
+				continue
+			}
+
+			// Fetch rate limit info for this client.
+			context, err := policy.limiter.Get(c, key)
+			if err != nil {
+				// Since we use an in-memory cache now,
+				// it's actually impossible for this to
+				// error, but handle it nicely anyway in
+				// case we switch implementation in future.
+				errWithCode := gtserror.NewErrorInternalError(err)
+
+				// Set error on gin context so it'll
+				// be picked up by logging middleware.
+				c.Error(errWithCode) //nolint:errcheck
+
+				// Bail with 500.
+				c.AbortWithStatusJSON(
+					errWithCode.Code(),
+					gin.H{"error": errWithCode.Safe()},
+				)
+				return
+			}
+
+			setRateLimitHeaders(c, policy.Name, context)
+
+			if context.Reached {
+				if tracker != nil && policy.Name != "" {
+					tracker.Throttle(policy.Name, key)
+				}
+
+				// Return JSON error message for
+				// consistency with other endpoints.
+				apiutil.Data(c,
+					http.StatusTooManyRequests,
+					apiutil.AppJSON,
+					apiutil.ErrorRateLimited,
+				)
+				c.Abort()
+				return
+			}
 		}
 
 		// Allow the request
@@ -157,3 +243,71 @@ func RateLimit(limit int, exceptions []string) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// rateLimitKey returns the key to rate limit the
+// request on, in the form "type:id". This is the
+// authorized account or application if available,
+// else the given (already masked) client IP.
+func rateLimitKey(c *gin.Context, clientIP netip.Addr) string {
+	if i, ok := c.Get(oauth.SessionAuthorizedAccount); ok {
+		if account, ok := i.(*gtsmodel.Account); ok {
+			return "account:" + account.ID
+		}
+	}
+
+	if i, ok := c.Get(oauth.SessionAuthorizedApplication); ok {
+		if app, ok := i.(*gtsmodel.Application); ok {
+			return "application:" + app.ClientID
+		}
+	}
+
+	return "ip:" + clientIP.String()
+}
+
+// RateLimitHeaders returns the names of the limit,
+// remaining and reset headers for the named policy.
+func RateLimitHeaders(name string) (limit, remaining, reset string) {
+	prefix := "X-RateLimit-"
+	if name != "" {
+		prefix += strings.ToUpper(name[:1]) + name[1:] + "-"
+	}
+	return prefix + "Limit", prefix + "Remaining", prefix + "Reset"
+}
+
+// setRateLimitHeaders sets rate limit headers on the response
+// for the named policy, and updates the plain rate limit headers
+// if this policy has fewer requests remaining than any before it.
+func setRateLimitHeaders(c *gin.Context, name string, context limiter.Context) {
+	// Provide reset in same format used by
+	// Mastodon. There's no real standard as
+	// to what format X-RateLimit-Reset SHOULD
+	// use, but since most clients interacting
+	// with us will expect the Mastodon version,
+	// it makes sense to take this.
+	resetT := time.Unix(context.Reset, 0)
+	reset := util.FormatISO8601(resetT)
+
+	limitStr := strconv.FormatInt(context.Limit, 10)
+	remainingStr := strconv.FormatInt(context.Remaining, 10)
+
+	if name != "" {
+		limitH, remainingH, resetH := RateLimitHeaders(name)
+		c.Header(limitH, limitStr)
+		c.Header(remainingH, remainingStr)
+		c.Header(resetH, reset)
+	}
+
+	limitH, remainingH, resetH := RateLimitHeaders("")
+	if prev := c.Writer.Header().Get(remainingH); prev != "" {
+		prevRemaining, err := strconv.ParseInt(prev, 10, 64)
+		if err == nil && prevRemaining <= context.Remaining {
+			// An earlier policy
+			// is more constrained.
+			return
+		}
+	}
+
+	c.Header(limitH, limitStr)
+	c.Header(remainingH, remainingStr)
+	c.Header(resetH, reset)
+}
@@ -26,7 +26,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/middleware"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/ratelimit"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -187,6 +190,124 @@ func (suite *RateLimitTestSuite) TestRateLimit() {
 	}
 }
 
+func (suite *RateLimitTestSuite) TestRateLimitPolicies() {
+	// Suppress warnings about debug mode.
+	gin.SetMode(gin.ReleaseMode)
+
+	const trustedPlatform = "X-Test-IP"
+
+	var (
+		tracker = new(ratelimit.Tracker)
+		account = &gtsmodel.Account{ID: "01F8MH1H7YV1Z7D2C8K2730QBF"}
+	)
+
+	rlMiddleware := middleware.RateLimitPolicies(
+		tracker,
+		nil,
+		middleware.RateLimitPolicy{
+			Name:  middleware.RateLimitPolicyAccount,
+			Limit: 3,
+			Match: func(c *gin.Context) bool {
+				_, ok := c.Get(oauth.SessionAuthorizedAccount)
+				return ok
+			},
+		},
+		middleware.RateLimitPolicy{
+			Name:  middleware.RateLimitPolicyWrite,
+			Limit: 2,
+			Match: func(c *gin.Context) bool {
+				return c.Request.Method == http.MethodPost
+			},
+		},
+		middleware.RateLimitPolicy{
+			// Disabled, should never apply.
+			Name:  middleware.RateLimitPolicySearch,
+			Limit: 0,
+		},
+	)
+
+	request := func(method string, clientIP string, account *gtsmodel.Account) *httptest.ResponseRecorder {
+		var (
+			recorder = httptest.NewRecorder()
+			ctx, e   = gin.CreateTestContext(recorder)
+		)
+
+		e.TrustedPlatform = trustedPlatform
+		ctx.Request = httptest.NewRequest(method, "/example", nil)
+		ctx.Request.Header.Add(trustedPlatform, clientIP)
+		if account != nil {
+			ctx.Set(oauth.SessionAuthorizedAccount, account)
+		}
+
+		rlMiddleware(ctx)
+		return recorder
+	}
+
+	// Unauthenticated reads aren't
+	// covered by any policy here.
+	recorder := request(http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("X-RateLimit-Limit"))
+
+	// Authenticated reads are limited per
+	// account, no matter the IP they come from.
+	for i, clientIP := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		recorder := request(http.MethodGet, clientIP, account)
+		suite.Equal(http.StatusOK, recorder.Code)
+		suite.Equal("3", recorder.Header().Get("X-RateLimit-Account-Limit"))
+		suite.Equal(strconv.Itoa(2-i), recorder.Header().Get("X-RateLimit-Account-Remaining"))
+		suite.Equal(strconv.Itoa(2-i), recorder.Header().Get("X-RateLimit-Remaining"))
+		suite.Empty(recorder.Header().Get("X-RateLimit-Write-Limit"))
+	}
+
+	recorder = request(http.MethodGet, "192.0.2.4", account)
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+
+	// Writes have their own bucket, keyed
+	// on IP when not authenticated. The plain
+	// headers follow the write bucket, as
+	// it's the only one that applies.
+	for i := range 2 {
+		recorder := request(http.MethodPost, "192.0.2.0", nil)
+		suite.Equal(http.StatusOK, recorder.Code)
+		suite.Equal("2", recorder.Header().Get("X-RateLimit-Write-Limit"))
+		suite.Equal(strconv.Itoa(1-i), recorder.Header().Get("X-RateLimit-Write-Remaining"))
+		suite.Equal("2", recorder.Header().Get("X-RateLimit-Limit"))
+		suite.Equal(strconv.Itoa(1-i), recorder.Header().Get("X-RateLimit-Remaining"))
+	}
+
+	recorder = request(http.MethodPost, "192.0.2.0", nil)
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+
+	// Reads from the same IP still allowed.
+	recorder = request(http.MethodGet, "192.0.2.0", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	// Throttled clients should be recorded
+	// under the policy that throttled them.
+	policies := tracker.Policies()
+	if !suite.Len(policies, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal(middleware.RateLimitPolicyAccount, policies[0].Name)
+	suite.Equal(3, policies[0].Limit)
+	suite.EqualValues(1, policies[0].Throttled)
+	if suite.Len(policies[0].Clients, 1) {
+		suite.Equal("account", policies[0].Clients[0].Type)
+		suite.Equal(account.ID, policies[0].Clients[0].ID)
+		suite.EqualValues(1, policies[0].Clients[0].Throttled)
+	}
+
+	suite.Equal(middleware.RateLimitPolicyWrite, policies[1].Name)
+	suite.Equal(2, policies[1].Limit)
+	suite.EqualValues(1, policies[1].Throttled)
+	if suite.Len(policies[1].Clients, 1) {
+		suite.Equal("ip", policies[1].Clients[0].Type)
+		suite.Equal("192.0.2.0", policies[1].Clients[0].ID)
+	}
+}
+
 func TestRateLimitTestSuite(t *testing.T) {
 	suite.Run(t, new(RateLimitTestSuite))
 }
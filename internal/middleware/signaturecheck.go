@@ -21,9 +21,12 @@ import (
 	"context"
 	"net/http"
 	"net/url"
+	"time"
 
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/httpsig"
@@ -39,7 +42,8 @@ const (
 // SignatureCheck returns a gin middleware for checking http signatures.
 //
 // The middleware first checks whether an incoming http request has been
-// http-signed with a well-formed signature. If so, it will check if the
+// http-signed with a well-formed signature, either using draft-cavage http
+// signatures, or RFC 9421 http message signatures. If so, it will check if the
 // domain that signed the request is permitted to access the server, using
 // the provided uriBlocked function. If the domain is blocked, the middleware
 // will abort the request chain with http code 403 forbidden. If it is not
@@ -51,28 +55,55 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 	return func(c *gin.Context) {
 		ctx := c.Request.Context()
 
-		// Create the signature verifier from the request;
-		// this will error if the request wasn't signed.
-		verifier, err := httpsig.NewVerifier(c.Request)
-		if err != nil {
-			// Only actually *abort* the request with 401
-			// if a signature was present but malformed.
-			// Otherwise proceed with an unsigned request;
-			// it's up to other functions to reject this.
-			if err.Error() != noSigError {
-				log.Debugf(ctx, "http signature was present but invalid: %s", err)
+		// Requests signed using RFC 9421 http message
+		// signatures are told apart by Signature-Input.
+		var msgVerifier *messagesig.Verifier
+		var verifier httpsig.VerifierWithOptions
+		var pubKeyIDStr string
+
+		if c.GetHeader(messagesig.SignatureInputHeader) != "" {
+			// Create the message signature verifier from
+			// the request, and check signature parameters
+			// and covered components are acceptable to us.
+			var err error
+			msgVerifier, err = messagesig.NewVerifier(c.Request, config.GetProtocol())
+			if err == nil {
+				err = msgVerifier.Validate(time.Now())
+			}
+
+			if err != nil {
+				log.Debugf(ctx, "http message signature was present but invalid: %s", err)
 				c.AbortWithStatus(http.StatusUnauthorized)
+				return
 			}
 
-			return
+			pubKeyIDStr = msgVerifier.KeyID()
+		} else {
+			// Create the signature verifier from the request;
+			// this will error if the request wasn't signed.
+			var err error
+			verifier, err = httpsig.NewVerifier(c.Request)
+			if err != nil {
+				// Only actually *abort* the request with 401
+				// if a signature was present but malformed.
+				// Otherwise proceed with an unsigned request;
+				// it's up to other functions to reject this.
+				if err.Error() != noSigError {
+					log.Debugf(ctx, "http signature was present but invalid: %s", err)
+					c.AbortWithStatus(http.StatusUnauthorized)
+				}
+
+				return
+			}
+
+			pubKeyIDStr = verifier.KeyId()
 		}
 
 		// The request was signed! The key ID should be given
 		// in the signature so that we know where to fetch it
 		// from the remote server. This will be something like:
 		// https://example.org/users/some_remote_user#main-key
-		pubKeyIDStr := verifier.KeyId()
-
+		//
 		// Key can sometimes be nil, according to url parse
 		// func: 'Trying to parse a hostname and path without
 		// a scheme is invalid but may not necessarily return
@@ -108,7 +139,11 @@ func SignatureCheck(uriBlocked func(context.Context, *url.URL) (bool, error)) fu
 
 		// Set relevant values on the request context
 		// to save some work further down the line.
-		ctx = gtscontext.SetHTTPSignatureVerifier(ctx, verifier)
+		if msgVerifier != nil {
+			ctx = gtscontext.SetHTTPMessageSignatureVerifier(ctx, msgVerifier)
+		} else {
+			ctx = gtscontext.SetHTTPSignatureVerifier(ctx, verifier)
+		}
 		ctx = gtscontext.SetHTTPSignature(ctx, signature)
 		ctx = gtscontext.SetHTTPSignaturePubKeyID(ctx, pubKeyID)
 
@@ -18,7 +18,9 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -42,6 +44,9 @@ import (
 // Next, it will look up the *gtsmodel.Account for the User. If the Account has been suspended, then the
 // middleware will return early. Otherwise, it will set the Account on the gin context too.
 //
+// It will also record (approximately) when the token was last used, so that users can
+// review the tokens held on their account and spot any that are being used unexpectedly.
+//
 // Finally, it will check the client ID of the token to see if a *gtsmodel.Application can be retrieved
 // for that client ID. This will also be set on the gin context.
 //
@@ -65,6 +70,9 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 		c.Set(oauth.SessionAuthorizedToken, ti)
 
+		// Note when this token was last used.
+		touchToken(ctx, dbConn, ti.GetAccess())
+
 		// check for user-level token
 		if userID := ti.GetUserID(); userID != "" {
 			log.Tracef(ctx, "authenticated user %s with bearer token, scope is %s", userID, ti.GetScope())
@@ -138,3 +146,34 @@ func TokenCheck(dbConn db.DB, validateBearerToken func(r *http.Request) (oauth2.
 		}
 	}
 }
+
+// tokenLastUsedInterval is the granularity at which we record the
+// last used time of a token. Only recording it when the stored value
+// is older than this keeps the cost down to a cached lookup for most
+// requests, rather than a database write on every single one.
+const tokenLastUsedInterval = 15 * time.Minute
+
+// touchToken updates the last used time of the
+// token with the given access value, if stale.
+func touchToken(ctx context.Context, dbConn db.DB, access string) {
+	if access == "" {
+		return
+	}
+
+	token, err := dbConn.GetTokenByAccess(ctx, access)
+	if err != nil {
+		log.Errorf(ctx, "db error getting token: %v", err)
+		return
+	}
+
+	now := time.Now()
+	if now.Sub(token.LastUsed) < tokenLastUsedInterval {
+		// Recent enough.
+		return
+	}
+
+	token.LastUsed = now
+	if err := dbConn.UpdateToken(ctx, token, "last_used"); err != nil {
+		log.Errorf(ctx, "db error updating token last used: %v", err)
+	}
+}
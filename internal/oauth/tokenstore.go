@@ -80,6 +80,9 @@ func (ts *tokenStore) sweep(ctx context.Context) error {
 		// we only want to check if a token expired before now if the expiry time is *not zero*;
 		// ie., if it's been explicity set.
 		if !dbt.CodeExpiresAt.IsZero() && dbt.CodeExpiresAt.Before(now) || !dbt.RefreshExpiresAt.IsZero() && dbt.RefreshExpiresAt.Before(now) || !dbt.AccessExpiresAt.IsZero() && dbt.AccessExpiresAt.Before(now) {
+			if err := ts.db.DeleteWebPushSubscriptionByTokenID(ctx, dbt.ID); err != nil {
+				return err
+			}
 			if err := ts.db.DeleteTokenByID(ctx, dbt.ID); err != nil {
 				return err
 			}
@@ -111,19 +114,46 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 
 // RemoveByCode deletes a token from the DB based on the Code field
 func (ts *tokenStore) RemoveByCode(ctx context.Context, code string) error {
+	token, err := ts.db.GetTokenByCode(ctx, code)
+	if err := ts.removeWebPushSubscription(ctx, token, err); err != nil {
+		return err
+	}
 	return ts.db.DeleteTokenByCode(ctx, code)
 }
 
 // RemoveByAccess deletes a token from the DB based on the Access field
 func (ts *tokenStore) RemoveByAccess(ctx context.Context, access string) error {
+	token, err := ts.db.GetTokenByAccess(ctx, access)
+	if err := ts.removeWebPushSubscription(ctx, token, err); err != nil {
+		return err
+	}
 	return ts.db.DeleteTokenByAccess(ctx, access)
 }
 
 // RemoveByRefresh deletes a token from the DB based on the Refresh field
 func (ts *tokenStore) RemoveByRefresh(ctx context.Context, refresh string) error {
+	token, err := ts.db.GetTokenByRefresh(ctx, refresh)
+	if err := ts.removeWebPushSubscription(ctx, token, err); err != nil {
+		return err
+	}
 	return ts.db.DeleteTokenByRefresh(ctx, refresh)
 }
 
+// removeWebPushSubscription deletes the Web Push subscription
+// belonging to the given token, if any, since it's useless once
+// the token is gone. It takes the result of looking up the token;
+// a token that's already gone is not an error.
+func (ts *tokenStore) removeWebPushSubscription(ctx context.Context, token *gtsmodel.Token, err error) error {
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Nothing to do.
+			return nil
+		}
+		return err
+	}
+	return ts.db.DeleteWebPushSubscriptionByTokenID(ctx, token.ID)
+}
+
 // GetByCode selects a token from the DB based on the Code field
 func (ts *tokenStore) GetByCode(ctx context.Context, code string) (oauth2.TokenInfo, error) {
 	token, err := ts.db.GetTokenByCode(ctx, code)
@@ -0,0 +1,657 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+const (
+	// archiveInterval is the minimum time
+	// between archive requests by one account,
+	// since generating an archive is expensive.
+	archiveInterval = 24 * time.Hour
+
+	// archivePageSize is the number of items
+	// fetched from the db at a time while
+	// generating an archive.
+	archivePageSize = 100
+
+	// archiveMediaDir is the directory within the
+	// archive that media attachments are put in,
+	// matching the layout of Mastodon archives.
+	archiveMediaDir = "media_attachments/files/"
+)
+
+// ArchivesGet returns the requester's
+// personal archives, newest first.
+func (p *Processor) ArchivesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Archive, gtserror.WithCode) {
+	archives, err := p.state.DB.GetArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiArchives := make([]*apimodel.Archive, 0, len(archives))
+	for _, archive := range archives {
+		apiArchives = append(apiArchives, p.converter.ArchiveToAPIArchive(archive))
+	}
+
+	return apiArchives, nil
+}
+
+// ArchiveCreate requests a new personal archive of
+// the requester's data. The archive is generated
+// asynchronously, and replaces any previous archives
+// of the requester once it has finished generating.
+func (p *Processor) ArchiveCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) (*apimodel.Archive, gtserror.WithCode) {
+	prevArchives, err := p.state.DB.GetArchivesByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archives: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Archives that are still unprocessed after
+	// the interval are presumed to have been lost
+	// to a restart, and are replaced like any other.
+	if len(prevArchives) != 0 &&
+		time.Since(prevArchives[0].CreatedAt) < archiveInterval {
+		var text string
+		if !prevArchives[0].Processed() {
+			text = "an archive is already being generated, please wait for it to finish"
+		} else {
+			text = "you can only request an archive once every 24 hours"
+		}
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	archive := &gtsmodel.Archive{
+		ID:        id.NewULID(),
+		CreatedAt: time.Now(),
+		AccountID: requester.ID,
+	}
+
+	if err := p.state.DB.PutArchive(ctx, archive); err != nil {
+		err = gtserror.Newf("db error putting archive: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Do the actual generation asynchronously.
+	f := archiveAsyncF(p, requester, archive, prevArchives)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return p.converter.ArchiveToAPIArchive(archive), nil
+}
+
+// ArchiveFileGet returns the zip file of the
+// requester's processed archive with the given ID.
+func (p *Processor) ArchiveFileGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archiveID string,
+) (*apimodel.Content, gtserror.WithCode) {
+	archive, err := p.state.DB.GetArchiveByID(ctx, archiveID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting archive %s: %w", archiveID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if archive == nil || archive.AccountID != requester.ID {
+		err := fmt.Errorf("archive %s not found", archiveID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if !archive.Processed() {
+		const text = "archive is still being generated"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	rc, err := p.state.Storage.GetStream(ctx, archive.FilePath)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			err := fmt.Errorf("archive %s file not found in storage", archiveID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		err = gtserror.Newf("error getting archive %s file: %w", archiveID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.Content{
+		ContentType:    "application/zip",
+		ContentLength:  int64(archive.FileSize),
+		ContentUpdated: archive.ProcessedAt,
+		Content:        rc,
+	}, nil
+}
+
+func archiveAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	archive *gtsmodel.Archive,
+	prevArchives []*gtsmodel.Archive,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		l := log.WithContext(ctx).
+			WithField("archive", archive.ID)
+
+		if err := p.generateArchive(ctx, requester, archive); err != nil {
+			l.Errorf("error generating archive: %v", err)
+
+			// Remove the failed archive, so that
+			// the requester can try again later.
+			p.deleteArchive(ctx, archive)
+			return
+		}
+
+		// This archive replaces any
+		// that were requested before.
+		for _, prevArchive := range prevArchives {
+			p.deleteArchive(ctx, prevArchive)
+		}
+
+		l.Info("archive generated")
+	}
+}
+
+// deleteArchive deletes the given archive
+// and its file in storage, logging any errors.
+func (p *Processor) deleteArchive(ctx context.Context, archive *gtsmodel.Archive) {
+	if archive.FilePath != "" {
+		if err := p.state.Storage.Delete(ctx, archive.FilePath); // nocollapse
+		err != nil && !storage.IsNotFound(err) {
+			log.Errorf(ctx, "error deleting archive %s file: %v", archive.ID, err)
+		}
+	}
+
+	if err := p.state.DB.DeleteArchiveByID(ctx, archive.ID); err != nil {
+		log.Errorf(ctx, "db error deleting archive %s: %v", archive.ID, err)
+	}
+}
+
+// generateArchive writes an archive of the requester's data
+// to a temporary zip file, moves it into storage, and marks
+// the archive as processed.
+func (p *Processor) generateArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archive *gtsmodel.Archive,
+) error {
+	// Refetch the requester, so that
+	// the account is fully populated and
+	// up to date when the archive is written.
+	account, err := p.state.DB.GetAccountByID(ctx, requester.ID)
+	if err != nil {
+		return gtserror.Newf("db error getting account: %w", err)
+	}
+
+	tmp, err := os.CreateTemp("", "gotosocial-archive-*.zip")
+	if err != nil {
+		return gtserror.Newf("error creating temp file: %w", err)
+	}
+	defer func() {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+	}()
+
+	zw := zip.NewWriter(tmp)
+	if err := p.writeArchive(ctx, zw, account); err != nil {
+		return err
+	}
+
+	if err := zw.Close(); err != nil {
+		return gtserror.Newf("error finishing zip: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return gtserror.Newf("error closing temp file: %w", err)
+	}
+
+	archive.FilePath = uris.StoragePathForArchive(account.ID, archive.ID)
+	size, err := p.state.Storage.PutFile(ctx, archive.FilePath, tmp.Name())
+	if err != nil {
+		return gtserror.Newf("error putting archive in storage: %w", err)
+	}
+
+	archive.FileSize = int(size)
+	archive.ProcessedAt = time.Now()
+	if err := p.state.DB.UpdateArchive(ctx, archive,
+		"file_path",
+		"file_size",
+		"processed_at",
+	); err != nil {
+		return gtserror.Newf("db error updating archive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchive writes the account's data into zw, using
+// the same layout as Mastodon archives: the account as
+// actor.json, statuses as outbox.json, faved and bookmarked
+// status URIs as likes.json and bookmarks.json, alongside
+// the media files that these refer to.
+func (p *Processor) writeArchive(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) error {
+	// Media files are written last, as only
+	// one file in a zip can be written at once.
+	var media []archiveMedia
+
+	actor, actorMedia, err := p.archiveActor(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error converting account: %w", err)
+	}
+	media = append(media, actorMedia...)
+
+	if err := writeArchiveJSON(zw, "actor.json", actor); err != nil {
+		return gtserror.Newf("error writing actor: %w", err)
+	}
+
+	outboxMedia, err := p.writeArchiveOutbox(ctx, zw, account)
+	if err != nil {
+		return gtserror.Newf("error writing outbox: %w", err)
+	}
+	media = append(media, outboxMedia...)
+
+	likes, err := p.archiveLikes(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error getting likes: %w", err)
+	}
+
+	if err := writeArchiveJSON(zw, "likes.json", archiveCollection("likes.json", likes)); err != nil {
+		return gtserror.Newf("error writing likes: %w", err)
+	}
+
+	bookmarks, err := p.archiveBookmarks(ctx, account)
+	if err != nil {
+		return gtserror.Newf("error getting bookmarks: %w", err)
+	}
+
+	if err := writeArchiveJSON(zw, "bookmarks.json", archiveCollection("bookmarks.json", bookmarks)); err != nil {
+		return gtserror.Newf("error writing bookmarks: %w", err)
+	}
+
+	for _, m := range media {
+		if err := p.writeArchiveMedia(ctx, zw, m); err != nil {
+			return gtserror.Newf("error writing media %s: %w", m.attachment.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// archiveMedia is a media
+// attachment to be written
+// into an archive as name.
+type archiveMedia struct {
+	name       string
+	attachment *gtsmodel.MediaAttachment
+}
+
+// archiveActor serializes the account as an actor,
+// pointing its avatar and header at their files
+// within the archive, which are returned as media.
+func (p *Processor) archiveActor(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) (map[string]interface{}, []archiveMedia, error) {
+	accountable, err := p.converter.AccountToAS(ctx, account)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	actor, err := ap.Serialize(accountable)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var media []archiveMedia
+	for _, img := range []struct {
+		prop       string
+		name       string
+		attachment *gtsmodel.MediaAttachment
+	}{
+		{prop: "icon", name: "avatar", attachment: account.AvatarMediaAttachment},
+		{prop: "image", name: "header", attachment: account.HeaderMediaAttachment},
+	} {
+		obj, ok := actor[img.prop].(map[string]interface{})
+		if !ok || img.attachment == nil || img.attachment.File.Path == "" {
+			continue
+		}
+
+		name := img.name + path.Ext(img.attachment.File.Path)
+		media = append(media, archiveMedia{name: name, attachment: img.attachment})
+		obj["url"] = name
+	}
+
+	return actor, media, nil
+}
+
+// writeArchiveOutbox streams all of the account's statuses
+// into outbox.json oldest first, as an OrderedCollection of
+// Create activities (or Announce activities for boosts).
+// Attachments are pointed at their files within the archive,
+// which are returned as media.
+func (p *Processor) writeArchiveOutbox(
+	ctx context.Context,
+	zw *zip.Writer,
+	account *gtsmodel.Account,
+) ([]archiveMedia, error) {
+	w, err := zw.Create("outbox.json")
+	if err != nil {
+		return nil, err
+	}
+
+	// Outboxes can be very large, so rather than building
+	// the whole collection in memory, write it page by page.
+	if _, err := io.WriteString(w, `{"@context":"https://www.w3.org/ns/activitystreams",`+
+		`"id":"outbox.json","type":"OrderedCollection","orderedItems":[`); err != nil {
+		return nil, err
+	}
+
+	var (
+		media []archiveMedia
+		total int
+		minID = id.Lowest
+		enc   = json.NewEncoder(w)
+	)
+
+	for {
+		// Page up through statuses, oldest first.
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			archivePageSize,
+			false, // excludeReplies
+			false, // excludeReblogs
+			"",    // maxID
+			minID,
+			false, // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting statuses: %w", err)
+		}
+
+		if len(statuses) == 0 {
+			break
+		}
+
+		// Pages are returned newest
+		// first, so iterate backwards.
+		for i := len(statuses) - 1; i >= 0; i-- {
+			status := statuses[i]
+
+			item, itemMedia, err := p.archiveOutboxItem(ctx, account, status)
+			if err != nil {
+				// Don't fail the whole archive
+				// because of one broken status.
+				log.Warnf(ctx, "error converting status %s: %v", status.ID, err)
+				continue
+			}
+			media = append(media, itemMedia...)
+
+			if total > 0 {
+				if _, err := io.WriteString(w, ","); err != nil {
+					return nil, err
+				}
+			}
+
+			if err := enc.Encode(item); err != nil {
+				return nil, err
+			}
+			total++
+		}
+
+		minID = statuses[0].ID
+	}
+
+	if _, err := fmt.Fprintf(w, `],"totalItems":%d}`, total); err != nil {
+		return nil, err
+	}
+
+	return media, nil
+}
+
+// archiveOutboxItem serializes the given status as it appears
+// in an archive outbox, returning any media it refers to.
+func (p *Processor) archiveOutboxItem(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	status *gtsmodel.Status,
+) (map[string]interface{}, []archiveMedia, error) {
+	if status.BoostOfID != "" {
+		announce, err := p.converter.BoostToAS(ctx, status, account, status.BoostOfAccount)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		item, err := ap.Serialize(announce)
+		return item, nil, err
+	}
+
+	statusable, err := p.converter.StatusToAS(ctx, status)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	item, err := ap.Serialize(typeutils.WrapStatusableInCreate(statusable, false))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Map attachment URLs to
+	// their names in the archive.
+	var (
+		media = make([]archiveMedia, 0, len(status.Attachments))
+		names = make(map[string]string, len(status.Attachments))
+	)
+
+	for _, attachment := range status.Attachments {
+		if attachment.File.Path == "" {
+			continue
+		}
+
+		name := archiveMediaDir + path.Base(attachment.File.Path)
+		media = append(media, archiveMedia{name: name, attachment: attachment})
+		names[attachment.URL] = name
+	}
+
+	object, _ := item["object"].(map[string]interface{})
+	switch attachments := object["attachment"].(type) {
+	case []interface{}:
+		for _, attachment := range attachments {
+			archiveAttachmentURL(attachment, names)
+		}
+	case map[string]interface{}:
+		archiveAttachmentURL(attachments, names)
+	}
+
+	return item, media, nil
+}
+
+// archiveAttachmentURL replaces the url of the given
+// serialized attachment with its name in the archive.
+func archiveAttachmentURL(attachment interface{}, names map[string]string) {
+	a, ok := attachment.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	url, _ := a["url"].(string)
+	if name, ok := names[url]; ok {
+		a["url"] = name
+	}
+}
+
+// archiveLikes returns the URIs of all statuses
+// faved by the account, most recently faved first.
+func (p *Processor) archiveLikes(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]string, error) {
+	var (
+		statusURIs []string
+		maxID      string
+	)
+
+	for {
+		statuses, nextMaxID, _, err := p.state.DB.GetFavedTimeline(ctx,
+			account.ID,
+			maxID,
+			"", // minID
+			archivePageSize,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if len(statuses) == 0 {
+			return statusURIs, nil
+		}
+
+		for _, status := range statuses {
+			statusURIs = append(statusURIs, status.URI)
+		}
+
+		maxID = nextMaxID
+	}
+}
+
+// archiveBookmarks returns the URIs of all statuses
+// bookmarked by the account, most recently bookmarked first.
+func (p *Processor) archiveBookmarks(
+	ctx context.Context,
+	account *gtsmodel.Account,
+) ([]string, error) {
+	var (
+		statusURIs []string
+		maxID      string
+	)
+
+	for {
+		bookmarks, err := p.state.DB.GetStatusBookmarks(ctx,
+			account.ID,
+			archivePageSize,
+			maxID,
+			"", // minID
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, err
+		}
+
+		if len(bookmarks) == 0 {
+			return statusURIs, nil
+		}
+
+		for _, bookmark := range bookmarks {
+			if bookmark.Status != nil {
+				statusURIs = append(statusURIs, bookmark.Status.URI)
+			}
+		}
+
+		maxID = bookmarks[len(bookmarks)-1].ID
+	}
+}
+
+// archiveCollection returns an OrderedCollection
+// with the given id, containing the given URIs.
+func archiveCollection(collectionID string, statusURIs []string) map[string]interface{} {
+	if statusURIs == nil {
+		// Serialize as an
+		// empty array, not null.
+		statusURIs = []string{}
+	}
+
+	return map[string]interface{}{
+		"@context":     "https://www.w3.org/ns/activitystreams",
+		"id":           collectionID,
+		"type":         "OrderedCollection",
+		"totalItems":   len(statusURIs),
+		"orderedItems": statusURIs,
+	}
+}
+
+// writeArchiveJSON writes v into
+// zw as a JSON file with name.
+func writeArchiveJSON(zw *zip.Writer, name string, v interface{}) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	return json.NewEncoder(w).Encode(v)
+}
+
+// writeArchiveMedia copies the file of the given media from
+// storage into zw. Missing files are logged and skipped.
+func (p *Processor) writeArchiveMedia(
+	ctx context.Context,
+	zw *zip.Writer,
+	m archiveMedia,
+) error {
+	rc, err := p.state.Storage.GetStream(ctx, m.attachment.File.Path)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			log.Warnf(ctx, "file %s not found in storage, leaving it out of archive", m.attachment.File.Path)
+			return nil
+		}
+		return err
+	}
+	defer rc.Close()
+
+	// Media files are compressed
+	// already, so store them as-is.
+	w, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     m.name,
+		Method:   zip.Store,
+		Modified: m.attachment.CreatedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, rc)
+	return err
+}
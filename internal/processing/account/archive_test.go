@@ -0,0 +1,272 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type ArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// runArchiveJob runs the queued archive
+// generation job, as the worker would.
+func (suite *ArchiveTestSuite) runArchiveJob() {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	f, ok := suite.state.Workers.Processing.Queue.PopCtx(ctx)
+	if !suite.True(ok) {
+		suite.FailNow("no archive job queued")
+	}
+	f(context.Background())
+}
+
+// readArchive returns the files in
+// the archive zip, keyed by name.
+func (suite *ArchiveTestSuite) readArchive(archive *gtsmodel.Archive) map[string][]byte {
+	data, err := suite.storage.Get(context.Background(), archive.FilePath)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(data), archive.FileSize)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		files[f.Name] = b
+	}
+
+	return files
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreate() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.False(apiArchive.Processed)
+
+	suite.runArchiveJob()
+
+	archive, err := suite.db.GetArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(archive.Processed())
+
+	files := suite.readArchive(archive)
+
+	// Avatar and header should be
+	// included, and referred to by
+	// their names in the archive.
+	var actor map[string]interface{}
+	if err := json.Unmarshal(files["actor.json"], &actor); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(requester.URI, actor["id"])
+	suite.Equal("avatar.jpeg", actor["icon"].(map[string]interface{})["url"])
+	suite.Equal("header.jpeg", actor["image"].(map[string]interface{})["url"])
+	suite.NotEmpty(files["avatar.jpeg"])
+	suite.NotEmpty(files["header.jpeg"])
+	suite.NotContains(string(files["actor.json"]), "PRIVATE KEY")
+
+	// All statuses should be in the
+	// outbox as Creates or Announces,
+	// oldest first.
+	var outbox struct {
+		Type         string                   `json:"type"`
+		TotalItems   int                      `json:"totalItems"`
+		OrderedItems []map[string]interface{} `json:"orderedItems"`
+	}
+	if err := json.Unmarshal(files["outbox.json"], &outbox); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("OrderedCollection", outbox.Type)
+	suite.Len(outbox.OrderedItems, len(statuses))
+	suite.Equal(len(statuses), outbox.TotalItems)
+
+	var (
+		lastPublished string
+		attachments   int
+	)
+	for _, item := range outbox.OrderedItems {
+		switch item["type"] {
+		case "Create":
+			object := item["object"].(map[string]interface{})
+			published := object["published"].(string)
+			suite.GreaterOrEqual(published, lastPublished)
+			lastPublished = published
+
+			objAttachments, _ := object["attachment"].([]interface{})
+			for _, a := range objAttachments {
+				url := a.(map[string]interface{})["url"].(string)
+				suite.True(strings.HasPrefix(url, "media_attachments/files/"), url)
+				if len(files[url]) != 0 {
+					// Not every test
+					// attachment has
+					// a file in storage.
+					attachments++
+				}
+			}
+		case "Announce":
+		default:
+			suite.Failf("unexpected outbox item type", "%v", item["type"])
+		}
+	}
+	suite.NotZero(attachments)
+
+	// Likes and bookmarks should
+	// contain status URIs.
+	for _, name := range []string{"likes.json", "bookmarks.json"} {
+		var collection struct {
+			Type         string   `json:"type"`
+			OrderedItems []string `json:"orderedItems"`
+		}
+		if err := json.Unmarshal(files[name], &collection); err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal("OrderedCollection", collection.Type)
+		suite.NotEmpty(collection.OrderedItems, name)
+		for _, uri := range collection.OrderedItems {
+			suite.True(strings.HasPrefix(uri, "http"), uri)
+		}
+	}
+
+	// Archive should be downloadable.
+	content, errWithCode := suite.accountProcessor.ArchiveFileGet(ctx, requester, archive.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	defer content.Content.Close()
+	suite.Equal("application/zip", content.ContentType)
+	suite.EqualValues(archive.FileSize, content.ContentLength)
+
+	// But not by anyone else.
+	_, errWithCode = suite.accountProcessor.ArchiveFileGet(ctx, suite.testAccounts["local_account_2"], archive.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreateTooSoon() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	if _, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Can't request another while
+	// the first is being generated...
+	_, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	suite.EqualError(errWithCode, "an archive is already being generated, please wait for it to finish")
+
+	// ...or once it's done.
+	suite.runArchiveJob()
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(ctx, requester)
+	suite.EqualError(errWithCode, "you can only request an archive once every 24 hours")
+}
+
+func (suite *ArchiveTestSuite) TestArchiveCreateReplacesPrevious() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	first, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.runArchiveJob()
+
+	prev, err := suite.db.GetArchiveByID(ctx, first.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Pretend the first archive
+	// was requested a while ago.
+	prev.CreatedAt = prev.CreatedAt.Add(-48 * time.Hour)
+	if err := suite.db.UpdateArchive(ctx, prev, "created_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	second, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.runArchiveJob()
+
+	archives, errWithCode := suite.accountProcessor.ArchivesGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if suite.Len(archives, 1) {
+		suite.Equal(second.ID, archives[0].ID)
+		suite.True(archives[0].Processed)
+	}
+
+	// First archive's file should be gone.
+	has, err := suite.storage.Has(ctx, prev.FilePath)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(has)
+}
+
+func TestArchiveTestSuite(t *testing.T) {
+	suite.Run(t, new(ArchiveTestSuite))
+}
@@ -73,7 +73,7 @@ func (p *Processor) Delete(
 		l.Errorf("continuing after error during account delete: %v", err)
 	}
 
-	if account.IsLocal() {
+	if account.IsLocal() && !account.IsGroup() {
 		// We delete tokens, applications and clients for
 		// account as one of the last stages during deletion,
 		// as other database models rely on these.
@@ -480,6 +480,41 @@ func (p *Processor) deleteAccountPeripheral(ctx context.Context, account *gtsmod
 		return gtserror.Newf("error deleting followed tags by account: %w", err)
 	}
 
+	// Delete all scheduled statuses owned by given account.
+	if err := p.state.DB.DeleteScheduledStatusesByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting scheduled statuses by account: %w", err)
+	}
+
+	// Delete all Web Push subscriptions owned by given account.
+	if err := p.state.DB.DeleteWebPushSubscriptionsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting Web Push subscriptions by account: %w", err)
+	}
+
+	// Delete all archives owned by given account,
+	// along with their files in storage.
+	archives, err := p.state.DB.GetArchivesByAccountID(ctx, account.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting archives by account: %w", err)
+	}
+
+	for _, archive := range archives {
+		p.deleteArchive(ctx, archive)
+	}
+
+	// Delete all imports owned by given account.
+	if err := p.state.DB.DeleteImportsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting imports by account: %w", err)
+	}
+
+	// Delete group moderator entries of, or for, given account.
+	if err := p.state.DB.DeleteGroupModeratorsByAccountID(ctx, account.ID); // nocollapse
+	err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error deleting group moderators by account: %w", err)
+	}
+
 	// Delete account stats model.
 	if err := p.state.DB.DeleteAccountStats(ctx, account.ID); err != nil {
 		return gtserror.Newf("error deleting stats for account: %w", err)
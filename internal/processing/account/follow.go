@@ -113,8 +113,11 @@ func (p *Processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 
 	// For unlocked accounts on the same instance,
 	// we can already optimistically show the follow
-	// request as accepted in the returned relationship.
-	if targetAccount.IsLocal() && !*targetAccount.Locked {
+	// request as accepted in the returned relationship,
+	// unless the requester is silenced, in which case
+	// the request always requires manual approval.
+	if targetAccount.IsLocal() && !*targetAccount.Locked &&
+		!requestingAccount.IsSilenced() {
 		rel.Requested = false
 		rel.Following = true
 		rel.ShowingReblogs = util.PtrOrValue(fr.ShowReblogs, true)
@@ -25,6 +25,7 @@ import (
 	"mime/multipart"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
@@ -55,12 +56,40 @@ func (p *Processor) ImportData(
 			overwrite,
 		)
 
+	case importTypeOutbox:
+		return p.importOutbox(
+			ctx,
+			requester,
+			data,
+			overwrite,
+		)
+
 	default:
 		const text = "import type not yet supported"
 		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
 	}
 }
 
+// ImportsGet returns the requester's
+// data imports, newest first.
+func (p *Processor) ImportsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Import, gtserror.WithCode) {
+	imports, err := p.state.DB.GetImportsByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiImports := make([]*apimodel.Import, 0, len(imports))
+	for _, imp := range imports {
+		apiImports = append(apiImports, p.converter.ImportToAPIImport(imp))
+	}
+
+	return apiImports, nil
+}
+
 func (p *Processor) importFollowing(
 	ctx context.Context,
 	requester *gtsmodel.Account,
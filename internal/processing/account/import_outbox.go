@@ -0,0 +1,709 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// importTypeOutbox is the import type
+	// for statuses from an archive outbox.
+	importTypeOutbox = "outbox"
+
+	// importStaleAfter is the time after
+	// which an unfinished import is presumed
+	// to have been lost to a restart, and no
+	// longer prevents a new import.
+	importStaleAfter = 24 * time.Hour
+
+	// importMaxFailures is the maximum number of
+	// failure descriptions stored for an import,
+	// to keep a broken archive from bloating the db.
+	importMaxFailures = 100
+
+	// importProgressInterval is the number
+	// of items processed between each update
+	// of an import's progress in the db.
+	importProgressInterval = 25
+)
+
+var (
+	// errImportSkipped is returned when an outbox
+	// item is deliberately not imported, as opposed
+	// to failing to import because of an error.
+	errImportSkipped = errors.New("skipped")
+
+	// errImportDuplicate is returned when an outbox
+	// item was already imported by an earlier import.
+	errImportDuplicate = errors.New("already imported")
+)
+
+func (p *Processor) importOutbox(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archiveData *multipart.FileHeader,
+	overwrite bool,
+) gtserror.WithCode {
+	if overwrite {
+		const text = "overwrite mode is not supported for outbox imports"
+		return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	prevImports, err := p.state.DB.GetImportsByAccountID(ctx, requester.ID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err = gtserror.Newf("db error getting imports: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, prev := range prevImports {
+		if prev.Type == importTypeOutbox &&
+			!prev.Finished() &&
+			time.Since(prev.CreatedAt) < importStaleAfter {
+			const text = "an outbox import is already in progress, please wait for it to finish"
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+	}
+
+	// The uploaded file is removed once the
+	// request is finished, so copy it somewhere
+	// that the async import can read it from.
+	tmpPath, err := copyToTemp(archiveData)
+	if err != nil {
+		err := gtserror.Newf("error copying archive: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if errWithCode := p.checkOutboxArchive(ctx, requester, tmpPath); errWithCode != nil {
+		_ = os.Remove(tmpPath)
+		return errWithCode
+	}
+
+	imp := &gtsmodel.Import{
+		ID:        id.NewULID(),
+		AccountID: requester.ID,
+		Type:      importTypeOutbox,
+	}
+
+	if err := p.state.DB.PutImport(ctx, imp); err != nil {
+		_ = os.Remove(tmpPath)
+		err = gtserror.Newf("db error putting import: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// Do remaining processing of this import asynchronously.
+	f := importOutboxAsyncF(p, requester, imp, tmpPath)
+	p.state.Workers.Processing.Queue.Push(f)
+
+	return nil
+}
+
+// copyToTemp copies the given uploaded
+// file to a new temporary file, and
+// returns the path of the temporary file.
+func copyToTemp(data *multipart.FileHeader) (string, error) {
+	file, err := data.Open()
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	tmp, err := os.CreateTemp("", "gotosocial-import-*.zip")
+	if err != nil {
+		return "", err
+	}
+
+	if _, err := io.Copy(tmp, file); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return "", err
+	}
+
+	return tmp.Name(), nil
+}
+
+// checkOutboxArchive checks that the archive at the
+// given path is a readable zip containing an outbox,
+// and that the actor the archive was exported from is
+// an account the requester is aliased to (ie., an
+// account that the requester moved from).
+func (p *Processor) checkOutboxArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	archivePath string,
+) gtserror.WithCode {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		err := fmt.Errorf("error reading archive: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	defer zr.Close()
+
+	if _, err := zr.Open("outbox.json"); err != nil {
+		const text = "archive does not contain outbox.json"
+		return gtserror.NewErrorBadRequest(err, text)
+	}
+
+	actorURI, err := archiveActorURI(ctx, zr)
+	if err != nil {
+		err := fmt.Errorf("error reading actor.json from archive: %w", err)
+		return gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if !requester.IsAliasedTo(actorURI) {
+		text := fmt.Sprintf(
+			"archive belongs to %s; add it as an alias of your account before importing its posts",
+			actorURI,
+		)
+		return gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return nil
+}
+
+// archiveActorURI returns the ID of
+// the actor stored in the given archive.
+func archiveActorURI(ctx context.Context, zr *zip.ReadCloser) (string, error) {
+	rc, err := zr.Open("actor.json")
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve handles closing rc.
+	accountable, err := ap.ResolveAccountable(ctx, rc)
+	if err != nil {
+		return "", err
+	}
+
+	actorURI := ap.GetJSONLDId(accountable)
+	if actorURI == nil {
+		return "", errors.New("actor has no id")
+	}
+
+	return actorURI.String(), nil
+}
+
+func importOutboxAsyncF(
+	p *Processor,
+	requester *gtsmodel.Account,
+	imp *gtsmodel.Import,
+	archivePath string,
+) func(context.Context) {
+	return func(ctx context.Context) {
+		defer func() {
+			_ = os.Remove(archivePath)
+		}()
+
+		l := log.WithContext(ctx).
+			WithField("import", imp.ID)
+
+		if err := p.importOutboxArchive(ctx, requester, imp, archivePath); err != nil {
+			l.Errorf("error importing outbox: %v", err)
+			imp.FailedCount = imp.TotalCount - imp.ImportedCount - imp.SkippedCount
+			addImportFailure(imp, err.Error())
+		}
+
+		imp.FinishedAt = time.Now()
+		if err := p.updateImportProgress(ctx, imp); err != nil {
+			l.Error(err)
+		}
+
+		// Recount statuses, as none of the
+		// imported ones went through the usual
+		// client API side effects which do this.
+		unlock := p.state.ProcessingLocks.Lock(requester.URI)
+		defer unlock()
+
+		if err := p.state.DB.RegenerateAccountStats(ctx, requester); err != nil {
+			l.Errorf("db error regenerating account stats: %v", err)
+		}
+
+		l.Infof("outbox import finished: %d imported, %d skipped, %d failed",
+			imp.ImportedCount, imp.SkippedCount, imp.FailedCount)
+	}
+}
+
+// updateImportProgress stores the
+// current counts of the given import.
+func (p *Processor) updateImportProgress(ctx context.Context, imp *gtsmodel.Import) error {
+	if err := p.state.DB.UpdateImport(ctx, imp,
+		"total_count",
+		"imported_count",
+		"skipped_count",
+		"failed_count",
+		"failures",
+		"finished_at",
+	); err != nil {
+		return gtserror.Newf("db error updating import: %w", err)
+	}
+	return nil
+}
+
+// addImportFailure adds the given failure
+// description to the import, if the maximum
+// number of failures isn't already reached.
+func addImportFailure(imp *gtsmodel.Import, failure string) {
+	if len(imp.Failures) < importMaxFailures {
+		imp.Failures = append(imp.Failures, failure)
+	}
+}
+
+// importOutboxArchive recreates statuses found in the
+// outbox of the archive at the given path as statuses
+// of the requester, tracking progress in the import.
+func (p *Processor) importOutboxArchive(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	imp *gtsmodel.Import,
+	archivePath string,
+) error {
+	zr, err := zip.OpenReader(archivePath)
+	if err != nil {
+		return gtserror.Newf("error reading archive: %w", err)
+	}
+	defer zr.Close()
+
+	actorURI, err := archiveActorURI(ctx, zr)
+	if err != nil {
+		return gtserror.Newf("error reading actor: %w", err)
+	}
+
+	rc, err := zr.Open("outbox.json")
+	if err != nil {
+		return gtserror.Newf("error opening outbox: %w", err)
+	}
+
+	// Items are only decoded as far as their
+	// type; objects of Create activities are
+	// resolved one at a time further down.
+	var outbox struct {
+		Context      json.RawMessage `json:"@context"`
+		OrderedItems []struct {
+			Type   string          `json:"type"`
+			Object json.RawMessage `json:"object"`
+		} `json:"orderedItems"`
+	}
+
+	err = json.NewDecoder(rc).Decode(&outbox)
+	_ = rc.Close()
+	if err != nil {
+		return gtserror.Newf("error decoding outbox: %w", err)
+	}
+
+	imp.TotalCount = len(outbox.OrderedItems)
+	if err := p.updateImportProgress(ctx, imp); err != nil {
+		return err
+	}
+
+	// Imported statuses by their URI in
+	// the archive, so that replies to the
+	// requester's own statuses can be kept
+	// threaded. Archives are ordered oldest
+	// first, so parents come before replies.
+	imported := make(map[string]*gtsmodel.Status)
+
+	for i, item := range outbox.OrderedItems {
+		var (
+			status *gtsmodel.Status
+			uri    string
+			err    error
+		)
+
+		if item.Type == ap.ActivityCreate {
+			status, uri, err = p.importOutboxStatus(ctx,
+				zr,
+				requester,
+				actorURI,
+				outbox.Context,
+				item.Object,
+				imported,
+			)
+		} else {
+			// Boosts etc. aren't
+			// authored by requester.
+			err = errImportSkipped
+		}
+
+		if err == nil || errors.Is(err, errImportDuplicate) {
+			// Only keep what's needed to thread
+			// replies, as archives can be huge.
+			imported[uri] = &gtsmodel.Status{
+				ID:       status.ID,
+				URI:      status.URI,
+				ThreadID: status.ThreadID,
+			}
+		}
+
+		switch {
+		case err == nil:
+			imp.ImportedCount++
+
+		case errors.Is(err, errImportSkipped),
+			errors.Is(err, errImportDuplicate):
+			imp.SkippedCount++
+
+		default:
+			if uri == "" {
+				uri = fmt.Sprintf("item %d", i)
+			}
+			imp.FailedCount++
+			addImportFailure(imp, uri+": "+err.Error())
+		}
+
+		if (i+1)%importProgressInterval == 0 {
+			if err := p.updateImportProgress(ctx, imp); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// importOutboxStatus recreates the given status object,
+// as stored in an archive outbox by the given actor, as
+// a local status of the requester, returning the status
+// and its URI in the archive. Statuses that shouldn't be
+// imported are skipped by returning errImportSkipped, and
+// statuses already imported are returned along with
+// errImportDuplicate, rather than being imported again.
+//
+// The status is put straight in the database, without
+// going through client API side effects, so that it's
+// not delivered to followers or timelined as a new post.
+func (p *Processor) importOutboxStatus(
+	ctx context.Context,
+	zr *zip.ReadCloser,
+	requester *gtsmodel.Account,
+	actorURI string,
+	outboxContext json.RawMessage,
+	rawObject json.RawMessage,
+	imported map[string]*gtsmodel.Status,
+) (*gtsmodel.Status, string, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(rawObject, &raw); err != nil {
+		// Object is an IRI (or missing),
+		// so there's nothing to import.
+		return nil, "", errImportSkipped
+	}
+
+	if _, ok := raw["@context"]; !ok {
+		// Objects embedded in the outbox
+		// share its context, but can't be
+		// resolved on their own without it.
+		raw["@context"] = outboxContext
+	}
+
+	if err := absArchiveAttachmentURLs(raw); err != nil {
+		return nil, "", gtserror.Newf("error decoding attachments: %w", err)
+	}
+
+	object, err := json.Marshal(raw)
+	if err != nil {
+		return nil, "", gtserror.Newf("error encoding status: %w", err)
+	}
+
+	statusable, err := ap.ResolveStatusable(ctx, io.NopCloser(bytes.NewReader(object)))
+	if err != nil {
+		return nil, "", gtserror.Newf("error resolving status: %w", err)
+	}
+
+	var uri string
+	if uriObj := ap.GetJSONLDId(statusable); uriObj != nil {
+		uri = uriObj.String()
+	}
+
+	if attributedTo, err := ap.ExtractAttributedToURI(statusable); err != nil ||
+		attributedTo.String() != actorURI {
+		// Only import the actor's own statuses.
+		return nil, uri, errImportSkipped
+	}
+
+	if uri != "" {
+		// Don't import the same status twice,
+		// eg., when an archive is re-uploaded.
+		existing, err := p.state.DB.GetStatusByImportedFromURI(ctx, requester.ID, uri)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, uri, gtserror.Newf("db error checking for existing status: %w", err)
+		}
+
+		if existing != nil {
+			return existing, uri, errImportDuplicate
+		}
+	}
+
+	if _, ok := ap.ToPollable(statusable); ok {
+		// Polls can't be voted on
+		// after the fact, so skip.
+		return nil, uri, errImportSkipped
+	}
+
+	visibility, err := ap.ExtractVisibility(statusable, "")
+	if err != nil {
+		return nil, uri, gtserror.Newf("error extracting visibility: %w", err)
+	}
+
+	if visibility != gtsmodel.VisibilityPublic &&
+		visibility != gtsmodel.VisibilityUnlocked {
+		// Only public and unlisted statuses are imported,
+		// as the audience of anything more restrictive
+		// can't be recreated on this instance.
+		return nil, uri, errImportSkipped
+	}
+
+	var inReplyTo *gtsmodel.Status
+	if inReplyToURI := ap.ExtractInReplyToURI(statusable); inReplyToURI != nil {
+		var ok bool
+		inReplyTo, ok = imported[inReplyToURI.String()]
+		if !ok {
+			// Only keep replies to the requester's
+			// own imported statuses, as replies to
+			// others would be out of context here.
+			return nil, uri, errImportSkipped
+		}
+	}
+
+	published := ap.GetPublished(statusable)
+	if published.IsZero() || published.After(time.Now()) {
+		return nil, uri, gtserror.New("missing or invalid published time")
+	}
+
+	// Give the status an ID from when it
+	// was originally published, so that
+	// it's sorted as it was on the old
+	// instance wherever it's paged through.
+	statusID, err := id.NewULIDFromTime(published)
+	if err != nil {
+		return nil, uri, gtserror.Newf("error generating id: %w", err)
+	}
+
+	content, language := typeutils.ContentToContentLanguage(ctx,
+		ap.ExtractContent(statusable),
+	)
+
+	accountURIs := uris.GenerateURIsForAccount(requester.Username)
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 accountURIs.StatusesURI + "/" + statusID,
+		URL:                 accountURIs.StatusesURL + "/" + statusID,
+		CreatedAt:           published,
+		UpdatedAt:           published,
+		Local:               util.Ptr(true),
+		Imported:            util.Ptr(true),
+		ImportedFromURI:     uri,
+		Account:             requester,
+		AccountID:           requester.ID,
+		AccountURI:          requester.URI,
+		ActivityStreamsType: statusable.GetTypeName(),
+		Content:             content,
+		Text:                text.SanitizeToPlaintext(content),
+		ContentWarning:      ap.ExtractSummary(statusable),
+		Sensitive:           util.Ptr(ap.ExtractSensitive(statusable)),
+		Language:            language,
+		Visibility:          visibility,
+		Federated:           util.Ptr(true),
+		PendingApproval:     util.Ptr(false),
+	}
+
+	if inReplyTo != nil {
+		status.InReplyToID = inReplyTo.ID
+		status.InReplyToURI = inReplyTo.URI
+		status.InReplyToAccountID = requester.ID
+		status.ThreadID = inReplyTo.ThreadID
+	} else {
+		// Mark new thread
+		// starting from here.
+		status.ThreadID = id.NewULID()
+		if err := p.state.DB.PutThread(ctx,
+			&gtsmodel.Thread{ID: status.ThreadID},
+		); err != nil {
+			return nil, uri, gtserror.Newf("db error putting thread: %w", err)
+		}
+	}
+
+	if err := p.importOutboxAttachments(ctx,
+		zr,
+		requester,
+		statusable,
+		status,
+	); err != nil {
+		return nil, uri, err
+	}
+
+	if err := p.state.DB.PutStatus(ctx, status); err != nil {
+		return nil, uri, gtserror.Newf("db error putting status: %w", err)
+	}
+
+	return status, uri, nil
+}
+
+// importOutboxAttachments stores the files of attachments
+// of the given statusable, found in the archive, as local
+// media attached to the given status.
+func (p *Processor) importOutboxAttachments(
+	ctx context.Context,
+	zr *zip.ReadCloser,
+	requester *gtsmodel.Account,
+	statusable ap.Statusable,
+	status *gtsmodel.Status,
+) error {
+	attachments, err := ap.ExtractAttachments(statusable)
+	if err != nil {
+		return gtserror.Newf("error extracting attachments: %w", err)
+	}
+
+	if maxFiles := config.GetStatusesMediaMaxFiles(); len(attachments) > maxFiles {
+		attachments = attachments[:maxFiles]
+	}
+
+	for _, attachment := range attachments {
+		name, err := archiveEntryName(attachment.RemoteURL)
+		if err != nil {
+			return gtserror.Newf("invalid attachment url %s: %w", attachment.RemoteURL, err)
+		}
+
+		// Check the file is in the archive
+		// before doing anything with it.
+		f, err := zr.Open(name)
+		if err != nil {
+			return gtserror.Newf("attachment %s not found in archive", name)
+		}
+		_ = f.Close()
+
+		stored, errWithCode := p.c.StoreLocalMedia(ctx,
+			requester.ID,
+			func(context.Context) (io.ReadCloser, error) {
+				return zr.Open(name)
+			},
+			media.AdditionalMediaInfo{
+				CreatedAt:   &status.CreatedAt,
+				StatusID:    &status.ID,
+				Description: &attachment.Description,
+				Blurhash:    &attachment.Blurhash,
+			},
+		)
+		if errWithCode != nil {
+			return gtserror.Newf("error storing attachment %s: %w", name, errWithCode.Unwrap())
+		}
+
+		status.AttachmentIDs = append(status.AttachmentIDs, stored.ID)
+		status.Attachments = append(status.Attachments, stored)
+	}
+
+	return nil
+}
+
+// archiveBaseURL is the base that relative urls
+// of files in an archive are resolved against, as
+// only absolute urls are picked up from statuses.
+var archiveBaseURL = &url.URL{Scheme: "https", Host: "archive.invalid", Path: "/"}
+
+// absArchiveAttachmentURLs resolves relative
+// urls of attachments in the given raw status
+// object against archiveBaseURL, in place.
+func absArchiveAttachmentURLs(raw map[string]json.RawMessage) error {
+	rawAttachments, ok := raw["attachment"]
+	if !ok {
+		return nil
+	}
+
+	var attachments interface{}
+	if err := json.Unmarshal(rawAttachments, &attachments); err != nil {
+		return err
+	}
+
+	absURL := func(attachment interface{}) {
+		a, ok := attachment.(map[string]interface{})
+		if !ok {
+			return
+		}
+
+		fileURL, ok := a["url"].(string)
+		if !ok {
+			return
+		}
+
+		if u, err := url.Parse(fileURL); err == nil {
+			a["url"] = archiveBaseURL.ResolveReference(u).String()
+		}
+	}
+
+	switch attachments := attachments.(type) {
+	case []interface{}:
+		for _, attachment := range attachments {
+			absURL(attachment)
+		}
+	case map[string]interface{}:
+		absURL(attachments)
+	}
+
+	b, err := json.Marshal(attachments)
+	if err != nil {
+		return err
+	}
+
+	raw["attachment"] = b
+	return nil
+}
+
+// archiveEntryName returns the name within an archive of
+// a file referred to by the given url. Mastodon archives
+// refer to eg. attachments with an absolute path, while
+// the files in the archive have no leading slash.
+func archiveEntryName(fileURL string) (string, error) {
+	u, err := url.Parse(fileURL)
+	if err != nil {
+		return "", err
+	}
+
+	name := strings.TrimPrefix(u.Path, "/")
+	if name == "" {
+		return "", errors.New("empty path")
+	}
+
+	return name, nil
+}
@@ -0,0 +1,495 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const oldActorURI = "https://old.example.org/users/someone"
+
+type ImportOutboxTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// runImportJob runs the queued
+// import job, as the worker would.
+func (suite *ImportOutboxTestSuite) runImportJob() {
+	ctx, cncl := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cncl()
+
+	f, ok := suite.state.Workers.Processing.Queue.PopCtx(ctx)
+	if !suite.True(ok) {
+		suite.FailNow("no import job queued")
+	}
+	f(context.Background())
+}
+
+// archiveFileHeader writes the given files into
+// a zip, and returns it as an uploaded file.
+func (suite *ImportOutboxTestSuite) archiveFileHeader(files map[string][]byte) *multipart.FileHeader {
+	zipBuf := new(bytes.Buffer)
+	zw := zip.NewWriter(zipBuf)
+	for name, data := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		if _, err := w.Write(data); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+	if err := zw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	formBuf := new(bytes.Buffer)
+	mw := multipart.NewWriter(formBuf)
+	w, err := mw.CreateFormFile("data", "archive.zip")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if _, err := w.Write(zipBuf.Bytes()); err != nil {
+		suite.FailNow(err.Error())
+	}
+	if err := mw.Close(); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	form, err := multipart.NewReader(formBuf, mw.Boundary()).ReadForm(1 << 20)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return form.File["data"][0]
+}
+
+func (suite *ImportOutboxTestSuite) marshal(v interface{}) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return b
+}
+
+// oldArchive returns the files of a Mastodon
+// archive belonging to the old actor, containing
+// a mix of importable and unimportable items.
+func (suite *ImportOutboxTestSuite) oldArchive() map[string][]byte {
+	image, err := os.ReadFile("../../../testrig/media/test-jpeg.jpg")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var (
+		public    = "https://www.w3.org/ns/activitystreams#Public"
+		followers = oldActorURI + "/followers"
+	)
+
+	note := func(id string, published string, to string, cc string, extra map[string]interface{}) map[string]interface{} {
+		object := map[string]interface{}{
+			"id":           oldActorURI + "/statuses/" + id,
+			"type":         "Note",
+			"attributedTo": oldActorURI,
+			"published":    published,
+			"to":           []string{to},
+			"cc":           []string{cc},
+			"content":      "<p>post " + id + "</p>",
+		}
+		for k, v := range extra {
+			object[k] = v
+		}
+		return map[string]interface{}{
+			"id":     oldActorURI + "/statuses/" + id + "/activity",
+			"type":   "Create",
+			"actor":  oldActorURI,
+			"object": object,
+		}
+	}
+
+	outbox := map[string]interface{}{
+		"@context": "https://www.w3.org/ns/activitystreams",
+		"id":       "outbox.json",
+		"type":     "OrderedCollection",
+		"orderedItems": []interface{}{
+			// Public post with media and
+			// a content warning: imported.
+			note("1", "2020-01-01T12:00:00Z", public, followers, map[string]interface{}{
+				"summary":   "cw",
+				"sensitive": true,
+				"attachment": []interface{}{
+					map[string]interface{}{
+						"type":      "Document",
+						"mediaType": "image/jpeg",
+						"url":       "/media_attachments/files/1/original/test.jpg",
+						"name":      "a test image",
+					},
+				},
+			}),
+
+			// Unlisted self-reply: imported.
+			note("2", "2020-01-02T12:00:00Z", followers, public, map[string]interface{}{
+				"inReplyTo": oldActorURI + "/statuses/1",
+			}),
+
+			// Followers-only post: skipped.
+			note("3", "2020-01-03T12:00:00Z", followers, "", nil),
+
+			// Reply to someone else: skipped.
+			note("4", "2020-01-04T12:00:00Z", public, followers, map[string]interface{}{
+				"inReplyTo": "https://elsewhere.example.org/users/other/statuses/1",
+			}),
+
+			// Boost: skipped.
+			map[string]interface{}{
+				"id":     oldActorURI + "/statuses/5/activity",
+				"type":   "Announce",
+				"actor":  oldActorURI,
+				"object": "https://elsewhere.example.org/users/other/statuses/2",
+			},
+
+			// Media missing from archive: failed.
+			note("6", "2020-01-06T12:00:00Z", public, followers, map[string]interface{}{
+				"attachment": []interface{}{
+					map[string]interface{}{
+						"type":      "Document",
+						"mediaType": "image/jpeg",
+						"url":       "/media_attachments/files/6/original/missing.jpg",
+					},
+				},
+			}),
+		},
+	}
+
+	actor := map[string]interface{}{
+		"@context":          "https://www.w3.org/ns/activitystreams",
+		"id":                oldActorURI,
+		"type":              "Person",
+		"preferredUsername": "someone",
+		"inbox":             oldActorURI + "/inbox",
+		"outbox":            "outbox.json",
+		"followers":         followers,
+	}
+
+	return map[string][]byte{
+		"actor.json":  suite.marshal(actor),
+		"outbox.json": suite.marshal(outbox),
+		"media_attachments/files/1/original/test.jpg": image,
+	}
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutbox() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Requester moved from the old account.
+	requester.AlsoKnownAsURIs = []string{oldActorURI}
+	if err := suite.db.UpdateAccount(ctx, requester, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	prevStatuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if errWithCode := suite.accountProcessor.ImportData(ctx,
+		requester,
+		suite.archiveFileHeader(suite.oldArchive()),
+		"outbox",
+		false,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Only one outbox import at a time.
+	errWithCode := suite.accountProcessor.ImportData(ctx,
+		requester,
+		suite.archiveFileHeader(suite.oldArchive()),
+		"outbox",
+		false,
+	)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	suite.runImportJob()
+
+	imports, errWithCode := suite.accountProcessor.ImportsGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(imports, 1) {
+		suite.FailNow("")
+	}
+
+	imp := imports[0]
+	suite.Equal("outbox", imp.Type)
+	suite.True(imp.Finished)
+	suite.Equal(6, imp.Total)
+	suite.Equal(2, imp.Imported)
+	suite.Equal(3, imp.Skipped)
+	suite.Equal(1, imp.Failed)
+	if suite.Len(imp.Failures, 1) {
+		suite.Contains(imp.Failures[0], oldActorURI+"/statuses/6")
+	}
+
+	// Imported statuses should be the
+	// oldest statuses of the requester.
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	if !suite.Len(statuses, len(prevStatuses)+2) {
+		suite.FailNow("")
+	}
+
+	reply := statuses[len(statuses)-2]
+	post := statuses[len(statuses)-1]
+
+	suite.True(post.IsImported())
+	suite.True(post.IsLocal())
+	suite.Equal(requester.ID, post.AccountID)
+	suite.Equal(gtsmodel.VisibilityPublic, post.Visibility)
+	suite.Equal(time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), post.CreatedAt.UTC())
+	suite.Equal("<p>post 1</p>", post.Content)
+	suite.Equal("cw", post.ContentWarning)
+	suite.True(*post.Sensitive)
+	if suite.Len(post.Attachments, 1) {
+		suite.Equal("a test image", post.Attachments[0].Description)
+		suite.Equal(gtsmodel.FileTypeImage, post.Attachments[0].Type)
+	}
+
+	suite.True(reply.IsImported())
+	suite.Equal(gtsmodel.VisibilityUnlocked, reply.Visibility)
+	suite.Equal(post.ID, reply.InReplyToID)
+	suite.Equal(post.ThreadID, reply.ThreadID)
+
+	// Nothing should have been
+	// sent out to followers.
+	_, ok := suite.getClientMsg(time.Second)
+	suite.False(ok)
+
+	// Statuses count should include the
+	// imported statuses, once regenerated.
+	if err := suite.db.PopulateAccountStats(ctx, requester); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(len(statuses), *requester.Stats.StatusesCount)
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutboxTwice() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Requester moved from the old account.
+	requester.AlsoKnownAsURIs = []string{oldActorURI}
+	if err := suite.db.UpdateAccount(ctx, requester, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	prevStatuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Import the same archive twice over.
+	for i := 0; i < 2; i++ {
+		if errWithCode := suite.accountProcessor.ImportData(ctx,
+			requester,
+			suite.archiveFileHeader(suite.oldArchive()),
+			"outbox",
+			false,
+		); errWithCode != nil {
+			suite.FailNow(errWithCode.Error())
+		}
+		suite.runImportJob()
+	}
+
+	imports, errWithCode := suite.accountProcessor.ImportsGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(imports, 2) {
+		suite.FailNow("")
+	}
+
+	// Everything imported the first time
+	// should be skipped the second time.
+	var first, second = imports[1], imports[0]
+	if first.ID > second.ID {
+		first, second = second, first
+	}
+	suite.Equal(2, first.Imported)
+	suite.Equal(0, second.Imported)
+	suite.Equal(first.Skipped+first.Imported, second.Skipped)
+
+	// So statuses should only have been
+	// added by the first import.
+	statuses, err := suite.db.GetAccountStatuses(ctx, requester.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(statuses, len(prevStatuses)+2)
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutboxFromArchive() {
+	var (
+		ctx       = context.Background()
+		oldAcct   = suite.testAccounts["local_account_2"]
+		requester = suite.testAccounts["local_account_1"]
+	)
+
+	// Generate an archive for the old account.
+	apiArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, oldAcct)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.runImportJob()
+
+	archive, err := suite.db.GetArchiveByID(ctx, apiArchive.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	data, err := suite.storage.Get(ctx, archive.FilePath)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		b, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		files[f.Name] = b
+	}
+
+	// Requester moved from the old account.
+	requester.AlsoKnownAsURIs = []string{oldAcct.URI}
+	if err := suite.db.UpdateAccount(ctx, requester, "also_known_as_uris"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if errWithCode := suite.accountProcessor.ImportData(ctx,
+		requester,
+		suite.archiveFileHeader(files),
+		"outbox",
+		false,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.runImportJob()
+
+	imports, errWithCode := suite.accountProcessor.ImportsGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(imports, 1) {
+		suite.FailNow("")
+	}
+
+	// Every public or unlisted status (that's
+	// not a poll) of the old account should
+	// have been imported.
+	oldStatuses, err := suite.db.GetAccountStatuses(ctx, oldAcct.ID, 0, false, false, "", "", false, false)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	var importable int
+	for _, status := range oldStatuses {
+		if status.BoostOfID == "" &&
+			status.InReplyToID == "" &&
+			status.PollID == "" &&
+			(status.Visibility == gtsmodel.VisibilityPublic ||
+				status.Visibility == gtsmodel.VisibilityUnlocked) {
+			importable++
+		}
+	}
+
+	imp := imports[0]
+	suite.True(imp.Finished)
+	suite.Equal(len(oldStatuses), imp.Total)
+	suite.Empty(imp.Failures)
+	suite.NotZero(imp.Imported)
+	suite.GreaterOrEqual(imp.Imported, importable)
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutboxNotAliased() {
+	var (
+		ctx       = context.Background()
+		requester = suite.testAccounts["local_account_2"]
+	)
+
+	// Requester isn't aliased to the
+	// old account, so can't import.
+	errWithCode := suite.accountProcessor.ImportData(ctx,
+		requester,
+		suite.archiveFileHeader(suite.oldArchive()),
+		"outbox",
+		false,
+	)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	imports, errWithCode := suite.accountProcessor.ImportsGet(ctx, requester)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Empty(imports)
+}
+
+func (suite *ImportOutboxTestSuite) TestImportOutboxNotArchive() {
+	errWithCode := suite.accountProcessor.ImportData(context.Background(),
+		suite.testAccounts["local_account_1"],
+		suite.archiveFileHeader(map[string][]byte{
+			"following.csv": []byte("Account address,Show boosts\n"),
+		}),
+		"outbox",
+		false,
+	)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
+func TestImportOutboxTestSuite(t *testing.T) {
+	suite.Run(t, new(ImportOutboxTestSuite))
+}
@@ -77,6 +77,86 @@ func (suite *AccountTestSuite) TestAccountActionSuspend() {
 	suite.NotZero(targetAcct.SuspendedAt)
 }
 
+func (suite *AccountTestSuite) TestAccountActionSilenceUnsilence() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["remote_account_1"].ID
+	)
+
+	// Silence the account, then
+	// check its state after each action.
+	for _, actionType := range []gtsmodel.AdminActionType{
+		gtsmodel.AdminActionSilence,
+		gtsmodel.AdminActionUnsilence,
+	} {
+		targetAcct := suite.doAccountAction(ctx, adminAcct, targetID, actionType)
+		suite.Equal(actionType == gtsmodel.AdminActionSilence, targetAcct.IsSilenced())
+	}
+}
+
+func (suite *AccountTestSuite) TestAccountActionSensitizeUnsensitize() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		targetID  = suite.testAccounts["local_account_1"].ID
+	)
+
+	// Sensitize the account, then
+	// check its state after each action.
+	for _, actionType := range []gtsmodel.AdminActionType{
+		gtsmodel.AdminActionSensitize,
+		gtsmodel.AdminActionUnsensitize,
+	} {
+		targetAcct := suite.doAccountAction(ctx, adminAcct, targetID, actionType)
+		suite.Equal(actionType == gtsmodel.AdminActionSensitize, targetAcct.IsSensitized())
+	}
+}
+
+// doAccountAction performs the given action type on
+// the target account, waits for it to complete, and
+// returns the target account as stored afterwards.
+func (suite *AccountTestSuite) doAccountAction(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetID string,
+	actionType gtsmodel.AdminActionType,
+) *gtsmodel.Account {
+	actionID, errWithCode := suite.adminProcessor.AccountAction(
+		ctx,
+		adminAcct,
+		&apimodel.AdminActionRequest{
+			Category: gtsmodel.AdminActionCategoryAccount.String(),
+			Type:     actionType.String(),
+			TargetID: targetID,
+		},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	adminAction, err := suite.db.GetAdminAction(ctx, actionID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(adminAction.CompletedAt)
+	suite.Empty(adminAction.Errors)
+
+	targetAcct, err := suite.db.GetAccountByID(ctx, targetID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return targetAcct
+}
+
 func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 	var (
 		ctx       = context.Background()
@@ -94,7 +174,7 @@ func (suite *AccountTestSuite) TestAccountActionUnsupported() {
 		adminAcct,
 		request,
 	)
-	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\"]")
+	suite.EqualError(errWithCode, "admin action type pee pee poo poo is not supported for this endpoint, currently supported types are: [\"suspend\" \"silence\" \"unsilence\" \"sensitive\" \"unsensitive\"]")
 	suite.Empty(actionID)
 }
 
@@ -19,13 +19,17 @@ package admin
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -40,15 +44,30 @@ func (p *Processor) AccountAction(
 		return "", gtserror.NewErrorInternalError(err)
 	}
 
+	var (
+		actionID    string
+		errWithCode gtserror.WithCode
+	)
+
 	switch gtsmodel.NewAdminActionType(request.Type) {
 	case gtsmodel.AdminActionSuspend:
-		return p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+		actionID, errWithCode = p.accountActionSuspend(ctx, adminAcct, targetAcct, request.Text)
+
+	case gtsmodel.AdminActionSilence,
+		gtsmodel.AdminActionUnsilence,
+		gtsmodel.AdminActionSensitize,
+		gtsmodel.AdminActionUnsensitize:
+		actionID, errWithCode = p.accountActionRestrict(ctx, adminAcct, targetAcct, gtsmodel.NewAdminActionType(request.Type), request.Text)
 
 	default:
 		// TODO: add more types to this slice when adding
 		//       more types to the switch statement above.
 		supportedTypes := []string{
 			gtsmodel.AdminActionSuspend.String(),
+			gtsmodel.AdminActionSilence.String(),
+			gtsmodel.AdminActionUnsilence.String(),
+			gtsmodel.AdminActionSensitize.String(),
+			gtsmodel.AdminActionUnsensitize.String(),
 		}
 
 		err := fmt.Errorf(
@@ -58,6 +77,20 @@ func (p *Processor) AccountAction(
 
 		return "", gtserror.NewErrorBadRequest(err, err.Error())
 	}
+
+	if errWithCode != nil {
+		return "", errWithCode
+	}
+
+	// The action itself runs asynchronously,
+	// so record the request that kicked it off.
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.NewAdminActionType(request.Type).String(),
+		gtsmodel.AuditLogTargetAccount, targetAcct.ID,
+		nil, request,
+	)
+
+	return actionID, nil
 }
 
 func (p *Processor) accountActionSuspend(
@@ -100,3 +133,119 @@ func (p *Processor) accountActionSuspend(
 
 	return actionID, errWithCode
 }
+
+// accountActionRestrict silences or sensitizes the target
+// account (or reverses this), depending on action type.
+// This works the same for both local and remote accounts,
+// as restrictions are enforced only on this instance.
+func (p *Processor) accountActionRestrict(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	targetAcct *gtsmodel.Account,
+	actionType gtsmodel.AdminActionType,
+	text string,
+) (string, gtserror.WithCode) {
+	actionID := id.NewULID()
+
+	errWithCode := p.actions.Run(
+		ctx,
+		&gtsmodel.AdminAction{
+			ID:             actionID,
+			TargetCategory: gtsmodel.AdminActionCategoryAccount,
+			TargetID:       targetAcct.ID,
+			Target:         targetAcct,
+			Type:           actionType,
+			AccountID:      adminAcct.ID,
+			Text:           text,
+		},
+		func(ctx context.Context) gtserror.MultiError {
+			var column string
+
+			switch actionType {
+			case gtsmodel.AdminActionSilence:
+				targetAcct.SilencedAt = time.Now()
+				column = "silenced_at"
+
+			case gtsmodel.AdminActionUnsilence:
+				targetAcct.SilencedAt = time.Time{}
+				column = "silenced_at"
+
+			case gtsmodel.AdminActionSensitize:
+				targetAcct.SensitizedAt = time.Now()
+				column = "sensitized_at"
+
+			case gtsmodel.AdminActionUnsensitize:
+				targetAcct.SensitizedAt = time.Time{}
+				column = "sensitized_at"
+			}
+
+			if err := p.state.DB.UpdateAccount(ctx, targetAcct, column); err != nil {
+				errs := gtserror.NewMultiError(1)
+				errs.Appendf("db error updating account: %w", err)
+				return errs
+			}
+
+			switch actionType {
+			case gtsmodel.AdminActionSilence,
+				gtsmodel.AdminActionUnsilence:
+				// Visibility of the account's statuses
+				// has changed for (almost) everyone, and
+				// status visibility is cached per status,
+				// so just clear the whole visibility cache.
+				p.state.Caches.Visibility.Clear()
+
+			case gtsmodel.AdminActionSensitize,
+				gtsmodel.AdminActionUnsensitize:
+				// Timelined statuses with media by
+				// this account need to be re-prepared
+				// to show the right sensitive flag.
+				p.unprepareMediaStatuses(ctx, targetAcct)
+			}
+
+			return nil
+		},
+	)
+
+	return actionID, errWithCode
+}
+
+// unprepareMediaStatuses unprepares all statuses with media
+// by the given account from all home and list timelines.
+func (p *Processor) unprepareMediaStatuses(ctx context.Context, account *gtsmodel.Account) {
+	var maxID string
+
+	for {
+		statuses, err := p.state.DB.GetAccountStatuses(ctx,
+			account.ID,
+			100,   // limit
+			false, // excludeReplies
+			true,  // excludeReblogs
+			maxID,
+			"",    // minID
+			true,  // mediaOnly
+			false, // publicOnly
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting statuses of account %s: %v", account.ID, err)
+			return
+		}
+
+		if len(statuses) == 0 {
+			// Reached
+			// the end.
+			return
+		}
+
+		for _, status := range statuses {
+			if err := p.state.Timelines.Home.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+				log.Errorf(ctx, "error unpreparing status %s from home timelines: %v", status.ID, err)
+			}
+
+			if err := p.state.Timelines.List.UnprepareItemFromAllTimelines(ctx, status.ID); err != nil {
+				log.Errorf(ctx, "error unpreparing status %s from list timelines: %v", status.ID, err)
+			}
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+	}
+}
@@ -117,6 +117,7 @@ func (suite *AdminStandardTestSuite) SetupTest() {
 		suite.emailSender,
 		visibility.NewFilter(&suite.state),
 		interaction.NewFilter(&suite.state),
+		testrig.NewWebPushSender(nil),
 	)
 
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
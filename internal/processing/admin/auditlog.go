@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	apiutil "github.com/superseriousbusiness/gotosocial/internal/api/util"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// AuditLogGet returns a page of entries from the audit log,
+// newest first, filtered by any of the given non-empty params.
+func (p *Processor) AuditLogGet(
+	ctx context.Context,
+	accountID string,
+	action string,
+	targetType string,
+	targetID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	entries, err := p.state.DB.GetAuditLogEntries(ctx,
+		accountID,
+		action,
+		gtsmodel.AuditLogTargetType(targetType),
+		targetID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting audit log entries: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(entries)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	// Get the lowest and highest
+	// ID values, used for paging.
+	lo := entries[count-1].ID
+	hi := entries[0].ID
+
+	// Convert each entry to API model.
+	items := make([]interface{}, 0, count)
+	for _, entry := range entries {
+		item, err := p.converter.AuditLogEntryToAdminAPIAuditLogEntry(ctx, entry)
+		if err != nil {
+			log.Errorf(ctx, "error converting audit log entry %s: %v", entry.ID, err)
+			continue
+		}
+		items = append(items, item)
+	}
+
+	// Assemble next/prev page queries.
+	query := make(url.Values, 4)
+	if accountID != "" {
+		query.Set(apiutil.AccountIDKey, accountID)
+	}
+	if action != "" {
+		query.Set(apiutil.AuditLogActionKey, action)
+	}
+	if targetType != "" {
+		query.Set(apiutil.AuditLogTargetTypeKey, targetType)
+	}
+	if targetID != "" {
+		query.Set(apiutil.AuditLogTargetIDKey, targetID)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/admin/audit_log",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+		Query: query,
+	}), nil
+}
+
+// AuditLogEntryGet returns one audit log entry, with the given ID.
+func (p *Processor) AuditLogEntryGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminAuditLogEntry, gtserror.WithCode) {
+	entry, err := p.state.DB.GetAuditLogEntryByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting audit log entry %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if entry == nil {
+		err := gtserror.Newf("audit log entry %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	apiEntry, err := p.converter.AuditLogEntryToAdminAPIAuditLogEntry(ctx, entry)
+	if err != nil {
+		err := gtserror.Newf("error converting audit log entry %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiEntry, nil
+}
+
+// auditLog records an action performed by the given admin
+// account in the audit log. The before and after params should
+// be the admin API representations of the target from before
+// and after the action, either of which may be nil.
+//
+// Errors are logged rather than returned, as by the time an
+// action is recorded it's already been performed, and failing
+// the request at that point would only confuse the caller.
+func (p *Processor) auditLog(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	action string,
+	targetType gtsmodel.AuditLogTargetType,
+	targetID string,
+	before any,
+	after any,
+) {
+	entry := &gtsmodel.AuditLogEntry{
+		ID:         id.NewMonotonicULID(),
+		AccountID:  adminAcct.ID,
+		Account:    adminAcct,
+		Action:     action,
+		TargetType: targetType,
+		TargetID:   targetID,
+		Before:     auditSnapshot(ctx, before),
+		After:      auditSnapshot(ctx, after),
+	}
+
+	if err := p.state.DB.PutAuditLogEntry(ctx, entry); err != nil {
+		log.Errorf(ctx, "db error putting audit log entry for %s %s %s: %v",
+			action, targetType, targetID, err,
+		)
+	}
+}
+
+// auditSnapshot serializes the given target
+// as JSON, returning empty string for nil.
+func auditSnapshot(ctx context.Context, target any) string {
+	if target == nil {
+		return ""
+	}
+
+	b, err := json.Marshal(target)
+	if err != nil {
+		log.Errorf(ctx, "error serializing audit log snapshot: %v", err)
+		return ""
+	}
+
+	if string(b) == "null" {
+		// Typed nil pointer.
+		return ""
+	}
+
+	return string(b)
+}
@@ -0,0 +1,113 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+type AuditLogTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AuditLogTestSuite) TestAuditLogRuleLifecycle() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	rule, errWithCode := suite.adminProcessor.RuleCreate(ctx, adminAcct, &apimodel.InstanceRuleCreateRequest{
+		Text: "be nice",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if _, errWithCode := suite.adminProcessor.RuleUpdate(ctx, adminAcct, rule.ID, &apimodel.InstanceRuleCreateRequest{
+		Text: "be very nice",
+	}); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	if _, errWithCode := suite.adminProcessor.RuleDelete(ctx, adminAcct, rule.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	resp, errWithCode := suite.adminProcessor.AuditLogGet(ctx,
+		"", "", string(gtsmodel.AuditLogTargetRule), rule.ID,
+		&paging.Page{Limit: 20},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Newest first.
+	if !suite.Len(resp.Items, 3) {
+		suite.FailNow("")
+	}
+
+	deleted := resp.Items[0].(*apimodel.AdminAuditLogEntry)
+	updated := resp.Items[1].(*apimodel.AdminAuditLogEntry)
+	created := resp.Items[2].(*apimodel.AdminAuditLogEntry)
+
+	suite.Equal(gtsmodel.AuditLogActionCreate, created.Action)
+	suite.Equal(adminAcct.ID, created.Account.ID)
+	suite.Nil(created.Before)
+	suite.Equal("be nice", created.After["text"])
+
+	suite.Equal(gtsmodel.AuditLogActionUpdate, updated.Action)
+	suite.Equal("be nice", updated.Before["text"])
+	suite.Equal("be very nice", updated.After["text"])
+	suite.Contains(updated.Changes, "text")
+	suite.NotContains(updated.Changes, "id")
+
+	suite.Equal(gtsmodel.AuditLogActionDelete, deleted.Action)
+	suite.Nil(deleted.After)
+
+	// Filtering on action should narrow it down.
+	resp, errWithCode = suite.adminProcessor.AuditLogGet(ctx,
+		adminAcct.ID, gtsmodel.AuditLogActionUpdate, "", rule.ID,
+		&paging.Page{Limit: 20},
+	)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+
+	// Fetch the one entry directly.
+	entry, errWithCode := suite.adminProcessor.AuditLogEntryGet(ctx, updated.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(updated.Changes, entry.Changes)
+
+	// Unknown entry should 404.
+	_, errWithCode = suite.adminProcessor.AuditLogEntryGet(ctx, "01J4KPJZWD6N8RZSWXCYWNAKF6")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestAuditLogTestSuite(t *testing.T) {
+	suite.Run(t, new(AuditLogTestSuite))
+}
@@ -0,0 +1,168 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxParkedDeliveryAge is the maximum age of a parked
+// journaled delivery, after which it's dropped rather
+// than being requeued when its domain's park expires.
+const maxParkedDeliveryAge = 7 * 24 * time.Hour
+
+// ScheduleDeliveryUnparking schedules a job to requeue
+// parked journaled deliveries, once per minute, if the
+// delivery journal is enabled. Else this is a no-op.
+func (p *Processor) ScheduleDeliveryUnparking() error {
+	if p.state.Workers.Delivery.Journal == nil {
+		// Journal
+		// disabled.
+		return nil
+	}
+
+	fn := func(ctx context.Context, _ time.Time) {
+		p.UnparkDeliveries(ctx)
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@deliveryunpark",
+		time.Time{},
+		time.Minute,
+		fn,
+	) {
+		panic("failed to schedule @deliveryunpark")
+	}
+
+	return nil
+}
+
+// UnparkDeliveries requeues all journaled deliveries to
+// domains whose park has expired, giving each a fresh set
+// of delivery attempts. Deliveries that have been parked
+// for longer than maxParkedDeliveryAge are dropped instead.
+func (p *Processor) UnparkDeliveries(ctx context.Context) {
+	journal := p.state.Workers.Delivery.Journal
+
+	for _, domain := range journal.ExpiredParks(ctx) {
+		parked, err := p.state.DB.GetParkedDeliveries(ctx, domain)
+		if err != nil {
+			log.Errorf(ctx, "error getting parked deliveries to %s: %v", domain, err)
+			continue
+		}
+
+		var requeued int
+
+		for _, pending := range parked {
+			if time.Since(pending.CreatedAt) > maxParkedDeliveryAge {
+				// Too old, just drop it.
+				p.dropJournaledDelivery(ctx, pending)
+				continue
+			}
+
+			// Unpark with fresh attempts.
+			pending.Parked = util.Ptr(false)
+			pending.Attempts = 0
+			pending.NextAttemptAt = time.Time{}
+
+			if err := p.state.DB.UpdatePendingDelivery(ctx,
+				pending,
+				"parked",
+				"attempts",
+				"next_attempt_at",
+			); err != nil {
+				log.Errorf(ctx, "error unparking delivery %s: %v", pending.ID, err)
+				continue
+			}
+
+			if err := p.requeueJournaledDelivery(ctx, pending); err != nil {
+				log.Errorf(ctx, "error requeueing delivery %s: %v", pending.ID, err)
+				p.dropJournaledDelivery(ctx, pending)
+				continue
+			}
+
+			requeued++
+		}
+
+		log.Infof(ctx, "unparked %d deliveries to %s", requeued, domain)
+	}
+}
+
+// fillJournaledDeliveries requeues all unparked journaled
+// deliveries (if the delivery journal is enabled), returning
+// the number successfully requeued, and the number of errors.
+func (p *Processor) fillJournaledDeliveries(ctx context.Context) (int, int) {
+	if p.state.Workers.Delivery.Journal == nil {
+		// Journal
+		// disabled.
+		return 0, 0
+	}
+
+	journaled, err := p.state.DB.GetPendingDeliveries(ctx)
+	if err != nil {
+		log.Errorf(ctx, "error getting journaled deliveries: %v", err)
+		return 0, 1
+	}
+
+	var requeued, errors int
+
+	for _, pending := range journaled {
+		if err := p.requeueJournaledDelivery(ctx, pending); err != nil {
+			log.Errorf(ctx, "error requeueing delivery %s: %v", pending.ID, err)
+			p.dropJournaledDelivery(ctx, pending)
+			errors++
+			continue
+		}
+
+		requeued++
+	}
+
+	return requeued, errors
+}
+
+// requeueJournaledDelivery restores a delivery.Delivery{} from
+// journaled delivery, signs it, and pushes it to the delivery
+// queue. It is not journaled again, as it's still journaled.
+func (p *Processor) requeueJournaledDelivery(ctx context.Context, pending *gtsmodel.PendingDelivery) error {
+	journal := p.state.Workers.Delivery.Journal
+
+	dlv, err := journal.Restore(pending)
+	if err != nil {
+		return gtserror.Newf("error restoring delivery: %w", err)
+	}
+
+	if err := p.signDelivery(ctx, dlv); err != nil {
+		return err
+	}
+
+	p.state.Workers.Delivery.Queue.Push(dlv)
+	return nil
+}
+
+// dropJournaledDelivery deletes the given journaled delivery.
+func (p *Processor) dropJournaledDelivery(ctx context.Context, pending *gtsmodel.PendingDelivery) {
+	if err := p.state.DB.DeletePendingDeliveryByID(ctx, pending.ID); err != nil {
+		log.Errorf(ctx, "error deleting journaled delivery %s: %v", pending.ID, err)
+	}
+}
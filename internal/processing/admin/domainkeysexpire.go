@@ -58,6 +58,12 @@ func (p *Processor) DomainKeysExpire(
 		return actionID, errWithCode
 	}
 
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AdminActionExpireKeys.String(),
+		gtsmodel.AuditLogTargetDomain, domain,
+		nil, nil,
+	)
+
 	return actionID, nil
 }
 
@@ -69,11 +69,17 @@ func (p *Processor) DomainPermissionCreate(
 	privateComment string,
 	subscriptionID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	var (
+		perm        *apimodel.DomainPermission
+		actionID    string
+		errWithCode gtserror.WithCode
+	)
+
 	switch permissionType {
 
 	// Explicitly block a domain.
 	case gtsmodel.DomainPermissionBlock:
-		return p.createDomainBlock(
+		perm, actionID, errWithCode = p.createDomainBlock(
 			ctx,
 			adminAcct,
 			domain,
@@ -85,7 +91,7 @@ func (p *Processor) DomainPermissionCreate(
 
 	// Explicitly allow a domain.
 	case gtsmodel.DomainPermissionAllow:
-		return p.createDomainAllow(
+		perm, actionID, errWithCode = p.createDomainAllow(
 			ctx,
 			adminAcct,
 			domain,
@@ -100,6 +106,18 @@ func (p *Processor) DomainPermissionCreate(
 		err := gtserror.Newf("unrecognized permission type %d", permissionType)
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
+
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		domainPermAuditTarget(permissionType), perm.ID,
+		nil, perm,
+	)
+
+	return perm, actionID, nil
 }
 
 // DomainPermissionDelete removes one domain block with the given ID,
@@ -113,11 +131,17 @@ func (p *Processor) DomainPermissionDelete(
 	adminAcct *gtsmodel.Account,
 	domainBlockID string,
 ) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	var (
+		perm        *apimodel.DomainPermission
+		actionID    string
+		errWithCode gtserror.WithCode
+	)
+
 	switch permissionType {
 
 	// Delete explicit domain block.
 	case gtsmodel.DomainPermissionBlock:
-		return p.deleteDomainBlock(
+		perm, actionID, errWithCode = p.deleteDomainBlock(
 			ctx,
 			adminAcct,
 			domainBlockID,
@@ -125,7 +149,7 @@ func (p *Processor) DomainPermissionDelete(
 
 	// Delete explicit domain allow.
 	case gtsmodel.DomainPermissionAllow:
-		return p.deleteDomainAllow(
+		perm, actionID, errWithCode = p.deleteDomainAllow(
 			ctx,
 			adminAcct,
 			domainBlockID,
@@ -137,6 +161,27 @@ func (p *Processor) DomainPermissionDelete(
 		err := gtserror.Newf("unrecognized permission type %d", permissionType)
 		return nil, "", gtserror.NewErrorInternalError(err)
 	}
+
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		domainPermAuditTarget(permissionType), domainBlockID,
+		perm, nil,
+	)
+
+	return perm, actionID, nil
+}
+
+// domainPermAuditTarget returns the audit
+// log target type for the given permission type.
+func domainPermAuditTarget(permType gtsmodel.DomainPermissionType) gtsmodel.AuditLogTargetType {
+	if permType == gtsmodel.DomainPermissionAllow {
+		return gtsmodel.AuditLogTargetDomainAllow
+	}
+	return gtsmodel.AuditLogTargetDomainBlock
 }
 
 // DomainPermissionsImport handles the import of multiple
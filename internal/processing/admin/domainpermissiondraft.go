@@ -0,0 +1,167 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// DomainPermissionDraftsGet returns all domain permission drafts
+// of the given permission type (or all types, if permType is
+// DomainPermissionUnknown), optionally filtered by subscription ID.
+func (p *Processor) DomainPermissionDraftsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	subscriptionID string,
+) ([]*apimodel.DomainPermission, gtserror.WithCode) {
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx, permType, subscriptionID)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDrafts := make([]*apimodel.DomainPermission, len(drafts))
+	for i, draft := range drafts {
+		apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		apiDrafts[i] = apiDraft
+	}
+
+	return apiDrafts, nil
+}
+
+// DomainPermissionDraftGet returns one
+// domain permission draft with the given id.
+func (p *Processor) DomainPermissionDraftGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiDomainPerm(ctx, draft, false)
+}
+
+// DomainPermissionDraftAccept turns the domain permission draft
+// with the given id into an enforced domain permission, processing
+// side effects as usual, and removes the draft.
+//
+// Return values are the new (or existing) domain permission, the
+// ID of the resulting admin action, and/or an error.
+func (p *Processor) DomainPermissionDraftAccept(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.DomainPermission, string, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, "", errWithCode
+	}
+
+	perm, actionID, errWithCode := p.DomainPermissionCreate(
+		ctx,
+		draft.PermissionType,
+		adminAcct,
+		draft.Domain,
+		*draft.Obfuscate,
+		draft.PublicComment,
+		draft.PrivateComment,
+		draft.SubscriptionID,
+	)
+	if errWithCode != nil {
+		return nil, actionID, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, actionID, gtserror.NewErrorInternalError(err)
+	}
+
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionAccept,
+		gtsmodel.AuditLogTargetDomainPermissionDraft, draft.ID,
+		nil, perm,
+	)
+
+	return perm, actionID, nil
+}
+
+// DomainPermissionDraftRemove removes the domain permission draft
+// with the given id, without enforcing it. If the draft was created
+// by a subscription that still lists the domain, it will be drafted
+// again next time the subscription is processed.
+func (p *Processor) DomainPermissionDraftRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.DomainPermission, gtserror.WithCode) {
+	draft, errWithCode := p.getDomainPermissionDraft(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission draft: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiDraft, errWithCode := p.apiDomainPerm(ctx, draft, false)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetDomainPermissionDraft, draft.ID,
+		apiDraft, nil,
+	)
+
+	return apiDraft, nil
+}
+
+// getDomainPermissionDraft fetches the domain permission
+// draft with the given id, wrapping errors for the caller.
+func (p *Processor) getDomainPermissionDraft(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionDraft, gtserror.WithCode) {
+	draft, err := p.state.DB.GetDomainPermissionDraftByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no domain permission draft exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting domain permission draft %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return draft, nil
+}
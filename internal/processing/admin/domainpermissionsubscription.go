@@ -0,0 +1,405 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// DomainPermissionSubscriptionsGet returns all domain permission
+// subscriptions of the given permission type, or of all types if
+// permType is DomainPermissionUnknown, highest priority first.
+func (p *Processor) DomainPermissionSubscriptionsGet(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+) ([]*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	subs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSubs := make([]*apimodel.DomainPermissionSubscription, len(subs))
+	for i, sub := range subs {
+		apiSubs[i] = p.converter.DomainPermSubToAPIDomainPermSub(sub)
+	}
+
+	return apiSubs, nil
+}
+
+// DomainPermissionSubscriptionGet returns one
+// domain permission subscription with the given id.
+func (p *Processor) DomainPermissionSubscriptionGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.DomainPermSubToAPIDomainPermSub(sub), nil
+}
+
+// DomainPermissionSubscriptionCreate creates a new domain
+// permission subscription using the given form. The list
+// is fetched and processed on the next scheduled run.
+func (p *Processor) DomainPermissionSubscriptionCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	if form.URI == nil {
+		const text = "uri must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if form.PermissionType == nil {
+		const text = "permission_type must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	sub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id.NewULID(),
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		AsDraft:            util.Ptr(true),
+		ContentType:        gtsmodel.DomainPermSubContentTypeCSV,
+	}
+
+	if errWithCode := applyDomainPermSubForm(sub, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.checkDomainPermSubURI(ctx, sub); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.PutDomainPermissionSubscription(ctx, sub); err != nil {
+		err := gtserror.Newf("db error putting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSub := p.converter.DomainPermSubToAPIDomainPermSub(sub)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetDomainPermissionSubscription, sub.ID,
+		nil, apiSub,
+	)
+
+	return apiSub, nil
+}
+
+// DomainPermissionSubscriptionUpdate updates the domain permission
+// subscription with the given id, using any fields set on the form.
+// The permission type of an existing subscription can't be changed.
+func (p *Processor) DomainPermissionSubscriptionUpdate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.PermissionType != nil &&
+		gtsmodel.NewDomainPermissionType(*form.PermissionType) != sub.PermissionType {
+		const text = "permission_type of an existing subscription cannot be changed"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	before := p.converter.DomainPermSubToAPIDomainPermSub(sub)
+
+	oldURI := sub.URI
+	if errWithCode := applyDomainPermSubForm(sub, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if sub.URI != oldURI {
+		if errWithCode := p.checkDomainPermSubURI(ctx, sub); errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		// New list, so make sure it gets
+		// fetched in full on the next run.
+		sub.ETag = ""
+		sub.LastModified = time.Time{}
+	}
+
+	if err := p.state.DB.UpdateDomainPermissionSubscription(ctx, sub); err != nil {
+		err := gtserror.Newf("db error updating domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSub := p.converter.DomainPermSubToAPIDomainPermSub(sub)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetDomainPermissionSubscription, sub.ID,
+		before, apiSub,
+	)
+
+	return apiSub, nil
+}
+
+// DomainPermissionSubscriptionRemove removes the domain permission
+// subscription with the given id, along with any drafts it created.
+//
+// If removeChildren is true, domain permissions created by the
+// subscription are removed too (with side effects). Otherwise
+// they're orphaned, ie., kept as if they were created manually.
+func (p *Processor) DomainPermissionSubscriptionRemove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	removeChildren bool,
+) (*apimodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, errWithCode := p.getDomainPermissionSubscription(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraftsBySubscriptionID(ctx, sub.ID); err != nil {
+		err := gtserror.Newf("db error deleting drafts: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	perms, err := p.getSubscriptionDomainPerms(ctx, sub)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	for _, perm := range perms {
+		if removeChildren {
+			if _, _, errWithCode := p.DomainPermissionDelete(
+				ctx,
+				sub.PermissionType,
+				adminAcct,
+				perm.GetID(),
+			); errWithCode != nil {
+				return nil, errWithCode
+			}
+			continue
+		}
+
+		if err := p.orphanDomainPerm(ctx, perm); err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionSubscription(ctx, sub.ID); err != nil {
+		err := gtserror.Newf("db error deleting domain permission subscription: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiSub := p.converter.DomainPermSubToAPIDomainPermSub(sub)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetDomainPermissionSubscription, sub.ID,
+		apiSub, nil,
+	)
+
+	return apiSub, nil
+}
+
+// getDomainPermissionSubscription fetches the domain permission
+// subscription with the given id, wrapping errors for the caller.
+func (p *Processor) getDomainPermissionSubscription(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.DomainPermissionSubscription, gtserror.WithCode) {
+	sub, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			err = fmt.Errorf("no domain permission subscription exists with id %s", id)
+			return nil, gtserror.NewErrorNotFound(err, err.Error())
+		}
+
+		err = gtserror.Newf("db error getting domain permission subscription %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return sub, nil
+}
+
+// checkDomainPermSubURI ensures no other
+// subscription already uses the URI of sub.
+func (p *Processor) checkDomainPermSubURI(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) gtserror.WithCode {
+	subs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, gtsmodel.DomainPermissionUnknown)
+	if err != nil {
+		err := gtserror.Newf("db error getting domain permission subscriptions: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, other := range subs {
+		if other.ID != sub.ID && other.URI == sub.URI {
+			text := fmt.Sprintf("domain permission subscription %s already exists with uri %s", other.ID, sub.URI)
+			return gtserror.NewErrorConflict(errors.New(text), text)
+		}
+	}
+
+	return nil
+}
+
+// applyDomainPermSubForm validates any set
+// fields of form, and applies them to sub.
+func applyDomainPermSubForm(
+	sub *gtsmodel.DomainPermissionSubscription,
+	form *apimodel.DomainPermissionSubscriptionRequest,
+) gtserror.WithCode {
+	if form.PermissionType != nil {
+		permType := gtsmodel.NewDomainPermissionType(*form.PermissionType)
+		if permType == gtsmodel.DomainPermissionUnknown {
+			const text = "permission_type must be one of block, allow"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		sub.PermissionType = permType
+	}
+
+	if form.URI != nil {
+		uri, err := url.Parse(*form.URI)
+		if err != nil || uri.Host == "" ||
+			(uri.Scheme != "https" && uri.Scheme != "http") {
+			const text = "uri must be an absolute http(s) url"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		sub.URI = uri.String()
+	}
+
+	if form.ContentType != nil {
+		contentType := gtsmodel.DomainPermSubContentType(*form.ContentType)
+		switch contentType {
+		case gtsmodel.DomainPermSubContentTypeCSV,
+			gtsmodel.DomainPermSubContentTypeJSON,
+			gtsmodel.DomainPermSubContentTypePlaintext:
+			sub.ContentType = contentType
+		default:
+			text := fmt.Sprintf(
+				"content_type must be one of %s, %s, %s",
+				gtsmodel.DomainPermSubContentTypeCSV,
+				gtsmodel.DomainPermSubContentTypeJSON,
+				gtsmodel.DomainPermSubContentTypePlaintext,
+			)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+	}
+
+	if form.Priority != nil {
+		if *form.Priority < 0 || *form.Priority > 255 {
+			const text = "priority must be a number in the range 0 to 255"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		sub.Priority = uint8(*form.Priority) // #nosec G115 -- Just validated.
+	}
+
+	if form.Title != nil {
+		sub.Title = text.SanitizeToPlaintext(*form.Title)
+	}
+
+	if form.AsDraft != nil {
+		sub.AsDraft = util.Ptr(*form.AsDraft)
+	}
+
+	return nil
+}
+
+// getSubscriptionDomainPerms returns all domain permissions
+// (blocks or allows) currently owned by the given subscription.
+func (p *Processor) getSubscriptionDomainPerms(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) ([]gtsmodel.DomainPermission, error) {
+	var perms []gtsmodel.DomainPermission
+
+	switch sub.PermissionType {
+	case gtsmodel.DomainPermissionBlock:
+		blocks, err := p.state.DB.GetDomainBlocks(ctx)
+		if err != nil {
+			return nil, gtserror.Newf("db error getting domain blocks: %w", err)
+		}
+
+		for _, block := range blocks {
+			if block.SubscriptionID == sub.ID {
+				perms = append(perms, block)
+			}
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		allows, err := p.state.DB.GetDomainAllows(ctx)
+		if err != nil {
+			return nil, gtserror.Newf("db error getting domain allows: %w", err)
+		}
+
+		for _, allow := range allows {
+			if allow.SubscriptionID == sub.ID {
+				perms = append(perms, allow)
+			}
+		}
+	}
+
+	return perms, nil
+}
+
+// setDomainPermSubscriptionID updates the subscription ID of
+// the given domain permission, ie., which subscription owns it.
+func (p *Processor) setDomainPermSubscriptionID(
+	ctx context.Context,
+	perm gtsmodel.DomainPermission,
+	subscriptionID string,
+) error {
+	var err error
+
+	switch perm := perm.(type) {
+	case *gtsmodel.DomainBlock:
+		perm.SubscriptionID = subscriptionID
+		err = p.state.DB.UpdateDomainBlock(ctx, perm, "subscription_id")
+	case *gtsmodel.DomainAllow:
+		perm.SubscriptionID = subscriptionID
+		err = p.state.DB.UpdateDomainAllow(ctx, perm, "subscription_id")
+	}
+
+	if err != nil {
+		return gtserror.Newf("db error updating domain %s %s: %w", perm.GetType().String(), perm.GetDomain(), err)
+	}
+
+	return nil
+}
+
+// orphanDomainPerm detaches the given domain permission from its
+// subscription, so it's treated as having been created manually.
+func (p *Processor) orphanDomainPerm(ctx context.Context, perm gtsmodel.DomainPermission) error {
+	log.Debugf(ctx, "orphaning domain %s %s", perm.GetType().String(), perm.GetDomain())
+	return p.setDomainPermSubscriptionID(ctx, perm, "")
+}
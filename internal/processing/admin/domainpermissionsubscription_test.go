@@ -0,0 +1,339 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DomainPermissionSubscriptionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) newSubscription(
+	ctx context.Context,
+	id string,
+	uri string,
+	contentType gtsmodel.DomainPermSubContentType,
+	priority uint8,
+	asDraft bool,
+) *gtsmodel.DomainPermissionSubscription {
+	sub := &gtsmodel.DomainPermissionSubscription{
+		ID:                 id,
+		Priority:           priority,
+		PermissionType:     gtsmodel.DomainPermissionBlock,
+		AsDraft:            util.Ptr(asDraft),
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+		URI:                uri,
+		ContentType:        contentType,
+	}
+
+	if err := suite.db.PutDomainPermissionSubscription(ctx, sub); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return sub
+}
+
+// process runs domain permission subscriptions processing,
+// and waits for any resulting side effects to finish.
+func (suite *DomainPermissionSubscriptionTestSuite) process(ctx context.Context) {
+	suite.adminProcessor.DomainPermissionSubscriptionsProcess(ctx)
+	suite.awaitActions()
+}
+
+// awaitActions waits for all running admin actions to finish.
+func (suite *DomainPermissionSubscriptionTestSuite) awaitActions() {
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessCSV() {
+	ctx := context.Background()
+
+	sub := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/baddies.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		100, false,
+	)
+
+	suite.process(ctx)
+
+	// Suspended domains should be blocked,
+	// and owned by the subscription.
+	for _, domain := range []string{
+		"bumfaces.net",
+		"peepee.poopoo",
+		"nothanks.com",
+	} {
+		block, err := suite.db.GetDomainBlock(ctx, domain)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(sub.ID, block.SubscriptionID)
+	}
+
+	block, err := suite.db.GetDomainBlock(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("big jerks", block.PublicComment)
+
+	// Silenced domain should be skipped.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "silenced.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	// Fetch details should be stored.
+	sub, err = suite.db.GetDomainPermissionSubscriptionByID(ctx, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(sub.SuccessfullyFetchedAt)
+	suite.Empty(sub.Error)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessAsDraft() {
+	ctx := context.Background()
+
+	sub := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/baddies.txt",
+		gtsmodel.DomainPermSubContentTypePlaintext,
+		100, true,
+	)
+
+	suite.process(ctx)
+
+	// Nothing should be blocked yet.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "bumfaces.net")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	drafts, err := suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(drafts, 3)
+
+	// Accepting a draft should block the
+	// domain, and remove the draft.
+	adminAcct := suite.testAccounts["admin_account"]
+	perm, _, errWithCode := suite.adminProcessor.DomainPermissionDraftAccept(ctx, adminAcct, drafts[0].ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.awaitActions()
+	suite.Equal(sub.ID, perm.SubscriptionID)
+
+	drafts, err = suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(drafts, 2)
+
+	// Processing again shouldn't
+	// duplicate any drafts.
+	suite.process(ctx)
+
+	drafts, err = suite.db.GetDomainPermissionDrafts(ctx, gtsmodel.DomainPermissionBlock, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(drafts, 2)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessPriority() {
+	ctx := context.Background()
+
+	low := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/baddies.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		10, false,
+	)
+
+	suite.process(ctx)
+
+	high := suite.newSubscription(ctx,
+		"01JGE68ZP0ZX4QGSN9S1WSHB8F",
+		"https://lists.example.org/baddies.json",
+		gtsmodel.DomainPermSubContentTypeJSON,
+		200, false,
+	)
+
+	suite.process(ctx)
+
+	// Higher priority subscription
+	// should have claimed the blocks.
+	block, err := suite.db.GetDomainBlock(ctx, "peepee.poopoo")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(high.ID, block.SubscriptionID)
+
+	// Manually created block should be untouched.
+	block, err = suite.db.GetDomainBlock(ctx, "replyguys.com")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(block.SubscriptionID)
+
+	// Removing the high priority subscription
+	// with its children should lift its blocks.
+	adminAcct := suite.testAccounts["admin_account"]
+	if _, errWithCode := suite.adminProcessor.DomainPermissionSubscriptionRemove(
+		ctx, adminAcct, high.ID, true,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.awaitActions()
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, "peepee.poopoo")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+
+	// Next run, the low priority
+	// subscription should take over.
+	suite.process(ctx)
+
+	block, err = suite.db.GetDomainBlock(ctx, "peepee.poopoo")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(low.ID, block.SubscriptionID)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessLift() {
+	ctx := context.Background()
+
+	sub := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/baddies.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		100, false,
+	)
+
+	// Block a domain on behalf of the
+	// subscription that it doesn't list.
+	adminAcct := suite.testAccounts["admin_account"]
+	if _, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		"not-a-baddie.example.org",
+		false, "", "",
+		sub.ID,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.awaitActions()
+
+	suite.process(ctx)
+
+	// No longer listed, so should be lifted.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "not-a-baddie.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(blocked)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessFetchError() {
+	ctx := context.Background()
+
+	sub := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/does-not-exist.csv",
+		gtsmodel.DomainPermSubContentTypeCSV,
+		100, false,
+	)
+
+	suite.process(ctx)
+
+	sub, err := suite.db.GetDomainPermissionSubscriptionByID(ctx, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(sub.FetchedAt)
+	suite.Zero(sub.SuccessfullyFetchedAt)
+	suite.NotEmpty(sub.Error)
+}
+
+func (suite *DomainPermissionSubscriptionTestSuite) TestProcessTooBig() {
+	ctx := context.Background()
+
+	sub := suite.newSubscription(ctx,
+		"01JGE681TQSBPAV59GZXPKE62H",
+		"https://lists.example.org/huge.txt",
+		gtsmodel.DomainPermSubContentTypePlaintext,
+		100, false,
+	)
+
+	// Block a domain on behalf of the
+	// subscription that it doesn't list.
+	adminAcct := suite.testAccounts["admin_account"]
+	if _, _, errWithCode := suite.adminProcessor.DomainPermissionCreate(
+		ctx,
+		gtsmodel.DomainPermissionBlock,
+		adminAcct,
+		"not-a-baddie.example.org",
+		false, "", "",
+		sub.ID,
+	); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.awaitActions()
+
+	suite.process(ctx)
+
+	// List was too big to use, so
+	// nothing should be lifted.
+	blocked, err := suite.db.IsDomainBlocked(ctx, "not-a-baddie.example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(blocked)
+
+	sub, err = suite.db.GetDomainPermissionSubscriptionByID(ctx, sub.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(sub.SuccessfullyFetchedAt)
+	suite.Contains(sub.Error, "larger than max size")
+}
+
+func TestDomainPermissionSubscriptionTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPermissionSubscriptionTestSuite))
+}
@@ -0,0 +1,732 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/gruf/go-kv"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// maxDomainPermSubSize is the maximum
+// size of list we're willing to download
+// for a domain permission subscription.
+const maxDomainPermSubSize = 10 << 20 // 10MiB
+
+// subscribedDomainPerm is one entry
+// parsed from a subscribed list.
+type subscribedDomainPerm struct {
+	domain        string
+	publicComment string
+	obfuscate     bool
+}
+
+// ScheduleDomainPermissionSubscriptions schedules processing of
+// all domain permission subscriptions using configured parameters.
+//
+// Returns an error if `InstanceSubscriptionsProcessFrom`
+// is not a valid format (hh:mm), or if
+// `InstanceSubscriptionsProcessEvery` is not positive.
+func (p *Processor) ScheduleDomainPermissionSubscriptions() error {
+	const hourMinute = "15:04"
+
+	var (
+		now            = time.Now()
+		processEvery   = config.GetInstanceSubscriptionsProcessEvery()
+		processFromStr = config.GetInstanceSubscriptionsProcessFrom()
+	)
+
+	if processEvery <= 0 {
+		return gtserror.Newf(
+			"instance-subscriptions-process-every must be greater than 0, was %s",
+			processEvery,
+		)
+	}
+
+	// Parse processFromStr as hh:mm.
+	// Resulting time will be on 1 Jan year zero.
+	processFrom, err := time.Parse(hourMinute, processFromStr)
+	if err != nil {
+		return gtserror.Newf(
+			"error parsing '%s' in time format 'hh:mm': %w",
+			processFromStr, err,
+		)
+	}
+
+	firstProcessAt := time.Date(
+		now.Year(),
+		now.Month(),
+		now.Day(),
+		processFrom.Hour(),
+		processFrom.Minute(),
+		0,
+		0,
+		now.Location(),
+	)
+
+	// Ensure first run is in the future.
+	for firstProcessAt.Before(now) {
+		firstProcessAt = firstProcessAt.Add(processEvery)
+	}
+
+	fn := func(ctx context.Context, start time.Time) {
+		log.Info(ctx, "starting domain permission subscriptions processing")
+		p.DomainPermissionSubscriptionsProcess(ctx)
+		log.Infof(ctx, "finished domain permission subscriptions processing after %s", time.Since(start))
+	}
+
+	log.Infof(nil,
+		"scheduling domain permission subscriptions processing to run every %s, starting from %s; next run will be at %s",
+		processEvery, processFromStr, firstProcessAt,
+	)
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@domainpermsubs",
+		firstProcessAt,
+		processEvery,
+		fn,
+	) {
+		panic("failed to schedule @domainpermsubs")
+	}
+
+	return nil
+}
+
+// DomainPermissionSubscriptionsProcess fetches and processes
+// every domain permission subscription, of each permission type,
+// in order of priority. Errors are logged, and stored on the
+// subscription that caused them.
+func (p *Processor) DomainPermissionSubscriptionsProcess(ctx context.Context) {
+	for _, permType := range []gtsmodel.DomainPermissionType{
+		gtsmodel.DomainPermissionBlock,
+		gtsmodel.DomainPermissionAllow,
+	} {
+		// Subscriptions come back highest priority first,
+		// so by the time a lower priority subscription is
+		// processed, any higher priority ones will already
+		// have claimed entries they share with it.
+		subs, err := p.state.DB.GetDomainPermissionSubscriptions(ctx, permType)
+		if err != nil {
+			log.Errorf(ctx, "db error getting domain permission subscriptions: %v", err)
+			continue
+		}
+
+		for _, sub := range subs {
+			p.processDomainPermissionSubscription(ctx, sub)
+		}
+	}
+}
+
+// processDomainPermissionSubscription fetches the list of the given
+// subscription, and creates, claims, or retracts domain permissions
+// (or drafts) so that they match the list.
+func (p *Processor) processDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) {
+	l := log.WithContext(ctx).WithFields(kv.Fields{
+		{"subscriptionID", sub.ID},
+		{"uri", sub.URI},
+	}...)
+
+	perms, err := p.fetchDomainPermissionSubscription(ctx, sub)
+	if err != nil {
+		// Store the error so admins can see
+		// what went wrong, but otherwise leave
+		// existing domain permissions alone.
+		l.Warnf("error fetching list: %v", err)
+		sub.Error = err.Error()
+		p.updateFetchedDomainPermSub(ctx, sub)
+		return
+	}
+
+	// Fetch was successful.
+	sub.SuccessfullyFetchedAt = sub.FetchedAt
+	sub.Error = ""
+	defer p.updateFetchedDomainPermSub(ctx, sub)
+
+	if perms == nil {
+		l.Debug("list unchanged since last fetch")
+		return
+	}
+
+	// Get the creator of the subscription
+	// to attribute domain permissions to.
+	adminAcct, err := p.state.DB.GetAccountByID(ctx, sub.CreatedByAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		l.Errorf("db error getting subscription creator: %v", err)
+		return
+	}
+
+	if adminAcct == nil {
+		// Creator's gone, fall
+		// back to instance account.
+		adminAcct, err = p.state.DB.GetInstanceAccount(ctx, "")
+		if err != nil {
+			l.Errorf("db error getting instance account: %v", err)
+			return
+		}
+	}
+
+	asDraft := util.PtrOrValue(sub.AsDraft, true)
+	listed := make(map[string]struct{}, len(perms))
+
+	for _, perm := range perms {
+		listed[perm.domain] = struct{}{}
+
+		if asDraft {
+			err = p.draftSubscribedDomainPerm(ctx, sub, adminAcct, perm)
+		} else {
+			err = p.applySubscribedDomainPerm(ctx, sub, adminAcct, perm)
+		}
+
+		if err != nil {
+			l.Warnf("error processing domain %s: %v", perm.domain, err)
+		}
+	}
+
+	// Drafts from this subscription that are
+	// no longer listed don't need reviewing.
+	drafts, err := p.state.DB.GetDomainPermissionDrafts(ctx, sub.PermissionType, sub.ID)
+	if err != nil {
+		l.Errorf("db error getting drafts: %v", err)
+		return
+	}
+
+	for _, draft := range drafts {
+		if _, ok := listed[draft.Domain]; ok {
+			continue
+		}
+
+		if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+			l.Errorf("db error deleting draft %s: %v", draft.ID, err)
+		}
+	}
+
+	if asDraft {
+		// Review mode: lifting
+		// entries is up to admins.
+		return
+	}
+
+	// Lift domain permissions owned by this
+	// subscription that are no longer listed.
+	owned, err := p.getSubscriptionDomainPerms(ctx, sub)
+	if err != nil {
+		l.Error(err)
+		return
+	}
+
+	for _, perm := range owned {
+		if _, ok := listed[perm.GetDomain()]; ok {
+			continue
+		}
+
+		l.Infof("lifting domain %s %s no longer listed", sub.PermissionType.String(), perm.GetDomain())
+		if _, _, errWithCode := p.DomainPermissionDelete(
+			ctx,
+			sub.PermissionType,
+			adminAcct,
+			perm.GetID(),
+		); errWithCode != nil {
+			l.Warnf("error lifting domain %s: %v", perm.GetDomain(), errWithCode)
+		}
+	}
+}
+
+// applySubscribedDomainPerm ensures the given listed entry is
+// enforced as a domain permission, either by creating it, or by
+// claiming an existing one created by a lower priority subscription.
+// Domain permissions created manually are never touched.
+func (p *Processor) applySubscribedDomainPerm(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	adminAcct *gtsmodel.Account,
+	perm *subscribedDomainPerm,
+) error {
+	existing, err := p.getDomainPerm(ctx, sub.PermissionType, perm.domain)
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		// Brand new, create with side effects.
+		_, _, errWithCode := p.DomainPermissionCreate(
+			ctx,
+			sub.PermissionType,
+			adminAcct,
+			perm.domain,
+			perm.obfuscate,
+			perm.publicComment,
+			"",
+			sub.ID,
+		)
+		if errWithCode != nil {
+			return errWithCode
+		}
+
+		// A draft is now pointless.
+		return p.deleteDomainPermDraft(ctx, sub.PermissionType, perm.domain)
+	}
+
+	switch ownerID := existing.GetSubscriptionID(); {
+	case ownerID == "":
+		// Created manually,
+		// leave it be.
+		return nil
+
+	case ownerID == sub.ID:
+		// Already ours.
+		return nil
+
+	default:
+		owner, err := p.state.DB.GetDomainPermissionSubscriptionByID(ctx, ownerID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting subscription %s: %w", ownerID, err)
+		}
+
+		if owner != nil && owner.Priority >= sub.Priority {
+			// Owner takes precedence
+			// (or it's a tie); leave it.
+			return nil
+		}
+
+		// Owner is gone or has lower
+		// priority than us, claim it.
+		return p.setDomainPermSubscriptionID(ctx, existing, sub.ID)
+	}
+}
+
+// draftSubscribedDomainPerm creates a draft for the given listed
+// entry, unless it's already enforced or drafted.
+func (p *Processor) draftSubscribedDomainPerm(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+	adminAcct *gtsmodel.Account,
+	perm *subscribedDomainPerm,
+) error {
+	existing, err := p.getDomainPerm(ctx, sub.PermissionType, perm.domain)
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		// Already in force.
+		return nil
+	}
+
+	draft, err := p.state.DB.GetDomainPermissionDraftByDomain(ctx, sub.PermissionType, perm.domain)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error getting draft: %w", err)
+	}
+
+	if draft != nil {
+		// Already drafted.
+		return nil
+	}
+
+	draft = &gtsmodel.DomainPermissionDraft{
+		ID:                 id.NewULID(),
+		PermissionType:     sub.PermissionType,
+		Domain:             perm.domain,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+		PublicComment:      perm.publicComment,
+		Obfuscate:          &perm.obfuscate,
+		SubscriptionID:     sub.ID,
+	}
+
+	if err := p.state.DB.PutDomainPermissionDraft(ctx, draft); err != nil {
+		return gtserror.Newf("db error putting draft: %w", err)
+	}
+
+	return nil
+}
+
+// getDomainPerm returns the domain permission of
+// the given type for domain, or nil if there's none.
+func (p *Processor) getDomainPerm(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) (gtsmodel.DomainPermission, error) {
+	var (
+		perm gtsmodel.DomainPermission
+		err  error
+	)
+
+	switch permType {
+	case gtsmodel.DomainPermissionBlock:
+		var block *gtsmodel.DomainBlock
+		block, err = p.state.DB.GetDomainBlock(ctx, domain)
+		if block != nil {
+			perm = block
+		}
+
+	case gtsmodel.DomainPermissionAllow:
+		var allow *gtsmodel.DomainAllow
+		allow, err = p.state.DB.GetDomainAllow(ctx, domain)
+		if allow != nil {
+			perm = allow
+		}
+	}
+
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting domain %s %s: %w", permType.String(), domain, err)
+	}
+
+	return perm, nil
+}
+
+// deleteDomainPermDraft deletes the draft of
+// the given type for domain, if there is one.
+func (p *Processor) deleteDomainPermDraft(
+	ctx context.Context,
+	permType gtsmodel.DomainPermissionType,
+	domain string,
+) error {
+	draft, err := p.state.DB.GetDomainPermissionDraftByDomain(ctx, permType, domain)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			return nil
+		}
+		return gtserror.Newf("db error getting draft: %w", err)
+	}
+
+	if err := p.state.DB.DeleteDomainPermissionDraft(ctx, draft.ID); err != nil {
+		return gtserror.Newf("db error deleting draft: %w", err)
+	}
+
+	return nil
+}
+
+// updateFetchedDomainPermSub stores the
+// fetch-related fields of the given subscription.
+func (p *Processor) updateFetchedDomainPermSub(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) {
+	if err := p.state.DB.UpdateDomainPermissionSubscription(
+		ctx, sub,
+		"fetched_at",
+		"successfully_fetched_at",
+		"etag",
+		"last_modified",
+		"error",
+	); err != nil {
+		log.Errorf(ctx, "db error updating domain permission subscription %s: %v", sub.ID, err)
+	}
+}
+
+// fetchDomainPermissionSubscription dereferences the list of the
+// given subscription, and parses it according to its content type.
+//
+// If the list hasn't changed since the last successful fetch,
+// the returned slice and error will both be nil.
+func (p *Processor) fetchDomainPermissionSubscription(
+	ctx context.Context,
+	sub *gtsmodel.DomainPermissionSubscription,
+) ([]*subscribedDomainPerm, error) {
+	sub.FetchedAt = time.Now()
+
+	// Lists aren't tied to any one
+	// user, so fetch as the instance.
+	tsport, err := p.transport.NewTransportForUsername(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error creating transport: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(
+		// Running in the background,
+		// nobody's waiting on this.
+		gtscontext.SetFastFail(ctx),
+		http.MethodGet, sub.URI, nil,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error creating request: %w", err)
+	}
+
+	req.Header.Add("Accept", string(sub.ContentType))
+
+	// Only fetch the whole list
+	// again if it's been changed.
+	if sub.ETag != "" {
+		req.Header.Add("If-None-Match", sub.ETag)
+	}
+	if !sub.LastModified.IsZero() {
+		req.Header.Add("If-Modified-Since", sub.LastModified.UTC().Format(http.TimeFormat))
+	}
+
+	rsp, err := tsport.GET(req)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching list: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode == http.StatusNotModified {
+		return nil, nil
+	}
+
+	if rsp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected response status fetching list: %s", rsp.Status)
+	}
+
+	// Read one byte over the max, so we can tell
+	// a list that's too big from one that's exactly
+	// the max size. A truncated list must never be
+	// used, or we'd lift everything past the cutoff.
+	b, err := io.ReadAll(io.LimitReader(rsp.Body, maxDomainPermSubSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("error reading list: %w", err)
+	}
+
+	if len(b) > maxDomainPermSubSize {
+		return nil, fmt.Errorf("list larger than max size of %d bytes", maxDomainPermSubSize)
+	}
+
+	body := bytes.NewReader(b)
+
+	var perms []*subscribedDomainPerm
+	switch sub.ContentType {
+	case gtsmodel.DomainPermSubContentTypeCSV:
+		perms, err = parseDomainPermsCSV(body, sub.PermissionType)
+	case gtsmodel.DomainPermSubContentTypeJSON:
+		perms, err = parseDomainPermsJSON(body)
+	case gtsmodel.DomainPermSubContentTypePlaintext:
+		perms, err = parseDomainPermsPlaintext(body)
+	default:
+		err = fmt.Errorf("unsupported content type %s", sub.ContentType)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Don't lift every entry just because
+	// a list was accidentally emptied.
+	perms = dedupeDomainPerms(perms)
+	if len(perms) == 0 {
+		return nil, errors.New("list contained no valid entries")
+	}
+
+	// Only update these once we know the
+	// list is OK, or we might skip a fixed
+	// version next time due to conditional GET.
+	sub.ETag = rsp.Header.Get("ETag")
+	sub.LastModified, _ = http.ParseTime(rsp.Header.Get("Last-Modified"))
+
+	return perms, nil
+}
+
+// parseDomainPermsCSV parses a Mastodon-style domain block
+// CSV export, with a header row of columns like "#domain".
+// For blocks, only entries with severity "suspend" are used,
+// since "silence" and "noop" have no equivalent here.
+func parseDomainPermsCSV(
+	r io.Reader,
+	permType gtsmodel.DomainPermissionType,
+) ([]*subscribedDomainPerm, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1 // Allow variable.
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("error reading csv header: %w", err)
+	}
+
+	var (
+		domainI    = -1
+		severityI  = -1
+		commentI   = -1
+		obfuscateI = -1
+	)
+
+	for i, column := range header {
+		switch strings.TrimPrefix(strings.TrimSpace(column), "#") {
+		case "domain":
+			domainI = i
+		case "severity":
+			severityI = i
+		case "public_comment":
+			commentI = i
+		case "obfuscate":
+			obfuscateI = i
+		}
+	}
+
+	if domainI == -1 {
+		return nil, errors.New("csv header has no domain column")
+	}
+
+	field := func(record []string, i int) string {
+		if i == -1 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	var perms []*subscribedDomainPerm
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading csv: %w", err)
+		}
+
+		if permType == gtsmodel.DomainPermissionBlock {
+			severity := field(record, severityI)
+			if severity != "" && severity != "suspend" {
+				continue
+			}
+		}
+
+		domain, ok := normalizeSubscribedDomain(field(record, domainI))
+		if !ok {
+			continue
+		}
+
+		obfuscate, _ := strconv.ParseBool(field(record, obfuscateI))
+		perms = append(perms, &subscribedDomainPerm{
+			domain:        domain,
+			publicComment: field(record, commentI),
+			obfuscate:     obfuscate,
+		})
+	}
+
+	return perms, nil
+}
+
+// parseDomainPermsJSON parses a JSON array of domain
+// permissions, as exported by the domain permissions API.
+func parseDomainPermsJSON(r io.Reader) ([]*subscribedDomainPerm, error) {
+	var apiPerms []*apimodel.DomainPermission
+	if err := json.NewDecoder(r).Decode(&apiPerms); err != nil {
+		return nil, fmt.Errorf("error decoding json: %w", err)
+	}
+
+	perms := make([]*subscribedDomainPerm, 0, len(apiPerms))
+	for _, apiPerm := range apiPerms {
+		if apiPerm == nil {
+			continue
+		}
+
+		domain, ok := normalizeSubscribedDomain(apiPerm.Domain.Domain)
+		if !ok {
+			continue
+		}
+
+		perms = append(perms, &subscribedDomainPerm{
+			domain:        domain,
+			publicComment: apiPerm.PublicComment,
+			obfuscate:     apiPerm.Obfuscate,
+		})
+	}
+
+	return perms, nil
+}
+
+// parseDomainPermsPlaintext parses a list of one
+// domain per line, ignoring blanks and # comments.
+func parseDomainPermsPlaintext(r io.Reader) ([]*subscribedDomainPerm, error) {
+	var perms []*subscribedDomainPerm
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		domain, ok := normalizeSubscribedDomain(line)
+		if !ok {
+			continue
+		}
+
+		perms = append(perms, &subscribedDomainPerm{domain: domain})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading plaintext: %w", err)
+	}
+
+	return perms, nil
+}
+
+// normalizeSubscribedDomain lowercases and punifies the
+// given listed domain, returning false if it's unusable:
+// eg., obfuscated ("b*dplace.com"), or our own domain.
+func normalizeSubscribedDomain(domain string) (string, bool) {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == "" || !strings.Contains(domain, ".") ||
+		strings.ContainsAny(domain, "*/:@ \t") {
+		return "", false
+	}
+
+	domain, err := util.Punify(domain)
+	if err != nil {
+		return "", false
+	}
+
+	if domain == config.GetHost() ||
+		domain == config.GetAccountDomain() {
+		return "", false
+	}
+
+	return domain, true
+}
+
+// dedupeDomainPerms removes entries with a
+// duplicate domain, keeping the first one.
+func dedupeDomainPerms(perms []*subscribedDomainPerm) []*subscribedDomainPerm {
+	seen := make(map[string]struct{}, len(perms))
+	deduped := perms[:0]
+
+	for _, perm := range perms {
+		if _, ok := seen[perm.domain]; ok {
+			continue
+		}
+		seen[perm.domain] = struct{}{}
+		deduped = append(deduped, perm)
+	}
+
+	return deduped
+}
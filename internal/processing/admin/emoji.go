@@ -82,6 +82,18 @@ func (p *Processor) EmojiCreate(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	adminEmoji, err := p.converter.EmojiToAdminAPIEmoji(ctx, emoji)
+	if err != nil {
+		err := gtserror.Newf("error converting emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.auditLog(ctx, account,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetEmoji, emoji.ID,
+		nil, adminEmoji,
+	)
+
 	return &apiEmoji, nil
 }
 
@@ -177,6 +189,7 @@ func (p *Processor) EmojiGet(
 // from the database, with the given id.
 func (p *Processor) EmojiDelete(
 	ctx context.Context,
+	adminAcct *gtsmodel.Account,
 	id string,
 ) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	emoji, err := p.state.DB.GetEmojiByID(ctx, id)
@@ -208,6 +221,12 @@ func (p *Processor) EmojiDelete(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetEmoji, id,
+		adminEmoji, nil,
+	)
+
 	return adminEmoji, nil
 }
 
@@ -215,6 +234,7 @@ func (p *Processor) EmojiDelete(
 // given id, using the provided form parameters.
 func (p *Processor) EmojiUpdate(
 	ctx context.Context,
+	adminAcct *gtsmodel.Account,
 	emojiID string,
 	form *apimodel.EmojiUpdateRequest,
 ) (*apimodel.AdminEmoji, gtserror.WithCode) {
@@ -232,21 +252,46 @@ func (p *Processor) EmojiUpdate(
 		return nil, gtserror.NewErrorNotFound(errors.New(text), text)
 	}
 
+	// Convert to admin emoji before
+	// update, for the audit log.
+	before, err := p.converter.EmojiToAdminAPIEmoji(ctx, emoji)
+	if err != nil {
+		err := gtserror.Newf("error converting emoji: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var (
+		adminEmoji  *apimodel.AdminEmoji
+		errWithCode gtserror.WithCode
+	)
+
 	switch form.Type {
 
 	case apimodel.EmojiUpdateCopy:
-		return p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
+		adminEmoji, errWithCode = p.emojiUpdateCopy(ctx, emoji, form.Shortcode, form.CategoryName)
 
 	case apimodel.EmojiUpdateDisable:
-		return p.emojiUpdateDisable(ctx, emoji)
+		adminEmoji, errWithCode = p.emojiUpdateDisable(ctx, emoji)
 
 	case apimodel.EmojiUpdateModify:
-		return p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
+		adminEmoji, errWithCode = p.emojiUpdateModify(ctx, emoji, form.Image, form.CategoryName)
 
 	default:
 		const text = "unrecognized emoji update action type"
 		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
 	}
+
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.auditLog(ctx, adminAcct,
+		string(form.Type),
+		gtsmodel.AuditLogTargetEmoji, emojiID,
+		before, adminEmoji,
+	)
+
+	return adminEmoji, nil
 }
 
 // EmojiCategoriesGet returns all custom emoji
@@ -54,22 +54,22 @@ func (p *Processor) GetBlockHeaderFilters(ctx context.Context) ([]*apimodel.Head
 
 // CreateAllowHeaderFilter inserts the incoming allow HTTP header filter into the database, marking as authored by provided admin account.
 func (p *Processor) CreateAllowHeaderFilter(ctx context.Context, admin *gtsmodel.Account, request *apimodel.HeaderFilterRequest) (*apimodel.HeaderFilter, gtserror.WithCode) {
-	return p.createHeaderFilter(ctx, admin, request, p.state.DB.PutAllowHeaderFilter)
+	return p.createHeaderFilter(ctx, admin, request, gtsmodel.AuditLogTargetHeaderFilterAllow, p.state.DB.PutAllowHeaderFilter)
 }
 
 // CreateBlockHeaderFilter inserts the incoming block HTTP header filter into the database, marking as authored by provided admin account.
 func (p *Processor) CreateBlockHeaderFilter(ctx context.Context, admin *gtsmodel.Account, request *apimodel.HeaderFilterRequest) (*apimodel.HeaderFilter, gtserror.WithCode) {
-	return p.createHeaderFilter(ctx, admin, request, p.state.DB.PutBlockHeaderFilter)
+	return p.createHeaderFilter(ctx, admin, request, gtsmodel.AuditLogTargetHeaderFilterBlock, p.state.DB.PutBlockHeaderFilter)
 }
 
 // DeleteAllowHeaderFilter deletes the allowing HTTP header filter with provided ID from the database.
-func (p *Processor) DeleteAllowHeaderFilter(ctx context.Context, id string) gtserror.WithCode {
-	return p.deleteHeaderFilter(ctx, id, p.state.DB.DeleteAllowHeaderFilter)
+func (p *Processor) DeleteAllowHeaderFilter(ctx context.Context, admin *gtsmodel.Account, id string) gtserror.WithCode {
+	return p.deleteHeaderFilter(ctx, admin, id, gtsmodel.AuditLogTargetHeaderFilterAllow, p.state.DB.GetAllowHeaderFilter, p.state.DB.DeleteAllowHeaderFilter)
 }
 
 // DeleteBlockHeaderFilter deletes the blocking HTTP header filter with provided ID from the database.
-func (p *Processor) DeleteBlockHeaderFilter(ctx context.Context, id string) gtserror.WithCode {
-	return p.deleteHeaderFilter(ctx, id, p.state.DB.DeleteBlockHeaderFilter)
+func (p *Processor) DeleteBlockHeaderFilter(ctx context.Context, admin *gtsmodel.Account, id string) gtserror.WithCode {
+	return p.deleteHeaderFilter(ctx, admin, id, gtsmodel.AuditLogTargetHeaderFilterBlock, p.state.DB.GetBlockHeaderFilter, p.state.DB.DeleteBlockHeaderFilter)
 }
 
 // getHeaderFilter fetches an HTTP header filter with
@@ -138,6 +138,7 @@ func (p *Processor) createHeaderFilter(
 	ctx context.Context,
 	admin *gtsmodel.Account,
 	request *apimodel.HeaderFilterRequest,
+	targetType gtsmodel.AuditLogTargetType,
 	insert func(context.Context, *gtsmodel.HeaderFilter) error,
 ) (
 	*apimodel.HeaderFilter,
@@ -168,21 +169,52 @@ func (p *Processor) createHeaderFilter(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	apiFilter := toAPIHeaderFilter(&filter)
+	p.auditLog(ctx, admin,
+		gtsmodel.AuditLogActionCreate,
+		targetType, filter.ID,
+		nil, apiFilter,
+	)
+
 	// Finally return API model response.
-	return toAPIHeaderFilter(&filter), nil
+	return apiFilter, nil
 }
 
 // deleteHeaderFilter deletes the HTTP header filter
 // with provided ID, using the given delete function.
+// The given get function is used to fetch the filter
+// beforehand, so that its deletion can be recorded.
 func (p *Processor) deleteHeaderFilter(
 	ctx context.Context,
+	admin *gtsmodel.Account,
 	id string,
+	targetType gtsmodel.AuditLogTargetType,
+	get func(context.Context, string) (*gtsmodel.HeaderFilter, error),
 	delete func(context.Context, string) error,
 ) gtserror.WithCode {
+	filter, err := get(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("error selecting from database: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if filter == nil {
+		// Already gone,
+		// nothing to do.
+		return nil
+	}
+
 	if err := delete(ctx, id); err != nil && !errors.Is(err, db.ErrNoEntries) {
 		err := gtserror.Newf("error deleting from database: %w", err)
 		return gtserror.NewErrorInternalError(err)
 	}
+
+	p.auditLog(ctx, admin,
+		gtsmodel.AuditLogActionDelete,
+		targetType, id,
+		toAPIHeaderFilter(filter), nil,
+	)
+
 	return nil
 }
 
@@ -0,0 +1,195 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// maxMediaHashBlockThreshold is the maximum allowed threshold of a
+// media hash block. The hashes of unrelated images differ by around
+// 32 bits on average, so anything much higher than this would start
+// blocking unrelated media.
+const maxMediaHashBlockThreshold = 16
+
+// MediaHashBlocksGet returns all media hash blocks of this instance.
+func (p *Processor) MediaHashBlocksGet(ctx context.Context) ([]*apimodel.AdminMediaHashBlock, gtserror.WithCode) {
+	blocks, err := p.state.DB.GetMediaHashBlocks(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting media hash blocks: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlocks := make([]*apimodel.AdminMediaHashBlock, len(blocks))
+	for i, block := range blocks {
+		apiBlocks[i] = p.converter.MediaHashBlockToAdminAPIMediaHashBlock(block)
+	}
+
+	return apiBlocks, nil
+}
+
+// MediaHashBlockGet returns one media hash block, with the given ID.
+func (p *Processor) MediaHashBlockGet(ctx context.Context, id string) (*apimodel.AdminMediaHashBlock, gtserror.WithCode) {
+	block, errWithCode := p.getMediaHashBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.MediaHashBlockToAdminAPIMediaHashBlock(block), nil
+}
+
+// MediaHashBlockCreate blocks media with a perceptual hash within
+// the given threshold of either the given hash, or the hash of the
+// given media attachment. Blocks apply only to media processed
+// after their creation; already-stored media is not affected.
+func (p *Processor) MediaHashBlockCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminMediaHashBlockCreateRequest,
+) (*apimodel.AdminMediaHashBlock, gtserror.WithCode) {
+	var hash string
+
+	switch {
+	case form.Hash != "" && form.MediaID != "":
+		const text = "only one of hash or media_id should be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+
+	case form.Hash != "":
+		hash = strings.ToLower(form.Hash)
+		if _, err := strconv.ParseUint(hash, 16, 64); err != nil || len(hash) != 16 {
+			const text = "hash must be a 64-bit perceptual hash, encoded as 16 hex characters"
+			return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+	case form.MediaID != "":
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, form.MediaID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("db error getting media %s: %w", form.MediaID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if attachment == nil {
+			err := fmt.Errorf("media %s not found", form.MediaID)
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+
+		if attachment.PerceptualHash == "" {
+			text := fmt.Sprintf("media %s has no perceptual hash", form.MediaID)
+			return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
+		hash = attachment.PerceptualHash
+
+	default:
+		const text = "one of hash or media_id must be provided"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	var threshold int
+	if form.Threshold != nil {
+		threshold = *form.Threshold
+	}
+
+	if threshold < 0 || threshold > maxMediaHashBlockThreshold {
+		text := fmt.Sprintf("threshold must be between 0 and %d", maxMediaHashBlockThreshold)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Check this hash isn't already blocked.
+	existing, err := p.state.DB.GetMediaHashBlockByHash(ctx, hash)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("hash %s is already blocked", hash)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	block := &gtsmodel.MediaHashBlock{
+		ID:                 id.NewULID(),
+		Hash:               hash,
+		Threshold:          threshold,
+		Comment:            form.Comment,
+		CreatedByAccountID: adminAcct.ID,
+		CreatedByAccount:   adminAcct,
+	}
+
+	if err := p.state.DB.PutMediaHashBlock(ctx, block); err != nil {
+		err := gtserror.Newf("db error putting media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.MediaHashBlockToAdminAPIMediaHashBlock(block)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetMediaHashBlock, block.ID,
+		nil, apiBlock,
+	)
+
+	return apiBlock, nil
+}
+
+// MediaHashBlockDelete removes the media
+// hash block with the given ID, and returns it.
+func (p *Processor) MediaHashBlockDelete(ctx context.Context, adminAcct *gtsmodel.Account, id string) (*apimodel.AdminMediaHashBlock, gtserror.WithCode) {
+	block, errWithCode := p.getMediaHashBlock(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteMediaHashBlockByID(ctx, block.ID); err != nil {
+		err := gtserror.Newf("db error deleting media hash block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiBlock := p.converter.MediaHashBlockToAdminAPIMediaHashBlock(block)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetMediaHashBlock, block.ID,
+		apiBlock, nil,
+	)
+
+	return apiBlock, nil
+}
+
+func (p *Processor) getMediaHashBlock(ctx context.Context, id string) (*gtsmodel.MediaHashBlock, gtserror.WithCode) {
+	block, err := p.state.DB.GetMediaHashBlockByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting media hash block %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if block == nil {
+		err := fmt.Errorf("media hash block %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,118 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type MediaHashBlockTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *MediaHashBlockTestSuite) TestMediaHashBlockCreateFromMedia() {
+	var (
+		ctx        = context.Background()
+		adminAcct  = suite.testAccounts["admin_account"]
+		attachment = suite.testAttachments["local_account_1_status_4_attachment_1"]
+	)
+
+	// Test attachments have no hash to begin with.
+	_, errWithCode := suite.adminProcessor.MediaHashBlockCreate(ctx, adminAcct, &apimodel.AdminMediaHashBlockCreateRequest{
+		MediaID: attachment.ID,
+	})
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	attachment.PerceptualHash = "0f0f0f0f0f0f0f0f"
+	if err := suite.db.UpdateAttachment(ctx, attachment, "perceptual_hash"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	apiBlock, errWithCode := suite.adminProcessor.MediaHashBlockCreate(ctx, adminAcct, &apimodel.AdminMediaHashBlockCreateRequest{
+		MediaID:   attachment.ID,
+		Threshold: util.Ptr(6),
+		Comment:   "spam image",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("0f0f0f0f0f0f0f0f", apiBlock.Hash)
+	suite.Equal(6, apiBlock.Threshold)
+	suite.Equal(adminAcct.ID, apiBlock.CreatedBy)
+
+	// Media with a near hash should now match.
+	block, err := suite.db.MatchMediaHashBlock(ctx, "0f0f0f0f0f0f0f00")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apiBlock.ID, block.ID)
+
+	// Blocking the same hash again should conflict.
+	_, errWithCode = suite.adminProcessor.MediaHashBlockCreate(ctx, adminAcct, &apimodel.AdminMediaHashBlockCreateRequest{
+		Hash: "0F0F0F0F0F0F0F0F",
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	apiBlocks, errWithCode := suite.adminProcessor.MediaHashBlocksGet(ctx)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(apiBlocks, 1)
+
+	if _, errWithCode := suite.adminProcessor.MediaHashBlockDelete(ctx, adminAcct, apiBlock.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.adminProcessor.MediaHashBlockGet(ctx, apiBlock.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *MediaHashBlockTestSuite) TestMediaHashBlockCreateInvalid() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+	)
+
+	for _, form := range []*apimodel.AdminMediaHashBlockCreateRequest{
+		// Neither hash nor media.
+		{},
+
+		// Hash not hex, or too short.
+		{Hash: "not a hash"},
+		{Hash: "0f0f"},
+
+		// Threshold too high.
+		{Hash: "0f0f0f0f0f0f0f0f", Threshold: util.Ptr(64)},
+
+		// Both hash and media.
+		{Hash: "0f0f0f0f0f0f0f0f", MediaID: "01F8MH8RMYQ6MSNY3JM2XT1CQ5"},
+	} {
+		_, errWithCode := suite.adminProcessor.MediaHashBlockCreate(ctx, adminAcct, form)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	}
+}
+
+func TestMediaHashBlockTestSuite(t *testing.T) {
+	suite.Run(t, new(MediaHashBlockTestSuite))
+}
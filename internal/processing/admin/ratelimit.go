@@ -0,0 +1,54 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// RateLimitsGet returns the rate limit policies in
+// use by this instance, and the clients throttled
+// by each of them since startup.
+func (p *Processor) RateLimitsGet(_ context.Context) []*apimodel.AdminRateLimitPolicy {
+	policies := p.state.RateLimits.Policies()
+
+	apiPolicies := make([]*apimodel.AdminRateLimitPolicy, len(policies))
+	for i, policy := range policies {
+		clients := make([]apimodel.AdminRateLimitClient, len(policy.Clients))
+		for j, client := range policy.Clients {
+			clients[j] = apimodel.AdminRateLimitClient{
+				Type:            client.Type,
+				ID:              client.ID,
+				Throttled:       client.Throttled,
+				LastThrottledAt: util.FormatISO8601(client.Last),
+			}
+		}
+
+		apiPolicies[i] = &apimodel.AdminRateLimitPolicy{
+			Name:      policy.Name,
+			Limit:     policy.Limit,
+			Throttled: policy.Throttled,
+			Clients:   clients,
+		}
+	}
+
+	return apiPolicies
+}
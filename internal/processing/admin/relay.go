@@ -0,0 +1,207 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// RelaysGet returns all relay subscriptions of this instance.
+func (p *Processor) RelaysGet(ctx context.Context) ([]*apimodel.AdminRelay, gtserror.WithCode) {
+	relays, err := p.state.DB.GetRelays(ctx)
+	if err != nil {
+		err := gtserror.Newf("db error getting relays: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiRelays := make([]*apimodel.AdminRelay, len(relays))
+	for i, relay := range relays {
+		apiRelays[i] = p.converter.RelayToAdminAPIRelay(relay)
+	}
+
+	return apiRelays, nil
+}
+
+// RelayGet returns one relay subscription, with the given ID.
+func (p *Processor) RelayGet(ctx context.Context, id string) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.converter.RelayToAdminAPIRelay(relay), nil
+}
+
+// RelayCreate subscribes to the relay with the given
+// inbox, by sending it a Follow from the instance account.
+// The subscription is pending until the relay Accepts.
+func (p *Processor) RelayCreate(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	form *apimodel.AdminRelayCreateRequest,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	inboxURI, err := url.Parse(form.InboxURL)
+	if err != nil || inboxURI.Host == "" ||
+		(inboxURI.Scheme != "https" && inboxURI.Scheme != "http") {
+		const text = "inbox_url must be an absolute http(s) url"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	mode := gtsmodel.RelayMode(form.Mode)
+	switch mode {
+	case "":
+		mode = gtsmodel.RelayModeMastodon
+	case gtsmodel.RelayModeMastodon, gtsmodel.RelayModeLitePub:
+		// Fine.
+	default:
+		text := fmt.Sprintf("mode must be one of %s, %s", gtsmodel.RelayModeMastodon, gtsmodel.RelayModeLitePub)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	blocked, err := p.state.DB.IsDomainBlocked(ctx, inboxURI.Host)
+	if err != nil {
+		err := gtserror.Newf("db error checking domain block: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if blocked {
+		text := fmt.Sprintf("domain %s is blocked", inboxURI.Host)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Check we're not already subscribed.
+	existing, err := p.state.DB.GetRelayByInboxURI(ctx, inboxURI.String())
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if existing != nil {
+		text := fmt.Sprintf("already subscribed to relay %s", inboxURI)
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	instanceAcct, err := p.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		err := gtserror.Newf("db error getting instance account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	relayID := id.NewULID()
+	relay := &gtsmodel.Relay{
+		ID:        relayID,
+		InboxURI:  inboxURI.String(),
+		FollowURI: uris.GenerateURIForFollow(instanceAcct.Username, relayID),
+		Mode:      mode,
+		State:     gtsmodel.RelayStatePending,
+	}
+
+	if mode == gtsmodel.RelayModeLitePub {
+		// LitePub relays are Followed directly, and
+		// conventionally serve their actor alongside
+		// the inbox, eg., https://relay.example.org/actor.
+		actorURI := *inboxURI
+		actorURI.Path = path.Join(path.Dir(inboxURI.Path), "actor")
+		relay.ActorURI = actorURI.String()
+	}
+
+	if err := p.state.DB.PutRelay(ctx, relay); err != nil {
+		err := gtserror.Newf("db error putting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the Follow asynchronously.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorService,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       relay,
+		Origin:         adminAcct,
+	})
+
+	apiRelay := p.converter.RelayToAdminAPIRelay(relay)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetRelay, relay.ID,
+		nil, apiRelay,
+	)
+
+	return apiRelay, nil
+}
+
+// RelayDelete unsubscribes from the relay with the given
+// ID, sending it an Undo of our Follow, and returns it.
+func (p *Processor) RelayDelete(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminRelay, gtserror.WithCode) {
+	relay, errWithCode := p.getRelay(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.state.DB.DeleteRelayByID(ctx, relay.ID); err != nil {
+		err := gtserror.Newf("db error deleting relay: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Send the Undo asynchronously.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorService,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       relay,
+		Origin:         adminAcct,
+	})
+
+	apiRelay := p.converter.RelayToAdminAPIRelay(relay)
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetRelay, relay.ID,
+		apiRelay, nil,
+	)
+
+	return apiRelay, nil
+}
+
+func (p *Processor) getRelay(ctx context.Context, id string) (*gtsmodel.Relay, gtserror.WithCode) {
+	relay, err := p.state.DB.GetRelayByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting relay %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if relay == nil {
+		err := fmt.Errorf("relay %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return relay, nil
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"slices"
 	"strconv"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // ReportsGet returns reports stored on this
@@ -100,12 +102,9 @@ func (p *Processor) ReportsGet(
 
 // ReportGet returns one report, with the given ID.
 func (p *Processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminReport, gtserror.WithCode) {
-	report, err := p.state.DB.GetReportByID(ctx, id)
-	if err != nil {
-		if err == db.ErrNoEntries {
-			return nil, gtserror.NewErrorNotFound(err)
-		}
-		return nil, gtserror.NewErrorInternalError(err)
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	apimodelReport, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
@@ -117,34 +116,118 @@ func (p *Processor) ReportGet(ctx context.Context, account *gtsmodel.Account, id
 }
 
 // ReportResolve marks a report with the given id as resolved,
-// and stores the provided actionTakenComment (if not null).
+// and stores the provided action taken comment (if not null).
+//
+// If an account action is set on the form, that action is taken
+// against the reported account. If delete statuses is set, any
+// statuses attached to the report are deleted.
+//
 // If the report creator is from this instance, an email will
 // be sent to them to let them know that the report is resolved.
-func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account, id string, actionTakenComment *string) (*apimodel.AdminReport, gtserror.WithCode) {
-	report, err := p.state.DB.GetReportByID(ctx, id)
-	if err != nil {
-		if err == db.ErrNoEntries {
-			return nil, gtserror.NewErrorNotFound(err)
+func (p *Processor) ReportResolve(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	form *apimodel.AdminReportResolveRequest,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check account action before
+	// doing anything else, so we
+	// don't half-resolve the report.
+	actionType := gtsmodel.AdminActionUnknown
+	if form.AccountAction != "" {
+		actionType = gtsmodel.NewAdminActionType(form.AccountAction)
+		switch actionType {
+		case gtsmodel.AdminActionSuspend,
+			gtsmodel.AdminActionSilence,
+			gtsmodel.AdminActionSensitize:
+			// Supported.
+
+		default:
+			supportedTypes := []string{
+				gtsmodel.AdminActionSuspend.String(),
+				gtsmodel.AdminActionSilence.String(),
+				gtsmodel.AdminActionSensitize.String(),
+			}
+
+			err := fmt.Errorf(
+				"account_action %s is not supported when resolving a report, "+
+					"currently supported actions are: %q",
+				form.AccountAction, supportedTypes)
+
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
+	}
+
+	before, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if actionType != gtsmodel.AdminActionUnknown {
+		var text string
+		if form.ActionTakenComment != nil {
+			text = *form.ActionTakenComment
+		}
+
+		if _, errWithCode := p.AccountAction(ctx, account, &apimodel.AdminActionRequest{
+			Type:     actionType.String(),
+			Text:     text,
+			TargetID: report.TargetAccountID,
+		}); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Suspending an account deletes all
+	// of its statuses anyway, so only
+	// bother doing this separately if
+	// the account wasn't suspended.
+	if form.DeleteStatuses &&
+		actionType != gtsmodel.AdminActionSuspend {
+		if errWithCode := p.deleteReportStatuses(ctx, report); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	columns := []string{
 		"action_taken_at",
 		"action_taken_by_account_id",
+		"state",
 	}
 
 	report.ActionTakenAt = time.Now()
 	report.ActionTakenByAccountID = account.ID
+	report.ActionTakenByAccount = account
+	report.State = gtsmodel.ReportStateResolved
+
+	if report.AssignedAccountID == "" {
+		// Nobody picked up the report
+		// before it was resolved, so
+		// it was handled by resolver.
+		report.AssignedAccountID = account.ID
+		report.AssignedAccount = account
+		columns = append(columns, "assigned_account_id")
+	}
 
-	if actionTakenComment != nil {
-		report.ActionTaken = *actionTakenComment
+	if form.ActionTakenComment != nil {
+		report.ActionTaken = *form.ActionTakenComment
 		columns = append(columns, "action_taken")
 	}
 
-	updatedReport, err := p.state.DB.UpdateReport(ctx, report, columns...)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
+	apimodelReport, errWithCode := p.updateReport(ctx,
+		account,
+		report,
+		before,
+		gtsmodel.AuditLogActionResolve,
+		columns...,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
 	}
 
 	// Process side effects of closing the report.
@@ -156,10 +239,310 @@ func (p *Processor) ReportResolve(ctx context.Context, account *gtsmodel.Account
 		Target:         report.Account,
 	})
 
+	return apimodelReport, nil
+}
+
+// ReportReopen reopens the resolved report with the given id,
+// so that it can be handled again. The comment on the action
+// taken (if any) is kept until the report is resolved again.
+func (p *Processor) ReportReopen(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if report.State != gtsmodel.ReportStateResolved {
+		const text = "report is not resolved"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	before, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.ActionTakenAt = time.Time{}
+	report.ActionTakenByAccountID = ""
+	report.ActionTakenByAccount = nil
+	report.State = gtsmodel.ReportStateReopened
+
+	return p.updateReport(ctx,
+		account,
+		report,
+		before,
+		gtsmodel.AuditLogActionReopen,
+		"action_taken_at",
+		"action_taken_by_account_id",
+		"state",
+	)
+}
+
+// ReportForward forwards the report with the given id to
+// the instance of the reported account, and to instances
+// of any reply-parents of reported statuses that are in
+// the given forwardToDomains. Delivery happens async, and
+// is recorded on the report once it succeeds.
+func (p *Processor) ReportForward(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	forwardToDomains []string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if report.TargetAccount.IsLocal() {
+		const text = "reported account is local, there's nowhere to forward the report to"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	forwardDomains := slices.Clone(report.ForwardDomains)
+	for _, domain := range forwardToDomains {
+		punyDomain, err := util.Punify(domain)
+		if err != nil {
+			err := fmt.Errorf("invalid forward_to_domains entry %s: %w", domain, err)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		forwardDomains = append(forwardDomains, punyDomain)
+	}
+
+	before, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.Forwarded = util.Ptr(true)
+	report.ForwardDomains = util.Deduplicate(forwardDomains)
+
+	apimodelReport, errWithCode := p.updateReport(ctx,
+		account,
+		report,
+		before,
+		gtsmodel.AuditLogActionForward,
+		"forwarded",
+		"forward_domains",
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Deliver the report.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityFlag,
+		APActivityType: ap.ActivityFlag,
+		GTSModel:       report,
+		Origin:         account,
+		Target:         report.TargetAccount,
+	})
+
+	return apimodelReport, nil
+}
+
+// ReportAssign assigns the unresolved report with the given
+// id to the moderator account with the given assigneeID, or
+// to the requesting account if assigneeID is not set.
+func (p *Processor) ReportAssign(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+	assigneeID string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if report.State == gtsmodel.ReportStateResolved {
+		const text = "report is resolved, reopen it first"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	assignee := account
+	if assigneeID != "" && assigneeID != account.ID {
+		assignee, errWithCode = p.getModerator(ctx, assigneeID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	before, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	report.AssignedAccountID = assignee.ID
+	report.AssignedAccount = assignee
+	report.State = gtsmodel.ReportStateAssigned
+
+	return p.updateReport(ctx,
+		account,
+		report,
+		before,
+		gtsmodel.AuditLogActionAssign,
+		"assigned_account_id",
+		"state",
+	)
+}
+
+// ReportUnassign removes the assigned moderator from the
+// report with the given id. Unresolved reports go back to
+// being open, so that any moderator can pick them up.
+func (p *Processor) ReportUnassign(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	id string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	report, errWithCode := p.getReport(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, err := p.converter.ReportToAdminAPIReport(ctx, report, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if report.AssignedAccountID == "" {
+		// Nothing to do.
+		return before, nil
+	}
+
+	report.AssignedAccountID = ""
+	report.AssignedAccount = nil
+	if report.State == gtsmodel.ReportStateAssigned {
+		report.State = gtsmodel.ReportStateOpen
+	}
+
+	return p.updateReport(ctx,
+		account,
+		report,
+		before,
+		gtsmodel.AuditLogActionUnassign,
+		"assigned_account_id",
+		"state",
+	)
+}
+
+// getReport fetches the report with the
+// given id, wrapping errors for the caller.
+func (p *Processor) getReport(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Report, gtserror.WithCode) {
+	report, err := p.state.DB.GetReportByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if report == nil {
+		err := gtserror.Newf("report %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return report, nil
+}
+
+// updateReport updates the given columns of the report,
+// records the update in the audit log under the given
+// action, and returns the updated report as API model.
+func (p *Processor) updateReport(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	report *gtsmodel.Report,
+	before *apimodel.AdminReport,
+	action string,
+	columns ...string,
+) (*apimodel.AdminReport, gtserror.WithCode) {
+	updatedReport, err := p.state.DB.UpdateReport(ctx, report, columns...)
+	if err != nil {
+		err := gtserror.Newf("db error updating report %s: %w", report.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
 	apimodelReport, err := p.converter.ReportToAdminAPIReport(ctx, updatedReport, account)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.auditLog(ctx, account,
+		action,
+		gtsmodel.AuditLogTargetReport, report.ID,
+		before, apimodelReport,
+	)
+
 	return apimodelReport, nil
 }
+
+// getModerator fetches the account with the given
+// id, checking that it belongs to a local user that
+// is allowed to handle reports (ie., admin or mod).
+func (p *Processor) getModerator(
+	ctx context.Context,
+	accountID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	user, err := p.state.DB.GetUserByAccountID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting user for account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if user == nil || (!*user.Admin && !*user.Moderator) {
+		const text = "account_id must be the id of a local admin or moderator"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if user.Account == nil {
+		user.Account, err = p.state.DB.GetAccountByID(ctx, accountID)
+		if err != nil {
+			err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return user.Account, nil
+}
+
+// deleteReportStatuses deletes any statuses attached
+// to the report that were authored by the reported
+// account, processing side effects asynchronously.
+func (p *Processor) deleteReportStatuses(
+	ctx context.Context,
+	report *gtsmodel.Report,
+) gtserror.WithCode {
+	if len(report.StatusIDs) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	statuses, err := p.state.DB.GetStatusesByIDs(ctx, report.StatusIDs)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report statuses: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	for _, status := range statuses {
+		if status.AccountID != report.TargetAccountID {
+			// Only ever delete
+			// statuses by the
+			// reported account.
+			continue
+		}
+
+		p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityDelete,
+			GTSModel:       status,
+			Origin:         status.Account,
+			Target:         status.Account,
+		})
+	}
+
+	return nil
+}
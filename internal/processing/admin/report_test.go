@@ -0,0 +1,260 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReportTestSuite struct {
+	AdminStandardTestSuite
+	testReports map[string]*gtsmodel.Report
+}
+
+func (suite *ReportTestSuite) SetupTest() {
+	suite.AdminStandardTestSuite.SetupTest()
+	suite.testReports = testrig.NewTestReports()
+}
+
+func (suite *ReportTestSuite) TestReportWorkflow() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+	)
+
+	// Assign report to self.
+	apiReport, errWithCode := suite.adminProcessor.ReportAssign(ctx, adminAcct, report.ID, "")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("assigned", apiReport.State)
+	suite.Equal(adminAcct.ID, apiReport.AssignedAccount.ID)
+
+	// Reports can't be assigned to non-moderators.
+	_, errWithCode = suite.adminProcessor.ReportAssign(ctx, adminAcct, report.ID, suite.testAccounts["local_account_1"].ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Unassign report again.
+	apiReport, errWithCode = suite.adminProcessor.ReportUnassign(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("open", apiReport.State)
+	suite.Nil(apiReport.AssignedAccount)
+
+	// Resolve report.
+	apiReport, errWithCode = suite.adminProcessor.ReportResolve(ctx, adminAcct, report.ID, &apimodel.AdminReportResolveRequest{
+		ActionTakenComment: util.Ptr("no action needed"),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("resolved", apiReport.State)
+	suite.True(apiReport.ActionTaken)
+
+	// Resolved reports can't be assigned.
+	_, errWithCode = suite.adminProcessor.ReportAssign(ctx, adminAcct, report.ID, "")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Reopen report.
+	apiReport, errWithCode = suite.adminProcessor.ReportReopen(ctx, adminAcct, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("reopened", apiReport.State)
+	suite.False(apiReport.ActionTaken)
+	suite.Nil(apiReport.ActionTakenByAccount)
+	suite.Equal("no action needed", *apiReport.ActionTakenComment)
+
+	// Only resolved reports can be reopened.
+	_, errWithCode = suite.adminProcessor.ReportReopen(ctx, adminAcct, report.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	// Reopened report should now show up as unresolved.
+	reports, err := suite.db.GetReports(ctx, util.Ptr(false), "", "", nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Contains(reportIDs(reports), report.ID)
+}
+
+func (suite *ReportTestSuite) TestReportResolveWithAction() {
+	var (
+		ctx       = context.Background()
+		adminAcct = suite.testAccounts["admin_account"]
+		report    = suite.testReports["local_account_2_report_remote_account_1"]
+	)
+
+	// Unsupported actions are refused.
+	_, errWithCode := suite.adminProcessor.ReportResolve(ctx, adminAcct, report.ID, &apimodel.AdminReportResolveRequest{
+		AccountAction: "disable",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	apiReport, errWithCode := suite.adminProcessor.ReportResolve(ctx, adminAcct, report.ID, &apimodel.AdminReportResolveRequest{
+		ActionTakenComment: util.Ptr("silenced for spam"),
+		AccountAction:      "silence",
+		DeleteStatuses:     true,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("resolved", apiReport.State)
+
+	// Wait for action to finish.
+	if !testrig.WaitFor(func() bool {
+		return suite.adminProcessor.Actions().TotalRunning() == 0
+	}) {
+		suite.FailNow("timed out waiting for admin action(s) to finish")
+	}
+
+	// Reported account should be silenced.
+	targetAcct, err := suite.db.GetAccountByID(ctx, report.TargetAccountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotZero(targetAcct.SilencedAt)
+
+	// Reported status should be deleted.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetStatusByID(ctx, report.StatusIDs[0])
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for status to be deleted")
+	}
+}
+
+func (suite *ReportTestSuite) TestReportNotes() {
+	var (
+		ctx         = context.Background()
+		adminAcct   = suite.testAccounts["admin_account"]
+		report      = suite.testReports["local_account_2_report_remote_account_1"]
+		otherReport = suite.testReports["remote_account_1_report_local_account_2"]
+	)
+
+	note, errWithCode := suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, report.ID, &apimodel.AdminReportNoteCreateRequest{
+		Content: "  I'll take a look at this.  ",
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("I'll take a look at this.", note.Content)
+	suite.Nil(note.InReplyToID)
+
+	reply, errWithCode := suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, report.ID, &apimodel.AdminReportNoteCreateRequest{
+		Content:     "Turns out it's spam.",
+		InReplyToID: note.ID,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(note.ID, *reply.InReplyToID)
+
+	// Empty notes aren't allowed.
+	_, errWithCode = suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, report.ID, &apimodel.AdminReportNoteCreateRequest{
+		Content: " ",
+	})
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+
+	// Can't reply to notes on other reports.
+	_, errWithCode = suite.adminProcessor.ReportNoteCreate(ctx, adminAcct, otherReport.ID, &apimodel.AdminReportNoteCreateRequest{
+		Content:     "Wrong report.",
+		InReplyToID: note.ID,
+	})
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	notes, errWithCode := suite.adminProcessor.ReportNotesGet(ctx, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(notes, 2) {
+		suite.FailNow("")
+	}
+	suite.Equal(note.ID, notes[0].ID)
+	suite.Equal(reply.ID, notes[1].ID)
+	suite.Equal(adminAcct.ID, notes[0].Account.ID)
+
+	// Only the author can delete a note.
+	_, errWithCode = suite.adminProcessor.ReportNoteDelete(ctx, suite.testAccounts["local_account_1"], report.ID, note.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	if _, errWithCode := suite.adminProcessor.ReportNoteDelete(ctx, adminAcct, report.ID, note.ID); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Reply should be kept, but unthreaded.
+	notes, errWithCode = suite.adminProcessor.ReportNotesGet(ctx, report.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	if !suite.Len(notes, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal(reply.ID, notes[0].ID)
+	suite.Nil(notes[0].InReplyToID)
+}
+
+func reportIDs(reports []*gtsmodel.Report) []string {
+	ids := make([]string, 0, len(reports))
+	for _, report := range reports {
+		ids = append(ids, report.ID)
+	}
+	return ids
+}
+
+func (suite *ReportTestSuite) TestReportForward() {
+	var (
+		ctx          = context.Background()
+		adminAcct    = suite.testAccounts["admin_account"]
+		remoteReport = suite.testReports["local_account_2_report_remote_account_1"]
+		localReport  = suite.testReports["remote_account_1_report_local_account_2"]
+	)
+
+	// Reports about local accounts
+	// have nowhere to be forwarded.
+	_, errWithCode := suite.adminProcessor.ReportForward(ctx, adminAcct, localReport.ID, nil)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	apiReport, errWithCode := suite.adminProcessor.ReportForward(ctx, adminAcct, remoteReport.ID, []string{"Example.org", "example.org"})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.True(apiReport.Forwarded)
+
+	dbReport, err := suite.state.DB.GetReportByID(ctx, remoteReport.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(*dbReport.Forwarded)
+	suite.Equal([]string{"example.org"}, dbReport.ForwardDomains)
+}
+
+func TestReportTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportTestSuite))
+}
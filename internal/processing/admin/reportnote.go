@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ReportNotesGet returns all moderator notes left
+// on the report with the given id, oldest first.
+func (p *Processor) ReportNotesGet(
+	ctx context.Context,
+	reportID string,
+) ([]*apimodel.AdminReportNote, gtserror.WithCode) {
+	if _, errWithCode := p.getReport(
+		gtscontext.SetBarebones(ctx),
+		reportID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	notes, err := p.state.DB.GetReportNotes(ctx, reportID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting notes for report %s: %w", reportID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNotes := make([]*apimodel.AdminReportNote, 0, len(notes))
+	for _, note := range notes {
+		apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+		if err != nil {
+			log.Errorf(ctx, "error converting report note %s: %v", note.ID, err)
+			continue
+		}
+		apiNotes = append(apiNotes, apiNote)
+	}
+
+	return apiNotes, nil
+}
+
+// ReportNoteCreate leaves a new moderator note on the
+// report with the given id, optionally in reply to
+// another note left on the same report.
+func (p *Processor) ReportNoteCreate(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reportID string,
+	form *apimodel.AdminReportNoteCreateRequest,
+) (*apimodel.AdminReportNote, gtserror.WithCode) {
+	content := strings.TrimSpace(form.Content)
+	if err := validate.ReportNoteContent(content); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if _, errWithCode := p.getReport(
+		gtscontext.SetBarebones(ctx),
+		reportID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if form.InReplyToID != "" {
+		if _, errWithCode := p.getReportNote(ctx, reportID, form.InReplyToID); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	note := &gtsmodel.ReportNote{
+		ID:          id.NewMonotonicULID(),
+		ReportID:    reportID,
+		AccountID:   account.ID,
+		Account:     account,
+		InReplyToID: form.InReplyToID,
+		Content:     content,
+	}
+
+	if err := p.state.DB.PutReportNote(ctx, note); err != nil {
+		err := gtserror.Newf("db error putting report note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		err := gtserror.Newf("error converting report note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.auditLog(ctx, account,
+		gtsmodel.AuditLogActionCreate,
+		gtsmodel.AuditLogTargetReportNote, note.ID,
+		nil, apiNote,
+	)
+
+	return apiNote, nil
+}
+
+// ReportNoteDelete deletes the moderator note with the given
+// id from the report with the given id. Notes can only be
+// deleted by the account that wrote them.
+func (p *Processor) ReportNoteDelete(
+	ctx context.Context,
+	account *gtsmodel.Account,
+	reportID string,
+	noteID string,
+) (*apimodel.AdminReportNote, gtserror.WithCode) {
+	note, errWithCode := p.getReportNote(ctx, reportID, noteID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if note.AccountID != account.ID {
+		const text = "notes can only be deleted by their author"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	apiNote, err := p.converter.ReportNoteToAdminAPIReportNote(ctx, note)
+	if err != nil {
+		err := gtserror.Newf("error converting report note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteReportNoteByID(ctx, note.ID); err != nil {
+		err := gtserror.Newf("db error deleting report note: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	p.auditLog(ctx, account,
+		gtsmodel.AuditLogActionDelete,
+		gtsmodel.AuditLogTargetReportNote, note.ID,
+		apiNote, nil,
+	)
+
+	return apiNote, nil
+}
+
+// getReportNote fetches the note with the given id,
+// checking it was left on the report with the given
+// id, and wrapping errors for the caller.
+func (p *Processor) getReportNote(
+	ctx context.Context,
+	reportID string,
+	noteID string,
+) (*gtsmodel.ReportNote, gtserror.WithCode) {
+	note, err := p.state.DB.GetReportNoteByID(ctx, noteID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting report note %s: %w", noteID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if note == nil || note.ReportID != reportID {
+		err := gtserror.Newf("note %s not found on report %s", noteID, reportID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return note, nil
+}
@@ -0,0 +1,278 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// Weights (and limits) of each signal used
+// when computing the reputation of an instance.
+const (
+	// Per full week since first contact.
+	reputationPerWeek    = 2
+	reputationPerWeekMax = 20
+
+	// Per follow between local accounts and
+	// accounts on the instance, either way.
+	reputationPerFollow    = 5
+	reputationPerFollowMax = 50
+
+	// Per report against accounts on the instance.
+	reputationPerReport    = -10
+	reputationPerReportMin = -50
+
+	// Per message caught by the spam filter.
+	reputationPerSpam    = -2
+	reputationPerSpamMin = -40
+
+	// Per previous block of the instance's domain.
+	reputationPerBlock    = -30
+	reputationPerBlockMin = -60
+)
+
+// ScheduleReputations schedules a job to recompute the
+// reputation of all known remote instances, once per
+// hour, if reputation-based federation is enabled.
+func (p *Processor) ScheduleReputations() error {
+	if !config.GetInstanceFederationReputation() {
+		// Reputation-based
+		// federation disabled.
+		return nil
+	}
+
+	fn := func(ctx context.Context, now time.Time) {
+		p.ComputeReputations(ctx, now)
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@reputations",
+		time.Time{},
+		time.Hour,
+		fn,
+	) {
+		panic("failed to schedule @reputations")
+	}
+
+	return nil
+}
+
+// ComputeReputations recomputes and stores the reputation
+// of all known, non-suspended, remote instances, as of now.
+func (p *Processor) ComputeReputations(ctx context.Context, now time.Time) {
+	instances, err := p.state.DB.GetInstancePeers(ctx, false)
+	if err != nil {
+		log.Errorf(ctx, "db error getting instances: %v", err)
+		return
+	}
+
+	for _, instance := range instances {
+		reputation, err := p.computeReputation(ctx, instance, now)
+		if err != nil {
+			log.Errorf(ctx, "error computing reputation of %s: %v", instance.Domain, err)
+			continue
+		}
+
+		instance.Reputation = reputation
+		instance.ReputationComputedAt = now
+		if err := p.state.DB.UpdateInstance(ctx,
+			instance,
+			"reputation",
+			"reputation_computed_at",
+		); err != nil {
+			log.Errorf(ctx, "db error updating instance %s: %v", instance.Domain, err)
+		}
+	}
+
+	log.Infof(ctx, "computed reputation of %d instances", len(instances))
+}
+
+// computeReputation computes the reputation of the given
+// instance as of now, from how long we've known it, its
+// follows with local accounts, reports against its accounts,
+// spam filter hits, and its history of domain blocks.
+func (p *Processor) computeReputation(
+	ctx context.Context,
+	instance *gtsmodel.Instance,
+	now time.Time,
+) (int64, error) {
+	follows, err := p.state.DB.CountInstanceLocalFollows(ctx, instance.Domain)
+	if err != nil {
+		return 0, gtserror.Newf("db error counting follows: %w", err)
+	}
+
+	reports, err := p.state.DB.CountInstanceReports(ctx, instance.Domain)
+	if err != nil {
+		return 0, gtserror.Newf("db error counting reports: %w", err)
+	}
+
+	blocks, err := p.state.DB.CountInstanceBlocks(ctx, instance.Domain)
+	if err != nil {
+		return 0, gtserror.Newf("db error counting blocks: %w", err)
+	}
+
+	weeks := int(now.Sub(instance.CreatedAt) / (7 * 24 * time.Hour))
+
+	return weighReputation(weeks, reputationPerWeek, reputationPerWeekMax) +
+		weighReputation(follows, reputationPerFollow, reputationPerFollowMax) +
+		weighReputation(reports, reputationPerReport, reputationPerReportMin) +
+		weighReputation(instance.SpamHits, reputationPerSpam, reputationPerSpamMin) +
+		weighReputation(blocks, reputationPerBlock, reputationPerBlockMin), nil
+}
+
+// weighReputation returns count * weight, capped at
+// limit (which has the same sign as weight).
+func weighReputation(count int, weight int, limit int) int64 {
+	if count <= 0 {
+		return 0
+	}
+
+	score := count * weight
+	if weight > 0 {
+		score = min(score, limit)
+	} else {
+		score = max(score, limit)
+	}
+
+	return int64(score)
+}
+
+// InstanceReputationsGet returns a page of the reputations
+// of known remote instances, lowest reputation first.
+func (p *Processor) InstanceReputationsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminInstanceReputation, gtserror.WithCode) {
+	instances, err := p.state.DB.GetInstancesByReputation(ctx, limit, offset)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting instances: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiReputations := make([]*apimodel.AdminInstanceReputation, 0, len(instances))
+	for _, instance := range instances {
+		apiReputation, err := p.converter.InstanceToAdminAPIInstanceReputation(ctx, instance)
+		if err != nil {
+			log.Errorf(ctx, "error converting instance %s: %v", instance.Domain, err)
+			continue
+		}
+
+		apiReputations = append(apiReputations, apiReputation)
+	}
+
+	return apiReputations, nil
+}
+
+// InstanceReputationGet returns the reputation
+// of the remote instance with the given ID.
+func (p *Processor) InstanceReputationGet(
+	ctx context.Context,
+	id string,
+) (*apimodel.AdminInstanceReputation, gtserror.WithCode) {
+	instance, errWithCode := p.getRemoteInstance(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiInstanceReputation(ctx, instance)
+}
+
+// InstanceReputationOverride sets a manual override of the reputation
+// of the remote instance with the given ID, taking precedence over its
+// computed reputation. If override is nil, any override is removed.
+func (p *Processor) InstanceReputationOverride(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	id string,
+	override *int64,
+) (*apimodel.AdminInstanceReputation, gtserror.WithCode) {
+	instance, errWithCode := p.getRemoteInstance(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	before, errWithCode := p.apiInstanceReputation(ctx, instance)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	instance.ReputationOverride = override
+	if err := p.state.DB.UpdateInstance(ctx,
+		instance,
+		"reputation_override",
+	); err != nil {
+		err := gtserror.Newf("db error updating instance %s: %w", instance.Domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiReputation, errWithCode := p.apiInstanceReputation(ctx, instance)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionUpdate,
+		gtsmodel.AuditLogTargetInstanceReputation, instance.ID,
+		before, apiReputation,
+	)
+
+	return apiReputation, nil
+}
+
+// getRemoteInstance gets the remote instance with the given ID,
+// returning 404 if it doesn't exist, or if it's our own instance.
+func (p *Processor) getRemoteInstance(
+	ctx context.Context,
+	id string,
+) (*gtsmodel.Instance, gtserror.WithCode) {
+	instance, err := p.state.DB.GetInstanceByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting instance %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if instance == nil || instance.Domain == config.GetHost() {
+		err := gtserror.Newf("remote instance %s not found", id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return instance, nil
+}
+
+func (p *Processor) apiInstanceReputation(
+	ctx context.Context,
+	instance *gtsmodel.Instance,
+) (*apimodel.AdminInstanceReputation, gtserror.WithCode) {
+	apiReputation, err := p.converter.InstanceToAdminAPIInstanceReputation(ctx, instance)
+	if err != nil {
+		err := gtserror.Newf("error converting instance %s: %w", instance.Domain, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiReputation, nil
+}
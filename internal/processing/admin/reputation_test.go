@@ -0,0 +1,111 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ReputationTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ReputationTestSuite) TestComputeReputations() {
+	var (
+		ctx = context.Background()
+		now = testrig.TimeMustParse("2021-10-18T12:40:37+02:00")
+	)
+
+	suite.adminProcessor.ComputeReputations(ctx, now)
+
+	// Known for 4 weeks (+8), with one
+	// report against its accounts (-10).
+	instance, err := suite.db.GetInstance(ctx, "fossbros-anonymous.io")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(-2, instance.Reputation)
+	suite.Equal(now, instance.ReputationComputedAt)
+
+	// Known for well over 10 weeks (+20, capped).
+	instance, err = suite.db.GetInstance(ctx, "example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(20, instance.Reputation)
+
+	// Spam hits should count against reputation.
+	instance.SpamHits = 5
+	if err := suite.db.UpdateInstance(ctx, instance, "spam_hits"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.adminProcessor.ComputeReputations(ctx, now)
+
+	instance, err = suite.db.GetInstance(ctx, "example.org")
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.EqualValues(10, instance.Reputation)
+}
+
+func (suite *ReputationTestSuite) TestInstanceReputationOverride() {
+	var (
+		ctx        = context.Background()
+		instanceID = "01G5H6YMJQKR86QZKXXQ2S95FZ" // fossbros-anonymous.io
+	)
+
+	apiReputation, errWithCode := suite.adminProcessor.InstanceReputationOverride(ctx, suite.testAccounts["admin_account"], instanceID, util.Ptr(int64(-100)))
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(-100, apiReputation.Reputation)
+	suite.EqualValues(0, apiReputation.ComputedReputation)
+
+	// Recomputing shouldn't touch the override.
+	suite.adminProcessor.ComputeReputations(ctx, time.Now())
+
+	apiReputation, errWithCode = suite.adminProcessor.InstanceReputationGet(ctx, instanceID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.EqualValues(-100, apiReputation.Reputation)
+	suite.NotNil(apiReputation.Override)
+
+	// Remove the override again.
+	apiReputation, errWithCode = suite.adminProcessor.InstanceReputationOverride(ctx, suite.testAccounts["admin_account"], instanceID, nil)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(apiReputation.ComputedReputation, apiReputation.Reputation)
+	suite.Nil(apiReputation.Override)
+
+	// Our own instance has no reputation.
+	_, errWithCode = suite.adminProcessor.InstanceReputationGet(ctx, "01G774F5TSHJ2ZSF7XRC5EMT6K")
+	suite.EqualError(errWithCode, "getRemoteInstance: remote instance 01G774F5TSHJ2ZSF7XRC5EMT6K not found")
+}
+
+func TestReputationTestSuite(t *testing.T) {
+	suite.Run(t, new(ReputationTestSuite))
+}
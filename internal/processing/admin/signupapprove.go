@@ -72,11 +72,23 @@ func (p *Processor) SignupApprove(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Keep a copy of the
+	// account as it was.
+	before := *apiAccount
+
 	// Optimistically set approved to true and
 	// clear sign-up IP to reflect state that
 	// will be produced by side effects.
 	apiAccount.Approved = true
 	apiAccount.IP = nil
 
+	if !before.Approved {
+		p.auditLog(ctx, adminAcct,
+			gtsmodel.AuditLogActionApprove,
+			gtsmodel.AuditLogTargetAccount, accountID,
+			&before, apiAccount,
+		)
+	}
+
 	return apiAccount, nil
 }
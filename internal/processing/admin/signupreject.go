@@ -112,5 +112,13 @@ func (p *Processor) SignupReject(
 		Target:         user.Account,
 	})
 
+	// The account is removed by the rejection,
+	// so there's nothing left to snapshot after.
+	p.auditLog(ctx, adminAcct,
+		gtsmodel.AuditLogActionReject,
+		gtsmodel.AuditLogTargetAccount, accountID,
+		apiAccount, nil,
+	)
+
 	return apiAccount, nil
 }
@@ -0,0 +1,157 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// TrendsGet returns currently trending trends of the given
+// type for admin review, in descending order of trend score.
+// If state is empty, trends in any review state are returned.
+func (p *Processor) TrendsGet(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	state gtsmodel.TrendState,
+	limit int,
+	offset int,
+) ([]*apimodel.AdminTrend, gtserror.WithCode) {
+	switch state {
+	case "",
+		gtsmodel.TrendStatePending,
+		gtsmodel.TrendStateApproved,
+		gtsmodel.TrendStateRejected:
+		// Fine.
+	default:
+		text := fmt.Sprintf("state must be one of %s, %s, %s",
+			gtsmodel.TrendStatePending,
+			gtsmodel.TrendStateApproved,
+			gtsmodel.TrendStateRejected,
+		)
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	trends, err := p.state.DB.GetTrends(ctx, trendType, state, limit, offset)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting %s trends: %w", trendType, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTrends := make([]*apimodel.AdminTrend, 0, len(trends))
+	for _, trend := range trends {
+		apiTrend, err := p.converter.TrendToAdminAPITrend(ctx, trend, adminAcct)
+		if err != nil {
+			log.Errorf(ctx, "error converting trend %s: %v", trend.ID, err)
+			continue
+		}
+
+		apiTrends = append(apiTrends, apiTrend)
+	}
+
+	return apiTrends, nil
+}
+
+// TrendApprove approves the trend of the given type
+// with the given ID, allowing it to be shown to users.
+func (p *Processor) TrendApprove(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	id string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.trendReview(ctx, adminAcct, trendType, id, gtsmodel.TrendStateApproved)
+}
+
+// TrendReject rejects the trend of the given type
+// with the given ID, so that it's never shown to users.
+func (p *Processor) TrendReject(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	id string,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	return p.trendReview(ctx, adminAcct, trendType, id, gtsmodel.TrendStateRejected)
+}
+
+func (p *Processor) trendReview(
+	ctx context.Context,
+	adminAcct *gtsmodel.Account,
+	trendType gtsmodel.TrendType,
+	id string,
+	state gtsmodel.TrendState,
+) (*apimodel.AdminTrend, gtserror.WithCode) {
+	trend, err := p.state.DB.GetTrendByID(ctx, id)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if trend == nil || trend.Type != trendType {
+		err := gtserror.Newf("%s trend %s not found", trendType, id)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	before, err := p.converter.TrendToAdminAPITrend(ctx, trend, adminAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	trend.State = state
+	trend.ReviewedByAccountID = adminAcct.ID
+	trend.ReviewedAt = time.Now()
+
+	if err := p.state.DB.UpdateTrend(ctx,
+		trend,
+		"state",
+		"reviewed_by_account_id",
+		"reviewed_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiTrend, err := p.converter.TrendToAdminAPITrend(ctx, trend, adminAcct)
+	if err != nil {
+		err := gtserror.Newf("error converting trend %s: %w", id, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	action := gtsmodel.AuditLogActionApprove
+	if state == gtsmodel.TrendStateRejected {
+		action = gtsmodel.AuditLogActionReject
+	}
+
+	p.auditLog(ctx, adminAcct,
+		action,
+		gtsmodel.AuditLogTargetTrend, id,
+		before, apiTrend,
+	)
+
+	return apiTrend, nil
+}
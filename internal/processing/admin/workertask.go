@@ -47,6 +47,10 @@ import (
 func (p *Processor) FillWorkerQueues(ctx context.Context) error {
 	log.Info(ctx, "rehydrate!")
 
+	// Requeue any journaled deliveries first, as recovered
+	// delivery tasks below will themselves be journaled.
+	journaled, jerrors := p.fillJournaledDeliveries(ctx)
+
 	// Get all persisted worker tasks from db.
 	//
 	// (database returns these as ASCENDING, i.e.
@@ -64,7 +68,7 @@ func (p *Processor) FillWorkerQueues(ctx context.Context) error {
 		client    int
 
 		// Failed recoveries.
-		errors int
+		errors = jerrors
 	)
 
 loop:
@@ -127,6 +131,7 @@ loop:
 
 	// Log recovered tasks.
 	log.WithContext(ctx).
+		WithField("journaled", journaled).
 		WithField("delivery", delivery).
 		WithField("federator", federator).
 		WithField("client", client).
@@ -155,7 +160,10 @@ func (p *Processor) PersistWorkerQueues(ctx context.Context) error {
 		tasks []*gtsmodel.WorkerTask
 	)
 
-	for {
+	// When the delivery journal is enabled, all queued
+	// deliveries were already journaled, and will be
+	// replayed from the journal on next startup.
+	for p.state.Workers.Delivery.Journal == nil {
 		// Pop all queued deliveries.
 		task, err := p.popDelivery()
 		if err != nil {
@@ -239,6 +247,21 @@ func (p *Processor) pushDelivery(ctx context.Context, task *gtsmodel.WorkerTask)
 		return gtserror.Newf("error deserializing delivery: %w", err)
 	}
 
+	// Sign the deserialized delivery.
+	if err := p.signDelivery(ctx, dlv); err != nil {
+		return err
+	}
+
+	// Push deserialized task to delivery
+	// queue (journaling it if enabled).
+	p.state.Workers.Delivery.Push(ctx, dlv)
+
+	return nil
+}
+
+// signDelivery sets up the signing func of a deserialized delivery.Delivery{},
+// using the transport of its actor account, or of the instance account if none.
+func (p *Processor) signDelivery(ctx context.Context, dlv *delivery.Delivery) error {
 	var tsport transport.Transport
 
 	if uri := dlv.ActorID; uri != "" {
@@ -268,9 +291,6 @@ func (p *Processor) pushDelivery(ctx context.Context, task *gtsmodel.WorkerTask)
 		return gtserror.Newf("error signing delivery: %w", err)
 	}
 
-	// Push deserialized task to delivery queue.
-	p.state.Workers.Delivery.Queue.Push(dlv)
-
 	return nil
 }
 
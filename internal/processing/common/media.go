@@ -51,7 +51,10 @@ func (p *Processor) StoreLocalMedia(
 
 	// Immediately trigger write to storage.
 	attachment, err := processing.Load(ctx)
-	if err != nil {
+	if errors.Is(err, media.ErrBlockedMedia) {
+		const text = "media is not permitted on this instance"
+		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
+	} else if err != nil {
 		const text = "error processing emoji"
 		err := gtserror.Newf("error processing media: %w", err)
 		return nil, gtserror.NewErrorUnprocessableEntity(err, text)
@@ -23,7 +23,6 @@ import (
 	"fmt"
 	"net/url"
 
-	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -110,10 +109,10 @@ func (p *Processor) UserGet(ctx context.Context, requestedUsername string, reque
 	return data(person)
 }
 
-func data(requestedPerson vocab.ActivityStreamsPerson) (interface{}, gtserror.WithCode) {
-	data, err := ap.Serialize(requestedPerson)
+func data(requestedAccountable ap.Accountable) (interface{}, gtserror.WithCode) {
+	data, err := ap.Serialize(requestedAccountable)
 	if err != nil {
-		err := gtserror.Newf("error serializing person: %w", err)
+		err := gtserror.Newf("error serializing accountable: %w", err)
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
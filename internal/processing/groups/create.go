@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// Create creates a new local group with the given
+// parameters, with requester as its first moderator.
+func (p *Processor) Create(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	form *apimodel.GroupCreateRequest,
+) (*apimodel.Account, gtserror.WithCode) {
+	if err := validate.Username(form.Username); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if form.DisplayName != "" {
+		if err := validate.DisplayName(form.DisplayName); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	if form.Note != "" {
+		if err := validate.Note(form.Note); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+	}
+
+	usernameAvailable, err := p.state.DB.IsUsernameAvailable(ctx, form.Username)
+	if err != nil {
+		err := gtserror.Newf("db error checking username availability: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !usernameAvailable {
+		const text = "username is already in use"
+		return nil, gtserror.NewErrorConflict(errors.New(text), text)
+	}
+
+	var note string
+	if form.Note != "" {
+		note = p.formatter.FromPlain(ctx,
+			p.parseMention,
+			requester.ID,
+			"",
+			form.Note,
+		).HTML
+	}
+
+	group, err := p.state.DB.NewGroup(ctx, gtsmodel.NewGroup{
+		Username:    form.Username,
+		CreatorID:   requester.ID,
+		DisplayName: form.DisplayName,
+		Note:        note,
+		NoteRaw:     form.Note,
+		Locked:      form.Locked,
+	})
+	if err != nil {
+		err := gtserror.Newf("db error creating group: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiGroup(ctx, group)
+}
+
+func (p *Processor) apiGroup(
+	ctx context.Context,
+	group *gtsmodel.Account,
+) (*apimodel.Account, gtserror.WithCode) {
+	apiGroup, err := p.converter.AccountToAPIAccountPublic(ctx, group)
+	if err != nil {
+		err := gtserror.Newf("error converting group: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiGroup, nil
+}
@@ -0,0 +1,75 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// FollowRequestsGet gets accounts requesting to join (ie.,
+// follow) the given locked group, on behalf of a moderator.
+func (p *Processor) FollowRequestsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.account.FollowRequestsGet(ctx, group, page)
+}
+
+// FollowRequestAccept accepts a request from the given
+// account to join the group, on behalf of a moderator.
+func (p *Processor) FollowRequestAccept(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.account.FollowRequestAccept(ctx, group, accountID)
+}
+
+// FollowRequestReject rejects a request from the given
+// account to join the group, on behalf of a moderator.
+func (p *Processor) FollowRequestReject(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) (*apimodel.Relationship, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.account.FollowRequestReject(ctx, group, accountID)
+}
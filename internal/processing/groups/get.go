@@ -0,0 +1,100 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// Get gets the local group with the given
+// ID, if requester is one of its moderators.
+func (p *Processor) Get(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) (*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiGroup(ctx, group)
+}
+
+// GetAll gets all local groups that requester moderates.
+func (p *Processor) GetAll(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	moderators, err := p.state.DB.GetGroupModeratorsByAccountID(ctx, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting moderated groups: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiGroups := make([]*apimodel.Account, 0, len(moderators))
+	for _, moderator := range moderators {
+		if moderator.Group == nil || moderator.Group.IsSuspended() {
+			// Group deleted.
+			continue
+		}
+
+		apiGroup, err := p.converter.AccountToAPIAccountPublic(ctx, moderator.Group)
+		if err != nil {
+			log.Errorf(ctx, "error converting group %s: %v", moderator.GroupID, err)
+			continue
+		}
+
+		apiGroups = append(apiGroups, apiGroup)
+	}
+
+	return apiGroups, nil
+}
+
+// Delete deletes the local group with the given
+// ID, if requester is one of its moderators.
+func (p *Processor) Delete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) gtserror.WithCode {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Process group delete side effects
+	// (federating, clearing boosts, etc)
+	// asynchronously, as for any account.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActorGroup,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       group,
+		Origin:         requester,
+		Target:         group,
+	})
+
+	return nil
+}
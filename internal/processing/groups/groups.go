@@ -0,0 +1,96 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+)
+
+// Processor wraps functionality for creating and
+// moderating local group actors (see FEP-1b12).
+type Processor struct {
+	// common processor logic
+	c *common.Processor
+
+	state        *state.State
+	converter    *typeutils.Converter
+	formatter    *text.Formatter
+	account      *account.Processor
+	parseMention gtsmodel.ParseMentionFunc
+}
+
+// New returns a new groups processor.
+func New(
+	common *common.Processor,
+	state *state.State,
+	converter *typeutils.Converter,
+	account *account.Processor,
+	parseMention gtsmodel.ParseMentionFunc,
+) Processor {
+	return Processor{
+		c:            common,
+		state:        state,
+		converter:    converter,
+		formatter:    text.NewFormatter(state.DB),
+		account:      account,
+		parseMention: parseMention,
+	}
+}
+
+// getModeratedGroup gets the local group with the given
+// ID, checking that requester is one of its moderators.
+func (p *Processor) getModeratedGroup(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) (*gtsmodel.Account, gtserror.WithCode) {
+	group, err := p.state.DB.GetAccountByID(ctx, groupID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting group %s: %w", groupID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if group == nil || !group.IsLocal() ||
+		!group.IsGroup() || group.IsSuspended() {
+		err := gtserror.Newf("local group %s not found", groupID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	moderator, err := p.state.DB.IsGroupModerator(ctx, group.ID, requester.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking group moderator: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !moderator {
+		const text = "not a moderator of this group"
+		return nil, gtserror.NewErrorForbidden(errors.New(text), text)
+	}
+
+	return group, nil
+}
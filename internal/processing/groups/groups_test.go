@@ -0,0 +1,169 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/groups"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type GroupsTestSuite struct {
+	suite.Suite
+	state   state.State
+	account account.Processor
+	groups  groups.Processor
+
+	testAccounts map[string]*gtsmodel.Account
+	testStatuses map[string]*gtsmodel.Status
+}
+
+func (suite *GroupsTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+	suite.state.Caches.Init()
+	testrig.StartNoopWorkers(&suite.state)
+	testrig.NewTestDB(&suite.state)
+	converter := typeutils.NewConverter(&suite.state)
+	controller := testrig.NewTestTransportController(&suite.state, nil)
+	mediaMgr := media.NewManager(&suite.state)
+	federator := testrig.NewTestFederator(&suite.state, controller, mediaMgr)
+	filter := visibility.NewFilter(&suite.state)
+	common := common.New(&suite.state, mediaMgr, converter, federator, filter)
+	parseMention := processing.GetParseMentionFunc(&suite.state, federator)
+	suite.account = account.New(&common, &suite.state, converter, mediaMgr, federator, filter, parseMention)
+	suite.groups = groups.New(&common, &suite.state, converter, &suite.account, parseMention)
+	testrig.StandardDBSetup(suite.state.DB, nil)
+	suite.testAccounts = testrig.NewTestAccounts()
+	suite.testStatuses = testrig.NewTestStatuses()
+}
+
+func (suite *GroupsTestSuite) TearDownTest() {
+	testrig.StopWorkers(&suite.state)
+	testrig.StandardDBTeardown(suite.state.DB)
+}
+
+func (suite *GroupsTestSuite) createGroup(creator *gtsmodel.Account) *apimodel.Account {
+	group, errWithCode := suite.groups.Create(context.Background(), creator, &apimodel.GroupCreateRequest{
+		Username:    "cool_group",
+		DisplayName: "Cool Group",
+		Note:        "a group for cool people",
+		Locked:      true,
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	return group
+}
+
+func (suite *GroupsTestSuite) TestCreate() {
+	ctx := context.Background()
+	creator := suite.testAccounts["local_account_1"]
+
+	group := suite.createGroup(creator)
+	suite.True(group.Group)
+	suite.True(group.Locked)
+	suite.Equal("cool_group", group.Username)
+	suite.Equal("Cool Group", group.DisplayName)
+	suite.Equal("<p>a group for cool people</p>", group.Note)
+
+	// Creator should now moderate the group.
+	groups, errWithCode := suite.groups.GetAll(ctx, creator)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(groups, 1)
+	suite.Equal(group.ID, groups[0].ID)
+
+	// Username should now be taken.
+	_, errWithCode = suite.groups.Create(ctx, creator, &apimodel.GroupCreateRequest{
+		Username: "cool_group",
+	})
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+}
+
+func (suite *GroupsTestSuite) TestGetNotModerator() {
+	ctx := context.Background()
+	group := suite.createGroup(suite.testAccounts["local_account_1"])
+
+	_, errWithCode := suite.groups.Get(ctx, suite.testAccounts["local_account_2"], group.ID)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// Normal accounts aren't groups.
+	_, errWithCode = suite.groups.Get(ctx,
+		suite.testAccounts["local_account_1"],
+		suite.testAccounts["local_account_2"].ID,
+	)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *GroupsTestSuite) TestModerators() {
+	ctx := context.Background()
+	creator := suite.testAccounts["local_account_1"]
+	other := suite.testAccounts["local_account_2"]
+	group := suite.createGroup(creator)
+
+	// Add second moderator.
+	moderators, errWithCode := suite.groups.ModeratorAdd(ctx, creator, group.ID, other.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(moderators, 2)
+
+	// Remote accounts can't moderate local groups.
+	_, errWithCode = suite.groups.ModeratorAdd(ctx, creator, group.ID, suite.testAccounts["remote_account_1"].ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+
+	// Second moderator removes the creator.
+	moderators, errWithCode = suite.groups.ModeratorRemove(ctx, other, group.ID, creator.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(moderators, 1)
+	suite.Equal(other.ID, moderators[0].ID)
+
+	// Last moderator can't be removed.
+	_, errWithCode = suite.groups.ModeratorRemove(ctx, other, group.ID, other.ID)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *GroupsTestSuite) TestStatusRemoveNotInGroup() {
+	ctx := context.Background()
+	creator := suite.testAccounts["local_account_1"]
+	group := suite.createGroup(creator)
+
+	errWithCode := suite.groups.StatusRemove(ctx, creator, group.ID, suite.testStatuses["local_account_2_status_1"].ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestGroupsTestSuite(t *testing.T) {
+	suite.Run(t, new(GroupsTestSuite))
+}
@@ -0,0 +1,162 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+// ModeratorsGet gets the moderators of the given group.
+func (p *Processor) ModeratorsGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	moderators, err := p.state.DB.GetGroupModerators(ctx, group.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting group moderators: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	apiAccounts := make([]*apimodel.Account, 0, len(moderators))
+	for _, moderator := range moderators {
+		if moderator.Account == nil {
+			// Account deleted.
+			continue
+		}
+
+		apiAccount, err := p.converter.AccountToAPIAccountPublic(ctx, moderator.Account)
+		if err != nil {
+			log.Errorf(ctx, "error converting moderator %s: %v", moderator.AccountID, err)
+			continue
+		}
+
+		apiAccounts = append(apiAccounts, apiAccount)
+	}
+
+	return apiAccounts, nil
+}
+
+// ModeratorAdd adds the given local
+// account as a moderator of the group.
+func (p *Processor) ModeratorAdd(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	account, err := p.state.DB.GetAccountByID(ctx, accountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting account %s: %w", accountID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if account == nil || !account.IsLocal() ||
+		account.IsGroup() || account.IsInstance() {
+		err := gtserror.Newf("local account %s not found", accountID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	moderator, err := p.state.DB.IsGroupModerator(ctx, group.ID, account.ID)
+	if err != nil {
+		err := gtserror.Newf("db error checking group moderator: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if !moderator {
+		if err := p.state.DB.PutGroupModerator(ctx, &gtsmodel.GroupModerator{
+			ID:        id.NewULID(),
+			GroupID:   group.ID,
+			AccountID: account.ID,
+		}); err != nil {
+			err := gtserror.Newf("db error putting group moderator: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return p.ModeratorsGet(ctx, requester, group.ID)
+}
+
+// ModeratorRemove removes the given account as a moderator of the
+// group. The last remaining moderator of a group can't be removed.
+func (p *Processor) ModeratorRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	accountID string,
+) ([]*apimodel.Account, gtserror.WithCode) {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	moderators, err := p.state.DB.GetGroupModerators(ctx, group.ID)
+	if err != nil {
+		err := gtserror.Newf("db error getting group moderators: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	var found bool
+	for _, moderator := range moderators {
+		if moderator.AccountID == accountID {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		err := gtserror.Newf("account %s is not a moderator of group %s", accountID, group.ID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if len(moderators) == 1 {
+		const text = "cannot remove the last moderator of a group; delete the group instead"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if err := p.state.DB.DeleteGroupModerator(ctx, group.ID, accountID); err != nil {
+		err := gtserror.Newf("db error deleting group moderator: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if accountID == requester.ID {
+		// Requester removed themself,
+		// so can't see moderators now.
+		return []*apimodel.Account{}, nil
+	}
+
+	return p.ModeratorsGet(ctx, requester, group.ID)
+}
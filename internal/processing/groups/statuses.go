@@ -0,0 +1,93 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package groups
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+// StatusRemove removes the given status from the group, by
+// undoing the group's re-announce of it to its members.
+// The statusID may be either the ID of the original status,
+// or the ID of the group's boost wrapper of that status.
+func (p *Processor) StatusRemove(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	groupID string,
+	statusID string,
+) gtserror.WithCode {
+	group, errWithCode := p.getModeratedGroup(ctx, requester, groupID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	status, err := p.state.DB.GetStatusByID(
+		gtscontext.SetBarebones(ctx),
+		statusID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting status %s: %w", statusID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if status == nil {
+		err := gtserror.Newf("status %s not found", statusID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	if status.BoostOfID != "" && status.AccountID == group.ID {
+		// Given the group's boost
+		// wrapper, get the original.
+		statusID = status.BoostOfID
+	}
+
+	// Get the group's announce of the status.
+	boost, err := p.state.DB.GetStatusBoost(ctx,
+		statusID,
+		group.ID,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting boost of %s: %w", statusID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if boost == nil {
+		err := gtserror.Newf("status %s not in group %s", statusID, group.ID)
+		return gtserror.NewErrorNotFound(err)
+	}
+
+	// Process unboost side effects asynchronously,
+	// this will Undo the Announce to group members.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ActivityAnnounce,
+		APActivityType: ap.ActivityUndo,
+		GTSModel:       boost,
+		Origin:         group,
+		Target:         boost.BoostOfAccount,
+	})
+
+	return nil
+}
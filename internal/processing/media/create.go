@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Create creates a new media attachment belonging to the given account, using the request form.
@@ -44,7 +45,7 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 	}
 
 	// Parse focus details from API form input.
-	focusX, focusY, err := parseFocus(form.Focus)
+	focusX, focusY, err := util.ParseFocus(form.Focus)
 	if err != nil {
 		text := fmt.Sprintf("could not parse focus value %s: %s", form.Focus, err)
 		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
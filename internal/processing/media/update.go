@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Update updates a media attachment with the given id, using the provided form parameters.
@@ -52,7 +53,7 @@ func (p *Processor) Update(ctx context.Context, account *gtsmodel.Account, media
 	}
 
 	if form.Focus != nil {
-		focusx, focusy, err := parseFocus(*form.Focus)
+		focusx, focusy, err := util.ParseFocus(*form.Focus)
 		if err != nil {
 			return nil, gtserror.NewErrorBadRequest(err)
 		}
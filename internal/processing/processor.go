@@ -34,22 +34,26 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/fedi"
 	filtersv1 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v1"
 	filtersv2 "github.com/superseriousbusiness/gotosocial/internal/processing/filters/v2"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/groups"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/interactionrequests"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/list"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/markers"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/polls"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/push"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/report"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/search"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/workers"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // Processor groups together processing functions and
@@ -82,17 +86,20 @@ type Processor struct {
 	fedi                fedi.Processor
 	filtersv1           filtersv1.Processor
 	filtersv2           filtersv2.Processor
+	groups              groups.Processor
 	interactionRequests interactionrequests.Processor
 	list                list.Processor
 	markers             markers.Processor
 	media               media.Processor
 	polls               polls.Processor
+	push                push.Processor
 	report              report.Processor
 	search              search.Processor
 	status              status.Processor
 	stream              stream.Processor
 	tags                tags.Processor
 	timeline            timeline.Processor
+	trends              trends.Processor
 	user                user.Processor
 	workers             workers.Processor
 }
@@ -125,6 +132,10 @@ func (p *Processor) FiltersV2() *filtersv2.Processor {
 	return &p.filtersv2
 }
 
+func (p *Processor) Groups() *groups.Processor {
+	return &p.groups
+}
+
 func (p *Processor) InteractionRequests() *interactionrequests.Processor {
 	return &p.interactionRequests
 }
@@ -145,6 +156,10 @@ func (p *Processor) Polls() *polls.Processor {
 	return &p.polls
 }
 
+func (p *Processor) Push() *push.Processor {
+	return &p.push
+}
+
 func (p *Processor) Report() *report.Processor {
 	return &p.report
 }
@@ -169,6 +184,10 @@ func (p *Processor) Timeline() *timeline.Processor {
 	return &p.timeline
 }
 
+func (p *Processor) Trends() *trends.Processor {
+	return &p.trends
+}
+
 func (p *Processor) User() *user.Processor {
 	return &p.user
 }
@@ -188,6 +207,7 @@ func NewProcessor(
 	emailSender email.Sender,
 	visFilter *visibility.Filter,
 	intFilter *interaction.Filter,
+	webPushSender webpush.Sender,
 ) *Processor {
 	parseMentionFunc := GetParseMentionFunc(state, federator)
 	processor := &Processor{
@@ -215,13 +235,16 @@ func NewProcessor(
 	processor.fedi = fedi.New(state, &common, converter, federator, visFilter)
 	processor.filtersv1 = filtersv1.New(state, converter, &processor.stream)
 	processor.filtersv2 = filtersv2.New(state, converter, &processor.stream)
+	processor.groups = groups.New(&common, state, converter, &processor.account, parseMentionFunc)
 	processor.interactionRequests = interactionrequests.New(&common, state, converter)
 	processor.list = list.New(state, converter)
 	processor.markers = markers.New(state, converter)
 	processor.polls = polls.New(&common, state, converter)
+	processor.push = push.New(state, converter)
 	processor.report = report.New(state, converter)
 	processor.tags = tags.New(state, converter)
 	processor.timeline = timeline.New(state, converter, visFilter)
+	processor.trends = trends.New(state, converter, visFilter)
 	processor.search = search.New(state, federator, converter, visFilter)
 	processor.status = status.New(state, &common, &processor.polls, federator, converter, visFilter, intFilter, parseMentionFunc)
 	processor.user = user.New(state, converter, oauthServer, emailSender)
@@ -239,10 +262,12 @@ func NewProcessor(
 		converter,
 		visFilter,
 		emailSender,
+		webPushSender,
 		&processor.account,
 		&processor.media,
 		&processor.stream,
 		&processor.conversations,
+		&processor.trends,
 	)
 
 	return processor
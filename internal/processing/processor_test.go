@@ -133,6 +133,7 @@ func (suite *ProcessingStandardTestSuite) SetupTest() {
 		suite.emailSender,
 		visibility.NewFilter(&suite.state),
 		interaction.NewFilter(&suite.state),
+		testrig.NewWebPushSender(nil),
 	)
 	testrig.StartWorkers(&suite.state, suite.processor.Workers())
 
@@ -0,0 +1,149 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package push
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
+)
+
+// CreateOrReplace creates a Web Push subscription for the given access token,
+// or, if there's an existing subscription for that token, replaces it.
+func (p *Processor) CreateOrReplace(
+	ctx context.Context,
+	accountID string,
+	accessToken string,
+	request *apimodel.PushSubscriptionCreateRequest,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	tokenID, errWithCode := p.getTokenID(ctx, accessToken)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if request.Subscription == nil {
+		const text = "subscription must be set"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	// Push services must be reachable over HTTPS.
+	endpoint, err := url.Parse(request.Subscription.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		const text = "subscription endpoint must be an absolute https URL"
+		return nil, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	keys := request.Subscription.Keys
+	if err := webpush.ValidateKeys(keys.Auth, keys.P256dh); err != nil {
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	subscription := &gtsmodel.WebPushSubscription{
+		ID:        id.NewULID(),
+		AccountID: accountID,
+		TokenID:   tokenID,
+		Endpoint:  endpoint.String(),
+		Auth:      keys.Auth,
+		P256dh:    keys.P256dh,
+		Policy:    gtsmodel.WebPushNotificationPolicyAll,
+	}
+
+	if errWithCode := applyRequestData(subscription, request.Data); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Clients may only have one subscription per
+	// access token, so replace any existing one.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, tokenID); err != nil {
+		err = gtserror.Newf("couldn't delete Web Push subscription for token ID %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.PutWebPushSubscription(ctx, subscription); err != nil {
+		if errors.Is(err, db.ErrAlreadyExists) {
+			// Lost a race with a concurrent
+			// request for the same token.
+			const text = "a subscription for this access token was created concurrently"
+			return nil, gtserror.NewErrorConflict(errors.New(text), text)
+		}
+		err = gtserror.Newf("couldn't create Web Push subscription for token ID %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return p.apiSubscription(ctx, subscription)
+}
+
+// applyRequestData sets the alerts and
+// policy from the given request data on
+// the given subscription, if they're set.
+func applyRequestData(
+	subscription *gtsmodel.WebPushSubscription,
+	data *apimodel.PushSubscriptionRequestData,
+) gtserror.WithCode {
+	if data == nil {
+		// Nothing to change.
+		return nil
+	}
+
+	if data.Policy != nil {
+		policy, ok := gtsmodel.ParseWebPushNotificationPolicy(*data.Policy)
+		if !ok {
+			const text = "policy must be one of all, followed, follower, or none"
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+		subscription.Policy = policy
+	}
+
+	if alerts := data.Alerts; alerts != nil {
+		flags := &subscription.NotificationFlags
+		flags.Set(gtsmodel.NotificationFollow, alerts.Follow)
+		flags.Set(gtsmodel.NotificationFollowRequest, alerts.FollowRequest)
+		flags.Set(gtsmodel.NotificationFave, alerts.Favourite)
+		flags.Set(gtsmodel.NotificationMention, alerts.Mention)
+		flags.Set(gtsmodel.NotificationReblog, alerts.Reblog)
+		flags.Set(gtsmodel.NotificationPoll, alerts.Poll)
+		flags.Set(gtsmodel.NotificationStatus, alerts.Status)
+		flags.Set(gtsmodel.NotificationSignup, alerts.AdminSignup)
+		flags.Set(gtsmodel.NotificationPendingFave, alerts.PendingFavourite)
+		flags.Set(gtsmodel.NotificationPendingReply, alerts.PendingReply)
+		flags.Set(gtsmodel.NotificationPendingReblog, alerts.PendingReblog)
+	}
+
+	return nil
+}
+
+// apiSubscription converts the given
+// subscription to its API representation.
+func (p *Processor) apiSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.PushSubscription, gtserror.WithCode) {
+	apiSubscription, err := p.converter.WebPushSubscriptionToAPIWebPushSubscription(ctx, subscription)
+	if err != nil {
+		err = gtserror.Newf("error converting Web Push subscription %s to API representation: %w", subscription.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiSubscription, nil
+}
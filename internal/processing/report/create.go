@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // Create creates one user report / flag, using the provided form parameters.
@@ -71,6 +72,24 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// Normalize any additional domains to forward the
+	// report to. Whether these are actually domains of
+	// reply-parents of reported statuses is checked when
+	// the report is delivered; other domains are skipped.
+	var forwardDomains []string
+	if form.Forward {
+		forwardDomains = make([]string, 0, len(form.ForwardToDomains))
+		for _, domain := range form.ForwardToDomains {
+			punyDomain, err := util.Punify(domain)
+			if err != nil {
+				err = fmt.Errorf("invalid forward_to_domains entry %s: %w", domain, err)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			forwardDomains = append(forwardDomains, punyDomain)
+		}
+		forwardDomains = util.Deduplicate(forwardDomains)
+	}
+
 	reportID := id.NewULID()
 	report := &gtsmodel.Report{
 		ID:              reportID,
@@ -85,6 +104,8 @@ func (p *Processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		RuleIDs:         form.RuleIDs,
 		Rules:           rules,
 		Forwarded:       &form.Forward,
+		ForwardDomains:  forwardDomains,
+		State:           gtsmodel.ReportStateOpen,
 	}
 
 	if err := p.state.DB.PutReport(ctx, report); err != nil {
@@ -24,6 +24,7 @@ import (
 	"net/mail"
 	"net/url"
 	"strings"
+	"time"
 
 	"codeberg.org/gruf/go-kv"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -119,21 +120,6 @@ func (p *Processor) Get(
 		}...).
 		Debugf("beginning search")
 
-	// todo: Currently we don't support offset for paging;
-	// a caller can page using maxID or minID, but if they
-	// supply an offset greater than 0, return nothing as
-	// though there were no additional results.
-	if req.Offset > 0 {
-		return p.packageSearchResult(
-			ctx,
-			account,
-			nil, nil, nil, // No results.
-			req.APIv1,
-			includeInstanceAccounts,
-			includeBlockedAccounts,
-		)
-	}
-
 	var (
 		foundStatuses = make([]*gtsmodel.Status, 0, limit)
 		foundAccounts = make([]*gtsmodel.Account, 0, limit)
@@ -221,6 +207,19 @@ func (p *Processor) Get(
 		// caller wants to include blocked accounts too.
 		includeBlockedAccounts = true
 
+		// A URI only ever has one page
+		// of results; there's no more.
+		if offset > 0 {
+			return p.packageSearchResult(
+				ctx,
+				account,
+				nil, nil, nil, // No results.
+				req.APIv1,
+				includeInstanceAccounts,
+				includeBlockedAccounts,
+			)
+		}
+
 		if err := p.byURI(
 			ctx,
 			account,
@@ -301,6 +300,21 @@ func (p *Processor) Get(
 	// have 'mastodon' in the domain, and therefore in
 	// the username, making the search results useless.
 	includeInstanceAccounts = false
+
+	// Parse any search operators
+	// out of the query text.
+	parsed, errWithCode := p.parseQuery(ctx, query)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// If the owning account for statuses was provided as the
+	// account_id query parameter, it overrides any provided
+	// as a search operator in the query string.
+	if fromAccountID != "" {
+		parsed.FromAccountID = fromAccountID
+	}
+
 	if err := p.byText(
 		ctx,
 		account,
@@ -308,10 +322,9 @@ func (p *Processor) Get(
 		minID,
 		limit,
 		offset,
-		query,
+		parsed,
 		queryType,
 		following,
-		fromAccountID,
 		appendAccount,
 		appendStatus,
 	); err != nil && !errors.Is(err, db.ErrNoEntries) {
@@ -375,6 +388,12 @@ func (p *Processor) accountsByUsernameDomain(
 	// Domain and username were both set.
 	// Caller is likely trying to search for an exact
 	// match, from either a remote instance or local.
+	if offset > 0 {
+		// Exact match only ever has
+		// one page of results.
+		return nil
+	}
+
 	foundAccount, err := p.accountByUsernameDomain(
 		ctx,
 		requestingAccount,
@@ -730,7 +749,7 @@ func (p *Processor) hashtag(
 }
 
 // byText searches in the database for accounts and/or
-// statuses containing the given query string, using
+// statuses containing the given query text, using
 // the provided parameters.
 //
 // If queryType is any (empty string), both accounts
@@ -743,10 +762,9 @@ func (p *Processor) byText(
 	minID string,
 	limit int,
 	offset int,
-	query string,
+	query *db.StatusSearchQuery,
 	queryType string,
 	following bool,
-	fromAccountID string,
 	appendAccount func(*gtsmodel.Account),
 	appendStatus func(*gtsmodel.Status),
 ) error {
@@ -760,13 +778,14 @@ func (p *Processor) byText(
 
 	if includeAccounts(queryType) {
 		// Search for accounts using the given text.
+		// Search operators only apply to statuses.
 		if err := p.accountsByText(ctx,
 			requestingAccount.ID,
 			maxID,
 			minID,
 			limit,
 			offset,
-			query,
+			query.Text,
 			following,
 			appendAccount,
 		); err != nil {
@@ -775,7 +794,7 @@ func (p *Processor) byText(
 	}
 
 	if includeStatuses(queryType) {
-		// Search for statuses using the given text.
+		// Search for statuses using the given query.
 		if err := p.statusesByText(ctx,
 			requestingAccount.ID,
 			maxID,
@@ -783,7 +802,6 @@ func (p *Processor) byText(
 			limit,
 			offset,
 			query,
-			fromAccountID,
 			appendStatus,
 		); err != nil {
 			return err
@@ -822,7 +840,7 @@ func (p *Processor) accountsByText(
 }
 
 // statusesByText searches in the database for limit
-// number of statuses using the given query text.
+// number of statuses using the given parsed query.
 func (p *Processor) statusesByText(
 	ctx context.Context,
 	requestingAccountID string,
@@ -830,33 +848,20 @@ func (p *Processor) statusesByText(
 	minID string,
 	limit int,
 	offset int,
-	query string,
-	fromAccountID string,
+	query *db.StatusSearchQuery,
 	appendStatus func(*gtsmodel.Status),
 ) error {
-	parsed, err := p.parseQuery(ctx, query)
-	if err != nil {
-		return err
-	}
-	query = parsed.query
-	// If the owning account for statuses was not provided as the account_id query parameter,
-	// it may still have been provided as a search operator in the query string.
-	if fromAccountID == "" {
-		fromAccountID = parsed.fromAccountID
-	}
-
 	statuses, err := p.state.DB.SearchForStatuses(
 		ctx,
 		requestingAccountID,
 		query,
-		fromAccountID,
 		maxID,
 		minID,
 		limit,
 		offset,
 	)
 	if err != nil && !errors.Is(err, db.ErrNoEntries) {
-		return gtserror.Newf("error checking database for statuses using text %s: %w", query, err)
+		return gtserror.Newf("error checking database for statuses using text %s: %w", query.Text, err)
 	}
 
 	for _, status := range statuses {
@@ -866,38 +871,50 @@ func (p *Processor) statusesByText(
 	return nil
 }
 
-// parsedQuery represents the results of parsing the search operator terms within a query.
-type parsedQuery struct {
-	// query is the original search query text with operator terms removed.
-	query string
-	// fromAccountID is the account from a successfully resolved `from:` operator, if present.
-	fromAccountID string
-}
+// parseQuery parses query text and handles any search operator
+// terms present, returning a status search query containing the
+// query text with operator terms removed, and the filters given
+// by the operators. Returns a bad request error if an operator
+// couldn't be parsed.
+func (p *Processor) parseQuery(ctx context.Context, query string) (*db.StatusSearchQuery, gtserror.WithCode) {
+	var (
+		parsed                = new(db.StatusSearchQuery)
+		queryPartSeparator    = " "
+		queryParts            = strings.Split(query, queryPartSeparator)
+		nonOperatorQueryParts = make([]string, 0, len(queryParts))
+		err                   error
+	)
 
-// parseQuery parses query text and handles any search operator terms present.
-func (p *Processor) parseQuery(ctx context.Context, query string) (parsed parsedQuery, err error) {
-	queryPartSeparator := " "
-	queryParts := strings.Split(query, queryPartSeparator)
-	nonOperatorQueryParts := make([]string, 0, len(queryParts))
 	for _, queryPart := range queryParts {
-		if arg, hasPrefix := strings.CutPrefix(queryPart, "from:"); hasPrefix {
-			parsed.fromAccountID, err = p.parseFromOperatorArg(ctx, arg)
-			if err != nil {
-				return
-			}
+		if arg, ok := strings.CutPrefix(queryPart, "from:"); ok {
+			parsed.FromAccountID, err = p.parseFromOperatorArg(ctx, arg)
+		} else if arg, ok := strings.CutPrefix(queryPart, "has:"); ok {
+			err = parseHasOperatorArg(arg, parsed)
+		} else if arg, ok := strings.CutPrefix(queryPart, "before:"); ok {
+			parsed.Before, err = parseDateOperatorArg("before", arg)
+		} else if arg, ok := strings.CutPrefix(queryPart, "after:"); ok {
+			// Statuses *after* the given
+			// day, so from the next day on.
+			parsed.After, err = parseDateOperatorArg("after", arg)
+			parsed.After = parsed.After.AddDate(0, 0, 1)
 		} else {
 			nonOperatorQueryParts = append(nonOperatorQueryParts, queryPart)
 		}
+
+		if err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
 	}
-	parsed.query = strings.Join(nonOperatorQueryParts, queryPartSeparator)
-	return
+
+	parsed.Text = strings.Join(nonOperatorQueryParts, queryPartSeparator)
+	return parsed, nil
 }
 
 // parseFromOperatorArg attempts to parse the from: operator's argument as an account name,
 // and returns the account ID if possible. Allows specifying an account name with or without a leading @.
 func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string) (string, error) {
 	if namestring == "" {
-		return "", gtserror.New(
+		return "", errors.New(
 			"the 'from:' search operator requires an account name, but it wasn't provided",
 		)
 	}
@@ -907,14 +924,14 @@ func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string)
 
 	username, domain, err := util.ExtractNamestringParts(namestring)
 	if err != nil {
-		return "", gtserror.Newf(
+		return "", fmt.Errorf(
 			"the 'from:' search operator couldn't parse its argument as an account name: %w",
 			err,
 		)
 	}
 	account, err := p.state.DB.GetAccountByUsernameDomain(gtscontext.SetBarebones(ctx), username, domain)
 	if err != nil {
-		return "", gtserror.Newf(
+		return "", fmt.Errorf(
 			"the 'from:' search operator couldn't find the requested account name: %w",
 			err,
 		)
@@ -922,3 +939,37 @@ func (p *Processor) parseFromOperatorArg(ctx context.Context, namestring string)
 
 	return account.ID, nil
 }
+
+// parseHasOperatorArg parses the has: operator's
+// argument, setting the matching filter on parsed.
+func parseHasOperatorArg(arg string, parsed *db.StatusSearchQuery) error {
+	switch strings.ToLower(arg) {
+	case "media":
+		parsed.HasMedia = true
+	case "poll":
+		parsed.HasPoll = true
+	default:
+		return fmt.Errorf(
+			"the 'has:' search operator argument %q was not recognized, valid options are ['media', 'poll']",
+			arg,
+		)
+	}
+	return nil
+}
+
+// parseDateOperatorArg parses the argument of the given date
+// operator (eg., before:, after:) as a day, in the format
+// YYYY-MM-DD, returning the start of that day in UTC.
+func parseDateOperatorArg(operator string, arg string) (time.Time, error) {
+	const dateOnly = "2006-01-02"
+
+	t, err := time.Parse(dateOnly, arg)
+	if err != nil {
+		return time.Time{}, fmt.Errorf(
+			"the '%s:' search operator requires a date in the format YYYY-MM-DD, but got %q",
+			operator, arg,
+		)
+	}
+
+	return t, nil
+}
@@ -109,11 +109,20 @@ func (p *Processor) Create(
 		return nil, errWithCode
 	}
 
+	// Check + attach quoted status.
+	if errWithCode := p.processQuote(ctx,
+		requester,
+		status,
+		form.QuotedStatusID,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if errWithCode := p.processThreadID(ctx, status); errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	if errWithCode := p.processMediaIDs(ctx, form, requester.ID, status); errWithCode != nil {
+	if errWithCode := p.processMediaIDs(ctx, form.MediaIDs, requester.ID, status); errWithCode != nil {
 		return nil, errWithCode
 	}
 
@@ -127,11 +136,17 @@ func (p *Processor) Create(
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := processLanguage(form, requester.Settings.Language, status); err != nil {
+	if err := processLanguage(form.Language, requester.Settings.Language, status); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.processContent(ctx, p.parseMention, form, status); err != nil {
+	if err := p.processContent(ctx,
+		p.parseMention,
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
@@ -250,6 +265,72 @@ func (p *Processor) processInReplyTo(ctx context.Context, requester *gtsmodel.Ac
 	return nil
 }
 
+func (p *Processor) processQuote(ctx context.Context, requester *gtsmodel.Account, status *gtsmodel.Status, quotedStatusID string) gtserror.WithCode {
+	if quotedStatusID == "" {
+		// Not a quote.
+		// Nothing to do.
+		return nil
+	}
+
+	// Fetch target quoted status (checking visibility).
+	quote, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		quotedStatusID,
+		nil,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// If this is a boost, unwrap it to get source status.
+	quote, errWithCode = p.c.UnwrapIfBoost(ctx,
+		requester,
+		quote,
+	)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Only public and unlisted statuses may be quoted,
+	// anything else would expose the quoted status to
+	// an audience it was never intended for.
+	if quote.Visibility != gtsmodel.VisibilityPublic &&
+		quote.Visibility != gtsmodel.VisibilityUnlocked {
+		const errText = "only public or unlisted statuses can be quoted"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorUnprocessableEntity(err, errText)
+	}
+
+	// Quoting is a form of boosting, so
+	// respect the quoted author's boost policy.
+	policyResult, err := p.intFilter.StatusBoostable(ctx,
+		requester,
+		quote,
+	)
+	if err != nil {
+		err := gtserror.Newf("error seeing if status %s is quotable: %w", quote.ID, err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	// There's no approval flow for quotes, so only
+	// allow quotes that are permitted outright. A match
+	// on followers / following is only trusted when we
+	// hold the relevant collection locally ourselves.
+	if !policyResult.Permitted() ||
+		(policyResult.MatchedOnCollection() && !*quote.Local) {
+		const errText = "you do not have permission to quote this status"
+		err := gtserror.New(errText)
+		return gtserror.NewErrorForbidden(err, errText)
+	}
+
+	// Set status fields from quote.
+	status.QuoteID = quote.ID
+	status.QuoteURI = quote.URI
+	status.Quote = quote
+
+	return nil
+}
+
 func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status) gtserror.WithCode {
 	// Status takes the thread ID of
 	// whatever it replies to, if set.
@@ -290,8 +371,8 @@ func (p *Processor) processThreadID(ctx context.Context, status *gtsmodel.Status
 	return nil
 }
 
-func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.StatusCreateRequest, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
-	if form.MediaIDs == nil {
+func (p *Processor) processMediaIDs(ctx context.Context, mediaIDs []string, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
+	if mediaIDs == nil {
 		return nil
 	}
 
@@ -301,7 +382,7 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.StatusCr
 	attachments := []*gtsmodel.MediaAttachment{}
 	attachmentIDs := []string{}
 
-	for _, mediaID := range form.MediaIDs {
+	for _, mediaID := range mediaIDs {
 		attachment, err := p.state.DB.GetAttachmentByID(ctx, mediaID)
 		if err != nil && !errors.Is(err, db.ErrNoEntries) {
 			err := gtserror.Newf("error fetching media from db: %w", err)
@@ -318,11 +399,22 @@ func (p *Processor) processMediaIDs(ctx context.Context, form *apimodel.StatusCr
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
-		if attachment.StatusID != "" || attachment.ScheduledStatusID != "" {
+		// Media may already be attached to *this* status,
+		// in the case of an edit; but any other status is
+		// a no go, as is any media awaiting scheduling.
+		if (attachment.StatusID != "" && attachment.StatusID != status.ID) ||
+			attachment.ScheduledStatusID != "" {
 			text := fmt.Sprintf("media %s already attached to status", mediaID)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
 		}
 
+		// Media still being processed (eg., transcoded)
+		// has no full-size file yet, so can't be attached.
+		if attachment.Processing == gtsmodel.ProcessingStatusProcessing {
+			text := fmt.Sprintf("media %s has not finished processing", mediaID)
+			return gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+		}
+
 		if length := len([]rune(attachment.Description)); length < minChars {
 			text := fmt.Sprintf("media %s description too short, at least %d required", mediaID, minChars)
 			return gtserror.NewErrorBadRequest(errors.New(text), text)
@@ -413,9 +505,9 @@ func processInteractionPolicy(
 	return nil
 }
 
-func processLanguage(form *apimodel.StatusCreateRequest, accountDefaultLanguage string, status *gtsmodel.Status) error {
-	if form.Language != "" {
-		status.Language = form.Language
+func processLanguage(formLanguage string, accountDefaultLanguage string, status *gtsmodel.Status) error {
+	if formLanguage != "" {
+		status.Language = formLanguage
 	} else {
 		status.Language = accountDefaultLanguage
 	}
@@ -425,11 +517,17 @@ func processLanguage(form *apimodel.StatusCreateRequest, accountDefaultLanguage
 	return nil
 }
 
-func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.ParseMentionFunc, form *apimodel.StatusCreateRequest, status *gtsmodel.Status) error {
-	if form.ContentType == "" {
+func (p *Processor) processContent(
+	ctx context.Context,
+	parseMention gtsmodel.ParseMentionFunc,
+	contentType apimodel.StatusContentType,
+	content string,
+	contentWarning string,
+	status *gtsmodel.Status,
+) error {
+	if contentType == "" {
 		// If content type wasn't specified, use the author's preferred content-type.
-		contentType := apimodel.StatusContentType(status.Account.Settings.StatusContentType)
-		form.ContentType = contentType
+		contentType = apimodel.StatusContentType(status.Account.Settings.StatusContentType)
 	}
 
 	// format is the currently set text formatting
@@ -442,7 +540,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 		return formatFunc(ctx, parseMention, status.AccountID, status.ID, input)
 	}
 
-	switch form.ContentType {
+	switch contentType {
 	// None given / set,
 	// use default (plain).
 	case "":
@@ -458,11 +556,11 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 
 	// Unknown.
 	default:
-		return fmt.Errorf("invalid status format: %q", form.ContentType)
+		return fmt.Errorf("invalid status format: %q", contentType)
 	}
 
 	// Sanitize status text and format.
-	contentRes := formatInput(format, form.Status)
+	contentRes := formatInput(format, content)
 
 	// Collect formatted results.
 	status.Content = contentRes.HTML
@@ -475,7 +573,7 @@ func (p *Processor) processContent(ctx context.Context, parseMention gtsmodel.Pa
 	format = p.formatter.FromPlainEmojiOnly
 
 	// Sanitize content warning and format.
-	spoiler := text.SanitizeToPlaintext(form.SpoilerText)
+	spoiler := text.SanitizeToPlaintext(contentWarning)
 	warningRes := formatInput(format, spoiler)
 
 	// Collect formatted results.
@@ -174,6 +174,40 @@ func (suite *StatusCreateTestSuite) TestProcessMediaDescriptionTooShort() {
 	suite.Nil(apiStatus)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessMediaStillProcessing() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Mark attachment as still being
+	// processed, eg., pending transcode.
+	attachment := new(gtsmodel.MediaAttachment)
+	*attachment = *suite.testAttachments["local_account_1_unattached_1"]
+	attachment.Processing = gtsmodel.ProcessingStatusProcessing
+	if err := suite.state.DB.UpdateAttachment(ctx, attachment, "processing"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:      "poopoo peepee",
+		MediaIDs:    []string{attachment.ID},
+		Poll:        nil,
+		InReplyToID: "",
+		Sensitive:   false,
+		SpoilerText: "",
+		Visibility:  apimodel.VisibilityPublic,
+		LocalOnly:   util.Ptr(false),
+		ScheduledAt: "",
+		Language:    "en",
+		ContentType: apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.EqualError(err, "media 01F8MH8RMYQ6MSNY3JM2XT1CQ5 has not finished processing")
+	suite.Nil(apiStatus)
+}
+
 func (suite *StatusCreateTestSuite) TestProcessLanguageWithScriptPart() {
 	ctx := context.Background()
 
@@ -238,6 +272,69 @@ func (suite *StatusCreateTestSuite) TestProcessReplyToUnthreadedRemoteStatus() {
 	suite.NotEmpty(dbStatus.ThreadID)
 }
 
+func (suite *StatusCreateTestSuite) TestProcessQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quoted := suite.testStatuses["admin_account_status_1"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "look at this",
+		MediaIDs:       []string{},
+		QuotedStatusID: quoted.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	// Quote should be rendered on the status.
+	if suite.NotNil(apiStatus.Quote) {
+		suite.Equal(quoted.ID, apiStatus.Quote.ID)
+		suite.Equal(quoted.AccountID, apiStatus.Quote.Account.ID)
+	}
+
+	// And stored in the database.
+	dbStatus, dbErr := suite.state.DB.GetStatusByID(ctx, apiStatus.ID)
+	if dbErr != nil {
+		suite.FailNow(dbErr.Error())
+	}
+	suite.Equal(quoted.ID, dbStatus.QuoteID)
+	suite.Equal(quoted.URI, dbStatus.QuoteURI)
+}
+
+func (suite *StatusCreateTestSuite) TestProcessQuoteFollowersOnly() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// Followers-only status visible to
+	// the creating account, as it's a follower.
+	quoted := suite.testStatuses["local_account_2_status_7"]
+
+	statusCreateForm := &apimodel.StatusCreateRequest{
+		Status:         "look at this",
+		MediaIDs:       []string{},
+		QuotedStatusID: quoted.ID,
+		Visibility:     apimodel.VisibilityPublic,
+		LocalOnly:      util.Ptr(false),
+		Language:       "en",
+		ContentType:    apimodel.StatusContentTypePlain,
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	if suite.Error(err) {
+		suite.Equal("Unprocessable Entity: only public or unlisted statuses can be quoted", err.Safe())
+	}
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -0,0 +1,363 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Edit processes the given form to edit the target status, storing
+// the previous version of the status as a historical status edit,
+// and returning the api model representation of the edited status.
+//
+// Visibility, reply target and interaction policy of the status
+// cannot be changed by an edit, and are carried over as-is.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) Edit(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	statusID string,
+	form *apimodel.StatusEditRequest,
+) (
+	*apimodel.Status,
+	gtserror.WithCode,
+) {
+	// Fetch status and ensure it's owned by requesting account.
+	status, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+		requester,
+		statusID,
+		nil, // default freshness
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if status.AccountID != requester.ID {
+		err := gtserror.Newf(
+			"status %s does not belong to account %s",
+			statusID, requester.ID,
+		)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	if status.BoostOfID != "" {
+		const text = "cannot edit a boost"
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Get a lock on this status.
+	unlock := p.state.ProcessingLocks.Lock(status.URI)
+	defer unlock()
+
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	// Ensure status is fully populated, we need
+	// the current attachments, poll and mentions.
+	if err := p.state.DB.PopulateStatus(ctx, status); err != nil {
+		err := gtserror.Newf("error populating status: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Use requester as status author,
+	// as this has settings populated.
+	status.Account = requester
+
+	// Get current time.
+	now := time.Now()
+
+	// Snapshot the current version of the
+	// status as a historical status edit.
+	edit := status.Snapshot()
+	edit.ID = id.NewULID()
+
+	// Update any media attributes (description, focus) on form.
+	if errWithCode := p.processMediaAttributes(ctx,
+		requester.ID,
+		form.MediaIDs,
+		form.MediaAttributes,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Reset attachments and re-run through
+	// provided IDs, this allows media to be
+	// removed, added or re-ordered by edit.
+	status.Attachments = nil
+	status.AttachmentIDs = nil
+	if errWithCode := p.processMediaIDs(ctx, form.MediaIDs, requester.ID, status); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Fallback to existing status language
+	// when none is explicitly given in form.
+	language := status.Language
+	if language == "" {
+		language = requester.Settings.Language
+	}
+
+	if err := processLanguage(form.Language, language, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Keep old poll around so that we
+	// can compare it to the edited one.
+	oldPoll := status.Poll
+
+	if form.Poll != nil {
+		// Create new poll for status from form.
+		secs := time.Duration(form.Poll.ExpiresIn)
+		status.Poll = &gtsmodel.Poll{
+			ID:         id.NewULID(),
+			Multiple:   &form.Poll.Multiple,
+			HideCounts: &form.Poll.HideTotals,
+			Options:    form.Poll.Options,
+			StatusID:   status.ID,
+			Status:     status,
+			ExpiresAt:  now.Add(secs * time.Second),
+		}
+	} else {
+		// Poll removed.
+		status.Poll = nil
+	}
+
+	// Keep old mention IDs around so
+	// we can drop those no longer used.
+	oldMentionIDs := status.MentionIDs
+
+	// Reset formatting results
+	// before processing content.
+	status.Mentions = nil
+	status.Tags = nil
+	status.Emojis = nil
+
+	if err := p.processContent(ctx,
+		p.parseMention,
+		form.ContentType,
+		form.Status,
+		form.SpoilerText,
+		status,
+	); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Process poll changes, now that
+	// new poll options are formatted.
+	newPoll, errWithCode := p.processPollEdit(ctx,
+		oldPoll,
+		status.Poll,
+		edit,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	status.Poll = newPoll
+	if newPoll != nil {
+		status.PollID = newPoll.ID
+		status.ActivityStreamsType = ap.ActivityQuestion
+	} else {
+		status.PollID = ""
+		status.ActivityStreamsType = ap.ObjectNote
+	}
+
+	// Set remaining edited fields.
+	status.Text = form.Status
+	status.Sensitive = &form.Sensitive
+	status.EditedAt = now
+
+	// Insert the historical edit in the database.
+	if err := p.state.DB.PutStatusEdit(ctx, edit); err != nil {
+		err := gtserror.Newf("error inserting status edit in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Add the edit to status edits.
+	status.EditIDs = append(status.EditIDs, edit.ID)
+	status.Edits = append(status.Edits, edit)
+
+	// Update the edited status in the database.
+	if err := p.state.DB.UpdateStatus(ctx, status); err != nil {
+		err := gtserror.Newf("error updating status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Drop any mentions no longer
+	// referenced by edited status.
+	for _, id := range oldMentionIDs {
+		if slices.Contains(status.MentionIDs, id) {
+			continue
+		}
+
+		if err := p.state.DB.DeleteMentionByID(ctx, id); err != nil &&
+			!errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error deleting stale mention %s: %v", id, err)
+		}
+	}
+
+	// Send it to the client API worker for async side-effects.
+	p.state.Workers.Client.Queue.Push(&messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		Origin:         requester,
+	})
+
+	if newPoll != nil && newPoll != oldPoll {
+		// Now that the status is updated, and side effects queued,
+		// attempt to schedule an expiry handler for the new poll.
+		if err := p.polls.ScheduleExpiry(ctx, newPoll); err != nil {
+			log.Errorf(ctx, "error scheduling poll expiry: %v", err)
+		}
+	}
+
+	return p.c.GetAPIStatus(ctx, requester, status)
+}
+
+// processMediaAttributes updates the description and focus point of
+// media attachments according to given attributes from an edit form.
+// Only media also included in the form's media IDs may be updated.
+func (p *Processor) processMediaAttributes(
+	ctx context.Context,
+	thisAccountID string,
+	mediaIDs []string,
+	attrs []apimodel.AttachmentAttributesRequest,
+) gtserror.WithCode {
+	for _, attr := range attrs {
+		if !slices.Contains(mediaIDs, attr.ID) {
+			text := fmt.Sprintf("media %s not included in media ids", attr.ID)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		attachment, err := p.state.DB.GetAttachmentByID(ctx, attr.ID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error fetching media from db: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if attachment == nil {
+			text := fmt.Sprintf("media %s not found", attr.ID)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		if attachment.AccountID != thisAccountID {
+			text := fmt.Sprintf("media %s does not belong to account", attr.ID)
+			return gtserror.NewErrorBadRequest(errors.New(text), text)
+		}
+
+		// Take a copy of the attachment model
+		// before updating, as it may be cached
+		// and / or referenced in status edits.
+		attachmentCopy := new(gtsmodel.MediaAttachment)
+		*attachmentCopy = *attachment
+
+		columns := []string{"description"}
+		attachmentCopy.Description = text.SanitizeToPlaintext(attr.Description)
+
+		if attr.Focus != "" {
+			focusx, focusy, err := util.ParseFocus(attr.Focus)
+			if err != nil {
+				return gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			attachmentCopy.FileMeta.Focus.X = focusx
+			attachmentCopy.FileMeta.Focus.Y = focusy
+			columns = append(columns, "focus_x", "focus_y")
+		}
+
+		if err := p.state.DB.UpdateAttachment(ctx, attachmentCopy, columns...); err != nil {
+			err := gtserror.Newf("error updating media in db: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return nil
+}
+
+// processPollEdit compares the old status poll to the newly formatted
+// edited poll, returning the poll that the status should now reference.
+// If the poll is unchanged (aside from hiding counts), the old poll is
+// kept with votes intact. Otherwise, the old poll and its votes are
+// deleted, with vote counts recorded on the historical status edit.
+func (p *Processor) processPollEdit(
+	ctx context.Context,
+	oldPoll *gtsmodel.Poll,
+	newPoll *gtsmodel.Poll,
+	edit *gtsmodel.StatusEdit,
+) (*gtsmodel.Poll, gtserror.WithCode) {
+	if oldPoll != nil && newPoll != nil &&
+		slices.Equal(oldPoll.Options, newPoll.Options) &&
+		*oldPoll.Multiple == *newPoll.Multiple {
+		// Poll was kept along with its
+		// votes, no need to store counts.
+		edit.PollVotes = nil
+
+		// Poll is unchanged, only update
+		// whether counts should be hidden.
+		oldPoll.HideCounts = newPoll.HideCounts
+		if err := p.state.DB.UpdatePoll(ctx, oldPoll, "hide_counts"); err != nil {
+			err := gtserror.Newf("error updating poll in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		return oldPoll, nil
+	}
+
+	if oldPoll != nil {
+		// Delete the old poll from the database.
+		if err := p.state.DB.DeletePollByID(ctx, oldPoll.ID); err != nil {
+			err := gtserror.Newf("error deleting poll from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Delete any poll votes pointing to the old poll.
+		if err := p.state.DB.DeletePollVotes(ctx, oldPoll.ID); err != nil {
+			err := gtserror.Newf("error deleting poll votes from db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		// Cancel any scheduled expiry task for old poll.
+		_ = p.state.Workers.Scheduler.Cancel(oldPoll.ID)
+	}
+
+	if newPoll != nil {
+		// Try to insert the new status poll in the database.
+		if err := p.state.DB.PutPoll(ctx, newPoll); err != nil {
+			err := gtserror.Newf("error inserting poll in db: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	return newPoll, nil
+}
@@ -0,0 +1,150 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusEditTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusEditTestSuite) TestEditStatus() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	status := suite.testStatuses["local_account_1_status_1"]
+
+	apiStatus, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status:      "hello everyone, i've been edited!",
+		Sensitive:   true,
+		SpoilerText: "introduction post",
+		ContentType: apimodel.StatusContentTypePlain,
+	})
+	suite.NoError(errWithCode)
+	suite.Equal("<p>hello everyone, i've been edited!</p>", apiStatus.Content)
+	suite.NotNil(apiStatus.EditedAt)
+
+	// Previous version should be stored.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Len(dbStatus.EditIDs, 1)
+	suite.Len(dbStatus.Edits, 1)
+	suite.Equal(status.Content, dbStatus.Edits[0].Content)
+	suite.Equal(status.Text, dbStatus.Edits[0].Text)
+	suite.True(status.CreatedAt.Equal(dbStatus.Edits[0].CreatedAt))
+
+	// History should contain both versions, oldest first.
+	history, errWithCode := suite.status.HistoryGet(ctx, requester, status.ID)
+	suite.NoError(errWithCode)
+	suite.Len(history, 2)
+	suite.Equal(status.Content, history[0].Content)
+	suite.Equal(apiStatus.Content, history[1].Content)
+	suite.Equal(*apiStatus.EditedAt, history[1].CreatedAt)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusNotOwned() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	status := suite.testStatuses["local_account_2_status_1"]
+
+	_, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status: "i'm not allowed to do this",
+	})
+	suite.Error(errWithCode)
+	suite.Equal(404, errWithCode.Code())
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusPollUnchanged() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	status := suite.testStatuses["local_account_1_status_6"]
+	poll := testrig.NewTestPolls()["local_account_1_status_6_poll"]
+
+	_, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status: "what do you think of sloths? (edited)",
+		Poll: &apimodel.PollRequest{
+			Options:    poll.Options,
+			ExpiresIn:  3600,
+			Multiple:   *poll.Multiple,
+			HideTotals: false,
+		},
+	})
+	suite.NoError(errWithCode)
+
+	// Poll should be kept, with votes intact.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(poll.ID, dbStatus.PollID)
+	suite.Equal(poll.Votes, dbStatus.Poll.Votes)
+	suite.False(*dbStatus.Poll.HideCounts)
+	suite.Equal(poll.Options, dbStatus.Edits[0].PollOptions)
+	suite.Empty(dbStatus.Edits[0].PollVotes)
+}
+
+func (suite *StatusEditTestSuite) TestEditStatusPollChanged() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	status := suite.testStatuses["local_account_1_status_6"]
+	poll := testrig.NewTestPolls()["local_account_1_status_6_poll"]
+
+	// Ensure scheduler is running,
+	// as expiry of the new poll
+	// will need to be scheduled.
+	_ = suite.state.Workers.Scheduler.Start()
+
+	_, errWithCode := suite.status.Edit(ctx, requester, status.ID, &apimodel.StatusEditRequest{
+		Status: "what do you think of sloths? (edited)",
+		Poll: &apimodel.PollRequest{
+			Options:   []string{"good", "great", "the best"},
+			ExpiresIn: 3600,
+		},
+	})
+	suite.NoError(errWithCode)
+
+	// Poll should be replaced, with old votes stored on edit.
+	dbStatus, err := suite.db.GetStatusByID(ctx, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.NotEqual(poll.ID, dbStatus.PollID)
+	suite.Equal([]string{"good", "great", "the best"}, dbStatus.Poll.Options)
+	suite.Equal(poll.Options, dbStatus.Edits[0].PollOptions)
+	suite.Equal(poll.Votes, dbStatus.Edits[0].PollVotes)
+
+	// Old poll should be gone.
+	_, err = suite.db.GetPollByID(ctx, poll.ID)
+	suite.Error(err)
+}
+
+func TestStatusEditTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusEditTestSuite))
+}
@@ -23,11 +23,10 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
-	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // HistoryGet gets edit history for the target status, taking account of privacy settings and blocks etc.
-// TODO: currently this just returns the latest version of the status.
+// Returned edits are ordered oldest first, with the current version of the status as the final entry.
 func (p *Processor) HistoryGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) ([]*apimodel.StatusEdit, gtserror.WithCode) {
 	targetStatus, errWithCode := p.c.GetVisibleTargetStatus(ctx,
 		requestingAccount,
@@ -38,23 +37,23 @@ func (p *Processor) HistoryGet(ctx context.Context, requestingAccount *gtsmodel.
 		return nil, errWithCode
 	}
 
-	apiStatus, errWithCode := p.c.GetAPIStatus(ctx, requestingAccount, targetStatus)
+	// Redirect to wrapped status if boost.
+	targetStatus, errWithCode = p.c.UnwrapIfBoost(
+		ctx,
+		requestingAccount,
+		targetStatus,
+	)
 	if errWithCode != nil {
 		return nil, errWithCode
 	}
 
-	return []*apimodel.StatusEdit{
-		{
-			Content:          apiStatus.Content,
-			SpoilerText:      apiStatus.SpoilerText,
-			Sensitive:        apiStatus.Sensitive,
-			CreatedAt:        util.FormatISO8601(targetStatus.UpdatedAt),
-			Account:          apiStatus.Account,
-			Poll:             apiStatus.Poll,
-			MediaAttachments: apiStatus.MediaAttachments,
-			Emojis:           apiStatus.Emojis,
-		},
-	}, nil
+	apiEdits, err := p.converter.StatusToAPIEdits(ctx, targetStatus)
+	if err != nil {
+		err = gtserror.Newf("error converting status edits: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return apiEdits, nil
 }
 
 // Get gets the given status, taking account of privacy settings and blocks etc.
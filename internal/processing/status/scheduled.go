@@ -0,0 +1,668 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// scheduledStatusMinOffset is the minimum amount
+	// of time into the future a status can be scheduled.
+	scheduledStatusMinOffset = 5 * time.Minute
+
+	// scheduledStatusTotalLimit is the maximum
+	// number of pending scheduled statuses per account.
+	scheduledStatusTotalLimit = 300
+
+	// scheduledStatusDailyLimit is the maximum number of
+	// statuses an account may schedule on any one (UTC) day.
+	scheduledStatusDailyLimit = 25
+
+	// scheduledStatusMaxAttempts is the maximum number of
+	// times publishing a scheduled status is attempted,
+	// when it fails with a (possibly temporary) server error.
+	scheduledStatusMaxAttempts = 5
+
+	// scheduledStatusRetryBackoff is how long to wait before
+	// retrying to publish a scheduled status for the first
+	// time, doubling with each attempt after that.
+	scheduledStatusRetryBackoff = time.Minute
+)
+
+// ScheduledStatusCreate processes the given form to schedule a new status
+// for publishing at form.ScheduledAt, returning the api model representation
+// of the scheduled status if it's OK.
+//
+// Precondition: the form's fields should have already been validated and normalized by the caller.
+func (p *Processor) ScheduledStatusCreate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	application *gtsmodel.Application,
+	form *apimodel.StatusCreateRequest,
+) (
+	*apimodel.ScheduledStatus,
+	gtserror.WithCode,
+) {
+	// Ensure account populated; we'll need settings.
+	if err := p.state.DB.PopulateAccount(ctx, requester); err != nil {
+		log.Errorf(ctx, "error(s) populating account, will continue: %s", err)
+	}
+
+	scheduledAt, errWithCode := p.parseScheduledAt(ctx, requester.ID, form.ScheduledAt, time.Time{})
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Check the total number of pending scheduled statuses for this account.
+	total, err := p.state.DB.CountScheduledStatusesForAcct(ctx, requester.ID, time.Time{}, time.Time{})
+	if err != nil {
+		err := gtserror.Newf("error counting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if total >= scheduledStatusTotalLimit {
+		text := fmt.Sprintf("total number of scheduled statuses cannot exceed %d", scheduledStatusTotalLimit)
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	if form.InReplyToID != "" {
+		// Ensure the in-reply-to status exists and
+		// is visible; this is checked again when the
+		// scheduled status is eventually published.
+		if _, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+			requester,
+			form.InReplyToID,
+			nil,
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	if form.QuotedStatusID != "" {
+		// Likewise ensure the quoted status
+		// exists and is visible; permission to
+		// quote is checked again at publish time.
+		if _, errWithCode := p.c.GetVisibleTargetStatus(ctx,
+			requester,
+			form.QuotedStatusID,
+			nil,
+		); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
+	// Generate new ID for scheduled status.
+	scheduledStatusID := id.NewULID()
+
+	// Check + gather scheduled status media.
+	attachments, errWithCode := p.processScheduledMediaIDs(ctx,
+		form.MediaIDs,
+		requester.ID,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Use visibility from form, falling
+	// back to the account's default privacy.
+	visibility := requester.Settings.Privacy
+	if form.Visibility != "" {
+		visibility = typeutils.APIVisToVis(form.Visibility)
+	}
+
+	scheduledStatus := &gtsmodel.ScheduledStatus{
+		ID:               scheduledStatusID,
+		AccountID:        requester.ID,
+		Account:          requester,
+		ScheduledAt:      scheduledAt,
+		Text:             form.Status,
+		SpoilerText:      form.SpoilerText,
+		Sensitive:        &form.Sensitive,
+		Visibility:       visibility,
+		LocalOnly:        util.Ptr(util.PtrOrValue(form.LocalOnly, false)),
+		Language:         form.Language,
+		ContentType:      string(form.ContentType),
+		InReplyToID:      form.InReplyToID,
+		QuoteID:          form.QuotedStatusID,
+		MediaIDs:         form.MediaIDs,
+		MediaAttachments: attachments,
+		ApplicationID:    application.ID,
+		Application:      application,
+	}
+
+	if form.Poll != nil {
+		scheduledStatus.PollOptions = form.Poll.Options
+		scheduledStatus.PollExpiresIn = form.Poll.ExpiresIn
+		scheduledStatus.PollMultiple = &form.Poll.Multiple
+		scheduledStatus.PollHideTotals = &form.Poll.HideTotals
+	}
+
+	// Insert this new scheduled status in the database.
+	if err := p.state.DB.PutScheduledStatus(ctx, scheduledStatus); err != nil {
+		err := gtserror.Newf("error inserting scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Mark each of the media attachments as
+	// belonging to the new scheduled status.
+	for _, attachment := range attachments {
+		attachment.ScheduledStatusID = scheduledStatusID
+		if err := p.state.DB.UpdateAttachment(ctx,
+			attachment,
+			"scheduled_status_id",
+		); err != nil {
+			err := gtserror.Newf("error updating media %s: %w", attachment.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	// Now the scheduled status is inserted, add it to the scheduler.
+	if err := p.scheduleStatus(ctx, scheduledStatus); err != nil {
+		log.Errorf(ctx, "error scheduling status: %v", err)
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusesGetPage returns a page of the
+// requester's scheduled statuses, newest first.
+func (p *Processor) ScheduledStatusesGetPage(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	scheduledStatuses, err := p.state.DB.GetScheduledStatusesForAcct(ctx,
+		requester.ID,
+		page,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled statuses: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(scheduledStatuses)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = scheduledStatuses[count-1].ID
+		hi = scheduledStatuses[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, scheduledStatus := range scheduledStatuses {
+		apiScheduledStatus, errWithCode := p.toAPIScheduledStatus(ctx, scheduledStatus)
+		if errWithCode != nil {
+			log.Errorf(ctx, "error converting scheduled status %s: %v", scheduledStatus.ID, errWithCode)
+			continue
+		}
+
+		// Append scheduled status to return items.
+		items = append(items, apiScheduledStatus)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/scheduled_statuses",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// ScheduledStatusGet returns the requester's scheduled status with given ID.
+func (p *Processor) ScheduledStatusGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledStatusID string,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusUpdate moves the requester's scheduled status
+// with given ID to be published at the new given scheduledAt time.
+func (p *Processor) ScheduledStatusUpdate(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledStatusID string,
+	form *apimodel.ScheduledStatusUpdateRequest,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	scheduledAt, errWithCode := p.parseScheduledAt(ctx,
+		requester.ID,
+		form.ScheduledAt,
+		scheduledStatus.ScheduledAt,
+	)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// Update the scheduled time in the database,
+	// clearing any previous failure to publish.
+	scheduledStatus.ScheduledAt = scheduledAt
+	scheduledStatus.PublishAttempts = 0
+	scheduledStatus.FailedAt = time.Time{}
+	if err := p.state.DB.UpdateScheduledStatus(ctx,
+		scheduledStatus,
+		"scheduled_at",
+		"publish_attempts",
+		"failed_at",
+	); err != nil {
+		err := gtserror.Newf("error updating scheduled status in db: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// Replace the existing scheduler job with one at the new time.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+	if err := p.scheduleStatus(ctx, scheduledStatus); err != nil {
+		log.Errorf(ctx, "error rescheduling status: %v", err)
+	}
+
+	return p.toAPIScheduledStatus(ctx, scheduledStatus)
+}
+
+// ScheduledStatusDelete cancels and deletes
+// the requester's scheduled status with given ID.
+func (p *Processor) ScheduledStatusDelete(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledStatusID string,
+) gtserror.WithCode {
+	scheduledStatus, errWithCode := p.getOwnScheduledStatus(ctx, requester, scheduledStatusID)
+	if errWithCode != nil {
+		return errWithCode
+	}
+
+	// Stop this from being published.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+
+	if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// ScheduledStatusesScheduleAll adds all pending scheduled statuses in the
+// database to the scheduler. This should be called once at startup, so
+// that scheduled statuses survive server restarts. Any statuses whose
+// scheduled time passed while the server was down are published ASAP.
+// Statuses that failed to publish are left for the user to reschedule.
+func (p *Processor) ScheduledStatusesScheduleAll(ctx context.Context) error {
+	// Fetch all scheduled statuses from the database (barebones models are enough).
+	scheduledStatuses, err := p.state.DB.GetAllScheduledStatuses(gtscontext.SetBarebones(ctx))
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting scheduled statuses from db: %w", err)
+	}
+
+	var errs gtserror.MultiError
+
+	for _, scheduledStatus := range scheduledStatuses {
+		if !scheduledStatus.FailedAt.IsZero() {
+			// Failed to publish,
+			// don't try again.
+			continue
+		}
+
+		// Schedule each of the statuses and catch any errors.
+		if err := p.scheduleStatus(ctx, scheduledStatus); err != nil {
+			errs.Append(err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// scheduleStatus adds the given scheduled status to the
+// scheduler, to be published at its configured ScheduledAt time.
+func (p *Processor) scheduleStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	// Add the given scheduled status to the scheduler.
+	ok := p.state.Workers.Scheduler.AddOnce(
+		scheduledStatus.ID,
+		scheduledStatus.ScheduledAt,
+		p.onScheduledAt(scheduledStatus.ID),
+	)
+
+	if !ok {
+		// Failed to add the status to the scheduler, either it was
+		// starting / stopping or there already exists a task for it.
+		return gtserror.Newf("failed adding scheduled status %s to scheduler", scheduledStatus.ID)
+	}
+
+	atStr := scheduledStatus.ScheduledAt.Local().Format("Jan _2 2006 15:04:05")
+	log.Infof(ctx, "scheduled status %s for publishing at '%s'", scheduledStatus.ID, atStr)
+	return nil
+}
+
+// onScheduledAt returns a callback function to be used by the
+// scheduler when the given scheduled status is due to be published.
+func (p *Processor) onScheduledAt(scheduledStatusID string) func(context.Context, time.Time) {
+	return func(ctx context.Context, now time.Time) {
+		// Get the latest version of scheduled status from database.
+		scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledStatusID)
+		if errors.Is(err, db.ErrNoEntries) {
+			// Deleted in the meantime,
+			// e.g. along with its account.
+			return
+		} else if err != nil {
+			log.Errorf(ctx, "error getting scheduled status %s from db: %v", scheduledStatusID, err)
+			return
+		}
+
+		account := scheduledStatus.Account
+		if account == nil || account.IsSuspended() {
+			// Account no longer exists or was
+			// suspended, so don't publish anything.
+			if err := p.deleteScheduledStatus(ctx, scheduledStatus); err != nil {
+				log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledStatusID, err)
+			}
+			return
+		}
+
+		// Release any attached media before publishing, so
+		// that it can be attached to the newly created status.
+		if err := p.setScheduledMedia(ctx, scheduledStatus, ""); err != nil {
+			log.Errorf(ctx, "error releasing scheduled status %s media: %v", scheduledStatusID, err)
+			return
+		}
+
+		application := scheduledStatus.Application
+		if application == nil {
+			// Application may have since been
+			// deleted, don't let this stop us.
+			application = new(gtsmodel.Application)
+		}
+
+		// Recreate the original status create form.
+		form := &apimodel.StatusCreateRequest{
+			Status:         scheduledStatus.Text,
+			MediaIDs:       scheduledStatus.MediaIDs,
+			InReplyToID:    scheduledStatus.InReplyToID,
+			QuotedStatusID: scheduledStatus.QuoteID,
+			Sensitive:      util.PtrOrValue(scheduledStatus.Sensitive, false),
+			SpoilerText:    scheduledStatus.SpoilerText,
+			Visibility:     p.converter.VisToAPIVis(ctx, scheduledStatus.Visibility),
+			LocalOnly:      scheduledStatus.LocalOnly,
+			Language:       scheduledStatus.Language,
+			ContentType:    apimodel.StatusContentType(scheduledStatus.ContentType),
+		}
+
+		if len(scheduledStatus.PollOptions) > 0 {
+			form.Poll = &apimodel.PollRequest{
+				Options:    scheduledStatus.PollOptions,
+				ExpiresIn:  scheduledStatus.PollExpiresIn,
+				Multiple:   util.PtrOrValue(scheduledStatus.PollMultiple, false),
+				HideTotals: util.PtrOrValue(scheduledStatus.PollHideTotals, false),
+			}
+		}
+
+		// Finally, publish the status.
+		if _, errWithCode := p.Create(ctx,
+			account,
+			application,
+			form,
+		); errWithCode != nil {
+			log.Errorf(ctx, "error publishing scheduled status %s: %v", scheduledStatusID, errWithCode)
+
+			// Keep the scheduled status around (with its media)
+			// so that it can be retried, or so that the user can
+			// still see it, and fix it up + reschedule it, or
+			// delete it themselves.
+			if err := p.setScheduledMedia(ctx, scheduledStatus, scheduledStatus.ID); err != nil {
+				log.Errorf(ctx, "error reattaching scheduled status %s media: %v", scheduledStatusID, err)
+			}
+
+			p.onPublishFailed(ctx, scheduledStatus, errWithCode)
+			return
+		}
+
+		// Status was published, the scheduled status can go.
+		if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledStatusID); err != nil {
+			log.Errorf(ctx, "error deleting scheduled status %s: %v", scheduledStatusID, err)
+		}
+	}
+}
+
+// onPublishFailed handles failure to publish the given scheduled status
+// with the given error. Server errors may be temporary, so publishing is
+// retried with increasing backoff, up to scheduledStatusMaxAttempts times.
+// Other errors (eg., the replied-to status was deleted) won't go away by
+// themselves, so the scheduled status is marked as failed straight away,
+// leaving it for the user to fix up and reschedule, or delete.
+func (p *Processor) onPublishFailed(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+	errWithCode gtserror.WithCode,
+) {
+	scheduledStatus.PublishAttempts++
+	retry := errWithCode.Code() >= http.StatusInternalServerError &&
+		scheduledStatus.PublishAttempts < scheduledStatusMaxAttempts
+
+	columns := []string{"publish_attempts"}
+	if !retry {
+		scheduledStatus.FailedAt = time.Now()
+		columns = append(columns, "failed_at")
+	}
+
+	if err := p.state.DB.UpdateScheduledStatus(ctx,
+		scheduledStatus,
+		columns...,
+	); err != nil {
+		log.Errorf(ctx, "error updating scheduled status %s: %v", scheduledStatus.ID, err)
+	}
+
+	// Drop the spent scheduler job, so that
+	// the status can be scheduled again.
+	p.state.Workers.Scheduler.Cancel(scheduledStatus.ID)
+
+	if !retry {
+		log.Warnf(ctx, "giving up publishing scheduled status %s", scheduledStatus.ID)
+		return
+	}
+
+	backoff := scheduledStatusRetryBackoff << (scheduledStatus.PublishAttempts - 1)
+	if !p.state.Workers.Scheduler.AddOnce(
+		scheduledStatus.ID,
+		time.Now().Add(backoff),
+		p.onScheduledAt(scheduledStatus.ID),
+	) {
+		log.Errorf(ctx, "failed rescheduling scheduled status %s", scheduledStatus.ID)
+		return
+	}
+
+	log.Infof(ctx, "retrying publishing scheduled status %s in %s", scheduledStatus.ID, backoff)
+}
+
+// parseScheduledAt parses the given scheduled_at
+// string, checking that it is far enough into the
+// future, and doesn't take the account over its
+// daily scheduled status limit. The previous time
+// should be set if an existing scheduled status is
+// being moved, and otherwise left as zero time.
+func (p *Processor) parseScheduledAt(
+	ctx context.Context,
+	accountID string,
+	scheduledAtStr string,
+	previous time.Time,
+) (time.Time, gtserror.WithCode) {
+	scheduledAt, err := time.Parse(time.RFC3339, scheduledAtStr)
+	if err != nil {
+		text := fmt.Sprintf("invalid scheduled_at %s: %v", scheduledAtStr, err)
+		return time.Time{}, gtserror.NewErrorBadRequest(errors.New(text), text)
+	}
+
+	if scheduledAt.Before(time.Now().Add(scheduledStatusMinOffset)) {
+		const text = "scheduled_at must be at least 5 minutes in the future"
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	// Count the number of statuses
+	// scheduled for that (UTC) day.
+	dayStart := scheduledAt.UTC().Truncate(24 * time.Hour)
+	dayEnd := dayStart.Add(24 * time.Hour)
+	count, err := p.state.DB.CountScheduledStatusesForAcct(ctx,
+		accountID,
+		dayStart,
+		dayEnd,
+	)
+	if err != nil {
+		err := gtserror.Newf("error counting scheduled statuses: %w", err)
+		return time.Time{}, gtserror.NewErrorInternalError(err)
+	}
+
+	if !previous.Before(dayStart) && previous.Before(dayEnd) {
+		// Don't count the status being
+		// moved if it's already on this day.
+		count--
+	}
+
+	if count >= scheduledStatusDailyLimit {
+		text := fmt.Sprintf("number of statuses scheduled for the same day cannot exceed %d", scheduledStatusDailyLimit)
+		return time.Time{}, gtserror.NewErrorUnprocessableEntity(errors.New(text), text)
+	}
+
+	return scheduledAt, nil
+}
+
+// processScheduledMediaIDs checks that the given media IDs are all
+// owned by account, and not yet attached to any (scheduled) status.
+func (p *Processor) processScheduledMediaIDs(
+	ctx context.Context,
+	mediaIDs []string,
+	accountID string,
+) ([]*gtsmodel.MediaAttachment, gtserror.WithCode) {
+	// Reuse status create media checks
+	// with a placeholder status model.
+	status := new(gtsmodel.Status)
+	if errWithCode := p.processMediaIDs(ctx,
+		mediaIDs,
+		accountID,
+		status,
+	); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return status.Attachments, nil
+}
+
+// getOwnScheduledStatus fetches the scheduled status with
+// given ID, ensuring that it belongs to requester.
+func (p *Processor) getOwnScheduledStatus(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	scheduledStatusID string,
+) (*gtsmodel.ScheduledStatus, gtserror.WithCode) {
+	scheduledStatus, err := p.state.DB.GetScheduledStatusByID(ctx, scheduledStatusID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting scheduled status %s: %w", scheduledStatusID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if scheduledStatus == nil || scheduledStatus.AccountID != requester.ID {
+		// Don't leak existence of other accounts' scheduled statuses.
+		err := gtserror.Newf("scheduled status %s not found", scheduledStatusID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return scheduledStatus, nil
+}
+
+// setScheduledMedia sets the scheduled status ID of the media attached
+// to the given scheduled status, where an empty ID detaches the media.
+// Media that has since been attached elsewhere is left untouched.
+func (p *Processor) setScheduledMedia(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+	scheduledStatusID string,
+) error {
+	for _, attachment := range scheduledStatus.MediaAttachments {
+		if attachment.StatusID != "" ||
+			(attachment.ScheduledStatusID != "" &&
+				attachment.ScheduledStatusID != scheduledStatus.ID) {
+			continue
+		}
+
+		attachment.ScheduledStatusID = scheduledStatusID
+		if err := p.state.DB.UpdateAttachment(ctx,
+			attachment,
+			"scheduled_status_id",
+		); err != nil {
+			return gtserror.Newf("error updating media %s: %w", attachment.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteScheduledStatus deletes the given scheduled status from the
+// database, after detaching any media that was attached to it.
+func (p *Processor) deleteScheduledStatus(ctx context.Context, scheduledStatus *gtsmodel.ScheduledStatus) error {
+	if err := p.setScheduledMedia(ctx, scheduledStatus, ""); err != nil {
+		return err
+	}
+
+	if err := p.state.DB.DeleteScheduledStatusByID(ctx, scheduledStatus.ID); err != nil {
+		return gtserror.Newf("error deleting scheduled status %s: %w", scheduledStatus.ID, err)
+	}
+
+	return nil
+}
+
+// toAPIScheduledStatus converts the given scheduled status
+// to frontend API model, returning an appropriate error with
+// HTTP code on failure.
+func (p *Processor) toAPIScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, gtserror.WithCode) {
+	apiScheduledStatus, err := p.converter.ScheduledStatusToAPIScheduledStatus(ctx, scheduledStatus)
+	if err != nil {
+		err := gtserror.Newf("error converting to api model: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	return apiScheduledStatus, nil
+}
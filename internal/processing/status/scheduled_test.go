@@ -0,0 +1,288 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package status_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ScheduledStatusTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *ScheduledStatusTestSuite) SetupTest() {
+	suite.StatusStandardTestSuite.SetupTest()
+
+	// The previous test's scheduler routine may
+	// still stop the scheduler on its way out, after
+	// it was started again for this test, so ensure
+	// it's running as these tests rely on it.
+	_ = suite.state.Workers.Scheduler.Start()
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateGetUpdateDelete() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	scheduledAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "this is a scheduled status!",
+		MediaIDs:    []string{attachment.ID},
+		Visibility:  apimodel.VisibilityUnlisted,
+		ScheduledAt: scheduledAt.Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(apiScheduledStatus.ID)
+	suite.Equal("this is a scheduled status!", apiScheduledStatus.Params.Text)
+	suite.Equal("unlisted", apiScheduledStatus.Params.Visibility)
+	suite.Equal(application.ID, apiScheduledStatus.Params.ApplicationID)
+	suite.Equal([]string{attachment.ID}, apiScheduledStatus.Params.MediaIDs)
+	suite.Len(apiScheduledStatus.MediaAttachments, 1)
+
+	// Media should now belong to the scheduled status.
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(apiScheduledStatus.ID, dbAttachment.ScheduledStatusID)
+
+	// Scheduled status should be listed.
+	resp, errWithCode := suite.status.ScheduledStatusesGetPage(ctx, requester, &paging.Page{Limit: 20})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(resp.Items, 1)
+
+	// Move it to an hour later.
+	newScheduledAt := scheduledAt.Add(time.Hour)
+	apiScheduledStatus, errWithCode = suite.status.ScheduledStatusUpdate(ctx, requester, apiScheduledStatus.ID, &apimodel.ScheduledStatusUpdateRequest{
+		ScheduledAt: newScheduledAt.Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(newScheduledAt.Format("2006-01-02T15:04:05.000Z"), apiScheduledStatus.ScheduledAt)
+
+	// Other accounts shouldn't be able to see it.
+	_, errWithCode = suite.status.ScheduledStatusGet(ctx, suite.testAccounts["local_account_2"], apiScheduledStatus.ID)
+	suite.Equal(404, errWithCode.Code())
+
+	// Delete it.
+	errWithCode = suite.status.ScheduledStatusDelete(ctx, requester, apiScheduledStatus.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	_, errWithCode = suite.status.ScheduledStatusGet(ctx, requester, apiScheduledStatus.ID)
+	suite.Equal(404, errWithCode.Code())
+
+	// Media should be released again.
+	dbAttachment, err = suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbAttachment.ScheduledStatusID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusCreateTooSoon() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+
+	_, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "this is a scheduled status!",
+		ScheduledAt: time.Now().Add(time.Minute).Format(time.RFC3339),
+	})
+	suite.Equal(422, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: scheduled_at must be at least 5 minutes in the future", errWithCode.Safe())
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublish() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "this is a scheduled status!",
+		ScheduledAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Simulate the scheduled time passing while the
+	// instance was down: bring the scheduled time
+	// forward and reschedule as though at startup.
+	suite.state.Workers.Scheduler.Cancel(apiScheduledStatus.ID)
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	scheduledStatus.ScheduledAt = time.Now()
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduledStatus, "scheduled_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Scheduled status should be removed once published.
+	if !testrig.WaitFor(func() bool {
+		_, err := suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+		return errors.Is(err, db.ErrNoEntries)
+	}) {
+		suite.FailNow("timed out waiting for scheduled status to be published")
+	}
+
+	// The new status should have been sent for processing.
+	msg, ok := suite.state.Workers.Client.Queue.Pop()
+	if !ok {
+		suite.FailNow("no message in client queue")
+	}
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	status, ok := msg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		suite.FailNow("message model was not a status")
+	}
+	suite.Equal("this is a scheduled status!", status.Text)
+	suite.Equal(requester.ID, status.AccountID)
+	suite.Equal(application.ID, status.CreatedWithApplicationID)
+}
+
+func (suite *ScheduledStatusTestSuite) TestScheduledStatusPublishFailure() {
+	ctx := context.Background()
+
+	requester := suite.testAccounts["local_account_1"]
+	application := suite.testApplications["application_1"]
+	attachment := suite.testAttachments["local_account_1_unattached_1"]
+	inReplyTo := suite.testStatuses["local_account_2_status_1"]
+
+	apiScheduledStatus, errWithCode := suite.status.ScheduledStatusCreate(ctx, requester, application, &apimodel.StatusCreateRequest{
+		Status:      "this is a scheduled reply!",
+		MediaIDs:    []string{attachment.ID},
+		InReplyToID: inReplyTo.ID,
+		ScheduledAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Delete the status being replied to
+	// so that publishing it will fail.
+	if err := suite.db.DeleteStatusByID(ctx, inReplyTo.ID); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	updatedAt := dbAttachment.UpdatedAt
+
+	// Bring the scheduled time forward and reschedule.
+	suite.state.Workers.Scheduler.Cancel(apiScheduledStatus.ID)
+	scheduledStatus, err := suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	scheduledStatus.ScheduledAt = time.Now()
+	if err := suite.db.UpdateScheduledStatus(ctx, scheduledStatus, "scheduled_at"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Media is released on publishing, and
+	// should be reattached when that fails.
+	if !testrig.WaitFor(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachment.ID)
+		return err == nil &&
+			dbAttachment.UpdatedAt.After(updatedAt) &&
+			dbAttachment.ScheduledStatusID == apiScheduledStatus.ID
+	}) {
+		suite.FailNow("timed out waiting for scheduled status publish to fail")
+	}
+
+	// The scheduled status should still be there for the user to see.
+	apiScheduledStatus, errWithCode = suite.status.ScheduledStatusGet(ctx, requester, apiScheduledStatus.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal("this is a scheduled reply!", apiScheduledStatus.Params.Text)
+	suite.Len(apiScheduledStatus.MediaAttachments, 1)
+
+	// Nothing should have been published.
+	_, ok := suite.state.Workers.Client.Queue.Pop()
+	suite.False(ok)
+
+	// The replied-to status won't come back, so the
+	// scheduled status should be marked as failed
+	// rather than retried, even after a restart.
+	scheduledStatus, err = suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(1, scheduledStatus.PublishAttempts)
+	suite.False(scheduledStatus.FailedAt.IsZero())
+
+	if err := suite.status.ScheduledStatusesScheduleAll(ctx); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(suite.state.Workers.Scheduler.Cancel(apiScheduledStatus.ID))
+
+	// Rescheduling it should clear the failure.
+	_, errWithCode = suite.status.ScheduledStatusUpdate(ctx, requester, apiScheduledStatus.ID, &apimodel.ScheduledStatusUpdateRequest{
+		ScheduledAt: time.Now().Add(time.Hour).Format(time.RFC3339),
+	})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	scheduledStatus, err = suite.db.GetScheduledStatusByID(ctx, apiScheduledStatus.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(scheduledStatus.PublishAttempts)
+	suite.True(scheduledStatus.FailedAt.IsZero())
+	suite.True(suite.state.Workers.Scheduler.Cancel(apiScheduledStatus.ID))
+}
+
+func TestScheduledStatusTestSuite(t *testing.T) {
+	suite.Run(t, new(ScheduledStatusTestSuite))
+}
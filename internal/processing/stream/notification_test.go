@@ -69,6 +69,7 @@ func (suite *NotificationTestSuite) TestStreamNotification() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
     "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -72,6 +72,7 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
   "pinned": false,
   "content": "dark souls status bot: \"thoughts of dog\"",
   "reblog": null,
+  "quote": null,
   "account": {
     "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
     "username": "foss_satan",
@@ -80,6 +81,7 @@ func (suite *StatusUpdateTestSuite) TestStreamNotification() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
     "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -0,0 +1,416 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	// day is the length of one
+	// bucket of usage history.
+	day = 24 * time.Hour
+
+	// historyDays is the number of days of usage history
+	// kept per trend, and the window within which usage is
+	// considered when computing trends. The most recent day
+	// is compared against the days before it as a baseline.
+	historyDays = 7
+
+	// minTrendAccounts is the minimum number of distinct
+	// accounts that must have used a tag or link (or boosted
+	// or faved a status) in the last day for it to trend.
+	minTrendAccounts = 2
+
+	// maxTrends is the maximum number
+	// of trends stored for each type.
+	maxTrends = 100
+
+	// statusHalfLife is the age after which
+	// a status' trend score is halved, so that
+	// newer statuses are favoured over older ones.
+	statusHalfLife = 12 * time.Hour
+
+	// computeFrequency is how often trends are computed.
+	computeFrequency = 15 * time.Minute
+)
+
+// candidate wraps a potential
+// trend target and its usage.
+type candidate struct {
+	target   string
+	score    float64
+	uses     []int
+	accounts []int
+}
+
+// ScheduleTrends schedules a job to
+// compute trends every 15 minutes.
+func (p *Processor) ScheduleTrends() error {
+	fn := func(ctx context.Context, now time.Time) {
+		if err := p.Compute(ctx, now); err != nil {
+			log.Errorf(ctx, "error computing trends: %v", err)
+		}
+	}
+
+	if !p.state.Workers.Scheduler.AddRecurring(
+		"@trends",
+		time.Time{},
+		computeFrequency,
+		fn,
+	) {
+		panic("failed to schedule @trends")
+	}
+
+	return nil
+}
+
+// Compute computes currently trending tags, statuses and
+// links as of the given time, from local and federated
+// activity over the last week. New trends are stored as
+// pending review, and trends that are no longer trending
+// have their score zeroed, so they're no longer shown.
+func (p *Processor) Compute(ctx context.Context, now time.Time) error {
+	// Truncate to the second so that computation
+	// time can be compared exactly once stored.
+	now = now.Truncate(time.Second)
+	since := now.Add(-historyDays * day)
+
+	for _, compute := range []struct {
+		trendType gtsmodel.TrendType
+		fn        func(context.Context, time.Time, time.Time) ([]*candidate, error)
+	}{
+		{gtsmodel.TrendTypeTag, p.tagCandidates},
+		{gtsmodel.TrendTypeStatus, p.statusCandidates},
+		{gtsmodel.TrendTypeLink, p.linkCandidates},
+	} {
+		candidates, err := compute.fn(ctx, now, since)
+		if err != nil {
+			return gtserror.Newf("error computing %s trends: %w", compute.trendType, err)
+		}
+
+		if err := p.putTrends(ctx, compute.trendType, candidates, now); err != nil {
+			return gtserror.Newf("error storing %s trends: %w", compute.trendType, err)
+		}
+
+		log.Debugf(ctx, "computed %d %s trends", len(candidates), compute.trendType)
+	}
+
+	return nil
+}
+
+// tagCandidates returns trending tags
+// based on their use in public statuses.
+func (p *Processor) tagCandidates(ctx context.Context, now time.Time, since time.Time) ([]*candidate, error) {
+	uses, err := p.state.DB.GetTagUses(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting tag uses: %w", err)
+	}
+
+	candidates := scoreUses(uses, now)
+	return slices.DeleteFunc(candidates, func(c *candidate) bool {
+		tag, err := p.state.DB.GetTag(ctx, c.target)
+		if err != nil {
+			log.Errorf(ctx, "db error getting tag %s: %v", c.target, err)
+			return true
+		}
+
+		// Don't trend tags that admins
+		// have prevented from being used.
+		return !util.PtrOrValue(tag.Useable, true) ||
+			!util.PtrOrValue(tag.Listable, true)
+	}), nil
+}
+
+// linkCandidates returns trending links
+// based on their use in public statuses.
+func (p *Processor) linkCandidates(ctx context.Context, now time.Time, since time.Time) ([]*candidate, error) {
+	// Links shared before the window can
+	// no longer trend, so drop them first.
+	if err := p.state.DB.DeleteTrendLinksBefore(ctx, since); err != nil {
+		return nil, gtserror.Newf("db error deleting old links: %w", err)
+	}
+
+	uses, err := p.state.DB.GetLinkUses(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting link uses: %w", err)
+	}
+
+	return scoreUses(uses, now), nil
+}
+
+// statusCandidates returns trending statuses based on
+// how many accounts have recently boosted or faved them,
+// decaying with status age. Only non-sensitive, public
+// statuses by discoverable accounts may trend.
+func (p *Processor) statusCandidates(ctx context.Context, now time.Time, since time.Time) ([]*candidate, error) {
+	interactions, err := p.state.DB.GetStatusInteractions(ctx, since)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting status interactions: %w", err)
+	}
+
+	// Gather distinct interacting
+	// accounts for each status.
+	accounts := make(map[string]map[string]struct{})
+	for _, interaction := range interactions {
+		set, ok := accounts[interaction.Target]
+		if !ok {
+			set = make(map[string]struct{})
+			accounts[interaction.Target] = set
+		}
+		set[interaction.AccountID] = struct{}{}
+	}
+
+	var candidates []*candidate
+	for statusID, set := range accounts {
+		if len(set) < minTrendAccounts {
+			// Not enough interactions
+			// to bother checking.
+			continue
+		}
+
+		status, err := p.state.DB.GetStatusByID(
+			gtscontext.SetBarebones(ctx),
+			statusID,
+		)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", statusID, err)
+			}
+			continue
+		}
+
+		if status.Visibility != gtsmodel.VisibilityPublic ||
+			status.BoostOfID != "" ||
+			util.PtrOrZero(status.Sensitive) ||
+			status.CreatedAt.Before(since) {
+			// Not eligible.
+			continue
+		}
+
+		account, err := p.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.AccountID,
+		)
+		if err != nil {
+			log.Errorf(ctx, "db error getting account %s: %v", status.AccountID, err)
+			continue
+		}
+
+		if !util.PtrOrZero(account.Discoverable) ||
+			account.IsSuspended() {
+			// Author doesn't want to
+			// be discovered, or can't be.
+			continue
+		}
+
+		// Authors interacting with
+		// their own status don't count.
+		delete(set, status.AccountID)
+		if len(set) < minTrendAccounts {
+			continue
+		}
+
+		age := now.Sub(status.CreatedAt)
+		decay := math.Pow(0.5, float64(age)/float64(statusHalfLife))
+
+		candidates = append(candidates, &candidate{
+			target: statusID,
+			score:  float64(len(set)) * decay,
+		})
+	}
+
+	return topCandidates(candidates), nil
+}
+
+// scoreUses groups the given uses by target into daily
+// usage history, and scores each target by how much more
+// it was used (by distinct accounts) in the last day than
+// in the days before, returning only targets trending now.
+func scoreUses(uses []*db.TrendUse, now time.Time) []*candidate {
+	type usage struct {
+		uses     []int
+		accounts []map[string]struct{}
+
+		// Distinct accounts using the target in the last
+		// (rolling) day, and in the days before that.
+		recent map[string]struct{}
+		before map[string]struct{}
+	}
+
+	today := now.UTC().Truncate(day)
+	usages := make(map[string]*usage)
+
+	for _, use := range uses {
+		// Work out which history
+		// day this use falls into.
+		i := int(today.Sub(use.CreatedAt.UTC().Truncate(day)) / day)
+		if i < 0 {
+			i = 0
+		} else if i >= historyDays {
+			continue
+		}
+
+		u, ok := usages[use.Target]
+		if !ok {
+			u = &usage{
+				uses:     make([]int, historyDays),
+				accounts: make([]map[string]struct{}, historyDays),
+				recent:   make(map[string]struct{}),
+				before:   make(map[string]struct{}),
+			}
+			usages[use.Target] = u
+		}
+
+		u.uses[i]++
+		if u.accounts[i] == nil {
+			u.accounts[i] = make(map[string]struct{})
+		}
+		u.accounts[i][use.AccountID] = struct{}{}
+
+		if now.Sub(use.CreatedAt) < day {
+			u.recent[use.AccountID] = struct{}{}
+		} else {
+			u.before[use.AccountID] = struct{}{}
+		}
+	}
+
+	var candidates []*candidate
+	for target, u := range usages {
+		recent := float64(len(u.recent))
+		if recent < minTrendAccounts {
+			continue
+		}
+
+		// Compare recent use against the
+		// average daily use in the days
+		// before, so that things that are
+		// always popular don't trend.
+		expected := float64(len(u.before)) / (historyDays - 1)
+		if recent <= expected {
+			continue
+		}
+
+		accounts := make([]int, historyDays)
+		for i, set := range u.accounts {
+			accounts[i] = len(set)
+		}
+
+		candidates = append(candidates, &candidate{
+			target:   target,
+			score:    (recent - expected) * (recent - expected) / (expected + 1),
+			uses:     u.uses,
+			accounts: accounts,
+		})
+	}
+
+	return topCandidates(candidates)
+}
+
+// topCandidates sorts the given candidates by descending
+// score, and returns at most maxTrends of the top ones.
+func topCandidates(candidates []*candidate) []*candidate {
+	slices.SortFunc(candidates, func(a, b *candidate) int {
+		switch {
+		case a.score > b.score:
+			return -1
+		case a.score < b.score:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	if len(candidates) > maxTrends {
+		candidates = candidates[:maxTrends]
+	}
+
+	return candidates
+}
+
+// putTrends stores the given trend candidates of the given type
+// as computed at the given time, creating new trends pending
+// review as necessary, and zeroes the score of all other
+// trends of the given type, as they're no longer trending.
+func (p *Processor) putTrends(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	candidates []*candidate,
+	computedAt time.Time,
+) error {
+	for _, c := range candidates {
+		trend, err := p.state.DB.GetTrend(ctx, trendType, c.target)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return gtserror.Newf("db error getting trend: %w", err)
+		}
+
+		if trend == nil {
+			// Newly trending, so store
+			// it pending admin review.
+			trend = &gtsmodel.Trend{
+				ID:         id.NewULID(),
+				Type:       trendType,
+				Target:     c.target,
+				Score:      c.score,
+				Uses:       c.uses,
+				Accounts:   c.accounts,
+				ComputedAt: computedAt,
+				State:      gtsmodel.TrendStatePending,
+			}
+
+			if err := p.state.DB.PutTrend(ctx, trend); err != nil {
+				return gtserror.Newf("db error putting trend: %w", err)
+			}
+
+			continue
+		}
+
+		trend.Score = c.score
+		trend.Uses = c.uses
+		trend.Accounts = c.accounts
+		trend.ComputedAt = computedAt
+
+		if err := p.state.DB.UpdateTrend(ctx,
+			trend,
+			"score",
+			"uses",
+			"accounts",
+			"computed_at",
+		); err != nil {
+			return gtserror.Newf("db error updating trend: %w", err)
+		}
+	}
+
+	if err := p.state.DB.ClearStaleTrends(ctx, trendType, computedAt); err != nil {
+		return gtserror.Newf("db error clearing stale trends: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends_test
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+const (
+	trendingLink    = "https://example.org/articles/sloths"
+	trendingContent = `<p>have you seen this? <a href="` + trendingLink + `#comments" rel="nofollow noreferrer noopener" target="_blank">` + trendingLink + `</a> <a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag nofollow noreferrer noopener" target="_blank">#<span>welcome</span></a></p>`
+)
+
+type ComputeTestSuite struct {
+	TrendsStandardTestSuite
+}
+
+// putStatus stores a new public status by the given account,
+// with the given content and tags, along with its links.
+func (suite *ComputeTestSuite) putStatus(account *gtsmodel.Account, content string, tags ...*gtsmodel.Tag) *gtsmodel.Status {
+	statusID := id.NewULID()
+	status := &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		URL:                 account.URL + "/statuses/" + statusID,
+		Content:             content,
+		Local:               util.Ptr(true),
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		Visibility:          gtsmodel.VisibilityPublic,
+		Sensitive:           util.Ptr(false),
+		ActivityStreamsType: "Note",
+		Federated:           util.Ptr(true),
+	}
+
+	for _, tag := range tags {
+		status.TagIDs = append(status.TagIDs, tag.ID)
+	}
+
+	if err := suite.db.PutStatus(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.trends.StoreStatusLinks(context.Background(), status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	return status
+}
+
+// putFave stores a fave of the given
+// status by the given account.
+func (suite *ComputeTestSuite) putFave(account *gtsmodel.Account, status *gtsmodel.Status) {
+	faveID := id.NewULID()
+	if err := suite.db.PutStatusFave(context.Background(), &gtsmodel.StatusFave{
+		ID:              faveID,
+		AccountID:       account.ID,
+		TargetAccountID: status.AccountID,
+		StatusID:        status.ID,
+		URI:             account.URI + "/fave/" + faveID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+}
+
+func (suite *ComputeTestSuite) TestComputeTrends() {
+	var (
+		ctx     = context.Background()
+		zork    = suite.testAccounts["local_account_1"]
+		turtle  = suite.testAccounts["local_account_2"]
+		admin   = suite.testAccounts["admin_account"]
+		welcome = suite.testTags["welcome"]
+	)
+
+	// Two accounts use the same tag + link.
+	status := suite.putStatus(zork, trendingContent, welcome)
+	suite.putStatus(turtle, trendingContent, welcome)
+
+	// Two other accounts fave zork's status.
+	suite.putFave(turtle, status)
+	suite.putFave(admin, status)
+
+	if err := suite.trends.Compute(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The tag should now be trending,
+	// but pending review, so not shown.
+	tagTrend, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeTag, welcome.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(gtsmodel.TrendStatePending, tagTrend.State)
+	suite.Positive(tagTrend.Score)
+	suite.Equal(2, tagTrend.Uses[0])
+	suite.Equal(2, tagTrend.Accounts[0])
+
+	tags, errWithCode := suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	// The link should be trending too, with the
+	// fragment removed, while the hashtag isn't.
+	linkTrend, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeLink, trendingLink)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Positive(linkTrend.Score)
+
+	_, err = suite.db.GetTrend(ctx, gtsmodel.TrendTypeLink, "http://localhost:8080/tags/welcome")
+	suite.Error(err)
+
+	// As should zork's status.
+	statusTrend, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeStatus, status.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Positive(statusTrend.Score)
+
+	// Approve the trends, they should now be shown.
+	for _, trend := range []*gtsmodel.Trend{tagTrend, linkTrend, statusTrend} {
+		trend.State = gtsmodel.TrendStateApproved
+		if err := suite.db.UpdateTrend(ctx, trend, "state"); err != nil {
+			suite.FailNow(err.Error())
+		}
+	}
+
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Len(tags, 1)
+	suite.Equal("welcome", tags[0].Name)
+	suite.Len(*tags[0].History, 7)
+	suite.Equal("2", (*tags[0].History)[0].Uses)
+	suite.Equal(
+		strconv.FormatInt(time.Now().UTC().Truncate(24*time.Hour).Unix(), 10),
+		(*tags[0].History)[0].Day,
+	)
+
+	links, errWithCode := suite.trends.LinksGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Len(links, 1)
+	suite.Equal(trendingLink, links[0].URL)
+	suite.Equal("example.org", links[0].ProviderName)
+
+	statuses, errWithCode := suite.trends.StatusesGet(ctx, nil, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Len(statuses, 1)
+	suite.Equal(status.ID, statuses[0].ID)
+
+	// Compute again over a week later. Nothing is trending
+	// anymore, so nothing should be shown, but approval
+	// should be kept in case things trend again.
+	if err := suite.trends.Compute(ctx, time.Now().Add(8*24*time.Hour)); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	tags, errWithCode = suite.trends.TagsGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(tags)
+
+	links, errWithCode = suite.trends.LinksGet(ctx, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(links)
+
+	statuses, errWithCode = suite.trends.StatusesGet(ctx, nil, 10, 0)
+	suite.NoError(errWithCode)
+	suite.Empty(statuses)
+
+	tagTrend, err = suite.db.GetTrendByID(ctx, tagTrend.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(tagTrend.Score)
+	suite.True(tagTrend.IsApproved())
+}
+
+func (suite *ComputeTestSuite) TestComputeSingleAccountNotTrending() {
+	var (
+		ctx     = context.Background()
+		zork    = suite.testAccounts["local_account_1"]
+		welcome = suite.testTags["welcome"]
+	)
+
+	// One account spamming a tag
+	// shouldn't make it trend.
+	for i := 0; i < 5; i++ {
+		suite.putStatus(zork, trendingContent, welcome)
+	}
+
+	if err := suite.trends.Compute(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeTag, welcome.ID)
+	suite.Error(err)
+
+	_, err = suite.db.GetTrend(ctx, gtsmodel.TrendTypeLink, trendingLink)
+	suite.Error(err)
+}
+
+func (suite *ComputeTestSuite) TestComputeLinkEdited() {
+	var (
+		ctx    = context.Background()
+		zork   = suite.testAccounts["local_account_1"]
+		turtle = suite.testAccounts["local_account_2"]
+	)
+
+	suite.putStatus(zork, trendingContent)
+	status := suite.putStatus(turtle, trendingContent)
+
+	// Edit the link out of one of the
+	// statuses, it shouldn't trend now.
+	status.Content = "<p>never mind</p>"
+	if err := suite.db.UpdateStatus(ctx, status, "content"); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.trends.StoreStatusLinks(ctx, status); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	if err := suite.trends.Compute(ctx, time.Now()); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err := suite.db.GetTrend(ctx, gtsmodel.TrendTypeLink, trendingLink)
+	suite.Error(err)
+}
+
+func TestComputeTestSuite(t *testing.T) {
+	suite.Run(t, new(ComputeTestSuite))
+}
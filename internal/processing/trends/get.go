@@ -0,0 +1,182 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/filter/usermute"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// TagsGet returns approved trending tags,
+// in descending order of trend score.
+func (p *Processor) TagsGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.Tag, gtserror.WithCode) {
+	trends, errWithCode := p.getApproved(ctx, gtsmodel.TrendTypeTag, limit, offset)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiTags := make([]*apimodel.Tag, 0, len(trends))
+	for _, trend := range trends {
+		tag, err := p.state.DB.GetTag(ctx, trend.Target)
+		if err != nil {
+			log.Errorf(ctx, "db error getting tag %s: %v", trend.Target, err)
+			continue
+		}
+
+		if !util.PtrOrValue(tag.Useable, true) ||
+			!util.PtrOrValue(tag.Listable, true) {
+			// Tag was disabled
+			// since it trended.
+			continue
+		}
+
+		apiTag, err := p.converter.TrendToAPITag(ctx, trend, tag)
+		if err != nil {
+			log.Errorf(ctx, "error converting tag %s: %v", tag.ID, err)
+			continue
+		}
+
+		apiTags = append(apiTags, apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// StatusesGet returns approved trending statuses visible
+// to the given requester (which may be nil if the request
+// is unauthenticated), in descending order of trend score.
+func (p *Processor) StatusesGet(
+	ctx context.Context,
+	requester *gtsmodel.Account,
+	limit int,
+	offset int,
+) ([]*apimodel.Status, gtserror.WithCode) {
+	trends, errWithCode := p.getApproved(ctx, gtsmodel.TrendTypeStatus, limit, offset)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	var filters []*gtsmodel.Filter
+	var compiledMutes *usermute.CompiledUserMuteList
+	if requester != nil {
+		var err error
+		filters, err = p.state.DB.GetFiltersForAccountID(ctx, requester.ID)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve filters for account %s: %w", requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		mutes, err := p.state.DB.GetAccountMutes(gtscontext.SetBarebones(ctx), requester.ID, nil)
+		if err != nil {
+			err = gtserror.Newf("couldn't retrieve mutes for account %s: %w", requester.ID, err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		compiledMutes = usermute.NewCompiledUserMuteList(mutes)
+	}
+
+	apiStatuses := make([]*apimodel.Status, 0, len(trends))
+	for _, trend := range trends {
+		status, err := p.state.DB.GetStatusByID(ctx, trend.Target)
+		if err != nil {
+			if !errors.Is(err, db.ErrNoEntries) {
+				log.Errorf(ctx, "db error getting status %s: %v", trend.Target, err)
+			}
+			continue
+		}
+
+		visible, err := p.visFilter.StatusVisible(ctx, requester, status)
+		if err != nil {
+			log.Errorf(ctx, "error checking status visibility: %v", err)
+			continue
+		}
+
+		if !visible {
+			continue
+		}
+
+		apiStatus, err := p.converter.StatusToAPIStatus(ctx, status, requester, statusfilter.FilterContextPublic, filters, compiledMutes)
+		if errors.Is(err, statusfilter.ErrHideStatus) {
+			continue
+		}
+		if err != nil {
+			log.Errorf(ctx, "error converting to api status: %v", err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
+// LinksGet returns approved trending links,
+// in descending order of trend score.
+func (p *Processor) LinksGet(
+	ctx context.Context,
+	limit int,
+	offset int,
+) ([]*apimodel.TrendsLink, gtserror.WithCode) {
+	trends, errWithCode := p.getApproved(ctx, gtsmodel.TrendTypeLink, limit, offset)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiLinks := make([]*apimodel.TrendsLink, 0, len(trends))
+	for _, trend := range trends {
+		apiLinks = append(apiLinks, p.converter.TrendToAPITrendsLink(trend))
+	}
+
+	return apiLinks, nil
+}
+
+// getApproved returns currently trending
+// trends of the given type that have been
+// approved by an admin, highest score first.
+func (p *Processor) getApproved(
+	ctx context.Context,
+	trendType gtsmodel.TrendType,
+	limit int,
+	offset int,
+) ([]*gtsmodel.Trend, gtserror.WithCode) {
+	trends, err := p.state.DB.GetTrends(ctx,
+		trendType,
+		gtsmodel.TrendStateApproved,
+		limit,
+		offset,
+	)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting %s trends: %w", trendType, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return trends, nil
+}
@@ -0,0 +1,136 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trends
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// StoreStatusLinks extracts trendable links from the content
+// of the given status, and stores them for computing link
+// trends later on. Any links previously stored for the
+// status (ie., before it was edited) are replaced.
+func (p *Processor) StoreStatusLinks(ctx context.Context, status *gtsmodel.Status) error {
+	if err := p.state.DB.DeleteTrendLinks(ctx, status.ID); err != nil {
+		return gtserror.Newf("db error deleting links: %w", err)
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic ||
+		status.BoostOfID != "" {
+		// Only links in original,
+		// public statuses can trend.
+		return nil
+	}
+
+	var links []*gtsmodel.TrendLink
+	for _, link := range extractLinks(status.Content) {
+		links = append(links, &gtsmodel.TrendLink{
+			StatusID:  status.ID,
+			URL:       link,
+			AccountID: status.AccountID,
+			CreatedAt: status.CreatedAt,
+		})
+	}
+
+	if err := p.state.DB.PutTrendLinks(ctx, links); err != nil {
+		return gtserror.Newf("db error putting links: %w", err)
+	}
+
+	return nil
+}
+
+// extractLinks returns the deduplicated http(s) links
+// in the given status HTML content, excluding mention
+// and hashtag links, and links to this instance.
+func extractLinks(content string) []string {
+	var (
+		links     []string
+		tokenizer = html.NewTokenizer(strings.NewReader(content))
+	)
+
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			// Reached end of
+			// content (or error).
+			return links
+
+		case html.StartTagToken:
+			token := tokenizer.Token()
+			if token.DataAtom != atom.A {
+				continue
+			}
+
+			link, ok := trendableLink(token.Attr)
+			if ok && !slices.Contains(links, link) {
+				links = append(links, link)
+			}
+		}
+	}
+}
+
+// trendableLink returns the normalized href of
+// an anchor with the given attributes, and whether
+// the link is eligible to trend at all.
+func trendableLink(attrs []html.Attribute) (string, bool) {
+	var href string
+	for _, attr := range attrs {
+		switch attr.Key {
+		case "href":
+			href = attr.Val
+
+		case "class":
+			for _, class := range strings.Fields(attr.Val) {
+				if class == "mention" || class == "hashtag" {
+					// Link to an account or tag, which
+					// is never interesting as a link.
+					return "", false
+				}
+			}
+		}
+	}
+
+	u, err := url.Parse(href)
+	if err != nil ||
+		(u.Scheme != "https" && u.Scheme != "http") ||
+		u.Host == "" {
+		return "", false
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == config.GetHost() || host == config.GetAccountDomain() {
+		// Don't trend our own links.
+		return "", false
+	}
+
+	// Fragments point to the same
+	// resource, so normalize them away.
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	return u.String(), true
+}
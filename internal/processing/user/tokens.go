@@ -0,0 +1,159 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"errors"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+)
+
+// TokensGet returns a page of the access tokens
+// held by applications on behalf of the given user.
+func (p *Processor) TokensGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	page *paging.Page,
+) (*apimodel.PageableResponse, gtserror.WithCode) {
+	tokens, err := p.state.DB.GetAccessTokens(ctx, user.ID, page)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting tokens: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	count := len(tokens)
+	if count == 0 {
+		return paging.EmptyResponse(), nil
+	}
+
+	var (
+		// Get the lowest and highest
+		// ID values, used for paging.
+		lo = tokens[count-1].ID
+		hi = tokens[0].ID
+
+		// Best-guess items length.
+		items = make([]interface{}, 0, count)
+	)
+
+	for _, token := range tokens {
+		tokenInfo, err := p.converter.TokenToAPITokenInfo(ctx, token)
+		if err != nil {
+			log.Errorf(ctx, "error converting token %s: %v", token.ID, err)
+			continue
+		}
+
+		// Append token info to return items.
+		items = append(items, tokenInfo)
+	}
+
+	return paging.PackageResponse(paging.ResponseParams{
+		Items: items,
+		Path:  "/api/v1/tokens",
+		Next:  page.Next(lo, hi),
+		Prev:  page.Prev(lo, hi),
+	}), nil
+}
+
+// TokenGet returns the given user's access token with the given ID.
+func (p *Processor) TokenGet(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	return p.apiTokenInfo(ctx, token)
+}
+
+// TokenInvalidate revokes the given user's access token with
+// the given ID, so that it can no longer be used to access the
+// API, and returns the details of the token as it was.
+func (p *Processor) TokenInvalidate(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	token, errWithCode := p.getOwnToken(ctx, user, tokenID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	tokenInfo, errWithCode := p.apiTokenInfo(ctx, token)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// A Web Push subscription is useless
+	// once its token is gone, so remove it.
+	if err := p.state.DB.DeleteWebPushSubscriptionByTokenID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting web push subscription for token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if err := p.state.DB.DeleteTokenByID(ctx, token.ID); err != nil {
+		err := gtserror.Newf("db error deleting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tokenInfo, nil
+}
+
+// getOwnToken fetches the access token with the given
+// ID, returning 404 if it doesn't belong to the user.
+func (p *Processor) getOwnToken(
+	ctx context.Context,
+	user *gtsmodel.User,
+	tokenID string,
+) (*gtsmodel.Token, gtserror.WithCode) {
+	token, err := p.state.DB.GetTokenByID(ctx, tokenID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		err := gtserror.Newf("db error getting token %s: %w", tokenID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if token == nil || token.UserID != user.ID || token.Access == "" {
+		// Don't leak existence of other users' tokens.
+		err := gtserror.Newf("token %s not found", tokenID)
+		return nil, gtserror.NewErrorNotFound(err)
+	}
+
+	return token, nil
+}
+
+func (p *Processor) apiTokenInfo(
+	ctx context.Context,
+	token *gtsmodel.Token,
+) (*apimodel.TokenInfo, gtserror.WithCode) {
+	tokenInfo, err := p.converter.TokenToAPITokenInfo(ctx, token)
+	if err != nil {
+		err := gtserror.Newf("error converting token %s: %w", token.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return tokenInfo, nil
+}
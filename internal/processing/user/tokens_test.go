@@ -0,0 +1,92 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/paging"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokensTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TokensTestSuite) TestTokensGet() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	resp, errWithCode := suite.user.TokensGet(ctx, user, &paging.Page{Limit: 20})
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// Only the access token should be listed,
+	// not the outstanding authorization code.
+	suite.Len(resp.Items, 1)
+	tokenInfo := resp.Items[0].(*apimodel.TokenInfo)
+	suite.Equal("01F8MGTQW4DKTDF8SW5CT9HYGA", tokenInfo.ID)
+	suite.Equal("read write follow push", tokenInfo.Scope)
+	suite.Equal("2022-06-10T15:22:08.000Z", tokenInfo.CreatedAt)
+	suite.Empty(tokenInfo.LastUsed)
+	suite.Equal("really cool gts application", tokenInfo.Application.Name)
+}
+
+func (suite *TokensTestSuite) TestTokenGetNotOwn() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	otherToken := testrig.NewTestTokens()["local_account_2"]
+
+	_, errWithCode := suite.user.TokenGet(ctx, user, otherToken.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *TokensTestSuite) TestTokenInvalidate() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+	token := testrig.NewTestTokens()["local_account_1"]
+
+	tokenInfo, errWithCode := suite.user.TokenInvalidate(ctx, user, token.ID)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(token.ID, tokenInfo.ID)
+
+	// Token should be gone.
+	_, err := suite.db.GetTokenByID(ctx, token.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// And so should its Web Push subscription.
+	_, err = suite.db.GetWebPushSubscriptionByTokenID(ctx, token.ID)
+	suite.True(errors.Is(err, db.ErrNoEntries))
+
+	// Invalidating again should 404.
+	_, errWithCode = suite.user.TokenInvalidate(ctx, user, token.ID)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestTokensTestSuite(t *testing.T) {
+	suite.Run(t, new(TokensTestSuite))
+}
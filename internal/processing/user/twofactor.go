@@ -0,0 +1,402 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"math/big"
+	"strings"
+	"time"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// recoveryCodeCount is the number of recovery
+	// codes generated when enabling two-factor auth.
+	recoveryCodeCount = 8
+
+	// recoveryCodeLen is the length of one recovery code.
+	recoveryCodeLen = 10
+
+	// recoveryCodeAlphabet excludes characters that
+	// are easily confused with one another (0/o, 1/l/i).
+	recoveryCodeAlphabet = "abcdefghjkmnpqrstuvwxyz23456789"
+
+	// twoFactorMaxFailures is the number of incorrect codes
+	// that may be entered before two-factor sign-in is locked
+	// for twoFactorLockout, to make brute forcing impractical.
+	twoFactorMaxFailures = 5
+
+	// twoFactorLockout is how long two-factor sign-in is
+	// locked for after twoFactorMaxFailures incorrect codes.
+	twoFactorLockout = 15 * time.Minute
+)
+
+// TwoFactorSetup returns the TOTP secret and provisioning URI
+// for the given user, generating and storing a new secret if
+// necessary, provided the given password is correct. Two-factor
+// auth is not enabled until the user confirms they've stored
+// the secret, via TwoFactorEnable.
+func (p *Processor) TwoFactorSetup(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) (*apimodel.TwoFactorSetup, gtserror.WithCode) {
+	if errWithCode := checkPassword(user, password); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	if user.TwoFactorSecret == "" {
+		// No enrolment in progress,
+		// so generate a fresh secret.
+		secret, err := totp.GenerateSecret()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		user.TwoFactorSecret = secret
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_secret",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	}
+
+	account, err := p.state.DB.GetAccountByID(
+		gtscontext.SetBarebones(ctx),
+		user.AccountID,
+	)
+	if err != nil {
+		err := gtserror.Newf("db error getting account: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	host := config.GetHost()
+	return &apimodel.TwoFactorSetup{
+		Secret: user.TwoFactorSecret,
+		URI:    totp.URI(user.TwoFactorSecret, host, account.Username+"@"+host),
+	}, nil
+}
+
+// TwoFactorEnable enables two-factor auth for the given user,
+// provided the given password is correct and the given code
+// matches the secret from TwoFactorSetup. It returns single-use
+// recovery codes, which are only shown once.
+func (p *Processor) TwoFactorEnable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+	code string,
+) (*apimodel.TwoFactorRecoveryCodes, gtserror.WithCode) {
+	if errWithCode := checkPassword(user, password); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if user.TwoFactorEnabled() {
+		const help = "two-factor authentication is already enabled"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorConflict(err, help)
+	}
+
+	if user.TwoFactorSecret == "" {
+		const help = "two-factor authentication setup has not been started"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	step, err := totp.Match(user.TwoFactorSecret, code, time.Now(), 0)
+	if err != nil {
+		err := gtserror.Newf("error validating code: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if step == 0 {
+		const help = "two-factor authentication code was incorrect"
+		err := gtserror.New(help)
+		return nil, gtserror.NewErrorUnauthorized(err, help)
+	}
+
+	// Generate recovery codes, storing only their hashes.
+	codes := make([]string, recoveryCodeCount)
+	hashes := make([]string, recoveryCodeCount)
+	for i := range codes {
+		codes[i], err = newRecoveryCode()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		hash, err := bcrypt.GenerateFromPassword(
+			[]byte(codes[i]),
+			bcrypt.DefaultCost,
+		)
+		if err != nil {
+			err := gtserror.Newf("error hashing recovery code: %w", err)
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		hashes[i] = string(hash)
+	}
+
+	// Store the step of the confirming
+	// code so it can't be used to sign in.
+	user.TwoFactorBackups = hashes
+	user.TwoFactorEnabledAt = time.Now()
+	user.TwoFactorLastStep = step
+	user.TwoFactorFailures = 0
+	user.TwoFactorLockedUntil = time.Time{}
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_backups",
+		"two_factor_enabled_at",
+		"two_factor_last_step",
+		"two_factor_failures",
+		"two_factor_locked_until",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	return &apimodel.TwoFactorRecoveryCodes{
+		RecoveryCodes: codes,
+	}, nil
+}
+
+// TwoFactorDisable disables two-factor auth for the given user,
+// and clears their secret and recovery codes, provided the
+// given password is correct.
+func (p *Processor) TwoFactorDisable(
+	ctx context.Context,
+	user *gtsmodel.User,
+	password string,
+) gtserror.WithCode {
+	if errWithCode := checkPassword(user, password); errWithCode != nil {
+		return errWithCode
+	}
+
+	if !user.TwoFactorEnabled() && user.TwoFactorSecret == "" {
+		const help = "two-factor authentication is not enabled"
+		err := gtserror.New(help)
+		return gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	user.TwoFactorSecret = ""
+	user.TwoFactorBackups = nil
+	user.TwoFactorEnabledAt = time.Time{}
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_secret",
+		"two_factor_backups",
+		"two_factor_enabled_at",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// TwoFactorCheck checks the given code against the given
+// user's TOTP secret or, if it looks like one, their recovery
+// codes. A TOTP code can only be used once, and a matching
+// recovery code is used up. After too many incorrect codes,
+// checks are refused until a lockout period has passed.
+func (p *Processor) TwoFactorCheck(
+	ctx context.Context,
+	user *gtsmodel.User,
+	code string,
+) gtserror.WithCode {
+	if !user.TwoFactorEnabled() {
+		const help = "two-factor authentication is not enabled"
+		err := gtserror.New(help)
+		return gtserror.NewErrorUnprocessableEntity(err, help)
+	}
+
+	now := time.Now()
+	if now.Before(user.TwoFactorLockedUntil) {
+		const help = "too many incorrect two-factor authentication codes, please try again later"
+		err := gtserror.Newf("two-factor sign-in locked for user %s", user.ID)
+		return gtserror.NewErrorTooManyRequests(err, help)
+	}
+
+	step, err := totp.Match(user.TwoFactorSecret, code, now, user.TwoFactorLastStep)
+	if err != nil {
+		err := gtserror.Newf("error validating code: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	if step != 0 {
+		// Mark this code's step as used, which
+		// fails if it was used concurrently.
+		ok, err := p.state.DB.SetUserTwoFactorLastStep(ctx, user.ID, step)
+		if err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		if ok {
+			user.TwoFactorLastStep = step
+			return p.twoFactorSucceeded(ctx, user)
+		}
+	}
+
+	// Not a (fresh) TOTP code, check if
+	// it's a recovery code. Normalize as
+	// the user may have written it down in
+	// caps, or split with spaces or hyphens.
+	code = strings.ToLower(code)
+	code = strings.NewReplacer(" ", "", "-", "").Replace(code)
+	if isRecoveryCode(code) {
+		for i, hash := range user.TwoFactorBackups {
+			if bcrypt.CompareHashAndPassword([]byte(hash), []byte(code)) != nil {
+				continue
+			}
+
+			// Match! Remove this code from the user's
+			// recovery codes. Build a new slice as the
+			// cached user model shares the old one.
+			backups := make([]string, 0, len(user.TwoFactorBackups)-1)
+			backups = append(backups, user.TwoFactorBackups[:i]...)
+			backups = append(backups, user.TwoFactorBackups[i+1:]...)
+
+			user.TwoFactorBackups = backups
+			if err := p.state.DB.UpdateUser(
+				ctx, user,
+				"two_factor_backups",
+			); err != nil {
+				err := gtserror.Newf("db error updating user: %w", err)
+				return gtserror.NewErrorInternalError(err)
+			}
+
+			return p.twoFactorSucceeded(ctx, user)
+		}
+	}
+
+	return p.twoFactorFailed(ctx, user, now)
+}
+
+// twoFactorSucceeded resets the given
+// user's count of incorrect codes.
+func (p *Processor) twoFactorSucceeded(
+	ctx context.Context,
+	user *gtsmodel.User,
+) gtserror.WithCode {
+	if user.TwoFactorFailures == 0 {
+		// Nothing to reset.
+		return nil
+	}
+
+	user.TwoFactorFailures = 0
+	if err := p.state.DB.UpdateUser(
+		ctx, user,
+		"two_factor_failures",
+	); err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+
+	return nil
+}
+
+// twoFactorFailed counts an incorrect code for the given
+// user, locking two-factor sign-in if there were too many.
+func (p *Processor) twoFactorFailed(
+	ctx context.Context,
+	user *gtsmodel.User,
+	now time.Time,
+) gtserror.WithCode {
+	failures, err := p.state.DB.IncrementUserTwoFactorFailures(ctx, user.ID)
+	if err != nil {
+		err := gtserror.Newf("db error updating user: %w", err)
+		return gtserror.NewErrorInternalError(err)
+	}
+	user.TwoFactorFailures = failures
+
+	if failures >= twoFactorMaxFailures {
+		// Too many, lock sign-in for
+		// a while and start counting
+		// again once that's over.
+		user.TwoFactorFailures = 0
+		user.TwoFactorLockedUntil = now.Add(twoFactorLockout)
+		if err := p.state.DB.UpdateUser(
+			ctx, user,
+			"two_factor_failures",
+			"two_factor_locked_until",
+		); err != nil {
+			err := gtserror.Newf("db error updating user: %w", err)
+			return gtserror.NewErrorInternalError(err)
+		}
+
+		const help = "too many incorrect two-factor authentication codes, please try again later"
+		err := gtserror.Newf("two-factor sign-in locked for user %s", user.ID)
+		return gtserror.NewErrorTooManyRequests(err, help)
+	}
+
+	const help = "two-factor authentication code was incorrect"
+	err = gtserror.New(help)
+	return gtserror.NewErrorUnauthorized(err, help)
+}
+
+// checkPassword checks the given
+// password against the user's.
+func checkPassword(user *gtsmodel.User, password string) gtserror.WithCode {
+	if err := bcrypt.CompareHashAndPassword(
+		[]byte(user.EncryptedPassword),
+		[]byte(password),
+	); err != nil {
+		err := gtserror.Newf("%w", err)
+		return gtserror.NewErrorUnauthorized(err, "password was incorrect")
+	}
+	return nil
+}
+
+// isRecoveryCode returns whether the given normalized
+// code looks like a recovery code, so that they're only
+// checked (which is expensive) when it's worth doing so.
+func isRecoveryCode(code string) bool {
+	return len(code) == recoveryCodeLen &&
+		strings.Trim(code, recoveryCodeAlphabet) == ""
+}
+
+// newRecoveryCode returns a new random recovery code.
+func newRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeLen)
+	max := big.NewInt(int64(len(recoveryCodeAlphabet)))
+	for i := range b {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", gtserror.Newf("error generating recovery code: %w", err)
+		}
+		b[i] = recoveryCodeAlphabet[n.Int64()]
+	}
+	return string(b), nil
+}
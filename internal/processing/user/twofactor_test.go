@@ -0,0 +1,201 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type TwoFactorTestSuite struct {
+	UserStandardTestSuite
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorLifecycle() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	// Starting enrolment requires the right password.
+	_, errWithCode := suite.user.TwoFactorSetup(ctx, user, "wrong password")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Start enrolment.
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user, "password")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.NotEmpty(setup.Secret)
+	suite.True(strings.HasPrefix(setup.URI, "otpauth://totp/localhost:8080:the_mighty_zork@localhost:8080?"))
+	suite.False(user.TwoFactorEnabled())
+
+	// Setting up again should give the same secret.
+	setup2, errWithCode := suite.user.TwoFactorSetup(ctx, user, "password")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Equal(setup.Secret, setup2.Secret)
+
+	// Enabling with the wrong code should fail.
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "password", "000000")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+
+	// Enable with the right code, but the wrong password.
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	_, errWithCode = suite.user.TwoFactorEnable(ctx, user, "wrong password", code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	suite.False(user.TwoFactorEnabled())
+
+	// Enable with the right code and password.
+	recoveryCodes, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", code)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	suite.Len(recoveryCodes.RecoveryCodes, 8)
+
+	// Check it was stored.
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbUser.TwoFactorEnabled())
+	suite.Equal(setup.Secret, dbUser.TwoFactorSecret)
+	suite.Len(dbUser.TwoFactorBackups, 8)
+
+	// Setup is no longer possible.
+	_, errWithCode = suite.user.TwoFactorSetup(ctx, dbUser, "password")
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	// The code used to enable can't be used again.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// But a later TOTP code should pass the check, once.
+	code, err = totp.Code(setup.Secret, time.Now().Add(totp.Period))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Nil(suite.user.TwoFactorCheck(ctx, dbUser, code))
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, code)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// And so should a recovery code, but only once,
+	// leniently formatted as people might write it down.
+	recoveryCode := strings.ToUpper(recoveryCodes.RecoveryCodes[3])
+	recoveryCode = recoveryCode[:5] + "-" + recoveryCode[5:]
+	suite.Nil(suite.user.TwoFactorCheck(ctx, dbUser, recoveryCode))
+	suite.Len(dbUser.TwoFactorBackups, 7)
+
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, recoveryCode)
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Other codes are still good.
+	suite.Nil(suite.user.TwoFactorCheck(ctx, dbUser, recoveryCodes.RecoveryCodes[0]))
+
+	// Nonsense doesn't work.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, "nonsense")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	// Disabling requires the right password.
+	errWithCode = suite.user.TwoFactorDisable(ctx, dbUser, "wrong password")
+	suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+
+	if errWithCode := suite.user.TwoFactorDisable(ctx, dbUser, "password"); errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.False(dbUser.TwoFactorEnabled())
+	suite.Empty(dbUser.TwoFactorSecret)
+	suite.Empty(dbUser.TwoFactorBackups)
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorEnableNotSetUp() {
+	user := suite.testUsers["local_account_2"]
+
+	_, errWithCode := suite.user.TwoFactorEnable(context.Background(), user, "password", "123456")
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("Unprocessable Entity: two-factor authentication setup has not been started", errWithCode.Safe())
+}
+
+func (suite *TwoFactorTestSuite) TestTwoFactorLockout() {
+	ctx := context.Background()
+	user := suite.testUsers["local_account_1"]
+
+	setup, errWithCode := suite.user.TwoFactorSetup(ctx, user, "password")
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+	code, err := totp.Code(setup.Secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	recoveryCodes, errWithCode := suite.user.TwoFactorEnable(ctx, user, "password", code)
+	if errWithCode != nil {
+		suite.FailNow(errWithCode.Error())
+	}
+
+	// A few wrong codes are allowed.
+	for i := 0; i < 4; i++ {
+		errWithCode := suite.user.TwoFactorCheck(ctx, user, "000000")
+		suite.Equal(http.StatusUnauthorized, errWithCode.Code())
+	}
+
+	dbUser, err := suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(4, dbUser.TwoFactorFailures)
+
+	// But then sign-in is locked.
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, "000000")
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+
+	// Even for correct codes.
+	dbUser, err = suite.db.GetUserByID(ctx, user.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(dbUser.TwoFactorLockedUntil.After(time.Now()))
+	errWithCode = suite.user.TwoFactorCheck(ctx, dbUser, recoveryCodes.RecoveryCodes[0])
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+	suite.Len(dbUser.TwoFactorBackups, 8)
+
+	// Once the lock is over, correct codes work again.
+	dbUser.TwoFactorLockedUntil = time.Now().Add(-time.Second)
+	if err := suite.db.UpdateUser(ctx, dbUser, "two_factor_locked_until"); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Nil(suite.user.TwoFactorCheck(ctx, dbUser, recoveryCodes.RecoveryCodes[0]))
+}
+
+func TestTwoFactorTestSuite(t *testing.T) {
+	suite.Run(t, new(TwoFactorTestSuite))
+}
@@ -19,15 +19,22 @@ package workers
 
 import (
 	"context"
+	"errors"
 	"net/url"
+	"slices"
+	"sync"
+	"time"
 
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
@@ -192,6 +199,155 @@ func (f *federate) CreateStatus(ctx context.Context, status *gtsmodel.Status) er
 	if _, err := f.FederatingActor().Send(ctx, outboxIRI, create); err != nil {
 		return gtserror.Newf("error sending Create activity via outbox %s: %w", outboxIRI, err)
 	}
+
+	// Public statuses are also
+	// broadcast via any relays.
+	if status.Visibility == gtsmodel.VisibilityPublic {
+		if err := f.deliverToRelays(ctx, status.Account, create); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliverToRelays delivers the given Activity to
+// the inbox of each relay that has accepted our
+// subscription, on behalf of sendingAcct.
+func (f *federate) deliverToRelays(
+	ctx context.Context,
+	sendingAcct *gtsmodel.Account,
+	t vocab.Type,
+) error {
+	relays, err := f.state.DB.GetRelays(ctx)
+	if err != nil {
+		return gtserror.Newf("db error getting relays: %w", err)
+	}
+
+	inboxes := make([]*url.URL, 0, len(relays))
+	for _, relay := range relays {
+		if !relay.IsAccepted() {
+			continue
+		}
+
+		inbox, err := parseURI(relay.InboxURI)
+		if err != nil {
+			return err
+		}
+
+		inboxes = append(inboxes, inbox)
+	}
+
+	if len(inboxes) == 0 {
+		// Nothing
+		// to do.
+		return nil
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		sendingAcct.Username,
+	)
+	if err != nil {
+		return gtserror.Newf(
+			"error getting transport to deliver activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return err
+	}
+
+	if err := tsport.BatchDeliver(ctx, m, inboxes); err != nil {
+		return gtserror.Newf(
+			"error delivering activity %T to relays: %w",
+			t, err,
+		)
+	}
+
+	return nil
+}
+
+// FollowRelay sends a Follow to the given relay on
+// behalf of the instance account, subscribing to it.
+func (f *federate) FollowRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	// Convert relay to ActivityStreams Follow.
+	follow, err := f.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		return gtserror.Newf("error converting relay to AS: %w", err)
+	}
+
+	return f.deliverToRelayInbox(ctx, relay, follow)
+}
+
+// UndoFollowRelay sends an Undo of our Follow to the given
+// relay on behalf of the instance account, unsubscribing.
+func (f *federate) UndoFollowRelay(ctx context.Context, relay *gtsmodel.Relay) error {
+	// Recreate the ActivityStreams Follow.
+	follow, err := f.converter.RelayToASFollow(ctx, relay)
+	if err != nil {
+		return gtserror.Newf("error converting relay to AS: %w", err)
+	}
+
+	// Create a new Undo.
+	undo := streams.NewActivityStreamsUndo()
+
+	// Set the Actor for the Undo:
+	// same as the actor for the Follow.
+	undo.SetActivityStreamsActor(follow.GetActivityStreamsActor())
+
+	// Set recreated Follow as the 'object' property.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsFollow(follow)
+	undo.SetActivityStreamsObject(undoObject)
+
+	return f.deliverToRelayInbox(ctx, relay, undo)
+}
+
+// deliverToRelayInbox delivers the given Activity *only*
+// to the inbox of the given relay, on behalf of the
+// instance account. Relays aren't followers of ours,
+// so can't be addressed via the usual outbox Send.
+func (f *federate) deliverToRelayInbox(
+	ctx context.Context,
+	relay *gtsmodel.Relay,
+	t vocab.Type,
+) error {
+	inbox, err := parseURI(relay.InboxURI)
+	if err != nil {
+		return err
+	}
+
+	instanceAcct, err := f.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		instanceAcct.Username,
+	)
+	if err != nil {
+		return gtserror.Newf(
+			"error getting transport to deliver activity %T to relay inbox %s: %w",
+			t, relay.InboxURI, err,
+		)
+	}
+
+	m, err := ap.Serialize(t)
+	if err != nil {
+		return err
+	}
+
+	if err := tsport.Deliver(ctx, m, inbox); err != nil {
+		return gtserror.Newf(
+			"error delivering activity %T to relay inbox %s: %w",
+			t, relay.InboxURI, err,
+		)
+	}
+
 	return nil
 }
 
@@ -879,7 +1035,7 @@ func (f *federate) UpdateAccount(ctx context.Context, account *gtsmodel.Account)
 	}
 
 	// Use ActivityStreams Person as Object of Update.
-	update, err := f.converter.WrapPersonInUpdate(person, account)
+	update, err := f.converter.WrapAccountableInUpdate(person, account)
 	if err != nil {
 		return gtserror.Newf("error wrapping Person in Update: %w", err)
 	}
@@ -998,6 +1154,12 @@ func (f *federate) UndoBlock(ctx context.Context, block *gtsmodel.Block) error {
 	return nil
 }
 
+// Flag delivers the given report as an ActivityStreams Flag
+// to the instance of the reported account, and to instances
+// of any reply-parents of reported statuses that are in the
+// report's ForwardDomains. Domains that the Flag was delivered
+// to successfully are then recorded on the report, as and when
+// the delivery queue gets the Flag accepted by each of them.
 func (f *federate) Flag(ctx context.Context, report *gtsmodel.Report) error {
 	// Populate model.
 	if err := f.state.DB.PopulateReport(ctx, report); err != nil {
@@ -1012,19 +1174,15 @@ func (f *federate) Flag(ctx context.Context, report *gtsmodel.Report) error {
 
 	// Get our instance account from the db:
 	// to anonymize the report, we'll deliver
-	// using the outbox of the instance account.
+	// using the transport of the instance account.
 	instanceAcct, err := f.state.DB.GetInstanceAccount(ctx, "")
 	if err != nil {
 		return gtserror.Newf("error getting instance account: %w", err)
 	}
 
-	// Parse relevant URI(s).
-	outboxIRI, err := parseURI(instanceAcct.OutboxURI)
-	if err != nil {
-		return err
-	}
-
-	targetAccountIRI, err := parseURI(report.TargetAccount.URI)
+	// Gather one recipient per
+	// domain to deliver Flag to.
+	recipients, err := f.flagRecipients(ctx, report)
 	if err != nil {
 		return err
 	}
@@ -1035,31 +1193,146 @@ func (f *federate) Flag(ctx context.Context, report *gtsmodel.Report) error {
 		return gtserror.Newf("error converting report to AS: %w", err)
 	}
 
-	// To is not set explicitly on Flags. Instead,
-	// address Flag BTo report target account URI.
-	// This ensures that our federating actor still
-	// knows where to send the report, but the BTo
-	// property will be stripped before sending.
-	//
-	// Happily, BTo does not prevent federating
-	// actor from using shared inbox to deliver.
-	bTo := streams.NewActivityStreamsBtoProperty()
-	bTo.AppendIRI(targetAccountIRI)
-	flag.SetActivityStreamsBto(bTo)
+	m, err := ap.Serialize(flag)
+	if err != nil {
+		return err
+	}
 
-	// Send the Flag via the Actor's outbox.
-	if _, err := f.FederatingActor().Send(
-		ctx, outboxIRI, flag,
-	); err != nil {
+	tsport, err := f.TransportController().NewTransportForUsername(
+		ctx,
+		instanceAcct.Username,
+	)
+	if err != nil {
 		return gtserror.Newf(
-			"error sending activity %T via outbox %s: %w",
-			flag, outboxIRI, err,
+			"error getting transport to deliver flag %s: %w",
+			report.URI, err,
 		)
 	}
 
+	// Queue the Flag for delivery to each recipient,
+	// recording the recipient's domain on the report
+	// once the delivery worker reports it accepted.
+	for _, recipient := range recipients {
+		// Prefer shared inbox
+		// where it's available.
+		inboxURI := recipient.InboxURI
+		if sharedInbox := recipient.SharedInboxURI; sharedInbox != nil && *sharedInbox != "" {
+			inboxURI = *sharedInbox
+		}
+
+		inbox, err := parseURI(inboxURI)
+		if err != nil {
+			return err
+		}
+
+		domain := recipient.Domain
+		dctx := gtscontext.SetDeliveredFunc(ctx, func(ctx context.Context) {
+			f.flagDelivered(ctx, report.ID, domain)
+		})
+
+		if err := tsport.Deliver(dctx, m, inbox); err != nil {
+			return gtserror.Newf(
+				"error delivering flag %s to %s: %w",
+				report.URI, inboxURI, err,
+			)
+		}
+	}
+
 	return nil
 }
 
+// flagDeliveredMu serializes updates to the
+// forwarded domains of reports, as deliveries
+// of one Flag to several domains may complete
+// concurrently on different delivery workers.
+var flagDeliveredMu sync.Mutex
+
+// flagDelivered records on the report with the given
+// ID that its Flag was successfully delivered to domain.
+func (f *federate) flagDelivered(ctx context.Context, reportID string, domain string) {
+	flagDeliveredMu.Lock()
+	defer flagDeliveredMu.Unlock()
+
+	report, err := f.state.DB.GetReportByID(
+		gtscontext.SetBarebones(ctx),
+		reportID,
+	)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "db error getting report %s: %v", reportID, err)
+		}
+		return
+	}
+
+	report.ForwardedDomains = util.Deduplicate(
+		append(report.ForwardedDomains, domain),
+	)
+	report.ForwardedAt = time.Now()
+
+	if _, err := f.state.DB.UpdateReport(ctx,
+		report,
+		"forwarded_domains",
+		"forwarded_at",
+	); err != nil {
+		log.Errorf(ctx, "db error updating report %s: %v", reportID, err)
+	}
+}
+
+// flagRecipients returns the accounts whose inboxes the given
+// (populated) report should be delivered to, starting with the
+// reported account itself, followed by the author of each reply-
+// parent of a reported status whose domain is in ForwardDomains.
+// Only one account is returned per domain.
+func (f *federate) flagRecipients(
+	ctx context.Context,
+	report *gtsmodel.Report,
+) ([]*gtsmodel.Account, error) {
+	recipients := []*gtsmodel.Account{report.TargetAccount}
+	if len(report.ForwardDomains) == 0 {
+		// Nothing else
+		// to do.
+		return recipients, nil
+	}
+
+	for _, status := range report.Statuses {
+		if status.InReplyToAccountID == "" {
+			// Not a reply.
+			continue
+		}
+
+		parent, err := f.state.DB.GetAccountByID(
+			gtscontext.SetBarebones(ctx),
+			status.InReplyToAccountID,
+		)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf(
+				"db error getting reply-parent account %s: %w",
+				status.InReplyToAccountID, err,
+			)
+		}
+
+		if parent == nil ||
+			parent.IsLocal() ||
+			!slices.Contains(report.ForwardDomains, parent.Domain) {
+			// Reply-parent gone, ours,
+			// or not to be forwarded to.
+			continue
+		}
+
+		if slices.ContainsFunc(recipients, func(a *gtsmodel.Account) bool {
+			return a.Domain == parent.Domain
+		}) {
+			// Already delivering
+			// to this domain.
+			continue
+		}
+
+		recipients = append(recipients, parent)
+	}
+
+	return recipients, nil
+}
+
 func (f *federate) MoveAccount(ctx context.Context, account *gtsmodel.Account) error {
 	// Do nothing if it's not our
 	// account that's been moved.
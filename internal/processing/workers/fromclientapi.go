@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
@@ -50,6 +51,7 @@ type clientAPI struct {
 	federate  *federate
 	account   *account.Processor
 	common    *common.Processor
+	trends    *trends.Processor
 	utils     *utils
 }
 
@@ -107,6 +109,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// CREATE BLOCK
 		case ap.ActivityBlock:
 			return p.clientAPI.CreateBlock(ctx, cMsg)
+
+		// CREATE RELAY (subscription)
+		case ap.ActorService:
+			return p.clientAPI.CreateRelay(ctx, cMsg)
 		}
 
 	// UPDATE SOMETHING
@@ -199,6 +205,10 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		// UNDO ANNOUNCE/BOOST
 		case ap.ActivityAnnounce:
 			return p.clientAPI.UndoAnnounce(ctx, cMsg)
+
+		// UNDO RELAY (subscription)
+		case ap.ActorService:
+			return p.clientAPI.UndoRelay(ctx, cMsg)
 		}
 
 	// DELETE SOMETHING
@@ -209,18 +219,22 @@ func (p *Processor) ProcessFromClientAPI(ctx context.Context, cMsg *messages.Fro
 		case ap.ObjectNote:
 			return p.clientAPI.DeleteStatus(ctx, cMsg)
 
-		// DELETE REMOTE ACCOUNT or LOCAL USER+ACCOUNT
-		case ap.ActorPerson, ap.ObjectProfile:
+		// DELETE REMOTE ACCOUNT or LOCAL USER+ACCOUNT or LOCAL GROUP
+		case ap.ActorPerson, ap.ObjectProfile, ap.ActorGroup:
 			return p.clientAPI.DeleteAccountOrUser(ctx, cMsg)
 		}
 
 	// FLAG/REPORT SOMETHING
 	case ap.ActivityFlag:
-		switch cMsg.APObjectType { //nolint:gocritic
+		switch cMsg.APObjectType {
 
 		// FLAG/REPORT ACCOUNT
 		case ap.ActorPerson:
 			return p.clientAPI.ReportAccount(ctx, cMsg)
+
+		// FLAG/REPORT FLAG (ie., forward existing report)
+		case ap.ActivityFlag:
+			return p.clientAPI.ForwardReport(ctx, cMsg)
 		}
 
 	// MOVE SOMETHING
@@ -349,10 +363,20 @@ func (p *clientAPI) CreateStatus(ctx context.Context, cMsg *messages.FromClientA
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	if err := p.trends.StoreStatusLinks(ctx, status); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
 	if err := p.federate.CreateStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error federating status: %v", err)
 	}
 
+	// Re-announce from any local groups
+	// the status was addressed to.
+	p.utils.groupAnnounce(ctx, status,
+		mentionedLocalGroups(status)...,
+	)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status;
 		// uncache the prepared version from all timelines.
@@ -411,8 +435,11 @@ func (p *clientAPI) CreateFollowReq(ctx context.Context, cMsg *messages.FromClie
 
 	// If target is a local, unlocked account,
 	// we can skip side effects for the follow
-	// request and accept the follow immediately.
-	if cMsg.Target.IsLocal() && !*cMsg.Target.Locked {
+	// request and accept the follow immediately,
+	// unless origin is silenced: follow requests
+	// from silenced accounts always need approval.
+	if cMsg.Target.IsLocal() && !*cMsg.Target.Locked &&
+		!cMsg.Origin.IsSilenced() {
 		// Accept the FR first to get the Follow.
 		follow, err := p.state.DB.AcceptFollowRequest(
 			ctx,
@@ -695,6 +722,19 @@ func (p *clientAPI) CreateBlock(ctx context.Context, cMsg *messages.FromClientAP
 	return nil
 }
 
+func (p *clientAPI) CreateRelay(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	relay, ok := cMsg.GTSModel.(*gtsmodel.Relay)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Relay", cMsg.GTSModel)
+	}
+
+	if err := p.federate.FollowRelay(ctx, relay); err != nil {
+		log.Errorf(ctx, "error federating relay follow: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	// Cast the updated Status model attached to msg.
 	status, ok := cMsg.GTSModel.(*gtsmodel.Status)
@@ -716,11 +756,22 @@ func (p *clientAPI) UpdateStatus(ctx context.Context, cMsg *messages.FromClientA
 		}
 	}
 
+	// Notify any accounts newly mentioned
+	// by the edit (existing are deduplicated).
+	if err := p.surface.notifyMentions(ctx, status); err != nil {
+		log.Errorf(ctx, "error notifying status mentions: %v", err)
+	}
+
 	// Push message that the status has been edited to streams.
 	if err := p.surface.timelineStatusUpdate(ctx, status); err != nil {
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	// Links may have been changed by the edit.
+	if err := p.trends.StoreStatusLinks(ctx, status); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
 	// Status representation has changed, invalidate from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
@@ -910,6 +961,19 @@ func (p *clientAPI) UndoAnnounce(ctx context.Context, cMsg *messages.FromClientA
 	return nil
 }
 
+func (p *clientAPI) UndoRelay(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	relay, ok := cMsg.GTSModel.(*gtsmodel.Relay)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Relay", cMsg.GTSModel)
+	}
+
+	if err := p.federate.UndoFollowRelay(ctx, relay); err != nil {
+		log.Errorf(ctx, "error federating relay follow undo: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	// Don't delete attachments, just unattach them:
 	// this request comes from the client API and the
@@ -933,6 +997,7 @@ func (p *clientAPI) DeleteStatus(ctx context.Context, cMsg *messages.FromClientA
 	// this status, (stops queued likes, boosts, creates etc).
 	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
 	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.DeleteJournaled(ctx, status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
@@ -990,6 +1055,7 @@ func (p *clientAPI) DeleteAccountOrUser(ctx context.Context, cMsg *messages.From
 	p.state.Workers.Delivery.Queue.Delete("ActorID", account.URI)
 	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
 	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.DeleteJournaled(ctx, account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
@@ -1034,6 +1100,19 @@ func (p *clientAPI) ReportAccount(ctx context.Context, cMsg *messages.FromClient
 	return nil
 }
 
+func (p *clientAPI) ForwardReport(ctx context.Context, cMsg *messages.FromClientAPI) error {
+	report, ok := cMsg.GTSModel.(*gtsmodel.Report)
+	if !ok {
+		return gtserror.Newf("%T not parseable as *gtsmodel.Report", cMsg.GTSModel)
+	}
+
+	if err := p.federate.Flag(ctx, report); err != nil {
+		log.Errorf(ctx, "error federating flag: %v", err)
+	}
+
+	return nil
+}
+
 func (p *clientAPI) MoveAccount(ctx context.Context, cMsg *messages.FromClientAPI) error {
 	// Redirect each local follower of
 	// OriginAccount to follow move target.
@@ -1171,6 +1250,10 @@ func (p *clientAPI) AcceptReply(ctx context.Context, cMsg *messages.FromClientAP
 		log.Errorf(ctx, "error timelining and notifying status reply: %v", err)
 	}
 
+	if err := p.trends.StoreStatusLinks(ctx, reply); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
 	// Send out the Accept.
 	if err := p.federate.AcceptInteraction(ctx, req); err != nil {
 		log.Errorf(ctx, "error federating approval of reply: %v", err)
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -29,11 +30,13 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	statusfilter "github.com/superseriousbusiness/gotosocial/internal/filter/status"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -1660,6 +1663,200 @@ func (suite *FromClientAPITestSuite) TestProcessStatusDelete() {
 	}
 }
 
+func (suite *FromClientAPITestSuite) TestProcessCreateStatusGroupAnnounce() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx       = context.Background()
+		moderator = suite.testAccounts["local_account_1"]
+		member    = suite.testAccounts["local_account_2"]
+		nonMember = suite.testAccounts["admin_account"]
+	)
+
+	// Create a new local group.
+	group, err := testStructs.State.DB.NewGroup(ctx, gtsmodel.NewGroup{
+		Username:  "cool_group",
+		CreatorID: moderator.ID,
+	})
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Member joins the group.
+	if err := testStructs.State.DB.PutFollow(ctx, &gtsmodel.Follow{
+		ID:              id.NewULID(),
+		URI:             "http://localhost:8080/users/1happyturtle/follow/" + id.NewULID(),
+		AccountID:       member.ID,
+		TargetAccountID: group.ID,
+	}); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	process := func(poster *gtsmodel.Account, visibility gtsmodel.Visibility) *gtsmodel.Status {
+		status := suite.newStatus(
+			ctx,
+			testStructs.State,
+			poster,
+			visibility,
+			nil,
+			nil,
+			[]*gtsmodel.Account{group},
+			false,
+			nil,
+		)
+
+		if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+			ctx,
+			&messages.FromClientAPI{
+				APObjectType:   ap.ObjectNote,
+				APActivityType: ap.ActivityCreate,
+				GTSModel:       status,
+				Origin:         poster,
+			},
+		); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		return status
+	}
+
+	// Public post by a member should be announced by the group.
+	status := process(member, gtsmodel.VisibilityPublic)
+	boost, err := testStructs.State.DB.GetStatusBoost(ctx, status.ID, group.ID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(group.ID, boost.AccountID)
+	suite.Equal(gtsmodel.VisibilityPublic, boost.Visibility)
+
+	// Moderators can post to the group without being members.
+	status = process(moderator, gtsmodel.VisibilityUnlocked)
+	_, err = testStructs.State.DB.GetStatusBoost(ctx, status.ID, group.ID)
+	suite.NoError(err)
+
+	// Followers-only posts are not announced.
+	status = process(member, gtsmodel.VisibilityFollowersOnly)
+	_, err = testStructs.State.DB.GetStatusBoost(ctx, status.ID, group.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// Posts by non-members are not announced.
+	status = process(nonMember, gtsmodel.VisibilityPublic)
+	_, err = testStructs.State.DB.GetStatusBoost(ctx, status.ID, group.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessReportForward() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	var (
+		ctx            = context.Background()
+		reportingAcct  = suite.testAccounts["local_account_1"]
+		targetAcct     = suite.testAccounts["remote_account_1"]
+		parentAcct     = suite.testAccounts["remote_account_2"]
+		parentStatus   = suite.testStatuses["remote_account_2_status_1"]
+		reportedStatus = suite.testStatuses["remote_account_1_status_1"]
+	)
+
+	// Make the reported status a
+	// reply to the parent status.
+	reportedStatus.InReplyToID = parentStatus.ID
+	reportedStatus.InReplyToURI = parentStatus.URI
+	reportedStatus.InReplyToAccountID = parentAcct.ID
+	if err := testStructs.State.DB.UpdateStatus(ctx,
+		reportedStatus,
+		"in_reply_to_id",
+		"in_reply_to_uri",
+		"in_reply_to_account_id",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	reportID := id.NewULID()
+	report := &gtsmodel.Report{
+		ID:              reportID,
+		URI:             "http://localhost:8080/reports/" + reportID,
+		AccountID:       reportingAcct.ID,
+		TargetAccountID: targetAcct.ID,
+		StatusIDs:       []string{reportedStatus.ID},
+		Forwarded:       util.Ptr(true),
+		ForwardDomains: []string{
+			parentAcct.Domain,
+			"not-a-reply-parent.example.org",
+		},
+		State: gtsmodel.ReportStateOpen,
+	}
+	if err := testStructs.State.DB.PutReport(ctx, report); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Process the report forward.
+	if err := testStructs.Processor.Workers().ProcessFromClientAPI(
+		ctx,
+		&messages.FromClientAPI{
+			APObjectType:   ap.ActivityFlag,
+			APActivityType: ap.ActivityFlag,
+			GTSModel:       report,
+			Origin:         reportingAcct,
+			Target:         targetAcct,
+		},
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// The flag should have been queued for delivery to
+	// the target's shared inbox, and to the reply-parent
+	// author's inbox, but nothing recorded as delivered yet.
+	deliveries := make(map[string]*delivery.Delivery)
+	for _, inbox := range []string{
+		*targetAcct.SharedInboxURI,
+		parentAcct.InboxURI,
+	} {
+		dlv, ok := testStructs.State.Workers.Delivery.Queue.Pop()
+		if !ok {
+			suite.FailNow("flag not queued for " + inbox)
+		}
+
+		body, err := io.ReadAll(dlv.Request.Body)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Contains(string(body), `"type":"Flag"`)
+
+		deliveries[dlv.Request.URL.String()] = dlv
+	}
+	suite.Contains(deliveries, *targetAcct.SharedInboxURI)
+	suite.Contains(deliveries, parentAcct.InboxURI)
+
+	dbReport, err := testStructs.State.DB.GetReportByID(ctx, reportID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Empty(dbReport.ForwardedDomains)
+	suite.Zero(dbReport.ForwardedAt)
+
+	// Have the delivery worker report
+	// both deliveries as accepted.
+	for _, dlv := range deliveries {
+		rctx := dlv.Request.Context()
+		deliveredFn := gtscontext.DeliveredFunc(rctx)
+		if deliveredFn == nil {
+			suite.FailNow("flag delivery has no delivered func")
+		}
+		deliveredFn(rctx)
+	}
+
+	// The report should record
+	// the successful deliveries.
+	dbReport, err = testStructs.State.DB.GetReportByID(ctx, reportID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.ElementsMatch([]string{targetAcct.Domain, parentAcct.Domain}, dbReport.ForwardedDomains)
+	suite.NotZero(dbReport.ForwardedAt)
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
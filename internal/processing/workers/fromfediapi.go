@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"codeberg.org/gruf/go-kv"
@@ -36,6 +37,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -49,6 +51,7 @@ type fediAPI struct {
 	federate *federate
 	account  *account.Processor
 	common   *common.Processor
+	trends   *trends.Processor
 	utils    *utils
 }
 
@@ -230,6 +233,13 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		// creating this status! Return
 		// here and let the other thread
 		// handle timelining + notifying.
+		//
+		// The status may still have been
+		// addressed to a local group via
+		// this inbox though, so check that.
+		if status != nil && fMsg.Receiving.IsGroup() {
+			p.utils.groupAnnounce(ctx, status, fMsg.Receiving)
+		}
 		return nil
 	}
 
@@ -308,6 +318,21 @@ func (p *fediAPI) CreateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	if err := p.trends.StoreStatusLinks(ctx, status); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
+	// Re-announce from any local groups the status
+	// was addressed to, including the receiving group
+	// if it was sent to the group's inbox (FEP-1b12).
+	groups := mentionedLocalGroups(status)
+	if fMsg.Receiving.IsGroup() && !slices.ContainsFunc(groups,
+		func(g *gtsmodel.Account) bool { return g.ID == fMsg.Receiving.ID },
+	) {
+		groups = append(groups, fMsg.Receiving)
+	}
+	p.utils.groupAnnounce(ctx, status, groups...)
+
 	if status.InReplyToID != "" {
 		// Interaction counts changed on the replied status; uncache the
 		// prepared version from all timelines. The status dereferencer
@@ -373,8 +398,10 @@ func (p *fediAPI) CreateFollowReq(ctx context.Context, fMsg *messages.FromFediAP
 		return gtserror.Newf("error populating follow request: %w", err)
 	}
 
-	if *followRequest.TargetAccount.Locked {
-		// Local account is locked: just notify the follow request.
+	if *followRequest.TargetAccount.Locked ||
+		followRequest.Account.IsSilenced() {
+		// Local account is locked, or requester is
+		// silenced: just notify the follow request.
 		if err := p.surface.notifyFollowRequest(ctx, followRequest); err != nil {
 			log.Errorf(ctx, "error notifying follow request: %v", err)
 		}
@@ -794,6 +821,10 @@ func (p *fediAPI) AcceptReply(ctx context.Context, fMsg *messages.FromFediAPI) e
 		log.Errorf(ctx, "error timelining and notifying status: %v", err)
 	}
 
+	if err := p.trends.StoreStatusLinks(ctx, status); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
 	// Send out the reply again, fully this time.
 	if err := p.federate.CreateStatus(ctx, status); err != nil {
 		log.Errorf(ctx, "error federating announce: %v", err)
@@ -866,11 +897,22 @@ func (p *fediAPI) UpdateStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 		}
 	}
 
+	// Notify any accounts newly mentioned
+	// by the edit (existing are deduplicated).
+	if err := p.surface.notifyMentions(ctx, status); err != nil {
+		log.Errorf(ctx, "error notifying status mentions: %v", err)
+	}
+
 	// Push message that the status has been edited to streams.
 	if err := p.surface.timelineStatusUpdate(ctx, status); err != nil {
 		log.Errorf(ctx, "error streaming status edit: %v", err)
 	}
 
+	// Links may have been changed by the edit.
+	if err := p.trends.StoreStatusLinks(ctx, status); err != nil {
+		log.Errorf(ctx, "error storing status links: %v", err)
+	}
+
 	// Status representation was refetched, uncache from timelines.
 	p.surface.invalidateStatusFromTimelines(ctx, status.ID)
 
@@ -900,6 +942,7 @@ func (p *fediAPI) DeleteStatus(ctx context.Context, fMsg *messages.FromFediAPI)
 	// this status, (stops queued likes, boosts, creates etc).
 	p.state.Workers.Delivery.Queue.Delete("ObjectID", status.URI)
 	p.state.Workers.Delivery.Queue.Delete("TargetID", status.URI)
+	p.state.Workers.Delivery.DeleteJournaled(ctx, status.URI)
 
 	// Drop any incoming queued client messages about / targeting
 	// status, (stops processing of local origin data for status).
@@ -938,6 +981,7 @@ func (p *fediAPI) DeleteAccount(ctx context.Context, fMsg *messages.FromFediAPI)
 	// this account, (stops queued likes, boosts, creates etc).
 	p.state.Workers.Delivery.Queue.Delete("ObjectID", account.URI)
 	p.state.Workers.Delivery.Queue.Delete("TargetID", account.URI)
+	p.state.Workers.Delivery.DeleteJournaled(ctx, account.URI)
 
 	// Drop any incoming queued client messages to / from this
 	// account, (stops processing of local origin data for acccount).
@@ -0,0 +1,174 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package workers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// mentionedLocalGroups returns the local
+// group accounts mentioned by the given status.
+func mentionedLocalGroups(status *gtsmodel.Status) []*gtsmodel.Account {
+	var groups []*gtsmodel.Account
+	for _, mention := range status.Mentions {
+		target := mention.TargetAccount
+		if target == nil ||
+			!target.IsLocal() ||
+			!target.IsGroup() {
+			continue
+		}
+		groups = append(groups, target)
+	}
+	return groups
+}
+
+// groupAnnounce re-announces the given status from
+// each of the given local groups to their members,
+// as described in FEP-1b12. Groups that the status
+// author is not a member or moderator of are skipped,
+// as are statuses that aren't public or unlisted.
+func (u *utils) groupAnnounce(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	groups ...*gtsmodel.Account,
+) {
+	if len(groups) == 0 {
+		// Nothing
+		// to do.
+		return
+	}
+
+	if status.BoostOfID != "" ||
+		util.PtrOrZero(status.PendingApproval) {
+		// Don't re-announce boosts,
+		// or not-yet-approved replies.
+		return
+	}
+
+	if status.Visibility != gtsmodel.VisibilityPublic &&
+		status.Visibility != gtsmodel.VisibilityUnlocked {
+		// Only re-announce statuses
+		// that members can all see.
+		return
+	}
+
+	for _, group := range groups {
+		if err := u.groupAnnounceOne(ctx, status, group); err != nil {
+			log.Errorf(ctx, "error announcing status %s from group %s: %v",
+				status.URI, group.URI, err)
+		}
+	}
+}
+
+func (u *utils) groupAnnounceOne(
+	ctx context.Context,
+	status *gtsmodel.Status,
+	group *gtsmodel.Account,
+) error {
+	if !group.IsLocal() || !group.IsGroup() ||
+		group.IsSuspended() || status.AccountID == group.ID {
+		// Not a group we
+		// can announce from.
+		return nil
+	}
+
+	// Only members (followers) and moderators
+	// of the group can have posts re-announced.
+	member, err := u.state.DB.IsFollowing(ctx, status.AccountID, group.ID)
+	if err != nil {
+		return gtserror.Newf("db error checking membership: %w", err)
+	}
+
+	if !member {
+		moderator, err := u.state.DB.IsGroupModerator(ctx, group.ID, status.AccountID)
+		if err != nil {
+			return gtserror.Newf("db error checking moderator: %w", err)
+		}
+
+		if !moderator {
+			log.Debugf(ctx, "%s not a member of group %s", status.AccountURI, group.URI)
+			return nil
+		}
+	}
+
+	// Lock on this group + status combo so
+	// we don't accidentally announce twice.
+	unlock := u.state.ProcessingLocks.Lock(group.URI + status.URI)
+	defer unlock()
+
+	// Check whether the group already announced this.
+	_, err = u.state.DB.GetStatusBoost(
+		gtscontext.SetBarebones(ctx),
+		status.ID,
+		group.ID,
+	)
+	if err == nil {
+		// Already announced.
+		return nil
+	} else if !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("db error checking existing boost: %w", err)
+	}
+
+	// Create the group's boost wrapper status.
+	boost, err := u.converter.StatusToBoost(ctx, status, group, "")
+	if err != nil {
+		return gtserror.Newf("error converting status to boost: %w", err)
+	}
+
+	// Groups re-announce to members
+	// regardless of interaction policy.
+	boost.PendingApproval = util.Ptr(false)
+
+	if err := u.state.DB.PutStatus(ctx, boost); err != nil {
+		return gtserror.Newf("db error storing boost: %w", err)
+	}
+
+	// Update stats for the group account.
+	if err := u.incrementStatusesCount(ctx, group, boost); err != nil {
+		log.Errorf(ctx, "error updating account stats: %v", err)
+	}
+
+	// Timeline the boost for local members.
+	if err := u.surface.timelineAndNotifyStatus(ctx, boost); err != nil {
+		log.Errorf(ctx, "error timelining and notifying status: %v", err)
+	}
+
+	// Notify the author their post was announced.
+	if err := u.surface.notifyAnnounce(ctx, boost); err != nil {
+		log.Errorf(ctx, "error notifying boost: %v", err)
+	}
+
+	// Send the Announce out to remote members.
+	if err := u.federate.Announce(ctx, boost); err != nil {
+		log.Errorf(ctx, "error federating announce: %v", err)
+	}
+
+	// Interaction counts changed on the boosted status;
+	// uncache the prepared version from all timelines.
+	u.surface.invalidateStatusFromTimelines(ctx, boost.BoostOfID)
+
+	return nil
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
@@ -574,6 +575,13 @@ func (s *Surface) Notify(
 		return nil
 	}
 
+	if targetAccount.IsGroup() {
+		// Local groups have no user to
+		// notify; moderators manage them
+		// through the groups API instead.
+		return nil
+	}
+
 	// We're doing state-y stuff so get a
 	// lock on this combo of notif params.
 	lockURI := getNotifyLockURI(
@@ -647,5 +655,13 @@ func (s *Surface) Notify(
 	}
 	s.Stream.Notify(ctx, targetAccount, apiNotif)
 
+	// Send Web Push notification to the user in the background,
+	// so slow or failing push services don't hold up processing.
+	s.State.Workers.WebPush.Queue.Push(func(ctx context.Context) {
+		if err := s.WebPushSender.Send(ctx, notif, apiNotif); err != nil {
+			log.Errorf(ctx, "error sending Web Push notifications: %v", err)
+		}
+	})
+
 	return nil
 }
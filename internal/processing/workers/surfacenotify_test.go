@@ -19,11 +19,13 @@ package workers_test
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/filter/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -45,6 +47,7 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 		Stream:        testStructs.Processor.Stream(),
 		VisFilter:     visibility.NewFilter(testStructs.State),
 		EmailSender:   testStructs.EmailSender,
+		WebPushSender: testStructs.WebPushSender,
 		Conversations: testStructs.Processor.Conversations(),
 	}
 
@@ -112,6 +115,66 @@ func (suite *SurfaceNotifyTestSuite) TestSpamNotifs() {
 	}
 }
 
+// failingWebPushSender is a Web Push sender
+// that always fails, after signalling sent.
+type failingWebPushSender struct {
+	sent chan *gtsmodel.Notification
+}
+
+func (f *failingWebPushSender) Send(
+	_ context.Context,
+	notification *gtsmodel.Notification,
+	_ *apimodel.Notification,
+) error {
+	f.sent <- notification
+	return errors.New("push service unavailable")
+}
+
+func (suite *SurfaceNotifyTestSuite) TestNotifyWebPushFails() {
+	testStructs := testrig.SetupTestStructs(rMediaPath, rTemplatePath)
+	defer testrig.TearDownTestStructs(testStructs)
+
+	sender := &failingWebPushSender{
+		sent: make(chan *gtsmodel.Notification, 1),
+	}
+
+	surface := &workers.Surface{
+		State:         testStructs.State,
+		Converter:     testStructs.TypeConverter,
+		Stream:        testStructs.Processor.Stream(),
+		VisFilter:     visibility.NewFilter(testStructs.State),
+		EmailSender:   testStructs.EmailSender,
+		WebPushSender: sender,
+		Conversations: testStructs.Processor.Conversations(),
+	}
+
+	var (
+		ctx           = context.Background()
+		targetAccount = suite.testAccounts["local_account_1"]
+		originAccount = suite.testAccounts["local_account_2"]
+	)
+
+	// Failing to push the notification
+	// shouldn't fail notifying the user.
+	if err := surface.Notify(ctx,
+		gtsmodel.NotificationFollow,
+		targetAccount,
+		originAccount,
+		"",
+	); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Push should still have been
+	// attempted in the background.
+	select {
+	case notif := <-sender.sent:
+		suite.Equal(targetAccount.ID, notif.TargetAccountID)
+	case <-time.After(5 * time.Second):
+		suite.FailNow("timed out waiting for Web Push send")
+	}
+}
+
 func TestSurfaceNotifyTestSuite(t *testing.T) {
 	suite.Run(t, new(SurfaceNotifyTestSuite))
 }
@@ -20,6 +20,7 @@ package workers
 import (
 	"context"
 	"errors"
+	"slices"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
@@ -37,10 +38,12 @@ import (
 // util provides util functions used by both
 // the fromClientAPI and fromFediAPI functions.
 type utils struct {
-	state   *state.State
-	media   *media.Processor
-	account *account.Processor
-	surface *Surface
+	state     *state.State
+	converter *typeutils.Converter
+	media     *media.Processor
+	account   *account.Processor
+	surface   *Surface
+	federate  *federate
 }
 
 // wipeStatus encapsulates common logic
@@ -54,6 +57,25 @@ func (u *utils) wipeStatus(
 ) error {
 	var errs gtserror.MultiError
 
+	// Load all of the status' historical edits, as
+	// these may contain attachments that have since
+	// been removed from the latest status version.
+	edits, err := u.state.DB.GetStatusEditsByIDs(
+		gtscontext.SetBarebones(ctx),
+		statusToDelete.EditIDs,
+	)
+	if err != nil {
+		errs.Appendf("error fetching status edits: %w", err)
+	}
+
+	// Gather all attachment IDs from the status
+	// and its edits, ensuring they're only unique.
+	attachmentIDs := slices.Clone(statusToDelete.AttachmentIDs)
+	for _, edit := range edits {
+		attachmentIDs = append(attachmentIDs, edit.AttachmentIDs...)
+	}
+	attachmentIDs = util.Deduplicate(attachmentIDs)
+
 	// Either delete all attachments for this status,
 	// or simply unattach + clean them separately later.
 	//
@@ -62,14 +84,14 @@ func (u *utils) wipeStatus(
 	// status immediately (in case of delete + redraft)
 	if deleteAttachments {
 		// todo:u.state.DB.DeleteAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if err := u.media.Delete(ctx, id); err != nil {
 				errs.Appendf("error deleting media: %w", err)
 			}
 		}
 	} else {
 		// todo:u.state.DB.UnattachAttachmentsForStatus
-		for _, id := range statusToDelete.AttachmentIDs {
+		for _, id := range attachmentIDs {
 			if _, err := u.media.Unattach(ctx, statusToDelete.Account, id); err != nil {
 				errs.Appendf("error unattaching media: %w", err)
 			}
@@ -99,6 +121,11 @@ func (u *utils) wipeStatus(
 		errs.Appendf("error deleting status faves: %w", err)
 	}
 
+	// delete all historical edits of this status
+	if err := u.state.DB.DeleteStatusEdits(ctx, statusToDelete.EditIDs); err != nil {
+		errs.Appendf("error deleting status edits: %w", err)
+	}
+
 	if pollID := statusToDelete.PollID; pollID != "" {
 		// Delete this poll by ID from the database.
 		if err := u.state.DB.DeletePollByID(ctx, pollID); err != nil {
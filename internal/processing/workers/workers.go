@@ -26,8 +26,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/conversations"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/media"
 	"github.com/superseriousbusiness/gotosocial/internal/processing/stream"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/trends"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
 )
 
@@ -44,10 +46,12 @@ func New(
 	converter *typeutils.Converter,
 	visFilter *visibility.Filter,
 	emailSender email.Sender,
+	webPushSender webpush.Sender,
 	account *account.Processor,
 	media *media.Processor,
 	stream *stream.Processor,
 	conversations *conversations.Processor,
+	trends *trends.Processor,
 ) Processor {
 	// Init federate logic
 	// wrapper struct.
@@ -65,15 +69,18 @@ func New(
 		Stream:        stream,
 		VisFilter:     visFilter,
 		EmailSender:   emailSender,
+		WebPushSender: webPushSender,
 		Conversations: conversations,
 	}
 
 	// Init shared util funcs.
 	utils := &utils{
-		state:   state,
-		media:   media,
-		account: account,
-		surface: surface,
+		state:     state,
+		converter: converter,
+		media:     media,
+		account:   account,
+		surface:   surface,
+		federate:  federate,
 	}
 
 	return Processor{
@@ -85,6 +92,7 @@ func New(
 			federate:  federate,
 			account:   account,
 			common:    common,
+			trends:    trends,
 			utils:     utils,
 		},
 		fediAPI: fediAPI{
@@ -93,6 +101,7 @@ func New(
 			federate: federate,
 			account:  account,
 			common:   common,
+			trends:   trends,
 			utils:    utils,
 		},
 	}
@@ -0,0 +1,185 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package ratelimit
+
+import (
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxClients is the maximum number of throttled
+// clients remembered per policy. When exceeded, the
+// client that was least recently throttled is dropped.
+const maxClients = 500
+
+// Tracker keeps count of clients throttled by each
+// rate limit policy since startup, so that admins can
+// see who is hitting the limits and how often.
+//
+// The zero value is ready to use.
+type Tracker struct {
+	mu       sync.Mutex
+	policies map[string]*policy
+}
+
+// Policy is a snapshot of the
+// throttling state of a policy.
+type Policy struct {
+	// Name of the policy.
+	Name string
+
+	// Limit is the number of requests
+	// permitted per rate limit period.
+	Limit int
+
+	// Throttled is the total number of requests
+	// rejected under this policy since startup.
+	Throttled uint64
+
+	// Clients that have been throttled, most
+	// recently throttled first.
+	Clients []Client
+}
+
+// Client is a single client
+// throttled under a policy.
+type Client struct {
+	// Type of the key, one of
+	// "account", "application" or "ip".
+	Type string
+
+	// ID of the client, ie., the account
+	// ID, application client ID or IP.
+	ID string
+
+	// Throttled is the number of requests
+	// rejected for this client.
+	Throttled uint64
+
+	// Last time a request from
+	// this client was rejected.
+	Last time.Time
+}
+
+type policy struct {
+	limit     int
+	throttled uint64
+	clients   map[string]*Client
+}
+
+// Register makes the policy with given name and limit
+// known to the tracker, so it appears in Policies()
+// even before any client has been throttled by it.
+func (t *Tracker) Register(name string, limit int) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.get(name).limit = limit
+}
+
+// Throttle records that a request from the client
+// with given key was rejected under the named policy.
+// Key is expected in the form "type:id", as returned
+// by the rate limiting middleware.
+func (t *Tracker) Throttle(name string, key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.get(name)
+	p.throttled++
+
+	client, ok := p.clients[key]
+	if !ok {
+		if len(p.clients) >= maxClients {
+			p.evict()
+		}
+
+		typ, id, _ := strings.Cut(key, ":")
+		client = &Client{Type: typ, ID: id}
+		p.clients[key] = client
+	}
+
+	client.Throttled++
+	client.Last = time.Now()
+}
+
+// Policies returns a snapshot of
+// all known policies, sorted by name.
+func (t *Tracker) Policies() []Policy {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	policies := make([]Policy, 0, len(t.policies))
+	for name, p := range t.policies {
+		clients := make([]Client, 0, len(p.clients))
+		for _, client := range p.clients {
+			clients = append(clients, *client)
+		}
+
+		slices.SortFunc(clients, func(a, b Client) int {
+			return b.Last.Compare(a.Last)
+		})
+
+		policies = append(policies, Policy{
+			Name:      name,
+			Limit:     p.limit,
+			Throttled: p.throttled,
+			Clients:   clients,
+		})
+	}
+
+	slices.SortFunc(policies, func(a, b Policy) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return policies
+}
+
+// get returns the named policy, creating
+// it if necessary. Caller must hold lock.
+func (t *Tracker) get(name string) *policy {
+	if t.policies == nil {
+		t.policies = make(map[string]*policy)
+	}
+
+	p, ok := t.policies[name]
+	if !ok {
+		p = &policy{clients: make(map[string]*Client)}
+		t.policies[name] = p
+	}
+
+	return p
+}
+
+// evict drops the least recently throttled client.
+func (p *policy) evict() {
+	var (
+		oldestKey string
+		oldest    time.Time
+	)
+
+	for key, client := range p.clients {
+		if oldestKey == "" || client.Last.Before(oldest) {
+			oldestKey = key
+			oldest = client.Last
+		}
+	}
+
+	delete(p.clients, oldestKey)
+}
@@ -38,6 +38,7 @@ const (
 	follow    = "follow"
 	blocks    = "blocks"
 	reports   = "reports"
+	rules     = "rules"
 	accepts   = "accepts"
 
 	schemes                  = `(http|https)://`                                         // Allowed URI protocols for parsing links in text.
@@ -75,6 +76,7 @@ const (
 	acceptsPath       = userPathPrefix + `/` + accepts + `/(` + ulid + `)$`
 	blockPath         = userPathPrefix + `/` + blocks + `/(` + ulid + `)$`
 	reportPath        = `^/?` + reports + `/(` + ulid + `)$`
+	rulePath          = `^/?` + rules + `/(` + ulid + `)$`
 	filePath          = `^/?(` + ulid + `)/([a-z]+)/([a-z]+)/(` + ulid + `)\.([a-z0-9]+)$`
 )
 
@@ -160,6 +162,10 @@ var (
 	// from eg /reports/01GP3AWY4CRDVRNZKW0TEAMB5R
 	ReportPath = regexp.MustCompile(reportPath)
 
+	// RulePath parses a path that validates and captures the ulid part
+	// from eg /rules/01GP3AWY4CRDVRNZKW0TEAMB5R
+	RulePath = regexp.MustCompile(rulePath)
+
 	// ReportPath parses a path that validates and captures the username part and the ulid part
 	// from eg /users/example_username/accepts/01GP3AWY4CRDVRNZKW0TEAMB5R
 	AcceptsPath = regexp.MustCompile(acceptsPath)
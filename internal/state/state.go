@@ -21,6 +21,7 @@ import (
 	"codeberg.org/gruf/go-mutexes"
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/ratelimit"
 	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
 	"github.com/superseriousbusiness/gotosocial/internal/workers"
@@ -64,6 +65,10 @@ type State struct {
 	// Workers provides access to this state's collection of worker pools.
 	Workers workers.Workers
 
+	// RateLimits keeps count of clients throttled
+	// by the HTTP rate limiting middlewares.
+	RateLimits ratelimit.Tracker
+
 	// prevent pass-by-value.
 	_ nocopy
 }
@@ -189,3 +189,12 @@ func SanitizeToPlaintext(in string) string {
 	content = html.UnescapeString(content)
 	return strings.TrimSpace(content)
 }
+
+// SanitizeToSearchText is like SanitizeToPlaintext, but
+// separates the text of adjacent html elements with a
+// space, so that eg., the last word of one paragraph
+// isn't run together with the first word of the next.
+// Intended for indexing html content for full-text search.
+func SanitizeToSearchText(in string) string {
+	return SanitizeToPlaintext(strings.ReplaceAll(in, "<", " <"))
+}
@@ -0,0 +1,137 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package totp_test
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/totp"
+)
+
+type TOTPTestSuite struct {
+	suite.Suite
+}
+
+// rfcSecret is the SHA-1 secret used
+// in the RFC 6238 appendix B test vectors.
+var rfcSecret = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func (suite *TOTPTestSuite) TestCodeRFCVectors() {
+	// RFC 6238 gives 8 digit codes, we use the last 6.
+	for unix, expect := range map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	} {
+		code, err := totp.Code(rfcSecret, time.Unix(unix, 0))
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(expect, code, "at time %d", unix)
+	}
+}
+
+func (suite *TOTPTestSuite) TestValidate() {
+	now := time.Unix(1234567890, 0)
+
+	for _, test := range []struct {
+		passcode string
+		at       time.Time
+		valid    bool
+	}{
+		{passcode: "005924", at: now, valid: true},
+		{passcode: "005 924", at: now, valid: true},
+		{passcode: "005924", at: now.Add(totp.Period), valid: true},
+		{passcode: "005924", at: now.Add(-totp.Period), valid: true},
+		{passcode: "005924", at: now.Add(3 * totp.Period), valid: false},
+		{passcode: "005925", at: now, valid: false},
+		{passcode: "5924", at: now, valid: false},
+		{passcode: "", at: now, valid: false},
+	} {
+		valid, err := totp.Validate(rfcSecret, test.passcode, test.at)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		suite.Equal(test.valid, valid, "passcode %q", test.passcode)
+	}
+}
+
+func (suite *TOTPTestSuite) TestMatchReplay() {
+	now := time.Unix(1234567890, 0)
+	step := now.Unix() / int64(totp.Period/time.Second)
+
+	// First use gives the code's time step.
+	matched, err := totp.Match(rfcSecret, "005924", now, 0)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(step, matched)
+
+	// Using it again, even within the skew, is refused.
+	matched, err = totp.Match(rfcSecret, "005924", now.Add(totp.Period), step)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Zero(matched)
+
+	// But the next code is fine.
+	next, err := totp.Code(rfcSecret, now.Add(totp.Period))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	matched, err = totp.Match(rfcSecret, next, now.Add(totp.Period), step)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(step+1, matched)
+}
+
+func (suite *TOTPTestSuite) TestGenerateSecret() {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// 160 bits in unpadded base32.
+	suite.Len(secret, 32)
+
+	// Should round trip.
+	code, err := totp.Code(secret, time.Now())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	valid, err := totp.Validate(strings.ToLower(secret), code, time.Now())
+	suite.NoError(err)
+	suite.True(valid)
+}
+
+func (suite *TOTPTestSuite) TestURI() {
+	uri := totp.URI("JBSWY3DPEHPK3PXP", "example.org", "someone@example.org")
+	suite.Equal("otpauth://totp/example.org:someone@example.org?algorithm=SHA1&digits=6&issuer=example.org&period=30&secret=JBSWY3DPEHPK3PXP", uri)
+}
+
+func TestTOTPTestSuite(t *testing.T) {
+	suite.Run(t, new(TOTPTestSuite))
+}
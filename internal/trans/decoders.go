@@ -25,6 +25,7 @@ import (
 	"time"
 
 	"github.com/mitchellh/mapstructure"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
 )
 
@@ -53,6 +54,9 @@ func (i *importer) accountDecode(e transmodel.Entry) (*transmodel.Account, error
 	}
 	a.PublicKey = publicKey
 
+	// set plaintext note used for full-text search
+	a.NotePlain = text.SanitizeToSearchText(a.Note)
+
 	if a.Domain == "" {
 		// extract private key (local account)
 		privateKeyBlock, _ := pem.Decode([]byte(a.PrivateKeyString))
@@ -21,22 +21,33 @@ import (
 	"context"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 )
 
 // Exporter wraps functionality for exporting entries from the database to a file.
 type Exporter interface {
 	ExportMinimal(ctx context.Context, path string) error
+
+	// ExportFull exports every row of every table in the database, along with
+	// any media and emoji files from storage. If since is set to a ULID, only
+	// rows created or updated after it (and their files) will be exported.
+	ExportFull(ctx context.Context, path string, since string) error
 }
 
 type exporter struct {
 	db         db.DB
+	storage    *storage.Driver
 	writtenIDs map[string]bool
 }
 
 // NewExporter returns a new Exporter that will use the given db.
-func NewExporter(db db.DB) Exporter {
+//
+// Storage is only used by ExportFull, and may be nil to
+// leave media and emoji files out of full exports.
+func NewExporter(db db.DB, storage *storage.Driver) Exporter {
 	return &exporter{
 		db:         db,
+		storage:    storage,
 		writtenIDs: make(map[string]bool),
 	}
 }
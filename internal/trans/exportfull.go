@@ -0,0 +1,394 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/oklog/ulid"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+const (
+	// blobChunkSize is the max number of bytes of
+	// a stored file that will be written per entry.
+	blobChunkSize = 1024 * 1024
+
+	// keysPerEntry is the max number of row
+	// keys that will be written per entry.
+	keysPerEntry = 10000
+)
+
+func (e *exporter) ExportFull(ctx context.Context, path string, since string) error {
+	if path == "" {
+		return errors.New("ExportFull: path empty")
+	}
+
+	bdb, ok := e.db.(bunDB)
+	if !ok {
+		return errors.New("ExportFull: database does not support full exports")
+	}
+
+	var sinceTime time.Time
+	if since != "" {
+		sinceULID, err := ulid.ParseStrict(since)
+		if err != nil {
+			return fmt.Errorf("ExportFull: since %s is not a valid ULID: %w", since, err)
+		}
+		sinceTime = ulid.Time(sinceULID.Time())
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("ExportFull: couldn't export to %s: %w", path, err)
+	}
+
+	// Mark where this export ends *before* selecting
+	// anything, so that incremental exports continuing
+	// from it overlap with rather than miss any rows
+	// written while it's running. Overlap is harmless,
+	// since imported rows replace existing ones.
+	backup := &transmodel.Backup{
+		Type:      transmodel.TransBackup,
+		CreatedAt: time.Now(),
+		Since:     since,
+		Until:     id.NewULID(),
+	}
+
+	if err := e.exportFull(ctx, bdb.DB(), file, backup, sinceTime); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("ExportFull: %w", err)
+	}
+
+	log.Infof(ctx, "exported up to %s; pass this as 'since' to export incrementally from here", backup.Until)
+	return neatClose(file)
+}
+
+func (e *exporter) exportFull(
+	ctx context.Context,
+	db *bun.DB,
+	file *os.File,
+	backup *transmodel.Backup,
+	sinceTime time.Time,
+) error {
+	encoder := json.NewEncoder(file)
+
+	if err := encoder.Encode(backup); err != nil {
+		return fmt.Errorf("error encoding backup info: %w", err)
+	}
+
+	// Export every table, gathering
+	// up storage keys as we go along.
+	var keys []string
+	for _, m := range fullModels {
+		table := db.Dialect().Tables().Get(reflect.TypeOf(m).Elem())
+		tableKeys, err := e.exportTable(ctx, db, encoder, table, backup.Since, sinceTime)
+		if err != nil {
+			return fmt.Errorf("error exporting table %s: %w", table.Name, err)
+		}
+		keys = append(keys, tableKeys...)
+
+		if backup.Since == "" {
+			// Full exports are restored into
+			// an empty database, so there's
+			// nothing to be deleted.
+			continue
+		}
+
+		// Keys are listed *after* selecting rows, so
+		// that every exported row is listed, unless
+		// it was deleted in the meantime anyway.
+		if err := e.exportKeys(ctx, db, encoder, table); err != nil {
+			return fmt.Errorf("error exporting keys of table %s: %w", table.Name, err)
+		}
+	}
+
+	if e.storage == nil {
+		// Not exporting files.
+		return nil
+	}
+
+	buf := make([]byte, blobChunkSize)
+	for _, key := range keys {
+		if err := e.exportBlob(ctx, encoder, buf, key); err != nil {
+			return fmt.Errorf("error exporting file %s: %w", key, err)
+		}
+	}
+
+	return nil
+}
+
+// exportTable writes out rows of the given table as
+// transmodel.Row entries, returning the storage keys
+// of any files that the written rows refer to.
+//
+// If since is set, only rows with a greater ID, or
+// updated / fetched after sinceTime are written out.
+// Tables with none of those columns, and wholeTables,
+// are written in full.
+func (e *exporter) exportTable(
+	ctx context.Context,
+	db *bun.DB,
+	encoder *json.Encoder,
+	table *schema.Table,
+	since string,
+	sinceTime time.Time,
+) ([]string, error) {
+	q := db.NewSelect().Model(reflect.New(table.Type).Interface())
+
+	for _, pk := range table.PKs {
+		q = q.Order(pk.Name)
+	}
+
+	if since != "" && !slices.Contains(wholeTables, table.Name) {
+		q = whereSince(q, table, since, sinceTime)
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error selecting rows: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		v := reflect.New(table.Type)
+		if err := db.ScanRow(ctx, rows, v.Interface()); err != nil {
+			return nil, fmt.Errorf("error scanning row: %w", err)
+		}
+
+		row := &transmodel.Row{
+			Type:    transmodel.TransRow,
+			Table:   table.Name,
+			Columns: make(map[string]json.RawMessage, len(table.Fields)),
+		}
+
+		for _, field := range table.Fields {
+			b, err := json.Marshal(field.Value(v.Elem()).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("error encoding column %s: %w", field.Name, err)
+			}
+			row.Columns[field.Name] = b
+		}
+
+		if err := encoder.Encode(row); err != nil {
+			return nil, fmt.Errorf("error encoding row: %w", err)
+		}
+
+		keys = append(keys, storageKeys(v.Interface())...)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return keys, nil
+}
+
+// exportKeys writes out the keys of every row of the given table
+// as transmodel.Keys entries, so that rows deleted since the export
+// that an incremental export continues from can be deleted on
+// import. Tables without any key fields are skipped.
+func (e *exporter) exportKeys(
+	ctx context.Context,
+	db *bun.DB,
+	encoder *json.Encoder,
+	table *schema.Table,
+) error {
+	fields := keyFields(table)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	q := db.NewSelect().Model(reflect.New(table.Type).Interface())
+	for _, key := range fields {
+		q = q.Column(key.Name).Order(key.Name)
+	}
+
+	rows, err := q.Rows(ctx)
+	if err != nil {
+		return fmt.Errorf("error selecting keys: %w", err)
+	}
+	defer rows.Close()
+
+	entry := &transmodel.Keys{
+		Type:  transmodel.TransKeys,
+		Table: table.Name,
+	}
+
+	var written bool
+	for rows.Next() {
+		v := reflect.New(table.Type)
+		if err := db.ScanRow(ctx, rows, v.Interface()); err != nil {
+			return fmt.Errorf("error scanning keys: %w", err)
+		}
+
+		key, err := rowKey(fields, v.Elem())
+		if err != nil {
+			return fmt.Errorf("error encoding key: %w", err)
+		}
+		entry.Keys = append(entry.Keys, key)
+
+		if len(entry.Keys) >= keysPerEntry {
+			if err := encoder.Encode(entry); err != nil {
+				return fmt.Errorf("error encoding keys: %w", err)
+			}
+			entry.Keys = entry.Keys[:0]
+			written = true
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating keys: %w", err)
+	}
+
+	if len(entry.Keys) == 0 && written {
+		// All keys
+		// written out.
+		return nil
+	}
+
+	// Write the remainder, even if there are no
+	// keys at all, so that a table that's since
+	// been emptied is also emptied on import.
+	if err := encoder.Encode(entry); err != nil {
+		return fmt.Errorf("error encoding keys: %w", err)
+	}
+
+	return nil
+}
+
+// whereSince limits q to rows of table created or
+// updated since the given ULID / time, as best as
+// can be determined from the columns of the table.
+func whereSince(
+	q *bun.SelectQuery,
+	table *schema.Table,
+	since string,
+	sinceTime time.Time,
+) *bun.SelectQuery {
+	var (
+		idField  = table.FieldMap["id"]
+		useID    = idField != nil && idField.IndirectType.Kind() == reflect.String
+		timeCols []string
+	)
+
+	for _, col := range []string{"updated_at", "fetched_at"} {
+		if table.HasField(col) {
+			timeCols = append(timeCols, col)
+		}
+	}
+
+	if !useID && len(timeCols) == 0 {
+		// Nothing to go on,
+		// select everything.
+		return q
+	}
+
+	return q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+		if useID {
+			q = q.WhereOr("? > ?", bun.Ident("id"), since)
+		}
+		for _, col := range timeCols {
+			q = q.WhereOr("? > ?", bun.Ident(col), sinceTime)
+		}
+		return q
+	})
+}
+
+// storageKeys returns the keys of any cached
+// files in storage that belong to the given model.
+func storageKeys(m interface{}) []string {
+	var keys []string
+
+	switch m := m.(type) {
+	case *gtsmodel.MediaAttachment:
+		if m.Cached != nil && *m.Cached {
+			keys = append(keys, m.File.Path, m.Thumbnail.Path)
+		}
+	case *gtsmodel.Emoji:
+		if m.Cached != nil && *m.Cached {
+			keys = append(keys, m.ImagePath, m.ImageStaticPath)
+		}
+	case *gtsmodel.Archive:
+		keys = append(keys, m.FilePath)
+	}
+
+	// Drop empty keys,
+	// eg., no thumbnail.
+	nonEmpty := keys[:0]
+	for _, key := range keys {
+		if key != "" {
+			nonEmpty = append(nonEmpty, key)
+		}
+	}
+
+	return nonEmpty
+}
+
+// exportBlob streams the file at key from storage into
+// one or more transmodel.Blob entries, using buf to
+// read each chunk. Missing files are logged and skipped.
+func (e *exporter) exportBlob(ctx context.Context, encoder *json.Encoder, buf []byte, key string) error {
+	rc, err := e.storage.GetStream(ctx, key)
+	if err != nil {
+		if storage.IsNotFound(err) {
+			log.Warnf(ctx, "file %s not found in storage, skipping it", key)
+			return nil
+		}
+		return err
+	}
+	defer rc.Close()
+
+	for {
+		n, err := io.ReadFull(rc, buf)
+		final := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !final {
+			return err
+		}
+
+		blob := &transmodel.Blob{
+			Type:  transmodel.TransBlob,
+			Key:   key,
+			Data:  buf[:n],
+			Final: final,
+		}
+
+		if err := encoder.Encode(blob); err != nil {
+			return err
+		}
+
+		if final {
+			return nil
+		}
+	}
+}
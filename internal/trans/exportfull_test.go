@@ -0,0 +1,350 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ExportFullTestSuite struct {
+	TransTestSuite
+}
+
+// readEntries returns the backup info and
+// rows (keyed by table) of the given export.
+func (suite *ExportFullTestSuite) readEntries(path string) (*transmodel.Backup, map[string][]*transmodel.Row, int) {
+	file, err := os.Open(path)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	defer file.Close()
+
+	var (
+		backup *transmodel.Backup
+		rows   = make(map[string][]*transmodel.Row)
+		blobs  int
+	)
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 4*1024*1024)
+	for scanner.Scan() {
+		var head struct {
+			Type transmodel.Type `json:"type"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &head); err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		switch head.Type {
+		case transmodel.TransBackup:
+			backup = &transmodel.Backup{}
+			if err := json.Unmarshal(scanner.Bytes(), backup); err != nil {
+				suite.FailNow(err.Error())
+			}
+		case transmodel.TransRow:
+			row := &transmodel.Row{}
+			if err := json.Unmarshal(scanner.Bytes(), row); err != nil {
+				suite.FailNow(err.Error())
+			}
+			rows[row.Table] = append(rows[row.Table], row)
+		case transmodel.TransBlob:
+			blobs++
+		case transmodel.TransKeys:
+			// Checked by importing.
+		default:
+			suite.FailNow("unexpected entry type " + string(head.Type))
+		}
+	}
+	suite.NoError(scanner.Err())
+
+	return backup, rows, blobs
+}
+
+func (suite *ExportFullTestSuite) newDB() (db.DB, *storage.Driver) {
+	var state state.State
+	state.Caches.Init()
+
+	return testrig.NewTestDB(&state), testrig.NewInMemoryStorage()
+}
+
+func (suite *ExportFullTestSuite) count(db db.DB, model interface{}) int {
+	count, err := db.(*bundb.DBService).DB().
+		NewSelect().
+		Model(model).
+		Count(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return count
+}
+
+func (suite *ExportFullTestSuite) TestExportImportFull() {
+	ctx := context.Background()
+	path := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+
+	err := trans.NewExporter(suite.db, suite.storage).ExportFull(ctx, path, "")
+	suite.NoError(err)
+
+	backup, rows, blobs := suite.readEntries(path)
+	suite.NotNil(backup)
+	suite.Empty(backup.Since)
+	suite.NotEmpty(backup.Until)
+	suite.NotZero(blobs)
+	suite.NotEmpty(rows["statuses"])
+
+	newDB, newStorage := suite.newDB()
+	defer testrig.StandardStorageTeardown(newStorage)
+
+	err = trans.NewImporter(newDB, newStorage).Import(ctx, path)
+	suite.NoError(err)
+
+	// Every row of these tables
+	// should have made it across.
+	for _, model := range []interface{}{
+		(*gtsmodel.Account)(nil),
+		(*gtsmodel.AuditLogEntry)(nil),
+		(*gtsmodel.Emoji)(nil),
+		(*gtsmodel.Filter)(nil),
+		(*gtsmodel.List)(nil),
+		(*gtsmodel.MediaAttachment)(nil),
+		(*gtsmodel.Notification)(nil),
+		(*gtsmodel.Poll)(nil),
+		(*gtsmodel.Report)(nil),
+		(*gtsmodel.Status)(nil),
+		(*gtsmodel.StatusBookmark)(nil),
+		(*gtsmodel.Token)(nil),
+		(*gtsmodel.User)(nil),
+	} {
+		suite.Equal(suite.count(suite.db, model), suite.count(newDB, model), "%T", model)
+	}
+
+	// Check a status in detail.
+	statusID := testrig.NewTestStatuses()["local_account_1_status_1"].ID
+	statusBefore, err := suite.db.GetStatusByID(ctx, statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	statusAfter, err := newDB.GetStatusByID(ctx, statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(statusBefore.URI, statusAfter.URI)
+	suite.Equal(statusBefore.Content, statusAfter.Content)
+	suite.Equal(statusBefore.Visibility, statusAfter.Visibility)
+	suite.Equal(statusBefore.CreatedAt, statusAfter.CreatedAt)
+	suite.Equal(statusBefore.AttachmentIDs, statusAfter.AttachmentIDs)
+	suite.Equal(statusBefore.InteractionPolicy, statusAfter.InteractionPolicy)
+
+	// Keys should still be usable.
+	accountID := suite.testAccounts["local_account_1"].ID
+	accountBefore, err := suite.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	accountAfter, err := newDB.GetAccountByID(ctx, accountID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.True(accountBefore.PrivateKey.Equal(accountAfter.PrivateKey))
+	suite.True(accountBefore.PublicKey.Equal(accountAfter.PublicKey))
+
+	// Stored files should have come along too.
+	attachment := testrig.NewTestAttachments()["local_account_1_status_4_attachment_1"]
+	emoji := testrig.NewTestEmojis()["rainbow"]
+	for _, key := range []string{
+		attachment.File.Path,
+		attachment.Thumbnail.Path,
+		emoji.ImagePath,
+		emoji.ImageStaticPath,
+	} {
+		before, err := suite.storage.Get(ctx, key)
+		suite.NoError(err)
+		after, err := newStorage.Get(ctx, key)
+		suite.NoError(err)
+		suite.Equal(before, after, key)
+	}
+}
+
+func (suite *ExportFullTestSuite) TestExportImportIncremental() {
+	ctx := context.Background()
+	dir := suite.T().TempDir()
+	fullPath := fmt.Sprintf("%s/%s", dir, uuid.NewString())
+	incrPath := fmt.Sprintf("%s/%s", dir, uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, nil)
+	err := exporter.ExportFull(ctx, fullPath, "")
+	suite.NoError(err)
+	backup, fullRows, _ := suite.readEntries(fullPath)
+
+	// Make sure we're past the end of the full export.
+	time.Sleep(2 * time.Millisecond)
+
+	// Add a new status.
+	status := testrig.NewTestStatuses()["local_account_1_status_1"]
+	status.ID = id.NewULID()
+	status.URI = status.URI + "/new"
+	status.URL = status.URL + "/new"
+	status.CreatedAt = time.Now()
+	status.UpdatedAt = status.CreatedAt
+	err = suite.db.PutStatus(ctx, status)
+	suite.NoError(err)
+
+	// Update an existing account.
+	account := suite.testAccounts["local_account_1"]
+	account.Note = "i've been updated since the last export"
+	account.UpdatedAt = time.Now()
+	err = suite.db.UpdateAccount(ctx, account, "note", "updated_at")
+	suite.NoError(err)
+
+	// Delete an existing status and bookmark.
+	deletedStatus := testrig.NewTestStatuses()["admin_account_status_1"]
+	err = suite.db.DeleteStatusByID(ctx, deletedStatus.ID)
+	suite.NoError(err)
+
+	deletedBookmark := testrig.NewTestBookmarks()["local_account_1_admin_account_status_1"]
+	err = suite.db.DeleteStatusBookmarkByID(ctx, deletedBookmark.ID)
+	suite.NoError(err)
+
+	err = trans.NewExporter(suite.db, nil).ExportFull(ctx, incrPath, backup.Until)
+	suite.NoError(err)
+	incrBackup, incrRows, _ := suite.readEntries(incrPath)
+	suite.Equal(backup.Until, incrBackup.Since)
+
+	// Only changed statuses + accounts
+	// should be in the incremental export.
+	suite.Less(len(incrRows["statuses"]), len(fullRows["statuses"]))
+	suite.Less(len(incrRows["accounts"]), len(fullRows["accounts"]))
+
+	var foundStatus bool
+	for _, row := range incrRows["statuses"] {
+		if string(row.Columns["id"]) == `"`+status.ID+`"` {
+			foundStatus = true
+		}
+	}
+	suite.True(foundStatus)
+
+	// Restore the full export with the incremental one on top.
+	newDB, _ := suite.newDB()
+	importer := trans.NewImporter(newDB, nil)
+	suite.NoError(importer.Import(ctx, fullPath))
+	suite.NoError(importer.Import(ctx, incrPath))
+
+	_, err = newDB.GetStatusByID(ctx, status.ID)
+	suite.NoError(err)
+
+	accountAfter, err := newDB.GetAccountByID(ctx, account.ID)
+	suite.NoError(err)
+	suite.Equal(account.Note, accountAfter.Note)
+
+	// Deleted rows should be gone again.
+	_, err = newDB.GetStatusByID(ctx, deletedStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	_, err = newDB.GetStatusBookmarkByID(ctx, deletedBookmark.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	for _, model := range []interface{}{
+		(*gtsmodel.Account)(nil),
+		(*gtsmodel.Status)(nil),
+		(*gtsmodel.StatusBookmark)(nil),
+		(*gtsmodel.StatusToTag)(nil),
+	} {
+		suite.Equal(suite.count(suite.db, model), suite.count(newDB, model), "%T", model)
+	}
+}
+
+func (suite *ExportFullTestSuite) TestExportImportIncrementalUpdatedInPlace() {
+	ctx := context.Background()
+	dir := suite.T().TempDir()
+	fullPath := fmt.Sprintf("%s/%s", dir, uuid.NewString())
+	incrPath := fmt.Sprintf("%s/%s", dir, uuid.NewString())
+
+	exporter := trans.NewExporter(suite.db, nil)
+	err := exporter.ExportFull(ctx, fullPath, "")
+	suite.NoError(err)
+	backup, _, _ := suite.readEntries(fullPath)
+
+	// Make sure we're past the end of the full export.
+	time.Sleep(2 * time.Millisecond)
+
+	// Update an old poll, which has
+	// no updated_at to show for it.
+	poll := testrig.NewTestPolls()["local_account_1_status_6_poll"]
+	poll.Votes = []int{2, 1, 0}
+	poll.Voters = util.Ptr(3)
+	poll.ClosedAt = time.Now()
+	err = suite.db.UpdatePoll(ctx, poll, "votes", "voters", "closed_at")
+	suite.NoError(err)
+
+	// And accept an old interaction request.
+	request := testrig.NewTestInteractionRequests()["admin_account_reply_turtle"]
+	request.AcceptedAt = time.Now()
+	request.URI = "http://localhost:8080/users/1happyturtle/accepts/" + request.ID
+	err = suite.db.UpdateInteractionRequest(ctx, request, "accepted_at", "uri")
+	suite.NoError(err)
+
+	err = exporter.ExportFull(ctx, incrPath, backup.Until)
+	suite.NoError(err)
+
+	// Restore the full export with the incremental one on top.
+	newDB, _ := suite.newDB()
+	importer := trans.NewImporter(newDB, nil)
+	suite.NoError(importer.Import(ctx, fullPath))
+	suite.NoError(importer.Import(ctx, incrPath))
+
+	// Both updates should have made it across.
+	pollAfter, err := newDB.GetPollByID(ctx, poll.ID)
+	suite.NoError(err)
+	suite.Equal(poll.Votes, pollAfter.Votes)
+	suite.Equal(*poll.Voters, *pollAfter.Voters)
+	suite.False(pollAfter.ClosedAt.IsZero())
+
+	requestAfter, err := newDB.GetInteractionRequestByID(ctx, request.ID)
+	suite.NoError(err)
+	suite.False(requestAfter.AcceptedAt.IsZero())
+	suite.Equal(request.URI, requestAfter.URI)
+}
+
+func (suite *ExportFullTestSuite) TestExportFullBadSince() {
+	path := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
+	err := trans.NewExporter(suite.db, nil).ExportFull(context.Background(), path, "yesterday")
+	suite.EqualError(err, "ExportFull: since yesterday is not a valid ULID: ulid: bad data size when unmarshaling")
+}
+
+func TestExportFullTestSuite(t *testing.T) {
+	suite.Run(t, &ExportFullTestSuite{})
+}
@@ -37,7 +37,7 @@ func (suite *ExportMinimalTestSuite) TestExportMinimalOK() {
 	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
 
 	// export to the tempFilePath
-	exporter := trans.NewExporter(suite.db)
+	exporter := trans.NewExporter(suite.db, nil)
 	err := exporter.ExportMinimal(context.Background(), tempFilePath)
 	suite.NoError(err)
 
@@ -18,6 +18,7 @@
 package trans
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -40,25 +41,87 @@ func (i *importer) Import(ctx context.Context, path string) error {
 		return fmt.Errorf("Import: couldn't export to %s: %s", path, err)
 	}
 
+	// Make sure we don't leave anything
+	// hanging if we bail out half way.
+	defer i.abortFull()
+
 	decoder := json.NewDecoder(file)
-	decoder.UseNumber()
 
 	for {
-		entry := transmodel.Entry{}
-		err := decoder.Decode(&entry)
+		line := json.RawMessage{}
+		err := decoder.Decode(&line)
 		if err != nil {
 			if err == io.EOF {
 				log.Infof(ctx, "reached end of file")
+				if err := i.finishFull(ctx); err != nil {
+					return fmt.Errorf("Import: error finishing import: %s", err)
+				}
 				return neatClose(file)
 			}
 			return fmt.Errorf("Import: error decoding in readLoop: %s", err)
 		}
-		if err := i.inputEntry(ctx, entry); err != nil {
+		if err := i.inputLine(ctx, line); err != nil {
 			return fmt.Errorf("Import: error inputting entry: %s", err)
 		}
 	}
 }
 
+// inputLine peeks at the type of the given line
+// and hands it off to the relevant input function.
+func (i *importer) inputLine(ctx context.Context, line json.RawMessage) error {
+	var head struct {
+		Type transmodel.Type `json:"type"`
+	}
+	if err := json.Unmarshal(line, &head); err != nil {
+		return fmt.Errorf("inputLine: error decoding entry type: %s", err)
+	}
+
+	switch head.Type {
+	case transmodel.TransBackup:
+		backup := &transmodel.Backup{}
+		if err := json.Unmarshal(line, backup); err != nil {
+			return fmt.Errorf("inputLine: error decoding entry into backup: %s", err)
+		}
+		log.Infof(ctx, "importing backup created at %s, covering changes since '%s' until %s",
+			backup.CreatedAt, backup.Since, backup.Until)
+		return nil
+	case transmodel.TransRow:
+		row := &transmodel.Row{}
+		if err := json.Unmarshal(line, row); err != nil {
+			return fmt.Errorf("inputLine: error decoding entry into row: %s", err)
+		}
+		return i.inputRow(ctx, row)
+	case transmodel.TransBlob:
+		blob := &transmodel.Blob{}
+		if err := json.Unmarshal(line, blob); err != nil {
+			return fmt.Errorf("inputLine: error decoding entry into blob: %s", err)
+		}
+		return i.inputBlob(ctx, blob)
+	case transmodel.TransKeys:
+		keys := &transmodel.Keys{}
+		if err := json.Unmarshal(line, keys); err != nil {
+			return fmt.Errorf("inputLine: error decoding entry into keys: %s", err)
+		}
+		return i.inputKeys(ctx, keys)
+	}
+
+	// Other entries are put via the db.DB
+	// interface, so commit any pending rows.
+	if err := i.commitRows(); err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(line))
+	decoder.UseNumber()
+
+	entry := transmodel.Entry{}
+	if err := decoder.Decode(&entry); err != nil {
+		return fmt.Errorf("inputLine: error decoding entry: %s", err)
+	}
+
+	return i.inputEntry(ctx, entry)
+}
+
 func (i *importer) inputEntry(ctx context.Context, entry transmodel.Entry) error {
 	t, ok := entry[transmodel.TypeKey].(string)
 	if !ok {
@@ -47,7 +47,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	tempFilePath := fmt.Sprintf("%s/%s", suite.T().TempDir(), uuid.NewString())
 
 	// export to the tempFilePath
-	exporter := trans.NewExporter(suite.db)
+	exporter := trans.NewExporter(suite.db, nil)
 	err = exporter.ExportMinimal(ctx, tempFilePath)
 	suite.NoError(err)
 
@@ -63,7 +63,7 @@ func (suite *ImportMinimalTestSuite) TestImportMinimalOK() {
 	// create a new database with just the tables created, no entries
 	newDB := testrig.NewTestDB(&state)
 
-	importer := trans.NewImporter(newDB)
+	importer := trans.NewImporter(newDB, nil)
 	err = importer.Import(ctx, tempFilePath)
 	suite.NoError(err)
 
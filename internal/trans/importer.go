@@ -19,8 +19,12 @@ package trans
 
 import (
 	"context"
+	"os"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
 // Importer wraps functionality for importing entries from a file into the database.
@@ -29,12 +33,27 @@ type Importer interface {
 }
 
 type importer struct {
-	db db.DB
+	db      db.DB
+	storage *storage.Driver
+
+	// State used while importing
+	// the rows + files of full exports.
+	tables   map[string]*schema.Table
+	tx       *bun.Tx
+	txRows   int
+	seqs     map[string]*schema.Table
+	keys     map[string]map[string]struct{}
+	blobKey  string
+	blobFile *os.File
 }
 
 // NewImporter returns a new Importer interface that uses the given db.
-func NewImporter(db db.DB) Importer {
+//
+// Storage is only needed for importing full exports
+// which contain media and emoji files, and may be nil.
+func NewImporter(db db.DB, storage *storage.Driver) Importer {
 	return &importer{
-		db: db,
+		db:      db,
+		storage: storage,
 	}
 }
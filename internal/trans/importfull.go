@@ -0,0 +1,327 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
+	transmodel "github.com/superseriousbusiness/gotosocial/internal/trans/model"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// rowsPerTx is the number of rows of a full
+// export to insert per database transaction.
+const rowsPerTx = 1000
+
+// inputRow decodes the given row into a model of
+// the table it belongs to, and inserts it into the
+// database, replacing any existing row with the same
+// primary key(s) so that incremental exports can
+// be imported on top of the ones that came before.
+func (i *importer) inputRow(ctx context.Context, row *transmodel.Row) error {
+	bdb, ok := i.db.(bunDB)
+	if !ok {
+		return errors.New("inputRow: database does not support full imports")
+	}
+	db := bdb.DB()
+
+	if i.tables == nil {
+		i.tables = fullTables(db)
+	}
+
+	table, ok := i.tables[row.Table]
+	if !ok {
+		log.Errorf(ctx, "didn't recognize table '%s', skipping it", row.Table)
+		return nil
+	}
+
+	v := reflect.New(table.Type)
+	for col, raw := range row.Columns {
+		field, ok := table.FieldMap[col]
+		if !ok {
+			log.Warnf(ctx, "table %s has no column %s, skipping it", row.Table, col)
+			continue
+		}
+
+		if err := json.Unmarshal(raw, field.Value(v.Elem()).Addr().Interface()); err != nil {
+			return fmt.Errorf("inputRow: error decoding %s column %s: %s", row.Table, col, err)
+		}
+	}
+
+	if i.tx == nil {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			return fmt.Errorf("inputRow: error beginning transaction: %s", err)
+		}
+		i.tx = &tx
+	}
+
+	if keys := keyFields(table); len(keys) > 0 {
+		q := i.tx.NewDelete().Model(v.Interface())
+		for _, key := range keys {
+			q = q.Where("? = ?", bun.Ident(key.Name), key.Value(v.Elem()).Interface())
+		}
+
+		if _, err := q.Exec(ctx); err != nil {
+			return fmt.Errorf("inputRow: error replacing %s row: %s", row.Table, err)
+		}
+	}
+
+	if _, err := i.tx.NewInsert().
+		Model(v.Interface()).
+		Exec(ctx); err != nil {
+		return fmt.Errorf("inputRow: error inserting %s row: %s", row.Table, err)
+	}
+
+	for _, pk := range table.PKs {
+		if pk.AutoIncrement {
+			if i.seqs == nil {
+				i.seqs = make(map[string]*schema.Table)
+			}
+			i.seqs[table.Name] = table
+		}
+	}
+
+	i.txRows++
+	if i.txRows >= rowsPerTx {
+		return i.commitRows()
+	}
+
+	return nil
+}
+
+// inputKeys gathers up the given keys of rows that still existed
+// when an incremental export was made, so that rows missing from
+// them can be deleted once all rows have been imported.
+func (i *importer) inputKeys(ctx context.Context, keys *transmodel.Keys) error {
+	bdb, ok := i.db.(bunDB)
+	if !ok {
+		return errors.New("inputKeys: database does not support full imports")
+	}
+
+	if i.tables == nil {
+		i.tables = fullTables(bdb.DB())
+	}
+
+	if _, ok := i.tables[keys.Table]; !ok {
+		log.Errorf(ctx, "didn't recognize table '%s', skipping it", keys.Table)
+		return nil
+	}
+
+	if i.keys == nil {
+		i.keys = make(map[string]map[string]struct{})
+	}
+
+	set, ok := i.keys[keys.Table]
+	if !ok {
+		set = make(map[string]struct{}, len(keys.Keys))
+		i.keys[keys.Table] = set
+	}
+
+	for _, key := range keys.Keys {
+		set[string(key)] = struct{}{}
+	}
+
+	return nil
+}
+
+// deleteRows deletes the rows of each table that keys were
+// imported for, which are missing from those keys, ie., the
+// rows deleted since the previous export was made.
+func (i *importer) deleteRows(ctx context.Context) error {
+	db := i.db.(bunDB).DB()
+
+	return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		for name, set := range i.keys {
+			table := i.tables[name]
+			fields := keyFields(table)
+
+			// Gather up the rows to delete
+			// before deleting any of them.
+			var deleted []reflect.Value
+			q := tx.NewSelect().Model(reflect.New(table.Type).Interface())
+			for _, key := range fields {
+				q = q.Column(key.Name)
+			}
+
+			rows, err := q.Rows(ctx)
+			if err != nil {
+				return fmt.Errorf("error selecting %s keys: %w", name, err)
+			}
+
+			for rows.Next() {
+				v := reflect.New(table.Type)
+				if err := db.ScanRow(ctx, rows, v.Interface()); err != nil {
+					rows.Close()
+					return fmt.Errorf("error scanning %s keys: %w", name, err)
+				}
+
+				key, err := rowKey(fields, v.Elem())
+				if err != nil {
+					rows.Close()
+					return fmt.Errorf("error encoding %s key: %w", name, err)
+				}
+
+				if _, ok := set[string(key)]; !ok {
+					deleted = append(deleted, v)
+				}
+			}
+
+			err = rows.Err()
+			rows.Close()
+			if err != nil {
+				return fmt.Errorf("error iterating %s keys: %w", name, err)
+			}
+
+			for _, v := range deleted {
+				q := tx.NewDelete().Model(v.Interface())
+				for _, key := range fields {
+					q = q.Where("? = ?", bun.Ident(key.Name), key.Value(v.Elem()).Interface())
+				}
+
+				if _, err := q.Exec(ctx); err != nil {
+					return fmt.Errorf("error deleting %s row: %w", name, err)
+				}
+			}
+
+			if len(deleted) > 0 {
+				log.Infof(ctx, "deleted %d %s rows", len(deleted), name)
+			}
+		}
+
+		return nil
+	})
+}
+
+// commitRows commits the transaction
+// of pending inserted rows, if any.
+func (i *importer) commitRows() error {
+	if i.tx == nil {
+		return nil
+	}
+
+	tx := i.tx
+	i.tx = nil
+	i.txRows = 0
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commitRows: error committing rows: %s", err)
+	}
+
+	return nil
+}
+
+// inputBlob writes the given chunk of a stored file to a temporary
+// file, and puts that file in storage once its final chunk arrives.
+func (i *importer) inputBlob(ctx context.Context, blob *transmodel.Blob) error {
+	if i.storage == nil {
+		return errors.New("inputBlob: import contains files, but no storage was provided")
+	}
+
+	if i.blobFile == nil {
+		f, err := os.CreateTemp("", "gotosocial-import-*")
+		if err != nil {
+			return fmt.Errorf("inputBlob: error creating temporary file: %s", err)
+		}
+		i.blobFile = f
+		i.blobKey = blob.Key
+	} else if i.blobKey != blob.Key {
+		return fmt.Errorf("inputBlob: file %s was cut off by file %s", i.blobKey, blob.Key)
+	}
+
+	if _, err := i.blobFile.Write(blob.Data); err != nil {
+		return fmt.Errorf("inputBlob: error writing temporary file: %s", err)
+	}
+
+	if !blob.Final {
+		// More to come.
+		return nil
+	}
+
+	path := i.blobFile.Name()
+	defer os.Remove(path)
+
+	err := neatClose(i.blobFile)
+	i.blobFile = nil
+	if err != nil {
+		return fmt.Errorf("inputBlob: %s", err)
+	}
+
+	if _, err := i.storage.PutFile(ctx, blob.Key, path); err != nil && !storage.IsAlreadyExist(err) {
+		return fmt.Errorf("inputBlob: error putting file %s in storage: %s", blob.Key, err)
+	}
+
+	log.Infof(ctx, "added file %s", blob.Key)
+	return nil
+}
+
+// finishFull commits any pending rows once the end of the import has
+// been reached, deletes rows that were deleted since the previous
+// export, and brings sequences in line with imported rows.
+func (i *importer) finishFull(ctx context.Context) error {
+	if i.blobFile != nil {
+		return fmt.Errorf("finishFull: file %s was cut off before its final chunk", i.blobKey)
+	}
+
+	if err := i.commitRows(); err != nil {
+		return err
+	}
+
+	if len(i.keys) > 0 {
+		err := i.deleteRows(ctx)
+		i.keys = nil
+		if err != nil {
+			return fmt.Errorf("finishFull: %s", err)
+		}
+	}
+
+	if len(i.seqs) == 0 {
+		return nil
+	}
+
+	if err := resetSequences(ctx, i.db.(bunDB).DB(), i.seqs); err != nil {
+		return fmt.Errorf("finishFull: %s", err)
+	}
+
+	return nil
+}
+
+// abortFull rolls back any pending rows and removes any
+// temporary file; it's a no-op after a successful finishFull.
+func (i *importer) abortFull() {
+	if i.tx != nil {
+		_ = i.tx.Rollback()
+		i.tx = nil
+	}
+
+	if i.blobFile != nil {
+		_ = i.blobFile.Close()
+		_ = os.Remove(i.blobFile.Name())
+		i.blobFile = nil
+	}
+
+	i.keys = nil
+}
@@ -0,0 +1,439 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
+)
+
+// Migrator wraps functionality for copying the
+// contents of one database into another, eg.,
+// when moving from SQLite to Postgres.
+type Migrator interface {
+	// Migrate copies every table of the source database into
+	// the target database, batch by batch in ID order, then
+	// verifies that row counts and checksums match.
+	//
+	// Batches that already match in the target are skipped,
+	// so Migrate can be run again to resume after interruption,
+	// or to catch up on changes made to the source since.
+	Migrate(ctx context.Context) error
+}
+
+type migrator struct {
+	source    db.DB
+	target    db.DB
+	batchSize int
+}
+
+// NewMigrator returns a new Migrator that will copy
+// from source to target, batchSize rows at a time.
+func NewMigrator(source db.DB, target db.DB, batchSize int) Migrator {
+	return &migrator{
+		source:    source,
+		target:    target,
+		batchSize: batchSize,
+	}
+}
+
+func (m *migrator) Migrate(ctx context.Context) error {
+	source, ok := m.source.(bunDB)
+	if !ok {
+		return errors.New("Migrate: source database does not support migration")
+	}
+
+	target, ok := m.target.(bunDB)
+	if !ok {
+		return errors.New("Migrate: target database does not support migration")
+	}
+
+	if m.batchSize < 1 {
+		return fmt.Errorf("Migrate: invalid batch size %d", m.batchSize)
+	}
+
+	var (
+		sourceDB = source.DB()
+		targetDB = target.DB()
+		tables   = make(map[string]*schema.Table, len(fullModels))
+	)
+
+	for i, model := range fullModels {
+		table := sourceDB.Dialect().Tables().Get(reflect.TypeOf(model).Elem())
+		tables[table.Name] = table
+
+		log.Infof(ctx, "migrating table %s (%d/%d)", table.Name, i+1, len(fullModels))
+
+		var err error
+		if len(table.PKs) == 1 {
+			err = m.migrateByKey(ctx, sourceDB, targetDB, table, table.PKs[0])
+		} else {
+			err = m.migrateWhole(ctx, sourceDB, targetDB, table)
+		}
+
+		if err != nil {
+			return fmt.Errorf("Migrate: error migrating table %s: %w", table.Name, err)
+		}
+	}
+
+	if err := resetSequences(ctx, targetDB, tables); err != nil {
+		return fmt.Errorf("Migrate: %w", err)
+	}
+
+	// Now everything's been copied, check
+	// nothing has changed underneath us.
+	var mismatched []string
+	for _, model := range fullModels {
+		table := sourceDB.Dialect().Tables().Get(reflect.TypeOf(model).Elem())
+
+		sourceCount, sourceSum, err := tableChecksum(ctx, sourceDB, table)
+		if err != nil {
+			return fmt.Errorf("Migrate: error checksumming source table %s: %w", table.Name, err)
+		}
+
+		targetCount, targetSum, err := tableChecksum(ctx, targetDB, table)
+		if err != nil {
+			return fmt.Errorf("Migrate: error checksumming target table %s: %w", table.Name, err)
+		}
+
+		if sourceCount != targetCount || sourceSum != targetSum {
+			log.Warnf(ctx, "table %s doesn't match: source has %d rows, target has %d", table.Name, sourceCount, targetCount)
+			mismatched = append(mismatched, table.Name)
+			continue
+		}
+
+		log.Infof(ctx, "verified table %s: %d rows", table.Name, targetCount)
+	}
+
+	if len(mismatched) > 0 {
+		return fmt.Errorf(
+			"Migrate: tables changed during migration, stop GoToSocial and run again to catch up: %s",
+			strings.Join(mismatched, ", "),
+		)
+	}
+
+	return nil
+}
+
+// migrateByKey copies the given table, which has a single primary key, in
+// batches ordered by that key. For each batch, rows in the same key range
+// are selected from the target, and the range is only replaced if it
+// doesn't match, so that unchanged batches are cheap to resume over.
+func (m *migrator) migrateByKey(
+	ctx context.Context,
+	source *bun.DB,
+	target *bun.DB,
+	table *schema.Table,
+	pk *schema.Field,
+) error {
+	total, err := source.NewSelect().Model(newModel(table)).Count(ctx)
+	if err != nil {
+		return fmt.Errorf("error counting rows: %w", err)
+	}
+
+	var (
+		// Key range of current batch; nil
+		// means unbounded in that direction.
+		lower interface{}
+		upper interface{}
+
+		checked int
+		copied  int
+	)
+
+	for {
+		rows := newModels(table)
+		q := source.NewSelect().
+			Model(rows.Interface()).
+			Order(pk.Name).
+			Limit(m.batchSize)
+		q = whereKeyRange(q, pk, lower, nil)
+		if err := q.Scan(ctx); err != nil {
+			return fmt.Errorf("error selecting source rows: %w", err)
+		}
+
+		n := rows.Elem().Len()
+		final := n < m.batchSize
+
+		upper = nil
+		if !final {
+			// Take the range up to the last row of this batch.
+			// The final batch takes everything after lower, so
+			// stray rows at the end of the target are caught.
+			upper = pk.Value(rows.Elem().Index(n - 1).Elem()).Interface()
+		}
+
+		existing := newModels(table)
+		q = target.NewSelect().Model(existing.Interface())
+		q = whereKeyRange(q, pk, lower, upper)
+		if err := q.Scan(ctx); err != nil {
+			return fmt.Errorf("error selecting target rows: %w", err)
+		}
+
+		if rowsChecksum(table, rows.Elem()) != rowsChecksum(table, existing.Elem()) {
+			if err := target.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+				q := tx.NewDelete().Model(newModel(table))
+				q = whereKeyRange(q, pk, lower, upper)
+				if lower == nil && upper == nil {
+					// Deletes need a where clause.
+					q = q.Where("1 = 1")
+				}
+				if _, err := q.Exec(ctx); err != nil {
+					return err
+				}
+
+				if n == 0 {
+					return nil
+				}
+
+				return insertRows(ctx, tx, table, rows)
+			}); err != nil {
+				return fmt.Errorf("error copying rows: %w", err)
+			}
+
+			copied += n
+		}
+
+		checked += n
+		log.Infof(ctx, "table %s: checked %d/%d rows, copied %d", table.Name, checked, total, copied)
+
+		if final {
+			return nil
+		}
+
+		lower = upper
+	}
+}
+
+// migrateWhole copies the given table, which lacks a single primary
+// key (eg., join tables), in one go if its checksum doesn't match.
+func (m *migrator) migrateWhole(
+	ctx context.Context,
+	source *bun.DB,
+	target *bun.DB,
+	table *schema.Table,
+) error {
+	sourceCount, sourceSum, err := tableChecksum(ctx, source, table)
+	if err != nil {
+		return fmt.Errorf("error checksumming source: %w", err)
+	}
+
+	_, targetSum, err := tableChecksum(ctx, target, table)
+	if err != nil {
+		return fmt.Errorf("error checksumming target: %w", err)
+	}
+
+	if sourceSum == targetSum {
+		log.Infof(ctx, "table %s: %d rows already copied", table.Name, sourceCount)
+		return nil
+	}
+
+	rows, err := source.NewSelect().Model(newModel(table)).Rows(ctx)
+	if err != nil {
+		return fmt.Errorf("error selecting source rows: %w", err)
+	}
+	defer rows.Close()
+
+	return target.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		if _, err := tx.NewDelete().
+			Model(newModel(table)).
+			Where("1 = 1").
+			Exec(ctx); err != nil {
+			return fmt.Errorf("error clearing target: %w", err)
+		}
+
+		var (
+			batch  = newModels(table)
+			copied int
+		)
+
+		flush := func() error {
+			n := batch.Elem().Len()
+			if n == 0 {
+				return nil
+			}
+
+			if err := insertRows(ctx, tx, table, batch); err != nil {
+				return fmt.Errorf("error copying rows: %w", err)
+			}
+
+			copied += n
+			log.Infof(ctx, "table %s: copied %d/%d rows", table.Name, copied, sourceCount)
+			batch = newModels(table)
+			return nil
+		}
+
+		for rows.Next() {
+			v := reflect.New(table.Type)
+			if err := source.ScanRow(ctx, rows, v.Interface()); err != nil {
+				return fmt.Errorf("error scanning source row: %w", err)
+			}
+
+			batch.Elem().Set(reflect.Append(batch.Elem(), v))
+			if batch.Elem().Len() >= m.batchSize {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error iterating source rows: %w", err)
+		}
+
+		return flush()
+	})
+}
+
+// insertRows inserts the given pointer to a slice of
+// models of the given table. Columns are named explicitly,
+// as otherwise bun leaves out columns with a default when
+// the first row has a zero value, losing them for all rows.
+func insertRows(ctx context.Context, tx bun.Tx, table *schema.Table, rows reflect.Value) error {
+	columns := make([]string, len(table.Fields))
+	for i, f := range table.Fields {
+		columns[i] = f.Name
+	}
+
+	_, err := tx.NewInsert().
+		Model(rows.Interface()).
+		Column(columns...).
+		Exec(ctx)
+	return err
+}
+
+// newModel returns a new pointer
+// to a model of the given table.
+func newModel(table *schema.Table) interface{} {
+	return reflect.New(table.Type).Interface()
+}
+
+// newModels returns a new pointer to an
+// empty slice of models of the given table.
+func newModels(table *schema.Table) reflect.Value {
+	return reflect.New(reflect.SliceOf(reflect.PointerTo(table.Type)))
+}
+
+// whereKeyRange limits q to rows with key
+// within (lower, upper], either of which
+// may be nil to leave that end unbounded.
+func whereKeyRange[Q interface {
+	Where(string, ...interface{}) Q
+}](q Q, key *schema.Field, lower interface{}, upper interface{}) Q {
+	if lower != nil {
+		q = q.Where("? > ?", bun.Ident(key.Name), lower)
+	}
+	if upper != nil {
+		q = q.Where("? <= ?", bun.Ident(key.Name), upper)
+	}
+	return q
+}
+
+// checksum is an order-independent checksum of a
+// set of rows, so that differences in collation
+// between databases don't change the result.
+type checksum [4]uint64
+
+// add adds the hash of the given row to the checksum.
+func (c *checksum) add(table *schema.Table, v reflect.Value) {
+	h := sha256.New()
+	for _, field := range table.Fields {
+		b, _ := json.Marshal(normalize(field.Value(v)))
+		h.Write([]byte(field.Name))
+		h.Write(b)
+	}
+
+	sum := h.Sum(nil)
+	for i := range c {
+		c[i] += binary.BigEndian.Uint64(sum[i*8:])
+	}
+}
+
+// normalize returns the value of the given field in a form
+// that's the same no matter which database it came from.
+func normalize(v reflect.Value) interface{} {
+	switch t := v.Interface().(type) {
+	case time.Time:
+		// Postgres only stores microseconds,
+		// and may return times in local zone.
+		return t.UTC().Truncate(time.Microsecond)
+	case *time.Time:
+		if t != nil {
+			return t.UTC().Truncate(time.Microsecond)
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		// Empty arrays and JSON
+		// may come back as null.
+		if v.Len() == 0 {
+			return nil
+		}
+	}
+
+	return v.Interface()
+}
+
+// rowsChecksum returns the checksum of the
+// given slice of pointers to table models.
+func rowsChecksum(table *schema.Table, rows reflect.Value) checksum {
+	var c checksum
+	for i := 0; i < rows.Len(); i++ {
+		c.add(table, rows.Index(i).Elem())
+	}
+	return c
+}
+
+// tableChecksum returns the number of rows
+// in the given table, and their checksum.
+func tableChecksum(ctx context.Context, db *bun.DB, table *schema.Table) (int, checksum, error) {
+	var (
+		c     checksum
+		count int
+	)
+
+	rows, err := db.NewSelect().Model(newModel(table)).Rows(ctx)
+	if err != nil {
+		return 0, c, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		v := reflect.New(table.Type)
+		if err := db.ScanRow(ctx, rows, v.Interface()); err != nil {
+			return 0, c, err
+		}
+		c.add(table, v.Elem())
+		count++
+	}
+
+	return count, c, rows.Err()
+}
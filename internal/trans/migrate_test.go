@@ -0,0 +1,148 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/db/bundb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/trans"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type MigrateTestSuite struct {
+	TransTestSuite
+	target db.DB
+}
+
+func (suite *MigrateTestSuite) SetupTest() {
+	suite.TransTestSuite.SetupTest()
+
+	var state state.State
+	state.Caches.Init()
+	suite.target = testrig.NewTestDB(&state)
+}
+
+func (suite *MigrateTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.target)
+	suite.TransTestSuite.TearDownTest()
+}
+
+func (suite *MigrateTestSuite) count(db db.DB, model interface{}) int {
+	count, err := db.(*bundb.DBService).DB().
+		NewSelect().
+		Model(model).
+		Count(context.Background())
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	return count
+}
+
+func (suite *MigrateTestSuite) checkCounts() {
+	for _, model := range []interface{}{
+		(*gtsmodel.Account)(nil),
+		(*gtsmodel.AccountToEmoji)(nil),
+		(*gtsmodel.Emoji)(nil),
+		(*gtsmodel.Follow)(nil),
+		(*gtsmodel.MediaAttachment)(nil),
+		(*gtsmodel.Notification)(nil),
+		(*gtsmodel.Status)(nil),
+		(*gtsmodel.StatusToTag)(nil),
+		(*gtsmodel.User)(nil),
+	} {
+		suite.Equal(suite.count(suite.db, model), suite.count(suite.target, model), "%T", model)
+	}
+}
+
+func (suite *MigrateTestSuite) TestMigrate() {
+	ctx := context.Background()
+
+	// Small batches to make sure we
+	// work across batch boundaries.
+	err := trans.NewMigrator(suite.db, suite.target, 5).Migrate(ctx)
+	suite.NoError(err)
+	suite.checkCounts()
+
+	statusID := testrig.NewTestStatuses()["local_account_1_status_1"].ID
+	statusBefore, err := suite.db.GetStatusByID(ctx, statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	statusAfter, err := suite.target.GetStatusByID(ctx, statusID)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(statusBefore.URI, statusAfter.URI)
+	suite.Equal(statusBefore.Content, statusAfter.Content)
+	suite.Equal(statusBefore.MentionIDs, statusAfter.MentionIDs)
+	suite.Equal(statusBefore.InteractionPolicy, statusAfter.InteractionPolicy)
+}
+
+func (suite *MigrateTestSuite) TestMigrateResume() {
+	ctx := context.Background()
+
+	err := trans.NewMigrator(suite.db, suite.target, 5).Migrate(ctx)
+	suite.NoError(err)
+
+	// Simulate an interrupted migration by
+	// removing a status from the target, and
+	// make some changes to the source meanwhile.
+	testStatuses := testrig.NewTestStatuses()
+	err = suite.target.DeleteStatusByID(ctx, testStatuses["admin_account_status_1"].ID)
+	suite.NoError(err)
+
+	err = suite.db.DeleteStatusByID(ctx, testStatuses["local_account_2_status_1"].ID)
+	suite.NoError(err)
+
+	account := suite.testAccounts["local_account_1"]
+	account.Note = "i was changed during the migration"
+	account.UpdatedAt = time.Now()
+	err = suite.db.UpdateAccount(ctx, account, "note", "updated_at")
+	suite.NoError(err)
+
+	// Running again should catch the target up.
+	err = trans.NewMigrator(suite.db, suite.target, 5).Migrate(ctx)
+	suite.NoError(err)
+	suite.checkCounts()
+
+	_, err = suite.target.GetStatusByID(ctx, testStatuses["admin_account_status_1"].ID)
+	suite.NoError(err)
+
+	_, err = suite.target.GetStatusByID(ctx, testStatuses["local_account_2_status_1"].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	accountAfter, err := suite.target.GetAccountByID(ctx, account.ID)
+	suite.NoError(err)
+	suite.Equal(account.Note, accountAfter.Note)
+}
+
+func (suite *MigrateTestSuite) TestMigrateBadBatchSize() {
+	err := trans.NewMigrator(suite.db, suite.target, 0).Migrate(context.Background())
+	suite.EqualError(err, "Migrate: invalid batch size 0")
+}
+
+func TestMigrateTestSuite(t *testing.T) {
+	suite.Run(t, &MigrateTestSuite{})
+}
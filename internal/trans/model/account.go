@@ -34,6 +34,7 @@ type Account struct {
 	DisplayName           string          `json:"displayName,omitempty" bun:",nullzero"`
 	Note                  string          `json:"note,omitempty" bun:",nullzero"`
 	NoteRaw               string          `json:"noteRaw,omitempty" bun:",nullzero"`
+	NotePlain             string          `json:"-" bun:",nullzero"`
 	Memorial              *bool           `json:"memorial"`
 	Bot                   *bool           `json:"bot"`
 	Locked                *bool           `json:"locked"`
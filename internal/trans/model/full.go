@@ -0,0 +1,63 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Backup is the first entry written to a full export,
+// describing which slice of the database it covers.
+type Backup struct {
+	Type      Type      `json:"type"`
+	CreatedAt time.Time `json:"createdAt"`
+	Since     string    `json:"since,omitempty"` // Rows created or updated after this ULID are included, or everything if empty.
+	Until     string    `json:"until"`           // Pass this ULID as 'since' to continue incrementally from this export.
+}
+
+// Row represents one row of any database table as serialized
+// in a full export, with each column value encoded as JSON.
+type Row struct {
+	Type    Type                       `json:"type"`
+	Table   string                     `json:"table"`
+	Columns map[string]json.RawMessage `json:"columns"`
+}
+
+// Keys lists the keys of rows of one table that still existed
+// when an incremental export was made, each encoded as a JSON
+// array of the values of the table's key columns. Rows missing
+// from the list were deleted since the previous export, and so
+// are deleted on import. Large tables are split over several
+// consecutive entries.
+type Keys struct {
+	Type  Type              `json:"type"`
+	Table string            `json:"table"`
+	Keys  []json.RawMessage `json:"keys"`
+}
+
+// Blob represents one chunk of a file from storage as
+// serialized in a full export. Large files are split
+// over several consecutive chunks, the last of which
+// has Final set.
+type Blob struct {
+	Type  Type   `json:"type"`
+	Key   string `json:"key"`
+	Data  []byte `json:"data"`
+	Final bool   `json:"final"`
+}
@@ -26,12 +26,16 @@ type Type string
 // Type of the trans entry. Describes how it should be read from file.
 const (
 	TransAccount          Type = "account"
+	TransBackup           Type = "backup"
+	TransBlob             Type = "blob"
 	TransBlock            Type = "block"
 	TransDomainBlock      Type = "domainBlock"
 	TransEmailDomainBlock Type = "emailDomainBlock"
 	TransFollow           Type = "follow"
 	TransFollowRequest    Type = "followRequest"
 	TransInstance         Type = "instance"
+	TransKeys             Type = "keys"
+	TransRow              Type = "row"
 	TransUser             Type = "user"
 )
 
@@ -0,0 +1,202 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/dialect"
+	"github.com/uptrace/bun/schema"
+)
+
+// fullModels contains every gtsmodel type that is persisted
+// in its own database table, and so is covered by full exports.
+//
+// Full text search tables are not included, since they're
+// maintained by triggers on the statuses + accounts tables.
+var fullModels = []interface{}{
+	&gtsmodel.Account{},
+	&gtsmodel.AccountNote{},
+	&gtsmodel.AccountSettings{},
+	&gtsmodel.AccountStats{},
+	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.AdminAction{},
+	&gtsmodel.AdvancedMigration{},
+	&gtsmodel.Application{},
+	&gtsmodel.Archive{},
+	&gtsmodel.AuditLogEntry{},
+	&gtsmodel.Block{},
+	&gtsmodel.Client{},
+	&gtsmodel.Conversation{},
+	&gtsmodel.ConversationToStatus{},
+	&gtsmodel.DeniedUser{},
+	&gtsmodel.DomainAllow{},
+	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainDeliveryState{},
+	&gtsmodel.DomainPermissionDraft{},
+	&gtsmodel.DomainPermissionSubscription{},
+	&gtsmodel.EmailDomainBlock{},
+	&gtsmodel.Emoji{},
+	&gtsmodel.EmojiCategory{},
+	&gtsmodel.Filter{},
+	&gtsmodel.FilterKeyword{},
+	&gtsmodel.FilterStatus{},
+	&gtsmodel.Follow{},
+	&gtsmodel.FollowedTag{},
+	&gtsmodel.FollowRequest{},
+	&gtsmodel.GroupModerator{},
+	&gtsmodel.HeaderFilterAllow{},
+	&gtsmodel.HeaderFilterBlock{},
+	&gtsmodel.Import{},
+	&gtsmodel.Instance{},
+	&gtsmodel.InteractionRequest{},
+	&gtsmodel.List{},
+	&gtsmodel.ListEntry{},
+	&gtsmodel.Marker{},
+	&gtsmodel.MediaAttachment{},
+	&gtsmodel.MediaHashBlock{},
+	&gtsmodel.Mention{},
+	&gtsmodel.Move{},
+	&gtsmodel.Notification{},
+	&gtsmodel.PendingDelivery{},
+	&gtsmodel.Poll{},
+	&gtsmodel.PollVote{},
+	&gtsmodel.Relay{},
+	&gtsmodel.Report{},
+	&gtsmodel.ReportNote{},
+	&gtsmodel.RouterSession{},
+	&gtsmodel.Rule{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.Status{},
+	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
+	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusToEmoji{},
+	&gtsmodel.StatusToTag{},
+	&gtsmodel.Tag{},
+	&gtsmodel.Thread{},
+	&gtsmodel.ThreadMute{},
+	&gtsmodel.ThreadToStatus{},
+	&gtsmodel.Token{},
+	&gtsmodel.Tombstone{},
+	&gtsmodel.Trend{},
+	&gtsmodel.TrendLink{},
+	&gtsmodel.User{},
+	&gtsmodel.UserMute{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
+	&gtsmodel.WorkerTask{},
+}
+
+// wholeTables are the tables whose rows can change in place
+// without an updated_at column to show for it, eg., poll vote
+// counts, or interaction requests being accepted. These are
+// always exported in full, even by incremental exports.
+var wholeTables = []string{
+	"archives",
+	"interaction_requests",
+	"pending_deliveries",
+	"polls",
+}
+
+// bunDB is implemented by db.DB implementations
+// backed by bun, which full exports + imports need
+// in order to work with tables generically.
+type bunDB interface {
+	DB() *bun.DB
+}
+
+// fullTables returns the bun table of
+// each of fullModels, keyed by table name.
+func fullTables(db *bun.DB) map[string]*schema.Table {
+	tables := make(map[string]*schema.Table, len(fullModels))
+	for _, m := range fullModels {
+		table := db.Dialect().Tables().Get(reflect.TypeOf(m).Elem())
+		tables[table.Name] = table
+	}
+	return tables
+}
+
+// keyFields returns the fields that identify a row of the given table:
+// its primary key(s), or for join tables without primary keys, the
+// fields of its unique constraint.
+func keyFields(table *schema.Table) []*schema.Field {
+	if len(table.PKs) > 0 {
+		return table.PKs
+	}
+
+	names := make([]string, 0, len(table.Unique))
+	for name := range table.Unique {
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+
+	// Pick consistently if there's
+	// more than one to choose from.
+	slices.Sort(names)
+	return table.Unique[names[0]]
+}
+
+// rowKey returns the values of the given key fields of the
+// given row as a JSON array, identifying it within its table.
+func rowKey(keys []*schema.Field, v reflect.Value) (json.RawMessage, error) {
+	values := make([]interface{}, len(keys))
+	for i, key := range keys {
+		values[i] = key.Value(v).Interface()
+	}
+	return json.Marshal(values)
+}
+
+// resetSequences brings the sequences of any autoincrement
+// primary keys of the given tables in line with their rows.
+//
+// Postgres doesn't advance sequences for explicitly inserted
+// values, so this must be called after copying such rows in.
+// SQLite picks the next value based on the table, so for it
+// this is a no-op.
+func resetSequences(ctx context.Context, db *bun.DB, tables map[string]*schema.Table) error {
+	if db.Dialect().Name() != dialect.PG {
+		return nil
+	}
+
+	for name, table := range tables {
+		for _, pk := range table.PKs {
+			if !pk.AutoIncrement {
+				continue
+			}
+
+			if _, err := db.ExecContext(ctx,
+				"SELECT setval(pg_get_serial_sequence(?, ?), (SELECT COALESCE(MAX(?), 0) + 1 FROM ?), false)",
+				name, pk.Name, bun.Ident(pk.Name), bun.Ident(name),
+			); err != nil {
+				return fmt.Errorf("error resetting sequence of %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
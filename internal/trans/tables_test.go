@@ -0,0 +1,69 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package trans
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+	"github.com/uptrace/bun/dialect"
+)
+
+// TestFullModelsCoverAllTables makes sure nobody adds a
+// new table without also adding it to full exports.
+func TestFullModelsCoverAllTables(t *testing.T) {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	var state state.State
+	db := testrig.NewTestDB(&state).(bunDB).DB()
+	defer db.Close()
+
+	var query string
+	switch db.Dialect().Name() {
+	case dialect.SQLite:
+		query = "SELECT name FROM sqlite_master WHERE type = 'table'"
+	case dialect.PG:
+		query = "SELECT tablename FROM pg_tables WHERE schemaname = current_schema()"
+	default:
+		t.Skipf("unsupported dialect %s", db.Dialect().Name())
+	}
+
+	var names []string
+	if err := db.NewRaw(query).Scan(context.Background(), &names); err != nil {
+		t.Fatal(err)
+	}
+
+	tables := fullTables(db)
+	for _, name := range names {
+		if strings.HasPrefix(name, "bun_") ||
+			strings.HasPrefix(name, "sqlite_") ||
+			strings.Contains(name, "_fts") {
+			// Migrations, internal, and
+			// trigger-maintained tables.
+			continue
+		}
+
+		if _, ok := tables[name]; !ok {
+			t.Errorf("table %s is not covered by full exports", name)
+		}
+	}
+}
@@ -22,12 +22,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/storage"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type TransTestSuite struct {
 	suite.Suite
 	db           db.DB
+	storage      *storage.Driver
 	testAccounts map[string]*gtsmodel.Account
 }
 
@@ -42,8 +44,12 @@ func (suite *TransTestSuite) SetupTest() {
 
 	suite.db = testrig.NewTestDB(&state)
 	testrig.StandardDBSetup(suite.db, nil)
+
+	suite.storage = testrig.NewInMemoryStorage()
+	testrig.StandardStorageSetup(suite.storage, "../../testrig/media")
 }
 
 func (suite *TransTestSuite) TearDownTest() {
 	testrig.StandardDBTeardown(suite.db)
+	testrig.StandardStorageTeardown(suite.storage)
 }
@@ -36,16 +36,25 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 )
 
 // Controller generates transports for use in making federation requests to other servers.
 type Controller interface {
-	// NewTransport returns an http signature transport with the given public key ID (URL location of pubkey), and the given private key.
-	NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Transport, error)
+	// NewTransport returns an http signature transport signing with the keys of the given local account.
+	NewTransport(account *gtsmodel.Account) (Transport, error)
 
 	// NewTransportForUsername searches for account with username, and returns result of .NewTransport().
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+
+	// SetSignatureScheme records the http signature scheme
+	// accepted by the instance at given host, so that it
+	// will be used when signing subsequent requests to it.
+	SetSignatureScheme(ctx context.Context, host string, scheme gtsmodel.SignatureScheme) error
 }
 
 type controller struct {
@@ -55,6 +64,11 @@ type controller struct {
 	client    pub.HttpClient
 	trspCache cache.TTLCache[string, *transport]
 	userAgent string
+
+	// schemes caches the http signature scheme
+	// negotiated with each host, including hosts
+	// we don't (yet) have an instance entry for.
+	schemes cache.TTLCache[string, gtsmodel.SignatureScheme]
 }
 
 // NewController returns an implementation of the Controller interface for creating new transports
@@ -72,19 +86,20 @@ func NewController(state *state.State, federatingDB federatingdb.DB, clock pub.C
 		client:    client,
 		trspCache: cache.NewTTL[string, *transport](0, 100, 0),
 		userAgent: fmt.Sprintf("gotosocial/%s (+%s://%s)", version, proto, host),
+		schemes:   cache.NewTTL[string, gtsmodel.SignatureScheme](0, 1000, 0),
 	}
 
 	return c
 }
 
-func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Transport, error) {
+func (c *controller) NewTransport(account *gtsmodel.Account) (Transport, error) {
 	// Generate public key string for cache key
 	//
 	// NOTE: it is safe to use the public key as the cache
 	// key here as we are generating it ourselves from the
 	// private key. If we were simply using a public key
 	// provided as argument that would absolutely NOT be safe.
-	pubStr := privkeyToPublicStr(privkey)
+	pubStr := privkeyToPublicStr(account.PrivateKey)
 
 	// First check for cached transport
 	transp, ok := c.trspCache.Get(pubStr)
@@ -94,9 +109,11 @@ func (c *controller) NewTransport(pubKeyID string, privkey *rsa.PrivateKey) (Tra
 
 	// Create the transport
 	transp = &transport{
-		controller: c,
-		pubKeyID:   pubKeyID,
-		privkey:    privkey,
+		controller:   c,
+		pubKeyID:     account.PublicKeyURI,
+		privkey:      account.PrivateKey,
+		ed25519KeyID: account.Ed25519PublicKeyURI,
+		ed25519Key:   account.Ed25519PrivateKey,
 	}
 
 	// Cache this transport under pubkey
@@ -132,7 +149,7 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 		return nil, fmt.Errorf("error getting account %s from db: %s", username, err)
 	}
 
-	transport, err := c.NewTransport(ourAccount.PublicKeyURI, ourAccount.PrivateKey)
+	transport, err := c.NewTransport(ourAccount)
 	if err != nil {
 		return nil, fmt.Errorf("error creating transport for user %s: %s", username, err)
 	}
@@ -140,6 +157,58 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 	return transport, nil
 }
 
+func (c *controller) SetSignatureScheme(ctx context.Context, host string, scheme gtsmodel.SignatureScheme) error {
+	// Always keep the scheme in memory, so it's
+	// used even for hosts without an instance entry.
+	c.schemes.Set(host, scheme)
+
+	instance, err := c.state.DB.GetInstance(gtscontext.SetBarebones(ctx), host)
+	if err != nil {
+		if errors.Is(err, db.ErrNoEntries) {
+			// Instance not yet known to us,
+			// nothing to persist it on.
+			return nil
+		}
+		return gtserror.Newf("error getting instance %s: %w", host, err)
+	}
+
+	if instance.SignatureScheme == scheme {
+		// Nothing changed.
+		return nil
+	}
+
+	instance.SignatureScheme = scheme
+	if err := c.state.DB.UpdateInstance(ctx, instance, "signature_scheme"); err != nil {
+		return gtserror.Newf("error updating instance %s: %w", host, err)
+	}
+
+	return nil
+}
+
+// signatureScheme returns the http signature scheme
+// known to be accepted by the instance at given host,
+// or SignatureSchemeUnknown if this isn't known (yet).
+func (c *controller) signatureScheme(ctx context.Context, host string) gtsmodel.SignatureScheme {
+	if scheme, ok := c.schemes.Get(host); ok {
+		return scheme
+	}
+
+	instance, err := c.state.DB.GetInstance(gtscontext.SetBarebones(ctx), host)
+	if err != nil {
+		if !errors.Is(err, db.ErrNoEntries) {
+			log.Errorf(ctx, "error getting instance %s: %v", host, err)
+		}
+		return gtsmodel.SignatureSchemeUnknown
+	}
+
+	if instance.SignatureScheme != gtsmodel.SignatureSchemeUnknown {
+		// Save looking it up next time.
+		c.schemes.Set(host, instance.SignatureScheme)
+	}
+
+	return instance.SignatureScheme
+}
+
 // dereferenceLocalFollowers is a shortcut to dereference followers of an
 // account on this instance, without making any external api/http calls.
 //
@@ -81,7 +81,7 @@ func (t *transport) BatchDeliver(ctx context.Context, obj map[string]interface{}
 	}
 
 	// Push prepared request list to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(reqs...)
+	t.controller.state.Workers.Delivery.Push(ctx, reqs...)
 
 	// Return combined err.
 	return errs.Combine()
@@ -112,7 +112,7 @@ func (t *transport) Deliver(ctx context.Context, obj map[string]interface{}, to
 	}
 
 	// Push prepared request to the delivery queue.
-	t.controller.state.Workers.Delivery.Queue.Push(req)
+	t.controller.state.Workers.Delivery.Push(ctx, req)
 
 	return nil
 }
@@ -131,8 +131,8 @@ func (t *transport) prepare(
 	*delivery.Delivery,
 	error,
 ) {
-	// Prepare POST signer.
-	sign := t.signPOST(data)
+	// Prepare POST signer for host.
+	sign := t.signPOSTFor(ctx, to.Host, data)
 
 	// Use *bytes.Reader for request body,
 	// as NewRequest() automatically will
@@ -187,13 +187,13 @@ func (t *transport) SignDelivery(dlv *delivery.Delivery) error {
 		return gtserror.Newf("error reading request body: %w", err)
 	}
 
-	// Get signing function for POST data.
-	// (note that delivery is ALWAYS POST).
-	sign := t.signPOST(data)
-
 	// Extract delivery context.
 	ctx := dlv.Request.Context()
 
+	// Get signing function for POST data.
+	// (note that delivery is ALWAYS POST).
+	sign := t.signPOSTFor(ctx, dlv.Request.URL.Host, data)
+
 	// Update delivery request context with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
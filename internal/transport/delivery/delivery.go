@@ -54,7 +54,8 @@ type Delivery struct {
 	Request *httpclient.Request
 
 	// internal fields.
-	next time.Time
+	next      time.Time
+	journalID string
 }
 
 // delivery is an internal type
@@ -156,3 +157,8 @@ func (dlv *Delivery) backoff() time.Duration {
 	}
 	return time.Until(dlv.next)
 }
+
+// domain returns the domain this delivery is addressed to.
+func (dlv *Delivery) domain() string {
+	return dlv.Request.URL.Hostname()
+}
@@ -0,0 +1,328 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// Journal is an optional database-backed record of pending
+// deliveries, so that deliveries queued in memory survive a
+// crash and can be replayed on startup. It also tracks the
+// number of consecutive failed deliveries to each domain,
+// so that deliveries to a dead host are parked (kept only
+// in the database) until the host is due to be tried again.
+//
+// All methods are safe to call on a nil Journal{},
+// in which case they will simply do nothing.
+type Journal struct {
+	db db.Delivery
+
+	// domain delivery states,
+	// protected by mutex.
+	domains map[string]*gtsmodel.DomainDeliveryState
+	mutex   sync.Mutex
+}
+
+// NewJournal returns a new delivery Journal{} using given
+// database, loading any previously stored domain states.
+func NewJournal(ctx context.Context, db db.Delivery) (*Journal, error) {
+	states, err := db.GetDomainDeliveryStates(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error getting domain delivery states: %w", err)
+	}
+
+	j := &Journal{
+		db:      db,
+		domains: make(map[string]*gtsmodel.DomainDeliveryState, len(states)),
+	}
+
+	for _, state := range states {
+		j.domains[state.Domain] = state
+	}
+
+	return j, nil
+}
+
+// Restore returns a Delivery{} from given journaled
+// delivery, with its previous number of attempts and next
+// attempt time. Like Delivery{}.Deserialize(), the returned
+// delivery still requires its signing func to be set up.
+func (j *Journal) Restore(pending *gtsmodel.PendingDelivery) (*Delivery, error) {
+	dlv := new(Delivery)
+
+	// Deserialize the journaled delivery data.
+	if err := dlv.Deserialize(pending.Data); err != nil {
+		return nil, err
+	}
+
+	// Restore the journal state.
+	dlv.journalID = pending.ID
+	dlv.next = pending.NextAttemptAt
+	dlv.Request.SetAttempts(uint(pending.Attempts))
+
+	return dlv, nil
+}
+
+// ExpiredParks returns the domains whose deliveries were parked,
+// but whose park has since expired, clearing the expired parks.
+// Deliveries to these domains should now be unparked and requeued.
+//
+// Note the failure count of an unparked domain is left as-is, so
+// a single further failure will park the domain's deliveries again.
+func (j *Journal) ExpiredParks(ctx context.Context) []string {
+	if j == nil {
+		return nil
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	var domains []string
+	now := time.Now()
+
+	for domain, state := range j.domains {
+		if state.ParkedUntil.IsZero() ||
+			state.ParkedUntil.After(now) {
+			continue
+		}
+
+		// Clear expired park.
+		state.ParkedUntil = time.Time{}
+		if err := j.db.UpdateDomainDeliveryState(ctx,
+			state,
+			"parked_until",
+		); err != nil {
+			log.Errorf(ctx, "error updating domain delivery state: %v", err)
+		}
+
+		domains = append(domains, domain)
+	}
+
+	return domains
+}
+
+// record journals the given (newly queued) deliveries.
+func (j *Journal) record(ctx context.Context, dlvs []*Delivery) {
+	if j == nil || len(dlvs) == 0 {
+		return
+	}
+
+	pending := make([]*gtsmodel.PendingDelivery, 0, len(dlvs))
+
+	for _, dlv := range dlvs {
+		// Serialize delivery data for storage.
+		data, err := dlv.Serialize()
+		if err != nil {
+			log.Errorf(ctx, "error serializing delivery: %v", err)
+			continue
+		}
+
+		pd := &gtsmodel.PendingDelivery{
+			ID:       id.NewULID(),
+			Domain:   dlv.domain(),
+			ActorID:  dlv.ActorID,
+			ObjectID: dlv.ObjectID,
+			TargetID: dlv.TargetID,
+			Data:     data,
+			Parked:   util.Ptr(false),
+		}
+
+		dlv.journalID = pd.ID
+		pending = append(pending, pd)
+	}
+
+	if err := j.db.PutPendingDeliveries(ctx, pending); err != nil {
+		log.Errorf(ctx, "error journaling deliveries: %v", err)
+
+		// Unset journal IDs so we
+		// don't later try to update.
+		for _, dlv := range dlvs {
+			dlv.journalID = ""
+		}
+	}
+}
+
+// reschedule updates the journaled attempts and
+// next attempt time of delivery after a failure.
+func (j *Journal) reschedule(ctx context.Context, dlv *Delivery) {
+	if j == nil || dlv.journalID == "" {
+		return
+	}
+
+	if err := j.db.UpdatePendingDelivery(ctx,
+		&gtsmodel.PendingDelivery{
+			ID:            dlv.journalID,
+			Attempts:      int(dlv.Request.Attempts()),
+			NextAttemptAt: dlv.next,
+		},
+		"attempts",
+		"next_attempt_at",
+	); err != nil {
+		log.Errorf(ctx, "error updating journaled delivery: %v", err)
+	}
+}
+
+// park marks journaled delivery as parked until
+// given time, after which it will be requeued by
+// whoever calls ExpiredParks(). A delivery that
+// was never journaled can't be parked, so is dropped.
+func (j *Journal) park(ctx context.Context, dlv *Delivery, until time.Time) {
+	if j == nil || dlv.journalID == "" {
+		return
+	}
+
+	if err := j.db.UpdatePendingDelivery(ctx,
+		&gtsmodel.PendingDelivery{
+			ID:            dlv.journalID,
+			Attempts:      int(dlv.Request.Attempts()),
+			NextAttemptAt: until,
+			Parked:        util.Ptr(true),
+		},
+		"attempts",
+		"next_attempt_at",
+		"parked",
+	); err != nil {
+		log.Errorf(ctx, "error parking journaled delivery: %v", err)
+	}
+}
+
+// remove deletes delivery from the journal, after it
+// was either successfully delivered or dropped.
+func (j *Journal) remove(ctx context.Context, dlv *Delivery) {
+	if j == nil || dlv.journalID == "" {
+		return
+	}
+
+	if err := j.db.DeletePendingDeliveryByID(ctx, dlv.journalID); err != nil {
+		log.Errorf(ctx, "error deleting journaled delivery: %v", err)
+	}
+}
+
+// removeIRI deletes all journaled deliveries with
+// given IRI as their actor, object or target ID.
+func (j *Journal) removeIRI(ctx context.Context, iri string) {
+	if j == nil {
+		return
+	}
+
+	if err := j.db.DeletePendingDeliveriesByIRI(ctx, iri); err != nil {
+		log.Errorf(ctx, "error deleting journaled deliveries: %v", err)
+	}
+}
+
+// parkedUntil returns the time until which deliveries
+// to the given domain are parked, or zero time if none.
+func (j *Journal) parkedUntil(domain string) time.Time {
+	if j == nil {
+		return time.Time{}
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	state, ok := j.domains[domain]
+	if !ok || !state.Parked() {
+		return time.Time{}
+	}
+
+	return state.ParkedUntil
+}
+
+// succeeded resets the failure count for given domain.
+func (j *Journal) succeeded(ctx context.Context, domain string) {
+	if j == nil {
+		return
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	if _, ok := j.domains[domain]; !ok {
+		// Nothing
+		// to reset.
+		return
+	}
+
+	delete(j.domains, domain)
+	if err := j.db.DeleteDomainDeliveryState(ctx, domain); err != nil {
+		log.Errorf(ctx, "error deleting domain delivery state: %v", err)
+	}
+}
+
+// failed increments the failure count for given domain, returning
+// the time until which deliveries to the domain are now parked, or
+// zero time if the domain has not (yet) reached the park threshold.
+func (j *Journal) failed(ctx context.Context, domain string) time.Time {
+	if j == nil {
+		return time.Time{}
+	}
+
+	j.mutex.Lock()
+	defer j.mutex.Unlock()
+
+	now := time.Now()
+
+	state, ok := j.domains[domain]
+	if !ok {
+		state = &gtsmodel.DomainDeliveryState{
+			ID:     id.NewULID(),
+			Domain: domain,
+		}
+	}
+
+	state.Failures++
+	state.LastFailureAt = now
+
+	// Park domain if it reached the threshold, and
+	// isn't already parked (by another worker).
+	parkAfter := config.GetAdvancedDeliveryParkAfter()
+	if parkAfter > 0 && state.Failures >= parkAfter && !state.Parked() {
+		state.ParkedUntil = now.Add(config.GetAdvancedDeliveryParkFor())
+		log.Warnf(ctx, "parking deliveries to %s until %s after %d consecutive failures",
+			domain, state.ParkedUntil, state.Failures)
+	}
+
+	var err error
+	if ok {
+		err = j.db.UpdateDomainDeliveryState(ctx, state)
+	} else {
+		j.domains[domain] = state
+		err = j.db.PutDomainDeliveryState(ctx, state)
+	}
+
+	if err != nil {
+		log.Errorf(ctx, "error storing domain delivery state: %v", err)
+	}
+
+	if !state.Parked() {
+		return time.Time{}
+	}
+
+	return state.ParkedUntil
+}
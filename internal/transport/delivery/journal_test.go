@@ -0,0 +1,291 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package delivery_test
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
+)
+
+func TestJournalDelivered(t *testing.T) {
+	config.SetAdvancedDeliveryParkAfter(1)
+	config.SetAdvancedDeliveryParkFor(time.Hour)
+
+	addr := startTestServer(t, http.StatusAccepted)
+	db := newJournalDB()
+	wp := startJournaledPool(t, db)
+
+	wp.Push(context.Background(), newTestDelivery(t, addr))
+
+	// Once delivered, delivery should be dropped from journal.
+	waitFor(t, func() bool { return db.count() == 0 && db.recorded() == 1 })
+}
+
+func TestJournalParked(t *testing.T) {
+	config.SetAdvancedDeliveryParkAfter(1)
+	config.SetAdvancedDeliveryParkFor(time.Hour)
+
+	addr := startTestServer(t, http.StatusServiceUnavailable)
+	db := newJournalDB()
+	wp := startJournaledPool(t, db)
+
+	wp.Push(context.Background(), newTestDelivery(t, addr))
+
+	// First failure reaches the park threshold,
+	// so delivery should be parked in journal.
+	waitFor(t, func() bool { return len(db.parked()) == 1 })
+
+	states, _ := db.GetDomainDeliveryStates(context.Background())
+	if len(states) != 1 || !states[0].Parked() {
+		t.Fatalf("expected 1 parked domain state, got %+v", states)
+	}
+
+	// Nothing has expired yet.
+	if domains := wp.Journal.ExpiredParks(context.Background()); len(domains) != 0 {
+		t.Fatalf("expected no expired parks, got %v", domains)
+	}
+
+	// Expire the park, the domain should now
+	// be returned for unparking (only once).
+	states[0].ParkedUntil = time.Now().Add(-time.Second)
+	if domains := wp.Journal.ExpiredParks(context.Background()); len(domains) != 1 || domains[0] != "127.0.0.1" {
+		t.Fatalf("expected 1 expired park for 127.0.0.1, got %v", domains)
+	}
+	if domains := wp.Journal.ExpiredParks(context.Background()); len(domains) != 0 {
+		t.Fatalf("expected no expired parks, got %v", domains)
+	}
+
+	// Parked delivery should be restorable
+	// with its previous number of attempts.
+	parked := db.parked()[0]
+	dlv, err := wp.Journal.Restore(parked)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dlv.Request.Attempts() != 1 {
+		t.Fatalf("expected 1 previous attempt, got %d", dlv.Request.Attempts())
+	}
+}
+
+// startTestServer starts an HTTP server responding
+// to all requests with given code, returning address.
+func startTestServer(t *testing.T, code int) string {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := new(http.Server)
+	srv.Handler = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(code)
+	})
+	go srv.Serve(l)
+	t.Cleanup(func() { srv.Close() })
+	return "http://" + l.Addr().String()
+}
+
+// startJournaledPool starts a delivery pool journaling to given db.
+func startJournaledPool(t *testing.T, db *journalDB) *delivery.WorkerPool {
+	journal, err := delivery.NewJournal(context.Background(), db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Journal = journal
+	wp.Start(1)
+	t.Cleanup(wp.Stop)
+	return wp
+}
+
+// newTestDelivery returns a new POST delivery to addr.
+func newTestDelivery(t *testing.T, addr string) *delivery.Delivery {
+	req, err := http.NewRequest(http.MethodPost, addr+"/inbox", bytes.NewReader([]byte(`{"type":"Create"}`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &delivery.Delivery{
+		ObjectID: "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		Request:  httpclient.WrapRequest(req),
+	}
+}
+
+// waitFor waits up to 5s for condition to return true.
+func waitFor(t *testing.T, condition func() bool) {
+	for i := 0; i < 500; i++ {
+		if condition() {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("timed out waiting for condition")
+}
+
+// journalDB is a simple in-memory implementation of db.Delivery{}.
+type journalDB struct {
+	mutex   sync.Mutex
+	pending map[string]*gtsmodel.PendingDelivery
+	states  map[string]*gtsmodel.DomainDeliveryState
+	puts    int
+}
+
+func newJournalDB() *journalDB {
+	return &journalDB{
+		pending: make(map[string]*gtsmodel.PendingDelivery),
+		states:  make(map[string]*gtsmodel.DomainDeliveryState),
+	}
+}
+
+func (db *journalDB) count() int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return len(db.pending)
+}
+
+func (db *journalDB) recorded() int {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	return db.puts
+}
+
+func (db *journalDB) parked() []*gtsmodel.PendingDelivery {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	var parked []*gtsmodel.PendingDelivery
+	for _, pd := range db.pending {
+		if *pd.Parked {
+			parked = append(parked, pd)
+		}
+	}
+	return parked
+}
+
+func (db *journalDB) GetPendingDeliveries(ctx context.Context) ([]*gtsmodel.PendingDelivery, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	var pending []*gtsmodel.PendingDelivery
+	for _, pd := range db.pending {
+		if !*pd.Parked {
+			pending = append(pending, pd)
+		}
+	}
+	return pending, nil
+}
+
+func (db *journalDB) GetParkedDeliveries(ctx context.Context, domain string) ([]*gtsmodel.PendingDelivery, error) {
+	var parked []*gtsmodel.PendingDelivery
+	for _, pd := range db.parked() {
+		if pd.Domain == domain {
+			parked = append(parked, pd)
+		}
+	}
+	return parked, nil
+}
+
+func (db *journalDB) PutPendingDeliveries(ctx context.Context, deliveries []*gtsmodel.PendingDelivery) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for _, pd := range deliveries {
+		pd.CreatedAt = time.Now()
+		db.pending[pd.ID] = pd
+		db.puts++
+	}
+	return nil
+}
+
+func (db *journalDB) UpdatePendingDelivery(ctx context.Context, delivery *gtsmodel.PendingDelivery, columns ...string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	pd, ok := db.pending[delivery.ID]
+	if !ok {
+		return nil
+	}
+	if slices.Contains(columns, "attempts") {
+		pd.Attempts = delivery.Attempts
+	}
+	if slices.Contains(columns, "next_attempt_at") {
+		pd.NextAttemptAt = delivery.NextAttemptAt
+	}
+	if slices.Contains(columns, "parked") {
+		pd.Parked = delivery.Parked
+	}
+	return nil
+}
+
+func (db *journalDB) DeletePendingDeliveryByID(ctx context.Context, id string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	delete(db.pending, id)
+	return nil
+}
+
+func (db *journalDB) DeletePendingDeliveriesByIRI(ctx context.Context, iri string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	for id, pd := range db.pending {
+		if pd.ActorID == iri || pd.ObjectID == iri || pd.TargetID == iri {
+			delete(db.pending, id)
+		}
+	}
+	return nil
+}
+
+func (db *journalDB) GetDomainDeliveryStates(ctx context.Context) ([]*gtsmodel.DomainDeliveryState, error) {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	var states []*gtsmodel.DomainDeliveryState
+	for _, state := range db.states {
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+func (db *journalDB) PutDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.states[state.Domain] = state
+	return nil
+}
+
+func (db *journalDB) UpdateDomainDeliveryState(ctx context.Context, state *gtsmodel.DomainDeliveryState, columns ...string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	db.states[state.Domain] = state
+	return nil
+}
+
+func (db *journalDB) DeleteDomainDeliveryState(ctx context.Context, domain string) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+	delete(db.states, domain)
+	return nil
+}
@@ -44,6 +44,11 @@ type WorkerPool struct {
 	// passed to each of delivery pool Worker{}s.
 	Queue queue.StructQueue[*Delivery]
 
+	// Journal is the optional delivery Journal{}
+	// passed to each of delivery pool Worker{}s.
+	// When nil, deliveries are only held in memory.
+	Journal *Journal
+
 	// internal fields.
 	workers []*Worker
 }
@@ -79,6 +84,7 @@ func (p *WorkerPool) Start(n int) {
 		p.workers[i] = new(Worker)
 		p.workers[i].Client = p.Client
 		p.workers[i].Queue = &p.Queue
+		p.workers[i].Journal = p.Journal
 
 		// Attempt to start worker.
 		// Return bool not useful
@@ -88,6 +94,23 @@ func (p *WorkerPool) Start(n int) {
 	}
 }
 
+// Push will record the given deliveries in the
+// Journal{} (if enabled), and push them to the queue.
+func (p *WorkerPool) Push(ctx context.Context, dlvs ...*Delivery) {
+	p.Journal.record(ctx, dlvs)
+	p.Queue.Push(dlvs...)
+}
+
+// DeleteJournaled will delete all journaled deliveries
+// with the given ActivityPub ID IRI as their actor, object
+// or target ID. This is a no-op if the Journal{} is disabled.
+//
+// Note this does not drop queued deliveries, for that
+// see the Queue{}.Delete() function with relevant index.
+func (p *WorkerPool) DeleteJournaled(ctx context.Context, iri string) {
+	p.Journal.removeIRI(ctx, iri)
+}
+
 // Stop will attempt to stop contained Worker{}s.
 func (p *WorkerPool) Stop() {
 	// Check whether workers are
@@ -124,6 +147,10 @@ type Worker struct {
 	// that delivery worker will feed from.
 	Queue *queue.StructQueue[*Delivery]
 
+	// Journal is the optional Journal{} that
+	// delivery worker will record progress in.
+	Journal *Journal
+
 	// internal fields.
 	backlog []*Delivery
 	service runners.Service
@@ -174,6 +201,14 @@ loop:
 			return true
 		}
 
+		// Check whether delivery's domain is parked,
+		// in which case leave delivery in the journal
+		// until the domain is next due to be tried.
+		if until := w.Journal.parkedUntil(dlv.domain()); !until.IsZero() {
+			w.Journal.park(ctx, dlv, until)
+			continue loop
+		}
+
 		// Check whether backoff required.
 		const min = 100 * time.Millisecond
 		if d := dlv.backoff(); d > min {
@@ -210,6 +245,20 @@ loop:
 		case err == nil:
 			// Ensure body closed.
 			_ = rsp.Body.Close()
+
+			// Host responded, so remove from journal
+			// and reset domain's failure count.
+			w.Journal.remove(ctx, dlv)
+			w.Journal.succeeded(ctx, dlv.domain())
+
+			// Let the sender know of accepted
+			// delivery, if it asked to be told.
+			if code := rsp.StatusCode; code >= 200 && code < 300 {
+				rctx := dlv.Request.Context()
+				if fn := gtscontext.DeliveredFunc(rctx); fn != nil {
+					fn(rctx)
+				}
+			}
 			continue loop
 
 		case errors.Is(err, context.Canceled) &&
@@ -225,11 +274,20 @@ loop:
 			// faster check in the if-clause.
 			w.Queue.Push(dlv)
 			continue loop
+		}
 
-		case !retry:
+		// Delivery failed, check whether this
+		// failure parks the delivery's domain.
+		if until := w.Journal.failed(ctx, dlv.domain()); !until.IsZero() {
+			w.Journal.park(ctx, dlv, until)
+			continue loop
+		}
+
+		if !retry {
 			// Drop deliveries when no
 			// retry requested, or they
 			// reached max (either).
+			w.Journal.remove(ctx, dlv)
 			continue loop
 		}
 
@@ -237,6 +295,9 @@ loop:
 		backoff := dlv.Request.BackOff()
 		dlv.next = time.Now().Add(backoff)
 
+		// Update journaled attempt.
+		w.Journal.reschedule(ctx, dlv)
+
 		// Push to backlog.
 		w.pushBacklog(dlv)
 	}
@@ -18,6 +18,7 @@
 package delivery_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"math/rand"
@@ -26,9 +27,11 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"codeberg.org/gruf/go-byteutil"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
 	"github.com/superseriousbusiness/gotosocial/internal/queue"
 	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
@@ -107,6 +110,82 @@ func test(
 	}
 }
 
+func TestDeliveryWorkerDeliveredFunc(t *testing.T) {
+	// Start HTTP test server that responds
+	// with the status code in the request path.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	srv := new(http.Server)
+	srv.Addr = "http://" + l.Addr().String()
+	srv.Handler = http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		code, _ := strconv.Atoi(strings.TrimPrefix(r.URL.Path, "/"))
+		rw.WriteHeader(code)
+	})
+	go srv.Serve(l)
+	defer srv.Close()
+
+	wp := new(delivery.WorkerPool)
+	wp.Init(httpclient.New(httpclient.Config{
+		AllowRanges: config.MustParseIPPrefixes([]string{
+			"127.0.0.0/8",
+		}),
+	}))
+	wp.Start(1)
+	defer wp.Stop()
+
+	for _, test := range []struct {
+		code      int
+		delivered bool
+	}{
+		{code: http.StatusAccepted, delivered: true},
+		{code: http.StatusForbidden, delivered: false},
+	} {
+		done := make(chan bool, 1)
+
+		// Queue delivery with a func marking it delivered.
+		ctx := gtscontext.SetDeliveredFunc(context.Background(), func(context.Context) {
+			done <- true
+		})
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.Addr+"/"+strconv.Itoa(test.code), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wp.Queue.Push(&delivery.Delivery{Request: httpclient.WrapRequest(req)})
+
+		// Follow up with a delivery that is always accepted,
+		// so we know when the (single) worker is done with it.
+		followed := make(chan struct{})
+		ctx = gtscontext.SetDeliveredFunc(context.Background(), func(context.Context) {
+			close(followed)
+		})
+		follow, err := http.NewRequestWithContext(ctx, http.MethodPost, srv.Addr+"/200", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wp.Queue.Push(&delivery.Delivery{Request: httpclient.WrapRequest(follow)})
+
+		select {
+		case <-followed:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for deliveries")
+		}
+
+		var delivered bool
+		select {
+		case delivered = <-done:
+		default:
+		}
+
+		if delivered != test.delivered {
+			t.Errorf("status %d: expected delivered=%v, got %v", test.code, test.delivered, delivered)
+		}
+	}
+}
+
 type testrequest struct {
 	method string
 	uri    string
@@ -0,0 +1,105 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package transport_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SignatureSchemeTestSuite struct {
+	TransportTestSuite
+}
+
+func (suite *SignatureSchemeTestSuite) TestGETUnknownHostCavageOnly() {
+	var (
+		ctx      = context.Background()
+		requests []bool // whether each was rfc9421-signed
+	)
+
+	// Mock a host that only accepts
+	// draft-cavage signatures, and
+	// that we have no instance for.
+	client := testrig.NewMockHTTPClient(func(r *http.Request) (*http.Response, error) {
+		// Sign as the http client would.
+		r.Header.Set("Host", r.URL.Host)
+		r.Header.Set("Date", time.Now().UTC().Format(http.TimeFormat))
+		r.Header.Del("Signature")
+		r.Header.Del(messagesig.SignatureInputHeader)
+		if err := gtscontext.HTTPClientSignFunc(r.Context())(r); err != nil {
+			return nil, err
+		}
+
+		rfc9421 := r.Header.Get(messagesig.SignatureInputHeader) != ""
+		requests = append(requests, rfc9421)
+
+		status := http.StatusOK
+		if rfc9421 {
+			status = http.StatusUnauthorized
+		}
+
+		return &http.Response{
+			StatusCode: status,
+			Body:       io.NopCloser(strings.NewReader("{}")),
+		}, nil
+	}, "")
+
+	controller := testrig.NewTestTransportController(&suite.state, client)
+	transport, err := controller.NewTransport(suite.testAccounts["local_account_1"])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	get := func() {
+		r, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://cavage.example.org/users/someone", nil)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+
+		resp, err := transport.GET(r)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		_ = resp.Body.Close()
+		suite.Equal(http.StatusOK, resp.StatusCode)
+	}
+
+	// First GET should try RFC 9421,
+	// then fall back to draft-cavage.
+	get()
+	suite.Equal([]bool{true, false}, requests)
+
+	// Second GET should go straight to draft-cavage,
+	// even though there's no instance to record on.
+	requests = nil
+	get()
+	suite.Equal([]bool{false}, requests)
+}
+
+func TestSignatureSchemeTestSuite(t *testing.T) {
+	suite.Run(t, new(SignatureSchemeTestSuite))
+}
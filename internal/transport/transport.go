@@ -20,6 +20,7 @@ package transport
 import (
 	"context"
 	"crypto"
+	"crypto/ed25519"
 	"errors"
 	"io"
 	"net/http"
@@ -30,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/httpclient"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/messagesig"
 	"github.com/superseriousbusiness/gotosocial/internal/transport/delivery"
 	"github.com/superseriousbusiness/httpsig"
 )
@@ -89,6 +92,12 @@ type transport struct {
 	pubKeyID   string
 	privkey    crypto.PrivateKey
 
+	// ed25519 key used for RFC 9421
+	// http message signatures, may
+	// be nil for older accounts.
+	ed25519KeyID string
+	ed25519Key   ed25519.PrivateKey
+
 	signerExp  time.Time
 	getSigner  httpsig.SignerWithOptions
 	postSigner httpsig.SignerWithOptions
@@ -100,6 +109,58 @@ func (t *transport) GET(r *http.Request) (*http.Response, error) {
 		return nil, errors.New("must be GET request")
 	}
 
+	// Set our predefined controller user-agent.
+	r.Header.Set("User-Agent", t.controller.userAgent)
+
+	// Check which signature scheme is known
+	// to be accepted by the remote host, if any.
+	scheme := t.controller.signatureScheme(r.Context(), r.URL.Host)
+
+	if scheme != gtsmodel.SignatureSchemeCavage {
+		// Unless the host is known to only accept
+		// draft-cavage signatures, first try with
+		// RFC 9421 http message signatures.
+		ctx := r.Context() // update with signing details.
+		ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
+		ctx = gtscontext.SetHTTPClientSignFunc(ctx, t.signRFC9421(scheme, nil))
+		r = r.WithContext(ctx) // replace request ctx.
+
+		// Pass to underlying HTTP client.
+		resp, err := t.controller.client.Do(r)
+		if err != nil || resp.StatusCode != http.StatusUnauthorized {
+			return resp, err
+		}
+
+		// Ignore this response.
+		_ = resp.Body.Close()
+	}
+
+	// Retry with draft-cavage signatures.
+	resp, err := t.getCavage(r)
+	if err != nil {
+		return resp, err
+	}
+
+	if scheme != gtsmodel.SignatureSchemeCavage &&
+		resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		// RFC 9421 signature was rejected but a
+		// draft-cavage signature was accepted, so
+		// use that for future requests to this host.
+		if err := t.controller.SetSignatureScheme(r.Context(),
+			r.URL.Host,
+			gtsmodel.SignatureSchemeCavage,
+		); err != nil {
+			log.Errorf(r.Context(), "error setting signature scheme: %v", err)
+		}
+	}
+
+	return resp, nil
+}
+
+// getCavage performs the given http GET request signed
+// with draft-cavage http signatures, retrying once with
+// query string excluded from signature on unauthorized.
+func (t *transport) getCavage(r *http.Request) (*http.Response, error) {
 	// Prepare HTTP GET signing func with opts.
 	sign := t.signGET(httpsig.SignatureOption{
 		ExcludeQueryStringFromPathPseudoHeader: false,
@@ -110,9 +171,6 @@ func (t *transport) GET(r *http.Request) (*http.Response, error) {
 	ctx = gtscontext.SetHTTPClientSignFunc(ctx, sign)
 	r = r.WithContext(ctx) // replace request ctx.
 
-	// Set our predefined controller user-agent.
-	r.Header.Set("User-Agent", t.controller.userAgent)
-
 	// Pass to underlying HTTP client.
 	resp, err := t.controller.client.Do(r)
 	if err != nil || resp.StatusCode != http.StatusUnauthorized {
@@ -141,8 +199,8 @@ func (t *transport) POST(r *http.Request, body []byte) (*http.Response, error) {
 		return nil, errors.New("must be POST request")
 	}
 
-	// Prepare POST signer.
-	sign := t.signPOST(body)
+	// Prepare POST signer for host.
+	sign := t.signPOSTFor(r.Context(), r.URL.Host, body)
 
 	ctx := r.Context() // update with signing details.
 	ctx = gtscontext.SetOutgoingPublicKeyID(ctx, t.pubKeyID)
@@ -156,6 +214,32 @@ func (t *transport) POST(r *http.Request, body []byte) (*http.Response, error) {
 	return t.controller.client.Do(r)
 }
 
+// signPOSTFor returns a signing function for an HTTP POST
+// request with given body to host. Only hosts known to
+// accept RFC 9421 http message signatures are sent them,
+// as deliveries can't cheaply be retried with another
+// scheme, else this falls back to draft-cavage signatures.
+func (t *transport) signPOSTFor(ctx context.Context, host string, body []byte) httpclient.SignFunc {
+	switch scheme := t.controller.signatureScheme(ctx, host); scheme {
+	case gtsmodel.SignatureSchemeRFC9421,
+		gtsmodel.SignatureSchemeRFC9421Ed25519:
+		return t.signRFC9421(scheme, body)
+	default:
+		return t.signPOST(body)
+	}
+}
+
+// signRFC9421 returns a signing function for RFC 9421 http message
+// signatures, using our ed25519 key only if the scheme calls for it.
+func (t *transport) signRFC9421(scheme gtsmodel.SignatureScheme, body []byte) httpclient.SignFunc {
+	return func(r *http.Request) error {
+		if scheme == gtsmodel.SignatureSchemeRFC9421Ed25519 && t.ed25519Key != nil {
+			return messagesig.Sign(r, body, t.ed25519Key, t.ed25519KeyID, messagesig.ED25519)
+		}
+		return messagesig.Sign(r, body, t.privkey, t.pubKeyID, messagesig.RSA_V1_5_SHA256)
+	}
+}
+
 // signGET will safely sign an HTTP GET request.
 func (t *transport) signGET(opts httpsig.SignatureOption) httpclient.SignFunc {
 	return func(r *http.Request) (err error) {
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"net/url"
+	"slices"
 
 	"github.com/miekg/dns"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
@@ -157,6 +158,12 @@ func (c *Converter) ASRepresentationToAccount(
 
 	// Extract 'manuallyApprovesFollowers' aka locked account (default = true).
 	manuallyApprovesFollowers := ap.GetManuallyApprovesFollowers(accountable)
+	if acct.IsGroup() && accountable.GetActivityStreamsManuallyApprovesFollowers() == nil {
+		// Group software like Lemmy and Mbin doesn't
+		// set this property, but accepts all follows,
+		// so default to unlocked for group actors.
+		manuallyApprovesFollowers = false
+	}
 	acct.Locked = &manuallyApprovesFollowers
 
 	// Extract account discoverability (default = false).
@@ -245,6 +252,13 @@ func (c *Converter) ASRepresentationToAccount(
 	acct.PublicKey = pkey
 	acct.PublicKeyURI = pkeyURL.String()
 
+	// Extract optional ed25519 key, used by
+	// some servers for http message signatures.
+	if edKey, edKeyURL, err := ap.ExtractEd25519Key(accountable, uriObj); err == nil {
+		acct.Ed25519PublicKey = edKey
+		acct.Ed25519PublicKeyURI = edKeyURL.String()
+	}
+
 	return &acct, nil
 }
 
@@ -353,6 +367,15 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		log.Warnf(ctx, "unusable published property on %s", uri)
 	}
 
+	// status.EditedAt
+	//
+	// Extract updated time for the status,
+	// zero-time means it was never edited.
+	if upd := ap.GetUpdated(statusable); !upd.IsZero() &&
+		upd.After(status.CreatedAt) {
+		status.EditedAt = upd
+	}
+
 	// status.AccountURI
 	// status.AccountID
 	// status.Account
@@ -405,6 +428,31 @@ func (c *Converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// status.QuoteURI
+	// status.QuoteID
+	// status.Quote
+	//
+	// Status that this status quotes, if applicable.
+	// As with replies, if we don't have the quoted
+	// status yet we just set the URI to deref later.
+	if quoteURI := ap.ExtractQuoteURI(statusable); quoteURI != nil {
+		status.QuoteURI = quoteURI.String()
+
+		// Check if we already have the quoted status.
+		quote, err := c.state.DB.GetStatusByURI(ctx, status.QuoteURI)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting quote %s from db: %w", status.QuoteURI, err)
+			return nil, err
+		}
+
+		if quote != nil {
+			// We have it in the DB! Set
+			// appropriate fields here and now.
+			status.QuoteID = quote.ID
+			status.Quote = quote
+		}
+	}
+
 	// Calculate intended visibility of the status.
 	status.Visibility, err = ap.ExtractVisibility(
 		statusable,
@@ -683,6 +731,7 @@ func (c *Converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 		// Gathered from objects
 		// (+ content for misskey).
 		statusURIs   []*url.URL
+		ruleURIs     []*url.URL
 		targetAccURI *url.URL
 
 		// Get current hostname.
@@ -703,7 +752,8 @@ func (c *Converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 	// Misskey on the other hand will just contain the target account uri.
 	// We shouldn't assume the order of the objects will correspond to this,
 	// but we can check that he objects slice contains just one account, and
-	// maybe some statuses.
+	// maybe some statuses. Flags may also reference rules of
+	// this instance which were broken, by their rule URIs.
 	//
 	// Throw away anything that's not relevant to us.
 	objects := ap.GetObjectIRIs(flaggable)
@@ -728,6 +778,9 @@ func (c *Converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 
 		case uris.IsStatusesPath(object):
 			statusURIs = append(statusURIs, object)
+
+		case uris.IsRulePath(object):
+			ruleURIs = append(ruleURIs, object)
 		}
 	}
 
@@ -785,6 +838,43 @@ func (c *Converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 		statuses = append(statuses, status)
 	}
 
+	var (
+		// Preallocate expected rule + IDs slice lengths.
+		ruleIDs = make([]string, 0, len(ruleURIs))
+		rules   = make([]*gtsmodel.Rule, 0, len(ruleURIs))
+	)
+
+	for _, ruleURI := range ruleURIs {
+		// Extract the rule ID from its URI.
+		ruleID, err := uris.ParseRulePath(ruleURI)
+		if err != nil {
+			log.Warnf(ctx, "invalid target rule %s for %s: %v", ruleURI, uri, err)
+			continue
+		}
+
+		// Try getting rule by ID from database.
+		rule, err := c.state.DB.GetRuleByID(ctx, ruleID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			err := gtserror.Newf("error getting target rule %s from database: %w", ruleURI, err)
+			return nil, err
+		}
+
+		if rule == nil || *rule.Deleted {
+			log.Warnf(ctx, "missing target rule %s for %s", ruleURI, uri)
+			continue
+		}
+
+		if slices.Contains(ruleIDs, rule.ID) {
+			// Already
+			// got it.
+			continue
+		}
+
+		// Append the discovered rule to slices.
+		ruleIDs = append(ruleIDs, rule.ID)
+		rules = append(rules, rule)
+	}
+
 	// id etc should be handled the caller,
 	// so just return what we got
 	return &gtsmodel.Report{
@@ -796,6 +886,9 @@ func (c *Converter) ASFlagToReport(ctx context.Context, flaggable ap.Flaggable)
 		Comment:         content,
 		StatusIDs:       statusIDs,
 		Statuses:        statuses,
+		RuleIDs:         ruleIDs,
+		Rules:           rules,
+		Forwarded:       util.Ptr(false),
 	}, nil
 }
 
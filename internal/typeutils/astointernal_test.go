@@ -31,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/cache"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type ASToInternalTestSuite struct {
@@ -472,6 +474,50 @@ func (suite *ASToInternalTestSuite) TestParseFlag6() {
 	suite.Equal(report.Comment, "misinformation")
 }
 
+func (suite *ASToInternalTestSuite) TestParseFlag7() {
+	reportedAccount := suite.testAccounts["local_account_1"]
+	reportingAccount := suite.testAccounts["remote_account_1"]
+	reportedStatus := suite.testStatuses["local_account_1_status_1"]
+	testRules := testrig.NewTestRules()
+	brokenRule := testRules["rule1"]
+	deletedRule := testRules["deleted_rule"]
+
+	// flag referencing rules of this instance, one of which
+	// is deleted and one of which doesn't exist at all
+	raw := `{
+  "@context": "https://www.w3.org/ns/activitystreams",
+  "actor": "` + reportingAccount.URI + `",
+  "content": "misinformation",
+  "id": "http://fossbros-anonymous.io/db22128d-884e-4358-9935-6a7c3940535d",
+  "object": [
+    "` + reportedAccount.URI + `",
+    "` + reportedStatus.URI + `",
+    "` + uris.URIForRule(brokenRule.ID) + `",
+    "` + uris.URIForRule(deletedRule.ID) + `",
+    "` + uris.URIForRule("01GP3DFY9XQ1TJMZT5BGAZPXX9") + `"
+  ],
+  "type": "Flag"
+  }`
+
+	t := suite.jsonToType(raw)
+	asFlag, ok := t.(ap.Flaggable)
+	if !ok {
+		suite.FailNow("type not coercible")
+	}
+
+	report, err := suite.typeconverter.ASFlagToReport(context.Background(), asFlag)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	suite.Equal(report.AccountID, reportingAccount.ID)
+	suite.Equal(report.TargetAccountID, reportedAccount.ID)
+	suite.Equal([]string{reportedStatus.ID}, report.StatusIDs)
+	suite.Equal([]string{brokenRule.ID}, report.RuleIDs)
+	suite.Len(report.Rules, 1)
+	suite.Equal(brokenRule.Text, report.Rules[0].Text)
+}
+
 func (suite *ASToInternalTestSuite) TestParseAnnounce() {
 	// Boost a status that belongs to a local account
 	boostingAccount := suite.testAccounts["remote_account_1"]
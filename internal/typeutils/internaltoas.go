@@ -39,9 +39,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
-// AccountToAS converts a gts model account into an activity streams person, suitable for federation
-func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error) {
-	person := streams.NewActivityStreamsPerson()
+// AccountToAS converts a gts model account into an activity streams
+// actor, suitable for federation. This will be a person, or a group
+// for group accounts.
+func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (ap.Accountable, error) {
+	person := newAccountable(a)
 
 	// id should be the activitypub URI of this user
 	// something like https://example.org/users/example_user
@@ -237,6 +239,17 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Ed25519 key for http message
+	// signatures, if we have one.
+	if a.Ed25519PublicKey != nil {
+		ed25519KeyURI, err := url.Parse(a.Ed25519PublicKeyURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetEd25519Key(person, ed25519KeyURI, profileIDURI, a.Ed25519PublicKey)
+	}
+
 	// tags
 	tagProp := streams.NewActivityStreamsTagProperty()
 
@@ -361,13 +374,22 @@ func (c *Converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 	return person, nil
 }
 
-// AccountToASMinimal converts a gts model account into an activity streams person, suitable for federation.
+// newAccountable returns a new, empty activity
+// streams actor of the appropriate type for a.
+func newAccountable(a *gtsmodel.Account) ap.Accountable {
+	if a.IsGroup() {
+		return streams.NewActivityStreamsGroup()
+	}
+	return streams.NewActivityStreamsPerson()
+}
+
+// AccountToASMinimal converts a gts model account into an activity streams actor, suitable for federation.
 //
 // The returned account will just have the Type, Username, PublicKey, and ID properties set. This is
 // suitable for serving to requesters to whom we want to give as little information as possible because
 // we don't trust them (yet).
-func (c *Converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (vocab.ActivityStreamsPerson, error) {
-	person := streams.NewActivityStreamsPerson()
+func (c *Converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account) (ap.Accountable, error) {
+	person := newAccountable(a)
 
 	// id should be the activitypub URI of this user
 	// something like https://example.org/users/example_user
@@ -425,6 +447,17 @@ func (c *Converter) AccountToASMinimal(ctx context.Context, a *gtsmodel.Account)
 	// set the public key property on the Person
 	person.SetW3IDSecurityV1PublicKey(publicKeyProp)
 
+	// assertionMethod
+	// Ed25519 key for http message
+	// signatures, if we have one.
+	if a.Ed25519PublicKey != nil {
+		ed25519KeyURI, err := url.Parse(a.Ed25519PublicKeyURI)
+		if err != nil {
+			return nil, err
+		}
+		ap.SetEd25519Key(person, ed25519KeyURI, profileIDURI, a.Ed25519PublicKey)
+	}
+
 	return person, nil
 }
 
@@ -489,6 +522,11 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	publishedProp.Set(s.CreatedAt)
 	status.SetActivityStreamsPublished(publishedProp)
 
+	// updated
+	if !s.EditedAt.IsZero() {
+		ap.SetUpdated(status, s.EditedAt)
+	}
+
 	// url
 	if s.URL != "" {
 		sURL, err := url.Parse(s.URL)
@@ -562,6 +600,15 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 	}
 	status.SetActivityStreamsTag(tagProp)
 
+	// quote -- FEP-e232 link tag + friends
+	if s.QuoteURI != "" {
+		quoteURI, err := url.Parse(s.QuoteURI)
+		if err != nil {
+			return nil, gtserror.Newf("error parsing url %s: %w", s.QuoteURI, err)
+		}
+		ap.SetQuoteURI(status, quoteURI)
+	}
+
 	// parse out some URIs we need here
 	authorFollowersURI, err := url.Parse(s.Account.FollowersURI)
 	if err != nil {
@@ -670,7 +717,7 @@ func (c *Converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (ap.Stat
 
 	// sensitive
 	sensitiveProp := streams.NewActivityStreamsSensitiveProperty()
-	sensitiveProp.AppendXMLSchemaBoolean(*s.Sensitive)
+	sensitiveProp.AppendXMLSchemaBoolean(statusSensitive(s))
 	status.SetActivityStreamsSensitive(sensitiveProp)
 
 	// interactionPolicy
@@ -965,6 +1012,70 @@ func (c *Converter) FollowToAS(ctx context.Context, f *gtsmodel.Follow) (vocab.A
 	return follow, nil
 }
 
+// RelayToASFollow converts a gts model relay subscription
+// into the activity streams Follow sent to the relay on
+// behalf of the instance account.
+//
+// Mastodon-style relays expect a Follow of the Public
+// collection, while LitePub relays expect a Follow of
+// the relay actor itself.
+func (c *Converter) RelayToASFollow(ctx context.Context, r *gtsmodel.Relay) (vocab.ActivityStreamsFollow, error) {
+	instanceAcct, err := c.state.DB.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, gtserror.Newf("error getting instance account: %w", err)
+	}
+
+	actorURI, err := url.Parse(instanceAcct.URI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing instance account uri: %w", err)
+	}
+
+	followURI, err := url.Parse(r.FollowURI)
+	if err != nil {
+		return nil, gtserror.Newf("error parsing follow uri: %w", err)
+	}
+
+	var objectURI *url.URL
+	switch r.Mode {
+	case gtsmodel.RelayModeMastodon:
+		objectURI, err = url.Parse(pub.PublicActivityPubIRI)
+	case gtsmodel.RelayModeLitePub:
+		objectURI, err = url.Parse(r.ActorURI)
+	default:
+		err = fmt.Errorf("unknown relay mode %q", r.Mode)
+	}
+	if err != nil {
+		return nil, gtserror.Newf("error determining follow object: %w", err)
+	}
+
+	follow := streams.NewActivityStreamsFollow()
+
+	// Set the instance account as actor.
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(actorURI)
+	follow.SetActivityStreamsActor(actorProp)
+
+	// Set the id.
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.SetIRI(followURI)
+	follow.SetJSONLDId(idProp)
+
+	// Set the object.
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(objectURI)
+	follow.SetActivityStreamsObject(objectProp)
+
+	if r.Mode == gtsmodel.RelayModeLitePub {
+		// Address LitePub
+		// relay actor directly.
+		toProp := streams.NewActivityStreamsToProperty()
+		toProp.AppendIRI(objectURI)
+		follow.SetActivityStreamsTo(toProp)
+	}
+
+	return follow, nil
+}
+
 // MentionToAS converts a gts model mention into an activity streams Mention, suitable for federation
 func (c *Converter) MentionToAS(ctx context.Context, m *gtsmodel.Mention) (vocab.ActivityStreamsMention, error) {
 	if m.TargetAccount == nil {
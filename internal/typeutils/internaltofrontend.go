@@ -19,8 +19,13 @@ package typeutils
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -97,6 +102,10 @@ func (c *Converter) UserToAPIUser(ctx context.Context, u *gtsmodel.User) *apimod
 		user.ResetPasswordSentAt = util.FormatISO8601(u.ResetPasswordSentAt)
 	}
 
+	if !u.TwoFactorEnabledAt.IsZero() {
+		user.TwoFactorEnabledAt = util.FormatISO8601(u.TwoFactorEnabledAt)
+	}
+
 	return user
 }
 
@@ -325,12 +334,15 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		// fetch more info. Skip for instance
 		// accounts since they have no user.
 		if !a.IsInstance() {
-			user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
-			if err != nil {
-				return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
-			}
-			if role := c.UserToAPIAccountDisplayRole(user); role != nil {
-				roles = append(roles, *role)
+			// Groups have settings but no user.
+			if !a.IsGroup() {
+				user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
+				if err != nil {
+					return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
+				}
+				if role := c.UserToAPIAccountDisplayRole(user); role != nil {
+					roles = append(roles, *role)
+				}
 			}
 
 			enableRSS = *a.Settings.EnableRSS
@@ -359,6 +371,7 @@ func (c *Converter) accountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		Locked:            locked,
 		Discoverable:      discoverable,
 		Bot:               bot,
+		Group:             a.IsGroup(),
 		CreatedAt:         util.FormatISO8601(a.CreatedAt),
 		Note:              a.Note,
 		URL:               a.URL,
@@ -481,8 +494,8 @@ func (c *Converter) AccountToAPIAccountBlocked(ctx context.Context, a *gtsmodel.
 	} else {
 		// This is a local account, try to
 		// fetch more info. Skip for instance
-		// accounts since they have no user.
-		if !a.IsInstance() {
+		// and group accounts since they have no user.
+		if !a.IsInstance() && !a.IsGroup() {
 			user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
 			if err != nil {
 				return nil, gtserror.Newf("error getting user from database for account id %s: %w", a.ID, err)
@@ -500,6 +513,7 @@ func (c *Converter) AccountToAPIAccountBlocked(ctx context.Context, a *gtsmodel.
 		Username:  a.Username,
 		Acct:      acct,
 		Bot:       *a.Bot,
+		Group:     a.IsGroup(),
 		CreatedAt: util.FormatISO8601(a.CreatedAt),
 		URL:       a.URL,
 		// Empty array (not nillable).
@@ -547,8 +561,8 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		}
 
 		domain = &d
-	} else if !a.IsInstance() {
-		// This is a local, non-instance
+	} else if !a.IsInstance() && !a.IsGroup() {
+		// This is a local, non-instance, non-group
 		// acct; we can fetch more info.
 		user, err := c.state.DB.GetUserByAccountID(ctx, a.ID)
 		if err != nil {
@@ -600,6 +614,7 @@ func (c *Converter) AccountToAdminAPIAccount(ctx context.Context, a *gtsmodel.Ac
 		Approved:               approved,
 		Disabled:               disabled,
 		Silenced:               !a.SilencedAt.IsZero(),
+		Sensitized:             a.IsSensitized(),
 		Suspended:              !a.SuspendedAt.IsZero(),
 		Account:                apiAccount,
 		CreatedByApplicationID: createdByApplicationID,
@@ -628,6 +643,87 @@ func (c *Converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 	}, nil
 }
 
+// TokenToAPITokenInfo converts a gts model token into an api model token info,
+// populated with the public details of the application that holds the token.
+// The secret access token value itself is never included.
+func (c *Converter) TokenToAPITokenInfo(ctx context.Context, t *gtsmodel.Token) (*apimodel.TokenInfo, error) {
+	createdAt := t.AccessCreateAt
+	if createdAt.IsZero() {
+		createdAt = t.CreatedAt
+	}
+
+	tokenInfo := &apimodel.TokenInfo{
+		ID:        t.ID,
+		CreatedAt: util.FormatISO8601(createdAt),
+		Scope:     t.Scope,
+	}
+
+	if !t.LastUsed.IsZero() {
+		tokenInfo.LastUsed = util.FormatISO8601(t.LastUsed)
+	}
+
+	app, err := c.state.DB.GetApplicationByClientID(ctx, t.ClientID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return nil, gtserror.Newf("db error getting application for token %s: %w", t.ID, err)
+	}
+
+	if app != nil {
+		tokenInfo.Application, err = c.AppToAPIAppPublic(ctx, app)
+		if err != nil {
+			return nil, gtserror.Newf("error converting application for token %s: %w", t.ID, err)
+		}
+	}
+
+	return tokenInfo, nil
+}
+
+// ArchiveToAPIArchive converts a gts model
+// archive into its api (frontend) representation.
+func (c *Converter) ArchiveToAPIArchive(a *gtsmodel.Archive) *apimodel.Archive {
+	archive := &apimodel.Archive{
+		ID:        a.ID,
+		CreatedAt: util.FormatISO8601(a.CreatedAt),
+		Processed: a.Processed(),
+	}
+
+	if a.Processed() {
+		processedAt := util.FormatISO8601(a.ProcessedAt)
+		archive.ProcessedAt = &processedAt
+		archive.Size = a.FileSize
+	}
+
+	return archive
+}
+
+// ImportToAPIImport converts a gts model
+// import into its api (frontend) representation.
+func (c *Converter) ImportToAPIImport(i *gtsmodel.Import) *apimodel.Import {
+	imp := &apimodel.Import{
+		ID:        i.ID,
+		CreatedAt: util.FormatISO8601(i.CreatedAt),
+		Type:      i.Type,
+		Total:     i.TotalCount,
+		Imported:  i.ImportedCount,
+		Skipped:   i.SkippedCount,
+		Failed:    i.FailedCount,
+		Failures:  i.Failures,
+		Finished:  i.Finished(),
+	}
+
+	if imp.Failures == nil {
+		// Return empty
+		// array, not null.
+		imp.Failures = []string{}
+	}
+
+	if i.Finished() {
+		finishedAt := util.FormatISO8601(i.FinishedAt)
+		imp.FinishedAt = &finishedAt
+	}
+
+	return imp
+}
+
 // AttachmentToAPIAttachment converts a gts model media attacahment into its api representation for serialization on the API.
 func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmodel.MediaAttachment) (apimodel.Attachment, error) {
 	var api apimodel.Attachment
@@ -673,9 +769,25 @@ func (c *Converter) AttachmentToAPIAttachment(ctx context.Context, media *gtsmod
 			// Copy over local thumbnail file URL.
 			api.PreviewURL = util.Ptr(media.Thumbnail.URL)
 		}
+	} else if media.Processing == gtsmodel.ProcessingStatusProcessing &&
+		media.Thumbnail.Path != "" {
+		// Full-size file is still being
+		// processed (eg., transcoded), but
+		// the thumbnail is already stored.
+		api.Meta = new(apimodel.MediaMeta)
+		api.Meta.Small = apimodel.MediaDimensions{
+			Width:  media.FileMeta.Small.Width,
+			Height: media.FileMeta.Small.Height,
+			Aspect: media.FileMeta.Small.Aspect,
+			Size:   toAPISize(media.FileMeta.Small.Width, media.FileMeta.Small.Height),
+		}
+
+		// Copy over local thumbnail file URL.
+		api.PreviewURL = util.Ptr(media.Thumbnail.URL)
 	}
 
 	// Set remaining API attachment fields.
+	api.Processing = (media.Processing == gtsmodel.ProcessingStatusProcessing)
 	api.Blurhash = util.PtrIf(media.Blurhash)
 	api.RemoteURL = util.PtrIf(media.RemoteURL)
 	api.PreviewRemoteURL = util.PtrIf(media.Thumbnail.RemoteURL)
@@ -786,12 +898,12 @@ func (c *Converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag, stubHistor
 	return apimodel.Tag{
 		Name: strings.ToLower(t.Name),
 		URL:  uris.URIForTag(t.Name),
-		History: func() *[]any {
+		History: func() *[]apimodel.History {
 			if !stubHistory {
 				return nil
 			}
 
-			h := make([]any, 0)
+			h := make([]apimodel.History, 0)
 			return &h
 		}(),
 		Following: following,
@@ -844,6 +956,23 @@ func (c *Converter) StatusToAPIStatus(
 		apiStatus.Reblog.Account = boostAcct
 	}
 
+	// Convert quoted status (if set and
+	// visible to requester) to API model,
+	// attaching it to the boosted status
+	// instead in the case of a boost.
+	quoting, apiQuoting := s, apiStatus
+	if apiStatus.Reblog != nil {
+		quoting, apiQuoting = s.BoostOf, apiStatus.Reblog.Status
+	}
+
+	apiQuoting.Quote, err = c.statusQuoteToAPIStatus(ctx,
+		quoting,
+		requestingAccount,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// Normalize status for API by pruning
 	// attachments that were not locally
 	// stored, replacing them with a helpful
@@ -859,6 +988,60 @@ func (c *Converter) StatusToAPIStatus(
 	return apiStatus, nil
 }
 
+// statusQuoteToAPIStatus converts the status quoted by
+// the given status to its API model representation.
+//
+// Nil is returned if the status does not quote anything,
+// if the quoted status is not (or no longer) stored, or
+// if it's not visible to the requesting account.
+func (c *Converter) statusQuoteToAPIStatus(
+	ctx context.Context,
+	s *gtsmodel.Status,
+	requestingAccount *gtsmodel.Account,
+) (*apimodel.StatusQuoted, error) {
+	if s.Quote == nil {
+		// Nothing quoted, or
+		// we don't have it.
+		return nil, nil
+	}
+
+	visible, err := c.visFilter.StatusVisible(ctx, requestingAccount, s.Quote)
+	if err != nil {
+		return nil, gtserror.Newf("error checking quote visibility: %w", err)
+	}
+
+	if !visible {
+		// Don't leak
+		// hidden quotes.
+		return nil, nil
+	}
+
+	// Convert without any filtering, the
+	// quoting status has already been filtered
+	// and there's no sense half-hiding it.
+	quote, err := c.baseStatusToFrontend(ctx,
+		s.Quote,
+		requestingAccount,
+		statusfilter.FilterContextNone,
+		nil,
+		nil,
+	)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quoted status: %w", err)
+	}
+
+	quote.Account, err = c.AccountToAPIAccountPublic(ctx, s.Quote.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting quote acct: %w", err)
+	}
+
+	var aside string
+	aside, quote.MediaAttachments = placeholderAttachments(quote.MediaAttachments)
+	quote.Content += aside
+
+	return &apimodel.StatusQuoted{quote}, nil
+}
+
 // statusToAPIFilterResults applies filters and mutes to a status and returns an API filter result object.
 // The result may be nil if no filters matched.
 // If the status should not be returned at all, it returns the ErrHideStatus error.
@@ -1175,17 +1358,119 @@ func (c *Converter) StatusToWebStatus(
 // Callers should check beforehand whether a requester has permission to view the
 // source of the status, and ensure they're passing only a local status into this function.
 func (c *Converter) StatusToAPIStatusSource(ctx context.Context, s *gtsmodel.Status) (*apimodel.StatusSource, error) {
-	// TODO: remove this when edit support is added.
-	text := "**STATUS EDITS ARE NOT CURRENTLY SUPPORTED IN GOTOSOCIAL (coming in 2024)**\n" +
-		"You can review the original text of your status below, but you will not be able to submit this edit.\n\n---\n\n" + s.Text
-
 	return &apimodel.StatusSource{
 		ID:          s.ID,
-		Text:        text,
+		Text:        s.Text,
 		SpoilerText: s.ContentWarning,
 	}, nil
 }
 
+// StatusToAPIEdits converts a status and its historical edits
+// into a slice of frontend API model status edits, oldest first.
+// The final entry in the returned slice is the current version.
+func (c *Converter) StatusToAPIEdits(ctx context.Context, s *gtsmodel.Status) ([]*apimodel.StatusEdit, error) {
+	// Ensure that status is populated,
+	// this also populates status edits.
+	if err := c.state.DB.PopulateStatus(ctx, s); err != nil {
+		return nil, gtserror.Newf("error populating status: %w", err)
+	}
+
+	// Edits are authored by the status author.
+	apiAccount, err := c.AccountToAPIAccountPublic(ctx, s.Account)
+	if err != nil {
+		return nil, gtserror.Newf("error converting account: %w", err)
+	}
+
+	// Status edits don't track emojis, so
+	// use the emojis of the latest version.
+	apiEmojis, err := c.convertEmojisToAPIEmojis(ctx, s.Emojis, s.EmojiIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status emojis: %v", err)
+	}
+
+	// Preallocate slice for all the edits, plus current version.
+	apiEdits := make([]*apimodel.StatusEdit, 0, len(s.Edits)+1)
+
+	for _, edit := range s.Edits {
+		apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, edit.Attachments, edit.AttachmentIDs)
+		if err != nil {
+			log.Errorf(ctx, "error converting edit attachments: %v", err)
+		}
+
+		// Restore the descriptions of attached
+		// media as they were at time of edit.
+		for i, desc := range edit.AttachmentDescriptions {
+			if i < len(apiAttachments) {
+				apiAttachments[i].Description = util.Ptr(desc)
+			}
+		}
+
+		apiEdits = append(apiEdits, &apimodel.StatusEdit{
+			Content:          edit.Content,
+			SpoilerText:      edit.ContentWarning,
+			Sensitive:        util.PtrOrZero(edit.Sensitive),
+			CreatedAt:        util.FormatISO8601(edit.CreatedAt),
+			Account:          apiAccount,
+			Poll:             editPollToAPIPoll(edit.PollOptions, edit.PollVotes, apiEmojis),
+			MediaAttachments: apiAttachments,
+			Emojis:           apiEmojis,
+		})
+	}
+
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(ctx, s.Attachments, s.AttachmentIDs)
+	if err != nil {
+		log.Errorf(ctx, "error converting status attachments: %v", err)
+	}
+
+	// Latest version is either when the status
+	// was last edited, or when it was created.
+	createdAt := s.EditedAt
+	if createdAt.IsZero() {
+		createdAt = s.CreatedAt
+	}
+
+	var apiPoll *apimodel.Poll
+	if s.Poll != nil {
+		apiPoll = editPollToAPIPoll(s.Poll.Options, nil, apiEmojis)
+	}
+
+	// Finally, append the current version of the status.
+	apiEdits = append(apiEdits, &apimodel.StatusEdit{
+		Content:          s.Content,
+		SpoilerText:      s.ContentWarning,
+		Sensitive:        util.PtrOrZero(s.Sensitive),
+		CreatedAt:        util.FormatISO8601(createdAt),
+		Account:          apiAccount,
+		Poll:             apiPoll,
+		MediaAttachments: apiAttachments,
+		Emojis:           apiEmojis,
+	})
+
+	return apiEdits, nil
+}
+
+// editPollToAPIPoll returns a minimal frontend API model poll
+// representation for a status edit, containing only options and
+// (if provided) the vote counts at the time of the edit.
+func editPollToAPIPoll(options []string, votes []int, emojis []apimodel.Emoji) *apimodel.Poll {
+	if len(options) == 0 {
+		return nil
+	}
+
+	apiOptions := make([]apimodel.PollOption, len(options))
+	for i, title := range options {
+		apiOptions[i].Title = title
+		if i < len(votes) {
+			apiOptions[i].VotesCount = util.Ptr(votes[i])
+		}
+	}
+
+	return &apimodel.Poll{
+		Options: apiOptions,
+		Emojis:  emojis,
+	}
+}
+
 // statusToFrontend is a package internal function for
 // parsing a status into its initial frontend representation.
 //
@@ -1328,9 +1613,10 @@ func (c *Converter) baseStatusToFrontend(
 	apiStatus := &apimodel.Status{
 		ID:                 s.ID,
 		CreatedAt:          util.FormatISO8601(s.CreatedAt),
+		EditedAt:           nil, // Set below.
 		InReplyToID:        nil, // Set below.
 		InReplyToAccountID: nil, // Set below.
-		Sensitive:          *s.Sensitive,
+		Sensitive:          statusSensitive(s),
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
 		LocalOnly:          s.IsLocalOnly(),
@@ -1354,6 +1640,10 @@ func (c *Converter) baseStatusToFrontend(
 	}
 
 	// Nullable fields.
+	if !s.EditedAt.IsZero() {
+		apiStatus.EditedAt = util.Ptr(util.FormatISO8601(s.EditedAt))
+	}
+
 	if s.InReplyToID != "" {
 		apiStatus.InReplyToID = util.Ptr(s.InReplyToID)
 	}
@@ -1475,6 +1765,34 @@ func (c *Converter) InstanceRuleToAdminAPIRule(r *gtsmodel.Rule) *apimodel.Admin
 	}
 }
 
+// MediaHashBlockToAdminAPIMediaHashBlock converts a
+// gts media hash block into its admin api equivalent.
+func (c *Converter) MediaHashBlockToAdminAPIMediaHashBlock(b *gtsmodel.MediaHashBlock) *apimodel.AdminMediaHashBlock {
+	return &apimodel.AdminMediaHashBlock{
+		ID:        b.ID,
+		CreatedAt: util.FormatISO8601(b.CreatedAt),
+		UpdatedAt: util.FormatISO8601(b.UpdatedAt),
+		Hash:      b.Hash,
+		Threshold: b.Threshold,
+		Comment:   b.Comment,
+		CreatedBy: b.CreatedByAccountID,
+	}
+}
+
+// RelayToAdminAPIRelay converts a gts relay
+// subscription into its admin api equivalent.
+func (c *Converter) RelayToAdminAPIRelay(r *gtsmodel.Relay) *apimodel.AdminRelay {
+	return &apimodel.AdminRelay{
+		ID:        r.ID,
+		CreatedAt: util.FormatISO8601(r.CreatedAt),
+		UpdatedAt: util.FormatISO8601(r.UpdatedAt),
+		InboxURL:  r.InboxURI,
+		ActorURL:  r.ActorURI,
+		Mode:      string(r.Mode),
+		State:     string(r.State),
+	}
+}
+
 // InstanceToAPIV1Instance converts a gts instance into its api equivalent for serving at /api/v1/instance
 func (c *Converter) InstanceToAPIV1Instance(ctx context.Context, i *gtsmodel.Instance) (*apimodel.InstanceV1, error) {
 	instance := &apimodel.InstanceV1{
@@ -1670,6 +1988,12 @@ func (c *Converter) InstanceToAPIV2Instance(ctx context.Context, i *gtsmodel.Ins
 	instance.Configuration.Emojis.EmojiSizeLimit = int(config.GetMediaEmojiLocalMaxSize())
 	instance.Configuration.OIDCEnabled = config.GetOIDCEnabled()
 
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error getting VAPID key pair: %w", err)
+	}
+	instance.Configuration.VAPID.PublicKey = vapidKeyPair.Public
+
 	// registrations
 	instance.Registrations.Enabled = config.GetAccountsRegistrationOpen()
 	instance.Registrations.ApprovalRequired = true // always required
@@ -1881,9 +2205,44 @@ func (c *Converter) DomainPermToAPIDomainPerm(
 	domainPerm.CreatedBy = d.GetCreatedByAccountID()
 	domainPerm.CreatedAt = util.FormatISO8601(d.GetCreatedAt())
 
+	// Drafts can be of either permission
+	// type, so let the caller know which.
+	if _, ok := d.(*gtsmodel.DomainPermissionDraft); ok {
+		domainPerm.PermissionType = d.GetType().String()
+	}
+
 	return domainPerm, nil
 }
 
+// DomainPermSubToAPIDomainPermSub converts the given
+// gts model domain permission subscription to an api model.
+func (c *Converter) DomainPermSubToAPIDomainPermSub(
+	sub *gtsmodel.DomainPermissionSubscription,
+) *apimodel.DomainPermissionSubscription {
+	apiSub := &apimodel.DomainPermissionSubscription{
+		ID:             sub.ID,
+		Priority:       sub.Priority,
+		Title:          sub.Title,
+		PermissionType: sub.PermissionType.String(),
+		AsDraft:        util.PtrOrValue(sub.AsDraft, true),
+		CreatedBy:      sub.CreatedByAccountID,
+		CreatedAt:      util.FormatISO8601(sub.CreatedAt),
+		URI:            sub.URI,
+		ContentType:    string(sub.ContentType),
+		Error:          sub.Error,
+	}
+
+	if !sub.FetchedAt.IsZero() {
+		apiSub.FetchedAt = util.FormatISO8601(sub.FetchedAt)
+	}
+
+	if !sub.SuccessfullyFetchedAt.IsZero() {
+		apiSub.SuccessfullyFetchedAt = util.FormatISO8601(sub.SuccessfullyFetchedAt)
+	}
+
+	return apiSub
+}
+
 // ReportToAPIReport converts a gts model report into an api model report, for serving at /api/v1/reports
 func (c *Converter) ReportToAPIReport(ctx context.Context, r *gtsmodel.Report) (*apimodel.Report, error) {
 	report := &apimodel.Report{
@@ -1930,6 +2289,8 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenAt        *string
 		actionTakenComment   *string
 		actionTakenByAccount *apimodel.AdminAccountInfo
+		assignedAccount      *apimodel.AdminAccountInfo
+		forwardedAt          *string
 	)
 
 	if !r.ActionTakenAt.IsZero() {
@@ -1937,6 +2298,17 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		actionTakenAt = &ata
 	}
 
+	if !r.ForwardedAt.IsZero() {
+		fa := util.FormatISO8601(r.ForwardedAt)
+		forwardedAt = &fa
+	}
+
+	forwardedDomains := r.ForwardedDomains
+	if forwardedDomains == nil {
+		// Serialize as empty array.
+		forwardedDomains = []string{}
+	}
+
 	if r.Account == nil {
 		r.Account, err = c.state.DB.GetAccountByID(ctx, r.AccountID)
 		if err != nil {
@@ -1973,6 +2345,20 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		}
 	}
 
+	if r.AssignedAccountID != "" {
+		if r.AssignedAccount == nil {
+			r.AssignedAccount, err = c.state.DB.GetAccountByID(ctx, r.AssignedAccountID)
+			if err != nil {
+				return nil, fmt.Errorf("ReportToAdminAPIReport: error getting assigned account with id %s from the db: %w", r.AssignedAccountID, err)
+			}
+		}
+
+		assignedAccount, err = c.AccountToAdminAPIAccount(ctx, r.AssignedAccount)
+		if err != nil {
+			return nil, fmt.Errorf("ReportToAdminAPIReport: error converting assigned account with id %s to adminAPIAccount: %w", r.AssignedAccountID, err)
+		}
+	}
+
 	statuses := make([]*apimodel.Status, 0, len(r.StatusIDs))
 	if len(r.StatusIDs) != 0 && len(r.Statuses) == 0 {
 		r.Statuses, err = c.state.DB.GetStatusesByIDs(ctx, r.StatusIDs)
@@ -2010,14 +2396,17 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 		ID:                   r.ID,
 		ActionTaken:          !r.ActionTakenAt.IsZero(),
 		ActionTakenAt:        actionTakenAt,
+		State:                r.State.String(),
 		Category:             "other", // todo: only support default 'other' category right now
 		Comment:              r.Comment,
 		Forwarded:            *r.Forwarded,
+		ForwardedDomains:     forwardedDomains,
+		ForwardedAt:          forwardedAt,
 		CreatedAt:            util.FormatISO8601(r.CreatedAt),
 		UpdatedAt:            util.FormatISO8601(r.UpdatedAt),
 		Account:              account,
 		TargetAccount:        targetAccount,
-		AssignedAccount:      actionTakenByAccount,
+		AssignedAccount:      assignedAccount,
 		ActionTakenByAccount: actionTakenByAccount,
 		ActionTakenComment:   actionTakenComment,
 		Statuses:             statuses,
@@ -2025,6 +2414,42 @@ func (c *Converter) ReportToAdminAPIReport(ctx context.Context, r *gtsmodel.Repo
 	}, nil
 }
 
+// ReportNoteToAdminAPIReportNote converts a gts model report
+// note into an admin view report note, for serving at
+// /api/v1/admin/reports/{id}/notes.
+func (c *Converter) ReportNoteToAdminAPIReportNote(ctx context.Context, n *gtsmodel.ReportNote) (*apimodel.AdminReportNote, error) {
+	var err error
+
+	if n.Account == nil {
+		n.Account, err = c.state.DB.GetAccountByID(ctx, n.AccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("error getting account %s: %w", n.AccountID, err)
+		}
+	}
+
+	var account *apimodel.AdminAccountInfo
+	if n.Account != nil {
+		account, err = c.AccountToAdminAPIAccount(ctx, n.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", n.AccountID, err)
+		}
+	}
+
+	var inReplyToID *string
+	if n.InReplyToID != "" {
+		inReplyToID = util.Ptr(n.InReplyToID)
+	}
+
+	return &apimodel.AdminReportNote{
+		ID:          n.ID,
+		CreatedAt:   util.FormatISO8601(n.CreatedAt),
+		ReportID:    n.ReportID,
+		Account:     account,
+		InReplyToID: inReplyToID,
+		Content:     n.Content,
+	}, nil
+}
+
 // ListToAPIList converts one gts model list into an api model list, for serving at /api/v1/lists/{id}
 func (c *Converter) ListToAPIList(ctx context.Context, l *gtsmodel.List) (*apimodel.List, error) {
 	return &apimodel.List{
@@ -2663,3 +3088,298 @@ func (c *Converter) InteractionReqToAPIInteractionReq(
 		URI:        req.URI,
 	}, nil
 }
+
+// ScheduledStatusToAPIScheduledStatus converts one GTS
+// model scheduled status into an API scheduled status.
+func (c *Converter) ScheduledStatusToAPIScheduledStatus(
+	ctx context.Context,
+	scheduledStatus *gtsmodel.ScheduledStatus,
+) (*apimodel.ScheduledStatus, error) {
+	apiAttachments, err := c.convertAttachmentsToAPIAttachments(
+		ctx,
+		scheduledStatus.MediaAttachments,
+		scheduledStatus.MediaIDs,
+	)
+	if err != nil {
+		log.Errorf(ctx, "error converting scheduled status attachments: %v", err)
+	}
+
+	// Deref attachment ptrs.
+	mediaAttachments := make([]apimodel.Attachment, 0, len(apiAttachments))
+	for _, apiAttachment := range apiAttachments {
+		mediaAttachments = append(mediaAttachments, *apiAttachment)
+	}
+
+	params := &apimodel.StatusParams{
+		Text:           scheduledStatus.Text,
+		InReplyToID:    scheduledStatus.InReplyToID,
+		QuotedStatusID: scheduledStatus.QuoteID,
+		MediaIDs:       scheduledStatus.MediaIDs,
+		Sensitive:      util.PtrOrValue(scheduledStatus.Sensitive, false),
+		SpoilerText:    scheduledStatus.SpoilerText,
+		Visibility:     string(c.VisToAPIVis(ctx, scheduledStatus.Visibility)),
+		LocalOnly:      util.PtrOrValue(scheduledStatus.LocalOnly, false),
+		Language:       scheduledStatus.Language,
+		ApplicationID:  scheduledStatus.ApplicationID,
+	}
+
+	if len(scheduledStatus.PollOptions) > 0 {
+		params.Poll = &apimodel.StatusParamsPoll{
+			Options:    scheduledStatus.PollOptions,
+			ExpiresIn:  scheduledStatus.PollExpiresIn,
+			Multiple:   util.PtrOrValue(scheduledStatus.PollMultiple, false),
+			HideTotals: util.PtrOrValue(scheduledStatus.PollHideTotals, false),
+		}
+	}
+
+	return &apimodel.ScheduledStatus{
+		ID:               scheduledStatus.ID,
+		ScheduledAt:      util.FormatISO8601(scheduledStatus.ScheduledAt),
+		Params:           params,
+		MediaAttachments: mediaAttachments,
+	}, nil
+}
+
+// WebPushSubscriptionToAPIWebPushSubscription converts
+// one GTS model Web Push subscription into an API model.
+func (c *Converter) WebPushSubscriptionToAPIWebPushSubscription(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+) (*apimodel.PushSubscription, error) {
+	vapidKeyPair, err := c.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return nil, gtserror.Newf("error getting VAPID key pair: %w", err)
+	}
+
+	flags := subscription.NotificationFlags
+	return &apimodel.PushSubscription{
+		ID:        subscription.ID,
+		Endpoint:  subscription.Endpoint,
+		ServerKey: vapidKeyPair.Public,
+		Alerts: apimodel.PushSubscriptionAlerts{
+			Follow:           flags.Get(gtsmodel.NotificationFollow),
+			FollowRequest:    flags.Get(gtsmodel.NotificationFollowRequest),
+			Favourite:        flags.Get(gtsmodel.NotificationFave),
+			Mention:          flags.Get(gtsmodel.NotificationMention),
+			Reblog:           flags.Get(gtsmodel.NotificationReblog),
+			Poll:             flags.Get(gtsmodel.NotificationPoll),
+			Status:           flags.Get(gtsmodel.NotificationStatus),
+			AdminSignup:      flags.Get(gtsmodel.NotificationSignup),
+			PendingFavourite: flags.Get(gtsmodel.NotificationPendingFave),
+			PendingReply:     flags.Get(gtsmodel.NotificationPendingReply),
+			PendingReblog:    flags.Get(gtsmodel.NotificationPendingReblog),
+		},
+		Policy: string(subscription.Policy),
+	}, nil
+}
+
+// TrendToAPIHistory converts the daily usage counts
+// of a gts trend into api history entries, newest
+// first, with days counted back from ComputedAt.
+func (c *Converter) TrendToAPIHistory(t *gtsmodel.Trend) []apimodel.History {
+	history := make([]apimodel.History, 0, len(t.Uses))
+	today := t.ComputedAt.UTC().Truncate(24 * time.Hour)
+
+	for i, uses := range t.Uses {
+		var accounts int
+		if i < len(t.Accounts) {
+			accounts = t.Accounts[i]
+		}
+
+		day := today.Add(-time.Duration(i) * 24 * time.Hour)
+		history = append(history, apimodel.History{
+			Day:      strconv.FormatInt(day.Unix(), 10),
+			Uses:     strconv.Itoa(uses),
+			Accounts: strconv.Itoa(accounts),
+		})
+	}
+
+	return history
+}
+
+// TrendToAPITag converts a gts tag trend, and
+// the trending tag, into an api tag with history.
+func (c *Converter) TrendToAPITag(ctx context.Context, t *gtsmodel.Trend, tag *gtsmodel.Tag) (*apimodel.Tag, error) {
+	apiTag, err := c.TagToAPITag(ctx, tag, false, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	history := c.TrendToAPIHistory(t)
+	apiTag.History = &history
+	return &apiTag, nil
+}
+
+// TrendToAPITrendsLink converts a gts link
+// trend into its api representation. As links
+// aren't dereferenced, the card is minimal.
+func (c *Converter) TrendToAPITrendsLink(t *gtsmodel.Trend) *apimodel.TrendsLink {
+	var provider string
+	if u, err := url.Parse(t.Target); err == nil {
+		provider = u.Host
+	}
+
+	return &apimodel.TrendsLink{
+		Card: apimodel.Card{
+			URL:          t.Target,
+			Title:        t.Target,
+			Type:         "link",
+			ProviderName: provider,
+		},
+		History: c.TrendToAPIHistory(t),
+	}
+}
+
+// TrendToAdminAPITrend converts a gts trend
+// into its admin api representation, including
+// the trending tag, status, or link.
+func (c *Converter) TrendToAdminAPITrend(
+	ctx context.Context,
+	t *gtsmodel.Trend,
+	requester *gtsmodel.Account,
+) (*apimodel.AdminTrend, error) {
+	apiTrend := &apimodel.AdminTrend{
+		ID:        t.ID,
+		Type:      string(t.Type),
+		State:     string(t.State),
+		Score:     t.Score,
+		CreatedAt: util.FormatISO8601(t.CreatedAt),
+	}
+
+	if !t.ReviewedAt.IsZero() {
+		apiTrend.ReviewedAt = util.FormatISO8601(t.ReviewedAt)
+	}
+
+	switch t.Type {
+	case gtsmodel.TrendTypeTag:
+		tag, err := c.state.DB.GetTag(ctx, t.Target)
+		if err != nil {
+			return nil, gtserror.Newf("error getting tag %s: %w", t.Target, err)
+		}
+
+		apiTrend.Tag, err = c.TrendToAPITag(ctx, t, tag)
+		if err != nil {
+			return nil, gtserror.Newf("error converting tag %s: %w", t.Target, err)
+		}
+
+	case gtsmodel.TrendTypeStatus:
+		status, err := c.state.DB.GetStatusByID(ctx, t.Target)
+		if err != nil {
+			return nil, gtserror.Newf("error getting status %s: %w", t.Target, err)
+		}
+
+		apiTrend.Status, err = c.StatusToAPIStatus(ctx,
+			status,
+			requester,
+			statusfilter.FilterContextNone,
+			nil,
+			nil,
+		)
+		if err != nil {
+			return nil, gtserror.Newf("error converting status %s: %w", t.Target, err)
+		}
+
+	case gtsmodel.TrendTypeLink:
+		apiTrend.Link = c.TrendToAPITrendsLink(t)
+	}
+
+	return apiTrend, nil
+}
+
+// InstanceToAdminAPIInstanceReputation converts the given
+// instance to the admin view of its reputation.
+func (c *Converter) InstanceToAdminAPIInstanceReputation(
+	ctx context.Context,
+	i *gtsmodel.Instance,
+) (*apimodel.AdminInstanceReputation, error) {
+	reputation := i.EffectiveReputation()
+
+	federation := "normal"
+	switch {
+	case !config.GetInstanceFederationReputation():
+		// Reputation-based
+		// federation disabled.
+	case reputation < int64(config.GetInstanceFederationReputationQuarantineThreshold()):
+		federation = "quarantine"
+	case reputation < int64(config.GetInstanceFederationReputationSlowThreshold()):
+		federation = "slow"
+	}
+
+	apiReputation := &apimodel.AdminInstanceReputation{
+		ID:                 i.ID,
+		Domain:             i.Domain,
+		Reputation:         reputation,
+		ComputedReputation: i.Reputation,
+		Override:           i.ReputationOverride,
+		Federation:         federation,
+		SpamHits:           i.SpamHits,
+		FirstContactAt:     util.FormatISO8601(i.CreatedAt),
+	}
+
+	if !i.ReputationComputedAt.IsZero() {
+		apiReputation.ComputedAt = util.FormatISO8601(i.ReputationComputedAt)
+	}
+
+	return apiReputation, nil
+}
+
+// AuditLogEntryToAdminAPIAuditLogEntry converts a gts model audit log
+// entry into its admin api representation, for serving at /api/v1/admin/audit_log.
+func (c *Converter) AuditLogEntryToAdminAPIAuditLogEntry(
+	ctx context.Context,
+	e *gtsmodel.AuditLogEntry,
+) (*apimodel.AdminAuditLogEntry, error) {
+	if e.Account == nil {
+		// Fetch the account that performed the action.
+		account, err := c.state.DB.GetAccountByID(ctx, e.AccountID)
+		if err != nil && !errors.Is(err, db.ErrNoEntries) {
+			return nil, gtserror.Newf("db error getting account %s: %w", e.AccountID, err)
+		}
+		e.Account = account
+	}
+
+	entry := &apimodel.AdminAuditLogEntry{
+		ID:         e.ID,
+		CreatedAt:  util.FormatISO8601(e.CreatedAt),
+		Action:     e.Action,
+		TargetType: string(e.TargetType),
+		TargetID:   e.TargetID,
+	}
+
+	if e.Account != nil {
+		account, err := c.AccountToAPIAccountPublic(ctx, e.Account)
+		if err != nil {
+			return nil, gtserror.Newf("error converting account %s: %w", e.AccountID, err)
+		}
+		entry.Account = account
+	}
+
+	if e.Before != "" {
+		if err := json.Unmarshal([]byte(e.Before), &entry.Before); err != nil {
+			return nil, gtserror.Newf("error decoding before snapshot of %s: %w", e.ID, err)
+		}
+	}
+
+	if e.After != "" {
+		if err := json.Unmarshal([]byte(e.After), &entry.After); err != nil {
+			return nil, gtserror.Newf("error decoding after snapshot of %s: %w", e.ID, err)
+		}
+	}
+
+	// Gather the top-level keys whose values
+	// differ between before and after snapshots.
+	entry.Changes = make([]string, 0)
+	for key, before := range entry.Before {
+		if after, ok := entry.After[key]; !ok || !reflect.DeepEqual(before, after) {
+			entry.Changes = append(entry.Changes, key)
+		}
+	}
+	for key := range entry.After {
+		if _, ok := entry.Before[key]; !ok {
+			entry.Changes = append(entry.Changes, key)
+		}
+	}
+	slices.Sort(entry.Changes)
+
+	return entry, nil
+}
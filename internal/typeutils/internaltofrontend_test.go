@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -53,6 +54,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontend() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -103,6 +105,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -146,6 +149,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendAliasedAndMoved()
     "locked": true,
     "discoverable": false,
     "bot": false,
+    "group": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
     "url": "http://localhost:8080/@1happyturtle",
@@ -197,6 +201,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiStruct()
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -244,6 +249,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendWithEmojiIDs() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -287,6 +293,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendSensitive() {
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2022-05-20T11:09:18.000Z",
   "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
   "url": "http://localhost:8080/@the_mighty_zork",
@@ -342,6 +349,7 @@ func (suite *InternalToFrontendTestSuite) TestAccountToFrontendPublicPunycode()
   "locked": false,
   "discoverable": false,
   "bot": false,
+  "group": false,
   "created_at": "2020-08-10T12:13:28.000Z",
   "note": "",
   "url": "https://xn--xample-ova.org/users/@%C3%BCser",
@@ -380,6 +388,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendPubl
   "locked": false,
   "discoverable": true,
   "bot": false,
+  "group": false,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
   "url": "http://localhost:8080/@localhost:8080",
@@ -418,6 +427,7 @@ func (suite *InternalToFrontendTestSuite) TestLocalInstanceAccountToFrontendBloc
   "locked": false,
   "discoverable": false,
   "bot": false,
+  "group": false,
   "created_at": "2020-05-17T13:10:59.000Z",
   "note": "",
   "url": "http://localhost:8080/@localhost:8080",
@@ -464,6 +474,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -476,6 +487,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -641,6 +653,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: ! fnord",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -653,6 +666,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredStatusToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -826,6 +840,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
     "pinned": false,
     "content": "hello world! #welcome ! first post on the instance :rainbow: ! fnord",
     "reblog": null,
+    "quote": null,
     "application": {
       "name": "superseriousbusiness",
       "website": "https://superserious.business"
@@ -838,6 +853,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-20T11:09:18.000Z",
       "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
       "url": "http://localhost:8080/@the_mighty_zork",
@@ -955,6 +971,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
       }
     }
   },
+  "quote": null,
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -967,6 +984,7 @@ func (suite *InternalToFrontendTestSuite) TestWarnFilteredBoostToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -1206,6 +1224,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
   "pinned": false,
   "content": "\u003cp\u003ehi \u003cspan class=\"h-card\"\u003e\u003ca href=\"http://localhost:8080/@admin\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e@\u003cspan\u003eadmin\u003c/span\u003e\u003c/a\u003e\u003c/span\u003e here's some media for ya\u003c/p\u003e\u003chr\u003e\u003cp\u003e\u003ci lang=\"en\"\u003eℹ️ Note from localhost:8080: 2 attachments in this status were not downloaded. Treat the following external links with care:\u003c/i\u003e\u003c/p\u003e\u003cul\u003e\u003cli\u003e\u003ca href=\"http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE7ZGJYTSYMXF927GF9353KR.svg\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e01HE7ZGJYTSYMXF927GF9353KR.svg\u003c/a\u003e [SVG line art of a sloth, public domain]\u003c/li\u003e\u003cli\u003e\u003ca href=\"http://example.org/fileserver/01HE7Y659ZWZ02JM4AWYJZ176Q/attachment/original/01HE892Y8ZS68TQCNPX7J888P3.mp3\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e01HE892Y8ZS68TQCNPX7J888P3.mp3\u003c/a\u003e [Jolly salsa song, public domain.]\u003c/li\u003e\u003c/ul\u003e",
   "reblog": null,
+  "quote": null,
   "account": {
     "id": "01FHMQX3GAABWSM0S2VZEC2SWC",
     "username": "Some_User",
@@ -1214,6 +1233,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownAttachments
     "locked": true,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
     "url": "http://example.org/@Some_User",
@@ -1337,6 +1357,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
   "pinned": false,
   "content": "\u003cp\u003ehi \u003cspan class=\"h-card\"\u003e\u003ca href=\"http://localhost:8080/@admin\" class=\"u-url mention\" rel=\"nofollow noreferrer noopener\" target=\"_blank\"\u003e@\u003cspan\u003eadmin\u003c/span\u003e\u003c/a\u003e\u003c/span\u003e here's some media for ya\u003c/p\u003e",
   "reblog": null,
+  "quote": null,
   "mentions": [
     {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -1377,6 +1398,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
     "locked": true,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2020-08-10T12:13:28.000Z",
     "note": "i'm a real son of a gun",
     "url": "http://example.org/@Some_User",
@@ -1465,6 +1487,32 @@ func (suite *InternalToFrontendTestSuite) TestStatusToWebStatus() {
 }`, string(b))
 }
 
+func (suite *InternalToFrontendTestSuite) TestStatusToFrontendSensitized() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["admin_account"]
+	testAccount.SensitizedAt = time.Now()
+
+	// Status with media by a sensitized
+	// account should be marked sensitive.
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_1"]
+	testStatus.Account = testAccount
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	apiStatus, err := suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	suite.NoError(err)
+	suite.True(apiStatus.Sensitive)
+
+	// Status without media should be left alone.
+	testStatus = &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["admin_account_status_3"]
+	testStatus.Account = testAccount
+
+	apiStatus, err = suite.typeconverter.StatusToAPIStatus(context.Background(), testStatus, requestingAccount, statusfilter.FilterContextNone, nil, nil)
+	suite.NoError(err)
+	suite.False(apiStatus.Sensitive)
+}
+
 func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage() {
 	testStatus := &gtsmodel.Status{}
 	*testStatus = *suite.testStatuses["admin_account_status_1"]
@@ -1497,6 +1545,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
   "pinned": false,
   "content": "hello world! #welcome ! first post on the instance :rainbow: !",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "superseriousbusiness",
     "website": "https://superserious.business"
@@ -1509,6 +1558,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendUnknownLanguage()
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -1639,6 +1689,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
   "pinned": false,
   "content": "this is a very personal post that I don't want anyone to interact with at all, and i only want mutuals to see it",
   "reblog": null,
+  "quote": null,
   "application": {
     "name": "really cool gts application",
     "website": "https://reallycool.app"
@@ -1651,6 +1702,7 @@ func (suite *InternalToFrontendTestSuite) TestStatusToFrontendPartialInteraction
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-20T11:09:18.000Z",
     "note": "\u003cp\u003ehey yo this is my profile!\u003c/p\u003e",
     "url": "http://localhost:8080/@the_mighty_zork",
@@ -1845,6 +1897,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV1ToFrontend() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2022-05-17T13:10:59.000Z",
     "note": "",
     "url": "http://localhost:8080/@admin",
@@ -1968,6 +2021,9 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
     },
     "emojis": {
       "emoji_size_limit": 51200
+    },
+    "vapid": {
+      "public_key": "BFj4RztYkjHuGHUo53qInqpxqyxGi8Q3neV2_XmVgfB1RfULuWk3CWk9V1nsTwmV9149yOy7XM27YiaO_n9xxnk"
     }
   },
   "registrations": {
@@ -1985,6 +2041,7 @@ func (suite *InternalToFrontendTestSuite) TestInstanceV2ToFrontend() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -2105,6 +2162,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend1() {
     "locked": false,
     "discoverable": true,
     "bot": false,
+    "group": false,
     "created_at": "2021-09-26T10:52:36.000Z",
     "note": "i post about like, i dunno, stuff, or whatever!!!!",
     "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2148,6 +2206,7 @@ func (suite *InternalToFrontendTestSuite) TestReportToFrontend2() {
     "locked": true,
     "discoverable": false,
     "bot": false,
+    "group": false,
     "created_at": "2022-06-04T13:12:00.000Z",
     "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
     "url": "http://localhost:8080/@1happyturtle",
@@ -2188,10 +2247,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
 	suite.Equal(`{
   "id": "01GP3DFY9XQ1TJMZT5BGAZPXX7",
   "action_taken": true,
+  "state": "resolved",
   "action_taken_at": "2022-05-15T15:01:56.000Z",
   "category": "other",
   "comment": "this is a turtle, not a person, therefore should not be a poster",
   "forwarded": true,
+  "forwarded_domains": [],
+  "forwarded_at": null,
   "created_at": "2022-05-15T14:20:12.000Z",
   "updated_at": "2022-05-15T14:20:12.000Z",
   "account": {
@@ -2215,6 +2277,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -2224,6 +2287,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2260,6 +2324,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -2269,6 +2334,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
       "url": "http://localhost:8080/@1happyturtle",
@@ -2318,6 +2384,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -2327,6 +2394,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -2372,6 +2440,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -2381,6 +2450,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend1() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -2422,10 +2492,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
 	suite.Equal(`{
   "id": "01GP3AWY4CRDVRNZKW0TEAMB5R",
   "action_taken": false,
+  "state": "open",
   "action_taken_at": null,
   "category": "other",
   "comment": "dark souls sucks, please yeet this nerd",
   "forwarded": true,
+  "forwarded_domains": [],
+  "forwarded_at": null,
   "created_at": "2022-05-14T10:20:03.000Z",
   "updated_at": "2022-05-14T10:20:03.000Z",
   "account": {
@@ -2449,6 +2522,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -2458,6 +2532,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "\u003cp\u003ei post about things that concern me\u003c/p\u003e",
       "url": "http://localhost:8080/@1happyturtle",
@@ -2507,6 +2582,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -2516,6 +2592,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2555,6 +2632,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
       "pinned": false,
       "content": "dark souls status bot: \"thoughts of dog\"",
       "reblog": null,
+      "quote": null,
       "account": {
         "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
         "username": "foss_satan",
@@ -2563,6 +2641,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontend2() {
         "locked": false,
         "discoverable": true,
         "bot": false,
+        "group": false,
         "created_at": "2021-09-26T10:52:36.000Z",
         "note": "i post about like, i dunno, stuff, or whatever!!!!",
         "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2685,10 +2764,13 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
 	suite.Equal(`{
   "id": "01GP3DFY9XQ1TJMZT5BGAZPXX7",
   "action_taken": true,
+  "state": "resolved",
   "action_taken_at": "2022-05-15T15:01:56.000Z",
   "category": "other",
   "comment": "this is a turtle, not a person, therefore should not be a poster",
   "forwarded": true,
+  "forwarded_domains": [],
+  "forwarded_at": null,
   "created_at": "2022-05-15T14:20:12.000Z",
   "updated_at": "2022-05-15T14:20:12.000Z",
   "account": {
@@ -2712,6 +2794,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": false,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH5ZK5VRH73AKHQM6Y9VNX",
@@ -2721,6 +2804,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2021-09-26T10:52:36.000Z",
       "note": "i post about like, i dunno, stuff, or whatever!!!!",
       "url": "http://fossbros-anonymous.io/@foss_satan",
@@ -2757,6 +2841,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": true,
     "account": {
       "id": "01F8MH5NBDF2MV7CTC4Q5128HF",
@@ -2766,6 +2851,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": true,
       "discoverable": false,
       "bot": false,
+      "group": false,
       "created_at": "2022-06-04T13:12:00.000Z",
       "note": "",
       "url": "http://localhost:8080/@1happyturtle",
@@ -2804,6 +2890,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -2813,6 +2900,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -2858,6 +2946,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
     "approved": true,
     "disabled": false,
     "silenced": false,
+    "sensitized": false,
     "suspended": false,
     "account": {
       "id": "01F8MH17FWEB39HZJ76B6VXSKF",
@@ -2867,6 +2956,7 @@ func (suite *InternalToFrontendTestSuite) TestAdminReportToFrontendSuspendedLoca
       "locked": false,
       "discoverable": true,
       "bot": false,
+      "group": false,
       "created_at": "2022-05-17T13:10:59.000Z",
       "note": "",
       "url": "http://localhost:8080/@admin",
@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 // toAPISize converts a set of media dimensions
@@ -101,6 +102,21 @@ func (c *Converter) interactionsWithStatusForAccount(ctx context.Context, s *gts
 	return si, nil
 }
 
+// statusSensitive returns whether the given status should
+// be marked as sensitive. This is the case if it was marked
+// sensitive by its author, or if it has attachments and its
+// author has been sensitized (ie., force-marked sensitive)
+// by an admin.
+func statusSensitive(s *gtsmodel.Status) bool {
+	if util.PtrOrZero(s.Sensitive) {
+		return true
+	}
+
+	return len(s.AttachmentIDs) != 0 &&
+		s.Account != nil &&
+		s.Account.IsSensitized()
+}
+
 func misskeyReportInlineURLs(content string) []*url.URL {
 	m := regexes.MisskeyReportNotes.FindAllStringSubmatch(content, -1)
 	urls := make([]*url.URL, 0, len(m))
@@ -30,8 +30,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-// WrapPersonInUpdate ...
-func (c *Converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
+// WrapAccountableInUpdate wraps the given actor
+// representation of originAccount in an Update.
+func (c *Converter) WrapAccountableInUpdate(accountable ap.Accountable, originAccount *gtsmodel.Account) (vocab.ActivityStreamsUpdate, error) {
 	update := streams.NewActivityStreamsUpdate()
 
 	// set the actor
@@ -58,9 +59,11 @@ func (c *Converter) WrapPersonInUpdate(person vocab.ActivityStreamsPerson, origi
 	idProp.SetIRI(idURI)
 	update.SetJSONLDId(idProp)
 
-	// set the person as the object here
+	// set the actor as the object here
 	objectProp := streams.NewActivityStreamsObjectProperty()
-	objectProp.AppendActivityStreamsPerson(person)
+	if err := objectProp.AppendType(accountable); err != nil {
+		return nil, gtserror.Newf("error appending actor: %w", err)
+	}
 	update.SetActivityStreamsObject(objectProp)
 
 	// to should be public
@@ -37,11 +37,13 @@ const (
 	CollectionsPath  = "collections"   // CollectionsPath represents the activitypub collections location
 	FeaturedPath     = "featured"      // FeaturedPath represents the activitypub featured location
 	PublicKeyPath    = "main-key"      // PublicKeyPath is for serving an account's public key
+	Ed25519KeyID     = "ed25519-key"   // Ed25519KeyID is the fragment identifying an account's ed25519 key on the public key path
 	FollowPath       = "follow"        // FollowPath used to generate the URI for an individual follow or follow request
 	UpdatePath       = "updates"       // UpdatePath is used to generate the URI for an account update
 	BlocksPath       = "blocks"        // BlocksPath is used to generate the URI for a block
 	MovesPath        = "moves"         // MovesPath is used to generate the URI for a move
 	ReportsPath      = "reports"       // ReportsPath is used to generate the URI for a report/flag
+	RulesPath        = "rules"         // RulesPath is used to generate the URI for an instance rule referenced by a report/flag
 	ConfirmEmailPath = "confirm_email" // ConfirmEmailPath is used to generate the URI for an email confirmation link
 	FileserverPath   = "fileserver"    // FileserverPath is a path component for serving attachments + media
 	EmojiPath        = "emoji"         // EmojiPath represents the activitypub emoji location
@@ -77,6 +79,8 @@ type UserURIs struct {
 	FeaturedCollectionURI string
 	// The URI for this user's public key, eg., https://example.org/users/example_user/publickey
 	PublicKeyURI string
+	// The URI for this user's ed25519 public key, eg., https://example.org/users/example_user/main-key#ed25519-key
+	Ed25519PublicKeyURI string
 }
 
 // GenerateURIForFollow returns the AP URI for a new follow -- something like:
@@ -130,6 +134,15 @@ func GenerateURIForReport(thisReportID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, ReportsPath, thisReportID)
 }
 
+// URIForRule returns the URI for an instance rule, as
+// referenced by a Flag activity -- something like:
+// https://example.org/rules/01GP3AWY4CRDVRNZKW0TEAMB5R
+func URIForRule(ruleID string) string {
+	protocol := config.GetProtocol()
+	host := config.GetHost()
+	return fmt.Sprintf("%s://%s/%s/%s", protocol, host, RulesPath, ruleID)
+}
+
 // GenerateURIForEmailConfirm returns a link for email confirmation -- something like:
 // https://example.org/confirm_email?token=490e337c-0162-454f-ac48-4b22bb92a205
 func GenerateURIForEmailConfirm(token string) string {
@@ -174,6 +187,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 	likedURI := fmt.Sprintf("%s/%s", userURI, LikedPath)
 	collectionURI := fmt.Sprintf("%s/%s/%s", userURI, CollectionsPath, FeaturedPath)
 	publicKeyURI := fmt.Sprintf("%s/%s", userURI, PublicKeyPath)
+	ed25519PublicKeyURI := fmt.Sprintf("%s#%s", publicKeyURI, Ed25519KeyID)
 
 	return &UserURIs{
 		HostURL:     hostURL,
@@ -189,6 +203,7 @@ func GenerateURIsForAccount(username string) *UserURIs {
 		LikedURI:              likedURI,
 		FeaturedCollectionURI: collectionURI,
 		PublicKeyURI:          publicKeyURI,
+		Ed25519PublicKeyURI:   ed25519PublicKeyURI,
 	}
 }
 
@@ -245,6 +260,16 @@ func StoragePathForAttachment(
 	)
 }
 
+// StoragePathForArchive generates a storage
+// path for an account's personal archive.
+//
+// Will produce something like:
+//
+//	"01FPST95B8FC3HG3AGCDKPQNQ2/archive/01J9TZ5CYN9H0Y2V2P0SKA5PAP.zip"
+func StoragePathForArchive(accountID string, archiveID string) string {
+	return accountID + "/archive/" + archiveID + ".zip"
+}
+
 // URIForEmoji generates an
 // ActivityPub URI for an emoji.
 //
@@ -335,6 +360,11 @@ func IsReportPath(id *url.URL) bool {
 	return regexes.ReportPath.MatchString(id.Path)
 }
 
+// IsRulePath returns true if the given URL path corresponds to eg /rules/SOME_ULID_OF_A_RULE
+func IsRulePath(id *url.URL) bool {
+	return regexes.RulePath.MatchString(id.Path)
+}
+
 // IsAcceptsPath returns true if the given URL path corresponds to eg /users/example_username/accepts/SOME_ULID_OF_AN_ACCEPT
 func IsAcceptsPath(id *url.URL) bool {
 	return regexes.AcceptsPath.MatchString(id.Path)
@@ -452,3 +482,14 @@ func ParseReportPath(id *url.URL) (ulid string, err error) {
 	ulid = matches[1]
 	return
 }
+
+// ParseRulePath returns the ulid from a path such as /rules/SOME_ULID_OF_A_RULE
+func ParseRulePath(id *url.URL) (ulid string, err error) {
+	matches := regexes.RulePath.FindStringSubmatch(id.Path)
+	if len(matches) != 2 {
+		err = fmt.Errorf("expected 2 matches but matches length was %d", len(matches))
+		return
+	}
+	ulid = matches[1]
+	return
+}
@@ -46,6 +46,7 @@ const (
 	maximumListTitleLength        = 200
 	maximumFilterKeywordLength    = 40
 	maximumFilterTitleLength      = 200
+	maximumReportNoteLength       = 5000
 )
 
 // Password returns a helpful error if the given password
@@ -292,6 +293,19 @@ func ListTitle(title string) error {
 	return nil
 }
 
+// ReportNoteContent validates the content of a new moderator note on a report.
+func ReportNoteContent(content string) error {
+	if content == "" {
+		return fmt.Errorf("note content must be provided, and must be no more than %d chars", maximumReportNoteLength)
+	}
+
+	if length := len([]rune(content)); length > maximumReportNoteLength {
+		return fmt.Errorf("note content length must be no more than %d chars, provided content was %d chars", maximumReportNoteLength, length)
+	}
+
+	return nil
+}
+
 // ListRepliesPolicy validates the replies_policy of a new or updated list.
 func ListRepliesPolicy(repliesPolicy gtsmodel.RepliesPolicy) error {
 	switch repliesPolicy {
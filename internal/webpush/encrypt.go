@@ -0,0 +1,301 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	// recordSize is the aes128gcm record size we advertise.
+	// Payloads are always sent as a single record, so they
+	// must fit within this size minus delimiter + GCM tag.
+	recordSize = 4096
+
+	// saltLen is the length of the random salt in bytes.
+	saltLen = 16
+
+	// vapidExpiry is the validity period of VAPID JWTs.
+	// RFC 8292 says this must be at most 24 hours.
+	vapidExpiry = 12 * time.Hour
+)
+
+// encrypt encrypts the given plaintext payload for the given subscription,
+// per RFC 8291, returning a request body in RFC 8188 aes128gcm encoding.
+func encrypt(subscription *gtsmodel.WebPushSubscription, plaintext []byte) ([]byte, error) {
+	// Decode the user agent's
+	// auth secret and public key.
+	authSecret, err := decodeBase64URL(subscription.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding auth secret: %w", err)
+	}
+
+	p256dh, err := decodeBase64URL(subscription.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding p256dh key: %w", err)
+	}
+
+	uaPublic, err := ecdh.P256().NewPublicKey(p256dh)
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	// Generate an ephemeral key pair
+	// for this application server message.
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("error generating ephemeral key: %w", err)
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("error generating salt: %w", err)
+	}
+
+	return encryptWithKey(authSecret, uaPublic, asPrivate, salt, plaintext)
+}
+
+// encryptWithKey encrypts the given plaintext payload for the user agent
+// with given auth secret and public key, using the given application
+// server ephemeral key pair and salt. Split out from encrypt so that
+// it can be checked against the RFC 8291 test vector.
+func encryptWithKey(
+	authSecret []byte,
+	uaPublic *ecdh.PublicKey,
+	asPrivate *ecdh.PrivateKey,
+	salt []byte,
+	plaintext []byte,
+) ([]byte, error) {
+	asPublic := asPrivate.PublicKey().Bytes()
+
+	ecdhSecret, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("error computing ECDH secret: %w", err)
+	}
+
+	// Derive content encryption key and nonce.
+	cek, nonce := deriveKeys(
+		ecdhSecret,
+		authSecret,
+		uaPublic.Bytes(),
+		asPublic,
+		salt,
+	)
+
+	// Single record, so plaintext is
+	// followed by the last record
+	// delimiter, without padding.
+	record := make([]byte, 0, len(plaintext)+1)
+	record = append(record, plaintext...)
+	record = append(record, 0x02)
+
+	gcm, err := newGCM(cek)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(record)+gcm.Overhead() > recordSize {
+		return nil, fmt.Errorf("payload of %d bytes is too large", len(plaintext))
+	}
+
+	// Prepare the aes128gcm header:
+	// salt || rs || idlen || keyid.
+	body := make([]byte, 0, len(salt)+4+1+len(asPublic)+len(record)+gcm.Overhead())
+	body = append(body, salt...)
+	body = binary.BigEndian.AppendUint32(body, recordSize)
+	body = append(body, byte(len(asPublic)))
+	body = append(body, asPublic...)
+
+	// Append the encrypted record.
+	return gcm.Seal(body, nonce, record, nil), nil
+}
+
+// deriveKeys derives the content encryption key and
+// nonce from the given shared ECDH secret, user agent
+// auth secret, both public keys, and salt, per RFC 8291.
+func deriveKeys(
+	ecdhSecret []byte,
+	authSecret []byte,
+	uaPublic []byte,
+	asPublic []byte,
+	salt []byte,
+) (cek []byte, nonce []byte) {
+	keyInfo := make([]byte, 0, 14+len(uaPublic)+len(asPublic))
+	keyInfo = append(keyInfo, "WebPush: info\x00"...)
+	keyInfo = append(keyInfo, uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+
+	ikm := hkdf(authSecret, ecdhSecret, keyInfo, 32)
+	cek = hkdf(salt, ikm, []byte("Content-Encoding: aes128gcm\x00"), 16)
+	nonce = hkdf(salt, ikm, []byte("Content-Encoding: nonce\x00"), 12)
+	return cek, nonce
+}
+
+// hkdf implements HKDF-SHA-256 (RFC 5869) for an
+// output length of at most one hash length, which
+// is all that RFC 8291 requires.
+func hkdf(salt, ikm, info []byte, length int) []byte {
+	// Extract.
+	mac := hmac.New(sha256.New, salt)
+	mac.Write(ikm)
+	prk := mac.Sum(nil)
+
+	// Expand (single block).
+	mac = hmac.New(sha256.New, prk)
+	mac.Write(info)
+	mac.Write([]byte{0x01})
+	return mac.Sum(nil)[:length]
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("error creating cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// vapidAuthorization returns an RFC 8292 Authorization
+// header value for a request to the given push endpoint.
+func vapidAuthorization(vapidKeyPair *gtsmodel.VAPIDKeyPair, endpoint string, subject string) (string, error) {
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("error parsing endpoint: %w", err)
+	}
+
+	privateKey, err := parseVAPIDPrivateKey(vapidKeyPair)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{
+		"typ": "JWT",
+		"alg": "ES256",
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"aud": endpointURL.Scheme + "://" + endpointURL.Host,
+		"exp": time.Now().Add(vapidExpiry).Unix(),
+		"sub": subject,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) +
+		"." + base64.RawURLEncoding.EncodeToString(claims)
+
+	// Sign with ES256; JWS wants the
+	// raw fixed-length r || s encoding.
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("error signing JWT: %w", err)
+	}
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	jwt := signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+	return "vapid t=" + jwt + ", k=" + vapidKeyPair.Public, nil
+}
+
+// parseVAPIDPrivateKey converts the stored VAPID
+// key pair into an ECDSA key suitable for signing.
+func parseVAPIDPrivateKey(vapidKeyPair *gtsmodel.VAPIDKeyPair) (*ecdsa.PrivateKey, error) {
+	privateBytes, err := decodeBase64URL(vapidKeyPair.Private)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding VAPID private key: %w", err)
+	}
+
+	// Validates the key and
+	// gives us the public point.
+	ecdhKey, err := ecdh.P256().NewPrivateKey(privateBytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid VAPID private key: %w", err)
+	}
+
+	// Uncompressed point: 0x04 || X || Y.
+	point := ecdhKey.PublicKey().Bytes()
+	if len(point) != 65 {
+		return nil, errors.New("unexpected VAPID public key length")
+	}
+
+	return &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(point[1:33]),
+			Y:     new(big.Int).SetBytes(point[33:]),
+		},
+		D: new(big.Int).SetBytes(privateBytes),
+	}, nil
+}
+
+// decodeBase64URL decodes base64url, tolerating
+// padding, since clients are inconsistent about it.
+func decodeBase64URL(s string) ([]byte, error) {
+	for len(s)%4 != 0 {
+		s += "="
+	}
+	return base64.URLEncoding.DecodeString(s)
+}
+
+// ValidateKeys checks that the given base64url-encoded
+// user agent auth secret and P-256 public key are usable
+// for encrypting messages.
+func ValidateKeys(auth string, p256dh string) error {
+	authSecret, err := decodeBase64URL(auth)
+	if err != nil {
+		return fmt.Errorf("auth secret is not valid base64url: %w", err)
+	}
+
+	if len(authSecret) != 16 {
+		return fmt.Errorf("auth secret must be 16 bytes, was %d bytes", len(authSecret))
+	}
+
+	publicKey, err := decodeBase64URL(p256dh)
+	if err != nil {
+		return fmt.Errorf("p256dh key is not valid base64url: %w", err)
+	}
+
+	if _, err := ecdh.P256().NewPublicKey(publicKey); err != nil {
+		return fmt.Errorf("p256dh key is not a valid P-256 public key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,255 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type EncryptTestSuite struct {
+	suite.Suite
+}
+
+func (suite *EncryptTestSuite) TestEncryptRoundTrip() {
+	// Generate user agent keys, since we need
+	// the private half to decrypt the message.
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	authSecret := make([]byte, 16)
+	if _, err := rand.Read(authSecret); err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	auth := base64.RawURLEncoding.EncodeToString(authSecret)
+	p256dh := base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes())
+	suite.NoError(ValidateKeys(auth, p256dh))
+
+	plaintext := []byte(`{"title":"hello","body":"world"}`)
+	body, err := encrypt(&gtsmodel.WebPushSubscription{
+		Auth:   auth,
+		P256dh: p256dh,
+	}, plaintext)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Parse the aes128gcm header.
+	salt := body[:saltLen]
+	rs := binary.BigEndian.Uint32(body[saltLen : saltLen+4])
+	idLen := int(body[saltLen+4])
+	asPublicBytes := body[saltLen+5 : saltLen+5+idLen]
+	ciphertext := body[saltLen+5+idLen:]
+	suite.EqualValues(recordSize, rs)
+
+	// Decrypt as the user agent would.
+	asPublic, err := ecdh.P256().NewPublicKey(asPublicBytes)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	ecdhSecret, err := uaPrivate.ECDH(asPublic)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	cek, nonce := deriveKeys(
+		ecdhSecret,
+		authSecret,
+		uaPrivate.PublicKey().Bytes(),
+		asPublicBytes,
+		salt,
+	)
+	gcm, err := newGCM(cek)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	record, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Last record delimiter should be stripped.
+	suite.Equal(byte(0x02), record[len(record)-1])
+	suite.Equal(plaintext, record[:len(record)-1])
+}
+
+func (suite *EncryptTestSuite) TestEncryptRFC8291() {
+	// Test vector from RFC 8291, Appendix A.
+	var (
+		plaintext  = "V2hlbiBJIGdyb3cgdXAsIEkgd2FudCB0byBiZSBhIHdhdGVybWVsb24"
+		asPrivate  = "yfWPiYE-n46HLnH0KqZOF1fJJU3MYrct3AELtAQ-oRw"
+		uaPublic   = "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"
+		salt       = "DGv6ra1nlYgDCS1FRnbzlw"
+		authSecret = "BTBZMqHH6r4Tts7J_aSIgg"
+		expected   = "DGv6ra1nlYgDCS1FRnbzlwAAEABBBP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A_yl95bQpu6cVPTpK4Mqgkf1CXztLVBSt2Ks3oZwbuwXPXLWyouBWLVWGNWQexSgSxsj_Qulcy4a-fN"
+	)
+
+	decode := func(s string) []byte {
+		b, err := decodeBase64URL(s)
+		if err != nil {
+			suite.FailNow(err.Error())
+		}
+		return b
+	}
+
+	asKey, err := ecdh.P256().NewPrivateKey(decode(asPrivate))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	uaKey, err := ecdh.P256().NewPublicKey(decode(uaPublic))
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	body, err := encryptWithKey(
+		decode(authSecret),
+		uaKey,
+		asKey,
+		decode(salt),
+		decode(plaintext),
+	)
+	suite.NoError(err)
+	suite.Equal(expected, base64.RawURLEncoding.EncodeToString(body))
+}
+
+func (suite *EncryptTestSuite) TestEncryptTooLarge() {
+	uaPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	_, err = encrypt(&gtsmodel.WebPushSubscription{
+		Auth:   base64.RawURLEncoding.EncodeToString(make([]byte, 16)),
+		P256dh: base64.RawURLEncoding.EncodeToString(uaPrivate.PublicKey().Bytes()),
+	}, make([]byte, recordSize))
+	suite.ErrorContains(err, "too large")
+}
+
+func (suite *EncryptTestSuite) TestValidateKeys() {
+	for _, test := range []struct {
+		auth   string
+		p256dh string
+		err    string
+	}{
+		{
+			auth:   "nEOwKCGKs7HggG0qnuPiIA",
+			p256dh: "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGvfLGDKxI3SSrziHW3UQuw0YIQC3vETqOUteUwxqD7ijU",
+		},
+		{
+			auth:   "nEOwKCGKs7HggG0qnuPiIA==",
+			p256dh: "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGvfLGDKxI3SSrziHW3UQuw0YIQC3vETqOUteUwxqD7ijU=",
+		},
+		{
+			auth:   "c2hvcnQ",
+			p256dh: "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGvfLGDKxI3SSrziHW3UQuw0YIQC3vETqOUteUwxqD7ijU",
+			err:    "auth secret must be 16 bytes",
+		},
+		{
+			auth:   "nEOwKCGKs7HggG0qnuPiIA",
+			p256dh: "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGv",
+			err:    "p256dh",
+		},
+	} {
+		err := ValidateKeys(test.auth, test.p256dh)
+		if test.err == "" {
+			suite.NoError(err)
+		} else {
+			suite.ErrorContains(err, test.err)
+		}
+	}
+}
+
+func (suite *EncryptTestSuite) TestVAPIDAuthorization() {
+	vapidKeyPair := &gtsmodel.VAPIDKeyPair{
+		Public:  "BFj4RztYkjHuGHUo53qInqpxqyxGi8Q3neV2_XmVgfB1RfULuWk3CWk9V1nsTwmV9149yOy7XM27YiaO_n9xxnk",
+		Private: "0uDq8zzh_CqGw9IqUttRGN1rxT6-GCDkRJqiT76yMHo",
+	}
+
+	authorization, err := vapidAuthorization(
+		vapidKeyPair,
+		"https://push.example.org/send/some-token",
+		"https://localhost:8080",
+	)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+
+	// Should be "vapid t=<jwt>, k=<public key>".
+	jwt, publicKey, ok := strings.Cut(strings.TrimPrefix(authorization, "vapid t="), ", k=")
+	suite.True(ok)
+	suite.Equal(vapidKeyPair.Public, publicKey)
+
+	parts := strings.Split(jwt, ".")
+	if !suite.Len(parts, 3) {
+		suite.FailNow("")
+	}
+
+	// Check claims.
+	claimsBytes, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	claims := map[string]any{}
+	if err := json.Unmarshal(claimsBytes, &claims); err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal("https://push.example.org", claims["aud"])
+	suite.Equal("https://localhost:8080", claims["sub"])
+
+	// Verify the signature with the public key.
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	privateKey, err := parseVAPIDPrivateKey(vapidKeyPair)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	suite.True(ecdsa.Verify(
+		&privateKey.PublicKey,
+		digest[:],
+		new(big.Int).SetBytes(signature[:32]),
+		new(big.Int).SetBytes(signature[32:]),
+	))
+
+	// And check the public key we advertise
+	// matches the private key we signed with.
+	publicBytes, err := decodeBase64URL(vapidKeyPair.Public)
+	if err != nil {
+		suite.FailNow(err.Error())
+	}
+	suite.Equal(privateKey.PublicKey.X.FillBytes(make([]byte, 32)), publicBytes[1:33])
+}
+
+func TestEncryptTestSuite(t *testing.T) {
+	suite.Run(t, new(EncryptTestSuite))
+}
@@ -0,0 +1,309 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtscontext"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// ttl is how long, in seconds, a push service
+	// should hold on to a message it can't deliver.
+	ttl = 48 * 60 * 60
+
+	// bodyMaxRunes is the maximum length of a push
+	// notification's body text, which keeps the
+	// payload within a single encryption record.
+	bodyMaxRunes = 140
+)
+
+type realSender struct {
+	httpClient HTTPClient
+	state      *state.State
+}
+
+func (r *realSender) Send(
+	ctx context.Context,
+	notification *gtsmodel.Notification,
+	apiNotification *apimodel.Notification,
+) error {
+	// Load all Web Push subscriptions for the target account.
+	subscriptions, err := r.state.DB.GetWebPushSubscriptionsByAccountID(ctx, notification.TargetAccountID)
+	if err != nil && !errors.Is(err, db.ErrNoEntries) {
+		return gtserror.Newf("error getting Web Push subscriptions for account %s: %w", notification.TargetAccountID, err)
+	}
+
+	// Pick out the subscriptions that
+	// want this notification delivered.
+	relevant := make([]*gtsmodel.WebPushSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		ok, err := r.shouldSend(ctx, subscription, notification)
+		if err != nil {
+			return gtserror.Newf("error checking Web Push subscription %s: %w", subscription.ID, err)
+		}
+
+		if ok {
+			relevant = append(relevant, subscription)
+		}
+	}
+
+	if len(relevant) == 0 {
+		// Nothing to do.
+		return nil
+	}
+
+	// Get the instance's VAPID keys,
+	// which identify us to push services.
+	vapidKeyPair, err := r.state.DB.GetVAPIDKeyPair(ctx)
+	if err != nil {
+		return gtserror.Newf("error getting VAPID key pair: %w", err)
+	}
+
+	// Get the target user for their preferred locale.
+	user, err := r.state.DB.GetUserByAccountID(gtscontext.SetBarebones(ctx), notification.TargetAccountID)
+	if err != nil {
+		return gtserror.Newf("error getting user for account %s: %w", notification.TargetAccountID, err)
+	}
+
+	// Format the push notification
+	// common to all subscriptions.
+	pushNotification := formatPushNotification(apiNotification)
+	pushNotification.PreferredLocale = user.Locale
+
+	errs := gtserror.NewMultiError(len(relevant))
+	for _, subscription := range relevant {
+		if err := r.sendToSubscription(
+			ctx,
+			vapidKeyPair,
+			subscription,
+			*pushNotification,
+		); err != nil {
+			errs.Appendf("error sending Web Push notification to subscription %s: %w", subscription.ID, err)
+		}
+	}
+
+	return errs.Combine()
+}
+
+// shouldSend returns whether the given notification
+// should be delivered to the given subscription, based
+// on its alert flags and policy.
+func (r *realSender) shouldSend(
+	ctx context.Context,
+	subscription *gtsmodel.WebPushSubscription,
+	notification *gtsmodel.Notification,
+) (bool, error) {
+	if !subscription.NotificationFlags.Get(notification.NotificationType) {
+		// Subscription doesn't
+		// want this alert type.
+		return false, nil
+	}
+
+	switch subscription.Policy {
+	case gtsmodel.WebPushNotificationPolicyAll:
+		return true, nil
+
+	case gtsmodel.WebPushNotificationPolicyFollowed:
+		// Only send if the target
+		// account follows the origin.
+		return r.state.DB.IsFollowing(ctx,
+			notification.TargetAccountID,
+			notification.OriginAccountID,
+		)
+
+	case gtsmodel.WebPushNotificationPolicyFollower:
+		// Only send if the origin
+		// account follows the target.
+		return r.state.DB.IsFollowing(ctx,
+			notification.OriginAccountID,
+			notification.TargetAccountID,
+		)
+
+	default: // incl. gtsmodel.WebPushNotificationPolicyNone
+		return false, nil
+	}
+}
+
+// sendToSubscription encrypts and POSTs the given
+// push notification to the given subscription.
+func (r *realSender) sendToSubscription(
+	ctx context.Context,
+	vapidKeyPair *gtsmodel.VAPIDKeyPair,
+	subscription *gtsmodel.WebPushSubscription,
+	pushNotification apimodel.PushNotification,
+) error {
+	// Clients use the subscription's access
+	// token to fetch further notification details.
+	token, err := r.state.DB.GetTokenByID(ctx, subscription.TokenID)
+	if err != nil {
+		return gtserror.Newf("error getting token %s: %w", subscription.TokenID, err)
+	}
+	pushNotification.AccessToken = token.Access
+
+	payload, err := json.Marshal(pushNotification)
+	if err != nil {
+		return gtserror.Newf("error marshaling push notification: %w", err)
+	}
+
+	body, err := encrypt(subscription, payload)
+	if err != nil {
+		return gtserror.Newf("error encrypting push notification: %w", err)
+	}
+
+	authorization, err := vapidAuthorization(vapidKeyPair, subscription.Endpoint, vapidSubject())
+	if err != nil {
+		return gtserror.Newf("error creating VAPID authorization: %w", err)
+	}
+
+	// Don't spend worker time retrying
+	// an unresponsive push service.
+	ctx = gtscontext.SetFastFail(ctx)
+
+	req, err := http.NewRequestWithContext(ctx,
+		http.MethodPost,
+		subscription.Endpoint,
+		bytes.NewReader(body),
+	)
+	if err != nil {
+		return gtserror.Newf("error creating request: %w", err)
+	}
+	req.Header.Set("Authorization", authorization)
+	req.Header.Set("Content-Encoding", "aes128gcm")
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("TTL", strconv.Itoa(ttl))
+	req.Header.Set("Urgency", "normal")
+
+	rsp, err := r.httpClient.Do(req)
+	if err != nil {
+		return gtserror.Newf("error sending request: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	switch code := rsp.StatusCode; {
+	case code >= 200 && code < 300:
+		return nil
+
+	case code == http.StatusNotFound || code == http.StatusGone:
+		// The push service says this subscription
+		// is no longer valid, so clean it up.
+		log.Infof(ctx, "deleting expired Web Push subscription %s", subscription.ID)
+		if err := r.state.DB.DeleteWebPushSubscriptionByID(ctx, subscription.ID); err != nil {
+			return gtserror.Newf("error deleting expired subscription: %w", err)
+		}
+		return nil
+
+	default:
+		return gtserror.Newf("push service responded with status %s", rsp.Status)
+	}
+}
+
+// vapidSubject returns a contact URL for this
+// instance, which push services may use to
+// get in touch with the instance operators.
+func vapidSubject() string {
+	return config.GetProtocol() + "://" + config.GetHost()
+}
+
+// formatPushNotification formats the given notification
+// into a (tokenless, localeless) Web Push message payload.
+func formatPushNotification(apiNotification *apimodel.Notification) *apimodel.PushNotification {
+	pushNotification := &apimodel.PushNotification{
+		NotificationID:   apiNotification.ID,
+		NotificationType: apiNotification.Type,
+	}
+
+	// Describe who did what.
+	var name string
+	if account := apiNotification.Account; account != nil {
+		pushNotification.Icon = account.Avatar
+		name = account.DisplayName
+		if name == "" {
+			name = "@" + account.Acct
+		}
+	}
+
+	switch gtsmodel.NotificationType(apiNotification.Type) {
+	case gtsmodel.NotificationFollow:
+		pushNotification.Title = name + " followed you"
+	case gtsmodel.NotificationFollowRequest:
+		pushNotification.Title = name + " requested to follow you"
+	case gtsmodel.NotificationMention:
+		pushNotification.Title = name + " mentioned you"
+	case gtsmodel.NotificationReblog:
+		pushNotification.Title = name + " boosted your post"
+	case gtsmodel.NotificationFave:
+		pushNotification.Title = name + " favourited your post"
+	case gtsmodel.NotificationPoll:
+		pushNotification.Title = "A poll has ended"
+	case gtsmodel.NotificationStatus:
+		pushNotification.Title = name + " just posted"
+	case gtsmodel.NotificationSignup:
+		pushNotification.Title = name + " signed up"
+	case gtsmodel.NotificationPendingFave:
+		pushNotification.Title = name + " favourited your post, pending your approval"
+	case gtsmodel.NotificationPendingReply:
+		pushNotification.Title = name + " replied to your post, pending your approval"
+	case gtsmodel.NotificationPendingReblog:
+		pushNotification.Title = name + " boosted your post, pending your approval"
+	default:
+		pushNotification.Title = "New notification from " + name
+	}
+
+	// Use the status as body if there is one,
+	// preferring content warning over content.
+	var body string
+	if status := apiNotification.Status; status != nil {
+		if status.SpoilerText != "" {
+			body = status.SpoilerText
+		} else {
+			body = text.SanitizeToPlaintext(status.Content)
+		}
+	} else if account := apiNotification.Account; account != nil {
+		body = text.SanitizeToPlaintext(account.Note)
+	}
+	pushNotification.Body = truncate(strings.TrimSpace(body), bodyMaxRunes)
+
+	return pushNotification
+}
+
+// truncate shortens the given string
+// to at most maxRunes runes, with an
+// ellipsis if anything was cut off.
+func truncate(s string, maxRunes int) string {
+	runes := []rune(s)
+	if len(runes) <= maxRunes {
+		return s
+	}
+	return string(runes[:maxRunes-1]) + "…"
+}
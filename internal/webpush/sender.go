@@ -0,0 +1,55 @@
+// GoToSocial
+// Copyright (C) GoToSocial Authors admin@gotosocial.org
+// SPDX-License-Identifier: AGPL-3.0-or-later
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package webpush
+
+import (
+	"context"
+	"net/http"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/state"
+)
+
+// Sender can send Web Push notifications.
+type Sender interface {
+	// Send encrypts and POSTs the given notification to the push
+	// endpoint of each of the target account's Web Push subscriptions
+	// that has opted in to notifications of this type, from accounts
+	// matching the subscription's policy.
+	Send(
+		ctx context.Context,
+		notification *gtsmodel.Notification,
+		apiNotification *apimodel.Notification,
+	) error
+}
+
+// HTTPClient is the subset of
+// httpclient.Client used by Sender.
+type HTTPClient interface {
+	Do(r *http.Request) (*http.Response, error)
+}
+
+// NewSender creates a new sender that POSTs
+// Web Push messages using the given HTTP client.
+func NewSender(httpClient HTTPClient, state *state.State) Sender {
+	return &realSender{
+		httpClient: httpClient,
+		state:      state,
+	}
+}
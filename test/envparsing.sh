@@ -11,16 +11,25 @@ EXPECT=$(cat << "EOF"
     "accounts-registration-open": true,
     "advanced-cookies-samesite": "strict",
     "advanced-csp-extra-uris": [],
+    "advanced-delivery-journal": false,
+    "advanced-delivery-park-after": 20,
+    "advanced-delivery-park-for": 3600000000000,
     "advanced-header-filter-mode": "block",
+    "advanced-rate-limit-account-requests": 420,
     "advanced-rate-limit-exceptions": [
         "192.0.2.0/24",
         "127.0.0.1/32"
     ],
+    "advanced-rate-limit-media-requests": 5,
     "advanced-rate-limit-requests": 6969,
+    "advanced-rate-limit-search-requests": -1,
+    "advanced-rate-limit-status-requests": 12,
+    "advanced-rate-limit-write-requests": 0,
     "advanced-sender-multiplier": -1,
     "advanced-throttling-multiplier": -1,
     "advanced-throttling-retry-after": 10000000000,
     "application-name": "gts",
+    "batch-size": 1000,
     "bind-address": "127.0.0.1",
     "cache": {
         "account-ids-following-tag-mem-ratio": 1,
@@ -59,8 +68,10 @@ EXPECT=$(cat << "EOF"
         "poll-vote-ids-mem-ratio": 2,
         "poll-vote-mem-ratio": 2,
         "report-mem-ratio": 1,
+        "scheduled-status-mem-ratio": 0.5,
         "status-bookmark-ids-mem-ratio": 2,
         "status-bookmark-mem-ratio": 0.5,
+        "status-edit-mem-ratio": 2,
         "status-fave-ids-mem-ratio": 3,
         "status-fave-mem-ratio": 2,
         "status-mem-ratio": 5,
@@ -73,6 +84,8 @@ EXPECT=$(cat << "EOF"
         "user-mute-ids-mem-ratio": 3,
         "user-mute-mem-ratio": 2,
         "visibility-mem-ratio": 2,
+        "web-push-subscription-ids-mem-ratio": 1,
+        "web-push-subscription-mem-ratio": 1,
         "webfinger-mem-ratio": 0.1
     },
     "config-path": "internal/config/testdata/test.yaml",
@@ -92,6 +105,7 @@ EXPECT=$(cat << "EOF"
     "db-user": "sex-haver",
     "dry-run": true,
     "email": "",
+    "full": false,
     "host": "example.com",
     "http-client": {
         "allow-ips": [],
@@ -105,12 +119,18 @@ EXPECT=$(cat << "EOF"
     "instance-expose-suspended": true,
     "instance-expose-suspended-web": true,
     "instance-federation-mode": "allowlist",
+    "instance-federation-reputation": true,
+    "instance-federation-reputation-quarantine-threshold": -20,
+    "instance-federation-reputation-slow-rate": 5,
+    "instance-federation-reputation-slow-threshold": 20,
     "instance-federation-spam-filter": true,
     "instance-inject-mastodon-version": true,
     "instance-languages": [
         "nl",
         "en-GB"
     ],
+    "instance-subscriptions-process-every": 86400000000000,
+    "instance-subscriptions-process-from": "23:00",
     "landing-page-user": "admin",
     "letsencrypt-cert-dir": "/gotosocial/storage/certs",
     "letsencrypt-email-address": "",
@@ -131,6 +151,11 @@ EXPECT=$(cat << "EOF"
     "media-local-max-size": 420,
     "media-remote-cache-days": 30,
     "media-remote-max-size": 420,
+    "media-transcode-audio-bitrate": 96,
+    "media-transcode-audio-codec": "opus",
+    "media-transcode-enabled": true,
+    "media-transcode-max-dimension": 1280,
+    "media-transcode-video-bitrate": 1000,
     "metrics-auth-enabled": false,
     "metrics-auth-password": "",
     "metrics-auth-username": "",
@@ -158,6 +183,8 @@ EXPECT=$(cat << "EOF"
     "protocol": "http",
     "remote-only": false,
     "request-id-header": "X-Trace-Id",
+    "since": "",
+    "skip-media": false,
     "smtp-disclose-recipients": true,
     "smtp-from": "queen.rip.in.piss@terfisland.org",
     "smtp-host": "example.com",
@@ -181,6 +208,8 @@ EXPECT=$(cat << "EOF"
     "syslog-address": "127.0.0.1:6969",
     "syslog-enabled": true,
     "syslog-protocol": "udp",
+    "target-db-address": "",
+    "target-db-type": "",
     "tls-certificate-chain": "",
     "tls-certificate-key": "",
     "tracing-enabled": false,
@@ -233,6 +262,10 @@ GTS_INSTANCE_EXPOSE_SUSPENDED=true \
 GTS_INSTANCE_EXPOSE_SUSPENDED_WEB=true \
 GTS_INSTANCE_EXPOSE_PUBLIC_TIMELINE=true \
 GTS_INSTANCE_FEDERATION_MODE='allowlist' \
+GTS_INSTANCE_FEDERATION_REPUTATION=true \
+GTS_INSTANCE_FEDERATION_REPUTATION_SLOW_THRESHOLD=20 \
+GTS_INSTANCE_FEDERATION_REPUTATION_SLOW_RATE=5 \
+GTS_INSTANCE_FEDERATION_REPUTATION_QUARANTINE_THRESHOLD=-20 \
 GTS_INSTANCE_FEDERATION_SPAM_FILTER=true \
 GTS_INSTANCE_DELIVER_TO_SHARED_INBOXES=false \
 GTS_INSTANCE_INJECT_MASTODON_VERSION=true \
@@ -249,6 +282,11 @@ GTS_MEDIA_REMOTE_CACHE_DAYS=30 \
 GTS_MEDIA_EMOJI_LOCAL_MAX_SIZE=420 \
 GTS_MEDIA_EMOJI_REMOTE_MAX_SIZE=420 \
 GTS_MEDIA_FFMPEG_POOL_SIZE=8 \
+GTS_MEDIA_TRANSCODE_ENABLED=true \
+GTS_MEDIA_TRANSCODE_MAX_DIMENSION=1280 \
+GTS_MEDIA_TRANSCODE_VIDEO_BITRATE=1000 \
+GTS_MEDIA_TRANSCODE_AUDIO_BITRATE=96 \
+GTS_MEDIA_TRANSCODE_AUDIO_CODEC='opus' \
 GTS_METRICS_AUTH_ENABLED=false \
 GTS_METRICS_ENABLED=false \
 GTS_STORAGE_BACKEND='local' \
@@ -291,8 +329,13 @@ GTS_SYSLOG_ADDRESS='127.0.0.1:6969' \
 GTS_TRACING_ENDPOINT='localhost:4317' \
 GTS_TRACING_INSECURE_TRANSPORT=true \
 GTS_ADVANCED_COOKIES_SAMESITE='strict' \
+GTS_ADVANCED_RATE_LIMIT_ACCOUNT_REQUESTS=420 \
 GTS_ADVANCED_RATE_LIMIT_EXCEPTIONS="192.0.2.0/24,127.0.0.1/32" \
+GTS_ADVANCED_RATE_LIMIT_MEDIA_REQUESTS=5 \
 GTS_ADVANCED_RATE_LIMIT_REQUESTS=6969 \
+GTS_ADVANCED_RATE_LIMIT_SEARCH_REQUESTS=-1 \
+GTS_ADVANCED_RATE_LIMIT_STATUS_REQUESTS=12 \
+GTS_ADVANCED_RATE_LIMIT_WRITE_REQUESTS=0 \
 GTS_ADVANCED_SENDER_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_MULTIPLIER=-1 \
 GTS_ADVANCED_THROTTLING_RETRY_AFTER='10s' \
@@ -85,12 +85,16 @@ func testDefaults() config.Configuration {
 		WebTemplateBaseDir: "./web/template/",
 		WebAssetBaseDir:    "./web/assets/",
 
-		InstanceFederationMode:         config.InstanceFederationModeDefault,
-		InstanceFederationSpamFilter:   true,
-		InstanceExposePeers:            true,
-		InstanceExposeSuspended:        true,
-		InstanceExposeSuspendedWeb:     true,
-		InstanceDeliverToSharedInboxes: true,
+		InstanceFederationMode:                          config.InstanceFederationModeDefault,
+		InstanceFederationSpamFilter:                    true,
+		InstanceFederationReputation:                    false,
+		InstanceFederationReputationSlowThreshold:       10,
+		InstanceFederationReputationSlowRate:            30,
+		InstanceFederationReputationQuarantineThreshold: -50,
+		InstanceExposePeers:                             true,
+		InstanceExposeSuspended:                         true,
+		InstanceExposeSuspendedWeb:                      true,
+		InstanceDeliverToSharedInboxes:                  true,
 		InstanceLanguages: language.Languages{
 			{
 				TagStr: "nl",
@@ -100,20 +104,28 @@ func testDefaults() config.Configuration {
 			},
 		},
 
+		InstanceSubscriptionsProcessFrom:  "23:00",        // 11pm.
+		InstanceSubscriptionsProcessEvery: 24 * time.Hour, // 1/day.
+
 		AccountsRegistrationOpen: true,
 		AccountsReasonRequired:   true,
 		AccountsAllowCustomCSS:   true,
 		AccountsCustomCSSLength:  10000,
 
-		MediaDescriptionMinChars: 0,
-		MediaDescriptionMaxChars: 500,
-		MediaRemoteCacheDays:     7,
-		MediaLocalMaxSize:        40 * bytesize.MiB,
-		MediaRemoteMaxSize:       40 * bytesize.MiB,
-		MediaEmojiLocalMaxSize:   51200,          // 50KiB
-		MediaEmojiRemoteMaxSize:  102400,         // 100KiB
-		MediaCleanupFrom:         "00:00",        // midnight.
-		MediaCleanupEvery:        24 * time.Hour, // 1/day.
+		MediaDescriptionMinChars:   0,
+		MediaDescriptionMaxChars:   500,
+		MediaRemoteCacheDays:       7,
+		MediaLocalMaxSize:          40 * bytesize.MiB,
+		MediaRemoteMaxSize:         40 * bytesize.MiB,
+		MediaEmojiLocalMaxSize:     51200,          // 50KiB
+		MediaEmojiRemoteMaxSize:    102400,         // 100KiB
+		MediaCleanupFrom:           "00:00",        // midnight.
+		MediaCleanupEvery:          24 * time.Hour, // 1/day.
+		MediaTranscodeEnabled:      false,
+		MediaTranscodeMaxDimension: 1920,
+		MediaTranscodeVideoBitrate: 2500,
+		MediaTranscodeAudioBitrate: 128,
+		MediaTranscodeAudioCodec:   "aac",
 
 		// the testrig only uses in-memory storage, so we can
 		// safely set this value to 'test' to avoid running storage
@@ -165,6 +177,9 @@ func testDefaults() config.Configuration {
 		AdvancedRateLimitRequests:    0, // disabled
 		AdvancedThrottlingMultiplier: 0, // disabled
 		AdvancedSenderMultiplier:     0, // 1 sender only, regardless of CPU
+		AdvancedDeliveryJournal:      false,
+		AdvancedDeliveryParkAfter:    20,
+		AdvancedDeliveryParkFor:      time.Hour,
 
 		SoftwareVersion: "0.0.0-testrig",
 
@@ -32,15 +32,22 @@ var testModels = []interface{}{
 	&gtsmodel.AccountNote{},
 	&gtsmodel.AccountSettings{},
 	&gtsmodel.AccountToEmoji{},
+	&gtsmodel.AuditLogEntry{},
 	&gtsmodel.Application{},
+	&gtsmodel.Archive{},
 	&gtsmodel.Block{},
+	&gtsmodel.DomainAllow{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainDeliveryState{},
+	&gtsmodel.DomainPermissionDraft{},
+	&gtsmodel.DomainPermissionSubscription{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Filter{},
 	&gtsmodel.FilterKeyword{},
 	&gtsmodel.FilterStatus{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
+	&gtsmodel.GroupModerator{},
 	&gtsmodel.InteractionRequest{},
 	&gtsmodel.List{},
 	&gtsmodel.ListEntry{},
@@ -54,6 +61,7 @@ var testModels = []interface{}{
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
 	&gtsmodel.StatusBookmark{},
+	&gtsmodel.StatusEdit{},
 	&gtsmodel.Tag{},
 	&gtsmodel.Thread{},
 	&gtsmodel.ThreadMute{},
@@ -61,15 +69,25 @@ var testModels = []interface{}{
 	&gtsmodel.User{},
 	&gtsmodel.UserMute{},
 	&gtsmodel.Emoji{},
+	&gtsmodel.Import{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.PendingDelivery{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
 	&gtsmodel.EmojiCategory{},
 	&gtsmodel.Tombstone{},
+	&gtsmodel.Trend{},
+	&gtsmodel.TrendLink{},
+	&gtsmodel.Relay{},
+	&gtsmodel.MediaHashBlock{},
 	&gtsmodel.Report{},
+	&gtsmodel.ReportNote{},
 	&gtsmodel.Rule{},
+	&gtsmodel.ScheduledStatus{},
+	&gtsmodel.VAPIDKeyPair{},
+	&gtsmodel.WebPushSubscription{},
 	&gtsmodel.WorkerTask{},
 }
 
@@ -353,6 +371,16 @@ func StandardDBSetup(db db.DB, accounts map[string]*gtsmodel.Account) {
 		}
 	}
 
+	if err := db.Put(ctx, NewTestVAPIDKeyPair()); err != nil {
+		log.Panic(nil, err)
+	}
+
+	for _, v := range NewTestWebPushSubscriptions() {
+		if err := db.Put(ctx, v); err != nil {
+			log.Panic(nil, err)
+		}
+	}
+
 	if err := db.CreateInstanceAccount(ctx); err != nil {
 		log.Panic(nil, err)
 	}
@@ -43,5 +43,6 @@ func NewTestProcessor(state *state.State, federator *federation.Federator, email
 		emailSender,
 		visibility.NewFilter(state),
 		interaction.NewFilter(state),
+		NewWebPushSender(nil),
 	)
 }
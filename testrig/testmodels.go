@@ -39,6 +39,7 @@ import (
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
@@ -644,6 +645,12 @@ func NewTestAccounts() map[string]*gtsmodel.Account {
 		v.PublicKey = &priv.PublicKey
 	}
 
+	// Set plaintext note as
+	// the database would on write.
+	for _, v := range accounts {
+		v.NotePlain = text.SanitizeToSearchText(v.Note)
+	}
+
 	return accounts
 }
 
@@ -1409,7 +1416,7 @@ func newTestStoredEmoji() map[string]filenames {
 // NewTestStatuses returns a map of statuses keyed according to which account
 // and status they are.
 func NewTestStatuses() map[string]*gtsmodel.Status {
-	return map[string]*gtsmodel.Status{
+	statuses := map[string]*gtsmodel.Status{
 		"admin_account_status_1": {
 			ID:                       "01F8MH75CBF9JFX4ZAD54N0W0R",
 			PinnedAt:                 TimeMustParse("2022-05-14T13:21:09+02:00"),
@@ -2064,6 +2071,14 @@ func NewTestStatuses() map[string]*gtsmodel.Status {
 			PendingApproval:          util.Ptr(false),
 		},
 	}
+
+	// Set plaintext content as
+	// the database would on write.
+	for _, v := range statuses {
+		v.ContentPlain = text.SanitizeToSearchText(v.Content)
+	}
+
+	return statuses
 }
 
 func NewTestPolls() map[string]*gtsmodel.Poll {
@@ -2622,6 +2637,7 @@ func NewTestReports() map[string]*gtsmodel.Report {
 			StatusIDs:       []string{"01FVW7JHQFSFK166WWKR8CBA6M"},
 			Forwarded:       util.Ptr(true),
 			RuleIDs:         []string{"01GP3AWY4CRDVRNZKW0TEAMB51", "01GP3DFY9XQ1TJMZT5BGAZPXX3"},
+			State:           gtsmodel.ReportStateOpen,
 		},
 		"remote_account_1_report_local_account_2": {
 			ID:                     "01GP3DFY9XQ1TJMZT5BGAZPXX7",
@@ -2637,6 +2653,8 @@ func NewTestReports() map[string]*gtsmodel.Report {
 			ActionTaken:            "user was warned not to be a turtle anymore",
 			ActionTakenAt:          TimeMustParse("2022-05-15T17:01:56+02:00"),
 			ActionTakenByAccountID: "01F8MH17FWEB39HZJ76B6VXSKF",
+			State:                  gtsmodel.ReportStateResolved,
+			AssignedAccountID:      "01F8MH17FWEB39HZJ76B6VXSKF",
 		},
 	}
 }
@@ -3485,6 +3503,37 @@ func NewTestInteractionRequests() map[string]*gtsmodel.InteractionRequest {
 	}
 }
 
+func NewTestVAPIDKeyPair() *gtsmodel.VAPIDKeyPair {
+	return &gtsmodel.VAPIDKeyPair{
+		ID:      1,
+		Public:  "BFj4RztYkjHuGHUo53qInqpxqyxGi8Q3neV2_XmVgfB1RfULuWk3CWk9V1nsTwmV9149yOy7XM27YiaO_n9xxnk",
+		Private: "0uDq8zzh_CqGw9IqUttRGN1rxT6-GCDkRJqiT76yMHo",
+	}
+}
+
+func NewTestWebPushSubscriptions() map[string]*gtsmodel.WebPushSubscription {
+	return map[string]*gtsmodel.WebPushSubscription{
+		"local_account_1_token_1": {
+			ID:        "01G65Z755AFWAKHE12NY0CQ9FH",
+			AccountID: "01F8MH1H7YV1Z7D2C8K2730QBF",
+			TokenID:   "01F8MGTQW4DKTDF8SW5CT9HYGA",
+			Endpoint:  "https://example.org/push/notifications/local_account_1_token_1",
+			Auth:      "nEOwKCGKs7HggG0qnuPiIA",
+			P256dh:    "BKi-KFHcmO0H6XGGI5H61MinLS_yFVtIVli_OqKkBAGvfLGDKxI3SSrziHW3UQuw0YIQC3vETqOUteUwxqD7ijU",
+			NotificationFlags: func() gtsmodel.WebPushSubscriptionNotificationFlags {
+				var flags gtsmodel.WebPushSubscriptionNotificationFlags
+				flags.Set(gtsmodel.NotificationFollow, true)
+				flags.Set(gtsmodel.NotificationFollowRequest, true)
+				flags.Set(gtsmodel.NotificationMention, true)
+				flags.Set(gtsmodel.NotificationReblog, true)
+				flags.Set(gtsmodel.NotificationFave, true)
+				return flags
+			}(),
+			Policy: gtsmodel.WebPushNotificationPolicyAll,
+		},
+	}
+}
+
 // GetSignatureForActivity prepares a mock HTTP request as if it were going to deliver activity to destination signed for privkey and pubKeyID, signs the request and returns the header values.
 func GetSignatureForActivity(activity pub.Activity, pubKeyID string, privkey *rsa.PrivateKey, destination *url.URL) (signatureHeader string, digestHeader string, dateHeader string) {
 	// convert the activity into json bytes
@@ -26,6 +26,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/common"
 	"github.com/superseriousbusiness/gotosocial/internal/state"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/webpush"
 )
 
 // TestStructs encapsulates structs needed to
@@ -44,6 +45,7 @@ type TestStructs struct {
 	HTTPClient    *MockHTTPClient
 	TypeConverter *typeutils.Converter
 	EmailSender   email.Sender
+	WebPushSender webpush.Sender
 }
 
 func SetupTestStructs(
@@ -78,6 +80,7 @@ func SetupTestStructs(
 	federator := NewTestFederator(&state, transportController, mediaManager)
 	oauthServer := NewTestOauthServer(db)
 	emailSender := NewEmailSender(rTemplatePath, nil)
+	webPushSender := NewWebPushSender(nil)
 
 	common := common.New(
 		&state,
@@ -97,6 +100,7 @@ func SetupTestStructs(
 		emailSender,
 		visFilter,
 		intFilter,
+		webPushSender,
 	)
 
 	StartWorkers(&state, processor.Workers())
@@ -111,6 +115,7 @@ func SetupTestStructs(
 		HTTPClient:    httpClient,
 		TypeConverter: typeconverter,
 		EmailSender:   emailSender,
+		WebPushSender: webPushSender,
 	}
 }
 
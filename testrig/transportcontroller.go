@@ -29,6 +29,7 @@ import (
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -41,6 +42,8 @@ import (
 const (
 	applicationJSON         = "application/json"
 	applicationActivityJSON = "application/activity+json"
+	textCSV                 = "text/csv"
+	textPlain               = "text/plain"
 )
 
 // NewTestTransportController returns a test transport controller with the given http client.
@@ -79,7 +82,7 @@ type MockHTTPClient struct {
 // to customize how the client is mocked.
 //
 // Note that you should never ever make ACTUAL http calls with this thing.
-func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relativeMediaPath string, extraPeople ...vocab.ActivityStreamsPerson) *MockHTTPClient {
+func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relativeMediaPath string, extraPeople ...ap.Accountable) *MockHTTPClient {
 	mockHTTPClient := &MockHTTPClient{}
 
 	if do != nil {
@@ -202,6 +205,8 @@ func NewMockHTTPClient(do func(req *http.Request) (*http.Response, error), relat
 			responseBytes = attachment.Data
 			responseContentType = attachment.ContentType
 			responseContentLength = len(attachment.Data)
+		} else if strings.HasPrefix(reqURLString, "https://lists.example.org/") {
+			responseCode, responseBytes, responseContentType, responseContentLength = DomainPermissionSubscriptionResponse(req)
 		} else if _, ok := mockHTTPClient.TestTombstones[reqURLString]; ok {
 			responseCode = http.StatusGone
 			responseBytes = []byte{}
@@ -261,6 +266,48 @@ func (m *MockHTTPClient) DoSigned(req *http.Request, sign httpclient.SignFunc) (
 	return m.do(req)
 }
 
+// DomainPermissionSubscriptionResponse serves test domain
+// permission lists from https://lists.example.org, in each
+// of the formats supported by domain permission subscriptions.
+func DomainPermissionSubscriptionResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
+	switch req.URL.String() {
+	case "https://lists.example.org/baddies.csv":
+		responseBytes = []byte(`#domain,#severity,#reject_media,#reject_reports,#public_comment,#obfuscate
+bumfaces.net,suspend,false,false,big jerks,false
+peepee.poopoo,suspend,false,false,harassment,false
+nothanks.com,suspend,false,false,,false
+silenced.example.org,silence,false,false,only silenced,false`)
+		responseContentType = textCSV
+	case "https://lists.example.org/baddies.json":
+		responseBytes = []byte(`[
+  {"domain": "bumfaces.net", "public_comment": "big jerks"},
+  {"domain": "peepee.poopoo", "public_comment": "harassment"},
+  {"domain": "nothanks.com"}
+]`)
+		responseContentType = applicationJSON
+	case "https://lists.example.org/baddies.txt":
+		responseBytes = []byte(`# some baddies
+bumfaces.net
+peepee.poopoo
+nothanks.com`)
+		responseContentType = textPlain
+	case "https://lists.example.org/huge.txt":
+		// Valid list, just too big (>10MiB).
+		responseBytes = bytes.Repeat([]byte("bumfaces.net\n"), 1<<20)
+		responseContentType = textPlain
+	default:
+		responseCode = http.StatusNotFound
+		responseBytes = []byte(`{"error":"404 not found"}`)
+		responseContentType = applicationJSON
+		responseContentLength = len(responseBytes)
+		return
+	}
+
+	responseCode = http.StatusOK
+	responseContentLength = len(responseBytes)
+	return
+}
+
 func HostMetaResponse(req *http.Request) (responseCode int, responseBytes []byte, responseContentType string, responseContentLength int) {
 	var hm *apimodel.HostMeta
 
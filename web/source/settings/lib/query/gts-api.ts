@@ -143,6 +143,12 @@ const gtsBaseQuery: BaseQueryFn<
 			return headers;
 		},
 		responseHandler: (response) => {
+			// Return a blob for binary
+			// downloads, eg., archives.
+			if (accept === "application/zip") {
+				return response.blob();
+			}
+
 			// Return just text if caller has
 			// set a custom accept content-type.
 			if (accept !== "application/json") {
@@ -169,6 +175,9 @@ export const gtsApi = createApi({
 		"HTTPHeaderBlocks",
 		"DefaultInteractionPolicies",
 		"InteractionRequest",
+		"TokenInfo",
+		"Archive",
+		"Import",
 	],
 	endpoints: (build) => ({
 		instanceV1: build.query<InstanceV1, void>({
@@ -21,7 +21,7 @@ import fileDownload from "js-file-download";
 
 import { gtsApi } from "../gts-api";
 import { FetchBaseQueryError } from "@reduxjs/toolkit/query";
-import { AccountExportStats } from "../../types/account";
+import { AccountExportStats, Archive, Import } from "../../types/account";
 
 const extended = gtsApi.injectEndpoints({
 	endpoints: (build) => ({
@@ -126,6 +126,47 @@ const extended = gtsApi.injectEndpoints({
 			}
 		}),
 
+		archives: build.query<Archive[], void>({
+			query: () => ({
+				url: `/api/v1/exports/archive`
+			}),
+			providesTags: ["Archive"],
+		}),
+
+		requestArchive: build.mutation<Archive, void>({
+			query: () => ({
+				method: "POST",
+				url: `/api/v1/exports/archive`,
+			}),
+			invalidatesTags: ["Archive"],
+		}),
+
+		downloadArchive: build.mutation<string | null, Archive>({
+			async queryFn(archive, _api, _extraOpts, fetchWithBQ) {
+				const zipRes = await fetchWithBQ({
+					url: `/api/v1/exports/archive/${archive.id}/archive.zip`,
+					acceptContentType: "application/zip",
+				});
+				if (zipRes.error) {
+					return { error: zipRes.error as FetchBaseQueryError };
+				}
+
+				if (zipRes.meta?.response?.status !== 200) {
+					return { error: zipRes.data };
+				}
+
+				fileDownload(zipRes.data, `archive-${archive.id}.zip`, "application/zip");
+				return { data: null };
+			}
+		}),
+
+		imports: build.query<Import[], void>({
+			query: () => ({
+				url: `/api/v1/import`
+			}),
+			providesTags: ["Import"],
+		}),
+
 		importData: build.mutation({
 			query: (formData) => ({
 				method: "POST",
@@ -134,6 +175,7 @@ const extended = gtsApi.injectEndpoints({
 				body: formData,
 				discardEmpty: true
 			}),
+			invalidatesTags: ["Import"],
 		}),
 	})
 });
@@ -145,5 +187,9 @@ export const {
 	useExportListsMutation,
 	useExportBlocksMutation,
 	useExportMutesMutation,
+	useArchivesQuery,
+	useRequestArchiveMutation,
+	useDownloadArchiveMutation,
+	useImportsQuery,
 	useImportDataMutation,
 } = extended;
@@ -120,3 +120,24 @@ export interface AccountExportStats {
 	blocks_count: number;
 	mutes_count: number;
 }
+
+export interface Archive {
+	id: string;
+	created_at: string;
+	processed: boolean;
+	processed_at?: string;
+	size: number;
+}
+
+export interface Import {
+	id: string;
+	created_at: string;
+	type: string;
+	total: number;
+	imported: number;
+	skipped: number;
+	failed: number;
+	failures: string[];
+	finished: boolean;
+	finished_at?: string;
+}
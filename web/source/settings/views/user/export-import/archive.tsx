@@ -0,0 +1,119 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useEffect, useState } from "react";
+import {
+	useArchivesQuery,
+	useRequestArchiveMutation,
+	useDownloadArchiveMutation,
+} from "../../../lib/query/user/export-import";
+import MutationButton from "../../../components/form/mutation-button";
+import Loading from "../../../components/loading";
+import { Error } from "../../../components/error";
+
+export default function Archive() {
+	// Poll for changes while an
+	// archive is being generated.
+	const [ pollingInterval, setPollingInterval ] = useState(0);
+	const {
+		data: archives,
+		isLoading,
+		isError,
+		error,
+	} = useArchivesQuery(undefined, { pollingInterval });
+
+	const [ requestArchive, requestArchiveResult ] = useRequestArchiveMutation();
+	const [ downloadArchive, downloadArchiveResult ] = useDownloadArchiveMutation();
+
+	const latest = archives?.[0];
+	const generating = latest !== undefined && !latest.processed;
+
+	useEffect(() => {
+		setPollingInterval(generating ? 10000 : 0);
+	}, [generating]);
+
+	if (isLoading) {
+		return <Loading />;
+	}
+
+	if (isError) {
+		return <Error error={error} />;
+	}
+
+	let status: string;
+	if (latest === undefined) {
+		status = "No archive requested yet";
+	} else if (generating) {
+		status = `Generating archive requested ${new Date(latest.created_at).toLocaleString()}...`;
+	} else {
+		const size = (latest.size / 1024 / 1024).toFixed(1);
+		status = `Archive from ${new Date(latest.processed_at ?? latest.created_at).toLocaleString()} (${size} MiB)`;
+	}
+
+	return (
+		<form className="export-data">
+			<div className="form-section-docs">
+				<h3>Request Archive</h3>
+				<p>
+					You can request a full archive of your account, containing your profile,
+					your posts and their media, and the posts you've liked and bookmarked.
+					The archive is a zip file in the same format as Mastodon archives.
+					It will take a while to generate, and can be requested once every 24 hours.
+				</p>
+				<a
+					href="https://docs.gotosocial.org/en/latest/user_guide/settings#request-archive"
+					target="_blank"
+					className="docslink"
+					rel="noreferrer"
+				>
+				Learn more about this section (opens in a new tab)
+				</a>
+			</div>
+
+			<div className="export-buttons-wrapper">
+				<div className="stats-and-button">
+					<span className="text-cutoff">{status}</span>
+					<MutationButton
+						className="text-cutoff"
+						label="Request new archive"
+						type="button"
+						onClick={() => requestArchive()}
+						result={requestArchiveResult}
+						showError={true}
+						disabled={generating}
+					/>
+				</div>
+				{ latest?.processed &&
+					<div className="stats-and-button">
+						<span className="text-cutoff">Ready to download</span>
+						<MutationButton
+							className="text-cutoff"
+							label="Download archive.zip"
+							type="button"
+							onClick={() => downloadArchive(latest)}
+							result={downloadArchiveResult}
+							showError={true}
+							disabled={false}
+						/>
+					</div>
+				}
+			</div>
+		</form>
+	);
+}
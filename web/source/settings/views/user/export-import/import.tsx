@@ -17,7 +17,7 @@
 	along with this program.  If not, see <http://www.gnu.org/licenses/>.
 */
 
-import React from "react";
+import React, { useEffect } from "react";
 import { useImportDataMutation } from "../../../lib/query/user/export-import";
 import MutationButton from "../../../components/form/mutation-button";
 import useFormSubmit from "../../../lib/form/submit";
@@ -39,6 +39,16 @@ export default function Import() {
 			form.mode.reset();
 		}
 	});
+
+	// Posts can only be added to, so
+	// there's no mode to choose from.
+	const isOutbox = form.type.value === "outbox";
+	useEffect(() => {
+		if (isOutbox) {
+			form.mode.reset();
+		}
+	// eslint-disable-next-line react-hooks/exhaustive-deps
+	}, [isOutbox]);
 	
 	return (
 		<form className="import-data" onSubmit={submitForm}>
@@ -55,9 +65,9 @@ export default function Import() {
 			</div>
 			
 			<FileInput
-				label="CSV data file"
+				label="CSV data file, or archive zip"
 				field={form.data}
-				accept="text/csv"
+				accept="text/csv,application/zip"
 			/>
 
 			<Select
@@ -68,27 +78,30 @@ export default function Import() {
 						<option value="">- Select import type -</option>
 						<option value="following">Following list</option>
 						<option value="blocks">Blocked accounts list</option>
+						<option value="outbox">Posts from an archive of an account you moved from</option>
 					</>
 				}>
 			</Select>
 
-			<Select
-				field={form.mode}
-				label="Import mode"
-				options={
-					<>
-						<option value="">- Select import mode -</option>
-						<option value="merge">Merge (recommended): add to existing records</option>
-						<option value="overwrite">Overwrite: replace existing records</option>
-					</>
-				}>
-			</Select>
+			{ !isOutbox &&
+				<Select
+					field={form.mode}
+					label="Import mode"
+					options={
+						<>
+							<option value="">- Select import mode -</option>
+							<option value="merge">Merge (recommended): add to existing records</option>
+							<option value="overwrite">Overwrite: replace existing records</option>
+						</>
+					}>
+				</Select>
+			}
 
 			<MutationButton
 				disabled={
 					form.data.value === undefined ||
 					!form.type.value ||
-					!form.mode.value
+					(!form.mode.value && !isOutbox)
 				}
 				label="Import"
 				result={result}
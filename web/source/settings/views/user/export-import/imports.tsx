@@ -0,0 +1,81 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { useEffect, useState } from "react";
+import { useImportsQuery } from "../../../lib/query/user/export-import";
+import Loading from "../../../components/loading";
+import { Error } from "../../../components/error";
+import { Import } from "../../../lib/types/account";
+
+export default function Imports() {
+	// Poll for changes while
+	// an import is in progress.
+	const [ pollingInterval, setPollingInterval ] = useState(0);
+	const {
+		data: imports,
+		isLoading,
+		isError,
+		error,
+	} = useImportsQuery(undefined, { pollingInterval });
+
+	const inProgress = imports?.some((imp) => !imp.finished) ?? false;
+
+	useEffect(() => {
+		setPollingInterval(inProgress ? 10000 : 0);
+	}, [inProgress]);
+
+	if (isLoading) {
+		return <Loading />;
+	}
+
+	if (isError) {
+		return <Error error={error} />;
+	}
+
+	if (!imports || imports.length === 0) {
+		return null;
+	}
+
+	return (
+		<div className="import-progress">
+			<h4>Import progress</h4>
+			{ imports.map((imp) => <ImportEntry key={imp.id} imp={imp} />) }
+		</div>
+	);
+}
+
+function ImportEntry({ imp }: { imp: Import }) {
+	const requested = new Date(imp.created_at).toLocaleString();
+	const state = imp.finished ? "finished" : "in progress";
+
+	return (
+		<details>
+			<summary>
+				Import of {imp.type} requested {requested}: {state},
+				{" "}{imp.imported} of {imp.total} imported
+				{imp.skipped > 0 && `, ${imp.skipped} skipped`}
+				{imp.failed > 0 && `, ${imp.failed} failed`}
+			</summary>
+			{ imp.failures.length > 0
+				? <ul>{ imp.failures.map((failure, i) => <li key={i}>{failure}</li>) }</ul>
+				: <p>No failures.</p>
+			}
+		</details>
+	);
+}
@@ -23,6 +23,8 @@ import Loading from "../../../components/loading";
 import { Error } from "../../../components/error";
 import { useExportStatsQuery } from "../../../lib/query/user/export-import";
 import Import from "./import";
+import Archive from "./archive";
+import Imports from "./imports";
 
 export default function ExportImport() {
 	const {
@@ -50,10 +52,14 @@ export default function ExportImport() {
 			<h1>Export & Import</h1>
 			<p>
 				On this page you can export data from your GoToSocial account, or import data into
-				your GoToSocial account. All exports and imports use Mastodon-compatible CSV files.
+				your GoToSocial account. All exports and imports use Mastodon-compatible CSV files,
+				apart from archives, which use Mastodon's archive format. Posts from an archive of an
+				account you've moved from can be imported too.
 			</p>
 			<Export exportStats={exportStats} />
+			<Archive />
 			<Import />
+			<Imports />
 		</>
 	);
 }
@@ -25,6 +25,8 @@ import React from "react";
  * - /settings/user/posts
  * - /settings/user/emailpassword
  * - /settings/user/migration
+ * - /settings/user/export-import
+ * - /settings/user/tokens
  */
 export default function UserMenu() {	
 	return (
@@ -63,6 +65,11 @@ export default function UserMenu() {
 				itemUrl="export-import"
 				icon="fa-floppy-o"
 			/>
+			<MenuItem
+				name="Access Tokens"
+				itemUrl="tokens"
+				icon="fa-key"
+			/>
 		</MenuItem>
 	);
 }
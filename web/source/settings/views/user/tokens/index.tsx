@@ -0,0 +1,148 @@
+/*
+	GoToSocial
+	Copyright (C) GoToSocial Authors admin@gotosocial.org
+	SPDX-License-Identifier: AGPL-3.0-or-later
+
+	This program is free software: you can redistribute it and/or modify
+	it under the terms of the GNU Affero General Public License as published by
+	the Free Software Foundation, either version 3 of the License, or
+	(at your option) any later version.
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	You should have received a copy of the GNU Affero General Public License
+	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+import React, { ReactNode, useEffect, useMemo } from "react";
+import { useSearch } from "wouter";
+import { PageableList } from "../../../components/pageable-list";
+import MutationButton from "../../../components/form/mutation-button";
+import { useInvalidateTokenMutation, useLazySearchTokenInfoQuery } from "../../../lib/query/user/tokens";
+import { TokenInfo } from "../../../lib/types/token";
+
+export default function Tokens() {
+	return (
+		<div className="tokens-view">
+			<div className="form-section-docs">
+				<h1>Access Tokens</h1>
+				<p>
+					On this page you can see the access tokens held by applications
+					that you've logged in to with your account, and when each token
+					was last used (approximately). If you don't recognize an application,
+					or a token has been used when you weren't using the application,
+					you can invalidate the token so that it can't be used anymore.
+				</p>
+				<p>
+					If you invalidate the token that you're currently using for this
+					settings panel, you will be logged out.
+				</p>
+			</div>
+			<TokensList />
+		</div>
+	);
+}
+
+function TokensList() {
+	const search = useSearch();
+	const urlQueryParams = useMemo(() => new URLSearchParams(search), [search]);
+	const [ searchTokens, searchRes ] = useLazySearchTokenInfoQuery();
+
+	// Trigger search on mount, and whenever
+	// url query params change (ie., paging).
+	useEffect(() => {
+		searchTokens(Object.fromEntries(urlQueryParams), true);
+	}, [urlQueryParams, searchTokens]);
+
+	// Function to map an item to a list entry.
+	function itemToEntry(token: TokenInfo): ReactNode {
+		return (
+			<TokenListEntry
+				key={token.id}
+				token={token}
+			/>
+		);
+	}
+
+	return (
+		<PageableList
+			isLoading={searchRes.isLoading}
+			isFetching={searchRes.isFetching}
+			isSuccess={searchRes.isSuccess}
+			items={searchRes.data?.tokens}
+			itemToEntry={itemToEntry}
+			isError={searchRes.isError}
+			error={searchRes.error}
+			emptyMessage={<b>No access tokens found.</b>}
+			prevNextLinks={searchRes.data?.links}
+		/>
+	);
+}
+
+interface TokenListEntryProps {
+	token: TokenInfo;
+}
+
+function TokenListEntry({ token }: TokenListEntryProps) {
+	const [ invalidate, invalidateResult ] = useInvalidateTokenMutation();
+
+	const appName = token.application?.name ?? "Unknown application";
+	const created = new Date(token.created_at).toLocaleString();
+	const lastUsed = token.last_used
+		? new Date(token.last_used).toLocaleString()
+		: "Never";
+
+	return (
+		<span
+			className="entry token"
+			aria-label={`Access token for ${appName}`}
+			title={`Access token for ${appName}`}
+		>
+			<span className="text-cutoff">
+				<i
+					className="fa fa-fw fa-key"
+					aria-hidden="true"
+				/> <strong>{appName}</strong>
+			</span>
+			<dl className="info-list">
+				{ token.application?.website &&
+					<div className="info-list-entry">
+						<dt>Website:</dt>
+						<dd className="text-cutoff">{token.application.website}</dd>
+					</div>
+				}
+				<div className="info-list-entry">
+					<dt>Scope:</dt>
+					<dd className="monospace">{token.scope}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Created:</dt>
+					<dd>{created}</dd>
+				</div>
+				<div className="info-list-entry">
+					<dt>Last used:</dt>
+					<dd>{lastUsed}</dd>
+				</div>
+			</dl>
+			<div className="action-buttons">
+				<MutationButton
+					label="Invalidate token"
+					title={`Invalidate access token for ${appName}`}
+					type="button"
+					className="button danger"
+					onClick={(e) => {
+						e.preventDefault();
+						e.stopPropagation();
+						invalidate(token.id);
+					}}
+					disabled={false}
+					showError={true}
+					result={invalidateResult}
+				/>
+			</div>
+		</span>
+	);
+}